@@ -1,43 +1,116 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 var (
 	// TaskTypeCopy is the identifier for the Copy adapter.
 	TaskTypeCopy = models.MustNewTaskType("copy")
+	// TaskTypeBase64 is the identifier for the Base64 adapter.
+	TaskTypeBase64 = models.MustNewTaskType("base64")
+	// TaskTypeBase64Decode is the identifier for the Base64Decode adapter.
+	TaskTypeBase64Decode = models.MustNewTaskType("base64decode")
+	// TaskTypeCache is the identifier for the Cache adapter.
+	TaskTypeCache = models.MustNewTaskType("cache")
+	// TaskTypeCircuitBreaker is the identifier for the CircuitBreaker adapter.
+	TaskTypeCircuitBreaker = models.MustNewTaskType("circuitbreaker")
+	// TaskTypeCompare is the identifier for the Compare adapter.
+	TaskTypeCompare = models.MustNewTaskType("compare")
+	// TaskTypeContractDeploy is the identifier for the ContractDeploy adapter.
+	TaskTypeContractDeploy = models.MustNewTaskType("contractdeploy")
+	// TaskTypeCSVParse is the identifier for the CSVParse adapter.
+	TaskTypeCSVParse = models.MustNewTaskType("csvparse")
+	// TaskTypeDeviation is the identifier for the Deviation adapter.
+	TaskTypeDeviation = models.MustNewTaskType("deviation")
+	// TaskTypeDivide is the identifier for the Divide adapter.
+	TaskTypeDivide = models.MustNewTaskType("divide")
 	// TaskTypeEthBool is the identifier for the EthBool adapter.
 	TaskTypeEthBool = models.MustNewTaskType("ethbool")
 	// TaskTypeEthBytes32 is the identifier for the EthBytes32 adapter.
 	TaskTypeEthBytes32 = models.MustNewTaskType("ethbytes32")
+	// TaskTypeEthCall is the identifier for the EthCall adapter.
+	TaskTypeEthCall = models.MustNewTaskType("ethcall")
 	// TaskTypeEthInt256 is the identifier for the EthInt256 adapter.
 	TaskTypeEthInt256 = models.MustNewTaskType("ethint256")
 	// TaskTypeEthUint256 is the identifier for the EthUint256 adapter.
 	TaskTypeEthUint256 = models.MustNewTaskType("ethuint256")
 	// TaskTypeEthTx is the identifier for the EthTx adapter.
 	TaskTypeEthTx = models.MustNewTaskType("ethtx")
+	// TaskTypeEthTxEncode is the identifier for the EthTxEncode adapter.
+	TaskTypeEthTxEncode = models.MustNewTaskType("ethtxencode")
+	// TaskTypeGRPC is the identifier for the GRPC adapter.
+	TaskTypeGRPC = models.MustNewTaskType("grpc")
+	// TaskTypeHexDecode is the identifier for the HexDecode adapter.
+	TaskTypeHexDecode = models.MustNewTaskType("hexdecode")
+	// TaskTypeHexEncode is the identifier for the HexEncode adapter.
+	TaskTypeHexEncode = models.MustNewTaskType("hexencode")
 	// TaskTypeHTTPGet is the identifier for the HTTPGet adapter.
 	TaskTypeHTTPGet = models.MustNewTaskType("httpget")
+	// TaskTypeHTTPGetAggregate is the identifier for the HTTPGetAggregate adapter.
+	TaskTypeHTTPGetAggregate = models.MustNewTaskType("httpgetaggregate")
 	// TaskTypeHTTPPost is the identifier for the HTTPPost adapter.
 	TaskTypeHTTPPost = models.MustNewTaskType("httppost")
+	// TaskTypeIPFS is the identifier for the IPFS adapter.
+	TaskTypeIPFS = models.MustNewTaskType("ipfs")
 	// TaskTypeJSONParse is the identifier for the JSONParse adapter.
 	TaskTypeJSONParse = models.MustNewTaskType("jsonparse")
+	// TaskTypeKafkaPublish is the identifier for the KafkaPublish adapter.
+	TaskTypeKafkaPublish = models.MustNewTaskType("kafkapublish")
+	// TaskTypeMean is the identifier for the Mean adapter.
+	TaskTypeMean = models.MustNewTaskType("mean")
+	// TaskTypeMedian is the identifier for the Median adapter.
+	TaskTypeMedian = models.MustNewTaskType("median")
+	// TaskTypeMode is the identifier for the Mode adapter.
+	TaskTypeMode = models.MustNewTaskType("mode")
 	// TaskTypeMultiply is the identifier for the Multiply adapter.
 	TaskTypeMultiply = models.MustNewTaskType("multiply")
 	// TaskTypeNoOp is the identifier for the NoOp adapter.
 	TaskTypeNoOp = models.MustNewTaskType("noop")
 	// TaskTypeNoOpPend is the identifier for the NoOpPend adapter.
 	TaskTypeNoOpPend = models.MustNewTaskType("nooppend")
+	// TaskTypeOAuth2 is the identifier for the OAuth2 adapter.
+	TaskTypeOAuth2 = models.MustNewTaskType("oauth2")
+	// TaskTypeQuotient is the identifier for the Quotient adapter.
+	TaskTypeQuotient = models.MustNewTaskType("quotient")
+	// TaskTypeRandom is the identifier for the Random adapter.
+	TaskTypeRandom = models.MustNewTaskType("random")
+	// TaskTypeRedis is the identifier for the Redis adapter.
+	TaskTypeRedis = models.MustNewTaskType("redis")
+	// TaskTypeRegexExtract is the identifier for the RegexExtract adapter.
+	TaskTypeRegexExtract = models.MustNewTaskType("regexextract")
+	// TaskTypeResultCollect is the identifier for the ResultCollect adapter.
+	TaskTypeResultCollect = models.MustNewTaskType("resultcollect")
+	// TaskTypeS3 is the identifier for the S3 adapter.
+	TaskTypeS3 = models.MustNewTaskType("s3")
+	// TaskTypeSign is the identifier for the Sign adapter.
+	TaskTypeSign = models.MustNewTaskType("sign")
 	// TaskTypeSleep is the identifier for the Sleep adapter.
 	TaskTypeSleep = models.MustNewTaskType("sleep")
+	// TaskTypeStringTemplate is the identifier for the StringTemplate adapter.
+	TaskTypeStringTemplate = models.MustNewTaskType("stringtemplate")
+	// TaskTypeSum is the identifier for the Sum adapter.
+	TaskTypeSum = models.MustNewTaskType("sum")
+	// TaskTypeTimestamp is the identifier for the Timestamp adapter.
+	TaskTypeTimestamp = models.MustNewTaskType("timestamp")
 	// TaskTypeWasm is the wasm interpereter adapter
 	TaskTypeWasm = models.MustNewTaskType("wasm")
+	// TaskTypeWasmSandbox is the identifier for the WasmSandbox adapter.
+	TaskTypeWasmSandbox = models.MustNewTaskType("wasmsandbox")
+	// TaskTypeWebSocket is the identifier for the WebSocket adapter.
+	TaskTypeWebSocket = models.MustNewTaskType("websocket")
+	// TaskTypeXMLParse is the identifier for the XMLParse adapter.
+	TaskTypeXMLParse = models.MustNewTaskType("xmlparse")
 )
 
 // BaseAdapter is the minimum interface required to create an adapter. Only core
@@ -46,13 +119,55 @@ type BaseAdapter interface {
 	Perform(models.RunResult, *store.Store) models.RunResult
 }
 
+// ContextAdapter is implemented by adapters which can be cancelled part way
+// through, such as those making network requests. PerformCtx is preferred to
+// Perform when an adapter has it, and should give up once ctx is done.
+type ContextAdapter interface {
+	PerformCtx(context.Context, models.RunResult, *store.Store) models.RunResult
+}
+
+// performCtx performs ba with ctx if it accepts one.
+func performCtx(ctx context.Context, ba BaseAdapter, input models.RunResult, str *store.Store) models.RunResult {
+	if ca, ok := ba.(ContextAdapter); ok {
+		return ca.PerformCtx(ctx, input, str)
+	}
+	return ba.Perform(input, str)
+}
+
 // PipelineAdapter wraps a BaseAdapter with requirements for execution in the pipeline.
 type PipelineAdapter struct {
 	BaseAdapter
+	taskType           models.TaskType
 	minConfs           uint64
 	minContractPayment assets.Link
 }
 
+// Perform runs the adapter without a deadline.
+func (p PipelineAdapter) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return p.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx runs the adapter inside a span named after its task type. The
+// span is a child of any span in ctx, such as that of a task wrapping this
+// one, and outbound requests made by the adapter carry it in a traceparent
+// header.
+func (p PipelineAdapter) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	ctx, span := observability.StartSpan(
+		ctx,
+		"task "+p.taskType.String(),
+		attribute.String("chainlink.task_type", p.taskType.String()),
+		attribute.String("chainlink.job_run_id", input.JobRunID),
+	)
+	defer span.End()
+
+	output := performCtx(ctx, p.BaseAdapter, input, str)
+	span.SetAttributes(attribute.String("chainlink.status", string(output.Status)))
+	if output.HasError() {
+		span.SetStatus(codes.Error, output.Error())
+	}
+	return output
+}
+
 // MinConfs returns the private attribute
 func (p PipelineAdapter) MinConfs() uint64 {
 	return p.minConfs
@@ -67,66 +182,36 @@ func (p PipelineAdapter) MinContractPayment() assets.Link {
 func For(task models.TaskSpec, store *store.Store) (*PipelineAdapter, error) {
 	var ba BaseAdapter
 	var err error
-	mic := store.Config.MinIncomingConfirmations
+	mic := store.CurrentConfig().MinIncomingConfirmations
 	mcp := *assets.NewLink(0)
 
-	switch task.Type {
-	case TaskTypeCopy:
-		ba = &Copy{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeEthBool:
-		ba = &EthBool{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeEthBytes32:
-		ba = &EthBytes32{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeEthInt256:
-		ba = &EthInt256{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeEthUint256:
-		ba = &EthUint256{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeEthTx:
-		ba = &EthTx{}
-		mcp = store.Config.MinimumContractPayment
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeHTTPGet:
-		ba = &HTTPGet{}
+	if factory, ok := lookupFactory(task.Type); ok {
+		ba = factory()
 		err = unmarshalParams(task.Params, ba)
-	case TaskTypeHTTPPost:
-		ba = &HTTPPost{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeJSONParse:
-		ba = &JSONParse{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeMultiply:
-		ba = &Multiply{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeNoOp:
-		ba = &NoOp{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeNoOpPend:
-		ba = &NoOpPend{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeSleep:
-		ba = &Sleep{}
-		err = unmarshalParams(task.Params, ba)
-	case TaskTypeWasm:
-		ba = &Wasm{}
-		err = unmarshalParams(task.Params, ba)
-	default:
+		if strings.EqualFold(task.Type.String(), TaskTypeEthTx.String()) ||
+			strings.EqualFold(task.Type.String(), TaskTypeEthTxEncode.String()) ||
+			strings.EqualFold(task.Type.String(), TaskTypeContractDeploy.String()) {
+			mcp = store.Config.MinimumContractPayment
+		}
+	} else {
 		bt, err := store.FindBridge(task.Type.String())
 		if err != nil {
-			return nil, fmt.Errorf("%s is not a supported adapter type", task.Type)
+			return nil, fmt.Errorf(
+				"%s is not a supported adapter type, expected a bridge or one of: %s",
+				task.Type, strings.Join(RegisteredTypes(), ", "))
 		}
 		b := Bridge{BridgeType: bt, Params: &task.Params}
 		ba = &b
 		mic = b.Confirmations
 		mcp = bt.MinimumContractPayment
 	}
+	if err == nil {
+		err = resolveWrappedTasks(ba, store)
+	}
 
 	pa := &PipelineAdapter{
 		BaseAdapter:        ba,
+		taskType:           task.Type,
 		minConfs:           mic,
 		minContractPayment: mcp,
 	}
@@ -134,6 +219,27 @@ func For(task models.TaskSpec, store *store.Store) (*PipelineAdapter, error) {
 	return pa, err
 }
 
+// taskWrapper is implemented by adapters which perform other tasks.
+type taskWrapper interface {
+	wrappedTasks() []models.TaskSpec
+}
+
+// resolveWrappedTasks checks that the tasks wrapped by ba have adapters too,
+// so that a job spec naming an unknown type anywhere is rejected when it is
+// created rather than when it runs.
+func resolveWrappedTasks(ba BaseAdapter, store *store.Store) error {
+	tw, ok := ba.(taskWrapper)
+	if !ok {
+		return nil
+	}
+	for _, task := range tw.wrappedTasks() {
+		if _, err := For(task, store); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func unmarshalParams(params models.JSON, dst interface{}) error {
 	bytes, err := params.MarshalJSON()
 	if err != nil {
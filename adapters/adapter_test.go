@@ -1,6 +1,10 @@
 package adapters_test
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -9,6 +13,9 @@ import (
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func TestCreatingAdapterWithConfig(t *testing.T) {
@@ -41,6 +48,7 @@ func TestAdapterFor(t *testing.T) {
 		{"adapter not found", "nonExistent", "<nil>", nil, true},
 		{"noop", "NoOp", "*adapters.NoOp", assets.NewLink(0), false},
 		{"ethtx", "EthTx", "*adapters.EthTx", &store.Config.MinimumContractPayment, false},
+		{"ethtxencode", "EthTxEncode", "*adapters.EthTxEncode", &store.Config.MinimumContractPayment, false},
 		{"bridge mixed case", "rideShare", "*adapters.Bridge", assets.NewLink(10), false},
 		{"bridge lower case", "rideshare", "*adapters.Bridge", assets.NewLink(10), false},
 	}
@@ -59,3 +67,79 @@ func TestAdapterFor(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineAdapter_Perform_Tracing(t *testing.T) {
+	recorder, restore := cltest.RecordSpans()
+	defer restore()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	var traceparent string
+	mock, assertCalled := cltest.NewHTTPMockServer(t, 200, "GET", "ok",
+		func(header http.Header, _ string) { traceparent = header.Get("traceparent") })
+	defer assertCalled()
+
+	task := cltest.NewTask("cache", fmt.Sprintf(
+		`{"key":"TestPipelineAdapter_Perform_Tracing","ttl":"1m","innerTask":{"type":"httpget","params":{"get":"%s"}}}`,
+		mock.URL))
+	adapter, err := adapters.For(task, store)
+	require.NoError(t, err)
+
+	input := cltest.RunResultWithValue("input")
+	input.JobRunID = "run-1"
+	result := adapter.Perform(input, store)
+	require.NoError(t, result.GetError())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	inner, outer := spans[0], spans[1]
+	assert.Equal(t, "task httpget", inner.Name())
+	assert.Equal(t, "task cache", outer.Name())
+	assert.Equal(t, outer.SpanContext().SpanID(), inner.Parent().SpanID())
+	assert.Contains(t, outer.Attributes(), attribute.String("chainlink.job_run_id", "run-1"))
+	assert.Contains(t, outer.Attributes(), attribute.String("chainlink.status", "completed"))
+
+	require.NotEmpty(t, traceparent)
+	assert.Contains(t, traceparent, inner.SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, inner.SpanContext().SpanID().String())
+}
+
+func TestPipelineAdapter_Perform_TracingRecordsErrors(t *testing.T) {
+	recorder, restore := cltest.RecordSpans()
+	defer restore()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	adapter, err := adapters.For(cltest.NewTask("ethuint256"), store)
+	require.NoError(t, err)
+	result := adapter.Perform(cltest.RunResultWithValue("not a number"), store)
+	require.True(t, result.HasError())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, result.Error(), spans[0].Status().Description)
+}
+
+func TestPipelineAdapter_PerformCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	adapter, err := adapters.For(cltest.NewTask("httpget", fmt.Sprintf(`{"get":"%s"}`, server.URL)), store)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := adapter.PerformCtx(ctx, cltest.RunResultWithValue("input"), store)
+
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), context.Canceled.Error())
+	assert.False(t, called)
+}
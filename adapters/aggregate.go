@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// Mean holds the number of decimal places to round the average of the
+// input's array of numbers to.
+type Mean struct {
+	Precision *int `json:"precision"`
+}
+
+// Perform returns the arithmetic mean of the array of numbers in the input's
+// "value" field.
+//
+// For example, if input value is [1, 2, "4"] the result's value will be
+// "2.333333333333333333", or "2.33" with a precision of 2.
+func (ma *Mean) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	return aggregate(input, ma.Precision, mean)
+}
+
+// Median holds the number of decimal places to round the median of the
+// input's array of numbers to.
+type Median struct {
+	Precision *int `json:"precision"`
+}
+
+// Perform returns the middle value of the array of numbers in the input's
+// "value" field, or the average of the two middle values when the array has
+// an even length.
+func (ma *Median) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	return aggregate(input, ma.Precision, median)
+}
+
+// Mode holds the number of decimal places to round the mode of the input's
+// array of numbers to.
+type Mode struct {
+	Precision *int `json:"precision"`
+}
+
+// Perform returns the most frequent value in the array of numbers in the
+// input's "value" field. When several values are equally frequent the
+// smallest of them is returned.
+func (ma *Mode) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	return aggregate(input, ma.Precision, mode)
+}
+
+func aggregate(input models.RunResult, precision *int, fn func([]*big.Rat) *big.Rat) models.RunResult {
+	values, err := decimalsFromArray(input.Get("value"))
+	if err != nil {
+		return input.WithError(err)
+	}
+	if len(values) == 0 {
+		return input.WithError(errors.New("cannot aggregate an empty array"))
+	}
+
+	val, err := formatDecimalWithPrecision(fn(values), precision)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(val)
+}
+
+func mean(values []*big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	for _, v := range values {
+		sum.Add(sum, v)
+	}
+	return sum.Quo(sum, new(big.Rat).SetInt64(int64(len(values))))
+}
+
+func median(values []*big.Rat) *big.Rat {
+	sorted := sortDecimals(values)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return mean(sorted[mid-1 : mid+1])
+}
+
+func mode(values []*big.Rat) *big.Rat {
+	sorted := sortDecimals(values)
+	best, bestCount := sorted[0], 0
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Cmp(sorted[i]) == 0 {
+			j++
+		}
+		if j-i > bestCount {
+			best, bestCount = sorted[i], j-i
+		}
+		i = j
+	}
+	return best
+}
+
+func sortDecimals(values []*big.Rat) []*big.Rat {
+	sorted := make([]*big.Rat, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	return sorted
+}
@@ -0,0 +1,115 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type aggregateTest struct {
+	name    string
+	params  string
+	json    string
+	want    string
+	errored bool
+}
+
+func runAggregateTests(t *testing.T, newAdapter func() adapters.BaseAdapter, tests []aggregateTest) {
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			input := models.RunResult{
+				Data: cltest.JSONFromString(test.json),
+			}
+			adapter := newAdapter()
+			assert.NoError(t, json.Unmarshal([]byte(test.params), adapter))
+			result := adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.GetError())
+			} else {
+				val, err := result.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, val)
+				assert.NoError(t, result.GetError())
+			}
+		})
+	}
+}
+
+func TestMean_Perform(t *testing.T) {
+	runAggregateTests(t, func() adapters.BaseAdapter { return &adapters.Mean{} }, []aggregateTest{
+		{"single element", `{}`, `{"value":[1.23]}`, "1.23", false},
+		{"integers", `{}`, `{"value":[1, 2, 3, 6]}`, "3", false},
+		{"fractional result", `{}`, `{"value":[1, 2]}`, "1.5", false},
+		{"numeric strings", `{}`, `{"value":["0.1", "0.2"]}`, "0.15", false},
+		{"negative numbers", `{}`, `{"value":[-5, 1]}`, "-2", false},
+		{"repeating decimal", `{}`, `{"value":[1, 2, 4]}`, "2.333333333333333333", false},
+		{"precision", `{"precision":2}`, `{"value":[1, 2, 4]}`, "2.33", false},
+		{"empty array", `{}`, `{"value":[]}`, "", true},
+		{"not an array", `{}`, `{"value":"1.23"}`, "", true},
+		{"non-numeric element", `{}`, `{"value":[1, "one"]}`, "", true},
+	})
+}
+
+func TestMedian_Perform(t *testing.T) {
+	runAggregateTests(t, func() adapters.BaseAdapter { return &adapters.Median{} }, []aggregateTest{
+		{"single element", `{}`, `{"value":[1.23]}`, "1.23", false},
+		{"odd length", `{}`, `{"value":[3, 1, 2]}`, "2", false},
+		{"even length", `{}`, `{"value":[4, 1, 3, 2]}`, "2.5", false},
+		{"even length equal middles", `{}`, `{"value":[1, 2, 2, 9]}`, "2", false},
+		{"outlier", `{}`, `{"value":[212.5, 212.7, 10000]}`, "212.7", false},
+		{"numeric strings", `{}`, `{"value":["10.5", "2", "3"]}`, "3", false},
+		{"precision", `{"precision":1}`, `{"value":[1.25, 1.3]}`, "1.3", false},
+		{"empty array", `{}`, `{"value":[]}`, "", true},
+		{"non-numeric element", `{}`, `{"value":[1, null]}`, "", true},
+	})
+}
+
+func TestMode_Perform(t *testing.T) {
+	runAggregateTests(t, func() adapters.BaseAdapter { return &adapters.Mode{} }, []aggregateTest{
+		{"single element", `{}`, `{"value":[1.23]}`, "1.23", false},
+		{"most frequent", `{}`, `{"value":[3, 1, 3, 2]}`, "3", false},
+		{"multi-modal picks smallest", `{}`, `{"value":[5, 2, 5, 2, 9]}`, "2", false},
+		{"all unique picks smallest", `{}`, `{"value":[3, -1, 2]}`, "-1", false},
+		{"equal values in different notation", `{}`, `{"value":["1.50", 1.5, 2]}`, "1.5", false},
+		{"precision", `{"precision":1}`, `{"value":[1.25, 1.25, 3]}`, "1.3", false},
+		{"empty array", `{}`, `{"value":[]}`, "", true},
+		{"non-numeric element", `{}`, `{"value":[1, {"foo":"bar"}]}`, "", true},
+	})
+}
+
+func benchmarkAggregate(b *testing.B, adapter adapters.BaseAdapter) {
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = fmt.Sprintf("%d.%d", i%97, i)
+	}
+	input := models.RunResult{
+		Data: cltest.JSONFromString(`{"value":[%s]}`, strings.Join(values, ",")),
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		result := adapter.Perform(input, nil)
+		assert.False(b, result.HasError())
+	}
+}
+
+func BenchmarkMean_Perform(b *testing.B) {
+	benchmarkAggregate(b, &adapters.Mean{})
+}
+
+func BenchmarkMedian_Perform(b *testing.B) {
+	benchmarkAggregate(b, &adapters.Median{})
+}
+
+func BenchmarkMode_Perform(b *testing.B) {
+	benchmarkAggregate(b, &adapters.Mode{})
+}
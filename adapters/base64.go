@@ -0,0 +1,160 @@
+package adapters
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	// Base64OperationEncode encodes the run's value.
+	Base64OperationEncode = "encode"
+	// Base64OperationDecode decodes the run's value.
+	Base64OperationDecode = "decode"
+)
+
+// The alphabets a Base64 adapter can use.
+const (
+	// Base64EncodingStd is the standard, padded, alphabet.
+	Base64EncodingStd = "std"
+	// Base64EncodingURL is the URL and filename safe, padded, alphabet.
+	Base64EncodingURL = "url"
+	// Base64EncodingRaw is the standard alphabet without padding.
+	Base64EncodingRaw = "raw"
+)
+
+// Base64DecodeError is returned when the run's value is not valid base64.
+type Base64DecodeError struct {
+	Encoding string
+	Err      error
+}
+
+func (e *Base64DecodeError) Error() string {
+	return fmt.Sprintf("invalid %s base64 input: %v", e.Encoding, e.Err)
+}
+
+// Base64 encodes or decodes the run's value.
+type Base64 struct {
+	Operation string `json:"operation"`
+	// Encoding is one of "std", "url" or "raw", defaulting to "std".
+	Encoding string `json:"encoding"`
+}
+
+// Perform returns the input's value base64 encoded, or decoded, as the
+// "value" field of the result. When decoding with the "raw" encoding any
+// trailing padding is ignored.
+func (ba *Base64) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val, err := input.Value()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	encoding, err := ba.encoding()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	switch ba.Operation {
+	case Base64OperationEncode:
+		return input.WithValue(encoding.EncodeToString([]byte(val)))
+	case Base64OperationDecode:
+		decoded, err := ba.decode(encoding, val)
+		if err != nil {
+			return input.WithError(err)
+		}
+		return input.WithValue(decoded)
+	default:
+		return input.WithError(fmt.Errorf("base64 operation must be %q or %q, got %q", Base64OperationEncode, Base64OperationDecode, ba.Operation))
+	}
+}
+
+func (ba *Base64) encoding() (*base64.Encoding, error) {
+	switch ba.Encoding {
+	case "", Base64EncodingStd:
+		return base64.StdEncoding, nil
+	case Base64EncodingURL:
+		return base64.URLEncoding, nil
+	case Base64EncodingRaw:
+		return base64.RawStdEncoding, nil
+	default:
+		return nil, fmt.Errorf("base64 encoding must be %q, %q or %q, got %q", Base64EncodingStd, Base64EncodingURL, Base64EncodingRaw, ba.Encoding)
+	}
+}
+
+func (ba *Base64) decode(encoding *base64.Encoding, val string) (string, error) {
+	if ba.Encoding == Base64EncodingRaw {
+		val = strings.TrimRight(val, "=")
+	}
+	decoded, err := encoding.DecodeString(val)
+	if err != nil {
+		return "", &Base64DecodeError{Encoding: ba.encodingName(), Err: err}
+	}
+	if !utf8.Valid(decoded) {
+		return "", &Base64DecodeError{Encoding: ba.encodingName(), Err: errors.New("decoded data is not valid UTF-8 text")}
+	}
+	return string(decoded), nil
+}
+
+func (ba *Base64) encodingName() string {
+	if ba.Encoding == "" {
+		return Base64EncodingStd
+	}
+	return ba.Encoding
+}
+
+// Base64Decode converts the run's base64 value to the hex of the bytes it
+// encodes, for data sources which return binary payloads.
+type Base64Decode struct {
+	// Encoding is "std" or "url" to accept only that alphabet. When unset
+	// either is accepted.
+	Encoding string `json:"encoding"`
+}
+
+// Perform returns the bytes which the input's value encodes as a 0x prefixed
+// hex string, ready for EthTx's "bytes" format. Padding is optional in
+// either alphabet.
+func (bd *Base64Decode) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val, err := input.Value()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	encoding, err := bd.encoding(val)
+	if err != nil {
+		return input.WithError(err)
+	}
+	decoded, err := encoding.DecodeString(strings.TrimRight(val, "="))
+	if err != nil {
+		return input.WithError(&Base64DecodeError{Encoding: bd.encodingName(), Err: err})
+	}
+	return input.WithValue(hexutil.Encode(decoded))
+}
+
+func (bd *Base64Decode) encoding(val string) (*base64.Encoding, error) {
+	switch bd.Encoding {
+	case "":
+		if strings.ContainsAny(val, "-_") {
+			return base64.RawURLEncoding, nil
+		}
+		return base64.RawStdEncoding, nil
+	case Base64EncodingStd:
+		return base64.RawStdEncoding, nil
+	case Base64EncodingURL:
+		return base64.RawURLEncoding, nil
+	default:
+		return nil, fmt.Errorf("base64decode encoding must be %q or %q, got %q", Base64EncodingStd, Base64EncodingURL, bd.Encoding)
+	}
+}
+
+func (bd *Base64Decode) encodingName() string {
+	if bd.Encoding == "" {
+		return "std or url"
+	}
+	return bd.Encoding
+}
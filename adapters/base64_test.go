@@ -0,0 +1,123 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase64_Perform_RoundTrip(t *testing.T) {
+	inputs := []string{"", "f", "fo", "foo", "hello, world", "ünïcödé ✓", "?>?>~~~", "{\"price\":\"212.54\"}"}
+	encodings := []string{"", "std", "url", "raw"}
+
+	for _, e := range encodings {
+		for _, in := range inputs {
+			encoding, input := e, in
+			t.Run(encoding+"/"+input, func(t *testing.T) {
+				t.Parallel()
+				encode := adapters.Base64{Operation: "encode", Encoding: encoding}
+				encoded := encode.Perform(cltest.RunResultWithValue(input), nil)
+				assert.False(t, encoded.HasError(), encoded.Error())
+
+				decode := adapters.Base64{Operation: "decode", Encoding: encoding}
+				decoded := decode.Perform(encoded, nil)
+				assert.False(t, decoded.HasError(), decoded.Error())
+
+				val, err := decoded.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, input, val)
+			})
+		}
+	}
+}
+
+func TestBase64_Perform(t *testing.T) {
+	tests := []struct {
+		name        string
+		operation   string
+		encoding    string
+		input       string
+		want        string
+		wantErrored bool
+	}{
+		{"std encode", "encode", "std", "?>?>~~~", "Pz4/Pn5+fg==", false},
+		{"url encode", "encode", "url", "?>?>~~~", "Pz4_Pn5-fg==", false},
+		{"raw encode", "encode", "raw", "?>?>~~~", "Pz4/Pn5+fg", false},
+		{"default encoding", "encode", "", "foo", "Zm9v", false},
+		{"empty encode", "encode", "std", "", "", false},
+		{"std decode", "decode", "std", "Pz4/Pn5+fg==", "?>?>~~~", false},
+		{"url decode", "decode", "url", "Pz4_Pn5-fg==", "?>?>~~~", false},
+		{"raw decode", "decode", "raw", "Pz4/Pn5+fg", "?>?>~~~", false},
+		{"raw decode with padding", "decode", "raw", "Pz4/Pn5+fg==", "?>?>~~~", false},
+		{"empty decode", "decode", "std", "", "", false},
+		{"std decode missing padding", "decode", "std", "Pz4/Pn5+fg", "Pz4/Pn5+fg", true},
+		{"std decode url alphabet", "decode", "std", "Pz4_Pn5-fg==", "Pz4_Pn5-fg==", true},
+		{"invalid characters", "decode", "std", "not base64!", "not base64!", true},
+		{"binary data", "decode", "std", "//79", "//79", true},
+		{"unknown encoding", "encode", "base32", "foo", "foo", true},
+		{"unknown operation", "reverse", "std", "foo", "foo", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.Base64{Operation: test.operation, Encoding: test.encoding}
+			result := adapter.Perform(cltest.RunResultWithValue(test.input), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestBase64_Perform_DecodeError(t *testing.T) {
+	adapter := adapters.Base64{Operation: "decode", Encoding: "url"}
+	result := adapter.Perform(cltest.RunResultWithValue("a+b/"), nil)
+
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "invalid url base64 input")
+}
+
+func TestBase64Decode_Perform(t *testing.T) {
+	tests := []struct {
+		name        string
+		encoding    string
+		input       string
+		want        string
+		wantErrored bool
+	}{
+		{"std", "", "3q2+7wD/AQ==", "0xdeadbeef00ff01", false},
+		{"std unpadded", "", "3q2+7wD/AQ", "0xdeadbeef00ff01", false},
+		{"url", "", "3q2-7wD_AQ==", "0xdeadbeef00ff01", false},
+		{"url unpadded", "", "3q2-7wD_AQ", "0xdeadbeef00ff01", false},
+		{"text", "", "aGVsbG8=", "0x68656c6c6f", false},
+		{"empty", "", "", "0x", false},
+		{"forced std", "std", "3q2+7wD/AQ", "0xdeadbeef00ff01", false},
+		{"forced url", "url", "3q2-7wD_AQ", "0xdeadbeef00ff01", false},
+		{"forced std given url", "std", "3q2-7wD_AQ==", "3q2-7wD_AQ==", true},
+		{"forced url given std", "url", "3q2+7wD/AQ==", "3q2+7wD/AQ==", true},
+		{"mixed alphabets", "", "3q2+7wD_AQ==", "3q2+7wD_AQ==", true},
+		{"invalid characters", "", "not base64!", "not base64!", true},
+		{"truncated", "", "3q2+7", "3q2+7", true},
+		{"unknown encoding", "raw", "aGVsbG8=", "aGVsbG8=", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.Base64Decode{Encoding: test.encoding}
+			result := adapter.Perform(cltest.RunResultWithValue(test.input), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
@@ -2,11 +2,13 @@ package adapters
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 )
@@ -25,12 +27,18 @@ type Bridge struct {
 // If the Perform is resumed with a pending RunResult, the RunResult is marked
 // not pending and the RunResult is returned.
 func (ba *Bridge) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	return ba.PerformCtx(context.Background(), input, store)
+}
+
+// PerformCtx is Perform, abandoning the request to the external adapter once
+// ctx is done.
+func (ba *Bridge) PerformCtx(ctx context.Context, input models.RunResult, store *store.Store) models.RunResult {
 	if input.Status.Finished() {
 		return input
 	} else if input.Status.PendingBridge() {
 		return resumeBridge(input)
 	}
-	return ba.handleNewRun(input, store.Config.BridgeResponseURL)
+	return ba.handleNewRun(ctx, input, store.Config.BridgeResponseURL)
 }
 
 func resumeBridge(input models.RunResult) models.RunResult {
@@ -38,7 +46,7 @@ func resumeBridge(input models.RunResult) models.RunResult {
 	return input
 }
 
-func (ba *Bridge) handleNewRun(input models.RunResult, bridgeResponseURL models.WebURL) models.RunResult {
+func (ba *Bridge) handleNewRun(ctx context.Context, input models.RunResult, bridgeResponseURL models.WebURL) models.RunResult {
 	var err error
 	if ba.Params != nil {
 		input.Data, err = input.Data.Merge(*ba.Params)
@@ -51,7 +59,7 @@ func (ba *Bridge) handleNewRun(input models.RunResult, bridgeResponseURL models.
 	if (responseURL != models.WebURL{}) {
 		responseURL.Path += fmt.Sprintf("/v2/runs/%s", input.JobRunID)
 	}
-	body, err := ba.postToExternalAdapter(input, responseURL)
+	body, err := ba.postToExternalAdapter(ctx, input, responseURL)
 	if err != nil {
 		return baRunResultError(input, "post to external adapter", err)
 	}
@@ -74,7 +82,7 @@ func responseToRunResult(body []byte, input models.RunResult) models.RunResult {
 	return rr
 }
 
-func (ba *Bridge) postToExternalAdapter(input models.RunResult, bridgeResponseURL models.WebURL) ([]byte, error) {
+func (ba *Bridge) postToExternalAdapter(ctx context.Context, input models.RunResult, bridgeResponseURL models.WebURL) ([]byte, error) {
 	in, err := json.Marshal(&bridgeOutgoing{
 		RunResult:   input,
 		ResponseURL: bridgeResponseURL,
@@ -89,9 +97,10 @@ func (ba *Bridge) postToExternalAdapter(input models.RunResult, bridgeResponseUR
 	}
 	request.Header.Set("Authorization", "Bearer "+ba.BridgeType.OutgoingToken)
 	request.Header.Set("Content-Type", "application/json")
+	observability.InjectHeaders(ctx, request.Header)
 
 	client := http.Client{}
-	resp, err := client.Do(request)
+	resp, err := client.Do(request.WithContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("POST request: %v", err)
 	}
@@ -0,0 +1,229 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	// CacheBackendMemory keeps cached values in the node's memory, and is the
+	// default.
+	CacheBackendMemory = "memory"
+	// CacheBackendRedis keeps cached values in the Redis server at
+	// CACHE_REDIS_URL, so they survive restarts and can be shared by nodes.
+	CacheBackendRedis = "redis"
+)
+
+// cacheBackend stores raw JSON values by key until they expire.
+type cacheBackend interface {
+	get(key string) (json.RawMessage, bool, error)
+	set(key string, value json.RawMessage, ttl time.Duration) error
+}
+
+// Cache returns a previously stored value for Key, running InnerTask to
+// produce it only when there is none or it has expired.
+type Cache struct {
+	// Key is a text/template rendered against the run data, for example
+	// "token-{{.address}}".
+	Key       string          `json:"key"`
+	TTL       store.Duration  `json:"ttl"`
+	InnerTask models.TaskSpec `json:"innerTask"`
+	Backend   string          `json:"backend"`
+}
+
+func (ca *Cache) wrappedTasks() []models.TaskSpec {
+	return []models.TaskSpec{ca.InnerTask}
+}
+
+// Perform renders Key and returns the value cached under it as the "value"
+// field of the result. On a miss InnerTask is performed, and its value is
+// cached for TTL if it completed. Errored and pending results are returned
+// as they are, without being cached.
+func (ca *Cache) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return ca.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx is Perform, passing ctx on to InnerTask.
+func (ca *Cache) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	if ca.TTL.Duration <= 0 {
+		return input.WithError(errors.New("Cache requires a positive ttl"))
+	}
+	backend, err := ca.backend(str)
+	if err != nil {
+		return input.WithError(err)
+	}
+	key, err := ca.renderKey(input)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	cached, ok, err := backend.get(key)
+	if err != nil {
+		return input.WithError(fmt.Errorf("unable to read cache: %v", err))
+	}
+	if ok {
+		return input.Add("value", cached)
+	}
+
+	inner, err := For(ca.InnerTask, str)
+	if err != nil {
+		return input.WithError(err)
+	}
+	output := inner.PerformCtx(ctx, input, str)
+	if output.HasError() || !output.Status.Completed() {
+		return output
+	}
+
+	value := output.Get("value")
+	if !value.Exists() {
+		return output
+	}
+	if err := backend.set(key, json.RawMessage(value.Raw), ca.TTL.Duration); err != nil {
+		return output.WithError(fmt.Errorf("unable to write cache: %v", err))
+	}
+	return output
+}
+
+func (ca *Cache) renderKey(input models.RunResult) (string, error) {
+	key, err := renderDataTemplate("cache key", ca.Key, input)
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", errors.New("cache key rendered to an empty string")
+	}
+	return key, nil
+}
+
+// renderDataTemplate renders a text/template against the run's data, so that
+// "{{.symbol}}" refers to the "symbol" field. Referring to a field the data
+// does not have is an error.
+func renderDataTemplate(name, text string, input models.RunResult) (string, error) {
+	tmpl, err := template.New(name).
+		Funcs(stringTemplateFuncs).
+		Option("missingkey=error").
+		Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse %s: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, input.Data.Value()); err != nil {
+		return "", fmt.Errorf("unable to render %s: %v", name, err)
+	}
+	return buf.String(), nil
+}
+
+func (ca *Cache) backend(str *store.Store) (cacheBackend, error) {
+	switch ca.Backend {
+	case "", CacheBackendMemory:
+		return &memoryCacheBackend{clock: str.Clock}, nil
+	case CacheBackendRedis:
+		return newRedisCacheBackend(str.Config.CacheRedisURL)
+	default:
+		return nil, fmt.Errorf("cache backend must be %q or %q, got %q", CacheBackendMemory, CacheBackendRedis, ca.Backend)
+	}
+}
+
+type memoryCacheEntry struct {
+	value     json.RawMessage
+	expiresAt time.Time
+}
+
+// memoryCache is shared by every memory backed Cache adapter in the node.
+var memoryCache = struct {
+	sync.Mutex
+	entries map[string]memoryCacheEntry
+}{entries: map[string]memoryCacheEntry{}}
+
+type memoryCacheBackend struct {
+	clock store.AfterNower
+}
+
+func (m *memoryCacheBackend) get(key string) (json.RawMessage, bool, error) {
+	memoryCache.Lock()
+	defer memoryCache.Unlock()
+
+	entry, ok := memoryCache.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !m.clock.Now().Before(entry.expiresAt) {
+		delete(memoryCache.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (m *memoryCacheBackend) set(key string, value json.RawMessage, ttl time.Duration) error {
+	memoryCache.Lock()
+	defer memoryCache.Unlock()
+
+	memoryCache.entries[key] = memoryCacheEntry{
+		value:     value,
+		expiresAt: m.clock.Now().Add(ttl),
+	}
+	return nil
+}
+
+// redisClients holds a client per Redis URL, so that connections are pooled
+// across runs rather than opened for each one.
+var redisClients = struct {
+	sync.Mutex
+	byURL map[string]*redis.Client
+}{byURL: map[string]*redis.Client{}}
+
+func redisClientFor(rawURL string) (*redis.Client, error) {
+	redisClients.Lock()
+	defer redisClients.Unlock()
+
+	if client, ok := redisClients.byURL[rawURL]; ok {
+		return client, nil
+	}
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %v", err)
+	}
+	client := redis.NewClient(opts)
+	redisClients.byURL[rawURL] = client
+	return client, nil
+}
+
+type redisCacheBackend struct {
+	client *redis.Client
+}
+
+func newRedisCacheBackend(rawURL string) (*redisCacheBackend, error) {
+	if rawURL == "" {
+		return nil, errors.New("the redis cache backend requires CACHE_REDIS_URL to be set")
+	}
+	client, err := redisClientFor(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &redisCacheBackend{client: client}, nil
+}
+
+func (r *redisCacheBackend) get(key string) (json.RawMessage, bool, error) {
+	value, err := r.client.Get(key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return json.RawMessage(value), true, nil
+}
+
+func (r *redisCacheBackend) set(key string, value json.RawMessage, ttl time.Duration) error {
+	return r.client.Set(key, []byte(value), ttl).Err()
+}
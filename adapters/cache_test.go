@@ -0,0 +1,155 @@
+package adapters_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCache(key, url string) adapters.Cache {
+	return adapters.Cache{
+		Key: key,
+		TTL: strpkg.Duration{Duration: time.Minute},
+		InnerTask: models.TaskSpec{
+			Type:   adapters.TaskTypeHTTPGet,
+			Params: cltest.JSONFromString(`{"get":"%s"}`, url),
+		},
+	}
+}
+
+func TestCache_Perform_MissThenHit(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newFlakyServer()
+	defer server.Close()
+	ca := newCache("TestCache_Perform_MissThenHit", server.URL)
+
+	for i := 0; i < 3; i++ {
+		result := ca.Perform(cltest.RunResultWithValue("input"), store)
+		assert.NoError(t, result.GetError())
+		val, err := result.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", val)
+	}
+	assert.Equal(t, int32(1), server.callCount())
+}
+
+func TestCache_Perform_KeyTemplate(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newFlakyServer()
+	defer server.Close()
+	ca := newCache("TestCache_Perform_KeyTemplate-{{.symbol}}", server.URL)
+
+	eth := models.RunResult{Data: cltest.JSONFromString(`{"symbol":"ETH"}`)}
+	btc := models.RunResult{Data: cltest.JSONFromString(`{"symbol":"BTC"}`)}
+
+	assert.NoError(t, ca.Perform(eth, store).GetError())
+	assert.NoError(t, ca.Perform(btc, store).GetError())
+	assert.NoError(t, ca.Perform(eth, store).GetError())
+	assert.Equal(t, int32(2), server.callCount())
+
+	missing := ca.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, missing.HasError())
+	assert.Contains(t, missing.Error(), "unable to render cache key")
+}
+
+func TestCache_Perform_Expiry(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Now())
+	server := newFlakyServer()
+	defer server.Close()
+	ca := newCache("TestCache_Perform_Expiry", server.URL)
+
+	ca.Perform(cltest.RunResultWithValue("input"), store)
+	clock.SetTime(clock.Now().Add(59 * time.Second))
+	ca.Perform(cltest.RunResultWithValue("input"), store)
+	assert.Equal(t, int32(1), server.callCount())
+
+	clock.SetTime(clock.Now().Add(time.Second))
+	result := ca.Perform(cltest.RunResultWithValue("input"), store)
+	assert.NoError(t, result.GetError())
+	assert.Equal(t, int32(2), server.callCount())
+}
+
+func TestCache_Perform_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newFlakyServer()
+	defer server.Close()
+	ca := newCache("TestCache_Perform_DoesNotCacheErrors", server.URL)
+
+	server.fail()
+	result := ca.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, result.HasError())
+
+	server.recover()
+	result = ca.Perform(cltest.RunResultWithValue("input"), store)
+	assert.NoError(t, result.GetError())
+	assert.Equal(t, int32(2), server.callCount())
+}
+
+func TestCache_Perform_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newFlakyServer()
+	defer server.Close()
+	ca := newCache("TestCache_Perform_ConcurrentAccess", server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := ca.Perform(cltest.RunResultWithValue("input"), store)
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", val)
+		}()
+	}
+	wg.Wait()
+
+	calls := server.callCount()
+	ca.Perform(cltest.RunResultWithValue("input"), store)
+	assert.Equal(t, calls, server.callCount(), "value should be cached once the concurrent runs finish")
+}
+
+func TestCache_Perform_InvalidParams(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		modify  func(*adapters.Cache)
+		wantErr string
+	}{
+		{"no ttl", func(ca *adapters.Cache) { ca.TTL = strpkg.Duration{} }, "positive ttl"},
+		{"unknown backend", func(ca *adapters.Cache) { ca.Backend = "disk" }, "cache backend must be"},
+		{"redis not configured", func(ca *adapters.Cache) { ca.Backend = "redis" }, "CACHE_REDIS_URL"},
+		{"empty key", func(ca *adapters.Cache) { ca.Key = "" }, "empty string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ca := newCache("TestCache_Perform_InvalidParams", "https://example.com")
+			test.modify(&ca)
+			result := ca.Perform(cltest.RunResultWithValue("input"), store)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), test.wantErr)
+		})
+	}
+}
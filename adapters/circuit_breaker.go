@@ -0,0 +1,138 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+type circuitState int
+
+const (
+	// circuitClosed lets every request through.
+	circuitClosed circuitState = iota
+	// circuitOpen fails requests without running the inner adapter.
+	circuitOpen
+	// circuitHalfOpen has let a single probe through after the recovery
+	// timeout, and fails everything else until that probe completes.
+	circuitHalfOpen
+)
+
+type circuit struct {
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// circuits holds the state of every circuit in the node, keyed by the URL of
+// the adapter it guards, so that all jobs calling the same adapter share it.
+var circuits = struct {
+	sync.Mutex
+	byKey map[string]*circuit
+}{byKey: map[string]*circuit{}}
+
+// CircuitBreaker wraps another task, and stops calling it for a while after
+// it has failed several times in a row.
+type CircuitBreaker struct {
+	Task models.TaskSpec `json:"task"`
+	// FailureThreshold is how many consecutive failures open the circuit.
+	FailureThreshold int `json:"failureThreshold"`
+	// RecoveryTimeout is how long the circuit stays open before a probe
+	// request is let through.
+	RecoveryTimeout store.Duration `json:"recoveryTimeout"`
+}
+
+func (cba *CircuitBreaker) wrappedTasks() []models.TaskSpec {
+	return []models.TaskSpec{cba.Task}
+}
+
+// Perform runs the wrapped task and returns its result, unless the circuit
+// for that task's adapter is open, in which case the run is errored without
+// calling it.
+//
+// The circuit opens after FailureThreshold consecutive errored results. Once
+// RecoveryTimeout has passed the next run probes the adapter: if it succeeds
+// the circuit closes, otherwise it stays open for another RecoveryTimeout.
+func (cba *CircuitBreaker) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return cba.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx is Perform, passing ctx on to Task.
+func (cba *CircuitBreaker) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	if cba.FailureThreshold < 1 {
+		return input.WithError(errors.New("CircuitBreaker requires a failureThreshold of at least 1"))
+	}
+	inner, err := For(cba.Task, str)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	key := circuitKey(inner.BaseAdapter, cba.Task)
+	if !cba.allow(key, str.Clock.Now()) {
+		return input.WithError(fmt.Errorf("circuit open for %s, not calling adapter", key))
+	}
+
+	output := inner.PerformCtx(ctx, input, str)
+	cba.record(key, output.HasError(), str.Clock.Now())
+	return output
+}
+
+// circuitKey identifies the circuit for an adapter by the URL it calls, or by
+// its task type if it doesn't call one.
+func circuitKey(ba BaseAdapter, task models.TaskSpec) string {
+	switch adapter := ba.(type) {
+	case *Bridge:
+		return adapter.URL.String()
+	case interface{ GetURL() string }:
+		return adapter.GetURL()
+	default:
+		return task.Type.String()
+	}
+}
+
+func (cba *CircuitBreaker) allow(key string, now time.Time) bool {
+	circuits.Lock()
+	defer circuits.Unlock()
+
+	c, ok := circuits.byKey[key]
+	if !ok {
+		c = &circuit{}
+		circuits.byKey[key] = c
+	}
+
+	switch c.state {
+	case circuitOpen:
+		if now.Sub(c.openedAt) < cba.RecoveryTimeout.Duration {
+			return false
+		}
+		c.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (cba *CircuitBreaker) record(key string, failed bool, now time.Time) {
+	circuits.Lock()
+	defer circuits.Unlock()
+
+	c := circuits.byKey[key]
+	if !failed {
+		c.state = circuitClosed
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.state == circuitHalfOpen || c.failures >= cba.FailureThreshold {
+		c.state = circuitOpen
+		c.openedAt = now
+	}
+}
@@ -0,0 +1,187 @@
+package adapters_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer responds with whatever status was last set, and counts how
+// many requests reached it.
+type flakyServer struct {
+	*httptest.Server
+	status int32
+	calls  int32
+}
+
+func newFlakyServer() *flakyServer {
+	fs := &flakyServer{status: http.StatusOK}
+	fs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fs.calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&fs.status)))
+		w.Write([]byte("ok"))
+	}))
+	return fs
+}
+
+func (fs *flakyServer) fail()            { atomic.StoreInt32(&fs.status, http.StatusBadRequest) }
+func (fs *flakyServer) recover()         { atomic.StoreInt32(&fs.status, http.StatusOK) }
+func (fs *flakyServer) callCount() int32 { return atomic.LoadInt32(&fs.calls) }
+
+func newCircuitBreaker(url string) adapters.CircuitBreaker {
+	return adapters.CircuitBreaker{
+		Task: models.TaskSpec{
+			Type:   adapters.TaskTypeHTTPGet,
+			Params: cltest.JSONFromString(`{"get":"%s"}`, url),
+		},
+		FailureThreshold: 2,
+		RecoveryTimeout:  strpkg.Duration{Duration: time.Minute},
+	}
+}
+
+func TestCircuitBreaker_Perform_Closed(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newFlakyServer()
+	defer server.Close()
+	cb := newCircuitBreaker(server.URL)
+
+	result := cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.False(t, result.HasError())
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", val)
+
+	server.fail()
+	result = cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, result.HasError())
+
+	server.recover()
+	result = cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.False(t, result.HasError())
+
+	server.fail()
+	result = cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, result.HasError())
+
+	assert.Equal(t, int32(4), server.callCount(), "a success should reset the consecutive failure count")
+}
+
+func TestCircuitBreaker_Perform_Open(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Now())
+	server := newFlakyServer()
+	defer server.Close()
+	cb := newCircuitBreaker(server.URL)
+
+	server.fail()
+	cb.Perform(cltest.RunResultWithValue("input"), store)
+	cb.Perform(cltest.RunResultWithValue("input"), store)
+	require.Equal(t, int32(2), server.callCount())
+
+	server.recover()
+	clock.SetTime(clock.Now().Add(59 * time.Second))
+	result := cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, result.HasError())
+	assert.Equal(t, models.RunStatusErrored, result.Status)
+	assert.Contains(t, result.Error(), "circuit open")
+	assert.Equal(t, int32(2), server.callCount())
+}
+
+func TestCircuitBreaker_Perform_HalfOpen(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Now())
+	server := newFlakyServer()
+	defer server.Close()
+	cb := newCircuitBreaker(server.URL)
+
+	server.fail()
+	cb.Perform(cltest.RunResultWithValue("input"), store)
+	cb.Perform(cltest.RunResultWithValue("input"), store)
+
+	clock.SetTime(clock.Now().Add(time.Minute))
+	result := cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, result.HasError())
+	assert.Equal(t, int32(3), server.callCount(), "a probe should be let through")
+
+	result = cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.Contains(t, result.Error(), "circuit open")
+	assert.Equal(t, int32(3), server.callCount(), "a failed probe should keep the circuit open")
+
+	server.recover()
+	clock.SetTime(clock.Now().Add(time.Minute))
+	result = cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.False(t, result.HasError())
+
+	result = cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.False(t, result.HasError())
+	assert.Equal(t, int32(5), server.callCount(), "a successful probe should close the circuit")
+}
+
+func TestCircuitBreaker_Perform_HalfOpenAllowsSingleProbe(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Now())
+
+	var calls int32
+	var failing int32 = 1
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 2 {
+			<-release
+		}
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	cb := newCircuitBreaker(server.URL)
+
+	cb.Perform(cltest.RunResultWithValue("input"), store)
+	cb.Perform(cltest.RunResultWithValue("input"), store)
+	atomic.StoreInt32(&failing, 0)
+	clock.SetTime(clock.Now().Add(time.Minute))
+
+	probed := make(chan models.RunResult)
+	go func() { probed <- cb.Perform(cltest.RunResultWithValue("input"), store) }()
+	gomega.NewGomegaWithT(t).Eventually(func() int32 {
+		return atomic.LoadInt32(&calls)
+	}).Should(gomega.Equal(int32(3)))
+
+	result := cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.Contains(t, result.Error(), "circuit open")
+
+	close(release)
+	assert.False(t, (<-probed).HasError())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestCircuitBreaker_Perform_InvalidThreshold(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	cb := newCircuitBreaker("https://example.com")
+	cb.FailureThreshold = 0
+	result := cb.Perform(cltest.RunResultWithValue("input"), store)
+	assert.True(t, result.HasError())
+}
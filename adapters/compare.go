@@ -0,0 +1,173 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/tidwall/gjson"
+)
+
+// The operators a Compare adapter can apply.
+const (
+	CompareOperatorLT  = "lt"
+	CompareOperatorLTE = "lte"
+	CompareOperatorGT  = "gt"
+	CompareOperatorGTE = "gte"
+	CompareOperatorEQ  = "eq"
+	CompareOperatorNEQ = "neq"
+)
+
+// The actions a Compare adapter can take when the comparison fails.
+const (
+	// CompareOnFailReview holds the run as pending review, until it is
+	// approved or rejected with PATCH /v2/runs/:RunID/review.
+	CompareOnFailReview = "review"
+	// CompareOnFailError errors the run.
+	CompareOnFailError = "error"
+	// CompareOnFailAbort completes the run without running its remaining tasks.
+	CompareOnFailAbort = "abort"
+)
+
+// Comparand is either a decimal number or a string. Numeric strings are
+// treated as numbers, any other string is compared exactly.
+type Comparand struct {
+	number *big.Rat
+	str    string
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a number or a
+// string.
+func (c *Comparand) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err == nil {
+		*c = newComparand(str)
+		return nil
+	}
+
+	number, ok := parseDecimal(string(input))
+	if !ok {
+		return fmt.Errorf("cannot parse into number or string: %s", input)
+	}
+	*c = Comparand{number: number}
+	return nil
+}
+
+func newComparand(str string) Comparand {
+	if number, ok := parseDecimal(str); ok {
+		return Comparand{number: number}
+	}
+	return Comparand{str: str}
+}
+
+func (c Comparand) String() string {
+	if c.number != nil {
+		return formatDecimal(c.number)
+	}
+	return c.str
+}
+
+// Compare checks the input's value against Threshold using Operator.
+//
+// Threshold isn't named "value" as the run's data, including its "value",
+// is merged over the task's params before the adapter is built.
+type Compare struct {
+	Operator  string     `json:"operator"`
+	Threshold *Comparand `json:"threshold"`
+	// OnFail is one of "review", "error" or "abort", defaulting to "review".
+	OnFail string `json:"onFail"`
+	// ErrorOnFail is equivalent to an OnFail of "error".
+	ErrorOnFail bool `json:"errorOnFail"`
+}
+
+// Perform passes the input through unchanged when "value <operator> threshold"
+// holds. Otherwise the run is held as pending review, errored, or aborted
+// depending on OnFail. An aborted run is completed without running any of its
+// remaining tasks.
+//
+// Numbers, including numeric strings, are compared as decimals. Other strings
+// can only be compared with "eq" and "neq", and must match exactly. Comparing
+// a number with a string is an error.
+//
+// For example, with "operator" set to "lt" and "threshold" set to "1000", an
+// input value of "212.54" passes while "2125.4" does not.
+func (ca *Compare) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	if ca.Threshold == nil {
+		return input.WithError(errors.New("compare requires a threshold"))
+	}
+
+	val := input.Get("value")
+	var actual Comparand
+	switch val.Type {
+	case gjson.Number:
+		actual = newComparand(val.Raw)
+	case gjson.String:
+		actual = newComparand(val.Str)
+	default:
+		return input.WithError(fmt.Errorf("cannot compare value %v, must be a number or string", val.Raw))
+	}
+
+	passed, err := compareValues(actual, *ca.Threshold, ca.Operator)
+	if err != nil {
+		return input.WithError(err)
+	}
+	if passed {
+		input.Status = models.RunStatusCompleted
+		return input
+	}
+
+	onFail := ca.OnFail
+	if onFail == "" && ca.ErrorOnFail {
+		onFail = CompareOnFailError
+	}
+	switch onFail {
+	case "", CompareOnFailReview:
+		return input.MarkPendingReview()
+	case CompareOnFailError:
+		return input.WithError(fmt.Errorf("comparison failed: %s %s %s", actual, ca.Operator, ca.Threshold))
+	case CompareOnFailAbort:
+		return input.MarkAborted()
+	default:
+		return input.WithError(fmt.Errorf("onFail must be %q, %q or %q, got %q", CompareOnFailReview, CompareOnFailError, CompareOnFailAbort, onFail))
+	}
+}
+
+func compareValues(actual, threshold Comparand, operator string) (bool, error) {
+	if (actual.number == nil) != (threshold.number == nil) {
+		return false, fmt.Errorf("cannot compare %q with %q, both must be numbers or both strings", actual, threshold)
+	}
+	if actual.number != nil {
+		return compare(actual.number.Cmp(threshold.number), operator)
+	}
+
+	switch operator {
+	case CompareOperatorEQ:
+		return actual.str == threshold.str, nil
+	case CompareOperatorNEQ:
+		return actual.str != threshold.str, nil
+	default:
+		return false, fmt.Errorf("compare operator %q is not supported for strings", operator)
+	}
+}
+
+func compare(cmp int, operator string) (bool, error) {
+	switch operator {
+	case CompareOperatorLT:
+		return cmp < 0, nil
+	case CompareOperatorLTE:
+		return cmp <= 0, nil
+	case CompareOperatorGT:
+		return cmp > 0, nil
+	case CompareOperatorGTE:
+		return cmp >= 0, nil
+	case CompareOperatorEQ:
+		return cmp == 0, nil
+	case CompareOperatorNEQ:
+		return cmp != 0, nil
+	default:
+		return false, fmt.Errorf("unsupported compare operator %q", operator)
+	}
+}
@@ -0,0 +1,87 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare_Perform(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     string
+		input      string
+		wantStatus models.RunStatus
+	}{
+		{"lt below", `{"operator":"lt","threshold":10}`, "9.99", models.RunStatusCompleted},
+		{"lt boundary", `{"operator":"lt","threshold":10}`, "10", models.RunStatusPendingReview},
+		{"lt above", `{"operator":"lt","threshold":10}`, "10.01", models.RunStatusPendingReview},
+		{"lte below", `{"operator":"lte","threshold":10}`, "9.99", models.RunStatusCompleted},
+		{"lte boundary", `{"operator":"lte","threshold":10}`, "10.00", models.RunStatusCompleted},
+		{"lte above", `{"operator":"lte","threshold":10}`, "10.01", models.RunStatusPendingReview},
+		{"gt below", `{"operator":"gt","threshold":"10"}`, "9.99", models.RunStatusPendingReview},
+		{"gt boundary", `{"operator":"gt","threshold":"10"}`, "10", models.RunStatusPendingReview},
+		{"gt above", `{"operator":"gt","threshold":"10"}`, "10.01", models.RunStatusCompleted},
+		{"gte below", `{"operator":"gte","threshold":"10"}`, "9.99", models.RunStatusPendingReview},
+		{"gte boundary", `{"operator":"gte","threshold":"10"}`, "1e1", models.RunStatusCompleted},
+		{"gte above", `{"operator":"gte","threshold":"10"}`, "10.01", models.RunStatusCompleted},
+		{"eq equal", `{"operator":"eq","threshold":0.1}`, "0.10", models.RunStatusCompleted},
+		{"eq not equal", `{"operator":"eq","threshold":0.1}`, "0.100000000000000001", models.RunStatusPendingReview},
+		{"neq equal", `{"operator":"neq","threshold":-5}`, "-5", models.RunStatusPendingReview},
+		{"neq not equal", `{"operator":"neq","threshold":-5}`, "5", models.RunStatusCompleted},
+		{"fail with error", `{"operator":"lt","threshold":10,"errorOnFail":true}`, "11", models.RunStatusErrored},
+		{"pass with error", `{"operator":"lt","threshold":10,"errorOnFail":true}`, "9", models.RunStatusCompleted},
+		{"abort on fail", `{"operator":"lt","threshold":10,"onFail":"abort"}`, "11", models.RunStatusAborted},
+		{"error on fail", `{"operator":"lt","threshold":10,"onFail":"error"}`, "11", models.RunStatusErrored},
+		{"review on fail", `{"operator":"lt","threshold":10,"onFail":"review"}`, "11", models.RunStatusPendingReview},
+		{"onFail overrides errorOnFail", `{"operator":"lt","threshold":10,"onFail":"abort","errorOnFail":true}`, "11", models.RunStatusAborted},
+		{"unknown onFail", `{"operator":"lt","threshold":10,"onFail":"retry"}`, "11", models.RunStatusErrored},
+		{"numeric string threshold", `{"operator":"eq","threshold":"10.0"}`, "10", models.RunStatusCompleted},
+		{"string eq", `{"operator":"eq","threshold":"ETH-USD"}`, "ETH-USD", models.RunStatusCompleted},
+		{"string eq is exact", `{"operator":"eq","threshold":"ETH-USD"}`, "eth-usd", models.RunStatusPendingReview},
+		{"string neq", `{"operator":"neq","threshold":"ETH-USD"}`, "BTC-USD", models.RunStatusCompleted},
+		{"string ordering", `{"operator":"lt","threshold":"ETH-USD"}`, "BTC-USD", models.RunStatusErrored},
+		{"string with number", `{"operator":"eq","threshold":"ETH-USD"}`, "10", models.RunStatusErrored},
+		{"unknown operator", `{"operator":"approx","threshold":10}`, "10", models.RunStatusErrored},
+		{"missing threshold", `{"operator":"eq"}`, "10", models.RunStatusErrored},
+		{"non-numeric input", `{"operator":"eq","threshold":10}`, "ten", models.RunStatusErrored},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.Compare{}
+			assert.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(cltest.RunResultWithValue(test.input), nil)
+
+			assert.Equal(t, test.wantStatus, result.Status)
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.input, val)
+		})
+	}
+}
+
+func TestCompare_Perform_NumericInput(t *testing.T) {
+	adapter := adapters.Compare{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"operator":"gt","threshold":"212.5"}`), &adapter))
+
+	input := models.RunResult{Data: cltest.JSONFromString(`{"value":212.54}`)}
+	result := adapter.Perform(input, nil)
+	assert.Equal(t, models.RunStatusCompleted, result.Status)
+
+	input = models.RunResult{Data: cltest.JSONFromString(`{"value":true}`)}
+	result = adapter.Perform(input, nil)
+	assert.Equal(t, models.RunStatusErrored, result.Status)
+}
+
+func TestCompare_UnmarshalJSON_InvalidThreshold(t *testing.T) {
+	adapter := adapters.Compare{}
+	err := json.Unmarshal([]byte(`{"operator":"eq","threshold":true}`), &adapter)
+	assert.Error(t, err)
+}
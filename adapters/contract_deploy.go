@@ -0,0 +1,106 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// ContractDeploy deploys a contract from its Bytecode and ConstructorArgs,
+// and completes with the contract's address once the transaction deploying
+// it has MIN_OUTGOING_CONFIRMATIONS.
+type ContractDeploy struct {
+	// ABI is the JSON ABI of the contract, which need only contain its
+	// constructor, and may be left out when the constructor takes no
+	// arguments.
+	ABI             abi.ABI           `json:"abi"`
+	Bytecode        hexutil.Bytes     `json:"bytecode"`
+	ConstructorArgs []json.RawMessage `json:"constructorArgs"`
+	// GasLimit overrides the node's default for the transaction when set, up
+	// to ETH_MAX_GAS_LIMIT.
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+}
+
+// UnmarshalJSON validates the params as they're parsed, so that missing
+// bytecode or the wrong number of constructor arguments rejects the job spec
+// when it is created rather than failing its first run.
+func (cd *ContractDeploy) UnmarshalJSON(input []byte) error {
+	type plain ContractDeploy
+	var aux plain
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+	if len(aux.Bytecode) == 0 {
+		return errors.New("ContractDeploy bytecode must be 0x prefixed hex of the contract's creation code")
+	}
+	if want := len(aux.ABI.Constructor.Inputs); len(aux.ConstructorArgs) != want {
+		return fmt.Errorf("ContractDeploy constructor takes %d argument(s), got %d", want, len(aux.ConstructorArgs))
+	}
+	*cd = ContractDeploy(aux)
+	return nil
+}
+
+// Perform sends the transaction deploying the contract if the existing run
+// result is not currently pending, and returns a pending confirmations
+// result. Each later call, made as new heads arrive, completes the run with
+// the contract's address once the transaction has enough confirmations.
+func (cd *ContractDeploy) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	if input.Status.PendingConfirmations() {
+		return ensureDeployRunResult(input, store.TxManager)
+	}
+
+	data, err := cd.creationCode()
+	if err != nil {
+		return input.WithError(models.NewPermanentError(err))
+	}
+	tx, err := store.TxManager.DeployContract(data, cd.GasLimit)
+	if err != nil {
+		return input.WithError(sendTxError(err))
+	}
+	return pendingTxRunResult(tx, input)
+}
+
+// creationCode returns the bytecode followed by the ABI encoded constructor
+// arguments.
+func (cd *ContractDeploy) creationCode() ([]byte, error) {
+	inputs := cd.ABI.Constructor.Inputs
+	if len(cd.ConstructorArgs) != len(inputs) {
+		return nil, fmt.Errorf("constructor takes %d argument(s), got %d", len(inputs), len(cd.ConstructorArgs))
+	}
+	args := make([]interface{}, len(cd.ConstructorArgs))
+	for i, raw := range cd.ConstructorArgs {
+		arg, err := abiInputValue(inputs[i].Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("constructor argument %d: %v", i, err)
+		}
+		args[i] = arg
+	}
+	encoded, err := inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode constructor arguments: %v", err)
+	}
+	return append(append([]byte{}, cd.Bytecode...), encoded...), nil
+}
+
+// ensureDeployRunResult is ensureTxRunResult, with the value set to the
+// deployed contract's address once the transaction is confirmed.
+func ensureDeployRunResult(input models.RunResult, txm store.TxManager) models.RunResult {
+	output, receipt := ensureTxReceipt(input, txm)
+	if receipt == nil || output.HasError() {
+		return output
+	}
+	if receipt.Status != nil && *receipt.Status == 0 {
+		return input.WithError(models.NewPermanentError(
+			fmt.Errorf("transaction %s deploying the contract reverted", receipt.Hash.Hex())))
+	}
+	if receipt.ContractAddress == nil {
+		return input.WithError(models.NewPermanentError(
+			fmt.Errorf("receipt of transaction %s has no contract address", receipt.Hash.Hex())))
+	}
+	return output.WithValue(receipt.ContractAddress.Hex())
+}
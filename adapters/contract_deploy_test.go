@@ -0,0 +1,112 @@
+package adapters_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storageContract stores its constructor argument, and returns it for any
+// call.
+const storageContract = "0x60206023600039600051600055600b8060186000396000f3" + // store the argument after the code, and return the runtime code below
+	"60005460005260206000f3" // return storage slot 0
+
+const storageABI = `[{
+	"type": "constructor",
+	"inputs": [{"name": "value", "type": "uint256"}]
+}, {
+	"name": "value", "type": "function", "constant": true,
+	"inputs": [],
+	"outputs": [{"name": "", "type": "uint256"}]
+}]`
+
+// revertingContract reverts on creation.
+const revertingContract = "0x60006000fd"
+
+func newContractDeploy(t *testing.T, params string) adapters.ContractDeploy {
+	t.Helper()
+	var cd adapters.ContractDeploy
+	require.NoError(t, json.Unmarshal([]byte(params), &cd))
+	return cd
+}
+
+func TestContractDeploy_Perform_SimulatedChain(t *testing.T) {
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	app.MockEthClient().Register("eth_getTransactionCount", `0x0100`)
+	require.NoError(t, app.Start())
+	chain := cltest.NewSimulatedChain(t)
+	cltest.UseSimulatedChain(store, chain)
+
+	confirm := func(t *testing.T, cd adapters.ContractDeploy) models.RunResult {
+		result := cd.Perform(cltest.RunResultWithValue("input"), store)
+		require.NoError(t, result.GetError())
+		require.Equal(t, models.RunStatusPendingConfirmations, result.Status)
+
+		for i := uint64(1); i < store.Config.MinOutgoingConfirmations; i++ {
+			result = cd.Perform(result, store)
+			require.NoError(t, result.GetError())
+			require.Equal(t, models.RunStatusPendingConfirmations, result.Status)
+			chain.Commit()
+		}
+		return cd.Perform(result, store)
+	}
+
+	t.Run("deploys with constructor arguments", func(t *testing.T) {
+		cd := newContractDeploy(t, `{"bytecode": "`+storageContract+`", "abi": `+storageABI+`,
+			"constructorArgs": ["0x2a"]}`)
+		result := confirm(t, cd)
+
+		require.NoError(t, result.GetError())
+		assert.Equal(t, models.RunStatusCompleted, result.Status)
+		address := common.HexToAddress(result.Get("value").String())
+		out, err := chain.CallContract(context.Background(), ethereum.CallMsg{
+			To:   &address,
+			Data: hexutil.MustDecode("0x3fa4f245"), // value()
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(42), new(big.Int).SetBytes(out))
+	})
+
+	t.Run("reverts", func(t *testing.T) {
+		cd := newContractDeploy(t, `{"bytecode": "`+revertingContract+`"}`)
+		result := confirm(t, cd)
+
+		assert.True(t, result.HasError())
+		assert.Contains(t, result.Error(), "reverted")
+	})
+}
+
+func TestContractDeploy_UnmarshalJSON_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"missing bytecode", `{"abi": ` + storageABI + `, "constructorArgs": [1]}`},
+		{"missing constructor argument", `{"bytecode": "` + storageContract + `", "abi": ` + storageABI + `}`},
+		{"extra constructor argument", `{"bytecode": "` + storageContract + `", "abi": ` + storageABI + `, "constructorArgs": [1, 2]}`},
+		{"arguments without constructor", `{"bytecode": "` + revertingContract + `", "constructorArgs": [1]}`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var cd adapters.ContractDeploy
+			assert.Error(t, json.Unmarshal([]byte(test.params), &cd))
+		})
+	}
+}
@@ -1,21 +1,35 @@
 package adapters
 
 import (
+	"encoding/json"
+
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/tidwall/gjson"
 )
 
-// Copy obj keys refers to which value to copy inside `data`,
-// each obj value refers to where to copy the value to inside `data`
+// Copy holds a path to the field inside the run's `data` to copy to the
+// "value" field, using the same path semantics as JSONParse.
 type Copy struct {
 	CopyPath JSONPath `json:"copyPath"`
+	// OnMissing is passed through to JSONParse, and decides what happens
+	// when part of the path does not exist.
+	OnMissing string `json:"onMissing"`
 }
 
-// Perform returns the copied values from the desired mapping within the `data` JSON object
+// Perform returns the value found at CopyPath within the `data` JSON object.
+//
+// When the current value is a string holding a JSON object or array, such as
+// the body returned by HTTPGet, it is parsed first so that a path starting
+// with "value" can reach into it.
 func (c *Copy) Perform(input models.RunResult, store *store.Store) models.RunResult {
-	jp := JSONParse{Path: c.CopyPath}
+	jp := JSONParse{Path: c.CopyPath, OnMissing: c.OnMissing}
 
-	data, err := input.Data.Add("value", input.Data.String())
+	source, err := copySource(input.Data)
+	if err != nil {
+		return input.WithError(err)
+	}
+	data, err := input.Data.Add("value", source.String())
 	if err != nil {
 		return input.WithError(err)
 	}
@@ -23,3 +37,14 @@ func (c *Copy) Perform(input models.RunResult, store *store.Store) models.RunRes
 
 	return jp.Perform(input, store)
 }
+
+func copySource(data models.JSON) (models.JSON, error) {
+	value := data.Get("value")
+	if value.Type != gjson.String || !gjson.Valid(value.Str) {
+		return data, nil
+	}
+	if parsed := gjson.Parse(value.Str); !parsed.IsObject() && !parsed.IsArray() {
+		return data, nil
+	}
+	return data.Add("value", json.RawMessage(value.Str))
+}
@@ -2,6 +2,7 @@ package adapters_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"log"
@@ -59,6 +60,44 @@ func TestCopy_Perform(t *testing.T) {
 	}
 }
 
+func TestCopy_Perform_OnMissing(t *testing.T) {
+	t.Parallel()
+	input := cltest.RunResultWithData(`{"high":"11850.00","last":"11779.99"}`)
+
+	strict := adapters.Copy{CopyPath: []string{"doesnotexist"}, OnMissing: adapters.JSONParseOnMissingError}
+	result := strict.Perform(input, nil)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "doesnotexist")
+
+	lax := adapters.Copy{CopyPath: []string{"no", "really"}, OnMissing: adapters.JSONParseOnMissingNull}
+	result = lax.Perform(input, nil)
+	assert.NoError(t, result.GetError())
+	assert.Equal(t, `{"high":"11850.00","last":"11779.99","value":null}`, result.Data.String())
+}
+
+func TestCopy_Perform_ChainedAfterHTTPGetIntoEthUint256(t *testing.T) {
+	t.Parallel()
+	mock, cleanup := cltest.NewHTTPMockServer(t, 200, "GET", `{"data":{"tickers":[{"last":"123.99"}]}}`)
+	defer cleanup()
+
+	params := fmt.Sprintf(`{"get":"%s"}`, mock.URL)
+	var hga adapters.HTTPGet
+	assert.NoError(t, json.Unmarshal([]byte(params), &hga))
+	var copier adapters.Copy
+	assert.NoError(t, json.Unmarshal([]byte(`{"copyPath":"value.data.tickers.0.last"}`), &copier))
+
+	input := cltest.RunResultWithData(`{"symbol":"ETH"}`)
+	result := hga.Perform(input, nil)
+	result = copier.Perform(result, nil)
+	assert.NoError(t, result.GetError())
+	assert.Equal(t, "123.99", result.Get("value").String())
+	assert.Equal(t, "ETH", result.Get("symbol").String())
+
+	result = (&adapters.EthUint256{}).Perform(result, nil)
+	assert.NoError(t, result.GetError())
+	assert.Equal(t, "0x000000000000000000000000000000000000000000000000000000000000007b", result.Get("value").String())
+}
+
 func TestCopy_UnmarshalJSON(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,136 @@
+package adapters
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// CSVParse extracts a single cell from a CSV document.
+type CSVParse struct {
+	// Data is the CSV document to parse. When empty, the input's value is used.
+	Data   string    `json:"data"`
+	Column CSVColumn `json:"column"`
+	// Row is the 0 based index of the row, not counting the header. Negative
+	// indexes count back from the last row.
+	Row       int    `json:"row"`
+	HasHeader bool   `json:"hasHeader"`
+	Delimiter string `json:"delimiter"`
+	// Type is one of "string", "number" or "bool", defaulting to "string".
+	Type string `json:"resultType"`
+}
+
+// Perform returns the cell at Row and Column, coerced to Type, as the "value"
+// field of the result.
+//
+// For example, if the CSV data looks like this:
+//   symbol,last
+//   ETH,212.54
+//   BTC,6481.20
+//
+// With HasHeader set, Row 1 and Column "last" would return "6481.20", as
+// would Row -1 and Column 1.
+func (cpa *CSVParse) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	data := cpa.Data
+	if data == "" {
+		val, err := input.Value()
+		if err != nil {
+			return input.WithError(err)
+		}
+		data = val
+	}
+
+	records, err := cpa.parse(data)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	var header []string
+	if cpa.HasHeader {
+		if len(records) == 0 {
+			return input.WithError(errors.New("CSV data has no header row"))
+		}
+		header, records = records[0], records[1:]
+	}
+
+	row, err := cpa.row(records)
+	if err != nil {
+		return input.WithError(err)
+	}
+	index, err := cpa.Column.index(header)
+	if err != nil {
+		return input.WithError(err)
+	}
+	if index >= len(row) {
+		return input.WithError(fmt.Errorf("CSV column %d is out of range, row %d has %d columns", index, cpa.Row, len(row)))
+	}
+
+	val, err := coerceValue(row[index], cpa.Type)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(val)
+}
+
+func (cpa *CSVParse) parse(data string) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	if cpa.Delimiter != "" {
+		delimiter, size := utf8.DecodeRuneInString(cpa.Delimiter)
+		if size != len(cpa.Delimiter) {
+			return nil, fmt.Errorf("CSV delimiter must be a single character, got %q", cpa.Delimiter)
+		}
+		reader.Comma = delimiter
+	}
+	return reader.ReadAll()
+}
+
+func (cpa *CSVParse) row(records [][]string) ([]string, error) {
+	index := cpa.Row
+	if index < 0 {
+		index = len(records) + index
+	}
+	if index < 0 || index >= len(records) {
+		return nil, fmt.Errorf("CSV row %d is out of range, there are %d rows", cpa.Row, len(records))
+	}
+	return records[index], nil
+}
+
+// CSVColumn selects a column either by its 0 based index or, when the CSV
+// has a header row, by its name.
+type CSVColumn struct {
+	Index int
+	Name  string
+}
+
+// UnmarshalJSON accepts either a number or a string.
+func (c *CSVColumn) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &c.Index); err == nil {
+		return nil
+	}
+	return json.Unmarshal(b, &c.Name)
+}
+
+func (c CSVColumn) index(header []string) (int, error) {
+	if c.Name == "" {
+		if c.Index < 0 {
+			return 0, fmt.Errorf("CSV column %d must not be negative", c.Index)
+		}
+		return c.Index, nil
+	}
+	if header == nil {
+		return 0, fmt.Errorf("CSV column %q can only be selected by name when hasHeader is set", c.Name)
+	}
+	for i, name := range header {
+		if strings.TrimSpace(name) == c.Name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("CSV column %q not found in header", c.Name)
+}
@@ -0,0 +1,103 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const pricesCSV = `symbol,last,volume,halted
+ETH,212.54,1000,false
+BTC,6481.20,250,true
+"LINK, Chainlink","0.42","1,500,000",false`
+
+func TestCSVParse_Perform(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		hasHeader   bool
+		delimiter   string
+		row         int
+		column      adapters.CSVColumn
+		resultType  string
+		want        string
+		wantErrored bool
+	}{
+		{"first row by index", pricesCSV, true, "", 0, adapters.CSVColumn{Index: 1}, "", "212.54", false},
+		{"later row by name", pricesCSV, true, "", 1, adapters.CSVColumn{Name: "last"}, "", "6481.20", false},
+		{"negative row", pricesCSV, true, "", -1, adapters.CSVColumn{Index: 0}, "", "LINK, Chainlink", false},
+		{"quoted field", pricesCSV, true, "", 2, adapters.CSVColumn{Name: "volume"}, "", "1,500,000", false},
+		{"coerced to number", pricesCSV, true, "", 1, adapters.CSVColumn{Name: "last"}, "number", "6481.2", false},
+		{"coerced to bool", pricesCSV, true, "", 1, adapters.CSVColumn{Name: "halted"}, "bool", "true", false},
+		{"without header", pricesCSV, false, "", 0, adapters.CSVColumn{Index: 1}, "", "last", false},
+		{"custom delimiter", "a;b\n1;2", false, ";", 1, adapters.CSVColumn{Index: 1}, "", "2", false},
+		{"row out of range", pricesCSV, true, "", 3, adapters.CSVColumn{Index: 0}, "", "inputValue", true},
+		{"negative row out of range", pricesCSV, true, "", -4, adapters.CSVColumn{Index: 0}, "", "inputValue", true},
+		{"column out of range", pricesCSV, true, "", 0, adapters.CSVColumn{Index: 4}, "", "inputValue", true},
+		{"negative column", pricesCSV, true, "", 0, adapters.CSVColumn{Index: -1}, "", "inputValue", true},
+		{"unknown column name", pricesCSV, true, "", 0, adapters.CSVColumn{Name: "bid"}, "", "inputValue", true},
+		{"column name without header", pricesCSV, false, "", 0, adapters.CSVColumn{Name: "last"}, "", "inputValue", true},
+		{"not a number", pricesCSV, true, "", 0, adapters.CSVColumn{Name: "symbol"}, "number", "inputValue", true},
+		{"empty data with header", "", true, "", 0, adapters.CSVColumn{Index: 0}, "", "inputValue", true},
+		{"malformed quotes", "a,\"b\nc", false, "", 0, adapters.CSVColumn{Index: 0}, "", "inputValue", true},
+		{"multi character delimiter", pricesCSV, true, "::", 0, adapters.CSVColumn{Index: 0}, "", "inputValue", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.CSVParse{
+				Data:      test.csv,
+				Column:    test.column,
+				Row:       test.row,
+				HasHeader: test.hasHeader,
+				Delimiter: test.delimiter,
+				Type:      test.resultType,
+			}
+			result := adapter.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestCSVParse_Perform_FromInputValue(t *testing.T) {
+	adapter := adapters.CSVParse{HasHeader: true, Row: -1, Column: adapters.CSVColumn{Name: "symbol"}}
+	result := adapter.Perform(cltest.RunResultWithValue(pricesCSV), nil)
+
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "LINK, Chainlink", val)
+	assert.False(t, result.HasError())
+}
+
+func TestCSVColumn_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    adapters.CSVColumn
+		wantErr bool
+	}{
+		{"index", `2`, adapters.CSVColumn{Index: 2}, false},
+		{"name", `"last"`, adapters.CSVColumn{Name: "last"}, false},
+		{"invalid", `{}`, adapters.CSVColumn{}, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var c adapters.CSVColumn
+			err := json.Unmarshal([]byte(test.input), &c)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.want, c)
+		})
+	}
+}
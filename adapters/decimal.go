@@ -0,0 +1,145 @@
+package adapters
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/tidwall/gjson"
+)
+
+var decimalRegexp = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d{1,3})?$`)
+
+// parseDecimal parses a decimal number, optionally in scientific notation,
+// without any loss of precision.
+func parseDecimal(s string) (*big.Rat, bool) {
+	if !decimalRegexp.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// formatDecimal renders r as a plain decimal string, never using an exponent.
+func formatDecimal(r *big.Rat) string {
+	return r.FloatString(decimalPlaces(r))
+}
+
+// formatDecimalWithPrecision renders r rounded to precision decimal places,
+// or exactly when precision is nil.
+func formatDecimalWithPrecision(r *big.Rat, precision *int) (string, error) {
+	if precision == nil {
+		return formatDecimal(r), nil
+	}
+	if *precision < 0 {
+		return "", fmt.Errorf("precision must not be negative, got %d", *precision)
+	}
+	return r.FloatString(*precision), nil
+}
+
+// formatSignificant renders r as a plain decimal string rounded to digits
+// significant digits, without trailing zeros after the decimal point.
+func formatSignificant(r *big.Rat, digits int) string {
+	if r.Sign() == 0 {
+		return "0"
+	}
+	places := digits - 1 - decimalExponent(r)
+	if places >= 0 {
+		s := r.FloatString(places)
+		if places > 0 {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+		return s
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-places)), nil)
+	rounded, _ := new(big.Int).SetString(new(big.Rat).Quo(r, new(big.Rat).SetInt(scale)).FloatString(0), 10)
+	return rounded.Mul(rounded, scale).String()
+}
+
+// decimalExponent returns the power of ten of r's most significant digit,
+// so 0 for 5.2, 2 for 123 and -3 for 0.004.
+func decimalExponent(r *big.Rat) int {
+	abs := new(big.Rat).Abs(r)
+	intPart := new(big.Int).Quo(abs.Num(), abs.Denom())
+	if intPart.Sign() > 0 {
+		return len(intPart.String()) - 1
+	}
+	exp := 0
+	ten := big.NewRat(10, 1)
+	for abs.Cmp(big.NewRat(1, 1)) < 0 {
+		abs.Mul(abs, ten)
+		exp--
+	}
+	return exp
+}
+
+// decimalsFromArray parses a JSON array whose elements are numbers or
+// numeric strings.
+func decimalsFromArray(val gjson.Result) ([]*big.Rat, error) {
+	if !val.IsArray() {
+		return nil, fmt.Errorf("expected an array of numbers, got %v", val.String())
+	}
+
+	values := []*big.Rat{}
+	for _, elem := range val.Array() {
+		var raw string
+		switch elem.Type {
+		case gjson.Number:
+			raw = elem.Raw
+		case gjson.String:
+			raw = elem.Str
+		default:
+			return nil, fmt.Errorf("expected an array of numbers, got non-numeric value %v", elem.Raw)
+		}
+		r, ok := parseDecimal(raw)
+		if !ok {
+			return nil, fmt.Errorf("expected an array of numbers, got non-numeric value %v", elem.Raw)
+		}
+		values = append(values, r)
+	}
+	return values, nil
+}
+
+// decimalPlaces returns the number of decimal places needed to represent r
+// exactly. Rationals with no terminating decimal representation are limited
+// to 18 places.
+func decimalPlaces(r *big.Rat) int {
+	one, ten := big.NewInt(1), big.NewInt(10)
+	denom := new(big.Int).Set(r.Denom())
+	places := 0
+	for denom.Cmp(one) != 0 {
+		gcd := new(big.Int).GCD(nil, nil, denom, ten)
+		if gcd.Cmp(one) == 0 {
+			return 18
+		}
+		denom.Quo(denom, gcd)
+		places++
+	}
+	return places
+}
+
+// Multiplier represents the number to multiply by in Multiply adapter, in
+// both its native and SGX builds.
+type Multiplier big.Rat
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a number or a
+// string containing a decimal number.
+func (m *Multiplier) UnmarshalJSON(input []byte) error {
+	input = utils.RemoveQuotes(input)
+	times, ok := parseDecimal(string(input))
+	if !ok {
+		return fmt.Errorf("cannot parse into decimal: %s", input)
+	}
+
+	*m = Multiplier(*times)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Multiplier) MarshalJSON() ([]byte, error) {
+	r := big.Rat(m)
+	return []byte(formatDecimal(&r)), nil
+}
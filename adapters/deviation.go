@@ -0,0 +1,105 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// Deviation continues a run only when the input's value has moved far enough
+// from the value it passed on in the job's last completed run.
+type Deviation struct {
+	// Threshold is the smallest change, as a percentage of the previous
+	// value, which lets the run continue.
+	Threshold *Comparand `json:"threshold"`
+}
+
+// Perform passes the input through unchanged when its value differs from
+// the one this task passed on in the job's last completed run by at least
+// Threshold percent, or when there is no such run. Otherwise the run is
+// aborted, completing without running its remaining tasks, such as an
+// EthTx, with the reason in the result's "reason".
+//
+// For example, with "threshold" set to "0.5", an input value of "100.4"
+// after a previous value of "100" ends the run, while "100.5" continues it.
+//
+// Aborted runs are not completed runs, so a price drifting slowly is still
+// compared against the last value submitted.
+func (da *Deviation) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	if da.Threshold == nil || da.Threshold.number == nil || da.Threshold.number.Sign() < 0 {
+		return input.WithError(errors.New("deviation requires a threshold, a percentage of at least 0"))
+	}
+	current, ok := parseDecimal(input.Get("value").String())
+	if !ok {
+		return input.WithError(fmt.Errorf("cannot parse into decimal: %v", input.Get("value").String()))
+	}
+
+	previous, err := previousDeviationValue(input.JobRunID, str)
+	if err != nil {
+		return input.WithError(err)
+	}
+	if previous == nil {
+		input.Status = models.RunStatusCompleted
+		return input
+	}
+
+	change := deviationPercent(previous, current)
+	if change == nil || change.Cmp(da.Threshold.number) >= 0 {
+		input.Status = models.RunStatusCompleted
+		return input
+	}
+	reason := fmt.Sprintf("value %s changed %s%% from %s, under the threshold of %s%%",
+		formatDecimal(current), formatSignificant(change, 6), formatDecimal(previous), da.Threshold)
+	return input.Add("reason", reason).MarkAborted()
+}
+
+// previousDeviationValue returns the value the Deviation task at the same
+// position in the job run passed on in the job's last completed run, or nil
+// when the job has never completed a run.
+func previousDeviationValue(jobRunID string, str *store.Store) (*big.Rat, error) {
+	run, err := str.FindJobRun(jobRunID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find job run %s: %v", jobRunID, err)
+	}
+	index, ok := run.NextTaskRunIndex()
+	if !ok {
+		return nil, fmt.Errorf("job run %s has no task left to run", jobRunID)
+	}
+	runs, err := str.JobRunsFor(run.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first
+	for _, previous := range runs {
+		if previous.ID == run.ID || !previous.Status.Completed() || index >= len(previous.TaskRuns) {
+			continue
+		}
+		tr := previous.TaskRuns[index]
+		if tr.Task.Type != TaskTypeDeviation {
+			continue
+		}
+		value, ok := parseDecimal(tr.Result.Get("value").String())
+		if !ok {
+			return nil, fmt.Errorf("cannot parse previous value into decimal: %v", tr.Result.Get("value").String())
+		}
+		return value, nil
+	}
+	return nil, nil
+}
+
+// deviationPercent returns how far current is from previous, as a
+// percentage of previous. Any change from 0 is unbounded, so is nil.
+func deviationPercent(previous, current *big.Rat) *big.Rat {
+	diff := new(big.Rat).Sub(current, previous)
+	if diff.Sign() == 0 {
+		return new(big.Rat)
+	} else if previous.Sign() == 0 {
+		return nil
+	}
+	change := diff.Abs(diff).Quo(diff, new(big.Rat).Abs(previous))
+	return change.Mul(change, big.NewRat(100, 1))
+}
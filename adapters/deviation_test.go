@@ -0,0 +1,144 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviation_Perform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		previous   []string
+		threshold  string
+		input      string
+		wantStatus models.RunStatus
+	}{
+		{"first run", nil, `"0.5"`, "100.1", models.RunStatusCompleted},
+		{"under threshold", []string{"100"}, `"0.5"`, "100.4", models.RunStatusAborted},
+		{"under threshold falling", []string{"100"}, `"0.5"`, "99.6", models.RunStatusAborted},
+		{"at threshold", []string{"100"}, `"0.5"`, "100.5", models.RunStatusCompleted},
+		{"over threshold falling", []string{"100"}, `"0.5"`, "99", models.RunStatusCompleted},
+		{"numeric threshold", []string{"100"}, `1`, "100.9", models.RunStatusAborted},
+		{"latest completed run", []string{"90", "100"}, `"0.5"`, "100.2", models.RunStatusAborted},
+		{"from zero", []string{"0"}, `"0.5"`, "0.0001", models.RunStatusCompleted},
+		{"zero unchanged", []string{"0"}, `"0.5"`, "0", models.RunStatusAborted},
+		{"zero threshold", []string{"100"}, `"0"`, "100", models.RunStatusCompleted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore()
+			defer cleanup()
+
+			params := `{"threshold":` + test.threshold + `}`
+			j, initr := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{cltest.NewTask("deviation", params), cltest.NewTask("noop")}
+			require.NoError(t, store.SaveJob(&j))
+			for i, value := range test.previous {
+				jr := j.NewRun(initr)
+				jr.CreatedAt = time.Now().Add(time.Duration(i-len(test.previous)) * time.Minute)
+				jr.Status = models.RunStatusCompleted
+				jr.TaskRuns[0].Status = models.RunStatusCompleted
+				jr.TaskRuns[0].Result = cltest.RunResultWithValue(value)
+				require.NoError(t, store.Save(&jr))
+			}
+			jr := j.NewRun(initr)
+			require.NoError(t, store.Save(&jr))
+
+			adapter := adapters.Deviation{}
+			require.NoError(t, json.Unmarshal([]byte(params), &adapter))
+			input := cltest.RunResultWithValue(test.input)
+			input.JobRunID = jr.ID
+			result := adapter.Perform(input, store)
+
+			require.False(t, result.HasError(), result.Error())
+			assert.Equal(t, test.wantStatus, result.Status)
+			assert.Equal(t, test.input, result.Get("value").String())
+			if test.wantStatus == models.RunStatusAborted {
+				assert.Contains(t, result.Get("reason").String(), "under the threshold of")
+			} else {
+				assert.False(t, result.Get("reason").Exists())
+			}
+		})
+	}
+}
+
+func TestDeviation_Perform_SkipsUncompletedRuns(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("deviation", `{"threshold":"0.5"}`)}
+	require.NoError(t, store.SaveJob(&j))
+	for i, previous := range []struct {
+		value  string
+		status models.RunStatus
+	}{
+		{"100", models.RunStatusCompleted},
+		{"100.4", models.RunStatusAborted},
+		{"200", models.RunStatusErrored},
+	} {
+		jr := j.NewRun(initr)
+		jr.CreatedAt = time.Now().Add(time.Duration(i-3) * time.Minute)
+		jr.Status = previous.status
+		jr.TaskRuns[0].Status = previous.status
+		jr.TaskRuns[0].Result = cltest.RunResultWithValue(previous.value)
+		require.NoError(t, store.Save(&jr))
+	}
+	jr := j.NewRun(initr)
+	require.NoError(t, store.Save(&jr))
+
+	input := cltest.RunResultWithValue("100.45")
+	input.JobRunID = jr.ID
+	threshold := adapters.Comparand{}
+	require.NoError(t, json.Unmarshal([]byte(`"0.5"`), &threshold))
+	result := (&adapters.Deviation{Threshold: &threshold}).Perform(input, store)
+
+	assert.Equal(t, models.RunStatusAborted, result.Status)
+	assert.Equal(t, "value 100.45 changed 0.45% from 100, under the threshold of 0.5%", result.Get("reason").String())
+}
+
+func TestDeviation_Perform_Errors(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("deviation", `{"threshold":"0.5"}`)}
+	require.NoError(t, store.SaveJob(&j))
+	jr := j.NewRun(initr)
+	require.NoError(t, store.Save(&jr))
+
+	tests := []struct {
+		name      string
+		threshold string
+		input     string
+		jobRunID  string
+	}{
+		{"no threshold", `null`, "100", jr.ID},
+		{"non-numeric threshold", `"half"`, "100", jr.ID},
+		{"negative threshold", `"-1"`, "100", jr.ID},
+		{"non-numeric value", `"0.5"`, "abc", jr.ID},
+		{"unknown run", `"0.5"`, "100", "idonotexist"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Deviation{}
+			require.NoError(t, json.Unmarshal([]byte(`{"threshold":`+test.threshold+`}`), &adapter))
+			input := cltest.RunResultWithValue(test.input)
+			input.JobRunID = test.jobRunID
+			assert.True(t, adapter.Perform(input, store).HasError())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// ErrorDivisionByZero is returned by the Divide adapter when its divisor is zero.
+var ErrorDivisionByZero = errors.New("division by zero")
+
+// Divisor represents the number to divide by in Divide adapter.
+type Divisor big.Rat
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a number or a
+// string containing a decimal number.
+func (d *Divisor) UnmarshalJSON(input []byte) error {
+	input = utils.RemoveQuotes(input)
+	divisor, ok := parseDecimal(string(input))
+	if !ok {
+		return fmt.Errorf("cannot parse into decimal: %s", input)
+	}
+
+	*d = Divisor(*divisor)
+
+	return nil
+}
+
+// Divide holds a number to divide the given value by, and optionally the
+// number of decimal places to round the result to.
+type Divide struct {
+	Divisor   *Divisor `json:"divisor"`
+	Precision *int     `json:"precision"`
+}
+
+// Perform returns the input's "value" field, divided by the adapter's
+// "divisor" field.
+//
+// For example, if input value is "9999.4" and the adapter's "divisor" is
+// set to "100", the result's value will be "99.994". With "precision" set to
+// 2 the result's value would be "99.99". Without a precision, results which
+// can't be written exactly are rounded to 18 decimal places.
+func (da *Divide) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val := input.Get("value")
+	i, ok := parseDecimal(val.String())
+	if !ok {
+		return input.WithError(fmt.Errorf("cannot parse into decimal: %v", val.String()))
+	}
+
+	if da.Divisor == nil {
+		return input.WithError(errors.New("divide requires a divisor"))
+	}
+	divisor := big.Rat(*da.Divisor)
+	if divisor.Sign() == 0 {
+		return input.WithError(ErrorDivisionByZero)
+	}
+
+	res, err := formatDecimalWithPrecision(i.Quo(i, &divisor), da.Precision)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(res)
+}
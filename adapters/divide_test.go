@@ -0,0 +1,79 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivide_Perform(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    string
+		json      string
+		want      string
+		errored   bool
+		jsonError bool
+	}{
+		{"string", `{"divisor":100}`, `{"value":"9999.4"}`, "99.994", false, false},
+		{"integer", `{"divisor":4}`, `{"value":10}`, "2.5", false, false},
+		{"float", `{"divisor":0.01}`, `{"value":1.23}`, "123", false, false},
+		{"negative", `{"divisor":-4}`, `{"value":"123"}`, "-30.75", false, false},
+		{"string divisor", `{"divisor":"100"}`, `{"value":"9999.4"}`, "99.994", false, false},
+		{"precision", `{"divisor":3,"precision":4}`, `{"value":"1"}`, "0.3333", false, false},
+		{"zero precision", `{"divisor":4,"precision":0}`, `{"value":"123"}`, "31", false, false},
+		{"padded precision", `{"divisor":4,"precision":3}`, `{"value":"10"}`, "2.500", false, false},
+		{"repeating decimal", `{"divisor":3}`, `{"value":"1"}`, "0.333333333333333333", false, false},
+		{"reciprocal", `{"divisor":"212.5"}`, `{"value":"1"}`, "0.004705882352941176", false, false},
+		{"wei amount", `{"divisor":"1e18"}`, `{"value":"3405678900000000000001"}`, "3405.678900000000000001", false, false},
+		{"exponent divisor", `{"divisor":1e-2}`, `{"value":"1.23"}`, "123", false, false},
+		{"negative precision", `{"divisor":4,"precision":-1}`, `{"value":"10"}`, "", true, false},
+		{"rubbish value", `{"divisor":100}`, `{"value":"1.23aaa"}`, "", true, false},
+		{"object", `{"divisor":100}`, `{"value":{"foo":"bar"}}`, "", true, false},
+		{"missing divisor", `{}`, `{"value":"1.23"}`, "", true, false},
+		{"zero", `{"divisor":0}`, `{"value":"1.23"}`, "", true, false},
+		{"array divisor", `{"divisor":[1, 2, 3]}`, `{"value":"1.23"}`, "", false, true},
+		{"rubbish divisor", `{"divisor":"123aaa123"}`, `{"value":"1.23"}`, "", false, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			input := models.RunResult{
+				Data: cltest.JSONFromString(test.json),
+			}
+			adapter := adapters.Divide{}
+			jsonErr := json.Unmarshal([]byte(test.params), &adapter)
+
+			if test.jsonError {
+				assert.Error(t, jsonErr)
+				return
+			}
+			assert.NoError(t, jsonErr)
+
+			result := adapter.Perform(input, nil)
+			if test.errored {
+				assert.Error(t, result.GetError())
+			} else {
+				val, err := result.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, val)
+				assert.NoError(t, result.GetError())
+			}
+		})
+	}
+}
+
+func TestDivide_Perform_DivisionByZero(t *testing.T) {
+	adapter := adapters.Divide{}
+	err := json.Unmarshal([]byte(`{"divisor": "0"}`), &adapter)
+	assert.NoError(t, err)
+
+	result := adapter.Perform(cltest.RunResultWithValue("1.23"), nil)
+	assert.Equal(t, adapters.ErrorDivisionByZero.Error(), result.Error())
+}
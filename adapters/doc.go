@@ -1,58 +1,434 @@
 // Package adapters contain the core adapters used by the Chainlink node.
 //
+// Each adapter is registered under its task type in an init function, and
+// other packages can add their own with Register. Task types with no
+// registered adapter are looked up as bridges.
+//  adapters.Register("myadapter", func() adapters.BaseAdapter { return &MyAdapter{} })
+//
+// New jobs are rejected if a task has params its adapter has no field for,
+// unless ALLOW_UNKNOWN_TASK_PARAMS is set. See CheckParams.
+//
+// They are also rejected if a task can never accept the value the task
+// before it produces, such as a Multiply's number going straight to an EthTx
+// which expects an encoded word. Adapters declare what they accept and
+// produce with TypedAdapter; bridges and adapters which do not are taken to
+// handle any value.
+//
+// The HTTPGet, HTTPPost, JSONParse, Multiply, EthBool, EthBytes32,
+// EthInt256 and EthUint256 adapters write their output to "value", or to
+// the key named by "resultKey", which may not contain dots. The other keys
+// of the run's data are kept, so a later EthTx can send several of them.
+//  { "type": "EthUint256", "resultKey": "price" }
+//
 // HTTPGet
 //
 // The HTTPGet adapter is used to grab the JSON data from the given URL.
 //  { "type": "HTTPGet", "url": "https://some-api-example.net/api" }
 //
+// With a "cacheTTL", the response is kept in memory for that long, and
+// identical requests, with the same URL and credential, reuse it instead of
+// going to the network, setting "cached" to true. Failed requests are never
+// cached.
+//  { "type": "HTTPGet", "url": "https://some-api-example.net/api", "cacheTTL": "30s" }
+//
+// Redirects are followed, up to "maxRedirects" of them (10 by default), and
+// the URL the response finally came from is set as "finalURL". For signed
+// URLs which must not be requested anywhere else, "followRedirects": false
+// fails the task on a redirect instead, with an error naming its Location.
+//  { "type": "HTTPGet", "url": "https://some-api-example.net/api", "followRedirects": false }
+//
 // HTTPPost
 //
 // Sends a POST request to the specified URL and will return the response.
 //  { "type": "HTTPPost", "url": "https://weiwatchers.com/api" }
 //
+// Both HTTP adapters accept an "auth" param naming a credential stored
+// through /v2/http_credentials, which is sent as the Authorization header.
+//  { "type": "HTTPGet", "url": "https://some-api-example.net/api", "auth": "example" }
+//
+// HTTPGetAggregate
+//
+// The HTTPGetAggregate adapter GETs several URLs at once and returns the
+// median of the number at "path" in each response. By default a majority of
+// the sources must succeed, which can be changed with "quorum".
+//   {
+//     "type": "HTTPGetAggregate",
+//     "urls": ["https://one.example.com/eth", "https://two.example.com/eth", "https://three.example.com/eth"],
+//     "path": ["data", "price"],
+//     "quorum": 2
+//   }
+//
+// GRPC
+//
+// The GRPC adapter makes a unary call to a gRPC service with reflection enabled,
+// returning the response message as JSON.
+//   {
+//     "type": "GRPC",
+//     "endpoint": "localhost:50051",
+//     "service": "prices.v1.Prices",
+//     "method": "Latest",
+//     "requestJSON": {"pair": "ETH-USD"}
+//   }
+//
+// IPFS
+//
+// The IPFS adapter fetches the content for a cid from an IPFS gateway, or adds
+// data and returns its cid.
+//   {
+//     "type": "IPFS",
+//     "operation": "get",
+//     "gateway": "https://ipfs.io",
+//     "cid": "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"
+//   }
+//
+// Copy
+//
+// The Copy adapter copies the field at "copyPath" in the run's data to the
+// value, following the same path rules and "onMissing" options as JSONParse.
+// A value holding a JSON body, as returned by HTTPGet, can be reached into.
+//  { "type": "Copy", "copyPath": ["value", "data", "0", "last"] }
+//
 // JSONParse
 //
 // The JSONParse adapter will obtain the value(s) for the given field(s).
 //  { "type": "JSONParse", "path": ["someField"] }
+// Set "onMissing" to "error" or "null" to choose what happens when any part
+// of the path does not exist.
+//  { "type": "JSONParse", "path": ["data", "0", "last"], "onMissing": "error" }
+// Alternatively "jsonPath" takes a gjson path, which supports wildcards,
+// queries and modifiers. Dots that are part of a key must be escaped with a
+// backslash, which itself needs escaping in JSON.
+//  { "type": "JSONParse", "jsonPath": "tickers.#(pair==\"ETH-USD\").last" }
+//  { "type": "JSONParse", "jsonPath": "rates.eth\\.usd" }
+// The path starts at the top of the JSON, which may be an array, as in
+// [[1546300800, "3.51"], [1546387200, "3.62"]], or a single number or string.
+//  { "type": "JSONParse", "path": ["-1", "1"] }
+//
+// XMLParse
+//
+// The XMLParse adapter evaluates an XPath expression against an XML document,
+// optionally coercing the result to a "number" or "bool".
+//  { "type": "XMLParse", "xpath": "/ticker/last", "resultType": "number" }
+//
+// CSVParse
+//
+// The CSVParse adapter selects a single cell from CSV data by row and column.
+// Columns may be referred to by name when the data has a header row.
+//  { "type": "CSVParse", "hasHeader": true, "row": -1, "column": "last", "resultType": "number" }
 //
 // EthBool
 //
 // The EthBool adapter will take the given values and format them for
-// the Ethereum blockhain in boolean value.
+// the Ethereum blockhain in boolean value. Non-zero numbers, non-empty
+// strings and true are true; objects and arrays are rejected.
 //  { "type": "EthBool" }
 //
 // EthBytes32
 //
 // The EthBytes32 adapter will take the given values and format them for
-// the Ethereum blockhain.
-//  { "type": "EthBytes32" }
+// the Ethereum blockhain. Values with a 0x prefix are decoded as hex, and
+// values longer than 32 bytes error unless "truncate" is set.
+//  { "type": "EthBytes32", "truncate": true }
 //
 // EthInt256
 //
 // The EthInt256 adapter will take a given signed 256 bit integer and format
-// it to hex for the Ethereum blockchain.
+// it to hex for the Ethereum blockchain. The value can be a number, or a
+// decimal or 0x prefixed hex string.
 //   { "type": "EthInt256" }
 //
 // EthUint256
 //
 // The EthUint256 adapter will take a given 256 bit integer and format it
-// in hex for the Ethereum blockchain.
+// in hex for the Ethereum blockchain, erroring on negative values.
 //  { "type": "EthUint256" }
 //
+// EthCall
+//
+// The EthCall adapter calls a function of a contract, without sending a
+// transaction, and returns the output at "outputIndex". "abi" need only
+// describe the function being called, and "blockNumber" reads state from an
+// earlier block.
+//   { "type": "EthCall", "contract": "0x514910771AF9Ca656af840dff83E8264EcF986CA",
+//     "method": "balanceOf", "params": ["0x9CA9d2D5E04012C9Ed24C0e513C9bfAa4A2dD77f"],
+//     "abi": [{ "name": "balanceOf", "type": "function", "constant": true,
+//               "inputs": [{ "name": "owner", "type": "address" }],
+//               "outputs": [{ "name": "balance", "type": "uint256" }] }] }
+//
 // EthTx
 //
 // The EthTx adapter will write the data to the given address and functionSelector.
+// With "format" set to "bytes" the value is sent as a bytes argument, either
+// its text or, for a 0x prefixed hex value, the bytes it encodes.
 //   {
 //     "type": "EthTx",
 //     "address": "0x0000000000000000000000000000000000000000",
 //     "functionSelector": "0xffffffff"
 //   }
 //
+// With "dataKeys" the value at each of those keys is sent as a 32 byte
+// argument instead, in the order given.
+//   {
+//     "type": "EthTx",
+//     "address": "0x0000000000000000000000000000000000000000",
+//     "functionSelector": "0xffffffff",
+//     "dataKeys": ["price", "timestamp"]
+//   }
+//
+// With "chainId" the transaction is sent on that chain instead of the one at
+// ETH_URL, which the node must be configured for in its CHAINS tables.
+//
+// "gasPrice", in wei as a number or decimal string, and "gasLimit" replace
+// the node's defaults for the transaction. Values over ETH_MAX_GAS_PRICE_WEI
+// or ETH_MAX_GAS_LIMIT are lowered to them, with a warning logged.
+//
+// The "address" of any task may be an ENS name, such as "oracle.eth", which
+// is resolved once as the job is created and saved as the address.
+//
+// EthTxEncode
+//
+// The EthTxEncode adapter sends a transaction calling a function which takes
+// several arguments, such as submit(uint256 roundId, int256 answer). Each
+// argument is taken from the run's data by its "name", and encoded as its
+// "format": one of address, bool, bytes, bytes32, int256, string or uint256.
+//   {
+//     "type": "EthTxEncode",
+//     "address": "0x0000000000000000000000000000000000000000",
+//     "functionSelector": "0xffffffff",
+//     "arguments": [
+//       {"name": "roundId", "format": "uint256"},
+//       {"name": "answer", "format": "int256"}
+//     ]
+//   }
+//
+// ContractDeploy
+//
+// The ContractDeploy adapter deploys a contract from its "bytecode" and
+// "constructorArgs", which are encoded by the constructor in "abi" as EthCall
+// encodes params. Once the transaction has MIN_OUTGOING_CONFIRMATIONS the
+// value is the address of the contract, or the run errors if the deployment
+// reverted. "gasLimit" replaces the node's default, as for EthTx.
+//   { "type": "ContractDeploy", "bytecode": "0x6080604052...",
+//     "constructorArgs": ["0x514910771AF9Ca656af840dff83E8264EcF986CA", 100],
+//     "abi": [{ "type": "constructor",
+//               "inputs": [{ "name": "link", "type": "address" },
+//                          { "name": "payment", "type": "uint256" }] }] }
+//
+// Sign
+//
+// The Sign adapter signs the Keccak256 hash of the value, as personal_sign
+// would, with the node's account. The signature is written to "signature"
+// and the account's address to "signerAddress", leaving the value as it is.
+//  { "type": "Sign" }
+//
 // Multiplier
 //
 // The Multiplier adapter multiplies the given input value times another specified
-// value.
-//   { "type": "Multiply", "times": 100 }
+// value. Both are treated as exact decimals, and "times" may be given as a
+// string to avoid any rounding by JSON parsers.
+//   { "type": "Multiply", "times": "1000000000000000000" }
+//
+// A negative "times" flips the sign of the value, which an EthInt256 task
+// after it encodes as a negative int256. Products outside the int256 range
+// are an error.
+//
+// Divide
+//
+// The Divide adapter divides the given input value by another specified
+// value, optionally rounding the result to a number of decimal places.
+//   { "type": "Divide", "divisor": 100, "precision": 2 }
+//
+// Quotient
+//
+// The Quotient adapter divides a "dividend", 1 by default, by the input value,
+// inverting a price pair. The result keeps up to "significantDigits"
+// significant digits, 18 by default.
+//   { "type": "Quotient", "dividend": 1, "significantDigits": 8 }
+//
+// Sum
+//
+// The Sum adapter adds together an array of numbers, taken from the input's
+// value or from the "values" param.
+//   { "type": "Sum", "precision": 2 }
+//
+// ResultCollect
+//
+// The ResultCollect adapter appends the input's value, or the field at the
+// gjson "path" param, to a "results" array in the run's data, leaving the
+// value as it was, so that the results of several tasks are kept together.
+//   { "type": "ResultCollect", "path": "price" }
+//
+// Timestamp
+//
+// The Timestamp adapter writes the current time to "key", by default
+// "timestamp", leaving "value" as it was. The "format" is one of "unix"
+// seconds, the default, "unixMillis" or "iso8601".
+//   { "type": "Timestamp", "key": "observedAt", "format": "iso8601" }
+//
+// Random
+//
+// The Random adapter returns a random unsigned 256 bit integer, optionally
+// between an inclusive "min" and "max". With "seedPath" the number is instead
+// derived from the Keccak256 hash of the run data at that path.
+//   { "type": "Random", "min": 1, "max": 100 }
+//
+// StringTemplate
+//
+// The StringTemplate adapter renders a Go text/template, with the input's
+// value bound to {{.value}} and each of "variables" bound by name.
+//   {
+//     "type": "StringTemplate",
+//     "template": "{{.base}}?symbol={{upper .value}}",
+//     "variables": {"base": "https://example.com/prices"}
+//   }
+//
+// Base64
+//
+// The Base64 adapter encodes or decodes the input's value, using the "std",
+// "url" or unpadded "raw" alphabet.
+//   { "type": "Base64", "operation": "decode", "encoding": "url" }
+//
+// Base64Decode
+//
+// The Base64Decode adapter decodes binary data from the input's base64 value,
+// in either alphabet unless "encoding" is "std" or "url", and writes it as a
+// 0x prefixed hex string for EthTx's "bytes" format.
+//   { "type": "Base64Decode" }
+//
+// HexEncode and HexDecode
+//
+// The HexEncode adapter converts the input's string value to a 0x prefixed
+// hex string of its bytes, and HexDecode converts such a string back. Numbers
+// are refused, since the EthBytes32, EthInt256 and EthUint256 adapters format
+// those.
+//   { "type": "HexDecode" }
+//
+// RegexExtract
+//
+// The RegexExtract adapter returns a capture group, by index or by
+// "groupName", from the first match of a regular expression against the
+// input's value, or from every match with "allMatches".
+//   { "type": "RegexExtract", "pattern": "last: ([0-9.]+)", "group": 1 }
+//
+// Compare
+//
+// The Compare adapter checks the input's value against a threshold using one of
+// "lt", "lte", "gt", "gte", "eq" or "neq". Numbers are compared as decimals and
+// strings must match exactly. When the comparison fails, "onFail" decides
+// whether the run is held as pending "review", errored with "error", or ended
+// early without error with "abort". A run held for review continues once
+// approved with PATCH /v2/runs/:RunID/review, and errors if rejected.
+//   { "type": "Compare", "operator": "gt", "threshold": "0.5", "onFail": "abort" }
+//
+// Deviation
+//
+// The Deviation adapter ends the run early, without error, unless the input's
+// value has moved by at least "threshold" percent from the value passed on by
+// the job's last completed run. A job's first run always continues, and the
+// reason for ending one is left in the result's "reason".
+//   { "type": "Deviation", "threshold": "0.5" }
+//
+// Mean, Median and Mode
+//
+// The Mean, Median and Mode adapters aggregate the array of numbers in the
+// input's value. Mode breaks ties by returning the smallest value.
+//   { "type": "Median", "precision": 2 }
+//
+// OAuth2
+//
+// The OAuth2 adapter obtains a bearer token from the tokenURL using the client
+// credentials grant, and returns it as the value. The client ID and secret
+// are the username and password of the HTTP credential named by "auth",
+// whose URL prefix must allow the tokenURL. With "cacheToken" the token is
+// kept in the node's database and reused until its expires_in elapses.
+//   {
+//     "type": "OAuth2",
+//     "tokenURL": "https://auth.example.com/oauth/token",
+//     "auth": "example-oauth",
+//     "scope": "prices:read",
+//     "cacheToken": true
+//   }
+//
+// Cache
+//
+// The Cache adapter returns the value stored under "key", a template rendered
+// against the run data, and runs "innerTask" to fill it when it is missing or
+// older than "ttl". The "backend" is "memory", the default, or "redis", which
+// uses the server at CACHE_REDIS_URL.
+//   { "type": "Cache", "key": "decimals-{{.address}}", "ttl": "1h",
+//     "innerTask": { "type": "HTTPGet", "params": { "get": "https://example.com/api" } } }
+//
+// CircuitBreaker
+//
+// The CircuitBreaker adapter runs another task, and after "failureThreshold"
+// consecutive failures errors runs without calling that task's adapter until
+// "recoveryTimeout" has passed.
+//   { "type": "CircuitBreaker", "failureThreshold": 3, "recoveryTimeout": "30s",
+//     "task": { "type": "HTTPGet", "params": { "get": "https://example.com/api" } } }
+//
+// Redis
+//
+// The Redis adapter runs a "get", "set" or "del" on "key", a template rendered
+// against the run data. A "get" returns the stored value and errors if the key
+// is missing, and a "set" stores the input's value, expiring it after "ttl".
+// The "mode" is "standalone", the default, "sentinel", which also takes the
+// "masterName", or "cluster", and "address" lists the sentinels or seed nodes
+// separated by commas.
+//   { "type": "Redis", "operation": "get", "address": "localhost:6379",
+//     "key": "price-{{.symbol}}", "db": 1 }
+//
+// S3
+//
+// The S3 adapter reads an object from an Amazon S3 bucket, or writes the
+// current value to one and returns its S3 URI.
+//   {
+//     "type": "S3",
+//     "operation": "get",
+//     "bucket": "reference-data",
+//     "key": "prices/eth.json",
+//     "region": "us-east-1"
+//   }
+//
+// WebSocket
+//
+// The WebSocket adapter connects to the given URL, sends the optional message
+// and returns the value at resultPath from the first message containing it.
+//   {
+//     "type": "WebSocket",
+//     "url": "wss://some-feed-example.net/ws",
+//     "message": {"subscribe": "ETH-USD"},
+//     "resultPath": "price",
+//     "timeout": "10s"
+//   }
+//
+// KafkaPublish
+//
+// The KafkaPublish adapter publishes the run result as JSON to a Kafka topic,
+// and returns the partition and offset it was written at. The optional key is
+// a template rendered against the run data.
+//   {
+//     "type": "KafkaPublish",
+//     "brokers": ["kafka-1.example.com:9093", "kafka-2.example.com:9093"],
+//     "topic": "oracle-prices",
+//     "key": "{{.symbol}}",
+//     "tlsEnabled": true,
+//     "saslMechanism": "SCRAM-SHA-512",
+//     "username": "oracle",
+//     "password": "s3cr3t",
+//     "flushTimeout": "5s"
+//   }
+//
+// WasmSandbox
+//
+// The WasmSandbox adapter runs a base64 encoded WebAssembly module's exported
+// "perform" function in a sandboxed interpreter, and sets "value" to the JSON
+// it outputs. The module can only read the run's data and write its output
+// through functions imported from "env", with no filesystem or network
+// access. Runs over the step limit, or which trap, are errored. Modules over
+// 64KiB, which are not valid, which import anything else or which do not
+// export "perform" are rejected when the job is created. Unlike the SGX only
+// Wasm adapter, it runs in every build of the node.
+//  { "type": "WasmSandbox", "wasm": "AGFzbQEAAAABCQJgAn9/AGAAAAIUAQNlbnYMb3V0cHV0X3dyaXRlAAADAgEBBQMBAAEHCwEHcGVyZm9ybQABCgoBCABBAEECEAALCwgBAEEACwI0Mg==" }
 //
 // Bridge
 //
@@ -1,35 +1,27 @@
 package adapters
 
 import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
-	"github.com/tidwall/gjson"
+	"github.com/smartcontractkit/chainlink/utils"
 )
 
-var evmFalse = "0x0000000000000000000000000000000000000000000000000000000000000000"
-var evmTrue = "0x0000000000000000000000000000000000000000000000000000000000000001"
-
-// EthBool holds no fields
-type EthBool struct{}
+// EthBool holds where the encoded boolean is written.
+type EthBool struct {
+	ResultKey ResultKey `json:"resultKey"`
+}
 
-// Perform returns the abi encoding for a boolean
+// Perform returns the abi encoding for a boolean, following the truthiness
+// rules of utils.EVMTranscodeBool. Objects and arrays error the run.
 //
 // For example, after converting the value false to hex encoded Ethereum
 // ABI, it would be:
 // "0x0000000000000000000000000000000000000000000000000000000000000000"
-func (*EthBool) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	r := input.Get("value")
-	if boolean(r.Type) {
-		return input.WithValue(evmTrue)
-	}
-	return input.WithValue(evmFalse)
-}
-
-func boolean(t gjson.Type) bool {
-	switch t {
-	case gjson.False, gjson.Null:
-		return false
-	default:
-		return true
+func (eb *EthBool) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	value, err := utils.EVMTranscodeBool(input.Get("value"))
+	if err != nil {
+		return input.WithError(err)
 	}
+	return eb.ResultKey.write(input, hexutil.Encode(value))
 }
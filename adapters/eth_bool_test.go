@@ -3,9 +3,12 @@ package adapters_test
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +26,10 @@ func TestEthBool_Perform(t *testing.T) {
 		{"value is null string", `{"value":"null"}`, evmTrue},
 		{"value is null", `{"value":null}`, evmFalse},
 		{"empty object", `{}`, evmFalse},
+		{"value is non-zero number", `{"value":42}`, evmTrue},
+		{"value is zero", `{"value":0}`, evmFalse},
+		{"value is string", `{"value":"yes"}`, evmTrue},
+		{"value is empty string", `{"value":""}`, evmFalse},
 	}
 
 	for _, tt := range tests {
@@ -42,3 +49,70 @@ func TestEthBool_Perform(t *testing.T) {
 		})
 	}
 }
+
+func TestEthBool_Perform_ObjectsAndArraysError(t *testing.T) {
+	tests := []string{`{"value":{"a":true}}`, `{"value":[true]}`}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test, func(t *testing.T) {
+			t.Parallel()
+			past := models.RunResult{
+				Data: cltest.JSONFromString(test),
+			}
+			adapter := adapters.EthBool{}
+			result := adapter.Perform(past, nil)
+
+			assert.True(t, result.HasError())
+		})
+	}
+}
+
+func TestEthBool_Perform_ThroughEthTx(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	config := store.Config
+
+	address := cltest.NewAddress()
+	fHash := models.HexToFunctionSelector("b3f98adc")
+
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionCount", `0x0100`)
+	assert.Nil(t, app.Start())
+
+	hash := cltest.NewHash()
+	sentAt := uint64(23456)
+	confirmed := sentAt + 1
+	safe := confirmed + config.MinOutgoingConfirmations
+	ethMock.Register("eth_sendRawTransaction", hash,
+		func(_ interface{}, data ...interface{}) error {
+			rlp := data[0].([]interface{})[0].(string)
+			tx, err := utils.DecodeEthereumTx(rlp)
+			assert.NoError(t, err)
+			assert.Equal(t, address.String(), tx.To().String())
+			assert.Equal(t, "0xb3f98adc"+evmTrue[2:], hexutil.Encode(tx.Data()))
+			return nil
+		})
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(sentAt))
+	receipt := strpkg.TxReceipt{Hash: hash, BlockNumber: cltest.Int(confirmed)}
+	ethMock.Register("eth_getTransactionReceipt", receipt)
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(safe))
+
+	input := models.RunResult{Data: cltest.JSONFromString(`{"value":7}`)}
+	boolResult := (&adapters.EthBool{}).Perform(input, store)
+	assert.NoError(t, boolResult.GetError())
+
+	ethTx := adapters.EthTx{Address: address, FunctionSelector: fHash}
+	result := ethTx.Perform(boolResult, store)
+	assert.False(t, result.HasError())
+	assert.True(t, result.Status.PendingConfirmations())
+
+	result = ethTx.Perform(result, store)
+	assert.False(t, result.HasError())
+	assert.True(t, result.Status.Completed())
+
+	ethMock.EventuallyAllCalled(t)
+}
@@ -0,0 +1,241 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// EthCall reads a value from a contract by calling one of its functions,
+// without sending a transaction.
+type EthCall struct {
+	Contract common.Address `json:"contract"`
+	// ABI is the JSON ABI of the contract, which need only contain Method.
+	ABI    abi.ABI           `json:"abi"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	// OutputIndex selects which of the function's return values to use.
+	OutputIndex int `json:"outputIndex"`
+	// BlockNumber is a decimal or hex block number to read state at, or
+	// "latest", the default, "earliest" or "pending".
+	BlockNumber string `json:"blockNumber"`
+}
+
+// Perform calls Method on Contract with Params, and returns the return value
+// at OutputIndex as the "value" field of the result.
+//
+// Integers are returned as decimal strings, addresses as checksummed hex, and
+// bytes as 0x prefixed hex. Booleans, strings and arrays keep their JSON type.
+func (eca *EthCall) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	method, ok := eca.ABI.Methods[eca.Method]
+	if !ok {
+		return input.WithError(fmt.Errorf("method %q not found in abi", eca.Method))
+	}
+	if eca.OutputIndex < 0 || eca.OutputIndex >= len(method.Outputs) {
+		return input.WithError(fmt.Errorf("outputIndex %d out of range, %s returns %d value(s)", eca.OutputIndex, eca.Method, len(method.Outputs)))
+	}
+	block, err := eca.block()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	data, err := eca.pack(method)
+	if err != nil {
+		return input.WithError(err)
+	}
+	out, err := str.TxManager.CallContract(eca.Contract, data, block)
+	if err != nil {
+		return input.WithError(err)
+	}
+	if len(out) == 0 {
+		return input.WithError(fmt.Errorf("call to %s returned no data, is it a contract?", eca.Contract.Hex()))
+	}
+
+	values, err := method.Outputs.UnpackValues(out)
+	if err != nil {
+		return input.WithError(fmt.Errorf("unable to decode %s output: %v", eca.Method, err))
+	}
+
+	switch value := abiOutputValue(values[eca.OutputIndex]).(type) {
+	case string:
+		return input.WithValue(value)
+	default:
+		return input.Add("value", value)
+	}
+}
+
+func (eca *EthCall) block() (string, error) {
+	switch eca.BlockNumber {
+	case "":
+		return "latest", nil
+	case "latest", "earliest", "pending":
+		return eca.BlockNumber, nil
+	}
+	if utils.HasHexPrefix(eca.BlockNumber) {
+		if _, err := hexutil.DecodeUint64(eca.BlockNumber); err != nil {
+			return "", fmt.Errorf("invalid blockNumber %q: %v", eca.BlockNumber, err)
+		}
+		return eca.BlockNumber, nil
+	}
+	n, err := strconv.ParseUint(eca.BlockNumber, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid blockNumber %q", eca.BlockNumber)
+	}
+	return hexutil.EncodeUint64(n), nil
+}
+
+func (eca *EthCall) pack(method abi.Method) ([]byte, error) {
+	if len(eca.Params) != len(method.Inputs) {
+		return nil, fmt.Errorf("%s takes %d param(s), got %d", eca.Method, len(method.Inputs), len(eca.Params))
+	}
+	args := make([]interface{}, len(eca.Params))
+	for i, raw := range eca.Params {
+		arg, err := abiInputValue(method.Inputs[i].Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("param %d of %s: %v", i, eca.Method, err)
+		}
+		args[i] = arg
+	}
+	return eca.ABI.Pack(eca.Method, args...)
+}
+
+var bigIntType = reflect.TypeOf(&big.Int{})
+
+// abiInputValue converts a JSON param into the Go type go-ethereum's abi
+// package packs for t.
+func abiInputValue(t abi.Type, raw json.RawMessage) (interface{}, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return abiInteger(t, raw)
+	case abi.BoolTy:
+		var b bool
+		err := json.Unmarshal(raw, &b)
+		return b, err
+	case abi.StringTy:
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case abi.AddressTy:
+		var a common.Address
+		err := json.Unmarshal(raw, &a)
+		return a, err
+	case abi.BytesTy:
+		var b hexutil.Bytes
+		err := json.Unmarshal(raw, &b)
+		return []byte(b), err
+	case abi.FixedBytesTy:
+		var b hexutil.Bytes
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, err
+		}
+		if len(b) > t.Size {
+			return nil, fmt.Errorf("%d bytes do not fit in bytes%d", len(b), t.Size)
+		}
+		v := reflect.New(t.Type).Elem()
+		reflect.Copy(v, reflect.ValueOf([]byte(b)))
+		return v.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		var v reflect.Value
+		if t.T == abi.SliceTy {
+			v = reflect.MakeSlice(t.Type, len(items), len(items))
+		} else if len(items) != t.Size {
+			return nil, fmt.Errorf("expected %d items, got %d", t.Size, len(items))
+		} else {
+			v = reflect.New(t.Type).Elem()
+		}
+		for i, item := range items {
+			elem, err := abiInputValue(*t.Elem, item)
+			if err != nil {
+				return nil, err
+			}
+			v.Index(i).Set(reflect.ValueOf(elem))
+		}
+		return v.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported abi type %s", t.String())
+	}
+}
+
+// abiInteger parses a JSON number, or a decimal or 0x prefixed hex string,
+// into the Go integer type for t.
+func abiInteger(t abi.Type, raw json.RawMessage) (interface{}, error) {
+	str := string(utils.RemoveQuotes(raw))
+	base := 10
+	if utils.HasHexPrefix(str) {
+		str, base = str[2:], 16
+	}
+	i, ok := new(big.Int).SetString(str, base)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse %s into an integer", raw)
+	}
+	if !abiIntegerFits(t, i) {
+		return nil, fmt.Errorf("%s does not fit in %s", i, t.String())
+	}
+
+	if t.Type == bigIntType {
+		return i, nil
+	}
+	v := reflect.New(t.Type).Elem()
+	if t.T == abi.IntTy {
+		v.SetInt(i.Int64())
+	} else {
+		v.SetUint(i.Uint64())
+	}
+	return v.Interface(), nil
+}
+
+func abiIntegerFits(t abi.Type, i *big.Int) bool {
+	if t.T == abi.UintTy {
+		return i.Sign() >= 0 && i.BitLen() <= t.Size
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	return i.Cmp(new(big.Int).Neg(limit)) >= 0 && i.Cmp(limit) < 0
+}
+
+// abiOutputValue converts a value unpacked by go-ethereum's abi package into
+// one which serializes to JSON sensibly.
+func abiOutputValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case string, bool:
+		return v
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = abiOutputValue(rv.Index(i).Interface())
+		}
+		return items
+	}
+	return value
+}
@@ -0,0 +1,163 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/mock_store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoContract returns its calldata, minus the function selector, so any
+// function whose outputs match its inputs returns its arguments.
+var echoContract = hexutil.MustDecode(
+	"0x6010600c60003960106000f3" + // copy the runtime code below into memory and return it
+		"600436036004600037600436036000f3") // return calldata[4:]
+
+const echoABI = `[{
+	"name": "echo", "type": "function", "constant": true,
+	"inputs": [
+		{"name": "a", "type": "uint256"}, {"name": "b", "type": "int8"}, {"name": "c", "type": "bool"},
+		{"name": "d", "type": "address"}, {"name": "e", "type": "bytes4"}, {"name": "f", "type": "string"}
+	],
+	"outputs": [
+		{"name": "a", "type": "uint256"}, {"name": "b", "type": "int8"}, {"name": "c", "type": "bool"},
+		{"name": "d", "type": "address"}, {"name": "e", "type": "bytes4"}, {"name": "f", "type": "string"}
+	]
+}, {
+	"name": "echoArray", "type": "function", "constant": true,
+	"inputs": [{"name": "a", "type": "uint256[]"}],
+	"outputs": [{"name": "a", "type": "uint256[]"}]
+}]`
+
+func newEthCall(t *testing.T, params string) adapters.EthCall {
+	t.Helper()
+	var eca adapters.EthCall
+	require.NoError(t, json.Unmarshal([]byte(params), &eca))
+	return eca
+}
+
+func TestEthCall_Perform_SimulatedChain(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	chain := cltest.NewSimulatedChain(t)
+	cltest.UseSimulatedChain(store, chain)
+	contract := chain.Deploy(t, echoABI, echoContract)
+
+	params := `["0x1b1ae4d6e2ef500000", -3, true, "0x9cA9d2D5E04012C9Ed24C0e513C9bfAa4A2dD77f", "0xdeadbeef", "hello"]`
+	tests := []struct {
+		name        string
+		outputIndex int
+		want        string
+	}{
+		{"uint256", 0, `"500000000000000000000"`},
+		{"int8", 1, `"-3"`},
+		{"bool", 2, `true`},
+		{"address", 3, `"0x9CA9d2D5E04012C9Ed24C0e513C9bfAa4A2dD77f"`},
+		{"bytes4", 4, `"0xdeadbeef"`},
+		{"string", 5, `"hello"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eca := newEthCall(t, `{"contract": "`+contract.Hex()+`", "abi": `+echoABI+`,
+				"method": "echo", "params": `+params+`, "outputIndex": `+strconv.Itoa(test.outputIndex)+`}`)
+			result := eca.Perform(cltest.RunResultWithValue("input"), store)
+
+			require.NoError(t, result.GetError())
+			assert.Equal(t, models.RunStatusCompleted, result.Status)
+			assert.JSONEq(t, test.want, result.Get("value").Raw)
+		})
+	}
+
+	t.Run("array", func(t *testing.T) {
+		eca := newEthCall(t, `{"contract": "`+contract.Hex()+`", "abi": `+echoABI+`,
+			"method": "echoArray", "params": [[1, "2", "0x3"]]}`)
+		result := eca.Perform(cltest.RunResultWithValue("input"), store)
+
+		require.NoError(t, result.GetError())
+		assert.JSONEq(t, `["1","2","3"]`, result.Get("value").Raw)
+	})
+
+	t.Run("not a contract", func(t *testing.T) {
+		eca := newEthCall(t, `{"contract": "`+cltest.NewAddress().Hex()+`", "abi": `+echoABI+`,
+			"method": "echoArray", "params": [[1]]}`)
+		result := eca.Perform(cltest.RunResultWithValue("input"), store)
+
+		assert.True(t, result.HasError())
+		assert.Contains(t, result.Error(), "returned no data")
+	})
+}
+
+func TestEthCall_Perform_InvalidParams(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"unknown method", `"method": "nope", "params": []`},
+		{"missing params", `"method": "echoArray", "params": []`},
+		{"output index out of range", `"method": "echoArray", "params": [[1]], "outputIndex": 1`},
+		{"negative uint", `"method": "echoArray", "params": [[-1]]`},
+		{"int8 overflow", `"method": "echo", "params": [1, 128, true, "0x9cA9d2D5E04012C9Ed24C0e513C9bfAa4A2dD77f", "0x00", ""]`},
+		{"bytes4 overflow", `"method": "echo", "params": [1, 1, true, "0x9cA9d2D5E04012C9Ed24C0e513C9bfAa4A2dD77f", "0x0000000000", ""]`},
+		{"invalid block number", `"method": "echoArray", "params": [[1]], "blockNumber": "yesterday"`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			eca := newEthCall(t, `{"contract": "`+cltest.NewAddress().Hex()+`", "abi": `+echoABI+`, `+test.params+`}`)
+			result := eca.Perform(cltest.RunResultWithValue("input"), store)
+			assert.True(t, result.HasError())
+		})
+	}
+}
+
+func TestEthCall_Perform_BlockNumber(t *testing.T) {
+	tests := []struct {
+		blockNumber string
+		want        string
+	}{
+		{"", "latest"},
+		{"pending", "pending"},
+		{"16", "0x10"},
+		{"0x10", "0x10"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.blockNumber, func(t *testing.T) {
+			store, cleanup := cltest.NewStore()
+			defer cleanup()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			txmMock := mock_store.NewMockTxManager(ctrl)
+			store.TxManager = txmMock
+
+			contract := cltest.NewAddress()
+			word := hexutil.MustDecode("0x" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000007")
+			txmMock.EXPECT().CallContract(contract, gomock.Any(), test.want).Return(word, nil)
+
+			eca := newEthCall(t, `{"contract": "`+contract.Hex()+`", "abi": `+echoABI+`,
+				"method": "echoArray", "params": [[7]], "blockNumber": "`+test.blockNumber+`"}`)
+			result := eca.Perform(cltest.RunResultWithValue("input"), store)
+
+			require.NoError(t, result.GetError())
+			assert.JSONEq(t, `["7"]`, result.Get("value").Raw)
+		})
+	}
+}
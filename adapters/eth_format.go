@@ -2,8 +2,7 @@ package adapters
 
 import (
 	"fmt"
-	"math/big"
-	"strings"
+	"unicode/utf8"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -12,78 +11,94 @@ import (
 	"github.com/smartcontractkit/chainlink/utils"
 )
 
-// EthBytes32 holds no fields.
-type EthBytes32 struct{}
+// EthBytes32 holds whether values too long for a bytes32 are truncated.
+type EthBytes32 struct {
+	Truncate  bool      `json:"truncate"`
+	ResultKey ResultKey `json:"resultKey"`
+}
 
-// Perform returns the hex value of the first 32 bytes of a string
-// so that it is in the proper format to be written to the blockchain.
+// Perform returns the hex value of a string, right padded to 32 bytes, so
+// that it is in the proper format to be written to the blockchain. Values
+// with a 0x prefix are decoded as hex rather than treated as text.
+//
+// Values longer than 32 bytes error the run unless Truncate is set, in which
+// case they are cut short without splitting a UTF-8 character.
 //
 // For example, after converting the string "16800.01" to hex encoded Ethereum
 // ABI, it would be:
 // "0x31363830302e3031000000000000000000000000000000000000000000000000"
-func (*EthBytes32) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	result := input.Get("value")
-	value := common.RightPadBytes([]byte(result.String()), utils.EVMWordByteLen)
-	hex := utils.RemoveHexPrefix(hexutil.Encode(value))
+func (eb *EthBytes32) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	str := input.Get("value").String()
+	value := []byte(str)
+	hexInput := utils.HasHexPrefix(str)
+	if hexInput {
+		decoded, err := hexutil.Decode(str)
+		if err != nil {
+			return input.WithError(fmt.Errorf("cannot decode %q as hex: %v", str, err))
+		}
+		value = decoded
+	}
+
+	if len(value) > utils.EVMWordByteLen {
+		if !eb.Truncate {
+			return input.WithError(fmt.Errorf("value is %d bytes, longer than the %d bytes of a bytes32; set truncate to shorten it", len(value), utils.EVMWordByteLen))
+		}
+		value = truncateBytes(value, utils.EVMWordByteLen, !hexInput)
+	}
 
-	if len(hex) > utils.EVMWordHexLen {
-		hex = hex[:utils.EVMWordHexLen]
+	return eb.ResultKey.write(input, hexutil.Encode(common.RightPadBytes(value, utils.EVMWordByteLen)))
+}
+
+// truncateBytes shortens b to at most n bytes, backing off to the start of a
+// UTF-8 character if utf8Text is set so that none is split.
+func truncateBytes(b []byte, n int, utf8Text bool) []byte {
+	if utf8Text {
+		for n > 0 && !utf8.RuneStart(b[n]) {
+			n--
+		}
 	}
-	return input.WithValue(utils.AddHexPrefix(hex))
+	return b[:n]
 }
 
-// EthInt256 holds no fields
-type EthInt256 struct{}
+// EthInt256 holds where the encoded number is written.
+type EthInt256 struct {
+	ResultKey ResultKey `json:"resultKey"`
+}
 
-// Perform returns the hex value of a given string so that it
-// is in the proper format to be written to the blockchain.
+// Perform returns the hex value of a given number so that it is in the proper
+// format to be written to the blockchain. The value can be a JSON number, or a
+// string holding a decimal or 0x prefixed hex number.
 //
 // For example, after converting the string "-123.99" to hex encoded Ethereum
 // ABI, it would be:
 // "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff85"
-func (*EthInt256) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	i, err := parseBigInt(input)
-	if err != nil {
-		return input.WithError(err)
-	}
-
-	sh, err := utils.EVMWordSignedBigInt(i)
+func (ei *EthInt256) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	sh, err := utils.EVMTranscodeInt256(input.Get("value"))
 	if err != nil {
 		return input.WithError(err)
 	}
 
-	return input.WithValue(hexutil.Encode(sh))
+	return ei.ResultKey.write(input, hexutil.Encode(sh))
 }
 
-// EthUint256 holds no fields.
-type EthUint256 struct{}
+// EthUint256 holds where the encoded number is written.
+type EthUint256 struct {
+	ResultKey ResultKey `json:"resultKey"`
+}
 
-// Perform returns the hex value of a given string so that it
-// is in the proper format to be written to the blockchain.
+// Perform returns the hex value of a given number so that it is in the proper
+// format to be written to the blockchain. The value can be a JSON number, or a
+// string holding a decimal or 0x prefixed hex number, and must not be
+// negative.
 //
 // For example, after converting the string "123.99" to hex encoded Ethereum
 // ABI, it would be:
 // "0x000000000000000000000000000000000000000000000000000000000000007b"
-func (*EthUint256) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	i, err := parseBigInt(input)
-	if err != nil {
-		return input.WithError(err)
-	}
-
-	sh, err := utils.EVMWordBigInt(i)
+func (eu *EthUint256) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	sh, err := utils.EVMTranscodeUint256(input.Get("value"))
 	if err != nil {
 		return input.WithError(err)
 	}
 
-	return input.WithValue(hexutil.Encode(sh))
-}
-
-func parseBigInt(input models.RunResult) (*big.Int, error) {
-	val := input.Get("value")
-	parts := strings.Split(val.String(), ".")
-	i, ok := (&big.Int{}).SetString(parts[0], 10)
-	if !ok {
-		return nil, fmt.Errorf("cannot parse into big.Int: %v", val)
-	}
-	return i, nil
+	return eu.ResultKey.write(input, hexutil.Encode(sh))
 }
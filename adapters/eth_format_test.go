@@ -1,8 +1,11 @@
 package adapters_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/store/models"
@@ -17,7 +20,6 @@ func TestEthBytes32_Perform(t *testing.T) {
 	}{
 		{"string", `{"value":"Hello World!"}`, "0x48656c6c6f20576f726c64210000000000000000000000000000000000000000"},
 		{"special characters", `{"value":"¡Holá Mündo!"}`, "0xc2a1486f6cc3a1204dc3bc6e646f210000000000000000000000000000000000"},
-		{"long string", `{"value":"string that is waaAAAaaay toooo long!!!!!"}`, "0x737472696e672074686174206973207761614141416161617920746f6f6f6f20"},
 		{"empty string", `{"value":""}`, "0x0000000000000000000000000000000000000000000000000000000000000000"},
 		{"string of number", `{"value":"16800.01"}`, "0x31363830302e3031000000000000000000000000000000000000000000000000"},
 		{"float", `{"value":16800.01}`, "0x31363830302e3031000000000000000000000000000000000000000000000000"},
@@ -26,6 +28,8 @@ func TestEthBytes32_Perform(t *testing.T) {
 		{"boolean true", `{"value":true}`, "0x7472756500000000000000000000000000000000000000000000000000000000"},
 		{"boolean false", `{"value":false}`, "0x66616c7365000000000000000000000000000000000000000000000000000000"},
 		{"null", `{"value":null}`, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{"hex", `{"value":"0x1234"}`, "0x1234000000000000000000000000000000000000000000000000000000000000"},
+		{"empty hex", `{"value":"0x"}`, "0x0000000000000000000000000000000000000000000000000000000000000000"},
 	}
 
 	for _, tt := range tests {
@@ -46,6 +50,81 @@ func TestEthBytes32_Perform(t *testing.T) {
 	}
 }
 
+func TestEthBytes32_Perform_Length(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		truncate    bool
+		want        string
+		wantErrored bool
+	}{
+		{"ascii 31 bytes", strings.Repeat("a", 31), false, strings.Repeat("a", 31), false},
+		{"ascii 32 bytes", strings.Repeat("a", 32), false, strings.Repeat("a", 32), false},
+		{"ascii 33 bytes", strings.Repeat("a", 33), false, "", true},
+		{"ascii 33 bytes truncated", strings.Repeat("a", 33), true, strings.Repeat("a", 32), false},
+		{"utf8 31 bytes", "a" + strings.Repeat("é", 15), false, "a" + strings.Repeat("é", 15), false},
+		{"utf8 32 bytes", strings.Repeat("é", 16), false, strings.Repeat("é", 16), false},
+		{"utf8 33 bytes", "a" + strings.Repeat("é", 16), false, "", true},
+		{"utf8 33 bytes truncated", "a" + strings.Repeat("é", 16), true, "a" + strings.Repeat("é", 15), false},
+		{"utf8 3 byte runes truncated", strings.Repeat("€", 11), true, strings.Repeat("€", 10), false},
+		{"utf8 32 bytes truncated", strings.Repeat("é", 16), true, strings.Repeat("é", 16), false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.EthBytes32{Truncate: test.truncate}
+			result := adapter.Perform(cltest.RunResultWithValue(test.value), nil)
+
+			if test.wantErrored {
+				assert.True(t, result.HasError())
+				return
+			}
+			assert.NoError(t, result.GetError())
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, hexutil.Encode(common.RightPadBytes([]byte(test.want), 32)), val)
+		})
+	}
+}
+
+func TestEthBytes32_Perform_HexLength(t *testing.T) {
+	word := "0x" + strings.Repeat("ab", 32)
+	tests := []struct {
+		name        string
+		value       string
+		truncate    bool
+		want        string
+		wantErrored bool
+	}{
+		{"31 bytes", "0x" + strings.Repeat("ab", 31), false, "0x" + strings.Repeat("ab", 31) + "00", false},
+		{"32 bytes", word, false, word, false},
+		{"33 bytes", word + "cd", false, "", true},
+		{"33 bytes truncated", word + "cd", true, word, false},
+		{"invalid hex", "0xzz", false, "", true},
+		{"odd length hex", "0xabc", false, "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.EthBytes32{Truncate: test.truncate}
+			result := adapter.Perform(cltest.RunResultWithValue(test.value), nil)
+
+			if test.wantErrored {
+				assert.True(t, result.HasError())
+				return
+			}
+			assert.NoError(t, result.GetError())
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+		})
+	}
+}
+
 func TestEthInt256_Perform(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -64,6 +143,16 @@ func TestEthInt256_Perform(t *testing.T) {
 			"0x000000000000000000000000000000000000000000000000000000000000007b", false},
 		{"negative string", `{"value":"-123"}`, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff85", false},
 		{"negative float", `{"value":-123.99}`, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff85", false},
+		{"hex string", `{"value":"0x7b"}`,
+			"0x000000000000000000000000000000000000000000000000000000000000007b", false},
+		{"negative hex string", `{"value":"-0x7b"}`, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff85", false},
+		{"exponent", `{"value":1.23e2}`,
+			"0x000000000000000000000000000000000000000000000000000000000000007b", false},
+		{"max int256", `{"value":"57896044618658097711785492504343953926634992332820282019728792003956564819967"}`,
+			"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", false},
+		{"overflow", `{"value":"57896044618658097711785492504343953926634992332820282019728792003956564819968"}`, "", true},
+		{"not a number", `{"value":"12a"}`, "", true},
+		{"bool", `{"value":true}`, "", true},
 		{"object", `{"value":{"a": "b"}}`, "", true},
 	}
 
@@ -106,6 +195,13 @@ func TestEthUint256_Perform(t *testing.T) {
 		{"negative integer", `{"value":-123}`, "", true},
 		{"negative string", `{"value":"-123"}`, "", true},
 		{"negative float", `{"value":-123.99}`, "", true},
+		{"negative hex string", `{"value":"-0x7b"}`, "", true},
+		{"hex string", `{"value":"0x7b"}`,
+			"0x000000000000000000000000000000000000000000000000000000000000007b", false},
+		{"max uint256", `{"value":"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"}`,
+			"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", false},
+		{"overflow", `{"value":"0x10000000000000000000000000000000000000000000000000000000000000000"}`, "", true},
+		{"huge exponent", `{"value":"1e1000000"}`, "", true},
 		{"object", `{"value":{"a": "b"}}`, "", true},
 	}
 
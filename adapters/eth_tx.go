@@ -1,6 +1,13 @@
 package adapters
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/logger"
@@ -11,31 +18,110 @@ import (
 
 const (
 	// DataFormatBytes instructs the EthTx Adapter to treat the input value as a
-	// bytes string, rather than a hexadecimal encoded bytes32
+	// bytes string, rather than a hexadecimal encoded bytes32. A 0x prefixed
+	// hex value, such as Base64Decode writes, is sent as the bytes it encodes.
 	DataFormatBytes = "bytes"
 )
 
 // EthTx holds the Address to send the result to and the FunctionSelector
-// to execute.
+// to execute. DataKeys names the keys of the run's data to send as the
+// function's arguments, in order, instead of only "value".
 type EthTx struct {
 	Address          common.Address          `json:"address"`
 	FunctionSelector models.FunctionSelector `json:"functionSelector"`
 	DataPrefix       hexutil.Bytes           `json:"dataPrefix"`
 	DataFormat       string                  `json:"format"`
+	DataKeys         []ResultKey             `json:"dataKeys"`
+	// ChainID is the chain to send the transaction on, one of the node's
+	// CHAINS, or the chain at ETH_URL when 0.
+	ChainID uint64 `json:"chainId"`
+	// GasPrice and GasLimit override the node's defaults for the transaction
+	// when set, up to ETH_MAX_GAS_PRICE_WEI and ETH_MAX_GAS_LIMIT.
+	GasPrice *big.Int `json:"gasPrice,omitempty"`
+	GasLimit uint64   `json:"gasLimit,omitempty"`
+}
+
+// UnmarshalJSON validates the params as they're parsed, so that a mistyped
+// functionSelector, dataPrefix, format, dataKeys or gasPrice rejects the job
+// spec when it is created rather than failing its first run.
+func (etx *EthTx) UnmarshalJSON(input []byte) error {
+	type plain EthTx
+	var aux struct {
+		plain
+		FunctionSelector json.RawMessage `json:"functionSelector"`
+		DataPrefix       json.RawMessage `json:"dataPrefix"`
+		GasPrice         json.RawMessage `json:"gasPrice"`
+	}
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+
+	if present(aux.FunctionSelector) {
+		if err := json.Unmarshal(aux.FunctionSelector, &aux.plain.FunctionSelector); err != nil {
+			return fmt.Errorf("EthTx functionSelector must be 4 bytes of hex: %v", err)
+		}
+	}
+	if present(aux.DataPrefix) {
+		if err := json.Unmarshal(aux.DataPrefix, &aux.plain.DataPrefix); err != nil {
+			return fmt.Errorf("EthTx dataPrefix must be 0x prefixed hex: %v", err)
+		}
+		if len(aux.plain.DataPrefix)%utils.EVMWordByteLen != 0 {
+			return fmt.Errorf("EthTx dataPrefix must be a multiple of %d bytes, got %d", utils.EVMWordByteLen, len(aux.plain.DataPrefix))
+		}
+	}
+	if present(aux.GasPrice) {
+		// Prices in wei are often too large for a JSON number to hold
+		// exactly, so they may also be given as a decimal string.
+		price, ok := new(big.Int).SetString(strings.Trim(string(aux.GasPrice), `"`), 10)
+		if !ok || price.Sign() <= 0 {
+			return fmt.Errorf("EthTx gasPrice must be a positive number of wei, got %s", aux.GasPrice)
+		}
+		aux.plain.GasPrice = price
+	}
+	if aux.DataFormat != "" && aux.DataFormat != DataFormatBytes {
+		return fmt.Errorf("EthTx format must be %q or unset, got %q", DataFormatBytes, aux.DataFormat)
+	}
+	if aux.DataFormat == DataFormatBytes && len(aux.DataKeys) > 0 {
+		return fmt.Errorf("EthTx format %q cannot be combined with dataKeys", DataFormatBytes)
+	}
+
+	*etx = EthTx(aux.plain)
+	return nil
 }
 
-// Perform creates the run result for the transaction if the existing run result
-// is not currently pending. Then it confirms the transaction was confirmed on
-// the blockchain.
+// present returns whether a JSON field was set to something other than null.
+func present(raw json.RawMessage) bool {
+	return len(raw) > 0 && string(raw) != "null"
+}
+
+// Perform creates and sends the transaction if the existing run result is not
+// currently pending, and returns a pending confirmations result. Each later
+// call, made as new heads arrive, completes the run once the transaction has
+// enough confirmations, or errors once it has gone unmined for too long.
 func (etx *EthTx) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	return etx.PerformCtx(context.Background(), input, store)
+}
+
+// PerformCtx is Perform, except that no transaction is sent once ctx is done.
+// A transaction already sent is still followed to confirmation, since it may
+// be mined regardless.
+func (etx *EthTx) PerformCtx(ctx context.Context, input models.RunResult, store *store.Store) models.RunResult {
+	txm := store.TxManager
+	if etx.ChainID != 0 {
+		if txm = txm.ForChain(etx.ChainID); txm == nil {
+			return input.WithError(models.NewPermanentError(fmt.Errorf("node is not configured for chain %d", etx.ChainID)))
+		}
+	}
 	if !input.Status.PendingConfirmations() {
-		return createTxRunResult(etx, input, store)
+		if err := ctx.Err(); err != nil {
+			return input.WithError(fmt.Errorf("not sending transaction: %v", err))
+		}
+		return createTxRunResult(etx, input, txm)
 	}
-	return ensureTxRunResult(input, store)
+	return ensureTxRunResult(input, txm)
 }
 
-func abiEncodeString(str string) ([]byte, error) {
-	input := []byte(str)
+func abiEncodeBytes(input []byte) ([]byte, error) {
 	length := len(input)
 	return utils.ConcatBytes(
 		utils.EVMWordUint64(utils.EVMWordByteLen*2),
@@ -47,59 +133,169 @@ func abiEncodeString(str string) ([]byte, error) {
 // getTxData returns the data to save against the callback encoded according to
 // the dataFormat parameter in the job spec
 func getTxData(e *EthTx, input models.RunResult) ([]byte, error) {
+	if len(e.DataKeys) > 0 {
+		return getTxDataForKeys(e.DataKeys, input)
+	}
+
 	val, err := input.Value()
 	if err != nil {
 		return nil, err
 	}
 
 	if e.DataFormat == DataFormatBytes {
-		return abiEncodeString(val)
+		if utils.HasHexPrefix(val) {
+			if b, err := hexutil.Decode(val); err == nil {
+				return abiEncodeBytes(b)
+			}
+		}
+		return abiEncodeBytes([]byte(val))
 	}
 
 	return common.HexToHash(val).Bytes(), nil
 }
 
+// getTxDataForKeys encodes the value at each key as a 32 byte word, in the
+// order given, as EthUint256 and the other formatting adapters write them.
+func getTxDataForKeys(keys []ResultKey, input models.RunResult) ([]byte, error) {
+	var data []byte
+	for _, key := range keys {
+		val := input.Get(key.String())
+		if !val.Exists() {
+			return nil, fmt.Errorf("dataKeys names %q, which the run's data does not have", key)
+		}
+		data = append(data, common.HexToHash(val.String()).Bytes()...)
+	}
+	return data, nil
+}
+
 func createTxRunResult(
 	e *EthTx,
 	input models.RunResult,
-	store *store.Store,
+	txm store.TxManager,
 ) models.RunResult {
 	val, err := getTxData(e, input)
 	if err != nil {
-		return input.WithError(err)
+		return input.WithError(models.NewPermanentError(err))
 	}
 
 	data, err := utils.ConcatBytes(e.FunctionSelector.Bytes(), e.DataPrefix, val)
 	if err != nil {
-		return input.WithError(err)
+		return input.WithError(models.NewPermanentError(err))
 	}
+	return sendTxWithGasRunResult(e.Address, data, e.GasPrice, e.GasLimit, input, txm)
+}
 
-	tx, err := store.TxManager.CreateTx(e.Address, data)
+// sendTxRunResult sends a transaction with data to address, returning a
+// pending confirmations result with the transaction's hash as its value.
+func sendTxRunResult(
+	address common.Address,
+	data []byte,
+	input models.RunResult,
+	txm store.TxManager,
+) models.RunResult {
+	return sendTxWithGasRunResult(address, data, nil, 0, input, txm)
+}
+
+// sendTxWithGasRunResult is sendTxRunResult with the gas price and limit
+// given, or the node's defaults for nil and 0.
+func sendTxWithGasRunResult(
+	address common.Address,
+	data []byte,
+	gasPrice *big.Int,
+	gasLimit uint64,
+	input models.RunResult,
+	txm store.TxManager,
+) models.RunResult {
+	tx, err := txm.CreateTxWithGas(address, data, gasPrice, gasLimit)
 	if err != nil {
-		return input.WithError(err)
+		return input.WithError(sendTxError(err))
 	}
+	return pendingTxRunResult(tx, input)
+}
+
+// sendTxError marks the error of sending a transaction permanent when the
+// node rejected the transaction as one it will never accept, so that the task
+// is not attempted again.
+func sendTxError(err error) error {
+	if sendErr, ok := err.(*store.SendError); ok && sendErr.Fatal() {
+		return models.NewPermanentError(err)
+	}
+	return err
+}
 
-	sendResult := input.WithValue(tx.Hash.String())
-	return ensureTxRunResult(sendResult, store)
+// pendingTxRunResult returns a pending confirmations result with the hash of
+// the transaction sent as its value.
+func pendingTxRunResult(tx *models.Tx, input models.RunResult) models.RunResult {
+	sendResult := withTxData(input.WithValue(tx.Hash.String()),
+		"txHash", tx.Hash.String(),
+		"nonce", tx.Nonce,
+		"gasPrice", bigString(tx.GasPrice),
+		"from", tx.From.String(),
+	)
+	if sendResult.HasError() {
+		return sendResult
+	}
+	return sendResult.MarkPendingConfirmations()
 }
 
-func ensureTxRunResult(input models.RunResult, store *store.Store) models.RunResult {
+// ensureTxRunResult checks on the transaction whose hash is the value of the
+// input, which is saved with the task run so that it survives restarts. Its
+// errors are permanent, since retrying the task would send the transaction
+// again.
+func ensureTxRunResult(input models.RunResult, txm store.TxManager) models.RunResult {
+	output, _ := ensureTxReceipt(input, txm)
+	return output
+}
+
+// ensureTxReceipt is ensureTxRunResult, also returning the transaction's
+// receipt once it is confirmed.
+func ensureTxReceipt(input models.RunResult, txm store.TxManager) (models.RunResult, *store.TxReceipt) {
 	val, err := input.Value()
 	if err != nil {
-		return input.WithError(err)
+		return input.WithError(models.NewPermanentError(err)), nil
 	}
 
 	hash := common.HexToHash(val)
-	if err != nil {
-		return input.WithError(err)
+	receipt, err := txm.ConfirmedTxReceipt(hash)
+	if missing, ok := err.(*store.TxMissingError); ok {
+		return input.WithError(models.NewPermanentError(missing)), nil
+	} else if err != nil {
+		logger.Error("EthTx Adapter Perform Resuming: ", err)
+	}
+	if receipt == nil {
+		return input.MarkPendingConfirmations(), nil
 	}
 
-	confirmed, err := store.TxManager.MeetsMinConfirmations(hash)
-	if err != nil {
-		logger.Error("EthTx Adapter Perform Resuming: ", err)
+	var status interface{}
+	if receipt.Status != nil {
+		status = uint64(*receipt.Status)
 	}
-	if !confirmed {
-		return input.MarkPendingConfirmations()
+	// The confirmed attempt may be a gas bump of the one first sent, so
+	// txHash is updated while value keeps the original hash.
+	return withTxData(input.WithValue(hash.String()),
+		"txHash", receipt.Hash.String(),
+		"blockNumber", receipt.BlockNumber.ToBig().Uint64(),
+		"status", status,
+	), receipt
+}
+
+// withTxData adds each key and value pair to the result's data, alongside
+// its value. The transaction has been sent by then, so any error is
+// permanent.
+func withTxData(input models.RunResult, keysAndValues ...interface{}) models.RunResult {
+	output := input
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		output = output.Add(keysAndValues[i].(string), keysAndValues[i+1])
+		if output.HasError() {
+			return input.WithError(models.NewPermanentError(errors.New(output.Error())))
+		}
+	}
+	return output
+}
+
+func bigString(i *big.Int) interface{} {
+	if i == nil {
+		return nil
 	}
-	return input.WithValue(hash.String())
+	return i.String()
 }
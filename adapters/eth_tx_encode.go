@@ -0,0 +1,105 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/tidwall/gjson"
+)
+
+// ethTxEncodeFormats are the ABI types EthTxEncode can encode an argument
+// as, and whether each is dynamic.
+var ethTxEncodeFormats = map[string]struct {
+	transcode func(gjson.Result) ([]byte, error)
+	dynamic   bool
+}{
+	"address": {utils.EVMTranscodeAddress, false},
+	"bool":    {utils.EVMTranscodeBool, false},
+	"bytes":   {utils.EVMTranscodeBytes, true},
+	"bytes32": {utils.EVMTranscodeBytes32, false},
+	"int256":  {utils.EVMTranscodeInt256, false},
+	"string":  {utils.EVMTranscodeString, true},
+	"uint256": {utils.EVMTranscodeUint256, false},
+}
+
+// EthTxEncodeArgument names the key of the run's data holding an argument,
+// and the ABI type it is encoded as.
+type EthTxEncodeArgument struct {
+	Name   ResultKey `json:"name"`
+	Format string    `json:"format"`
+}
+
+// EthTxEncode sends a transaction calling the function at FunctionSelector
+// on Address, with each of Arguments taken from the run's data, in order.
+type EthTxEncode struct {
+	Address          common.Address          `json:"address"`
+	FunctionSelector models.FunctionSelector `json:"functionSelector"`
+	Arguments        []EthTxEncodeArgument   `json:"arguments"`
+}
+
+// UnmarshalJSON validates the arguments as they're parsed, so that a
+// missing name or unknown format rejects the job spec when it is created.
+func (ete *EthTxEncode) UnmarshalJSON(input []byte) error {
+	type plain EthTxEncode
+	var aux plain
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+	for i, arg := range aux.Arguments {
+		if arg.Name == "" {
+			return fmt.Errorf("EthTxEncode argument %d must have a name", i)
+		}
+		if _, ok := ethTxEncodeFormats[arg.Format]; !ok {
+			return fmt.Errorf("EthTxEncode argument %q has unknown format %q", arg.Name, arg.Format)
+		}
+	}
+	*ete = EthTxEncode(aux)
+	return nil
+}
+
+// Perform sends the transaction, and follows it to confirmation, as EthTx
+// does.
+func (ete *EthTxEncode) Perform(input models.RunResult, store *store.Store) models.RunResult {
+	return ete.PerformCtx(context.Background(), input, store)
+}
+
+// PerformCtx is Perform, except that no transaction is sent once ctx is done.
+func (ete *EthTxEncode) PerformCtx(ctx context.Context, input models.RunResult, store *store.Store) models.RunResult {
+	if input.Status.PendingConfirmations() {
+		return ensureTxRunResult(input, store.TxManager)
+	}
+	if err := ctx.Err(); err != nil {
+		return input.WithError(fmt.Errorf("not sending transaction: %v", err))
+	}
+	data, err := ete.calldata(input)
+	if err != nil {
+		return input.WithError(models.NewPermanentError(err))
+	}
+	return sendTxRunResult(ete.Address, data, input, store.TxManager)
+}
+
+// calldata encodes the function selector followed by the arguments.
+func (ete *EthTxEncode) calldata(input models.RunResult) ([]byte, error) {
+	elements := make([]utils.EVMTupleElement, len(ete.Arguments))
+	for i, arg := range ete.Arguments {
+		format, ok := ethTxEncodeFormats[arg.Format]
+		if !ok {
+			return nil, fmt.Errorf("argument %q has unknown format %q", arg.Name, arg.Format)
+		}
+		val := input.Get(arg.Name.String())
+		if !val.Exists() {
+			return nil, fmt.Errorf("argument %q is missing from the run's data", arg.Name)
+		}
+		encoded, err := format.transcode(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode argument %q as %s: %v", arg.Name, arg.Format, err)
+		}
+		elements[i] = utils.EVMTupleElement{Encoded: encoded, Dynamic: format.dynamic}
+	}
+	return append(ete.FunctionSelector.Bytes(), utils.EVMEncodeTuple(elements)...), nil
+}
@@ -0,0 +1,169 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/mock_store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packWithABI returns the calldata go-ethereum's abi package encodes for a
+// function taking arguments of the given types.
+func packWithABI(t *testing.T, types []string, args ...interface{}) []byte {
+	var inputs []string
+	for _, typ := range types {
+		inputs = append(inputs, `{"name":"","type":"`+typ+`"}`)
+	}
+	definition := `[{"type":"function","name":"submit","inputs":[` + strings.Join(inputs, ",") + `]}]`
+	contract, err := abi.JSON(strings.NewReader(definition))
+	require.NoError(t, err)
+	data, err := contract.Pack("submit", args...)
+	require.NoError(t, err)
+	return data
+}
+
+func TestEthTxEncode_Perform(t *testing.T) {
+	t.Parallel()
+
+	address := cltest.NewAddress()
+	bytes33 := strings.Repeat("ab", 33)
+	var word [32]byte
+	copy(word[:], "ETH-USD")
+
+	tests := []struct {
+		name     string
+		formats  []string
+		data     string
+		wantArgs []interface{}
+	}{
+		{
+			"round and answer",
+			[]string{"uint256", "int256"},
+			`{"a0":"42","a1":-12345}`,
+			[]interface{}{big.NewInt(42), big.NewInt(-12345)},
+		},
+		{
+			"every static type",
+			[]string{"address", "bool", "bytes32", "int256", "uint256"},
+			`{"a0":"` + address.Hex() + `","a1":true,"a2":"ETH-USD","a3":"-0x10","a4":1e18}`,
+			[]interface{}{address, true, word, big.NewInt(-16), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)},
+		},
+		{
+			"dynamic between static",
+			[]string{"uint256", "string", "bytes", "bool"},
+			`{"a0":7,"a1":"hello, world","a2":"0x` + bytes33 + `","a3":false}`,
+			[]interface{}{big.NewInt(7), "hello, world", common.FromHex(bytes33), false},
+		},
+		{
+			"empty and whole word dynamic values",
+			[]string{"bytes", "string"},
+			`{"a0":"0x","a1":"` + strings.Repeat("x", 32) + `"}`,
+			[]interface{}{[]byte{}, strings.Repeat("x", 32)},
+		},
+		{
+			"no arguments",
+			[]string{},
+			`{}`,
+			[]interface{}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore()
+			defer cleanup()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			txmMock := mock_store.NewMockTxManager(ctrl)
+			store.TxManager = txmMock
+
+			want := packWithABI(t, test.formats, test.wantArgs...)
+			var args []adapters.EthTxEncodeArgument
+			for i, format := range test.formats {
+				args = append(args, adapters.EthTxEncodeArgument{Name: adapters.ResultKey(fmt.Sprintf("a%d", i)), Format: format})
+			}
+			adapter := adapters.EthTxEncode{
+				Address:          address,
+				FunctionSelector: models.BytesToFunctionSelector(want[:4]),
+				Arguments:        args,
+			}
+			tx := &models.Tx{TxAttempt: models.TxAttempt{Hash: cltest.NewHash()}}
+			txmMock.EXPECT().CreateTxWithGas(address, want, nil, uint64(0)).Return(tx, nil)
+
+			result := adapter.Perform(cltest.RunResultWithData(test.data), store)
+			require.NoError(t, result.GetError())
+			assert.True(t, result.Status.PendingConfirmations())
+			assert.Equal(t, tx.Hash.String(), result.Get("txHash").String())
+		})
+	}
+}
+
+func TestEthTxEncode_Perform_Errors(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name      string
+		arguments []adapters.EthTxEncodeArgument
+		data      string
+		wantError string
+	}{
+		{"missing key", []adapters.EthTxEncodeArgument{{"roundId", "uint256"}, {"answer", "int256"}}, `{"roundId":1}`, `argument "answer" is missing`},
+		{"negative uint", []adapters.EthTxEncodeArgument{{"roundId", "uint256"}}, `{"roundId":-1}`, `unable to encode argument "roundId" as uint256`},
+		{"bad address", []adapters.EthTxEncodeArgument{{"to", "address"}}, `{"to":"0x1234"}`, `unable to encode argument "to" as address`},
+		{"long bytes32", []adapters.EthTxEncodeArgument{{"id", "bytes32"}}, `{"id":"` + strings.Repeat("x", 33) + `"}`, `unable to encode argument "id" as bytes32`},
+		{"bad hex", []adapters.EthTxEncodeArgument{{"proof", "bytes"}}, `{"proof":"0xzz"}`, `unable to encode argument "proof" as bytes`},
+		{"not a string", []adapters.EthTxEncodeArgument{{"name", "string"}}, `{"name":12}`, `unable to encode argument "name" as string`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.EthTxEncode{Arguments: test.arguments}
+			result := adapter.Perform(cltest.RunResultWithData(test.data), store)
+			require.Error(t, result.GetError())
+			assert.Contains(t, result.Error(), test.wantError)
+		})
+	}
+}
+
+func TestEthTxEncode_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		params    string
+		wantError string
+	}{
+		{"valid", `{"functionSelector":"0xffffffff","arguments":[{"name":"roundId","format":"uint256"}]}`, ""},
+		{"unknown format", `{"arguments":[{"name":"roundId","format":"uint128"}]}`, `argument "roundId" has unknown format "uint128"`},
+		{"no format", `{"arguments":[{"name":"roundId"}]}`, `unknown format ""`},
+		{"no name", `{"arguments":[{"format":"uint256"}]}`, "argument 0 must have a name"},
+		{"dotted name", `{"arguments":[{"name":"a.b","format":"uint256"}]}`, "must not contain dots"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var adapter adapters.EthTxEncode
+			err := json.Unmarshal([]byte(test.params), &adapter)
+			if test.wantError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, []adapters.EthTxEncodeArgument{{"roundId", "uint256"}}, adapter.Arguments)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantError)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package adapters_test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
@@ -14,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEthTxAdapter_Perform_Confirmed(t *testing.T) {
@@ -63,8 +67,12 @@ func TestEthTxAdapter_Perform_Confirmed(t *testing.T) {
 	}
 	input := cltest.RunResultWithValue(inputValue)
 	data := adapter.Perform(input, store)
+	assert.False(t, data.HasError())
+	assert.True(t, data.Status.PendingConfirmations())
 
+	data = adapter.Perform(data, store)
 	assert.False(t, data.HasError())
+	assert.True(t, data.Status.Completed())
 
 	from := cltest.GetAccountAddress(store)
 	txs := []models.Tx{}
@@ -128,8 +136,12 @@ func TestEthTxAdapter_Perform_ConfirmedWithBytes(t *testing.T) {
 	}
 	input := cltest.RunResultWithValue(inputValue)
 	data := adapter.Perform(input, store)
+	assert.False(t, data.HasError())
+	assert.True(t, data.Status.PendingConfirmations())
 
+	data = adapter.Perform(data, store)
 	assert.False(t, data.HasError())
+	assert.True(t, data.Status.Completed())
 
 	from := cltest.GetAccountAddress(store)
 	txs := []models.Tx{}
@@ -276,6 +288,45 @@ func TestEthTxAdapter_Perform_WithError(t *testing.T) {
 	assert.Equal(t, "Cannot connect to nodes", output.Error())
 }
 
+func TestEthTxAdapter_Perform_SendError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		errMsg        string
+		wantPermanent bool
+	}{
+		{"fatal", "exceeds block gas limit", true},
+		{"insufficient funds", "insufficient funds for gas * price + value", false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			app, cleanup := cltest.NewApplicationWithKeyStore()
+			defer cleanup()
+			store := app.Store
+			ethMock := app.MockEthClient()
+			ethMock.Register("eth_getTransactionCount", `0x0100`)
+			require.NoError(t, app.Start())
+
+			adapter := adapters.EthTx{
+				Address:          cltest.NewAddress(),
+				FunctionSelector: models.HexToFunctionSelector("0xb3f98adc"),
+			}
+			ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+			ethMock.RegisterError("eth_sendRawTransaction", test.errMsg)
+			output := adapter.Perform(cltest.RunResultWithValue("0x9786856756"), store)
+
+			assert.True(t, output.HasError())
+			assert.Contains(t, output.Error(), test.errMsg)
+			assert.Equal(t, test.wantPermanent, output.Permanent())
+			ethMock.EventuallyAllCalled(t)
+		})
+	}
+}
+
 func TestEthTxAdapter_Perform_WithErrorInvalidInput(t *testing.T) {
 	t.Parallel()
 
@@ -322,19 +373,81 @@ func TestEthTxAdapter_Perform_PendingConfirmations_WithErrorInTxManager(t *testi
 	assert.False(t, output.HasError())
 }
 
+func TestEthTxAdapter_Perform_FromPendingConfirmations_Missing(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	hash := cltest.NewHash()
+	missing := &strpkg.TxMissingError{Hash: hash, SentAt: 100, BlockNumber: 340}
+	txmMock.EXPECT().ConfirmedTxReceipt(hash).Return(nil, missing)
+
+	input := cltest.RunResultWithValue(hash.String()).MarkPendingConfirmations()
+	output := (&adapters.EthTx{}).Perform(input, store)
+
+	assert.True(t, output.HasError())
+	assert.Contains(t, output.Error(), "still not mined at block 340")
+	assert.True(t, output.Permanent(), "a sent transaction is never retried")
+}
+
+func TestEthTxAdapter_PerformCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store.TxManager = mock_store.NewMockTxManager(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	adapter := adapters.EthTx{
+		Address:          cltest.NewAddress(),
+		FunctionSelector: models.HexToFunctionSelector("0xb3f98adc"),
+	}
+	output := adapter.PerformCtx(ctx, cltest.RunResultWithValue("0x9786856756"), store)
+
+	assert.True(t, output.HasError())
+	assert.Contains(t, output.Error(), "not sending transaction")
+}
+
+func TestEthTxAdapter_Perform_UnconfiguredChain(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	txmMock.EXPECT().ForChain(uint64(137)).Return(nil)
+	adapter := adapters.EthTx{
+		Address:          cltest.NewAddress(),
+		FunctionSelector: models.HexToFunctionSelector("0xb3f98adc"),
+		ChainID:          137,
+	}
+	output := adapter.Perform(cltest.RunResultWithValue("0x9786856756"), store)
+
+	assert.True(t, output.HasError())
+	assert.Equal(t, "node is not configured for chain 137", output.Error())
+	assert.True(t, output.Permanent())
+}
+
 func TestEthTxAdapter_DeserializationBytesFormat(t *testing.T) {
 	store, cleanup := cltest.NewStore()
 	defer cleanup()
 	ctrl := gomock.NewController(t)
 	txmMock := mock_store.NewMockTxManager(ctrl)
 	store.TxManager = txmMock
-	txmMock.EXPECT().CreateTx(gomock.Any(), []byte{
+	txmMock.EXPECT().CreateTxWithGas(gomock.Any(), []byte{
 		0, 0, 0, 0,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x40,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x0b,
 		0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x20, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	}).Return(&models.Tx{}, nil)
-	txmMock.EXPECT().MeetsMinConfirmations(gomock.Any())
+	}, nil, uint64(0)).Return(&models.Tx{}, nil)
 
 	task := models.TaskSpec{}
 	err := json.Unmarshal([]byte(`{"type": "EthTx", "params": {"format": "bytes"}}`), &task)
@@ -354,4 +467,259 @@ func TestEthTxAdapter_DeserializationBytesFormat(t *testing.T) {
 	result := adapter.Perform(input, store)
 	assert.False(t, result.HasError())
 	assert.Equal(t, result.Error(), "")
+	assert.True(t, result.Status.PendingConfirmations())
+}
+
+// A binary payload from an API reaches the contract as the bytes it encodes.
+func TestEthTxAdapter_Perform_Base64DecodedBytes(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	mock, cleanupMock := cltest.NewHTTPMockServer(t, 200, "GET", `{"data":{"blob":"3q2-7wD_AQ"}}`)
+	defer cleanupMock()
+
+	selector := []byte{0x76, 0x00, 0x5c, 0x26}
+	txmMock.EXPECT().CreateTxWithGas(gomock.Any(), append(selector, []byte{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x40,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x07,
+		0xde, 0xad, 0xbe, 0xef, 0x00, 0xff, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}...), nil, uint64(0)).Return(&models.Tx{}, nil)
+
+	tasks := []models.TaskSpec{
+		cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, mock.URL)),
+		cltest.NewTask("jsonparse", `{"path":["data","blob"]}`),
+		cltest.NewTask("base64decode", `{}`),
+		cltest.NewTask("ethtx", `{"functionSelector":"0x76005c26","format":"bytes"}`),
+	}
+	result := models.RunResult{Data: cltest.JSONFromString(`{}`)}
+	for _, task := range tasks {
+		adapter, err := adapters.For(task, store)
+		assert.NoError(t, err)
+		result = adapter.Perform(result, store)
+		assert.False(t, result.HasError(), result.Error())
+	}
+	assert.True(t, result.Status.PendingConfirmations())
+}
+
+func TestEthTxAdapter_Perform_AcceptsFormattedWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter adapters.BaseAdapter
+		value   string
+		want    string
+	}{
+		{"EthUint256", &adapters.EthUint256{}, `"0x7b"`,
+			"0x000000000000000000000000000000000000000000000000000000000000007b"},
+		{"EthInt256", &adapters.EthInt256{}, `-1`,
+			"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"EthBool", &adapters.EthBool{}, `true`,
+			"0x0000000000000000000000000000000000000000000000000000000000000001"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			store, cleanup := cltest.NewStore()
+			defer cleanup()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			txmMock := mock_store.NewMockTxManager(ctrl)
+			store.TxManager = txmMock
+
+			fHash := models.HexToFunctionSelector("b3f98adc")
+			wantData, err := utils.ConcatBytes(fHash.Bytes(), hexutil.MustDecode(test.want))
+			assert.NoError(t, err)
+			txmMock.EXPECT().CreateTxWithGas(gomock.Any(), wantData, nil, uint64(0)).Return(&models.Tx{}, nil)
+
+			input := models.RunResult{
+				Data:   cltest.JSONFromString(`{"value": %s}`, test.value),
+				Status: models.RunStatusInProgress,
+			}
+			formatted := test.adapter.Perform(input, store)
+			assert.NoError(t, formatted.GetError())
+
+			adapter := adapters.EthTx{Address: cltest.NewAddress(), FunctionSelector: fHash}
+			result := adapter.Perform(formatted, store)
+			assert.False(t, result.HasError())
+		})
+	}
+}
+
+// Each formatted output is written to its own key, and EthTx sends them as
+// separate arguments.
+func TestEthTxAdapter_Perform_DataKeys(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+	cltest.UseSettableClock(store).SetTime(time.Unix(0x5bdb3600, 0))
+
+	mock, cleanupMock := cltest.NewHTTPMockServer(t, 200, "GET", `{"data":{"price":"1.23"}}`)
+	defer cleanupMock()
+
+	selector := models.HexToFunctionSelector("0x76005c26")
+	wantData, err := utils.ConcatBytes(
+		selector.Bytes(),
+		hexutil.MustDecode("0x000000000000000000000000000000000000000000000000000000000000007b"),
+		hexutil.MustDecode("0x000000000000000000000000000000000000000000000000000000005bdb3600"),
+	)
+	assert.NoError(t, err)
+	txmMock.EXPECT().CreateTxWithGas(gomock.Any(), wantData, nil, uint64(0)).Return(&models.Tx{}, nil)
+
+	tasks := []models.TaskSpec{
+		cltest.NewTask("timestamp", `{"key":"value"}`),
+		cltest.NewTask("ethuint256", `{"resultKey":"timestamp"}`),
+		cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, mock.URL)),
+		cltest.NewTask("jsonparse", `{"path":["data","price"]}`),
+		cltest.NewTask("multiply", `{"times":100}`),
+		cltest.NewTask("ethuint256", `{"resultKey":"price"}`),
+		cltest.NewTask("ethtx", `{"functionSelector":"0x76005c26","dataKeys":["price","timestamp"]}`),
+	}
+	result := models.RunResult{Data: cltest.JSONFromString(`{}`)}
+	for _, task := range tasks {
+		adapter, err := adapters.For(task, store)
+		assert.NoError(t, err)
+		result = adapter.Perform(result, store)
+		assert.False(t, result.HasError(), result.Error())
+	}
+	assert.True(t, result.Status.PendingConfirmations())
+}
+
+func TestEthTxAdapter_Perform_MissingDataKey(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	adapter := adapters.EthTx{
+		FunctionSelector: models.HexToFunctionSelector("0x76005c26"),
+		DataKeys:         []adapters.ResultKey{"price", "timestamp"},
+	}
+	result := adapter.Perform(cltest.RunResultWithData(`{"price":"0x7b"}`), store)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), `"timestamp"`)
+}
+
+func TestEthTxAdapter_Perform_EnrichedResult(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	from := cltest.NewAddress()
+	sent := cltest.NewHash()
+	bumped := cltest.NewHash()
+	tx := &models.Tx{
+		From:      from,
+		Nonce:     7,
+		TxAttempt: models.TxAttempt{Hash: sent, GasPrice: big.NewInt(20000000000)},
+	}
+	txmMock.EXPECT().CreateTxWithGas(gomock.Any(), gomock.Any(), nil, uint64(0)).Return(tx, nil)
+
+	adapter := adapters.EthTx{Address: cltest.NewAddress(), FunctionSelector: models.HexToFunctionSelector("b3f98adc")}
+	pending := adapter.Perform(cltest.RunResultWithValue("0x01"), store)
+
+	assert.NoError(t, pending.GetError())
+	assert.True(t, pending.Status.PendingConfirmations())
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"value": "%[1]s",
+		"txHash": "%[1]s",
+		"nonce": 7,
+		"gasPrice": "20000000000",
+		"from": "%[2]s"
+	}`, sent.Hex(), from.Hex()), pending.Data.String())
+
+	status := hexutil.Uint64(1)
+	receipt := &strpkg.TxReceipt{Hash: bumped, BlockNumber: cltest.Int(23456), Status: &status}
+	txmMock.EXPECT().ConfirmedTxReceipt(sent).Return(receipt, nil)
+
+	confirmed := adapter.Perform(pending, store)
+
+	assert.NoError(t, confirmed.GetError())
+	assert.True(t, confirmed.Status.Completed())
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"value": "%[1]s",
+		"txHash": "%[2]s",
+		"nonce": 7,
+		"gasPrice": "20000000000",
+		"from": "%[3]s",
+		"blockNumber": 23456,
+		"status": 1
+	}`, sent.Hex(), bumped.Hex(), from.Hex()), confirmed.Data.String())
+}
+
+func TestEthTxAdapter_Perform_GasOverrides(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	var adapter adapters.EthTx
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"address": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+		"functionSelector": "0xb3f98adc",
+		"gasPrice": "30000000000",
+		"gasLimit": 700000
+	}`), &adapter))
+
+	tx := &models.Tx{TxAttempt: models.TxAttempt{Hash: cltest.NewHash()}}
+	txmMock.EXPECT().CreateTxWithGas(adapter.Address, gomock.Any(), big.NewInt(30000000000), uint64(700000)).Return(tx, nil)
+
+	result := adapter.Perform(cltest.RunResultWithValue("0x01"), store)
+	assert.NoError(t, result.GetError())
+	assert.True(t, result.Status.PendingConfirmations())
+}
+
+func TestEthTx_UnmarshalJSON(t *testing.T) {
+	word := "0x0000000000000000000000000000000000000000000000000045746736453745"
+	tests := []struct {
+		name    string
+		params  string
+		wantErr string
+	}{
+		{"valid", `{"address":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42","functionSelector":"0x76005c26","dataPrefix":"` + word + `"}`, ""},
+		{"no 0x on selector", `{"functionSelector":"76005c26"}`, ""},
+		{"two word prefix", `{"functionSelector":"0x76005c26","dataPrefix":"` + word + word[2:] + `"}`, ""},
+		{"bytes format", `{"functionSelector":"0x76005c26","format":"bytes"}`, ""},
+		{"nothing set", `{}`, ""},
+		{"odd length selector", `{"functionSelector":"0x76005c2"}`, "functionSelector"},
+		{"long selector", `{"functionSelector":"0x76005c2600"}`, "functionSelector"},
+		{"non hex selector", `{"functionSelector":"0x76005c2g"}`, "functionSelector"},
+		{"non hex prefix", `{"dataPrefix":"0xzz"}`, "dataPrefix"},
+		{"short prefix", `{"dataPrefix":"0x0017"}`, "dataPrefix must be a multiple of 32 bytes"},
+		{"unknown format", `{"format":"uint256"}`, "format"},
+		{"data keys", `{"functionSelector":"0x76005c26","dataKeys":["price","timestamp"]}`, ""},
+		{"data keys with bytes format", `{"format":"bytes","dataKeys":["price"]}`, "dataKeys"},
+		{"dotted data key", `{"dataKeys":["price.usd"]}`, "dots"},
+		{"gas overrides", `{"gasPrice":30000000000,"gasLimit":700000}`, ""},
+		{"gas price string", `{"gasPrice":"1500000000000000000000"}`, ""},
+		{"zero gas price", `{"gasPrice":0}`, "gasPrice"},
+		{"fractional gas price", `{"gasPrice":"1.5"}`, "gasPrice"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var etx adapters.EthTx
+			err := json.Unmarshal([]byte(test.params), &etx)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
 }
@@ -0,0 +1,9 @@
+package adapters
+
+import "net"
+
+func ExportedIsRestrictedIP(ip net.IP) bool {
+	return isRestrictedIP(ip)
+}
+
+const SignBurst = signBurst
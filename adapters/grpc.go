@@ -0,0 +1,118 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+const defaultGRPCTimeout = 15 * time.Second
+
+// GRPC makes a unary call to a gRPC service. The method's request and
+// response types are discovered through the server's reflection service, so
+// the server must have reflection enabled.
+type GRPC struct {
+	Endpoint    string         `json:"endpoint"`
+	Service     string         `json:"service"`
+	Method      string         `json:"method"`
+	RequestJSON models.JSON    `json:"requestJSON"`
+	TLSCertFile string         `json:"tlsCertFile"`
+	Timeout     store.Duration `json:"timeout"`
+}
+
+// GRPCStatusError is returned when the called method completes with a
+// status code other than OK.
+type GRPCStatusError struct {
+	Code    codes.Code
+	Message string
+}
+
+func (e GRPCStatusError) Error() string {
+	return fmt.Sprintf("grpc call failed with code %s: %s", e.Code, e.Message)
+}
+
+// Perform converts RequestJSON into the method's request message, invokes
+// the method and returns the response message encoded as JSON in the
+// "value" field of the result.
+func (ga *GRPC) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	ctx, cancel := context.WithTimeout(context.Background(), ga.timeout())
+	defer cancel()
+
+	conn, err := ga.dial(ctx)
+	if err != nil {
+		return input.WithError(err)
+	}
+	defer conn.Close()
+
+	refClient := grpcreflect.NewClient(ctx, rpb.NewServerReflectionClient(conn))
+	defer refClient.Reset()
+
+	sd, err := refClient.ResolveService(ga.Service)
+	if err != nil {
+		return input.WithError(err)
+	}
+	md := sd.FindMethodByName(ga.Method)
+	if md == nil {
+		return input.WithError(fmt.Errorf("service %s has no method %s", ga.Service, ga.Method))
+	}
+	if md.IsClientStreaming() || md.IsServerStreaming() {
+		return input.WithError(fmt.Errorf("method %s is not a unary method", ga.Method))
+	}
+
+	request := dynamic.NewMessage(md.GetInputType())
+	if !ga.RequestJSON.Empty() {
+		if err = request.UnmarshalJSON(ga.RequestJSON.Bytes()); err != nil {
+			return input.WithError(err)
+		}
+	}
+
+	response, err := grpcdynamic.NewStub(conn).InvokeRpc(ctx, md, request)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return input.WithError(GRPCStatusError{Code: st.Code(), Message: st.Message()})
+		}
+		return input.WithError(err)
+	}
+
+	dm, err := dynamic.AsDynamicMessage(response)
+	if err != nil {
+		return input.WithError(err)
+	}
+	body, err := dm.MarshalJSON()
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(string(body))
+}
+
+func (ga *GRPC) dial(ctx context.Context) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	if ga.TLSCertFile != "" {
+		creds, err := credentials.NewClientTLSFromFile(ga.TLSCertFile, "")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	return grpc.DialContext(ctx, ga.Endpoint, opts...)
+}
+
+func (ga *GRPC) timeout() time.Duration {
+	if ga.Timeout.Duration > 0 {
+		return ga.Timeout.Duration
+	}
+	return defaultGRPCTimeout
+}
@@ -0,0 +1,88 @@
+package adapters_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func newGRPCHealthServer(t *testing.T) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	hs := health.NewServer()
+	hs.SetServingStatus("oracle", healthpb.HealthCheckResponse_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, hs)
+	reflection.Register(server)
+	go server.Serve(listener)
+
+	return listener.Addr().String(), server.Stop
+}
+
+func TestGRPC_Perform(t *testing.T) {
+	endpoint, cleanup := newGRPCHealthServer(t)
+	defer cleanup()
+
+	tests := []struct {
+		name        string
+		service     string
+		method      string
+		request     string
+		want        string
+		wantErrored bool
+	}{
+		{"serving", "grpc.health.v1.Health", "Check", `{"service":"oracle"}`, `{"status":"SERVING"}`, false},
+		{"unknown method", "grpc.health.v1.Health", "Ping", `{}`, "inputValue", true},
+		{"unknown service", "grpc.health.v1.Nope", "Check", `{}`, "inputValue", true},
+		{"streaming method", "grpc.health.v1.Health", "Watch", `{}`, "inputValue", true},
+		{"non-OK status", "grpc.health.v1.Health", "Check", `{"service":"missing"}`, "inputValue", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ga := adapters.GRPC{
+				Endpoint:    endpoint,
+				Service:     test.service,
+				Method:      test.method,
+				RequestJSON: cltest.JSONFromString(test.request),
+			}
+			result := ga.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestGRPCStatusError(t *testing.T) {
+	err := adapters.GRPCStatusError{Code: codes.NotFound, Message: "unknown service"}
+	assert.Equal(t, "grpc call failed with code NotFound: unknown service", err.Error())
+}
+
+func TestGRPC_Perform_Unreachable(t *testing.T) {
+	ga := adapters.GRPC{
+		Endpoint: "127.0.0.1:1",
+		Service:  "grpc.health.v1.Health",
+		Method:   "Check",
+		Timeout:  store.Duration{Duration: cltest.MustParseDuration("100ms")},
+	}
+	result := ga.Perform(models.RunResult{}, nil)
+	assert.True(t, result.HasError())
+}
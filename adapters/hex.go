@@ -0,0 +1,79 @@
+package adapters
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/tidwall/gjson"
+)
+
+// HexEncode converts the run's string value to hex.
+type HexEncode struct{}
+
+// Perform returns the input's value as a 0x prefixed hex string of its bytes,
+// so that "hi" becomes "0x6869".
+func (*HexEncode) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val, err := hexAdapterInput("HexEncode", input)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(utils.StringToHex(val))
+}
+
+// HexDecode converts the run's hex value back to a string.
+type HexDecode struct{}
+
+// Perform returns the text whose bytes the input's value, a hex string with
+// or without a 0x prefix, encodes. Any character which is not a hex digit, an
+// odd number of digits, or bytes which are not UTF-8 text, are errors.
+func (*HexDecode) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val, err := hexAdapterInput("HexDecode", input)
+	if err != nil {
+		return input.WithError(err)
+	}
+	decoded, err := decodeHexStrict(val)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(decoded)
+}
+
+// hexAdapterInput returns the input's string value, pointing users who pass a
+// number towards the adapters which format numbers for Ethereum.
+func hexAdapterInput(adapter string, input models.RunResult) (string, error) {
+	if value := input.Get("value"); value.Type == gjson.Number {
+		return "", fmt.Errorf("%s cannot convert the number %s, use the EthBytes32, EthInt256 or EthUint256 adapters to format numbers", adapter, value.Raw)
+	}
+	return input.Value()
+}
+
+func decodeHexStrict(val string) (string, error) {
+	digits := utils.RemoveHexPrefix(val)
+	offset := len(val) - len(digits)
+	for i, r := range digits {
+		if !isHexDigit(r) {
+			return "", fmt.Errorf("invalid hex character %q at position %d", r, offset+i)
+		}
+	}
+	if len(digits)%2 != 0 {
+		return "", fmt.Errorf("hex input has an odd number of digits (%d)", len(digits))
+	}
+
+	decoded, err := hex.DecodeString(digits)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(decoded) {
+		return "", errors.New("decoded hex is not valid UTF-8 text")
+	}
+	return string(decoded), nil
+}
+
+func isHexDigit(r rune) bool {
+	return ('0' <= r && r <= '9') || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')
+}
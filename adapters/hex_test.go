@@ -0,0 +1,86 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHex_Perform_RoundTrip(t *testing.T) {
+	inputs := []string{"", "a", "hi", "hello, world", "ünïcödé ✓", "{\"price\":\"212.54\"}"}
+
+	for _, in := range inputs {
+		input := in
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			encoded := (&adapters.HexEncode{}).Perform(cltest.RunResultWithValue(input), nil)
+			assert.False(t, encoded.HasError(), encoded.Error())
+
+			decoded := (&adapters.HexDecode{}).Perform(encoded, nil)
+			assert.False(t, decoded.HasError(), decoded.Error())
+
+			val, err := decoded.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, input, val)
+		})
+	}
+}
+
+func TestHexEncode_Perform(t *testing.T) {
+	t.Parallel()
+	result := (&adapters.HexEncode{}).Perform(cltest.RunResultWithValue("hi"), nil)
+	assert.False(t, result.HasError(), result.Error())
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x6869", val)
+}
+
+func TestHexDecode_Perform(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"prefixed", "0x6869", "hi", ""},
+		{"unprefixed", "6869", "hi", ""},
+		{"upper case", "0x4A4B", "JK", ""},
+		{"empty", "0x", "", ""},
+		{"odd length", "0x686", "", "hex input has an odd number of digits (3)"},
+		{"non hex", "0x68zz", "", `invalid hex character 'z' at position 4`},
+		{"non hex unprefixed", "6g", "", `invalid hex character 'g' at position 1`},
+		{"binary data", "0xfffe", "", "decoded hex is not valid UTF-8 text"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result := (&adapters.HexDecode{}).Perform(cltest.RunResultWithValue(test.input), nil)
+			if test.wantErr != "" {
+				assert.True(t, result.HasError())
+				assert.Equal(t, test.wantErr, result.Error())
+				return
+			}
+			assert.False(t, result.HasError(), result.Error())
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+		})
+	}
+}
+
+func TestHex_Perform_RejectsNumbers(t *testing.T) {
+	t.Parallel()
+	input := models.RunResult{Data: cltest.JSONFromString(`{"value":42}`)}
+
+	for _, adapter := range []adapters.BaseAdapter{&adapters.HexEncode{}, &adapters.HexDecode{}} {
+		result := adapter.Perform(input, nil)
+		assert.True(t, result.HasError())
+		assert.Contains(t, result.Error(), "the number 42")
+		assert.Contains(t, result.Error(), "EthUint256")
+	}
+}
@@ -2,45 +2,92 @@ package adapters
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
 )
 
+// Fallbacks for when the adapter is performed without a store, mirroring the
+// defaults of the corresponding Config fields.
+const (
+	defaultHTTPTimeout         = 15 * time.Second
+	defaultHTTPLimit           = 4 * 1024 * 1024
+	defaultHTTPRetryAttempts   = 3
+	defaultHTTPRetryMinBackoff = 1 * time.Second
+	defaultHTTPRetryMaxBackoff = 10 * time.Second
+)
+
+// defaultHTTPMaxRedirects is how many redirects are followed when a task
+// does not set its own limit, as with net/http.
+const defaultHTTPMaxRedirects = 10
+
 // HTTPGet requires a URL which is used for a GET request when the adapter is called.
+// Requests failing with a connection error or a 5xx status are retried.
+// With a CacheTTL, the response is kept in the store's HTTPCache and reused
+// by identical requests for that long.
+// Up to MaxRedirects redirects are followed, unless FollowRedirects is
+// false, in which case a redirect fails the request.
 type HTTPGet struct {
-	URL models.WebURL `json:"url"`
-	GET models.WebURL `json:"get"`
+	URL             models.WebURL  `json:"url"`
+	GET             models.WebURL  `json:"get"`
+	Timeout         store.Duration `json:"timeout"`
+	Auth            string         `json:"auth"`
+	ResultKey       ResultKey      `json:"resultKey"`
+	CacheTTL        store.Duration `json:"cacheTTL"`
+	FollowRedirects *bool          `json:"followRedirects"`
+	MaxRedirects    int            `json:"maxRedirects"`
 }
 
 // Perform ensures that the adapter's URL responds to a GET request without
-// errors and returns the response body as the "value" field of the result.
-func (hga *HTTPGet) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	tr := &http.Transport{
-		DisableCompression: true,
-	}
-	client := &http.Client{Transport: tr}
-	response, err := client.Get(hga.GetURL())
-	if err != nil {
-		return input.WithError(err)
-	}
-
-	defer response.Body.Close()
+// errors and returns the response body as the "value" field of the result,
+// or the field named by ResultKey. A response taken from the cache also sets
+// "cached" to true, and a redirected request sets "finalURL" to the URL the
+// body came from.
+func (hga *HTTPGet) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return hga.PerformCtx(context.Background(), input, str)
+}
 
-	bytes, err := ioutil.ReadAll(response.Body)
-	body := string(bytes)
+// PerformCtx is Perform, abandoning the request and any retries once ctx is
+// done.
+func (hga *HTTPGet) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	credential, err := findHTTPCredential(str, hga.Auth, hga.GetURL())
 	if err != nil {
 		return input.WithError(err)
 	}
-
-	if response.StatusCode >= 400 {
-		return input.WithError(fmt.Errorf(body))
+	newRequest := func() (*http.Request, error) {
+		request, err := http.NewRequest("GET", hga.GetURL(), nil)
+		if err != nil {
+			return nil, err
+		}
+		setAuthorization(request, credential)
+		return request, nil
 	}
-
-	return input.WithValue(body)
+	config := newHTTPRequestConfig(str, hga.Timeout)
+	config.retry = true
+	config.resultKey = hga.ResultKey
+	config.noRedirects = hga.FollowRedirects != nil && !*hga.FollowRedirects
+	if hga.MaxRedirects > 0 {
+		config.maxRedirects = hga.MaxRedirects
+	}
+	if hga.CacheTTL.Duration <= 0 || str == nil {
+		return sendRequest(ctx, input, newRequest, config)
+	}
+	return sendCachedRequest(ctx, input, newRequest, config, str, hga.CacheTTL.Duration)
 }
 
 // GetURL retrieves the GET field if set otherwise returns the URL field
@@ -52,43 +99,397 @@ func (hga *HTTPGet) GetURL() string {
 }
 
 // HTTPPost requires a URL which is used for a POST request when the adapter is called.
+// Since a POST may not be safe to repeat, failed requests are only retried
+// when RetryOn5xx is set.
 type HTTPPost struct {
-	URL  models.WebURL `json:"url"`
-	POST models.WebURL `json:"post"`
+	URL        models.WebURL  `json:"url"`
+	POST       models.WebURL  `json:"post"`
+	Timeout    store.Duration `json:"timeout"`
+	RetryOn5xx bool           `json:"retryOn5xx"`
+	Auth       string         `json:"auth"`
+	ResultKey  ResultKey      `json:"resultKey"`
 }
 
 // Perform ensures that the adapter's URL responds to a POST request without
-// errors and returns the response body as the "value" field of the result.
-func (hpa *HTTPPost) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+// errors and returns the response body as the "value" field of the result,
+// or the field named by ResultKey.
+func (hpa *HTTPPost) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return hpa.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx is Perform, abandoning the request and any retries once ctx is
+// done.
+func (hpa *HTTPPost) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	credential, err := findHTTPCredential(str, hpa.Auth, hpa.GetURL())
+	if err != nil {
+		return input.WithError(err)
+	}
+	newRequest := func() (*http.Request, error) {
+		reqBody := bytes.NewBufferString(input.Data.String())
+		request, err := http.NewRequest("POST", hpa.GetURL(), reqBody)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		setAuthorization(request, credential)
+		return request, nil
+	}
+	config := newHTTPRequestConfig(str, hpa.Timeout)
+	config.retry = hpa.RetryOn5xx
+	config.resultKey = hpa.ResultKey
+	return sendRequest(ctx, input, newRequest, config)
+}
+
+// GetURL retrieves the POST field if set otherwise returns the URL field
+func (hpa *HTTPPost) GetURL() string {
+	if hpa.POST.String() != "" {
+		return hpa.POST.String()
+	}
+	return hpa.URL.String()
+}
+
+// findHTTPCredential resolves the credential named by a task's "auth" param,
+// returning nil when the task does not reference one, and an error when the
+// credential may not be sent to url.
+func findHTTPCredential(str *store.Store, name, url string) (*models.HTTPCredential, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if str == nil {
+		return nil, fmt.Errorf("unable to look up credential %s without a store", name)
+	}
+	credential, err := str.FindHTTPCredential(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find credential %s: %v", name, err)
+	}
+	if !credential.Allows(url) {
+		return nil, fmt.Errorf("credential %s may only be sent to %s", name, credential.URLPrefix)
+	}
+	return &credential, nil
+}
+
+func setAuthorization(request *http.Request, credential *models.HTTPCredential) {
+	if credential != nil {
+		request.Header.Set("Authorization", credential.AuthorizationHeader())
+	}
+}
+
+// httpRequestConfig bounds how long a request may take, how much of the
+// response body is read, how often a failed request is attempted, and how
+// many redirects are followed.
+type httpRequestConfig struct {
+	timeout      time.Duration
+	responseSize int64
+	retry        bool
+	attempts     uint64
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	restricted   bool
+	noRedirects  bool
+	maxRedirects int
+	resultKey    ResultKey
+}
+
+// newHTTPRequestConfig uses the task's timeout when given, and falls back to
+// the node's configured defaults otherwise. Retries are disabled until the
+// caller opts in. Performing without a store applies no network restrictions.
+func newHTTPRequestConfig(str *store.Store, timeout store.Duration) httpRequestConfig {
+	config := httpRequestConfig{
+		timeout:      defaultHTTPTimeout,
+		responseSize: defaultHTTPLimit,
+		attempts:     defaultHTTPRetryAttempts,
+		minBackoff:   defaultHTTPRetryMinBackoff,
+		maxBackoff:   defaultHTTPRetryMaxBackoff,
+		maxRedirects: defaultHTTPMaxRedirects,
+	}
+	if str != nil {
+		config.timeout = str.CurrentConfig().DefaultHTTPTimeout.Duration
+		config.responseSize = int64(str.Config.DefaultHTTPLimit)
+		config.attempts = str.Config.HTTPRetryAttempts
+		config.minBackoff = str.Config.HTTPRetryMinBackoff.Duration
+		config.maxBackoff = str.Config.HTTPRetryMaxBackoff.Duration
+		config.restricted = !str.Config.AllowUnrestrictedNetworkAccess
+	}
+	if timeout.Duration > 0 {
+		config.timeout = timeout.Duration
+	}
+	return config
+}
+
+// client returns an HTTP client honouring the configured timeout. When the
+// network is restricted, every hop of the request, redirects included, is
+// checked against the address and scheme restrictions.
+func (config httpRequestConfig) client() *http.Client {
 	tr := &http.Transport{
 		DisableCompression: true,
 	}
-	client := &http.Client{Transport: tr}
-	reqBody := bytes.NewBufferString(input.Data.String())
-	response, err := client.Post(hpa.GetURL(), "application/json", reqBody)
+	if !config.restricted {
+		return &http.Client{Transport: tr, Timeout: config.timeout, CheckRedirect: config.checkRedirect}
+	}
+	tr.DialContext = restrictedDialContext
+	return &http.Client{
+		Transport:     restrictedTransport{tr},
+		Timeout:       config.timeout,
+		CheckRedirect: config.checkRedirect,
+	}
+}
+
+// checkRedirect stops the client following more than maxRedirects
+// redirects, or any when they are turned off, in which case the redirect
+// response itself is returned.
+func (config httpRequestConfig) checkRedirect(request *http.Request, via []*http.Request) error {
+	if config.noRedirects {
+		return http.ErrUseLastResponse
+	}
+	if len(via) > config.maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", config.maxRedirects)
+	}
+	return nil
+}
+
+// sendRequest sends the request built by newRequest, and writes the
+// response body to the result.
+func sendRequest(
+	ctx context.Context,
+	input models.RunResult,
+	newRequest func() (*http.Request, error),
+	config httpRequestConfig,
+) models.RunResult {
+	response, err := fetchBody(ctx, newRequest, config)
 	if err != nil {
 		return input.WithError(err)
 	}
+	return config.writeResponse(input, response.body, response.url)
+}
 
-	defer response.Body.Close()
+// writeResponse writes body to the result, along with the URL it was
+// fetched from when the request was redirected.
+func (config httpRequestConfig) writeResponse(input models.RunResult, body, finalURL string) models.RunResult {
+	output := config.resultKey.write(input, body)
+	if finalURL != "" {
+		output = output.Add("finalURL", finalURL)
+	}
+	return output
+}
 
-	bytes, err := ioutil.ReadAll(response.Body)
-	body := string(bytes)
+// sendCachedRequest is sendRequest, taking the body from the store's
+// HTTPCache when an identical request was made within ttl.
+func sendCachedRequest(
+	ctx context.Context,
+	input models.RunResult,
+	newRequest func() (*http.Request, error),
+	config httpRequestConfig,
+	str *store.Store,
+	ttl time.Duration,
+) models.RunResult {
+	request, err := newRequest()
+	if err != nil {
+		return input.WithError(err)
+	}
+	key := httpCacheKey(request)
+	response, cached, err := str.HTTPCache.Fetch(key, str.Clock.Now(), ttl, func() (store.CachedResponse, error) {
+		response, err := fetchBody(ctx, newRequest, config)
+		return store.CachedResponse{Body: response.body, URL: response.url}, err
+	})
 	if err != nil {
 		return input.WithError(err)
 	}
+	output := config.writeResponse(input, response.Body, response.URL)
+	if cached {
+		output = output.Add("cached", true)
+	}
+	return output
+}
+
+// httpCacheKey identifies a request by a hash of its method, URL and
+// headers, so that requests made with different credentials are not shared.
+func httpCacheKey(request *http.Request) string {
+	names := make([]string, 0, len(request.Header))
+	for name := range request.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", request.Method, request.URL)
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s: %s\n", name, strings.Join(request.Header[name], ","))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// fetchBody sends the request built by newRequest, attempting it again
+// after a backoff if it fails in a way worth retrying, and returns the
+// response. The request is made with ctx, and no further attempts are made
+// once ctx is done.
+func fetchBody(
+	ctx context.Context,
+	newRequest func() (*http.Request, error),
+	config httpRequestConfig,
+) (httpResponse, error) {
+	client := config.client()
+	sleeper := utils.NewBoundedBackoffSleeper(config.minBackoff, config.maxBackoff)
+
+	var attempt uint64
+	for {
+		attempt++
+		request, err := newRequest()
+		if err != nil {
+			return httpResponse{}, err
+		}
+		request = request.WithContext(ctx)
+		observability.InjectHeaders(ctx, request.Header)
+
+		response, err := doRequest(client, request, config)
+		if err == nil {
+			return response, nil
+		}
+
+		if !config.retry || attempt >= config.attempts || !response.retryable || ctx.Err() != nil {
+			err = fmt.Errorf("%v (status code %d, %d attempt(s))", err, response.statusCode, attempt)
+			if response.statusCode >= 400 && response.statusCode < 500 {
+				// The server rejected the request, and would again.
+				err = models.NewPermanentError(err)
+			}
+			return httpResponse{}, err
+		}
+		select {
+		case <-time.After(sleeper.After()):
+		case <-ctx.Done():
+			return httpResponse{}, fmt.Errorf(
+				"%v (status code %d, %d attempt(s), then %v)", err, response.statusCode, attempt, ctx.Err())
+		}
+	}
+}
+
+// httpResponse is the outcome of a single request. url is set to where the
+// body came from when the request was redirected.
+type httpResponse struct {
+	body       string
+	url        string
+	statusCode int
+	retryable  bool
+}
+
+// doRequest sends a single request, flagging the failures which are worth
+// attempting again: connection errors and 5xx responses. A redirect which
+// is not followed fails with its Location.
+func doRequest(client *http.Client, request *http.Request, config httpRequestConfig) (httpResponse, error) {
+	limit := config.responseSize
+	response, err := client.Do(request)
+	if err != nil {
+		return httpResponse{retryable: isNetworkError(err)}, err
+	}
 
+	defer response.Body.Close()
+
+	result := httpResponse{statusCode: response.StatusCode}
+	source := io.LimitReader(response.Body, limit+1)
+	bytes, err := ioutil.ReadAll(source)
+	if err != nil {
+		result.retryable = isNetworkError(err)
+		return result, err
+	}
+
+	if int64(len(bytes)) > limit {
+		return result, fmt.Errorf("HTTP response too large, must be less than %d bytes", limit)
+	}
+
+	result.body = string(bytes)
+	if location := response.Header.Get("Location"); config.noRedirects && location != "" &&
+		response.StatusCode >= 300 && response.StatusCode < 400 {
+		return result, fmt.Errorf("redirected to %s, which followRedirects does not allow", location)
+	}
+	if final := response.Request.URL.String(); final != request.URL.String() {
+		result.url = final
+	}
 	if response.StatusCode >= 400 {
-		return input.WithError(fmt.Errorf(body))
+		result.retryable = response.StatusCode >= 500
+		return result, errors.New(result.body)
 	}
 
-	return input.WithValue(body)
+	return result, nil
 }
 
-// GetURL retrieves the POST field if set otherwise returns the URL field
-func (hpa *HTTPPost) GetURL() string {
-	if hpa.POST.String() != "" {
-		return hpa.POST.String()
+func isNetworkError(err error) bool {
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
 	}
-	return hpa.URL.String()
+	_, ok := err.(net.Error)
+	return ok
+}
+
+// restrictedTransport refuses any request which is not made over http or https.
+type restrictedTransport struct {
+	http.RoundTripper
+}
+
+func (rt restrictedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.URL.Scheme != "http" && request.URL.Scheme != "https" {
+		return nil, blockedByPolicyError(request.URL.String())
+	}
+	return rt.RoundTripper.RoundTrip(request)
+}
+
+// restrictedDialContext resolves the host and refuses to connect if any of
+// its addresses are internal, then dials the address it checked so that a
+// second lookup cannot be pointed elsewhere.
+func restrictedDialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s", host)
+	}
+	for _, addr := range addrs {
+		if isRestrictedIP(addr.IP) {
+			return nil, blockedByPolicyError(host)
+		}
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+}
+
+var restrictedNetworks = mustParseCIDRs(
+	"10.0.0.0/8",     // RFC1918
+	"172.16.0.0/12",  // RFC1918
+	"192.168.0.0/16", // RFC1918
+	"100.64.0.0/10",  // carrier grade NAT
+	"fc00::/7",       // unique local
+)
+
+func isRestrictedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range restrictedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+func blockedByPolicyError(destination string) error {
+	return fmt.Errorf(
+		"destination %s blocked by network access policy, set ALLOW_UNRESTRICTED_NETWORK_ACCESS=true to allow requests to internal addresses",
+		destination)
 }
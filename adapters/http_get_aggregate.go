@@ -0,0 +1,147 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	simplejson "github.com/bitly/go-simplejson"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// HTTPGetAggregate fetches the same data point from several sources and
+// returns their median, so that no single source can decide the answer.
+type HTTPGetAggregate struct {
+	URLs []models.WebURL `json:"urls"`
+	Path JSONPath        `json:"path"`
+	// Quorum is how many sources must succeed, defaulting to a majority.
+	Quorum  int            `json:"quorum"`
+	Timeout store.Duration `json:"timeout"`
+}
+
+type aggregateSource struct {
+	URL   string `json:"url"`
+	Value string `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+	value *big.Rat
+}
+
+// Perform GETs every URL concurrently, parses the decimal at Path from each
+// response, and returns the median of those which succeeded as the "value"
+// field of the result. The values from each source are listed under
+// "values", and the sources which failed under "failures".
+//
+// If fewer than Quorum sources succeed the run is errored with every
+// source's error.
+func (hga *HTTPGetAggregate) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return hga.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx is Perform, abandoning any requests still in flight once ctx is
+// done.
+func (hga *HTTPGetAggregate) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	quorum, err := hga.quorum()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	sources := make([]aggregateSource, len(hga.URLs))
+	var wg sync.WaitGroup
+	for i, u := range hga.URLs {
+		wg.Add(1)
+		go func(i int, rawURL string) {
+			defer wg.Done()
+			sources[i] = hga.fetch(ctx, rawURL, str)
+		}(i, u.String())
+	}
+	wg.Wait()
+
+	var values []*big.Rat
+	succeeded, failed := []aggregateSource{}, []aggregateSource{}
+	for _, source := range sources {
+		if source.Error != "" {
+			failed = append(failed, source)
+		} else {
+			succeeded = append(succeeded, source)
+			values = append(values, source.value)
+		}
+	}
+
+	if len(succeeded) < quorum {
+		msgs := make([]string, len(failed))
+		for i, source := range failed {
+			msgs[i] = fmt.Sprintf("%s: %s", source.URL, source.Error)
+		}
+		return input.WithError(fmt.Errorf("only %d of %d sources succeeded, %d required: %s",
+			len(succeeded), len(sources), quorum, strings.Join(msgs, "; ")))
+	}
+
+	output := input.WithValue(formatDecimal(median(values))).Add("values", succeeded)
+	if output.HasError() {
+		return output
+	}
+	return output.Add("failures", failed)
+}
+
+func (hga *HTTPGetAggregate) quorum() (int, error) {
+	if len(hga.URLs) == 0 {
+		return 0, errors.New("HTTPGetAggregate requires at least one url")
+	}
+	if hga.Quorum == 0 {
+		return len(hga.URLs)/2 + 1, nil
+	}
+	if hga.Quorum < 0 || hga.Quorum > len(hga.URLs) {
+		return 0, fmt.Errorf("quorum must be between 1 and the number of urls (%d), got %d", len(hga.URLs), hga.Quorum)
+	}
+	return hga.Quorum, nil
+}
+
+func (hga *HTTPGetAggregate) fetch(ctx context.Context, rawURL string, str *store.Store) aggregateSource {
+	source := aggregateSource{URL: rawURL}
+	newRequest := func() (*http.Request, error) {
+		return http.NewRequest("GET", rawURL, nil)
+	}
+	config := newHTTPRequestConfig(str, hga.Timeout)
+	config.retry = true
+
+	result := sendRequest(ctx, models.RunResult{}, newRequest, config)
+	if result.HasError() {
+		source.Error = result.Error()
+		return source
+	}
+	body, err := result.Value()
+	if err != nil {
+		source.Error = err.Error()
+		return source
+	}
+
+	js, err := simplejson.NewJson([]byte(body))
+	if err != nil {
+		source.Error = err.Error()
+		return source
+	}
+	last, err := dig(js, hga.Path)
+	if err != nil {
+		source.Error = err.Error()
+		return source
+	}
+	raw, err := getStringValue(last)
+	if err != nil {
+		source.Error = err.Error()
+		return source
+	}
+	value, ok := parseDecimal(raw)
+	if !ok {
+		source.Error = fmt.Sprintf("cannot parse %q into decimal", raw)
+		return source
+	}
+
+	source.Value = formatDecimal(value)
+	source.value = value
+	return source
+}
@@ -0,0 +1,140 @@
+package adapters_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type aggregateResponse struct {
+	status int
+	body   string
+}
+
+func TestHTTPGetAggregate_Perform(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []aggregateResponse
+		quorum       int
+		want         string
+		wantValues   int
+		wantFailures int
+		wantErrored  bool
+	}{
+		{
+			"all succeed",
+			[]aggregateResponse{{200, `{"data":{"price":"10.5"}}`}, {200, `{"data":{"price":12}}`}, {200, `{"data":{"price":"11"}}`}},
+			0, "11", 3, 0, false,
+		},
+		{
+			"even number of successes",
+			[]aggregateResponse{{200, `{"data":{"price":10}}`}, {200, `{"data":{"price":11}}`}, {404, `not found`}},
+			0, "10.5", 2, 1, false,
+		},
+		{
+			"failure below quorum",
+			[]aggregateResponse{{200, `{"data":{"price":10}}`}, {404, `not found`}, {200, `{"error":"rate limited"}`}},
+			0, "", 0, 0, true,
+		},
+		{
+			"explicit quorum",
+			[]aggregateResponse{{200, `{"data":{"price":10}}`}, {404, `not found`}, {200, `{"data":{"price":"n/a"}}`}},
+			1, "10", 1, 2, false,
+		},
+		{
+			"explicit quorum not met",
+			[]aggregateResponse{{200, `{"data":{"price":10}}`}, {200, `{"data":{"price":11}}`}, {400, `bad request`}},
+			3, "", 0, 0, true,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var urls []models.WebURL
+			for _, response := range test.responses {
+				mock, cleanup := cltest.NewHTTPMockServer(t, response.status, "GET", response.body)
+				defer cleanup()
+				urls = append(urls, cltest.WebURL(mock.URL))
+			}
+
+			adapter := adapters.HTTPGetAggregate{URLs: urls, Path: []string{"data", "price"}, Quorum: test.quorum}
+			result := adapter.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+			assert.Equal(t, test.wantErrored, result.HasError())
+			if test.wantErrored {
+				return
+			}
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Len(t, result.Get("values").Array(), test.wantValues)
+			assert.Len(t, result.Get("failures").Array(), test.wantFailures)
+		})
+	}
+}
+
+func TestHTTPGetAggregate_Perform_ReportsSources(t *testing.T) {
+	good, cleanup := cltest.NewHTTPMockServer(t, 200, "GET", `{"price":"212.54"}`)
+	defer cleanup()
+	bad, cleanup := cltest.NewHTTPMockServer(t, 404, "GET", `not found`)
+	defer cleanup()
+
+	adapter := adapters.HTTPGetAggregate{
+		URLs:   []models.WebURL{cltest.WebURL(good.URL), cltest.WebURL(bad.URL)},
+		Path:   []string{"price"},
+		Quorum: 1,
+	}
+	result := adapter.Perform(cltest.RunResultWithValue("inputValue"), nil)
+	assert.False(t, result.HasError())
+
+	assert.Equal(t, good.URL, result.Get("values.0.url").String())
+	assert.Equal(t, "212.54", result.Get("values.0.value").String())
+	assert.Equal(t, bad.URL, result.Get("failures.0.url").String())
+	assert.Contains(t, result.Get("failures.0.error").String(), "404")
+}
+
+func TestHTTPGetAggregate_Perform_QuorumErrorListsSources(t *testing.T) {
+	var urls []models.WebURL
+	var servers []*httptest.Server
+	for i := 0; i < 2; i++ {
+		mock, cleanup := cltest.NewHTTPMockServer(t, 400, "GET", fmt.Sprintf("bad request %d", i))
+		defer cleanup()
+		servers = append(servers, mock)
+		urls = append(urls, cltest.WebURL(mock.URL))
+	}
+
+	adapter := adapters.HTTPGetAggregate{URLs: urls, Path: []string{"price"}}
+	result := adapter.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+	assert.True(t, result.HasError())
+	for _, mock := range servers {
+		assert.Contains(t, result.Error(), mock.URL)
+	}
+}
+
+func TestHTTPGetAggregate_Perform_InvalidQuorum(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter adapters.HTTPGetAggregate
+	}{
+		{"no urls", adapters.HTTPGetAggregate{}},
+		{"quorum above urls", adapters.HTTPGetAggregate{URLs: []models.WebURL{cltest.WebURL("http://example.com")}, Quorum: 2}},
+		{"negative quorum", adapters.HTTPGetAggregate{URLs: []models.WebURL{cltest.WebURL("http://example.com")}, Quorum: -1}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result := test.adapter.Perform(cltest.RunResultWithValue("inputValue"), nil)
+			assert.True(t, result.HasError())
+		})
+	}
+}
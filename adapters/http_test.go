@@ -1,13 +1,23 @@
 package adapters_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHttpAdapters_NotAUrlError(t *testing.T) {
@@ -102,3 +112,446 @@ func TestHttpPost_Perform(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpAdapters_Timeout(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.DefaultHTTPTimeout = strpkg.Duration{Duration: 50 * time.Millisecond}
+	store.Config.HTTPRetryMinBackoff = strpkg.Duration{Duration: time.Millisecond}
+	store.Config.HTTPRetryMaxBackoff = strpkg.Duration{Duration: time.Millisecond}
+
+	done := make(chan struct{})
+	defer close(done)
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer mock.Close()
+
+	tests := []struct {
+		name    string
+		adapter adapters.BaseAdapter
+	}{
+		{"HTTPGet", &adapters.HTTPGet{URL: cltest.WebURL(mock.URL)}},
+		{"HTTPPost", &adapters.HTTPPost{URL: cltest.WebURL(mock.URL)}},
+		{"HTTPGet task timeout", &adapters.HTTPGet{
+			URL:     cltest.WebURL(mock.URL),
+			Timeout: strpkg.Duration{Duration: 10 * time.Millisecond},
+		}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			result := test.adapter.Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.True(t, result.HasError())
+			assert.Equal(t, models.RunStatusErrored, result.Status)
+		})
+	}
+}
+
+func TestHttpAdapters_ResponseTooLarge(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.DefaultHTTPLimit = 16
+
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 1024)))
+	}))
+	defer mock.Close()
+
+	tests := []struct {
+		name    string
+		adapter adapters.BaseAdapter
+	}{
+		{"HTTPGet", &adapters.HTTPGet{URL: cltest.WebURL(mock.URL)}},
+		{"HTTPPost", &adapters.HTTPPost{URL: cltest.WebURL(mock.URL)}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			result := test.adapter.Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), "response too large")
+		})
+	}
+}
+
+func TestHttpGet_UnmarshalTimeout(t *testing.T) {
+	var hga adapters.HTTPGet
+	err := json.Unmarshal([]byte(`{"url":"https://example.com","timeout":"3s"}`), &hga)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, hga.Timeout.Duration)
+}
+
+func TestHttpAdapters_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		adapter      func(url string) adapters.BaseAdapter
+		wantAttempts int32
+	}{
+		{"GET retries 5xx", 503,
+			func(url string) adapters.BaseAdapter { return &adapters.HTTPGet{URL: cltest.WebURL(url)} }, 3},
+		{"GET does not retry 4xx", 404,
+			func(url string) adapters.BaseAdapter { return &adapters.HTTPGet{URL: cltest.WebURL(url)} }, 1},
+		{"POST does not retry by default", 502,
+			func(url string) adapters.BaseAdapter { return &adapters.HTTPPost{URL: cltest.WebURL(url)} }, 1},
+		{"POST retries 5xx when asked", 502,
+			func(url string) adapters.BaseAdapter {
+				return &adapters.HTTPPost{URL: cltest.WebURL(url), RetryOn5xx: true}
+			}, 3},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			store, cleanup := cltest.NewStore()
+			defer cleanup()
+			store.Config.HTTPRetryAttempts = 3
+			store.Config.HTTPRetryMinBackoff = strpkg.Duration{Duration: time.Millisecond}
+			store.Config.HTTPRetryMaxBackoff = strpkg.Duration{Duration: time.Millisecond}
+
+			var attempts int32
+			mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(test.status)
+				w.Write([]byte("unavailable"))
+			}))
+			defer mock.Close()
+
+			result := test.adapter(mock.URL).Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.True(t, result.HasError())
+			assert.Equal(t, test.wantAttempts, atomic.LoadInt32(&attempts))
+			assert.Contains(t, result.Error(), fmt.Sprintf("status code %d", test.status))
+			assert.Contains(t, result.Error(), fmt.Sprintf("%d attempt(s)", test.wantAttempts))
+			assert.Equal(t, test.status < 500, result.Permanent(), "only 4xx responses are permanent")
+		})
+	}
+}
+
+func TestHttpGet_RetriesUntilSuccess(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.HTTPRetryMinBackoff = strpkg.Duration{Duration: time.Millisecond}
+	store.Config.HTTPRetryMaxBackoff = strpkg.Duration{Duration: time.Millisecond}
+
+	var attempts int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte("results!"))
+	}))
+	defer mock.Close()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL)}
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "results!", val)
+	assert.False(t, result.HasError())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestHttpAdapters_RestrictedNetworkAccess(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.AllowUnrestrictedNetworkAccess = false
+
+	mock, cleanupMock := cltest.NewHTTPMockServer(t, 200, "GET", "results!")
+	defer cleanupMock()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"loopback", mock.URL},
+		{"link local", "http://169.254.169.254/latest/meta-data"},
+		{"private", "http://10.0.0.1:8080"},
+		{"unique local", "http://[fd00::1]:8080"},
+		{"scheme", "ftp://example.com/file"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			hga := adapters.HTTPGet{URL: cltest.WebURL(test.url)}
+			result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), "blocked by network access policy")
+		})
+	}
+
+	// Unblock so the mock server's expectation of being called is met.
+	store.Config.AllowUnrestrictedNetworkAccess = true
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL)}
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.False(t, result.HasError())
+}
+
+func TestHttpAdapters_IsRestrictedIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"169.254.169.254", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"172.32.0.1", false},
+		{"192.168.1.1", true},
+		{"fd12:3456::1", true},
+		{"fe80::1", true},
+		{"0.0.0.0", true},
+		{"8.8.8.8", false},
+		{"2001:4860:4860::8888", false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.ip, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, adapters.ExportedIsRestrictedIP(net.ParseIP(test.ip)))
+		})
+	}
+}
+
+func TestHttpAdapters_Auth(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name    string
+		method  string
+		adapter func(url string) adapters.BaseAdapter
+	}{
+		{"HTTPGet", "GET", func(url string) adapters.BaseAdapter {
+			return &adapters.HTTPGet{URL: cltest.WebURL(url), Auth: "kaiko"}
+		}},
+		{"HTTPPost", "POST", func(url string) adapters.BaseAdapter {
+			return &adapters.HTTPPost{URL: cltest.WebURL(url), Auth: "kaiko"}
+		}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			mock, cleanup := cltest.NewHTTPMockServer(t, 200, test.method, "results!",
+				func(h http.Header, _ string) { assert.Equal(t, "Bearer s3cr3t", h.Get("Authorization")) })
+			defer cleanup()
+			require.NoError(t, store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: mock.URL + "/", Token: "s3cr3t"}))
+
+			result := test.adapter(mock.URL).Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.False(t, result.HasError())
+		})
+	}
+}
+
+func TestHttpAdapters_Auth_OtherURL(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	require.NoError(t, store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://api.kaiko.com/", Token: "s3cr3t"}))
+
+	var requests int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Empty(t, r.Header.Get("Authorization"))
+	}))
+	defer mock.Close()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL), Auth: "kaiko"}
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "https://api.kaiko.com/")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests), "the request is not sent")
+}
+
+func TestHttpAdapters_Auth_UnknownCredential(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL("https://example.com"), Auth: "missing"}
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "missing")
+}
+
+func TestHttpGet_CacheTTL(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Now())
+
+	var requests int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		w.Write([]byte(fmt.Sprintf("response %d", n)))
+	}))
+	defer mock.Close()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL), CacheTTL: strpkg.Duration{Duration: time.Minute}}
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "response 1", result.Get("value").String())
+	assert.False(t, result.Get("cached").Exists())
+
+	result = hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "response 1", result.Get("value").String())
+	assert.True(t, result.Get("cached").Bool())
+
+	uncached := adapters.HTTPGet{URL: cltest.WebURL(mock.URL)}
+	result = uncached.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.Equal(t, "response 2", result.Get("value").String(), "caching is opt in")
+
+	clock.SetTime(clock.Now().Add(time.Minute))
+	result = hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.Equal(t, "response 3", result.Get("value").String(), "expired")
+	assert.False(t, result.Get("cached").Exists())
+}
+
+func TestHttpGet_CacheTTL_ErrorsNotCached(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.HTTPRetryAttempts = 1
+
+	var requests int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte("recovered"))
+	}))
+	defer mock.Close()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL), CacheTTL: strpkg.Duration{Duration: time.Minute}}
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.Error(t, result.GetError())
+
+	result = hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "recovered", result.Get("value").String())
+	assert.False(t, result.Get("cached").Exists())
+}
+
+func TestHttpGet_CacheTTL_ParallelRuns(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	var requests int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("shared"))
+	}))
+	defer mock.Close()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL), CacheTTL: strpkg.Duration{Duration: time.Minute}}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.NoError(t, result.GetError())
+			assert.Equal(t, "shared", result.Get("value").String())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	other := adapters.HTTPGet{URL: cltest.WebURL(mock.URL + "?other"), CacheTTL: strpkg.Duration{Duration: time.Minute}}
+	other.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "different URLs are cached apart")
+}
+
+// redirectChain serves /hop/n, which redirects to /hop/n-1 until /hop/0
+// responds with "done".
+func redirectChain(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var hop int
+		fmt.Sscanf(r.URL.Path, "/hop/%d", &hop)
+		if hop == 0 {
+			w.Write([]byte("done"))
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/hop/%d", hop-1), http.StatusFound)
+	}))
+}
+
+func TestHttpGet_Redirects(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	var requests int32
+	mock := redirectChain(&requests)
+	defer mock.Close()
+
+	tests := []struct {
+		name         string
+		params       string
+		wantRequests int32
+		wantFinalURL string
+		wantError    string
+	}{
+		{"not redirected", `{"url":"%s/hop/0"}`, 1, "", ""},
+		{"followed by default", `{"url":"%s/hop/3"}`, 4, "/hop/0", ""},
+		{"within the limit", `{"url":"%s/hop/3","maxRedirects":3}`, 4, "/hop/0", ""},
+		{"over the limit", `{"url":"%s/hop/3","maxRedirects":2}`, 3, "", "stopped after 2 redirects"},
+		{"followed explicitly", `{"url":"%s/hop/1","followRedirects":true}`, 2, "/hop/0", ""},
+		{"not followed", `{"url":"%s/hop/2","followRedirects":false}`, 1, "", "redirected to /hop/1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var hga adapters.HTTPGet
+			require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(test.params, mock.URL)), &hga))
+			atomic.StoreInt32(&requests, 0)
+
+			result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+			assert.Equal(t, test.wantRequests, atomic.LoadInt32(&requests))
+			if test.wantError != "" {
+				require.Error(t, result.GetError())
+				assert.Contains(t, result.GetError().Error(), test.wantError)
+				return
+			}
+			require.NoError(t, result.GetError())
+			assert.Equal(t, "done", result.Get("value").String())
+			if test.wantFinalURL == "" {
+				assert.False(t, result.Get("finalURL").Exists())
+			} else {
+				assert.Equal(t, mock.URL+test.wantFinalURL, result.Get("finalURL").String())
+			}
+		})
+	}
+}
+
+func TestHttpGet_Redirects_Cached(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	var requests int32
+	mock := redirectChain(&requests)
+	defer mock.Close()
+
+	hga := adapters.HTTPGet{URL: cltest.WebURL(mock.URL + "/hop/2"), CacheTTL: strpkg.Duration{Duration: time.Minute}}
+	hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	result := hga.Perform(cltest.RunResultWithValue("inputValue"), store)
+	require.NoError(t, result.GetError())
+	assert.True(t, result.Get("cached").Bool())
+	assert.Equal(t, mock.URL+"/hop/0", result.Get("finalURL").String())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,142 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	// IPFSOperationGet fetches content from the gateway by its CID.
+	IPFSOperationGet = "get"
+	// IPFSOperationAdd stores content on the node and returns its CID.
+	IPFSOperationAdd = "add"
+)
+
+// IPFS reads content from, or adds content to, IPFS through an HTTP gateway.
+type IPFS struct {
+	Operation string        `json:"operation"`
+	Gateway   models.WebURL `json:"gateway"`
+	CID       CID           `json:"cid"`
+	Data      string        `json:"data"`
+}
+
+// Perform either fetches the content for CID, returning it as the "value"
+// of the result, or adds Data (or the input's value when no Data is given)
+// and returns the resulting CID.
+func (ia *IPFS) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return ia.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx is Perform, abandoning the request to the gateway once ctx is
+// done.
+func (ia *IPFS) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	config := newHTTPRequestConfig(str, store.Duration{})
+	if str != nil {
+		config.timeout = str.CurrentConfig().IPFSTimeout.Duration
+	}
+
+	switch ia.Operation {
+	case IPFSOperationGet:
+		return ia.get(ctx, input, config)
+	case IPFSOperationAdd:
+		return ia.add(ctx, input, config)
+	default:
+		return input.WithError(fmt.Errorf("IPFS operation must be %q or %q, got %q", IPFSOperationGet, IPFSOperationAdd, ia.Operation))
+	}
+}
+
+func (ia *IPFS) get(ctx context.Context, input models.RunResult, config httpRequestConfig) models.RunResult {
+	if ia.CID == "" {
+		return input.WithError(fmt.Errorf("IPFS get requires a cid"))
+	}
+	newRequest := func() (*http.Request, error) {
+		return http.NewRequest("GET", ia.endpoint("ipfs", string(ia.CID)), nil)
+	}
+	config.retry = true
+	return sendRequest(ctx, input, newRequest, config)
+}
+
+func (ia *IPFS) add(ctx context.Context, input models.RunResult, config httpRequestConfig) models.RunResult {
+	data := ia.Data
+	if data == "" {
+		val, err := input.Value()
+		if err != nil {
+			return input.WithError(err)
+		}
+		data = val
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "data")
+	if err != nil {
+		return input.WithError(err)
+	}
+	if _, err = part.Write([]byte(data)); err != nil {
+		return input.WithError(err)
+	}
+	if err = writer.Close(); err != nil {
+		return input.WithError(err)
+	}
+
+	request, err := http.NewRequest("POST", ia.endpoint("api/v0/add"), body)
+	if err != nil {
+		return input.WithError(err)
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	response, err := doRequest(config.client(), request.WithContext(ctx), config)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	var added struct {
+		Hash string `json:"Hash"`
+	}
+	if err = json.Unmarshal([]byte(response.body), &added); err != nil {
+		return input.WithError(err)
+	}
+	if !isValidCID(added.Hash) {
+		return input.WithError(fmt.Errorf("IPFS add returned an invalid cid %q", added.Hash))
+	}
+	return input.WithValue(added.Hash)
+}
+
+func (ia *IPFS) endpoint(parts ...string) string {
+	return strings.TrimRight(ia.Gateway.String(), "/") + "/" + strings.Join(parts, "/")
+}
+
+var (
+	cidV0Regexp = regexp.MustCompile(`^Qm[1-9A-HJ-NP-Za-km-z]{44}$`)
+	cidV1Regexp = regexp.MustCompile(`^b[a-z2-7]{58,}$`)
+)
+
+// CID is an IPFS content identifier, either a base58 encoded v0 CID or a
+// base32 encoded v1 CID.
+type CID string
+
+// UnmarshalJSON rejects strings which are not well formed CIDs.
+func (c *CID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s != "" && !isValidCID(s) {
+		return fmt.Errorf("%q is not a valid IPFS cid", s)
+	}
+	*c = CID(s)
+	return nil
+}
+
+func isValidCID(s string) bool {
+	return cidV0Regexp.MatchString(s) || cidV1Regexp.MatchString(s)
+}
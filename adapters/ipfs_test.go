@@ -0,0 +1,100 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCID = "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"
+
+func newIPFSGateway(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ipfs/"+testCID, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Write([]byte(`{"name":"token #1"}`))
+	})
+	mux.HandleFunc("/api/v0/add", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		b, err := ioutil.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "hello ipfs", string(b))
+		w.Write([]byte(`{"Name":"data","Hash":"` + testCID + `","Size":"18"}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestIPFS_Perform(t *testing.T) {
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	gateway := newIPFSGateway(t)
+	defer gateway.Close()
+
+	tests := []struct {
+		name        string
+		adapter     adapters.IPFS
+		input       string
+		want        string
+		wantErrored bool
+	}{
+		{"get", adapters.IPFS{Operation: "get", CID: testCID}, "inputValue", `{"name":"token #1"}`, false},
+		{"get unknown cid", adapters.IPFS{Operation: "get", CID: "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn"}, "inputValue", "inputValue", true},
+		{"get without cid", adapters.IPFS{Operation: "get"}, "inputValue", "inputValue", true},
+		{"add data", adapters.IPFS{Operation: "add", Data: "hello ipfs"}, "inputValue", testCID, false},
+		{"add input value", adapters.IPFS{Operation: "add"}, "hello ipfs", testCID, false},
+		{"unknown operation", adapters.IPFS{Operation: "pin"}, "inputValue", "inputValue", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			adapter := test.adapter
+			adapter.Gateway = cltest.WebURL(gateway.URL)
+			result := adapter.Perform(cltest.RunResultWithValue(test.input), store)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestIPFS_UnmarshalCID(t *testing.T) {
+	tests := []struct {
+		name      string
+		cid       string
+		wantError bool
+	}{
+		{"v0", testCID, false},
+		{"v1", "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", false},
+		{"empty", "", false},
+		{"too short", "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff", true},
+		{"not base58", "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff0O", true},
+		{"garbage", "not-a-cid", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var adapter adapters.IPFS
+			err := json.Unmarshal([]byte(`{"operation":"get","cid":"`+test.cid+`"}`), &adapter)
+			if test.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, adapters.CID(test.cid), adapter.CID)
+			}
+		})
+	}
+}
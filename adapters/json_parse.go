@@ -3,6 +3,7 @@ package adapters
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -10,14 +11,34 @@ import (
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/tidwall/gjson"
 )
 
 // JSONParse holds a path to the desired field in a JSON object,
-// made up of an array of strings.
+// made up of an array of strings. Numeric elements of the path index
+// into arrays, with negative indexes counting back from the end.
 type JSONParse struct {
 	Path JSONPath `json:"path"`
+	// JSONPath is a gjson path, used instead of Path when wildcards, queries
+	// or modifiers are needed. Dots in key names are escaped with a backslash,
+	// as in `fav\.movie`.
+	JSONPath string `json:"jsonPath"`
+	// OnMissing is either "error" or "null". When empty, a missing final
+	// element of the path results in null while any other miss is an error.
+	OnMissing string `json:"onMissing"`
+	// ResultKey is where the value found is written, "value" by default.
+	ResultKey ResultKey `json:"resultKey"`
 }
 
+const (
+	// JSONParseOnMissingError errors the run when any element of the path
+	// is missing.
+	JSONParseOnMissingError = "error"
+	// JSONParseOnMissingNull returns null when any element of the path is
+	// missing.
+	JSONParseOnMissingNull = "null"
+)
+
 // Perform returns the value associated to the desired field for a
 // given JSON object.
 //
@@ -29,12 +50,26 @@ type JSONParse struct {
 //     ]
 //   }
 //
-// Then ["0","last"] would be the path, and "111" would be the returned value
+// Then ["data","0","last"] would be the path, and "1111" would be the returned
+// value. ["data","-1","last"] would return "2222". An index which is out of
+// range is treated the same as a key which does not exist.
+//
+// The JSON need not be an object. A body such as [[1546300800, "3.5"]], as
+// HTTPGet returns it, is parsed with the path starting at the array, and so
+// is a value which is already JSON rather than text. With an empty path, a
+// plain number, or text which is not JSON, is passed through unchanged.
 func (jpa *JSONParse) Perform(input models.RunResult, _ *store.Store) models.RunResult {
-	val, err := input.Value()
+	value := input.Get("value")
+	if len(jpa.Path) == 0 && jpa.JSONPath == "" && isPlainValue(value) {
+		return jpa.ResultKey.write(input, json.RawMessage(value.Raw))
+	}
+	val, err := jsonText(value)
 	if err != nil {
 		return input.WithError(err)
 	}
+	if jpa.JSONPath != "" {
+		return jpa.performGJSON(input, val)
+	}
 
 	js, err := simplejson.NewJson([]byte(val))
 	if err != nil {
@@ -43,38 +78,118 @@ func (jpa *JSONParse) Perform(input models.RunResult, _ *store.Store) models.Run
 
 	last, err := dig(js, jpa.Path)
 	if err != nil {
-		return moldErrorOutput(js, jpa.Path, input)
+		if jpa.OnMissing == "" {
+			return jpa.moldErrorOutput(js, input)
+		}
+		return jpa.missing(input, err)
 	}
 
 	rval, err := getStringValue(last)
 	if err != nil {
 		return input.WithError(err)
 	}
-	return input.WithValue(rval)
+	return jpa.ResultKey.write(input, rval)
 }
 
-func dig(js *simplejson.Json, path []string) (*simplejson.Json, error) {
-	var ok bool
-	for _, k := range path[:len(path)] {
-		if isArray(js, k) {
-			js, ok = arrayGet(js, k)
-		} else {
-			js, ok = js.CheckGet(k)
+// performGJSON evaluates JSONPath against the input's value. Strings are
+// returned unquoted, any other result is returned as raw JSON.
+func (jpa *JSONParse) performGJSON(input models.RunResult, val string) models.RunResult {
+	if !gjson.Valid(val) {
+		return input.WithError(fmt.Errorf("unable to parse %q as JSON", val))
+	}
+
+	result := gjson.Get(val, jpa.JSONPath)
+	if !result.Exists() {
+		if jpa.OnMissing == "" {
+			return jpa.ResultKey.writeNull(input)
 		}
+		return jpa.missing(input, fmt.Errorf("No value could be found for the path '%s'", jpa.JSONPath))
+	}
+	if result.Type == gjson.String {
+		return jpa.ResultKey.write(input, result.Str)
+	}
+	return jpa.ResultKey.write(input, result.Raw)
+}
+
+// jsonText returns the JSON to parse from the value: the text of a string,
+// such as an HTTP response body, or the value itself when it is a number,
+// boolean, array or object.
+func jsonText(value gjson.Result) (string, error) {
+	switch value.Type {
+	case gjson.String:
+		return value.Str, nil
+	case gjson.Number, gjson.True, gjson.False, gjson.JSON:
+		return value.Raw, nil
+	default:
+		return "", errors.New("non string value")
+	}
+}
+
+// isPlainValue reports whether the value is a number, or text which is not
+// JSON, so that there is nothing to parse.
+func isPlainValue(value gjson.Result) bool {
+	switch value.Type {
+	case gjson.Number:
+		return true
+	case gjson.String:
+		return !gjson.Valid(value.Str)
+	default:
+		return false
+	}
+}
+
+func (jpa *JSONParse) missing(input models.RunResult, err error) models.RunResult {
+	switch jpa.OnMissing {
+	case JSONParseOnMissingError:
+		return input.WithError(err)
+	case JSONParseOnMissingNull:
+		return jpa.ResultKey.writeNull(input)
+	default:
+		return input.WithError(fmt.Errorf("onMissing must be %q or %q, got %q", JSONParseOnMissingError, JSONParseOnMissingNull, jpa.OnMissing))
+	}
+}
+
+// UnmarshalJSON rejects task params which set both "path" and "jsonPath".
+func (jpa *JSONParse) UnmarshalJSON(b []byte) error {
+	type plain JSONParse
+	var p plain
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	if len(p.Path) > 0 && p.JSONPath != "" {
+		return errors.New(`JSONParse accepts either "path" or "jsonPath", not both`)
+	}
+	*jpa = JSONParse(p)
+	return nil
+}
+
+func dig(js *simplejson.Json, path []string) (*simplejson.Json, error) {
+	for _, k := range path {
+		next, ok := step(js, k)
 		if !ok {
 			return js, errors.New("No value could be found for the key '" + k + "'")
 		}
+		js = next
 	}
 	return js, nil
 }
 
+// step descends one level into js, indexing into arrays and looking up keys
+// in objects.
+func step(js *simplejson.Json, key string) (*simplejson.Json, bool) {
+	if isArray(js) {
+		return arrayGet(js, key)
+	}
+	return js.CheckGet(key)
+}
+
 // only error if any keys prior to the last one in the path are nonexistent.
 // i.e. Path = ["errorIfNonExistent", "nullIfNonExistent"]
-func moldErrorOutput(js *simplejson.Json, path []string, input models.RunResult) models.RunResult {
-	if _, err := getEarlyPath(js, path); err != nil {
+func (jpa *JSONParse) moldErrorOutput(js *simplejson.Json, input models.RunResult) models.RunResult {
+	if _, err := getEarlyPath(js, jpa.Path); err != nil {
 		return input.WithError(err)
 	}
-	return input.WithNull()
+	return jpa.ResultKey.writeNull(input)
 }
 
 func getStringValue(js *simplejson.Json) (string, error) {
@@ -90,18 +205,7 @@ func getStringValue(js *simplejson.Json) (string, error) {
 }
 
 func getEarlyPath(js *simplejson.Json, path []string) (*simplejson.Json, error) {
-	var ok bool
-	for _, k := range path[:len(path)-1] {
-		if isArray(js, k) {
-			js, ok = arrayGet(js, k)
-		} else {
-			js, ok = js.CheckGet(k)
-		}
-		if !ok {
-			return js, errors.New("No value could be found for the key '" + k + "'")
-		}
-	}
-	return js, nil
+	return dig(js, path[:len(path)-1])
 }
 
 func arrayGet(js *simplejson.Json, key string) (*simplejson.Json, bool) {
@@ -125,7 +229,7 @@ func arrayGet(js *simplejson.Json, key string) (*simplejson.Json, bool) {
 	return js.GetIndex(index), true
 }
 
-func isArray(js *simplejson.Json, key string) bool {
+func isArray(js *simplejson.Json) bool {
 	if _, err := js.Array(); err != nil {
 		return false
 	}
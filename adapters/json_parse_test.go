@@ -6,7 +6,9 @@ import (
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJsonParse_Perform(t *testing.T) {
@@ -93,6 +95,62 @@ func TestJsonParse_Perform(t *testing.T) {
 			false,
 			false,
 		},
+		{
+			"object key after array index",
+			`{"data": [{"price": 10}, {"price": 12}]}`,
+			[]string{"data", "1", "price"},
+			`{"value":"12"}`,
+			false,
+			false,
+		},
+		{
+			"object key after negative array index",
+			`{"data": [{"price": 10}, {"price": 12}]}`,
+			[]string{"data", "-2", "price"},
+			`{"value":"10"}`,
+			false,
+			false,
+		},
+		{
+			"nested keys and indexes",
+			`{"a": [{"b": {"c": [[1, 2], [3, 4]]}}]}`,
+			[]string{"a", "0", "b", "c", "-1", "0"},
+			`{"value":"3"}`,
+			false,
+			false,
+		},
+		{
+			"array at root",
+			`[{"price": 10}, {"price": 12}]`,
+			[]string{"-1", "price"},
+			`{"value":"12"}`,
+			false,
+			false,
+		},
+		{
+			"out of range index at end of path",
+			`{"data": [{"price": 10}, {"price": 12}]}`,
+			[]string{"data", "2"},
+			`{"value":null}`,
+			false,
+			false,
+		},
+		{
+			"out of range index in middle of path",
+			`{"data":[{"price":10},{"price":12}]}`,
+			[]string{"data", "2", "price"},
+			`{"value":"{\"data\":[{\"price\":10},{\"price\":12}]}"}`,
+			false,
+			true,
+		},
+		{
+			"key on array",
+			`{"data": [{"price": 10}]}`,
+			[]string{"data", "price"},
+			`{"value":null}`,
+			false,
+			false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -112,6 +170,133 @@ func TestJsonParse_Perform(t *testing.T) {
 	}
 }
 
+func TestJsonParse_Perform_OnMissing(t *testing.T) {
+	t.Parallel()
+	const value = `{"data":{"last":"11779.99"}}`
+	tests := []struct {
+		name        string
+		onMissing   string
+		path        []string
+		want        string
+		wantErrored bool
+		wantMessage string
+	}{
+		{"default missing final key", "", []string{"data", "high"}, `{"value":null}`, false, ""},
+		{"default missing intermediate key", "", []string{"ticker", "last"}, value, true, "'ticker'"},
+		{"error missing final key", "error", []string{"data", "high"}, value, true, "'high'"},
+		{"error missing intermediate key", "error", []string{"ticker", "last"}, value, true, "'ticker'"},
+		{"error existing path", "error", []string{"data", "last"}, `{"value":"11779.99"}`, false, ""},
+		{"null missing final key", "null", []string{"data", "high"}, `{"value":null}`, false, ""},
+		{"null missing intermediate key", "null", []string{"ticker", "last"}, `{"value":null}`, false, ""},
+		{"null existing path", "null", []string{"data", "last"}, `{"value":"11779.99"}`, false, ""},
+		{"unknown mode", "ignore", []string{"ticker", "last"}, value, true, "onMissing"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.JSONParse{Path: test.path, OnMissing: test.onMissing}
+			result := adapter.Perform(cltest.RunResultWithValue(value), nil)
+
+			assert.Equal(t, test.wantErrored, result.HasError())
+			if test.wantErrored {
+				assert.Contains(t, result.Error(), test.wantMessage)
+				val, err := result.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, val)
+			} else {
+				assert.Equal(t, test.want, result.Data.String())
+			}
+		})
+	}
+}
+
+func TestJsonParse_Perform_JSONPath(t *testing.T) {
+	t.Parallel()
+	const value = `{
+		"friends": [
+			{"first": "Dale", "age": 44},
+			{"first": "Roger", "age": 68},
+			{"first": "Jane", "age": 37}
+		],
+		"fav.movie": "Deer Hunter",
+		"active": true
+	}`
+	tests := []struct {
+		name        string
+		jsonPath    string
+		onMissing   string
+		want        string
+		wantErrored bool
+	}{
+		{"simple key", "active", "", `{"value":"true"}`, false},
+		{"array index", "friends.1.first", "", `{"value":"Roger"}`, false},
+		{"query", "friends.#(age>60).first", "", `{"value":"Roger"}`, false},
+		{"query all", "friends.#(age>40)#.first", "", `{"value":"[\"Dale\",\"Roger\"]"}`, false},
+		{"wildcard", "fr?ends.#", "", `{"value":"3"}`, false},
+		{"object", "friends.0", "", `{"value":"{\"first\": \"Dale\", \"age\": 44}"}`, false},
+		{"escaped dot", `fav\.movie`, "", `{"value":"Deer Hunter"}`, false},
+		{"unescaped dot", "fav.movie", "", `{"value":null}`, false},
+		{"missing", "enemies.0", "", `{"value":null}`, false},
+		{"missing with error", "enemies.0", "error", "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.JSONParse{JSONPath: test.jsonPath, OnMissing: test.onMissing}
+			result := adapter.Perform(cltest.RunResultWithValue(value), nil)
+
+			assert.Equal(t, test.wantErrored, result.HasError())
+			if !test.wantErrored {
+				assert.Equal(t, test.want, result.Data.String())
+			}
+		})
+	}
+}
+
+func TestJsonParse_Perform_NonObjectRoots(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		input     string
+		params    string
+		want      string
+		wantError bool
+	}{
+		{"array of arrays", `{"value":"[[1546300800,\"3.51\"],[1546387200,\"3.62\"]]"}`, `{"path":["0","1"]}`, `{"value":"3.51"}`, false},
+		{"array of arrays jsonPath", `{"value":"[[1546300800,3.51],[1546387200,3.62]]"}`, `{"jsonPath":"1.0"}`, `{"value":"1546387200"}`, false},
+		{"scalar text", `{"value":"3.62"}`, `{}`, `{"value":"3.62"}`, false},
+		{"quoted scalar text", `{"value":"\"3.62\""}`, `{}`, `{"value":"3.62"}`, false},
+		{"boolean text", `{"value":"true"}`, `{}`, `{"value":"true"}`, false},
+		{"key on scalar text", `{"value":"3.62"}`, `{"path":["last"]}`, `{"value":null}`, false},
+		{"array value", `{"value":[[1,2],[3,4]]}`, `{"path":["-1","0"]}`, `{"value":"3"}`, false},
+		{"object value", `{"value":{"last":"3.62"}}`, `{"path":["last"]}`, `{"value":"3.62"}`, false},
+		{"plain number", `{"value":3.620}`, `{}`, `{"value":3.620}`, false},
+		{"plain number with resultKey", `{"value":3.62}`, `{"resultKey":"price"}`, `{"price":3.62,"value":3.62}`, false},
+		{"plain text", `{"value":"3.62 USD"}`, `{}`, `{"value":"3.62 USD"}`, false},
+		{"plain text with path", `{"value":"3.62 USD"}`, `{"path":["last"]}`, "", true},
+		{"null", `{"value":null}`, `{}`, "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.JSONParse{}
+			require.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(models.RunResult{Data: cltest.JSONFromString(test.input)}, nil)
+
+			assert.Equal(t, test.wantError, result.HasError())
+			if !test.wantError {
+				assert.JSONEq(t, test.want, result.Data.String())
+			}
+		})
+	}
+}
+
 func TestJSON_UnmarshalJSON(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -127,6 +312,7 @@ func TestJSON_UnmarshalJSON(t *testing.T) {
 		{"dot delimited empty string", `{"path":"1...b"}`, []string{"1", "", "", "b"}, false},
 		{"unclosed array errors", `{"path":["1"}`, []string{}, true},
 		{"unclosed string errors", `{"path":"1.2}`, []string{}, true},
+		{"path and jsonPath errors", `{"path":"1.b","jsonPath":"1.b"}`, []string{}, true},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
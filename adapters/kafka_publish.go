@@ -0,0 +1,202 @@
+package adapters
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	// KafkaSASLPlain authenticates with the SASL/PLAIN mechanism.
+	KafkaSASLPlain = "PLAIN"
+	// KafkaSASLScramSHA256 authenticates with the SASL/SCRAM-SHA-256 mechanism.
+	KafkaSASLScramSHA256 = "SCRAM-SHA-256"
+	// KafkaSASLScramSHA512 authenticates with the SASL/SCRAM-SHA-512 mechanism.
+	KafkaSASLScramSHA512 = "SCRAM-SHA-512"
+
+	defaultKafkaFlushTimeout = 10 * time.Second
+)
+
+// KafkaProducer writes a single message to a Kafka topic, returning the
+// partition and offset it was written at.
+type KafkaProducer interface {
+	Produce(ctx context.Context, msg kafka.Message) (partition int, offset int64, err error)
+}
+
+// KafkaPublish publishes the run result as a message on a Kafka topic.
+type KafkaPublish struct {
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+	// Key is a text/template rendered against the run data, for example
+	// "{{.symbol}}". Messages with the same key are written to the same
+	// partition, and messages without one are spread across partitions.
+	Key           string         `json:"key"`
+	TLSEnabled    bool           `json:"tlsEnabled"`
+	SASLMechanism string         `json:"saslMechanism"`
+	Username      string         `json:"username"`
+	Password      string         `json:"password"`
+	FlushTimeout  store.Duration `json:"flushTimeout"`
+
+	// Producer overrides the producer built from Brokers and the connection
+	// settings.
+	Producer KafkaProducer `json:"-"`
+}
+
+// Perform marshals the input run result to JSON and publishes it as a single
+// message, waiting up to FlushTimeout for the brokers to acknowledge it. The
+// partition and offset written to are returned as the "value" field of the
+// result, for example {"partition": 2, "offset": 1041}.
+func (kp *KafkaPublish) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	if kp.Topic == "" {
+		return input.WithError(errors.New("KafkaPublish requires a topic"))
+	}
+	producer, err := kp.producer()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	msg := kafka.Message{Time: time.Now()}
+	if kp.Key != "" {
+		key, err := renderDataTemplate("kafka key", kp.Key, input)
+		if err != nil {
+			return input.WithError(err)
+		}
+		msg.Key = []byte(key)
+	}
+	if msg.Value, err = json.Marshal(input); err != nil {
+		return input.WithError(err)
+	}
+
+	timeout := defaultKafkaFlushTimeout
+	if kp.FlushTimeout.Duration > 0 {
+		timeout = kp.FlushTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	partition, offset, err := producer.Produce(ctx, msg)
+	if err != nil {
+		return input.WithError(fmt.Errorf("unable to publish to kafka topic %s: %v", kp.Topic, err))
+	}
+
+	return input.Add("value", map[string]interface{}{
+		"partition": partition,
+		"offset":    offset,
+	})
+}
+
+func (kp *KafkaPublish) producer() (KafkaProducer, error) {
+	if kp.Producer != nil {
+		return kp.Producer, nil
+	}
+	if len(kp.Brokers) == 0 {
+		return nil, errors.New("KafkaPublish requires at least one broker")
+	}
+	dialer, err := kp.dialer()
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaLeaderProducer{dialer: dialer, brokers: kp.Brokers, topic: kp.Topic}, nil
+}
+
+func (kp *KafkaPublish) dialer() (*kafka.Dialer, error) {
+	dialer := &kafka.Dialer{Timeout: defaultKafkaFlushTimeout, DualStack: true}
+	if kp.TLSEnabled {
+		dialer.TLS = &tls.Config{}
+	}
+	if kp.SASLMechanism == "" {
+		return dialer, nil
+	}
+	if kp.Username == "" || kp.Password == "" {
+		return nil, fmt.Errorf("KafkaPublish SASL mechanism %s requires a username and password", kp.SASLMechanism)
+	}
+
+	var mechanism sasl.Mechanism
+	var err error
+	switch kp.SASLMechanism {
+	case KafkaSASLPlain:
+		mechanism = plain.Mechanism{Username: kp.Username, Password: kp.Password}
+	case KafkaSASLScramSHA256:
+		mechanism, err = scram.Mechanism(scram.SHA256, kp.Username, kp.Password)
+	case KafkaSASLScramSHA512:
+		mechanism, err = scram.Mechanism(scram.SHA512, kp.Username, kp.Password)
+	default:
+		return nil, fmt.Errorf("KafkaPublish SASL mechanism must be %q, %q or %q, got %q",
+			KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512, kp.SASLMechanism)
+	}
+	if err != nil {
+		return nil, err
+	}
+	dialer.SASLMechanism = mechanism
+	return dialer, nil
+}
+
+// kafkaRoundRobin spreads messages without a key across partitions, and is
+// shared so that successive runs do not all start at the first partition.
+var kafkaRoundRobin = &kafka.RoundRobin{}
+
+// kafkaLeaderProducer writes each message directly to the leader of the
+// partition it is balanced to, so that the offset it was written at is known.
+type kafkaLeaderProducer struct {
+	dialer  *kafka.Dialer
+	brokers []string
+	topic   string
+}
+
+func (p *kafkaLeaderProducer) Produce(ctx context.Context, msg kafka.Message) (int, int64, error) {
+	broker, partitions, err := p.lookupPartitions(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	ids := make([]int, len(partitions))
+	for i, partition := range partitions {
+		ids[i] = partition.ID
+	}
+
+	var balancer kafka.Balancer = kafkaRoundRobin
+	if len(msg.Key) > 0 {
+		balancer = &kafka.Hash{}
+	}
+	conn, err := p.dialer.DialLeader(ctx, "tcp", broker, p.topic, balancer.Balance(msg, ids...))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return 0, 0, err
+		}
+	}
+	_, partition, offset, _, err := conn.WriteCompressedMessagesAt(nil, msg)
+	return int(partition), offset, err
+}
+
+// lookupPartitions asks each broker in turn for the topic's partitions,
+// returning the first broker to answer along with them.
+func (p *kafkaLeaderProducer) lookupPartitions(ctx context.Context) (string, []kafka.Partition, error) {
+	var lastErr error
+	for _, broker := range p.brokers {
+		partitions, err := p.dialer.LookupPartitions(ctx, "tcp", broker, p.topic)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(partitions) == 0 {
+			lastErr = fmt.Errorf("topic %s has no partitions", p.topic)
+			continue
+		}
+		return broker, partitions, nil
+	}
+	return "", nil, lastErr
+}
@@ -0,0 +1,135 @@
+package adapters_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKafkaProducer appends messages to a single partition log.
+type mockKafkaProducer struct {
+	mutex    sync.Mutex
+	messages []kafka.Message
+	deadline time.Time
+	err      error
+}
+
+func (m *mockKafkaProducer) Produce(ctx context.Context, msg kafka.Message) (int, int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deadline, _ = ctx.Deadline()
+	if m.err != nil {
+		return 0, 0, m.err
+	}
+	m.messages = append(m.messages, msg)
+	return 3, int64(len(m.messages) - 1), nil
+}
+
+func TestKafkaPublish_Perform(t *testing.T) {
+	t.Parallel()
+	producer := &mockKafkaProducer{}
+	kp := adapters.KafkaPublish{Topic: "prices", Key: "{{.symbol}}", Producer: producer}
+
+	input := models.RunResult{
+		JobRunID: "job-run-1",
+		Data:     cltest.JSONFromString(`{"symbol":"ETH","value":"123.45"}`),
+	}
+	first := kp.Perform(input, nil)
+	second := kp.Perform(input, nil)
+
+	require.NoError(t, first.GetError())
+	assert.Equal(t, models.RunStatusCompleted, first.Status)
+	assert.JSONEq(t, `{"partition":3,"offset":0}`, first.Get("value").Raw)
+	assert.JSONEq(t, `{"partition":3,"offset":1}`, second.Get("value").Raw)
+	assert.Equal(t, "ETH", first.Get("symbol").String())
+
+	require.Len(t, producer.messages, 2)
+	msg := producer.messages[0]
+	assert.Equal(t, "ETH", string(msg.Key))
+	var published models.RunResult
+	require.NoError(t, json.Unmarshal(msg.Value, &published))
+	assert.Equal(t, "job-run-1", published.JobRunID)
+	assert.Equal(t, "123.45", published.Get("value").String())
+}
+
+func TestKafkaPublish_Perform_NoKey(t *testing.T) {
+	t.Parallel()
+	producer := &mockKafkaProducer{}
+	kp := adapters.KafkaPublish{Topic: "prices", Producer: producer}
+
+	result := kp.Perform(cltest.RunResultWithValue("123.45"), nil)
+
+	require.NoError(t, result.GetError())
+	require.Len(t, producer.messages, 1)
+	assert.Nil(t, producer.messages[0].Key)
+}
+
+func TestKafkaPublish_Perform_FlushTimeout(t *testing.T) {
+	t.Parallel()
+	producer := &mockKafkaProducer{}
+	kp := adapters.KafkaPublish{
+		Topic:        "prices",
+		FlushTimeout: strpkg.Duration{Duration: time.Minute},
+		Producer:     producer,
+	}
+
+	before := time.Now()
+	kp.Perform(cltest.RunResultWithValue("123.45"), nil)
+	assert.WithinDuration(t, before.Add(time.Minute), producer.deadline, 5*time.Second)
+}
+
+func TestKafkaPublish_Perform_ProducerError(t *testing.T) {
+	t.Parallel()
+	producer := &mockKafkaProducer{err: errors.New("leader not available")}
+	kp := adapters.KafkaPublish{Topic: "prices", Producer: producer}
+
+	result := kp.Perform(cltest.RunResultWithValue("123.45"), nil)
+
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "leader not available")
+}
+
+func TestKafkaPublish_Perform_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		adapter adapters.KafkaPublish
+		wantErr string
+	}{
+		{"no topic", adapters.KafkaPublish{Brokers: []string{"localhost:9092"}}, "requires a topic"},
+		{"no brokers", adapters.KafkaPublish{Topic: "prices"}, "at least one broker"},
+		{"unknown sasl mechanism", adapters.KafkaPublish{
+			Brokers: []string{"localhost:9092"}, Topic: "prices",
+			SASLMechanism: "GSSAPI", Username: "oracle", Password: "secret",
+		}, "SASL mechanism must be"},
+		{"sasl without password", adapters.KafkaPublish{
+			Brokers: []string{"localhost:9092"}, Topic: "prices",
+			SASLMechanism: adapters.KafkaSASLPlain, Username: "oracle",
+		}, "requires a username and password"},
+		{"missing key field", adapters.KafkaPublish{
+			Topic: "prices", Key: "{{.symbol}}", Producer: &mockKafkaProducer{},
+		}, "unable to render kafka key"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result := test.adapter.Perform(cltest.RunResultWithValue("123.45"), nil)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), test.wantErr)
+		})
+	}
+}
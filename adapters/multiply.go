@@ -5,46 +5,45 @@ package adapters
 import (
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
 )
 
-// Multiplier represents the number to multiply by in Multiply adapter.
-type Multiplier float64
-
-// UnmarshalJSON implements json.Unmarshaler.
-func (m *Multiplier) UnmarshalJSON(input []byte) error {
-	input = utils.RemoveQuotes(input)
-	times, err := strconv.ParseFloat(string(input), 64)
-	if err != nil {
-		return fmt.Errorf("cannot parse into float: %s", input)
-	}
-
-	*m = Multiplier(times)
-
-	return nil
-}
-
 // Multiply holds the a number to multiply the given value by.
 type Multiply struct {
-	Times Multiplier `json:"times"`
+	Times     Multiplier `json:"times"`
+	ResultKey ResultKey  `json:"resultKey"`
 }
 
 // Perform returns the input's "value" field, multiplied times the adapter's
 // "times" field.
 //
 // For example, if input value is "99.994" and the adapter's "times" is
-// set to "100", the result's value will be "9999.4".
+// set to "100", the result's value will be "9999.4". The product is written
+// to the field named by ResultKey instead when it is set.
+//
+// Both numbers are treated as exact decimals, so the result never loses
+// precision and is never written with an exponent. Either may be negative,
+// with the product keeping its sign for a following EthInt256, and products
+// outside the int256 range error.
 func (ma *Multiply) Perform(input models.RunResult, _ *store.Store) models.RunResult {
 	val := input.Get("value")
-	i, ok := (&big.Float{}).SetString(val.String())
+	i, ok := parseDecimal(val.String())
 	if !ok {
-		return input.WithError(fmt.Errorf("cannot parse into big.Float: %v", val.String()))
+		return input.WithError(fmt.Errorf("cannot parse into decimal: %v", val.String()))
 	}
 
-	res := i.Mul(i, big.NewFloat(float64(ma.Times)))
-	return input.WithValue(res.String())
+	times := big.Rat(ma.Times)
+	res := i.Mul(i, &times)
+	if res.Cmp(maxInt256Rat) > 0 || res.Cmp(minInt256Rat) < 0 {
+		return input.WithError(fmt.Errorf("product %s is outside the int256 range", formatDecimal(res)))
+	}
+	return ma.ResultKey.write(input, formatDecimal(res))
 }
+
+var (
+	maxInt256Rat = new(big.Rat).SetInt(utils.MaxInt256)
+	minInt256Rat = new(big.Rat).SetInt(utils.MinInt256)
+)
@@ -12,40 +12,24 @@ import "C"
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"unsafe"
 
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
-	"github.com/smartcontractkit/chainlink/utils"
 )
 
-// Multiplier represents the number to multiply by in Multiply adapter.
-type Multiplier float64
-
-// UnmarshalJSON implements json.Unmarshaler.
-func (m *Multiplier) UnmarshalJSON(input []byte) error {
-	input = utils.RemoveQuotes(input)
-	times, err := strconv.ParseFloat(string(input), 64)
-	if err != nil {
-		return fmt.Errorf("cannot parse into float: %s", input)
-	}
-
-	*m = Multiplier(times)
-
-	return nil
-}
-
 // Multiply holds the a number to multiply the given value by.
 type Multiply struct {
-	Times Multiplier `json:"times"`
+	Times     Multiplier `json:"times"`
+	ResultKey ResultKey  `json:"resultKey"`
 }
 
 // Perform returns the input's "value" field, multiplied times the adapter's
 // "times" field.
 //
 // For example, if input value is "99.994" and the adapter's "times" is
-// set to "100", the result's value will be "9999.4".
+// set to "100", the result's value will be "9999.4". The product is written
+// to the field named by ResultKey instead when it is set.
 func (ma *Multiply) Perform(input models.RunResult, _ *store.Store) models.RunResult {
 	adapterJSON, err := json.Marshal(ma)
 	if err != nil {
@@ -77,5 +61,9 @@ func (ma *Multiply) Perform(input models.RunResult, _ *store.Store) models.RunRe
 		return input.WithError(fmt.Errorf("unmarshaling SGX result: %v", err))
 	}
 
+	// The enclave always writes the product to "value".
+	if ma.ResultKey.String() != defaultResultKey && !result.HasError() {
+		return ma.ResultKey.write(input, result.Get("value").String())
+	}
 	return result
 }
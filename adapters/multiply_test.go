@@ -2,6 +2,8 @@ package adapters_test
 
 import (
 	"encoding/json"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/adapters"
@@ -34,6 +36,23 @@ func TestMultiply_Perform(t *testing.T) {
 		{"rubbish string", `{"times":"123aaa123"}`, `{"value":"1.23"}`, "", false, true},
 		{"zero string string", `{"times":"0"}`, `{"value":"1.23"}`, "0", false, false},
 		{"negative string string", `{"times":"-5"}`, `{"value":"1.23"}`, "-6.15", false, false},
+		{"rubbish value", `{"times":"100"}`, `{"value":"1.23aaa"}`, "", true, false},
+		{"fraction times", `{"times":"1/3"}`, `{"value":"1.23"}`, "", false, true},
+
+		{"wei price", `{"times":"1000000000000000000"}`, `{"value":"3405.6789"}`, "3405678900000000000000", false, false},
+		{"wei price exponent", `{"times":1e18}`, `{"value":3405.6789}`, "3405678900000000000000", false, false},
+		{"wei fraction", `{"times":"1e18"}`, `{"value":"0.000000000000000001"}`, "1", false, false},
+		{"token amount", `{"times":"1.5"}`, `{"value":"123456789.123456789123456789"}`, "185185183.6851851836851851835", false, false},
+		{"small times", `{"times":"0.000000000000000001"}`, `{"value":"3405678900000000000000"}`, "3405.6789", false, false},
+
+		{"negative times negative", `{"times":"-1e18"}`, `{"value":"-3405.6789"}`, "3405678900000000000000", false, false},
+		{"negative times positive", `{"times":"-1e18"}`, `{"value":"3405.6789"}`, "-3405678900000000000000", false, false},
+		{"positive times negative", `{"times":100}`, `{"value":-0.015}`, "-1.5", false, false},
+		{"max int256", `{"times":1}`, `{"value":"57896044618658097711785492504343953926634992332820282019728792003956564819967"}`, "57896044618658097711785492504343953926634992332820282019728792003956564819967", false, false},
+		{"min int256", `{"times":-1}`, `{"value":"57896044618658097711785492504343953926634992332820282019728792003956564819967"}`, "-57896044618658097711785492504343953926634992332820282019728792003956564819967", false, false},
+		{"over max int256", `{"times":"1.0000000000000000001"}`, `{"value":"57896044618658097711785492504343953926634992332820282019728792003956564819967"}`, "", true, false},
+		{"under min int256", `{"times":"-2"}`, `{"value":"28948022309329048855892746252171976963317496166410141009864396001978282409984"}`, "", true, false},
+		{"large magnitudes", `{"times":"1e77"}`, `{"value":"1e77"}`, "", true, false},
 	}
 
 	for _, tt := range tests {
@@ -62,3 +81,39 @@ func TestMultiply_Perform(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiply_Perform_MatchesExactDecimalWhereFloatDoesNot(t *testing.T) {
+	input := cltest.RunResultWithValue("3405.6789")
+	adapter := adapters.Multiply{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"times":"1000000000000000000"}`), &adapter))
+
+	float, _ := big.NewFloat(3405.6789 * 1e18).Int(nil)
+	assert.Equal(t, "3405678900000000049152", float.String())
+
+	result := adapter.Perform(input, nil)
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "3405678900000000000000", val)
+}
+
+func TestMultiply_Perform_OverflowNamesProduct(t *testing.T) {
+	input := cltest.RunResultWithValue("1e40")
+	adapter := adapters.Multiply{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"times":"-1e40"}`), &adapter))
+
+	result := adapter.Perform(input, nil)
+	assert.EqualError(t, result.GetError(), "product -1"+strings.Repeat("0", 80)+" is outside the int256 range")
+}
+
+func TestMultiply_Perform_NegativeProductEncodesAsInt256(t *testing.T) {
+	input := cltest.RunResultWithValue("12.5")
+	adapter := adapters.Multiply{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"times":-100}`), &adapter))
+	result := adapter.Perform(input, nil)
+	assert.NoError(t, result.GetError())
+
+	result = (&adapters.EthInt256{}).Perform(result, nil)
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffb1e", val)
+}
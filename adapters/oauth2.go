@@ -0,0 +1,139 @@
+package adapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// OAuth2 obtains a bearer token from an OAuth2 token endpoint using the
+// client credentials grant, so that later tasks can authenticate with it.
+// The client ID and secret are the username and password of the
+// HTTPCredential named by Auth, so that they are not part of the job spec.
+type OAuth2 struct {
+	TokenURL models.WebURL `json:"tokenURL"`
+	Auth     string        `json:"auth"`
+	Scope    string        `json:"scope"`
+	// CacheToken keeps the token in the node's database until its expires_in
+	// elapses, rather than requesting a new one on every run.
+	CacheToken bool `json:"cacheToken"`
+}
+
+// oauth2TokenResponse is the successful response of a token endpoint, as
+// defined by RFC 6749 section 5.1.
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Perform POSTs the client credentials to TokenURL and returns the access
+// token as the "value" field of the result. The client ID and secret are
+// sent using HTTP basic auth, and are never logged or returned.
+func (oa *OAuth2) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return oa.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx is Perform, abandoning the token request once ctx is done.
+func (oa *OAuth2) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	if oa.TokenURL.String() == "" || oa.Auth == "" {
+		return input.WithError(errors.New("OAuth2 requires a tokenURL and auth"))
+	}
+	credential, err := findHTTPCredential(str, oa.Auth, oa.GetURL())
+	if err != nil {
+		return input.WithError(err)
+	}
+	if credential.Username == "" || credential.Password == "" {
+		return input.WithError(fmt.Errorf("OAuth2 credential %s must have a username and password", oa.Auth))
+	}
+
+	key := oa.cacheKey(credential)
+	if oa.CacheToken {
+		token, err := str.FindOAuth2Token(key)
+		if err != nil && err != storm.ErrNotFound {
+			return input.WithError(fmt.Errorf("unable to read cached OAuth2 token: %v", err))
+		}
+		if token.Valid(str.Clock.Now()) {
+			return input.WithValue(token.AccessToken)
+		}
+	}
+
+	response, err := oa.requestToken(ctx, input, str, credential)
+	if err != nil {
+		return input.WithError(err)
+	}
+	logger.Debugw("Obtained OAuth2 token", "tokenURL", oa.TokenURL.String(), "expiresIn", response.ExpiresIn)
+
+	if oa.CacheToken && response.ExpiresIn > 0 {
+		token := models.OAuth2Token{
+			AccessToken: response.AccessToken,
+			ExpiresAt:   str.Clock.Now().Add(time.Duration(response.ExpiresIn) * time.Second),
+		}
+		if err := str.SaveOAuth2Token(key, token); err != nil {
+			return input.WithError(fmt.Errorf("unable to cache OAuth2 token: %v", err))
+		}
+	}
+	return input.WithValue(response.AccessToken)
+}
+
+// GetURL returns the token endpoint, which the credential must allow.
+func (oa *OAuth2) GetURL() string {
+	return oa.TokenURL.String()
+}
+
+func (oa *OAuth2) requestToken(ctx context.Context, input models.RunResult, str *store.Store, credential *models.HTTPCredential) (oauth2TokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if oa.Scope != "" {
+		form.Set("scope", oa.Scope)
+	}
+	newRequest := func() (*http.Request, error) {
+		request, err := http.NewRequest("POST", oa.TokenURL.String(), strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.Header.Set("Accept", "application/json")
+		request.SetBasicAuth(url.QueryEscape(credential.Username), url.QueryEscape(credential.Password))
+		return request, nil
+	}
+
+	result := sendRequest(ctx, input, newRequest, newHTTPRequestConfig(str, store.Duration{}))
+	if result.HasError() {
+		return oauth2TokenResponse{}, fmt.Errorf("OAuth2 token request failed: %v", result.Error())
+	}
+
+	var response oauth2TokenResponse
+	if err := json.Unmarshal([]byte(result.Get("value").String()), &response); err != nil {
+		return response, fmt.Errorf("unable to parse OAuth2 token response: %v", err)
+	}
+	if response.AccessToken == "" {
+		return response, errors.New("OAuth2 token response has no access_token")
+	}
+	if response.TokenType != "" && !strings.EqualFold(response.TokenType, "bearer") {
+		return response, fmt.Errorf("OAuth2 token type must be bearer, got %q", response.TokenType)
+	}
+	return response, nil
+}
+
+// cacheKey identifies the token request, so that the secret itself is never
+// stored and changing any of the credentials obtains a new token.
+func (oa *OAuth2) cacheKey(credential *models.HTTPCredential) string {
+	h := sha256.New()
+	for _, s := range []string{oa.TokenURL.String(), credential.Username, credential.Password, oa.Scope} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
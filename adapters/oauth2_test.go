@@ -0,0 +1,229 @@
+package adapters_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	oauth2ClientID     = "node-client-id"
+	oauth2ClientSecret = "do-not-log-this-secret"
+)
+
+// tokenServer is a client credentials token endpoint which issues a new
+// token on every successful request.
+type tokenServer struct {
+	*httptest.Server
+	calls int32
+}
+
+func newTokenServer(t *testing.T, response string) *tokenServer {
+	if response == "" {
+		response = `{"access_token":"token-%d","token_type":"Bearer","expires_in":60}`
+	}
+	ts := &tokenServer{}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&ts.calls, 1)
+		assert.Equal(t, "POST", r.Method)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "prices:read", r.PostForm.Get("scope"))
+
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != oauth2ClientID || secret != oauth2ClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, response, n)
+	}))
+	return ts
+}
+
+func (ts *tokenServer) callCount() int32 { return atomic.LoadInt32(&ts.calls) }
+
+// saveOAuth2Credential stores the client credentials for the token endpoint
+// at url under name, and returns an adapter which uses them.
+func saveOAuth2Credential(t *testing.T, store *strpkg.Store, name, url, clientID string) adapters.OAuth2 {
+	require.NoError(t, store.Save(&models.HTTPCredential{
+		Name:      name,
+		URLPrefix: url + "/",
+		Username:  clientID,
+		Password:  oauth2ClientSecret,
+	}))
+	return adapters.OAuth2{
+		TokenURL: cltest.WebURL(url),
+		Auth:     name,
+		Scope:    "prices:read",
+	}
+}
+
+func newOAuth2(t *testing.T, store *strpkg.Store, url string) adapters.OAuth2 {
+	return saveOAuth2Credential(t, store, "auth-server", url, oauth2ClientID)
+}
+
+func TestOAuth2_Perform(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newTokenServer(t, "")
+	defer server.Close()
+	oa := newOAuth2(t, store, server.URL)
+
+	for i := 1; i <= 2; i++ {
+		result := oa.Perform(cltest.RunResultWithValue("input"), store)
+		require.NoError(t, result.GetError())
+		val, err := result.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("token-%d", i), val)
+	}
+	assert.Equal(t, int32(2), server.callCount())
+}
+
+func TestOAuth2_Perform_CacheToken(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Now())
+	server := newTokenServer(t, "")
+	defer server.Close()
+	oa := newOAuth2(t, store, server.URL)
+	oa.CacheToken = true
+
+	assert.Equal(t, "token-1", oa.Perform(cltest.RunResultWithValue("input"), store).Get("value").String())
+	clock.SetTime(clock.Now().Add(59 * time.Second))
+	assert.Equal(t, "token-1", oa.Perform(cltest.RunResultWithValue("input"), store).Get("value").String())
+	assert.Equal(t, int32(1), server.callCount())
+
+	clock.SetTime(clock.Now().Add(time.Second))
+	assert.Equal(t, "token-2", oa.Perform(cltest.RunResultWithValue("input"), store).Get("value").String())
+	assert.Equal(t, int32(2), server.callCount())
+}
+
+func TestOAuth2_Perform_CachedTokenSurvivesRestart(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newTokenServer(t, "")
+	defer server.Close()
+
+	first := newOAuth2(t, store, server.URL)
+	first.CacheToken = true
+	assert.Equal(t, "token-1", first.Perform(cltest.RunResultWithValue("input"), store).Get("value").String())
+
+	// A freshly parsed adapter only shares the database with the first one.
+	task := cltest.NewTask("oauth2", fmt.Sprintf(`{"tokenURL":"%s","auth":"auth-server","scope":"prices:read","cacheToken":true}`, server.URL))
+	adapter, err := adapters.For(task, store)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", adapter.Perform(cltest.RunResultWithValue("input"), store).Get("value").String())
+	assert.Equal(t, int32(1), server.callCount())
+}
+
+func TestOAuth2_Perform_DoesNotLogCredentials(t *testing.T) {
+	logs := cltest.ObserveLogs()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newTokenServer(t, "")
+	defer server.Close()
+
+	oa := newOAuth2(t, store, server.URL)
+	oa.CacheToken = true
+	result := oa.Perform(cltest.RunResultWithValue("input"), store)
+	require.NoError(t, result.GetError())
+
+	rejected := saveOAuth2Credential(t, store, "someone-else", server.URL, "someone-else")
+	result = rejected.Perform(cltest.RunResultWithValue("input"), store)
+	require.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "invalid_client")
+	assert.NotContains(t, result.Error(), oauth2ClientSecret)
+
+	require.NotEmpty(t, logs.All())
+	for _, entry := range logs.All() {
+		line := fmt.Sprint(entry.Message, entry.ContextMap())
+		assert.False(t, strings.Contains(line, oauth2ClientSecret), "secret logged: %s", line)
+		assert.False(t, strings.Contains(line, oauth2ClientID), "client ID logged: %s", line)
+	}
+}
+
+func TestOAuth2_Perform_InvalidResponse(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{"not json", `token %d`, "unable to parse"},
+		{"no access token", `{"token_type":"Bearer","n":%d}`, "no access_token"},
+		{"not bearer", `{"access_token":"token-%d","token_type":"mac"}`, "must be bearer"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			server := newTokenServer(t, test.response)
+			defer server.Close()
+			oa := saveOAuth2Credential(t, store, test.name, server.URL, oauth2ClientID)
+			result := oa.Perform(cltest.RunResultWithValue("input"), store)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), test.wantErr)
+		})
+	}
+}
+
+func TestOAuth2_Perform_MissingParams(t *testing.T) {
+	t.Parallel()
+	oa := adapters.OAuth2{TokenURL: cltest.WebURL("https://auth.example.com/token")}
+	result := oa.Perform(cltest.RunResultWithValue("input"), nil)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "auth")
+}
+
+func TestOAuth2_Perform_Credential(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newTokenServer(t, "")
+	defer server.Close()
+
+	require.NoError(t, store.Save(&models.HTTPCredential{Name: "bearer", URLPrefix: server.URL + "/", Token: "s3cr3t"}))
+	require.NoError(t, store.Save(&models.HTTPCredential{Name: "elsewhere", URLPrefix: "https://auth.example.com/", Username: oauth2ClientID, Password: oauth2ClientSecret}))
+
+	tests := []struct {
+		name    string
+		auth    string
+		wantErr string
+	}{
+		{"unknown", "missing", "unable to find credential missing"},
+		{"token", "bearer", "must have a username and password"},
+		{"other url", "elsewhere", "may only be sent to https://auth.example.com/"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			oa := adapters.OAuth2{TokenURL: cltest.WebURL(server.URL), Auth: test.auth, Scope: "prices:read"}
+			result := oa.Perform(cltest.RunResultWithValue("input"), store)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), test.wantErr)
+		})
+	}
+	assert.Equal(t, int32(0), server.callCount())
+}
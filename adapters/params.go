@@ -0,0 +1,82 @@
+package adapters
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/tidwall/gjson"
+)
+
+// CheckParams returns an error naming the first of the task's params which
+// its adapter has no field for, such as a misspelt "tims" on a Multiply task,
+// which json.Unmarshal would otherwise quietly ignore. Tasks wrapped by
+// adapters such as Cache are checked too. Bridges accept any params, since
+// they are passed on to the external adapter.
+func CheckParams(task models.TaskSpec) error {
+	factory, ok := lookupFactory(task.Type)
+	if !ok {
+		return nil
+	}
+	ba := factory()
+
+	var unknown string
+	task.Params.ForEach(func(key, _ gjson.Result) bool {
+		// Older specs repeat the task's type among its params.
+		if key.String() == "type" {
+			return true
+		}
+		if !hasParamField(reflect.TypeOf(ba), key.String()) {
+			unknown = key.String()
+			return false
+		}
+		return true
+	})
+	if unknown != "" {
+		return fmt.Errorf("%s has no param named %q", task.Type, unknown)
+	}
+
+	tw, ok := ba.(taskWrapper)
+	if !ok || unmarshalParams(task.Params, ba) != nil {
+		return nil
+	}
+	for _, inner := range tw.wrappedTasks() {
+		if err := CheckParams(inner); err != nil {
+			return fmt.Errorf("%s: %v", task.Type, err)
+		}
+	}
+	return nil
+}
+
+// hasParamField reports whether json.Unmarshal would decode key into a field
+// of t, matching names in the same case insensitive way.
+func hasParamField(t reflect.Type, key string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		} else if field.Anonymous && name == "" {
+			if hasParamField(field.Type, key) {
+				return true
+			}
+			continue
+		} else if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return false
+}
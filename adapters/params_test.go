@@ -0,0 +1,46 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		taskType string
+		params   string
+		wantErr  string
+	}{
+		{"known", "multiply", `{"times":100}`, ""},
+		{"known in another case", "multiply", `{"TIMES":100}`, ""},
+		{"unknown", "multiply", `{"tims":100}`, `multiply has no param named "tims"`},
+		{"no params", "noop", ``, ""},
+		{"custom unmarshaler", "ethtx", `{"functionSelector":"0x609ff1bd","format":"bytes"}`, ""},
+		{"custom unmarshaler unknown", "ethtx", `{"functionSelectr":"0x609ff1bd"}`, `ethtx has no param named "functionSelectr"`},
+		{"bridge", "someBridge", `{"anything":"goes"}`, ""},
+		{"wrapped", "cache", `{"ttl":"1m","innerTask":{"type":"httpget","params":{"gett":"https://example.com"}}}`,
+			`cache: httpget has no param named "gett"`},
+		{"wrapped twice", "circuitbreaker", `{"task":{"type":"cache","params":{"innerTask":{"type":"multiply","params":{"tims":1}}}}}`,
+			`circuitbreaker: cache: multiply has no param named "tims"`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			task := cltest.NewTask(test.taskType, test.params)
+			err := adapters.CheckParams(task)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
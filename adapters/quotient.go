@@ -0,0 +1,70 @@
+package adapters
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// QuotientSignificantDigits is how many significant digits the Quotient
+// adapter keeps when none are given.
+const QuotientSignificantDigits = 18
+
+// Dividend represents the number divided in the Quotient adapter.
+type Dividend big.Rat
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a number or a
+// string containing a decimal number.
+func (d *Dividend) UnmarshalJSON(input []byte) error {
+	input = utils.RemoveQuotes(input)
+	dividend, ok := parseDecimal(string(input))
+	if !ok {
+		return fmt.Errorf("cannot parse into decimal: %s", input)
+	}
+
+	*d = Dividend(*dividend)
+
+	return nil
+}
+
+// Quotient holds a number to divide by the given value, and optionally the
+// number of significant digits to round the result to.
+type Quotient struct {
+	Dividend          *Dividend `json:"dividend"`
+	SignificantDigits *int      `json:"significantDigits"`
+}
+
+// Perform returns the adapter's "dividend" field, 1 by default, divided by
+// the input's "value" field.
+//
+// For example, if the input value is "8" the result's value will be "0.125",
+// inverting a price pair. Results are rounded to "significantDigits"
+// significant digits, 18 by default, so "3" gives "0.333333333333333333".
+func (qa *Quotient) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val := input.Get("value")
+	divisor, ok := parseDecimal(val.String())
+	if !ok {
+		return input.WithError(fmt.Errorf("cannot parse into decimal: %v", val.String()))
+	}
+	if divisor.Sign() == 0 {
+		return input.WithError(ErrorDivisionByZero)
+	}
+
+	digits := QuotientSignificantDigits
+	if qa.SignificantDigits != nil {
+		digits = *qa.SignificantDigits
+	}
+	if digits < 1 {
+		return input.WithError(fmt.Errorf("significantDigits must be at least 1, got %d", digits))
+	}
+
+	dividend := big.NewRat(1, 1)
+	if qa.Dividend != nil {
+		d := big.Rat(*qa.Dividend)
+		dividend = &d
+	}
+	return input.WithValue(formatSignificant(new(big.Rat).Quo(dividend, divisor), digits))
+}
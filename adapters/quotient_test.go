@@ -0,0 +1,76 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotient_Perform(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    string
+		json      string
+		want      string
+		errored   bool
+		jsonError bool
+	}{
+		{"default dividend", `{}`, `{"value":"4"}`, "0.25", false, false},
+		{"integer result", `{}`, `{"value":0.004}`, "250", false, false},
+		{"exact long fraction", `{}`, `{"value":1024}`, "0.0009765625", false, false},
+		{"negative", `{}`, `{"value":"-8"}`, "-0.125", false, false},
+		{"dividend", `{"dividend":100}`, `{"value":"8"}`, "12.5", false, false},
+		{"string dividend", `{"dividend":"1e18"}`, `{"value":"4"}`, "250000000000000000", false, false},
+		{"zero dividend", `{"dividend":0}`, `{"value":"4"}`, "0", false, false},
+		{"exponent value", `{}`, `{"value":"1e-18"}`, "1000000000000000000", false, false},
+		{"repeating", `{}`, `{"value":"3"}`, "0.333333333333333333", false, false},
+		{"repeating rounded up", `{"dividend":2}`, `{"value":"3"}`, "0.666666666666666667", false, false},
+		{"inverse price", `{}`, `{"value":"212.5"}`, "0.00470588235294117647", false, false},
+		{"significant digits", `{"significantDigits":5}`, `{"value":"3"}`, "0.33333", false, false},
+		{"significant digits of integer", `{"dividend":"1e30","significantDigits":3}`, `{"value":"3"}`, "333000000000000000000000000000", false, false},
+		{"rounds to fewer digits", `{"significantDigits":3}`, `{"value":"0.999999"}`, "1", false, false},
+		{"zero value", `{}`, `{"value":"0"}`, "", true, false},
+		{"rubbish value", `{}`, `{"value":"1.23aaa"}`, "", true, false},
+		{"object", `{}`, `{"value":{"foo":"bar"}}`, "", true, false},
+		{"zero significant digits", `{"significantDigits":0}`, `{"value":"3"}`, "", true, false},
+		{"rubbish dividend", `{"dividend":"123aaa123"}`, `{"value":"1.23"}`, "", false, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			input := models.RunResult{
+				Data: cltest.JSONFromString(test.json),
+			}
+			adapter := adapters.Quotient{}
+			jsonErr := json.Unmarshal([]byte(test.params), &adapter)
+
+			if test.jsonError {
+				assert.Error(t, jsonErr)
+				return
+			}
+			assert.NoError(t, jsonErr)
+
+			result := adapter.Perform(input, nil)
+			if test.errored {
+				assert.Error(t, result.GetError())
+			} else {
+				val, err := result.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, val)
+				assert.NoError(t, result.GetError())
+			}
+		})
+	}
+}
+
+func TestQuotient_Perform_DivisionByZero(t *testing.T) {
+	adapter := adapters.Quotient{}
+	result := adapter.Perform(cltest.RunResultWithValue("0"), nil)
+	assert.Equal(t, adapters.ErrorDivisionByZero.Error(), result.Error())
+}
@@ -0,0 +1,82 @@
+package adapters
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// Uint256Param is an unsigned integer that fits in 256 bits, given as either
+// a JSON number or a string.
+type Uint256Param big.Int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *Uint256Param) UnmarshalJSON(input []byte) error {
+	input = utils.RemoveQuotes(input)
+	i, ok := new(big.Int).SetString(string(input), 10)
+	if !ok {
+		return fmt.Errorf("cannot parse into integer: %s", input)
+	}
+	if i.Sign() < 0 || i.Cmp(utils.MaxUint256) > 0 {
+		return fmt.Errorf("%s does not fit in an unsigned 256 bit integer", input)
+	}
+
+	*u = Uint256Param(*i)
+
+	return nil
+}
+
+// Random holds the optional inclusive bounds of the random number to
+// generate, and an optional path to run data to derive it from.
+type Random struct {
+	Min      *Uint256Param `json:"min"`
+	Max      *Uint256Param `json:"max"`
+	SeedPath string        `json:"seedPath"`
+}
+
+// Perform returns a random number between Min and Max inclusive, which
+// default to 0 and 2^256-1, as a decimal string in the "value" field of the
+// result.
+//
+// The number comes from crypto/rand, unless SeedPath is set, in which case it
+// is derived from the Keccak256 hash of the run data at that path so that
+// the same data always results in the same number.
+func (ra *Random) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	min, max := big.NewInt(0), utils.MaxUint256
+	if ra.Min != nil {
+		min = (*big.Int)(ra.Min)
+	}
+	if ra.Max != nil {
+		max = (*big.Int)(ra.Max)
+	}
+	if min.Cmp(max) > 0 {
+		return input.WithError(fmt.Errorf("random min %s must not be greater than max %s", min, max))
+	}
+
+	span := new(big.Int).Sub(max, min)
+	span.Add(span, big.NewInt(1))
+
+	var offset *big.Int
+	if ra.SeedPath != "" {
+		seed := input.Get(ra.SeedPath)
+		if !seed.Exists() {
+			return input.WithError(fmt.Errorf("no run data found at seedPath %q", ra.SeedPath))
+		}
+		hash, err := utils.Keccak256([]byte(seed.String()))
+		if err != nil {
+			return input.WithError(err)
+		}
+		offset = new(big.Int).Mod(new(big.Int).SetBytes(hash), span)
+	} else {
+		var err error
+		if offset, err = rand.Int(rand.Reader, span); err != nil {
+			return input.WithError(err)
+		}
+	}
+
+	return input.WithValue(offset.Add(offset, min).String())
+}
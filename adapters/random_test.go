@@ -0,0 +1,126 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func performRandom(t *testing.T, params string, input models.RunResult) models.RunResult {
+	adapter := adapters.Random{}
+	require.NoError(t, json.Unmarshal([]byte(params), &adapter))
+	return adapter.Perform(input, nil)
+}
+
+func randomValue(t *testing.T, result models.RunResult) *big.Int {
+	require.False(t, result.HasError(), result.Error())
+	val, err := result.Value()
+	require.NoError(t, err)
+	i, ok := new(big.Int).SetString(val, 10)
+	require.True(t, ok, "not a decimal integer: %s", val)
+	return i
+}
+
+func TestRandom_Perform_Range(t *testing.T) {
+	maxUint256 := utils.MaxUint256.String()
+	maxUint256MinusOne := new(big.Int).Sub(utils.MaxUint256, big.NewInt(1)).String()
+
+	tests := []struct {
+		name   string
+		params string
+		min    string
+		max    string
+	}{
+		{"default range", `{}`, "0", maxUint256},
+		{"single value", `{"min":7,"max":7}`, "7", "7"},
+		{"zero", `{"min":0,"max":0}`, "0", "0"},
+		{"largest value", `{"min":"` + maxUint256 + `"}`, maxUint256, maxUint256},
+		{"top of range", `{"min":"` + maxUint256MinusOne + `","max":"` + maxUint256 + `"}`, maxUint256MinusOne, maxUint256},
+		{"small range", `{"min":"1","max":"6"}`, "1", "6"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			min, _ := new(big.Int).SetString(test.min, 10)
+			max, _ := new(big.Int).SetString(test.max, 10)
+			for i := 0; i < 50; i++ {
+				val := randomValue(t, performRandom(t, test.params, cltest.RunResultWithValue("")))
+				assert.True(t, val.Cmp(min) >= 0, "%s is below %s", val, min)
+				assert.True(t, val.Cmp(max) <= 0, "%s is above %s", val, max)
+			}
+		})
+	}
+}
+
+func TestRandom_Perform_ReachesBothBounds(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 200 && len(seen) < 2; i++ {
+		val := randomValue(t, performRandom(t, `{"min":10,"max":11}`, cltest.RunResultWithValue("")))
+		seen[val.String()] = true
+	}
+	assert.Equal(t, map[string]bool{"10": true, "11": true}, seen)
+}
+
+func TestRandom_Perform_Seeded(t *testing.T) {
+	input := models.RunResult{Data: cltest.JSONFromString(`{"requestId":"abc","nested":{"seed":"def"}}`)}
+
+	hash, err := utils.Keccak256([]byte("abc"))
+	require.NoError(t, err)
+	want := new(big.Int).SetBytes(hash)
+
+	first := randomValue(t, performRandom(t, `{"seedPath":"requestId"}`, input))
+	second := randomValue(t, performRandom(t, `{"seedPath":"requestId"}`, input))
+	assert.Equal(t, want, first)
+	assert.Equal(t, first, second)
+
+	nested := randomValue(t, performRandom(t, `{"seedPath":"nested.seed"}`, input))
+	assert.NotEqual(t, first, nested)
+
+	ranged := randomValue(t, performRandom(t, `{"seedPath":"requestId","min":100,"max":199}`, input))
+	wantRanged := new(big.Int).Mod(want, big.NewInt(100))
+	assert.Equal(t, wantRanged.Add(wantRanged, big.NewInt(100)), ranged)
+}
+
+func TestRandom_Perform_Errors(t *testing.T) {
+	result := performRandom(t, `{"min":2,"max":1}`, cltest.RunResultWithValue(""))
+	assert.True(t, result.HasError())
+
+	result = performRandom(t, `{"seedPath":"missing"}`, cltest.RunResultWithValue(""))
+	assert.True(t, result.HasError())
+}
+
+func TestRandom_UnmarshalJSON(t *testing.T) {
+	tooBig := new(big.Int).Add(utils.MaxUint256, big.NewInt(1)).String()
+
+	tests := []struct {
+		name    string
+		params  string
+		wantErr bool
+	}{
+		{"number", `{"min":1}`, false},
+		{"string", `{"max":"115792089237316195423570985008687907853269984665640564039457584007913129639935"}`, false},
+		{"negative", `{"min":-1}`, true},
+		{"too big", `{"max":"` + tooBig + `"}`, true},
+		{"fractional", `{"min":1.5}`, true},
+		{"not a number", `{"min":"one"}`, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.Random{}
+			err := json.Unmarshal([]byte(test.params), &adapter)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// RedisModeStandalone connects to a single Redis server.
+	RedisModeStandalone = "standalone"
+	// RedisModeSentinel asks the Redis Sentinels at Address for the current
+	// master of MasterName.
+	RedisModeSentinel = "sentinel"
+	// RedisModeCluster connects to a Redis Cluster through the seed nodes at
+	// Address.
+	RedisModeCluster = "cluster"
+)
+
+// ErrKeyNotFound is returned by a Redis "get" when nothing is stored under
+// the key.
+type ErrKeyNotFound struct {
+	Key string
+}
+
+func (e *ErrKeyNotFound) Error() string {
+	return fmt.Sprintf("redis key %q not found", e.Key)
+}
+
+// Redis gets, sets or deletes a key on a Redis server.
+type Redis struct {
+	// Operation is one of "get", "set" or "del".
+	Operation string `json:"operation"`
+	// Mode is one of "standalone", the default, "sentinel" or "cluster".
+	Mode string `json:"mode"`
+	// Address is the server's host:port, or a comma separated list of them
+	// for the sentinels or cluster seed nodes.
+	Address    string `json:"address"`
+	MasterName string `json:"masterName"`
+	// Key is a text/template rendered against the run data, for example
+	// "price-{{.symbol}}".
+	Key      string         `json:"key"`
+	TTL      store.Duration `json:"ttl"`
+	Password string         `json:"password"`
+	DB       int            `json:"db"`
+}
+
+// Perform runs the operation against the key. A "get" returns the stored
+// value as the "value" field of the result, a "set" stores the input's value,
+// expiring it after TTL if one is given, and a "del" removes the key. Both
+// "set" and "del" pass the input through unchanged.
+func (ra *Redis) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	if err := ra.validate(); err != nil {
+		return input.WithError(err)
+	}
+	key, err := renderDataTemplate("redis key", ra.Key, input)
+	if err != nil {
+		return input.WithError(err)
+	}
+	client, err := redisUniversalClientFor(ra.clientOptions())
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	switch ra.Operation {
+	case "get":
+		value, err := client.Get(key).Result()
+		if err == redis.Nil {
+			return input.WithError(&ErrKeyNotFound{Key: key})
+		} else if err != nil {
+			return input.WithError(err)
+		}
+		return input.WithValue(value)
+	case "set":
+		value := input.Get("value")
+		if !value.Exists() {
+			return input.WithError(errors.New("Redis set requires a value to store"))
+		}
+		stored := value.Raw
+		if value.Type == gjson.String {
+			stored = value.String()
+		}
+		if err := client.Set(key, stored, ra.TTL.Duration).Err(); err != nil {
+			return input.WithError(err)
+		}
+	case "del":
+		if err := client.Del(key).Err(); err != nil {
+			return input.WithError(err)
+		}
+	}
+	input.Status = models.RunStatusCompleted
+	return input
+}
+
+func (ra *Redis) validate() error {
+	switch ra.Operation {
+	case "get", "set", "del":
+	default:
+		return fmt.Errorf(`Redis operation must be "get", "set" or "del", got %q`, ra.Operation)
+	}
+	if ra.Address == "" {
+		return errors.New("Redis requires an address")
+	}
+	if ra.Key == "" {
+		return errors.New("Redis requires a key")
+	}
+
+	switch ra.Mode {
+	case "", RedisModeStandalone:
+		if len(ra.addresses()) != 1 {
+			return errors.New("Redis standalone mode takes a single address")
+		}
+	case RedisModeSentinel:
+		if ra.MasterName == "" {
+			return errors.New("Redis sentinel mode requires a masterName")
+		}
+	case RedisModeCluster:
+		if ra.DB != 0 {
+			return errors.New("Redis cluster mode only supports db 0")
+		}
+	default:
+		return fmt.Errorf("Redis mode must be %q, %q or %q, got %q",
+			RedisModeStandalone, RedisModeSentinel, RedisModeCluster, ra.Mode)
+	}
+	return nil
+}
+
+func (ra *Redis) addresses() []string {
+	var addrs []string
+	for _, addr := range strings.Split(ra.Address, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func (ra *Redis) clientOptions() redisClientOptions {
+	mode := ra.Mode
+	if mode == "" {
+		mode = RedisModeStandalone
+	}
+	return redisClientOptions{
+		mode:       mode,
+		addrs:      strings.Join(ra.addresses(), ","),
+		masterName: ra.MasterName,
+		password:   ra.Password,
+		db:         ra.DB,
+	}
+}
+
+// redisClientOptions identifies a pooled client in redisUniversalClients.
+type redisClientOptions struct {
+	mode       string
+	addrs      string
+	masterName string
+	password   string
+	db         int
+}
+
+// redisUniversalClients holds a client per server and credentials, in the
+// same way as redisClients does for the Cache adapter's URLs.
+var redisUniversalClients = struct {
+	sync.Mutex
+	byOptions map[redisClientOptions]redis.UniversalClient
+}{byOptions: map[redisClientOptions]redis.UniversalClient{}}
+
+func redisUniversalClientFor(opts redisClientOptions) (redis.UniversalClient, error) {
+	redisUniversalClients.Lock()
+	defer redisUniversalClients.Unlock()
+
+	if client, ok := redisUniversalClients.byOptions[opts]; ok {
+		return client, nil
+	}
+
+	addrs := strings.Split(opts.addrs, ",")
+	var client redis.UniversalClient
+	switch opts.mode {
+	case RedisModeStandalone:
+		client = redis.NewClient(&redis.Options{
+			Addr:     addrs[0],
+			Password: opts.password,
+			DB:       opts.db,
+		})
+	case RedisModeSentinel:
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    opts.masterName,
+			SentinelAddrs: addrs,
+			Password:      opts.password,
+			DB:            opts.db,
+		})
+	case RedisModeCluster:
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs,
+			Password: opts.password,
+		})
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", opts.mode)
+	}
+	redisUniversalClients.byOptions[opts] = client
+	return client, nil
+}
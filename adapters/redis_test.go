@@ -0,0 +1,172 @@
+package adapters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMiniredis(t *testing.T) *miniredis.Miniredis {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	return server
+}
+
+func TestRedis_Perform_Get(t *testing.T) {
+	t.Parallel()
+	server := newMiniredis(t)
+	defer server.Close()
+	require.NoError(t, server.Set("price-ETH", "123.45"))
+
+	ra := adapters.Redis{Operation: "get", Address: server.Addr(), Key: "price-{{.symbol}}"}
+	input := models.RunResult{Data: cltest.JSONFromString(`{"symbol":"ETH"}`)}
+	result := ra.Perform(input, nil)
+
+	require.NoError(t, result.GetError())
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "123.45", val)
+}
+
+func TestRedis_Perform_GetMissingKey(t *testing.T) {
+	t.Parallel()
+	server := newMiniredis(t)
+	defer server.Close()
+
+	ra := adapters.Redis{Operation: "get", Address: server.Addr(), Key: "price-ETH"}
+	result := ra.Perform(cltest.RunResultWithValue("input"), nil)
+
+	assert.True(t, result.HasError())
+	assert.Equal(t, (&adapters.ErrKeyNotFound{Key: "price-ETH"}).Error(), result.Error())
+}
+
+func TestRedis_Perform_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{"string", `{"value":"123.45"}`, "123.45"},
+		{"number", `{"value":123.45}`, "123.45"},
+		{"object", `{"value":{"bid":1,"ask":2}}`, `{"bid":1,"ask":2}`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			server := newMiniredis(t)
+			defer server.Close()
+
+			ra := adapters.Redis{Operation: "set", Address: server.Addr(), Key: "price"}
+			input := models.RunResult{Data: cltest.JSONFromString(test.json)}
+			result := ra.Perform(input, nil)
+
+			require.NoError(t, result.GetError())
+			assert.Equal(t, models.RunStatusCompleted, result.Status)
+			assert.JSONEq(t, test.json, result.Data.String())
+			stored, err := server.Get("price")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, stored)
+			assert.Zero(t, server.TTL("price"))
+		})
+	}
+}
+
+func TestRedis_Perform_SetWithTTL(t *testing.T) {
+	t.Parallel()
+	server := newMiniredis(t)
+	defer server.Close()
+
+	ra := adapters.Redis{
+		Operation: "set",
+		Address:   server.Addr(),
+		Key:       "price",
+		TTL:       strpkg.Duration{Duration: time.Minute},
+	}
+	result := ra.Perform(cltest.RunResultWithValue("123.45"), nil)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, time.Minute, server.TTL("price"))
+
+	server.FastForward(time.Minute)
+	assert.False(t, server.Exists("price"))
+}
+
+func TestRedis_Perform_Del(t *testing.T) {
+	t.Parallel()
+	server := newMiniredis(t)
+	defer server.Close()
+	require.NoError(t, server.Set("price", "123.45"))
+
+	ra := adapters.Redis{Operation: "del", Address: server.Addr(), Key: "price"}
+	result := ra.Perform(cltest.RunResultWithValue("input"), nil)
+
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "input", result.Get("value").String())
+	assert.False(t, server.Exists("price"))
+}
+
+func TestRedis_Perform_PasswordAndDB(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	server := newMiniredis(t)
+	defer server.Close()
+	server.RequireAuth("s3cr3t")
+	require.NoError(t, server.DB(2).Set("price", "123.45"))
+
+	task := cltest.NewTask("redis", `{"operation":"get","address":"`+server.Addr()+`","key":"price","password":"s3cr3t","db":2}`)
+	adapter, err := adapters.For(task, store)
+	require.NoError(t, err)
+	result := adapter.Perform(cltest.RunResultWithValue("input"), store)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "123.45", result.Get("value").String())
+
+	unauthenticated := adapters.Redis{Operation: "get", Address: server.Addr(), Key: "price", DB: 2}
+	result = unauthenticated.Perform(cltest.RunResultWithValue("input"), nil)
+	assert.True(t, result.HasError())
+}
+
+func TestRedis_Perform_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		adapter adapters.Redis
+		wantErr string
+	}{
+		{"unknown operation", adapters.Redis{Operation: "incr", Address: "localhost:6379", Key: "k"}, "operation must be"},
+		{"no address", adapters.Redis{Operation: "get", Key: "k"}, "requires an address"},
+		{"no key", adapters.Redis{Operation: "get", Address: "localhost:6379"}, "requires a key"},
+		{"unknown mode", adapters.Redis{Operation: "get", Address: "localhost:6379", Key: "k", Mode: "replica"}, "mode must be"},
+		{"standalone with many addresses", adapters.Redis{
+			Operation: "get", Address: "10.0.0.1:6379,10.0.0.2:6379", Key: "k",
+		}, "single address"},
+		{"sentinel without master", adapters.Redis{
+			Operation: "get", Address: "10.0.0.1:26379", Key: "k", Mode: adapters.RedisModeSentinel,
+		}, "requires a masterName"},
+		{"cluster with db", adapters.Redis{
+			Operation: "get", Address: "10.0.0.1:6379", Key: "k", Mode: adapters.RedisModeCluster, DB: 1,
+		}, "only supports db 0"},
+		{"missing key field", adapters.Redis{Operation: "get", Address: "localhost:6379", Key: "{{.symbol}}"}, "unable to render redis key"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result := test.adapter.Perform(cltest.RunResultWithValue("input"), nil)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), test.wantErr)
+		})
+	}
+}
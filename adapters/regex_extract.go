@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+var regexpCache = struct {
+	sync.RWMutex
+	compiled map[string]*regexp.Regexp
+}{compiled: map[string]*regexp.Regexp{}}
+
+// compileRegexp returns the compiled pattern, compiling it only the first
+// time it is seen.
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	regexpCache.RLock()
+	re, ok := regexpCache.compiled[pattern]
+	regexpCache.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexpCache.Lock()
+	regexpCache.compiled[pattern] = re
+	regexpCache.Unlock()
+	return re, nil
+}
+
+// RegexExtract extracts text from the input's value with a regular
+// expression.
+type RegexExtract struct {
+	Pattern string `json:"pattern"`
+	// Group is the index of the capture group to return, 0 being the whole match.
+	Group int `json:"group"`
+	// GroupName selects a named capture group instead of Group.
+	GroupName  string `json:"groupName"`
+	AllMatches bool   `json:"allMatches"`
+}
+
+// Perform returns the capture group from the first match as the "value"
+// field of the result, or an array of the group from every match when
+// AllMatches is set.
+//
+// For example, with "pattern" set to "last: ([0-9.]+)" and "group" set to 1,
+// an input value of "ETH last: 212.54" returns "212.54".
+func (rea *RegexExtract) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	val, err := input.Value()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	re, err := compileRegexp(rea.Pattern)
+	if err != nil {
+		return input.WithError(fmt.Errorf("invalid regex pattern: %v", err))
+	}
+	group, err := rea.group(re)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	if !rea.AllMatches {
+		match := re.FindStringSubmatch(val)
+		if match == nil {
+			return input.WithError(fmt.Errorf("no match found for pattern %q", rea.Pattern))
+		}
+		return input.WithValue(match[group])
+	}
+
+	matches := re.FindAllStringSubmatch(val, -1)
+	if len(matches) == 0 {
+		return input.WithError(fmt.Errorf("no match found for pattern %q", rea.Pattern))
+	}
+	values := make([]string, len(matches))
+	for i, match := range matches {
+		values[i] = match[group]
+	}
+	return input.Add("value", values)
+}
+
+func (rea *RegexExtract) group(re *regexp.Regexp) (int, error) {
+	if rea.GroupName != "" {
+		for i, name := range re.SubexpNames() {
+			if name == rea.GroupName {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("pattern %q has no group named %q", rea.Pattern, rea.GroupName)
+	}
+	if rea.Group < 0 || rea.Group > re.NumSubexp() {
+		return 0, fmt.Errorf("pattern %q has no group %d", rea.Pattern, rea.Group)
+	}
+	return rea.Group, nil
+}
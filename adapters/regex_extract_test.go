@@ -0,0 +1,79 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const tickerText = "ETH last: 212.54 volume: 1000\nBTC last: 6481.20 volume: 250"
+
+func TestRegexExtract_Perform(t *testing.T) {
+	tests := []struct {
+		name        string
+		adapter     adapters.RegexExtract
+		want        string
+		wantErrored bool
+	}{
+		{"whole match", adapters.RegexExtract{Pattern: `last: [0-9.]+`}, "last: 212.54", false},
+		{"capture group", adapters.RegexExtract{Pattern: `last: ([0-9.]+)`, Group: 1}, "212.54", false},
+		{"second capture group", adapters.RegexExtract{Pattern: `(\w+) last: ([0-9.]+)`, Group: 2}, "212.54", false},
+		{"named group", adapters.RegexExtract{Pattern: `BTC last: (?P<price>[0-9.]+)`, GroupName: "price"}, "6481.20", false},
+		{"multiline", adapters.RegexExtract{Pattern: `(?m)^BTC.*$`}, "BTC last: 6481.20 volume: 250", false},
+		{"no match", adapters.RegexExtract{Pattern: `LINK last: ([0-9.]+)`, Group: 1}, tickerText, true},
+		{"invalid pattern", adapters.RegexExtract{Pattern: `last: ([0-9.]+`}, tickerText, true},
+		{"group out of range", adapters.RegexExtract{Pattern: `last: ([0-9.]+)`, Group: 2}, tickerText, true},
+		{"negative group", adapters.RegexExtract{Pattern: `last: ([0-9.]+)`, Group: -1}, tickerText, true},
+		{"unknown group name", adapters.RegexExtract{Pattern: `last: (?P<price>[0-9.]+)`, GroupName: "volume"}, tickerText, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result := test.adapter.Perform(cltest.RunResultWithValue(tickerText), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestRegexExtract_Perform_AllMatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		adapter     adapters.RegexExtract
+		want        string
+		wantErrored bool
+	}{
+		{"capture group", adapters.RegexExtract{Pattern: `last: ([0-9.]+)`, Group: 1, AllMatches: true}, `["212.54","6481.20"]`, false},
+		{"named group", adapters.RegexExtract{Pattern: `(?P<symbol>[A-Z]+) last`, GroupName: "symbol", AllMatches: true}, `["ETH","BTC"]`, false},
+		{"whole match", adapters.RegexExtract{Pattern: `volume: \d+`, AllMatches: true}, `["volume: 1000","volume: 250"]`, false},
+		{"no match", adapters.RegexExtract{Pattern: `bid: ([0-9.]+)`, AllMatches: true}, "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result := test.adapter.Perform(cltest.RunResultWithValue(tickerText), nil)
+
+			assert.Equal(t, test.wantErrored, result.HasError())
+			if !test.wantErrored {
+				assert.Equal(t, test.want, result.Get("value").Raw)
+			}
+		})
+	}
+}
+
+func TestRegexExtract_Perform_NonStringValue(t *testing.T) {
+	adapter := adapters.RegexExtract{Pattern: `\d+`}
+	input := models.RunResult{Data: cltest.JSONFromString(`{"value":123}`)}
+	result := adapter.Perform(input, nil)
+	assert.True(t, result.HasError())
+}
@@ -0,0 +1,110 @@
+package adapters
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// Factory returns a new adapter, into which For unmarshals a task's params.
+type Factory func() BaseAdapter
+
+// registry maps each task type to the Factory for its adapter. Types without
+// an entry are resolved as bridges.
+var registry = struct {
+	sync.RWMutex
+	byType map[models.TaskType]Factory
+}{byType: map[models.TaskType]Factory{}}
+
+func init() {
+	Register(TaskTypeCopy.String(), func() BaseAdapter { return &Copy{} })
+	Register(TaskTypeBase64.String(), func() BaseAdapter { return &Base64{} })
+	Register(TaskTypeBase64Decode.String(), func() BaseAdapter { return &Base64Decode{} })
+	Register(TaskTypeCache.String(), func() BaseAdapter { return &Cache{} })
+	Register(TaskTypeCircuitBreaker.String(), func() BaseAdapter { return &CircuitBreaker{} })
+	Register(TaskTypeCompare.String(), func() BaseAdapter { return &Compare{} })
+	Register(TaskTypeContractDeploy.String(), func() BaseAdapter { return &ContractDeploy{} })
+	Register(TaskTypeCSVParse.String(), func() BaseAdapter { return &CSVParse{} })
+	Register(TaskTypeDeviation.String(), func() BaseAdapter { return &Deviation{} })
+	Register(TaskTypeDivide.String(), func() BaseAdapter { return &Divide{} })
+	Register(TaskTypeEthBool.String(), func() BaseAdapter { return &EthBool{} })
+	Register(TaskTypeEthBytes32.String(), func() BaseAdapter { return &EthBytes32{} })
+	Register(TaskTypeEthCall.String(), func() BaseAdapter { return &EthCall{} })
+	Register(TaskTypeEthInt256.String(), func() BaseAdapter { return &EthInt256{} })
+	Register(TaskTypeEthUint256.String(), func() BaseAdapter { return &EthUint256{} })
+	Register(TaskTypeEthTx.String(), func() BaseAdapter { return &EthTx{} })
+	Register(TaskTypeEthTxEncode.String(), func() BaseAdapter { return &EthTxEncode{} })
+	Register(TaskTypeGRPC.String(), func() BaseAdapter { return &GRPC{} })
+	Register(TaskTypeHexDecode.String(), func() BaseAdapter { return &HexDecode{} })
+	Register(TaskTypeHexEncode.String(), func() BaseAdapter { return &HexEncode{} })
+	Register(TaskTypeHTTPGet.String(), func() BaseAdapter { return &HTTPGet{} })
+	Register(TaskTypeHTTPGetAggregate.String(), func() BaseAdapter { return &HTTPGetAggregate{} })
+	Register(TaskTypeHTTPPost.String(), func() BaseAdapter { return &HTTPPost{} })
+	Register(TaskTypeIPFS.String(), func() BaseAdapter { return &IPFS{} })
+	Register(TaskTypeJSONParse.String(), func() BaseAdapter { return &JSONParse{} })
+	Register(TaskTypeKafkaPublish.String(), func() BaseAdapter { return &KafkaPublish{} })
+	Register(TaskTypeMean.String(), func() BaseAdapter { return &Mean{} })
+	Register(TaskTypeMedian.String(), func() BaseAdapter { return &Median{} })
+	Register(TaskTypeMode.String(), func() BaseAdapter { return &Mode{} })
+	Register(TaskTypeMultiply.String(), func() BaseAdapter { return &Multiply{} })
+	Register(TaskTypeNoOp.String(), func() BaseAdapter { return &NoOp{} })
+	Register(TaskTypeNoOpPend.String(), func() BaseAdapter { return &NoOpPend{} })
+	Register(TaskTypeOAuth2.String(), func() BaseAdapter { return &OAuth2{} })
+	Register(TaskTypeQuotient.String(), func() BaseAdapter { return &Quotient{} })
+	Register(TaskTypeRandom.String(), func() BaseAdapter { return &Random{} })
+	Register(TaskTypeRedis.String(), func() BaseAdapter { return &Redis{} })
+	Register(TaskTypeRegexExtract.String(), func() BaseAdapter { return &RegexExtract{} })
+	Register(TaskTypeResultCollect.String(), func() BaseAdapter { return &ResultCollect{} })
+	Register(TaskTypeS3.String(), func() BaseAdapter { return &S3{} })
+	Register(TaskTypeSign.String(), func() BaseAdapter { return &Sign{} })
+	Register(TaskTypeSleep.String(), func() BaseAdapter { return &Sleep{} })
+	Register(TaskTypeStringTemplate.String(), func() BaseAdapter { return &StringTemplate{} })
+	Register(TaskTypeSum.String(), func() BaseAdapter { return &Sum{} })
+	Register(TaskTypeTimestamp.String(), func() BaseAdapter { return &Timestamp{} })
+	Register(TaskTypeWasm.String(), func() BaseAdapter { return &Wasm{} })
+	Register(TaskTypeWasmSandbox.String(), func() BaseAdapter { return &WasmSandbox{} })
+	Register(TaskTypeWebSocket.String(), func() BaseAdapter { return &WebSocket{} })
+	Register(TaskTypeXMLParse.String(), func() BaseAdapter { return &XMLParse{} })
+}
+
+// Register makes the adapter returned by factory available to tasks of
+// taskType, which is matched without regard to case. It is meant to be
+// called from an init function, and panics if taskType is invalid or already
+// registered.
+func Register(taskType string, factory Factory) {
+	tt := models.MustNewTaskType(taskType)
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.byType[tt]; ok {
+		panic(fmt.Sprintf("adapter for task type %q is already registered", tt))
+	}
+	registry.byType[tt] = factory
+}
+
+// RegisteredTypes returns the registered task types in alphabetical order.
+func RegisteredTypes() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	types := make([]string, 0, len(registry.byType))
+	for tt := range registry.byType {
+		types = append(types, tt.String())
+	}
+	sort.Strings(types)
+	return types
+}
+
+func lookupFactory(taskType models.TaskType) (Factory, bool) {
+	tt, err := models.NewTaskType(taskType.String())
+	if err != nil {
+		return nil, false
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+	factory, ok := registry.byType[tt]
+	return factory, ok
+}
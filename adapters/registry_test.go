@@ -0,0 +1,112 @@
+package adapters_test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type suffix struct {
+	Suffix string `json:"suffix"`
+}
+
+func (s *suffix) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	return input.WithValue(input.Get("value").String() + s.Suffix)
+}
+
+func init() {
+	adapters.Register("TestSuffix", func() adapters.BaseAdapter { return &suffix{} })
+}
+
+func TestRegister_ResolvesCustomAdapter(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		taskType models.TaskType
+	}{
+		{"lower case", models.TaskType("testsuffix")},
+		{"mixed case", models.TaskType("TestSuffix")},
+		{"upper case", models.TaskType("TESTSUFFIX")},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			task := models.TaskSpec{Type: test.taskType, Params: cltest.JSONFromString(`{"suffix":"!"}`)}
+			adapter, err := adapters.For(task, store)
+			require.NoError(t, err)
+
+			result := adapter.Perform(cltest.RunResultWithValue("hi"), store)
+			require.NoError(t, result.GetError())
+			assert.Equal(t, "hi!", result.Get("value").String())
+		})
+	}
+}
+
+func TestRegister_DuplicatePanics(t *testing.T) {
+	t.Parallel()
+
+	factory := func() adapters.BaseAdapter { return &adapters.NoOp{} }
+	assert.Panics(t, func() { adapters.Register("httpget", factory) })
+	assert.Panics(t, func() { adapters.Register("HTTPGet", factory) })
+	assert.Panics(t, func() { adapters.Register("not valid!", factory) })
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	t.Parallel()
+
+	types := adapters.RegisteredTypes()
+	assert.True(t, sort.StringsAreSorted(types))
+	assert.Contains(t, types, "httpget")
+	assert.Contains(t, types, "testsuffix")
+}
+
+func TestFor_UnknownTypeListsRegisteredTypes(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	_, err := adapters.For(cltest.NewTask("idonotexist"), store)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "idonotexist is not a supported adapter type")
+	assert.Contains(t, err.Error(), "httpget, httpgetaggregate, httppost")
+}
+
+func TestRegister_ConcurrentWithLookups(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			adapters.Register(fmt.Sprintf("testconcurrent%d", i), func() adapters.BaseAdapter { return &adapters.NoOp{} })
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := adapters.For(cltest.NewTask("noop"), store)
+			assert.NoError(t, err)
+			adapters.RegisteredTypes()
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		_, err := adapters.For(cltest.NewTask(fmt.Sprintf("testconcurrent%d", i)), store)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,46 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// ResultCollect appends a field of the run's data to its "results" array, so
+// that the values of several tasks can be used together later in the job.
+type ResultCollect struct {
+	// Path is a gjson path to the field to collect, "value" by default.
+	Path string `json:"path"`
+}
+
+// Perform appends the field at Path, keeping its JSON type, to the end of
+// the "results" array, creating the array for the first collected value. The
+// rest of the data, including "value", is passed through unchanged.
+//
+// For example, collecting values of 1, "two" and {"three":3} in turn leaves
+// "results" as [1, "two", {"three":3}].
+func (rc *ResultCollect) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	path := rc.Path
+	if path == "" {
+		path = "value"
+	}
+	value := input.Get(path)
+	if !value.Exists() {
+		return input.WithError(fmt.Errorf("ResultCollect found no %q field to collect", path))
+	}
+
+	var results []json.RawMessage
+	if existing := input.Get("results"); existing.Exists() {
+		if !existing.IsArray() {
+			return input.WithError(fmt.Errorf("ResultCollect requires results to be an array, got %s", existing.Raw))
+		}
+		for _, r := range existing.Array() {
+			results = append(results, json.RawMessage(r.Raw))
+		}
+	}
+	results = append(results, json.RawMessage(value.Raw))
+
+	return input.Add("results", results)
+}
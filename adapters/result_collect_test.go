@@ -0,0 +1,68 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCollect_Perform(t *testing.T) {
+	t.Parallel()
+	adapter := adapters.ResultCollect{}
+
+	result := models.RunResult{Data: cltest.JSONFromString(`{}`)}
+	for _, value := range []string{`1.5`, `"two"`, `{"three":[3]}`} {
+		data, err := result.Data.Add("value", json.RawMessage(value))
+		require.NoError(t, err)
+		result.Data = data
+
+		result = adapter.Perform(result, nil)
+		require.NoError(t, result.GetError())
+		assert.Equal(t, models.RunStatusCompleted, result.Status)
+		assert.JSONEq(t, value, result.Get("value").Raw, "value should be left untouched")
+	}
+
+	assert.JSONEq(t, `[1.5, "two", {"three":[3]}]`, result.Get("results").Raw)
+}
+
+func TestResultCollect_Perform_Path(t *testing.T) {
+	t.Parallel()
+	adapter := adapters.ResultCollect{Path: "quote.price"}
+
+	input := models.RunResult{Data: cltest.JSONFromString(`{"value":"body","quote":{"price":100},"results":["first"]}`)}
+	result := adapter.Perform(input, nil)
+
+	require.NoError(t, result.GetError())
+	assert.JSONEq(t, `["first", 100]`, result.Get("results").Raw)
+	assert.Equal(t, "body", result.Get("value").String())
+}
+
+func TestResultCollect_Perform_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		json    string
+		wantErr string
+	}{
+		{"missing field", "price", `{"value":1}`, `no "price" field`},
+		{"results not an array", "", `{"value":1,"results":"oops"}`, "results to be an array"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.ResultCollect{Path: test.path}
+			result := adapter.Perform(models.RunResult{Data: cltest.JSONFromString(test.json)}, nil)
+			assert.True(t, result.HasError())
+			assert.Contains(t, result.Error(), test.wantErr)
+		})
+	}
+}
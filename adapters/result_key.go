@@ -0,0 +1,55 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// defaultResultKey is where tasks write their output unless told otherwise.
+const defaultResultKey = "value"
+
+// ResultKey names the key of the run's data which a task writes its output
+// to, so that a pipeline can keep several outputs, such as a price and the
+// time it was fetched, side by side. It is "value" when unset.
+type ResultKey string
+
+// UnmarshalJSON rejects keys containing dots, which would otherwise be read
+// as a path into nested objects.
+func (rk *ResultKey) UnmarshalJSON(input []byte) error {
+	var key string
+	if err := json.Unmarshal(input, &key); err != nil {
+		return fmt.Errorf("resultKey must be a string: %v", err)
+	}
+	if strings.Contains(key, ".") {
+		return fmt.Errorf("resultKey %q must not contain dots", key)
+	}
+	*rk = ResultKey(key)
+	return nil
+}
+
+// String returns the key, or "value" when unset.
+func (rk ResultKey) String() string {
+	if rk == "" {
+		return defaultResultKey
+	}
+	return string(rk)
+}
+
+// write returns input with val set at the key.
+func (rk ResultKey) write(input models.RunResult, val interface{}) models.RunResult {
+	return input.Add(rk.String(), val)
+}
+
+// writeNull returns input with null set at the key, leaving its status as
+// WithNull does.
+func (rk ResultKey) writeNull(input models.RunResult) models.RunResult {
+	data, err := input.Data.Add(rk.String(), nil)
+	if err != nil {
+		return input.WithError(err)
+	}
+	input.Data = data
+	return input
+}
@@ -0,0 +1,71 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultKey_WritesToKey(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		taskType string
+		params   string
+		input    string
+		want     string
+	}{
+		{"jsonparse", `{"path":["last"],"resultKey":"price"}`, `"{\"last\":\"1.23\"}"`, `"1.23"`},
+		{"jsonparse", `{"path":["missing"],"resultKey":"price"}`, `"{\"last\":\"1.23\"}"`, `null`},
+		{"multiply", `{"times":100,"resultKey":"price"}`, `"1.23"`, `"123"`},
+		{"ethbool", `{"resultKey":"flag"}`, `true`, `"0x0000000000000000000000000000000000000000000000000000000000000001"`},
+		{"ethbytes32", `{"resultKey":"name"}`, `"hi"`, `"0x6869000000000000000000000000000000000000000000000000000000000000"`},
+		{"ethint256", `{"resultKey":"delta"}`, `-1`, `"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"`},
+		{"ethuint256", `{"resultKey":"price"}`, `123`, `"0x000000000000000000000000000000000000000000000000000000000000007b"`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.taskType, func(t *testing.T) {
+			adapter, err := adapters.For(cltest.NewTask(test.taskType, test.params), store)
+			require.NoError(t, err)
+			input := cltest.RunResultWithData(`{"value":` + test.input + `}`)
+
+			result := adapter.Perform(input, store)
+			require.NoError(t, result.GetError())
+			assert.JSONEq(t, test.input, result.Get("value").Raw, "value is left as it was")
+			key := cltest.JSONFromString(test.params).Get("resultKey").String()
+			assert.JSONEq(t, test.want, result.Get(key).Raw)
+		})
+	}
+}
+
+func TestResultKey_HTTPGet(t *testing.T) {
+	t.Parallel()
+	mock, cleanup := cltest.NewHTTPMockServer(t, 200, "GET", `{"last":"1.23"}`)
+	defer cleanup()
+
+	adapter := adapters.HTTPGet{URL: cltest.WebURL(mock.URL), ResultKey: "response"}
+	result := adapter.Perform(cltest.RunResultWithValue("unchanged"), nil)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "unchanged", result.Get("value").String())
+	assert.Equal(t, `{"last":"1.23"}`, result.Get("response").String())
+}
+
+func TestResultKey_RejectsDots(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	for _, taskType := range []string{"httpget", "httppost", "jsonparse", "multiply", "ethbool", "ethbytes32", "ethint256", "ethuint256"} {
+		_, err := adapters.For(cltest.NewTask(taskType, `{"resultKey":"price.usd"}`), store)
+		assert.Error(t, err, taskType)
+	}
+	_, err := adapters.For(cltest.NewTask("jsonparse", `{"resultKey":"price_usd"}`), store)
+	assert.NoError(t, err)
+}
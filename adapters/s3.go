@@ -0,0 +1,124 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	// S3OperationGet reads an object's body.
+	S3OperationGet = "get"
+	// S3OperationPut writes the run's value to an object.
+	S3OperationPut = "put"
+)
+
+// S3 reads objects from, or writes objects to, an Amazon S3 bucket.
+//
+// Credentials are read from the environment variables <CredentialsEnvVar>_AWS_ACCESS_KEY_ID
+// and <CredentialsEnvVar>_AWS_SECRET_ACCESS_KEY when CredentialsEnvVar is set,
+// otherwise the AWS SDK's default credential chain is used.
+type S3 struct {
+	Operation         string `json:"operation"`
+	Bucket            string `json:"bucket"`
+	Key               string `json:"key"`
+	Region            string `json:"region"`
+	CredentialsEnvVar string `json:"credentialsEnvVar"`
+	ContentType       string `json:"contentType"`
+	ACL               string `json:"acl"`
+
+	// Client overrides the S3 client built from Region and the credentials.
+	Client s3iface.S3API `json:"-"`
+}
+
+// Perform returns the object's body as the "value" of the result for a get,
+// or writes the input's value to the object and returns its S3 URI for a put.
+func (sa *S3) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	client, err := sa.client()
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	switch sa.Operation {
+	case S3OperationGet:
+		return sa.get(input, client, newHTTPRequestConfig(str, store.Duration{}).responseSize)
+	case S3OperationPut:
+		return sa.put(input, client)
+	default:
+		return input.WithError(fmt.Errorf("S3 operation must be %q or %q, got %q", S3OperationGet, S3OperationPut, sa.Operation))
+	}
+}
+
+// get reads the object, failing when it is larger than DEFAULT_HTTP_LIMIT
+// as an HTTP response would.
+func (sa *S3) get(input models.RunResult, client s3iface.S3API, limit int64) models.RunResult {
+	output, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(sa.Bucket),
+		Key:    aws.String(sa.Key),
+	})
+	if err != nil {
+		return input.WithError(err)
+	}
+	defer output.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(output.Body, limit+1))
+	if err != nil {
+		return input.WithError(err)
+	}
+	if int64(len(body)) > limit {
+		return input.WithError(fmt.Errorf("S3 object too large, must be less than %d bytes", limit))
+	}
+	return input.WithValue(string(body))
+}
+
+func (sa *S3) put(input models.RunResult, client s3iface.S3API) models.RunResult {
+	params := &s3.PutObjectInput{
+		Bucket: aws.String(sa.Bucket),
+		Key:    aws.String(sa.Key),
+		Body:   strings.NewReader(input.Get("value").String()),
+	}
+	if sa.ContentType != "" {
+		params.ContentType = aws.String(sa.ContentType)
+	}
+	if sa.ACL != "" {
+		params.ACL = aws.String(sa.ACL)
+	}
+
+	if _, err := client.PutObject(params); err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(fmt.Sprintf("s3://%s/%s", sa.Bucket, sa.Key))
+}
+
+func (sa *S3) client() (s3iface.S3API, error) {
+	if sa.Client != nil {
+		return sa.Client, nil
+	}
+
+	config := aws.NewConfig().WithRegion(sa.Region)
+	if sa.CredentialsEnvVar != "" {
+		prefix := strings.TrimSuffix(sa.CredentialsEnvVar, "_")
+		id := os.Getenv(prefix + "_AWS_ACCESS_KEY_ID")
+		secret := os.Getenv(prefix + "_AWS_SECRET_ACCESS_KEY")
+		if id == "" || secret == "" {
+			return nil, fmt.Errorf("S3 credentials %s_AWS_ACCESS_KEY_ID and %s_AWS_SECRET_ACCESS_KEY must both be set", prefix, prefix)
+		}
+		config = config.WithCredentials(credentials.NewStaticCredentials(id, secret, ""))
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return s3.New(sess), nil
+}
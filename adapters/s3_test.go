@@ -0,0 +1,131 @@
+package adapters_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockS3 struct {
+	s3iface.S3API
+	objects map[string]string
+	puts    []*s3.PutObjectInput
+}
+
+func newMockS3() *mockS3 {
+	return &mockS3{objects: map[string]string{"prices/eth.json": `{"price":"123.45"}`}}
+}
+
+func (m *mockS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	body, ok := m.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, errors.New("NoSuchKey: The specified key does not exist")
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (m *mockS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	m.puts = append(m.puts, input)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3_Perform_Get(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		want        string
+		wantErrored bool
+	}{
+		{"existing object", "prices/eth.json", `{"price":"123.45"}`, false},
+		{"missing object", "prices/btc.json", "inputValue", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			sa := adapters.S3{Operation: "get", Bucket: "oracle", Key: test.key, Client: newMockS3()}
+			result := sa.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestS3_Perform_Get_TooLarge(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.DefaultHTTPLimit = 8
+
+	client := newMockS3()
+	client.objects["large"] = "123456789"
+	client.objects["small"] = "12345678"
+
+	sa := adapters.S3{Operation: "get", Bucket: "oracle", Key: "large", Client: client}
+	result := sa.Perform(cltest.RunResultWithValue("inputValue"), store)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "too large")
+
+	sa.Key = "small"
+	result = sa.Perform(cltest.RunResultWithValue("inputValue"), store)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "12345678", result.Get("value").String())
+}
+
+func TestS3_Perform_Put(t *testing.T) {
+	client := newMockS3()
+	sa := adapters.S3{
+		Operation:   "put",
+		Bucket:      "oracle",
+		Key:         "answers/latest",
+		ContentType: "text/plain",
+		ACL:         "private",
+		Client:      client,
+	}
+	result := sa.Perform(cltest.RunResultWithValue("123.45"), nil)
+
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://oracle/answers/latest", val)
+	assert.False(t, result.HasError())
+
+	require.Len(t, client.puts, 1)
+	put := client.puts[0]
+	assert.Equal(t, "oracle", aws.StringValue(put.Bucket))
+	assert.Equal(t, "answers/latest", aws.StringValue(put.Key))
+	assert.Equal(t, "text/plain", aws.StringValue(put.ContentType))
+	assert.Equal(t, "private", aws.StringValue(put.ACL))
+	body, err := ioutil.ReadAll(put.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "123.45", string(body))
+}
+
+func TestS3_Perform_UnknownOperation(t *testing.T) {
+	sa := adapters.S3{Operation: "delete", Client: newMockS3()}
+	result := sa.Perform(cltest.RunResultWithValue("inputValue"), nil)
+	assert.True(t, result.HasError())
+}
+
+func TestS3_Perform_MissingCredentials(t *testing.T) {
+	os.Unsetenv("S3TEST_AWS_ACCESS_KEY_ID")
+	os.Unsetenv("S3TEST_AWS_SECRET_ACCESS_KEY")
+
+	sa := adapters.S3{Operation: "get", Region: "us-east-1", CredentialsEnvVar: "S3TEST"}
+	result := sa.Perform(cltest.RunResultWithValue("inputValue"), nil)
+	assert.True(t, result.HasError())
+	assert.Contains(t, result.Error(), "S3TEST_AWS_ACCESS_KEY_ID")
+}
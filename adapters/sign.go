@@ -0,0 +1,116 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// signBurst is how many signatures a run can make at once, after which
+	// it is held to signRate.
+	signBurst = 10
+	// signRate is how many signatures per second a run can make once it has
+	// used its burst.
+	signRate = rate.Limit(1)
+	// maxIdleSignLimiters is how many runs' buckets are kept before those
+	// which have refilled are forgotten.
+	maxIdleSignLimiters = 10000
+)
+
+// Sign signs the value with the node's key, so that consumers can check
+// which node produced it.
+type Sign struct{}
+
+// Perform is PerformCtx, without a deadline for waiting on the rate limit.
+func (s *Sign) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	return s.PerformCtx(context.Background(), input, str)
+}
+
+// PerformCtx signs the Keccak256 hash of the value, as personal_sign would,
+// with the key of the TxManager's active account. The signature is written
+// to "signature" and the account's address to "signerAddress", leaving the
+// value as it is. Each run can make signBurst signatures at once, and
+// signRate a second after that, waiting until ctx is done for its turn.
+func (s *Sign) PerformCtx(ctx context.Context, input models.RunResult, str *store.Store) models.RunResult {
+	value := input.Get("value")
+	if !value.Exists() {
+		return input.WithError(errors.New("no value to sign"))
+	}
+	account := str.TxManager.GetActiveAccount()
+	if account == nil {
+		return input.WithError(errors.New("unable to sign, no account is active"))
+	}
+	if err := signLimiters.wait(ctx, input.JobRunID); err != nil {
+		return input.WithError(fmt.Errorf("signing rate limit exceeded: %v", err))
+	}
+
+	hash, err := utils.Keccak256([]byte(value.String()))
+	if err != nil {
+		return input.WithError(err)
+	}
+	personalHash, err := utils.PersonalMessageHash(hash)
+	if err != nil {
+		return input.WithError(err)
+	}
+	output, err := str.KeyStore.SignHash(account.Account, personalHash)
+	if err == keystore.ErrLocked {
+		return input.WithError(errors.New("unable to sign, the keystore is locked"))
+	} else if err != nil {
+		return input.WithError(fmt.Errorf("unable to sign: %v", err))
+	}
+	// ecrecover expects a recovery ID of 27 or 28.
+	output[64] += 27
+
+	var signature models.Signature
+	signature.SetBytes(output)
+	input = input.Add("signature", signature.Hex())
+	return input.Add("signerAddress", account.Address.Hex())
+}
+
+// signLimiters holds a token bucket for each run signing values.
+var signLimiters = &signLimiter{byRun: map[string]*runSignLimiter{}}
+
+type signLimiter struct {
+	mutex sync.Mutex
+	byRun map[string]*runSignLimiter
+}
+
+type runSignLimiter struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+// wait blocks until the run with runID may sign again, or ctx is done.
+func (sl *signLimiter) wait(ctx context.Context, runID string) error {
+	return sl.limiterFor(runID, time.Now()).Wait(ctx)
+}
+
+func (sl *signLimiter) limiterFor(runID string, now time.Time) *rate.Limiter {
+	sl.mutex.Lock()
+	defer sl.mutex.Unlock()
+
+	if rl, ok := sl.byRun[runID]; ok {
+		rl.lastSeen = now
+		return rl.Limiter
+	}
+	if len(sl.byRun) >= maxIdleSignLimiters {
+		refill := time.Duration(float64(signBurst) / float64(signRate) * float64(time.Second))
+		for id, rl := range sl.byRun {
+			if now.Sub(rl.lastSeen) > refill {
+				delete(sl.byRun, id)
+			}
+		}
+	}
+	rl := &runSignLimiter{Limiter: rate.NewLimiter(signRate, signBurst), lastSeen: now}
+	sl.byRun[runID] = rl
+	return rl.Limiter
+}
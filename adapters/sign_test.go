@@ -0,0 +1,93 @@
+package adapters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startSigningApp(t *testing.T) (*cltest.TestApplication, func()) {
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionCount", `0x0100`)
+	require.NoError(t, app.Start())
+	return app, cleanup
+}
+
+func TestSign_Perform(t *testing.T) {
+	t.Parallel()
+	app, cleanup := startSigningApp(t)
+	defer cleanup()
+	account := app.Store.TxManager.GetActiveAccount()
+
+	input := cltest.RunResultWithData(`{"value":"123.45"}`)
+	input.JobRunID = utils.NewBytes32ID()
+	adapter := adapters.Sign{}
+	result := adapter.Perform(input, app.Store)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, models.RunStatusCompleted, result.Status)
+	assert.Equal(t, "123.45", result.Get("value").String())
+	assert.Equal(t, account.Address.Hex(), result.Get("signerAddress").String())
+
+	signature, err := hexutil.Decode(result.Get("signature").String())
+	require.NoError(t, err)
+	hash, err := utils.Keccak256([]byte("123.45"))
+	require.NoError(t, err)
+	signer, err := utils.RecoverSignerAddress(hash, signature)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, signer)
+	assert.Contains(t, []byte{27, 28}, signature[64])
+}
+
+func TestSign_Perform_Errors(t *testing.T) {
+	t.Parallel()
+	app, cleanup := startSigningApp(t)
+	defer cleanup()
+	account := app.Store.TxManager.GetActiveAccount()
+	adapter := adapters.Sign{}
+
+	result := adapter.Perform(cltest.RunResultWithData(`{}`), app.Store)
+	assert.Error(t, result.GetError())
+
+	require.NoError(t, app.Store.KeyStore.Lock(account.Address))
+	input := cltest.RunResultWithData(`{"value":"123.45"}`)
+	input.JobRunID = utils.NewBytes32ID()
+	result = adapter.Perform(input, app.Store)
+	require.Error(t, result.GetError())
+	assert.Contains(t, result.GetError().Error(), "locked")
+	assert.False(t, result.Get("signature").Exists())
+}
+
+func TestSign_PerformCtx_RateLimited(t *testing.T) {
+	t.Parallel()
+	app, cleanup := startSigningApp(t)
+	defer cleanup()
+	adapter := adapters.Sign{}
+
+	input := cltest.RunResultWithData(`{"value":"123.45"}`)
+	input.JobRunID = utils.NewBytes32ID()
+	for i := 0; i < adapters.SignBurst; i++ {
+		result := adapter.Perform(input, app.Store)
+		require.NoError(t, result.GetError())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result := adapter.PerformCtx(ctx, input, app.Store)
+	assert.Error(t, result.GetError(), "run has used its burst")
+
+	other := input
+	other.JobRunID = utils.NewBytes32ID()
+	result = adapter.PerformCtx(ctx, other, app.Store)
+	assert.NoError(t, result.GetError(), "other runs have their own limit")
+	assert.NotEqual(t, common.Address{}, common.HexToAddress(result.Get("signerAddress").String()))
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/smartcontractkit/chainlink/store/models"
 )
 
-// Sleep adapter allows a job to do nothing for some amount of wall time.
+// Sleep adapter allows a job to do nothing for some amount of wall time,
+// either until a time or for a duration, such as "250ms". Until may be given
+// in Unix seconds with a fraction, such as 872835240.25.
 type Sleep struct {
-	Until models.Time `json:"until"`
+	Until    models.Time     `json:"until"`
+	Duration models.Duration `json:"duration"`
 }
 
 // Perform returns the input RunResult after waiting for the specified Until parameter.
@@ -18,7 +21,12 @@ func (adapter *Sleep) Perform(input models.RunResult, str *store.Store) models.R
 	return input
 }
 
-// Duration returns the amount of sleeping this task should be paused for.
-func (adapter *Sleep) Duration() time.Duration {
-	return adapter.Until.DurationFromNow()
+// WakeTime returns when the task should wake, to the millisecond, having
+// fallen asleep at now. Until is used when it is set, and Duration from now
+// otherwise.
+func (adapter *Sleep) WakeTime(now time.Time) time.Time {
+	if adapter.Until.IsZero() && adapter.Duration > 0 {
+		return now.Add(adapter.Duration.Duration()).Round(time.Millisecond)
+	}
+	return adapter.Until.Round(time.Millisecond)
 }
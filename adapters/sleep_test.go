@@ -3,6 +3,7 @@ package adapters_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
@@ -21,3 +22,29 @@ func TestSleep_Perform(t *testing.T) {
 	result := adapter.Perform(models.RunResult{}, store)
 	assert.Equal(t, string(models.RunStatusPendingSleep), string(result.Status))
 }
+
+func TestSleep_WakeTime(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		params string
+		want   time.Time
+	}{
+		{"until", `{"until": 872835240}`, time.Unix(872835240, 0)},
+		{"until with fraction", `{"until": 872835240.25}`, time.Unix(872835240, 250000000)},
+		{"duration", `{"duration": "250ms"}`, now.Add(250 * time.Millisecond)},
+		{"duration rounded", `{"duration": "1.0004s"}`, now.Add(time.Second)},
+		{"until over duration", `{"until": 872835240, "duration": "250ms"}`, time.Unix(872835240, 0)},
+		{"neither", `{}`, time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := adapters.Sleep{}
+			assert.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			assert.True(t, test.want.Equal(adapter.WakeTime(now)), "want %v, got %v", test.want, adapter.WakeTime(now))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package adapters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// stringTemplateFuncs replaces text/template's "call" builtin, so that a
+// template can't invoke functions, and adds a few string helpers.
+var stringTemplateFuncs = template.FuncMap{
+	"call": func(...interface{}) (string, error) {
+		return "", errors.New("call is not allowed in string templates")
+	},
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"replace":   func(s, old, new string) string { return strings.Replace(s, old, new, -1) },
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+}
+
+// StringTemplate renders a text/template with the input's value and
+// Variables bound.
+type StringTemplate struct {
+	Template  string            `json:"template"`
+	Variables map[string]string `json:"variables"`
+}
+
+// Perform renders Template and returns the result as the "value" field of
+// the result. The input's value is available as {{.value}} and each of the
+// Variables by its name, and referring to anything else is an error.
+//
+// For example, with "template" set to "{{.base}}/{{upper .value}}" and
+// "variables" set to {"base": "https://example.com/prices"}, an input value
+// of "eth" renders "https://example.com/prices/ETH".
+func (sta *StringTemplate) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	tmpl, err := template.New("template").
+		Funcs(stringTemplateFuncs).
+		Option("missingkey=error").
+		Parse(sta.Template)
+	if err != nil {
+		return input.WithError(fmt.Errorf("unable to parse template: %v", err))
+	}
+
+	data := map[string]string{}
+	for k, v := range sta.Variables {
+		data[k] = v
+	}
+	if _, ok := data["value"]; ok {
+		return input.WithError(errors.New(`"value" is reserved for the input's value and can't be used as a template variable`))
+	}
+	data["value"] = input.Get("value").String()
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return input.WithError(fmt.Errorf("unable to render template: %v", err))
+	}
+	return input.WithValue(buf.String())
+}
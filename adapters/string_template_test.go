@@ -0,0 +1,44 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringTemplate_Perform(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		variables   map[string]string
+		want        string
+		wantErrored bool
+	}{
+		{"value", "price: {{.value}}", nil, "price: eth", false},
+		{"variables", "{{.base}}?symbol={{.value}}", map[string]string{"base": "https://example.com"}, "https://example.com?symbol=eth", false},
+		{"functions", "{{upper .value}}-{{lower .quote}}", map[string]string{"quote": "USD"}, "ETH-usd", false},
+		{"builtins", `{{printf "%s/%s" .value .quote}}`, map[string]string{"quote": "usd"}, "eth/usd", false},
+		{"plain text", "no actions", nil, "no actions", false},
+		{"missing variable", "{{.value}}/{{.quote}}", nil, "eth", true},
+		{"parse error", "{{.value", nil, "eth", true},
+		{"unknown function", "{{exec .value}}", nil, "eth", true},
+		{"call not allowed", "{{call .value}}", nil, "eth", true},
+		{"value variable reserved", "{{.value}}", map[string]string{"value": "btc"}, "eth", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.StringTemplate{Template: test.template, Variables: test.variables}
+			result := adapter.Perform(cltest.RunResultWithValue("eth"), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// Sum adds together either the numbers given in Values or, when Values is
+// not set, the array of numbers in the input's "value" field.
+type Sum struct {
+	Values    []float64 `json:"values"`
+	Precision *int      `json:"precision"`
+}
+
+// Perform returns the sum as the "value" field of the result, rounded to
+// Precision decimal places when it is set. The sum of an empty array is 0.
+//
+// For example, if input value is ["1.5", 2, "3.25"] the result's value
+// will be "6.75".
+func (sa *Sum) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	values, err := sa.values(input)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	sum := new(big.Rat)
+	for _, v := range values {
+		sum.Add(sum, v)
+	}
+
+	val, err := formatDecimalWithPrecision(sum, sa.Precision)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(val)
+}
+
+func (sa *Sum) values(input models.RunResult) ([]*big.Rat, error) {
+	if sa.Values != nil {
+		values := make([]*big.Rat, len(sa.Values))
+		for i, v := range sa.Values {
+			values[i], _ = parseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		return values, nil
+	}
+
+	return decimalsFromArray(input.Get("value"))
+}
@@ -0,0 +1,66 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum_Perform(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		json    string
+		want    string
+		errored bool
+	}{
+		{"empty array", `{}`, `{"value":[]}`, "0", false},
+		{"single element", `{}`, `{"value":[1.23]}`, "1.23", false},
+		{"numbers", `{}`, `{"value":[1.5, 2, 3.25]}`, "6.75", false},
+		{"numeric strings", `{}`, `{"value":["1.5", "2", "3.25"]}`, "6.75", false},
+		{"exact decimals", `{}`, `{"value":[0.1, 0.2]}`, "0.3", false},
+		{"negative numbers", `{}`, `{"value":[10, -2.5]}`, "7.5", false},
+		{
+			"large integers",
+			`{}`,
+			`{"value":["115792089237316195423570985008687907853269984665640564039457584007913129639935", "1"]}`,
+			"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+			false,
+		},
+		{"precision", `{"precision":2}`, `{"value":[1.005, 2.333]}`, "3.34", false},
+		{"zero precision", `{"precision":0}`, `{"value":[1.25, 2]}`, "3", false},
+		{"values param", `{"values":[1, 2.5]}`, `{"value":"ignored"}`, "3.5", false},
+		{"empty values param", `{"values":[]}`, `{"value":[1, 2]}`, "0", false},
+		{"not an array", `{}`, `{"value":"1.23"}`, "", true},
+		{"non-numeric string", `{}`, `{"value":[1, "one"]}`, "", true},
+		{"non-numeric object", `{}`, `{"value":[1, {"foo":"bar"}]}`, "", true},
+		{"null element", `{}`, `{"value":[1, null]}`, "", true},
+		{"negative precision", `{"precision":-1}`, `{"value":[1]}`, "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			input := models.RunResult{
+				Data: cltest.JSONFromString(test.json),
+			}
+			adapter := adapters.Sum{}
+			assert.NoError(t, json.Unmarshal([]byte(test.params), &adapter))
+			result := adapter.Perform(input, nil)
+
+			if test.errored {
+				assert.Error(t, result.GetError())
+			} else {
+				val, err := result.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, val)
+				assert.NoError(t, result.GetError())
+			}
+		})
+	}
+}
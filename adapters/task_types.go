@@ -0,0 +1,164 @@
+package adapters
+
+// The kinds of value a task can accept or produce in the run's "value" field,
+// as declared by TypedAdapter.
+const (
+	// ValueAny is any value, and is assumed of adapters which do not declare
+	// their types, such as bridges.
+	ValueAny = "any"
+	// ValueText is a string, which may itself hold a number, hex or JSON.
+	ValueText = "text"
+	// ValueNumber is a decimal number, as a JSON number or a string.
+	ValueNumber = "number"
+	// ValueHex is a 0x prefixed hex string, such as an encoded EVM word.
+	ValueHex = "hex"
+	// ValueArray is a JSON array.
+	ValueArray = "array"
+)
+
+// TypedAdapter is implemented by adapters which declare the kinds of value
+// they accept from the previous task, and produce for the next, so that a
+// job spec chaining tasks which can never work together is rejected when it
+// is created. A task which leaves "value" as it was, such as one writing to a
+// ResultKey, produces nil.
+type TypedAdapter interface {
+	TaskTypes() (accepts, produces []string)
+}
+
+// TaskTypes returns the kinds of value the adapter accepts and produces, or
+// "any" for both when it does not declare them.
+func TaskTypes(ba BaseAdapter) (accepts, produces []string) {
+	if ta, ok := ba.(TypedAdapter); ok {
+		return ta.TaskTypes()
+	}
+	return []string{ValueAny}, []string{ValueAny}
+}
+
+// CanAccept returns true if any of the kinds of value produced may be one
+// of those accepted. Text may hold a number or hex, and numbers and hex are
+// written as text, so each satisfies the other; otherwise the kinds must
+// match.
+func CanAccept(produces, accepts []string) bool {
+	for _, p := range produces {
+		for _, a := range accepts {
+			if satisfies(p, a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func satisfies(produced, accepted string) bool {
+	switch {
+	case produced == accepted, produced == ValueAny, accepted == ValueAny:
+		return true
+	case produced == ValueText:
+		return accepted == ValueNumber || accepted == ValueHex
+	case accepted == ValueText:
+		return produced == ValueNumber || produced == ValueHex
+	}
+	return false
+}
+
+// produces returns the kinds of value written by a task with the key, or nil
+// when the key is not "value" and so the run's value is left as it was.
+func (rk ResultKey) produces(kinds ...string) []string {
+	if rk.String() != defaultResultKey {
+		return nil
+	}
+	return kinds
+}
+
+// TaskTypes accepts any value, which is ignored, and produces the response
+// body as text.
+func (hga *HTTPGet) TaskTypes() ([]string, []string) {
+	return []string{ValueAny}, hga.ResultKey.produces(ValueText)
+}
+
+// TaskTypes accepts any value, and produces the response body as text.
+func (hpa *HTTPPost) TaskTypes() ([]string, []string) {
+	return []string{ValueAny}, hpa.ResultKey.produces(ValueText)
+}
+
+// TaskTypes accepts and produces a number.
+func (ma *Multiply) TaskTypes() ([]string, []string) {
+	return []string{ValueNumber}, ma.ResultKey.produces(ValueNumber)
+}
+
+// TaskTypes accepts and produces a number.
+func (da *Divide) TaskTypes() ([]string, []string) {
+	return []string{ValueNumber}, []string{ValueNumber}
+}
+
+// TaskTypes accepts and produces a number.
+func (qa *Quotient) TaskTypes() ([]string, []string) {
+	return []string{ValueNumber}, []string{ValueNumber}
+}
+
+// TaskTypes accepts an array of numbers, or anything when the values are
+// given as a param, and produces their sum.
+func (sa *Sum) TaskTypes() ([]string, []string) {
+	if sa.Values != nil {
+		return []string{ValueAny}, []string{ValueNumber}
+	}
+	return []string{ValueArray}, []string{ValueNumber}
+}
+
+// TaskTypes accepts an array of numbers and produces their mean.
+func (ma *Mean) TaskTypes() ([]string, []string) {
+	return []string{ValueArray}, []string{ValueNumber}
+}
+
+// TaskTypes accepts an array of numbers and produces their median.
+func (ma *Median) TaskTypes() ([]string, []string) {
+	return []string{ValueArray}, []string{ValueNumber}
+}
+
+// TaskTypes accepts an array of numbers and produces their mode.
+func (ma *Mode) TaskTypes() ([]string, []string) {
+	return []string{ValueArray}, []string{ValueNumber}
+}
+
+// TaskTypes accepts text, numbers or hex, and produces an encoded word.
+func (eb *EthBytes32) TaskTypes() ([]string, []string) {
+	return []string{ValueText, ValueNumber, ValueHex}, eb.ResultKey.produces(ValueHex)
+}
+
+// TaskTypes accepts a number or hex, and produces an encoded word.
+func (ei *EthInt256) TaskTypes() ([]string, []string) {
+	return []string{ValueNumber, ValueHex}, ei.ResultKey.produces(ValueHex)
+}
+
+// TaskTypes accepts a number or hex, and produces an encoded word.
+func (eu *EthUint256) TaskTypes() ([]string, []string) {
+	return []string{ValueNumber, ValueHex}, eu.ResultKey.produces(ValueHex)
+}
+
+// TaskTypes accepts any value but an array, and produces an encoded word.
+func (eb *EthBool) TaskTypes() ([]string, []string) {
+	return []string{ValueText, ValueNumber, ValueHex}, eb.ResultKey.produces(ValueHex)
+}
+
+// TaskTypes accepts text and produces hex.
+func (*HexEncode) TaskTypes() ([]string, []string) {
+	return []string{ValueText}, []string{ValueHex}
+}
+
+// TaskTypes accepts hex and produces text.
+func (*HexDecode) TaskTypes() ([]string, []string) {
+	return []string{ValueHex}, []string{ValueText}
+}
+
+// TaskTypes accepts an encoded word, any text for the bytes format, or any
+// value when the data is taken from dataKeys, and produces the transaction
+// hash.
+func (etx *EthTx) TaskTypes() ([]string, []string) {
+	switch {
+	case len(etx.DataKeys) > 0:
+		return []string{ValueAny}, []string{ValueHex}
+	case etx.DataFormat == DataFormatBytes:
+		return []string{ValueText}, []string{ValueHex}
+	}
+	return []string{ValueHex}, []string{ValueHex}
+}
@@ -0,0 +1,66 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanAccept(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		produces []string
+		accepts  []string
+		want     bool
+	}{
+		{[]string{adapters.ValueAny}, []string{adapters.ValueArray}, true},
+		{[]string{adapters.ValueArray}, []string{adapters.ValueAny}, true},
+		{[]string{adapters.ValueText}, []string{adapters.ValueNumber}, true},
+		{[]string{adapters.ValueText}, []string{adapters.ValueHex}, true},
+		{[]string{adapters.ValueNumber}, []string{adapters.ValueText}, true},
+		{[]string{adapters.ValueHex}, []string{adapters.ValueText}, true},
+		{[]string{adapters.ValueNumber}, []string{adapters.ValueHex}, false},
+		{[]string{adapters.ValueHex}, []string{adapters.ValueNumber}, false},
+		{[]string{adapters.ValueText}, []string{adapters.ValueArray}, false},
+		{[]string{adapters.ValueNumber}, []string{adapters.ValueArray, adapters.ValueNumber}, true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, adapters.CanAccept(test.produces, test.accepts), "%v into %v", test.produces, test.accepts)
+	}
+}
+
+func TestTaskTypes(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		taskType string
+		params   string
+		accepts  []string
+		produces []string
+	}{
+		{"noop", `{}`, []string{adapters.ValueAny}, []string{adapters.ValueAny}},
+		{"httpget", `{"url":"https://example.com"}`, []string{adapters.ValueAny}, []string{adapters.ValueText}},
+		{"multiply", `{"times":100}`, []string{adapters.ValueNumber}, []string{adapters.ValueNumber}},
+		{"multiply", `{"times":100,"resultKey":"price"}`, []string{adapters.ValueNumber}, nil},
+		{"sum", `{}`, []string{adapters.ValueArray}, []string{adapters.ValueNumber}},
+		{"sum", `{"values":[1,2]}`, []string{adapters.ValueAny}, []string{adapters.ValueNumber}},
+		{"ethtx", `{}`, []string{adapters.ValueHex}, []string{adapters.ValueHex}},
+		{"ethtx", `{"format":"bytes"}`, []string{adapters.ValueText}, []string{adapters.ValueHex}},
+		{"ethtx", `{"dataKeys":["price"]}`, []string{adapters.ValueAny}, []string{adapters.ValueHex}},
+	}
+
+	for _, test := range tests {
+		adapter, err := adapters.For(cltest.NewTask(test.taskType, test.params), store)
+		require.NoError(t, err)
+		accepts, produces := adapters.TaskTypes(adapter.BaseAdapter)
+		assert.Equal(t, test.accepts, accepts, "%s %s", test.taskType, test.params)
+		assert.Equal(t, test.produces, produces, "%s %s", test.taskType, test.params)
+	}
+}
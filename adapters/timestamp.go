@@ -0,0 +1,70 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// TimestampFormat is how the Timestamp adapter writes the time.
+type TimestampFormat string
+
+const (
+	// TimestampUnix writes whole seconds since the Unix epoch.
+	TimestampUnix TimestampFormat = "unix"
+	// TimestampUnixMillis writes whole milliseconds since the Unix epoch.
+	TimestampUnixMillis TimestampFormat = "unixMillis"
+	// TimestampISO8601 writes an ISO8601 string in UTC, such as
+	// "2018-06-01T12:00:00.000Z".
+	TimestampISO8601 TimestampFormat = "iso8601"
+)
+
+// UnmarshalJSON implements json.Unmarshaler, rejecting unknown formats so
+// that a job with one is refused when it is created.
+func (f *TimestampFormat) UnmarshalJSON(input []byte) error {
+	var s string
+	if err := json.Unmarshal(input, &s); err != nil {
+		return err
+	}
+	switch TimestampFormat(s) {
+	case TimestampUnix, TimestampUnixMillis, TimestampISO8601:
+		*f = TimestampFormat(s)
+		return nil
+	}
+	return fmt.Errorf("unknown timestamp format %q, expected one of: %s, %s, %s",
+		s, TimestampUnix, TimestampUnixMillis, TimestampISO8601)
+}
+
+// Timestamp holds the key to write the current time to, and its format.
+type Timestamp struct {
+	Key    string          `json:"key"`
+	Format TimestampFormat `json:"format"`
+}
+
+// Perform writes the time of the store's clock to Key, "timestamp" by
+// default, in Format, which is Unix seconds by default. The rest of the data,
+// including "value", is passed through unchanged.
+func (ts *Timestamp) Perform(input models.RunResult, str *store.Store) models.RunResult {
+	key := ts.Key
+	if key == "" {
+		key = "timestamp"
+	}
+
+	now := str.Clock.Now().UTC()
+	var stamp interface{}
+	switch ts.Format {
+	case TimestampUnix, "":
+		stamp = now.Unix()
+	case TimestampUnixMillis:
+		stamp = now.UnixNano() / int64(time.Millisecond)
+	case TimestampISO8601:
+		stamp = now.Format("2006-01-02T15:04:05.000Z07:00")
+	default:
+		return input.WithError(fmt.Errorf("unknown timestamp format %q", ts.Format))
+	}
+
+	return input.Add(key, stamp)
+}
@@ -0,0 +1,60 @@
+package adapters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp_Perform(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(store)
+	clock.SetTime(time.Date(2018, 6, 1, 12, 0, 0, 250000000, time.UTC))
+
+	tests := []struct {
+		name    string
+		adapter adapters.Timestamp
+		key     string
+		want    string
+	}{
+		{"default", adapters.Timestamp{}, "timestamp", `1527854400`},
+		{"unix", adapters.Timestamp{Format: adapters.TimestampUnix}, "timestamp", `1527854400`},
+		{"unixMillis", adapters.Timestamp{Format: adapters.TimestampUnixMillis}, "timestamp", `1527854400250`},
+		{"iso8601", adapters.Timestamp{Format: adapters.TimestampISO8601}, "timestamp", `"2018-06-01T12:00:00.250Z"`},
+		{"key", adapters.Timestamp{Key: "observedAt"}, "observedAt", `1527854400`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			input := models.RunResult{Data: cltest.JSONFromString(`{"value":"100"}`)}
+			result := test.adapter.Perform(input, store)
+
+			require.NoError(t, result.GetError())
+			assert.Equal(t, models.RunStatusCompleted, result.Status)
+			assert.JSONEq(t, test.want, result.Get(test.key).Raw)
+			assert.Equal(t, "100", result.Get("value").String())
+		})
+	}
+}
+
+func TestTimestamp_UnknownFormat(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	task := cltest.NewTask("timestamp", `{"format":"rfc822"}`)
+	_, err := adapters.For(task, store)
+	assert.Error(t, err)
+
+	task = cltest.NewTask("timestamp", `{"format":"unixMillis"}`)
+	_, err = adapters.For(task, store)
+	assert.NoError(t, err)
+}
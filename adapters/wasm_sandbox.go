@@ -0,0 +1,188 @@
+package adapters
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/perlin-network/life/compiler"
+	"github.com/perlin-network/life/exec"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const (
+	// WasmMaxModuleSize is the largest module, in bytes once decoded, which a
+	// WasmSandbox task may contain.
+	WasmMaxModuleSize = 64 * 1024
+	// WasmStepLimit is the most instructions a module may execute in a run.
+	WasmStepLimit = 10000000
+	// wasmMaxMemoryPages caps a module's memory at 1MiB.
+	wasmMaxMemoryPages = 16
+	wasmMaxCallDepth   = 256
+	// wasmHostModule is the module a wasm module imports the host API from.
+	wasmHostModule = "env"
+)
+
+// WasmSandbox runs a WebAssembly module, given base64 encoded in "wasm", in
+// a sandboxed interpreter. Unlike Wasm, which hands its module to the SGX
+// enclave, it runs in every build of the node.
+type WasmSandbox struct {
+	Module string `json:"wasm"`
+	code   []byte
+}
+
+// UnmarshalJSON decodes and compiles the module, so that modules which are
+// not base64, are larger than WasmMaxModuleSize, are not valid wasm, import
+// anything outside the host API or do not export "perform" reject the job
+// spec when it is created.
+func (ws *WasmSandbox) UnmarshalJSON(input []byte) error {
+	type plain WasmSandbox
+	var aux plain
+	if err := json.Unmarshal(input, &aux); err != nil {
+		return err
+	}
+	code, err := base64.StdEncoding.DecodeString(aux.Module)
+	if err != nil {
+		return fmt.Errorf("Wasm module must be base64 encoded: %v", err)
+	}
+	if len(code) > WasmMaxModuleSize {
+		return fmt.Errorf("Wasm module is %d bytes, larger than the limit of %d", len(code), WasmMaxModuleSize)
+	}
+	if _, _, err := compileWasm(code, &wasmHost{}); err != nil {
+		return err
+	}
+	*ws = WasmSandbox(aux)
+	ws.code = code
+	return nil
+}
+
+// Perform calls the module's exported "perform" function, and writes the JSON
+// it outputs as the "value" field of the result.
+//
+// The module can only reach the host through three functions imported from
+// "env", and has no access to the filesystem or network:
+//  input_size() i32          the length of the run's data as JSON
+//  input_read(ptr i32)       copies the run's data as JSON into memory at ptr
+//  output_write(ptr i32, len i32)  sets the output to the JSON in memory
+//
+// Runs executing more than WasmStepLimit instructions, growing memory past
+// 1MiB, or trapping are errored.
+func (ws *WasmSandbox) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	data, err := json.Marshal(input.Data)
+	if err != nil {
+		return input.WithError(err)
+	}
+	output, err := runWasm(ws.code, data)
+	if err != nil {
+		return input.WithError(err)
+	}
+	if output == nil {
+		return input.WithError(errors.New("Wasm module did not call output_write"))
+	}
+	if !json.Valid(output) {
+		return input.WithError(fmt.Errorf("Wasm module wrote %q, which is not JSON", output))
+	}
+	return input.Add("value", json.RawMessage(output))
+}
+
+// compileWasm instantiates the module with its imports resolved by host,
+// returning its perform function. The interpreter panics on some invalid
+// modules and on imports the host refuses, which are returned as errors.
+func compileWasm(code []byte, host *wasmHost) (vm *exec.VirtualMachine, entry int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			vm, err = nil, fmt.Errorf("invalid Wasm module: %v", r)
+		}
+	}()
+
+	vm, err = exec.NewVirtualMachine(code, exec.VMConfig{
+		DefaultMemoryPages: 1,
+		MaxMemoryPages:     wasmMaxMemoryPages,
+		MaxCallStackDepth:  wasmMaxCallDepth,
+		GasLimit:           WasmStepLimit,
+	}, host, &compiler.SimpleGasPolicy{GasPerInstruction: 1})
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid Wasm module: %v", err)
+	}
+	entry, ok := vm.GetFunctionExport("perform")
+	if !ok {
+		return nil, 0, errors.New("Wasm module does not export a perform function")
+	}
+	return vm, entry, nil
+}
+
+// runWasm runs the module's perform function with data as its input,
+// returning what it wrote as output. The interpreter panics on traps, which
+// are returned as errors.
+func runWasm(code []byte, data []byte) (output []byte, err error) {
+	host := &wasmHost{input: data}
+	vm, entry, err := compileWasm(code, host)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = wasmRunError(vm, r)
+		}
+	}()
+
+	if _, err := vm.Run(entry); err != nil {
+		return nil, wasmRunError(vm, err)
+	}
+	return host.output, nil
+}
+
+// wasmRunError explains why the module stopped, which is either the step
+// limit or a trap.
+func wasmRunError(vm *exec.VirtualMachine, cause interface{}) error {
+	if vm.Gas >= WasmStepLimit {
+		return fmt.Errorf("Wasm module exceeded the limit of %d steps", WasmStepLimit)
+	}
+	return fmt.Errorf("Wasm module trapped: %v", cause)
+}
+
+// wasmHost resolves a module's imports to the host API, refusing any others.
+type wasmHost struct {
+	input  []byte
+	output []byte
+}
+
+func (h *wasmHost) ResolveFunc(module, field string) exec.FunctionImport {
+	if module == wasmHostModule {
+		switch field {
+		case "input_size":
+			return func(vm *exec.VirtualMachine) int64 {
+				return int64(len(h.input))
+			}
+		case "input_read":
+			return func(vm *exec.VirtualMachine) int64 {
+				ptr := int(uint32(vm.GetCurrentFrame().Locals[0]))
+				copy(wasmMemory(vm, ptr, len(h.input)), h.input)
+				return 0
+			}
+		case "output_write":
+			return func(vm *exec.VirtualMachine) int64 {
+				locals := vm.GetCurrentFrame().Locals
+				ptr, length := int(uint32(locals[0])), int(uint32(locals[1]))
+				h.output = append([]byte{}, wasmMemory(vm, ptr, length)...)
+				return 0
+			}
+		}
+	}
+	panic(fmt.Sprintf("module imports %s.%s, which is not part of the host API", module, field))
+}
+
+func (h *wasmHost) ResolveGlobal(module, field string) int64 {
+	panic(fmt.Sprintf("module imports the global %s.%s, which is not part of the host API", module, field))
+}
+
+// wasmMemory returns length bytes of the module's memory from ptr, trapping
+// if they are out of bounds.
+func wasmMemory(vm *exec.VirtualMachine, ptr, length int) []byte {
+	if ptr < 0 || length < 0 || ptr+length > len(vm.Memory) {
+		panic(fmt.Sprintf("memory access of %d bytes at %d is out of bounds", length, ptr))
+	}
+	return vm.Memory[ptr : ptr+length]
+}
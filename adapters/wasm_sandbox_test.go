@@ -0,0 +1,91 @@
+package adapters_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These programs were compiled then base64ed from the .wat files of the same
+// name in internal/fixtures/wasm.
+const (
+	wasmLoop        = "AGFzbQEAAAABBAFgAAADAgEABwsBB3BlcmZvcm0AAAoJAQcAA0AMAAsL"
+	wasmOutput42    = "AGFzbQEAAAABCQJgAn9/AGAAAAIUAQNlbnYMb3V0cHV0X3dyaXRlAAADAgEBBQMBAAEHCwEHcGVyZm9ybQABCgoBCABBAEECEAALCwgBAEEACwI0Mg=="
+	wasmEcho        = "AGFzbQEAAAABEQRgAAF/YAF/AGACf38AYAAAAjYDA2VudgppbnB1dF9zaXplAAADZW52CmlucHV0X3JlYWQAAQNlbnYMb3V0cHV0X3dyaXRlAAIDAgEDBQMBAAEHCwEHcGVyZm9ybQADCg4BDABBABABQQAQABACCw=="
+	wasmFdWrite     = "AGFzbQEAAAABDAJgBH9/f38Bf2AAAAIaAQ13YXNpX3Vuc3RhYmxlCGZkX3dyaXRlAAADAgEBBwsBB3BlcmZvcm0AAQoEAQIACw=="
+	wasmUnreachable = "AGFzbQEAAAABBAFgAAADAgEABwsBB3BlcmZvcm0AAAoFAQMAAAs="
+)
+
+func performWasm(t *testing.T, program string, data string) models.RunResult {
+	adapter := adapters.WasmSandbox{}
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"wasm":"%s"}`, program)), &adapter))
+	return adapter.Perform(models.RunResult{Data: cltest.JSONFromString(data)}, nil)
+}
+
+func TestWasmSandbox_Perform(t *testing.T) {
+	t.Parallel()
+
+	result := performWasm(t, wasmOutput42, `{"value":"ignored"}`)
+	require.NoError(t, result.GetError())
+	assert.Equal(t, "42", result.Get("value").Raw)
+
+	result = performWasm(t, wasmEcho, `{"value":1.5,"other":"kept"}`)
+	require.NoError(t, result.GetError())
+	assert.JSONEq(t, `{"value":1.5,"other":"kept"}`, result.Get("value").Raw)
+	assert.Equal(t, "kept", result.Get("other").String())
+}
+
+func TestWasmSandbox_Perform_StepLimit(t *testing.T) {
+	t.Parallel()
+
+	result := performWasm(t, wasmLoop, `{}`)
+	assert.EqualError(t, result.GetError(), fmt.Sprintf("Wasm module exceeded the limit of %d steps", adapters.WasmStepLimit))
+}
+
+func TestWasmSandbox_Perform_Trap(t *testing.T) {
+	t.Parallel()
+
+	result := performWasm(t, wasmUnreachable, `{}`)
+	require.Error(t, result.GetError())
+	assert.Contains(t, result.Error(), "Wasm module trapped")
+}
+
+func TestWasmSandbox_ValidatedAtCreation(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	_, err := adapters.For(cltest.NewTask("wasmsandbox", fmt.Sprintf(`{"wasm":"%s"}`, wasmOutput42)), store)
+	assert.NoError(t, err)
+
+	large := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("\x00", adapters.WasmMaxModuleSize+1)))
+	tests := []struct {
+		name   string
+		module string
+		want   string
+	}{
+		{"not base64", "123is", "Wasm module must be base64 encoded"},
+		{"too large", large, fmt.Sprintf("Wasm module is %d bytes, larger than the limit of %d", adapters.WasmMaxModuleSize+1, adapters.WasmMaxModuleSize)},
+		{"not wasm", base64.StdEncoding.EncodeToString([]byte("not a module")), "invalid Wasm module"},
+		{"filesystem import", wasmFdWrite, "module imports wasi_unstable.fd_write, which is not part of the host API"},
+		{"no perform export", base64.StdEncoding.EncodeToString([]byte("\x00asm\x01\x00\x00\x00")), "Wasm module does not export a perform function"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := adapters.For(cltest.NewTask("wasmsandbox", fmt.Sprintf(`{"wasm":"%s"}`, test.module)), store)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.want)
+		})
+	}
+}
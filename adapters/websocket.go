@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"fmt"
+	"time"
+
+	simplejson "github.com/bitly/go-simplejson"
+	"github.com/gorilla/websocket"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+const defaultWebSocketTimeout = 10 * time.Second
+
+// WebSocket subscribes to a WebSocket feed and returns the value found at
+// ResultPath in the first message which contains it.
+type WebSocket struct {
+	URL        models.WebURL  `json:"url"`
+	Message    models.JSON    `json:"message"`
+	ResultPath JSONPath       `json:"resultPath"`
+	Timeout    store.Duration `json:"timeout"`
+}
+
+// Perform opens a connection to the adapter's URL, sends the Message if one
+// was given, and waits up to Timeout for a message with a value at ResultPath.
+// The connection is closed once a value has been found or the wait gives up.
+func (wsa *WebSocket) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	conn, _, err := websocket.DefaultDialer.Dial(wsa.URL.String(), nil)
+	if err != nil {
+		return input.WithError(err)
+	}
+	defer conn.Close()
+
+	if !wsa.Message.Empty() {
+		err = conn.WriteMessage(websocket.TextMessage, wsa.Message.Bytes())
+		if err != nil {
+			return input.WithError(err)
+		}
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(wsa.timeout()))
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return input.WithError(fmt.Errorf("websocket: no matching message received: %v", err))
+		}
+
+		js, err := simplejson.NewJson(msg)
+		if err != nil {
+			continue
+		}
+
+		last, err := dig(js, wsa.ResultPath)
+		if err != nil {
+			continue
+		}
+
+		rval, err := getStringValue(last)
+		if err != nil {
+			return input.WithError(err)
+		}
+		return input.WithValue(rval)
+	}
+}
+
+func (wsa *WebSocket) timeout() time.Duration {
+	if wsa.Timeout.Duration > 0 {
+		return wsa.Timeout.Duration
+	}
+	return defaultWebSocketTimeout
+}
@@ -0,0 +1,118 @@
+package adapters_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEchoWSServer(t *testing.T, closeImmediately bool) (*httptest.Server, func()) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		if closeImmediately {
+			return
+		}
+		for {
+			mt, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err = conn.WriteMessage(mt, msg); err != nil {
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(handler)
+	return server, server.Close
+}
+
+func wsURL(server *httptest.Server) models.WebURL {
+	return cltest.WebURL(strings.Replace(server.URL, "http://", "ws://", 1))
+}
+
+func TestWebSocket_Perform(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		resultPath  adapters.JSONPath
+		want        string
+		wantErrored bool
+	}{
+		{"value at path", `{"ticker":{"price":"123.45"}}`, adapters.JSONPath{"ticker", "price"}, "123.45", false},
+		{"object at path", `{"ticker":{"price":"123.45"}}`, adapters.JSONPath{"ticker"}, `{"price":"123.45"}`, false},
+		{"no message at path", `{"ticker":{"price":"123.45"}}`, adapters.JSONPath{"volume"}, "inputValue", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			server, cleanup := newEchoWSServer(t, false)
+			defer cleanup()
+
+			wsa := adapters.WebSocket{
+				URL:        wsURL(server),
+				Message:    cltest.JSONFromString(test.message),
+				ResultPath: test.resultPath,
+				Timeout:    store.Duration{Duration: cltest.MustParseDuration("100ms")},
+			}
+			result := wsa.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestWebSocket_Perform_ConnectionDropped(t *testing.T) {
+	server, cleanup := newEchoWSServer(t, true)
+	defer cleanup()
+
+	wsa := adapters.WebSocket{
+		URL:        wsURL(server),
+		Message:    cltest.JSONFromString(`{"subscribe":"ETH-USD"}`),
+		ResultPath: adapters.JSONPath{"price"},
+	}
+	result := wsa.Perform(models.RunResult{}, nil)
+	assert.True(t, result.HasError())
+	assert.Equal(t, models.RunStatusErrored, result.Status)
+}
+
+func TestWebSocket_Perform_NotAURL(t *testing.T) {
+	wsa := adapters.WebSocket{URL: cltest.WebURL("NotAURL")}
+	result := wsa.Perform(models.RunResult{}, nil)
+	assert.True(t, result.HasError())
+}
+
+func TestWebSocket_UnmarshalParams(t *testing.T) {
+	var wsa adapters.WebSocket
+	err := json.Unmarshal([]byte(`{
+		"url": "ws://example.com/feed",
+		"message": {"subscribe": "ETH-USD"},
+		"resultPath": "ticker.price",
+		"timeout": "5s"
+	}`), &wsa)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ws://example.com/feed", wsa.URL.String())
+	assert.Equal(t, `{"subscribe": "ETH-USD"}`, wsa.Message.String())
+	assert.Equal(t, adapters.JSONPath{"ticker", "price"}, wsa.ResultPath)
+	assert.Equal(t, cltest.MustParseDuration("5s"), wsa.Timeout.Duration)
+}
@@ -0,0 +1,165 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// The types a parsed result can be coerced to by XMLParse and CSVParse.
+const (
+	ResultTypeString = "string"
+	ResultTypeNumber = "number"
+	ResultTypeBool   = "bool"
+)
+
+// XMLParse extracts a value from an XML document with an XPath expression.
+type XMLParse struct {
+	// Data is the XML document to parse. When empty, the input's value is used.
+	Data  string `json:"data"`
+	XPath string `json:"xpath"`
+	// Type is one of "string", "number" or "bool", defaulting to "string".
+	Type            string `json:"resultType"`
+	StripNamespaces bool   `json:"stripNamespaces"`
+}
+
+// Perform evaluates the XPath against the XML document and returns the
+// result, coerced to Type, as the "value" field of the result.
+//
+// For example, if the XML data looks like this:
+//   <ticker pair="ETH-USD"><last>212.54</last></ticker>
+//
+// Then "/ticker/last" would return "212.54" and "/ticker/@pair" "ETH-USD".
+func (xpa *XMLParse) Perform(input models.RunResult, _ *store.Store) models.RunResult {
+	expr, err := xpath.Compile(xpa.XPath)
+	if err != nil {
+		return input.WithError(fmt.Errorf("invalid xpath %q: %v", xpa.XPath, err))
+	}
+
+	data := xpa.Data
+	if data == "" {
+		data, err = input.Value()
+		if err != nil {
+			return input.WithError(err)
+		}
+	}
+	if xpa.StripNamespaces {
+		data, err = stripXMLNamespaces(data)
+		if err != nil {
+			return input.WithError(err)
+		}
+	}
+
+	doc, err := xmlquery.Parse(strings.NewReader(data))
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	raw, err := evaluateXPath(expr, doc)
+	if err != nil {
+		return input.WithError(err)
+	}
+
+	val, err := coerceValue(raw, xpa.Type)
+	if err != nil {
+		return input.WithError(err)
+	}
+	return input.WithValue(val)
+}
+
+func evaluateXPath(expr *xpath.Expr, doc *xmlquery.Node) (string, error) {
+	switch result := expr.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		if !result.MoveNext() {
+			return "", fmt.Errorf("no value could be found for the xpath %q", expr.String())
+		}
+		return result.Current().Value(), nil
+	case float64:
+		return strconv.FormatFloat(result, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(result), nil
+	case string:
+		return result, nil
+	default:
+		return "", fmt.Errorf("unsupported xpath result %v", result)
+	}
+}
+
+func coerceValue(raw, resultType string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	switch resultType {
+	case "", ResultTypeString:
+		return raw, nil
+	case ResultTypeNumber:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "", fmt.Errorf("unable to coerce %q to a number", raw)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case ResultTypeBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", fmt.Errorf("unable to coerce %q to a bool", raw)
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		return "", fmt.Errorf("unsupported result type %q", resultType)
+	}
+}
+
+// stripXMLNamespaces re-encodes the document without namespace prefixes or
+// declarations, so that elements can be selected by their local names.
+func stripXMLNamespaces(data string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			t.Name.Space = ""
+			attrs := t.Attr[:0]
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				attr.Name.Space = ""
+				attrs = append(attrs, attr)
+			}
+			t.Attr = attrs
+			token = t
+		case xml.EndElement:
+			t.Name.Space = ""
+			token = t
+		case xml.ProcInst:
+			// The encoder only allows the xml declaration as the first token.
+			if t.Target == "xml" {
+				continue
+			}
+		}
+
+		if err := encoder.EncodeToken(xml.CopyToken(token)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,80 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const tickerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ticker pair="ETH-USD" active="true">
+  <last>212.54</last>
+  <volume>1000</volume>
+  <name>Ether</name>
+</ticker>`
+
+const soapXML = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <m:GetPriceResponse xmlns:m="https://www.example.org/prices">
+      <m:Price currency="USD">34.5</m:Price>
+    </m:GetPriceResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestXMLParse_Perform(t *testing.T) {
+	tests := []struct {
+		name            string
+		xml             string
+		xpath           string
+		resultType      string
+		stripNamespaces bool
+		want            string
+		wantErrored     bool
+	}{
+		{"text content", tickerXML, "/ticker/last", "", false, "212.54", false},
+		{"text as number", tickerXML, "/ticker/volume", "number", false, "1000", false},
+		{"attribute", tickerXML, "/ticker/@pair", "string", false, "ETH-USD", false},
+		{"attribute as bool", tickerXML, "/ticker/@active", "bool", false, "true", false},
+		{"xpath function", tickerXML, "count(/ticker/*)", "number", false, "3", false},
+		{"namespaced element", soapXML, "//m:Price", "number", false, "34.5", false},
+		{"namespaced attribute", soapXML, "//m:Price/@currency", "", false, "USD", false},
+		{"stripped namespaces", soapXML, "/Envelope/Body/GetPriceResponse/Price", "number", true, "34.5", false},
+		{"missing element", tickerXML, "/ticker/bid", "", false, "inputValue", true},
+		{"text not a number", tickerXML, "/ticker/name", "number", false, "inputValue", true},
+		{"text not a bool", tickerXML, "/ticker/name", "bool", false, "inputValue", true},
+		{"unknown type", tickerXML, "/ticker/name", "date", false, "inputValue", true},
+		{"invalid xpath", tickerXML, "/ticker/[", "", false, "inputValue", true},
+		{"invalid xml", "<ticker><last>", "/ticker/last", "", false, "inputValue", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			adapter := adapters.XMLParse{
+				Data:            test.xml,
+				XPath:           test.xpath,
+				Type:            test.resultType,
+				StripNamespaces: test.stripNamespaces,
+			}
+			result := adapter.Perform(cltest.RunResultWithValue("inputValue"), nil)
+
+			val, err := result.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, val)
+			assert.Equal(t, test.wantErrored, result.HasError())
+		})
+	}
+}
+
+func TestXMLParse_Perform_FromInputValue(t *testing.T) {
+	adapter := adapters.XMLParse{XPath: "/ticker/last"}
+	result := adapter.Perform(cltest.RunResultWithValue(tickerXML), nil)
+
+	val, err := result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "212.54", val)
+	assert.False(t, result.HasError())
+}
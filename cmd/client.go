@@ -17,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/smartcontractkit/chainlink/web"
 	clipkg "github.com/urfave/cli"
 	"go.uber.org/multierr"
@@ -42,6 +43,9 @@ type Client struct {
 	FileSessionRequestBuilder      SessionRequestBuilder
 	PromptingSessionRequestBuilder SessionRequestBuilder
 	ChangePasswordPrompter         ChangePasswordPrompter
+	// Validators run by validate-config, which are store.DefaultConfigValidators
+	// when nil.
+	ConfigValidators []store.ConfigValidator
 }
 
 func (cli *Client) errorOut(err error) error {
@@ -104,6 +108,7 @@ type HTTPClient interface {
 	Get(string, ...map[string]string) (*http.Response, error)
 	Post(string, io.Reader) (*http.Response, error)
 	Patch(string, io.Reader, ...map[string]string) (*http.Response, error)
+	Put(string, io.Reader) (*http.Response, error)
 	Delete(string) (*http.Response, error)
 }
 
@@ -138,6 +143,11 @@ func (h *authenticatedHTTPClient) Patch(path string, body io.Reader, headers ...
 	return h.doRequest("PATCH", path, body, headers...)
 }
 
+// Put performs an HTTP Put using the authenticated HTTP client's cookie.
+func (h *authenticatedHTTPClient) Put(path string, body io.Reader) (*http.Response, error) {
+	return h.doRequest("PUT", path, body)
+}
+
 // Delete performs an HTTP Delete using the authenticated HTTP client's cookie.
 func (h *authenticatedHTTPClient) Delete(path string) (*http.Response, error) {
 	return h.doRequest("DELETE", path, nil)
@@ -343,7 +353,7 @@ func (t *promptingAPIInitializer) Initialize(store *store.Store) (models.User, e
 	for {
 		email := t.prompter.Prompt("Enter API Email: ")
 		pwd := t.prompter.PasswordPrompt("Enter API Password: ")
-		user, err := models.NewUser(email, pwd)
+		user, err := newAPIUser(store, email, pwd)
 		if err != nil {
 			fmt.Println("Error creating API user: ", err)
 			continue
@@ -375,13 +385,22 @@ func (f fileAPIInitializer) Initialize(store *store.Store) (models.User, error)
 		return models.User{}, err
 	}
 
-	user, err := models.NewUser(request.Email, request.Password)
+	user, err := newAPIUser(store, request.Email, request.Password)
 	if err != nil {
 		return user, err
 	}
 	return user, store.Save(&user)
 }
 
+// newAPIUser returns a user with the given credentials, as long as the
+// password follows the node's PasswordPolicy.
+func newAPIUser(store *store.Store, email, pwd string) (models.User, error) {
+	if err := utils.ValidatePassword(pwd, store.Config.PasswordPolicy()); err != nil {
+		return models.User{}, err
+	}
+	return models.NewUser(email, pwd)
+}
+
 var errNoCredentialFile = errors.New("No API user credential file was passed")
 
 func credentialsFromFile(file string) (models.SessionRequest, error) {
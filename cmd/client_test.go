@@ -192,6 +192,21 @@ func TestFileAPIInitializer_InitializeWithoutAPIUser(t *testing.T) {
 	}
 }
 
+func TestFileAPIInitializer_PasswordPolicy(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.PasswordRequireDigit = true
+	store.Config.PasswordRequireSymbol = true
+
+	tfi := cmd.NewFileAPIInitializer("../internal/fixtures/apicredentials")
+	_, err := tfi.Initialize(store)
+	require.Error(t, err)
+	assert.Equal(t, "password must contain a digit, must contain a symbol", err.Error())
+	_, err = store.FindUser()
+	assert.Error(t, err, "no user is saved")
+}
+
 func TestFileAPIInitializer_InitializeWithExistingAPIUser(t *testing.T) {
 	store, cleanup := cltest.NewStore()
 	defer cleanup()
@@ -20,7 +20,7 @@ type TerminalKeyStoreAuthenticator struct {
 }
 
 // Authenticate checks to see if there are accounts present in
-// the KeyStore, and if there are none, a new account will be created
+// the store's AccountStore, and if there are none, a new account will be created
 // by prompting for a password. If there are accounts present, the
 // account which is unlocked by the given password will be used.
 func (auth TerminalKeyStoreAuthenticator) Authenticate(store *store.Store, pwd string) error {
@@ -34,14 +34,14 @@ func (auth TerminalKeyStoreAuthenticator) Authenticate(store *store.Store, pwd s
 }
 
 func (auth TerminalKeyStoreAuthenticator) authenticationPrompt(store *store.Store) error {
-	if store.KeyStore.HasAccounts() {
+	if store.AccountStore().HasAccounts() {
 		return auth.promptAndCheckPasswordLoop(store)
 	}
 	return auth.promptAndCreateAccount(store)
 }
 
 func (auth TerminalKeyStoreAuthenticator) authenticateWithPwd(store *store.Store, pwd string) error {
-	if !store.KeyStore.HasAccounts() {
+	if !store.AccountStore().HasAccounts() {
 		fmt.Println("There are no accounts, creating a new account with the specified password")
 		return createAccount(store, pwd)
 	}
@@ -49,7 +49,11 @@ func (auth TerminalKeyStoreAuthenticator) authenticateWithPwd(store *store.Store
 }
 
 func checkPassword(store *store.Store, phrase string) error {
-	if err := store.KeyStore.Unlock(phrase); err != nil {
+	if err := store.AccountStore().Unlock(phrase); err != nil {
+		fmt.Println(err.Error())
+		return err
+	}
+	if err := store.VRFKeyStore.Unlock(phrase); err != nil {
 		fmt.Println(err.Error())
 		return err
 	}
@@ -81,7 +85,7 @@ func (auth TerminalKeyStoreAuthenticator) promptAndCreateAccount(store *store.St
 }
 
 func createAccount(store *store.Store, password string) error {
-	_, err := store.KeyStore.NewAccount(password)
+	_, err := store.AccountStore().NewAccount(password)
 	if err != nil {
 		return err
 	}
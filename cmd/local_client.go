@@ -122,6 +122,41 @@ func (cli *Client) DeleteUser(c *clipkg.Context) error {
 	return err
 }
 
+// ValidateConfig reports the issues found in the config by each of the
+// client's ConfigValidators, without starting the node. The config is read
+// from the TOML file given, or else the environment. It exits with 1 when
+// there are only warnings, and 2 when there are errors.
+func (cli *Client) ValidateConfig(c *clipkg.Context) error {
+	config := cli.Config
+	if c.Args().Present() {
+		var err error
+		if config, err = strpkg.ReadConfigFile(c.Args().First()); err != nil {
+			return clipkg.NewExitError(err.Error(), 2)
+		}
+	}
+	validators := cli.ConfigValidators
+	if validators == nil {
+		validators = strpkg.DefaultConfigValidators()
+	}
+
+	issues := strpkg.ValidateConfig(config, validators...)
+	if err := cli.Render(&issues); err != nil {
+		return clipkg.NewExitError(err.Error(), 2)
+	}
+	code := 0
+	for _, issue := range issues {
+		if issue.Severity == strpkg.ConfigError {
+			code = 2
+		} else if code == 0 {
+			code = 1
+		}
+	}
+	if code != 0 {
+		return clipkg.NewExitError(fmt.Sprintf("config has %d issue(s)", len(issues)), code)
+	}
+	return nil
+}
+
 // ImportKey imports a key to be used with the chainlink node
 func (cli *Client) ImportKey(c *clipkg.Context) error {
 	cfg := cli.Config
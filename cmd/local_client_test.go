@@ -2,7 +2,9 @@ package cmd_test
 
 import (
 	"flag"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/cmd"
@@ -10,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink/logger"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
 )
 
@@ -56,12 +59,37 @@ func TestClient_RunNodeShowsEnv(t *testing.T) {
 	assert.Contains(t, logs, "ETH_GAS_BUMP_THRESHOLD: 3\\n")
 	assert.Contains(t, logs, "ETH_GAS_BUMP_WEI: 5000000000\\n")
 	assert.Contains(t, logs, "ETH_GAS_PRICE_DEFAULT: 20000000000\\n")
+	assert.Contains(t, logs, "ETH_TX_MISSING_THRESHOLD: 240\\n")
 	assert.Contains(t, logs, "LINK_CONTRACT_ADDRESS: 0x514910771AF9Ca656af840dff83E8264EcF986CA\\n")
 	assert.Contains(t, logs, "MINIMUM_CONTRACT_PAYMENT: 0.000000000000000100\\n")
 	assert.Contains(t, logs, "ORACLE_CONTRACT_ADDRESS: \\n")
 	assert.Contains(t, logs, "DATABASE_POLL_INTERVAL: 500ms\\n")
 	assert.Contains(t, logs, "ALLOW_ORIGINS: http://localhost:3000,http://localhost:6688\\n")
 	assert.Contains(t, logs, "BRIDGE_RESPONSE_URL: http://localhost:6688\\n")
+	assert.Contains(t, logs, "DEFAULT_HTTP_LIMIT: 4194304\\n")
+	assert.Contains(t, logs, "DEFAULT_HTTP_TIMEOUT: 15s\\n")
+	assert.Contains(t, logs, "HTTP_RETRY_ATTEMPTS: 3\\n")
+	assert.Contains(t, logs, "HTTP_RETRY_MIN_BACKOFF: 1s\\n")
+	assert.Contains(t, logs, "HTTP_RETRY_MAX_BACKOFF: 10s\\n")
+	assert.Contains(t, logs, "IPFS_TIMEOUT: 30s\\n")
+	assert.Contains(t, logs, "ALLOW_UNRESTRICTED_NETWORK_ACCESS: true\\n")
+	assert.Contains(t, logs, "OTEL_EXPORTER_OTLP_ENDPOINT: \\n")
+	assert.Contains(t, logs, "JOB_RUN_TIMEOUT: 0s\\n")
+	assert.Contains(t, logs, "ALLOW_UNKNOWN_TASK_PARAMS: false\\n")
+	assert.Contains(t, logs, "API_RATE_LIMIT: 0\\n")
+	assert.Contains(t, logs, "API_BURST_LIMIT: 200\\n")
+	assert.Contains(t, logs, "ETH_LEDGER_PATH: \\n")
+	assert.Contains(t, logs, "VAULT_ADDR: \\n")
+	assert.Contains(t, logs, "VAULT_PATH: secret/chainlink\\n")
+	assert.Contains(t, logs, "MAX_SESSIONS: 0\\n")
+	assert.Contains(t, logs, "API_ALLOWED_IPS: \\n")
+	assert.Contains(t, logs, "API_DENIED_IPS: \\n")
+	assert.Contains(t, logs, "API_FORWARDED_DEPTH: 0\\n")
+	assert.Contains(t, logs, "PASSWORD_MIN_LENGTH: 8\\n")
+	assert.Contains(t, logs, "PASSWORD_REQUIRE_UPPERCASE: false\\n")
+	assert.Contains(t, logs, "PASSWORD_REQUIRE_LOWERCASE: false\\n")
+	assert.Contains(t, logs, "PASSWORD_REQUIRE_DIGIT: false\\n")
+	assert.Contains(t, logs, "PASSWORD_REQUIRE_SYMBOL: false\\n")
 }
 
 func TestClient_RunNodeWithPasswords(t *testing.T) {
@@ -208,3 +236,86 @@ func TestClient_LogToDiskOptionDisablesAsExpected(t *testing.T) {
 		})
 	}
 }
+
+// issuesValidator reports the same issues for every config.
+type issuesValidator []store.ConfigIssue
+
+func (v issuesValidator) ValidateConfig(store.Config) []store.ConfigIssue {
+	return v
+}
+
+func TestClient_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	warning := store.ConfigIssue{Severity: store.ConfigWarning, Setting: "ETH_GAS_BUMP_WEI", Message: "warning"}
+	failure := store.ConfigIssue{Severity: store.ConfigError, Setting: "ETH_URL", Message: "error"}
+	tests := []struct {
+		name     string
+		issues   []store.ConfigIssue
+		wantCode int
+	}{
+		{"no issues", nil, 0},
+		{"warning", []store.ConfigIssue{warning}, 1},
+		{"error", []store.ConfigIssue{failure}, 2},
+		{"warning and error", []store.ConfigIssue{warning, failure, warning}, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, cleanup := cltest.NewConfig()
+			defer cleanup()
+			r := &cltest.RendererMock{}
+			client := cmd.Client{
+				Renderer:         r,
+				Config:           config.Config,
+				ConfigValidators: []store.ConfigValidator{issuesValidator(test.issues)},
+			}
+
+			err := client.ValidateConfig(cli.NewContext(nil, flag.NewFlagSet("validate-config", 0), nil))
+			if test.wantCode == 0 {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, test.wantCode, err.(cli.ExitCoder).ExitCode())
+			}
+			require.Len(t, r.Renders, 1)
+			assert.Len(t, *r.Renders[0].(*[]store.ConfigIssue), len(test.issues))
+		})
+	}
+}
+
+func TestClient_ValidateConfig_File(t *testing.T) {
+	t.Parallel()
+	config, cleanup := cltest.NewConfig()
+	defer cleanup()
+	r := &cltest.RendererMock{}
+	client := cmd.Client{
+		Renderer:         r,
+		Config:           config.Config,
+		ConfigValidators: []store.ConfigValidator{store.SettingsValidator{}},
+	}
+	dir, err := ioutil.TempDir("", "chainlink_validate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	validate := func(contents string) error {
+		path := filepath.Join(dir, "chainlink.toml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+		set := flag.NewFlagSet("validate-config", 0)
+		set.Parse([]string{path})
+		return client.ValidateConfig(cli.NewContext(nil, set, nil))
+	}
+
+	assert.NoError(t, validate(`MAX_SESSIONS = 2`))
+
+	err = validate("MAX_SESSIONS = -1\nETH_URL = \"ftp://localhost\"")
+	require.Error(t, err)
+	assert.Equal(t, 2, err.(cli.ExitCoder).ExitCode())
+	issues := *r.Renders[1].(*[]store.ConfigIssue)
+	require.Len(t, issues, 2, "invalid settings are reported rather than stopping the command")
+
+	err = validate(`MAX_SESSIONS = "many"`)
+	require.Error(t, err)
+	assert.Equal(t, 2, err.(cli.ExitCoder).ExitCode())
+	assert.Contains(t, err.Error(), "invalid MAX_SESSIONS")
+	assert.Len(t, r.Renders, 2)
+}
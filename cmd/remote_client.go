@@ -262,6 +262,42 @@ func (cli *Client) RemoveBridge(c *clipkg.Context) error {
 	return cli.renderResponse(resp, &bridge)
 }
 
+// CreateHTTPCredential stores a named credential for use by the HTTP adapters.
+func (cli *Client) CreateHTTPCredential(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass in the credential's parameters [JSON blob | JSON filepath]"))
+	}
+
+	buf, err := getBufferFromJSON(c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/http_credentials", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var credential presenters.HTTPCredential
+	return cli.renderAPIResponse(resp, &credential)
+}
+
+// RemoveHTTPCredential removes a named credential.
+func (cli *Client) RemoveHTTPCredential(c *clipkg.Context) error {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the credential to be removed"))
+	}
+	resp, err := cli.HTTP.Delete("/v2/http_credentials/" + c.Args().First())
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer resp.Body.Close()
+
+	var credential presenters.HTTPCredential
+	return cli.renderAPIResponse(resp, &credential)
+}
+
 // RemoteLogin creates a cookie session to run remote commands.
 func (cli *Client) RemoteLogin(c *clipkg.Context) error {
 	sessionRequest, err := cli.buildSessionRequest(c.String("file"))
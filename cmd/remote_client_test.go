@@ -511,3 +511,40 @@ func TestClient_ChangePassword(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "401 Unauthorized")
 }
+
+func TestClient_CreateHTTPCredential(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client, r := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("credential", 0)
+	set.Parse([]string{`{"name":"kaiko","urlPrefix":"https://api.kaiko.com/","token":"s3cr3t"}`})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.CreateHTTPCredential(c))
+
+	require.Equal(t, 1, len(r.Renders))
+	rendered := r.Renders[0].(*presenters.HTTPCredential)
+	assert.Equal(t, "kaiko", rendered.Name)
+	assert.Equal(t, "Bearer", rendered.Scheme)
+
+	hc, err := app.Store.FindHTTPCredential("kaiko")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", hc.Token)
+}
+
+func TestClient_RemoveHTTPCredential(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	require.NoError(t, app.Store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://api.kaiko.com/", Token: "s3cr3t"}))
+	client, _ := app.NewClientAndRenderer()
+
+	set := flag.NewFlagSet("removecredential", 0)
+	set.Parse([]string{"kaiko"})
+	c := cli.NewContext(nil, set, nil)
+	require.NoError(t, client.RemoveHTTPCredential(c))
+
+	_, err := app.Store.FindHTTPCredential("kaiko")
+	assert.Error(t, err)
+}
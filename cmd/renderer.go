@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/store/presenters"
 	"github.com/smartcontractkit/chainlink/utils"
@@ -56,6 +57,10 @@ func (rt RendererTable) Render(v interface{}) error {
 		rt.renderAccountBalance(*typed)
 	case *presenters.ServiceAgreement:
 		rt.renderServiceAgreement(*typed)
+	case *presenters.HTTPCredential:
+		rt.renderHTTPCredential(*typed)
+	case *[]store.ConfigIssue:
+		rt.renderConfigIssues(*typed)
 	default:
 		return fmt.Errorf("Unable to render object: %v", typed)
 	}
@@ -111,6 +116,26 @@ func (rt RendererTable) renderBridges(bridges []models.BridgeType) error {
 	return nil
 }
 
+func (rt RendererTable) renderHTTPCredential(hc presenters.HTTPCredential) error {
+	table := rt.newTable([]string{"Name", "Scheme", "Username"})
+	table.Append([]string{hc.Name, hc.Scheme, hc.Username})
+	render("HTTP Credential", table)
+	return nil
+}
+
+func (rt RendererTable) renderConfigIssues(issues []store.ConfigIssue) error {
+	if len(issues) == 0 {
+		fmt.Fprintln(rt, "No issues found in config")
+		return nil
+	}
+	table := rt.newTable([]string{"Severity", "Setting", "Message"})
+	for _, issue := range issues {
+		table.Append([]string{string(issue.Severity), issue.Setting, issue.Message})
+	}
+	render("Config Issues", table)
+	return nil
+}
+
 func (rt RendererTable) renderBridge(bridge models.BridgeType) error {
 	table := rt.newTable([]string{"Name", "URL", "Default Confirmations", "Incoming Token", "Outgoing Token"})
 	table.Append([]string{
@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/cmd"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/store/presenters"
 	"github.com/stretchr/testify/assert"
@@ -151,3 +152,18 @@ func TestRendererTable_RenderUnknown(t *testing.T) {
 	anon := struct{ Name string }{"Romeo"}
 	assert.Error(t, r.Render(&anon))
 }
+
+func TestRendererTable_RenderConfigIssues(t *testing.T) {
+	t.Parallel()
+	issues := []store.ConfigIssue{
+		{Severity: store.ConfigWarning, Setting: "ETH_GAS_BUMP_WEI", Message: "stuck transactions are resent at the same gas price"},
+	}
+
+	tw := &testWriter{"stuck transactions", t, false}
+	assert.NoError(t, cmd.RendererTable{Writer: tw}.Render(&issues))
+	assert.True(t, tw.found)
+
+	tw = &testWriter{"No issues found", t, false}
+	assert.NoError(t, cmd.RendererTable{Writer: tw}.Render(&[]store.ConfigIssue{}))
+	assert.True(t, tw.found)
+}
@@ -71,8 +71,6 @@ func TestIntegration_HelloWorld(t *testing.T) {
 	eth.Context("ethTx.Perform()#1 at block 23456", func(eth *cltest.EthMock) {
 		eth.Register("eth_blockNumber", utils.Uint64ToHex(sentAt))
 		eth.Register("eth_sendRawTransaction", attempt1Hash)
-		eth.Register("eth_blockNumber", utils.Uint64ToHex(sentAt))
-		eth.Register("eth_getTransactionReceipt", unconfirmedReceipt)
 	})
 	j := cltest.CreateHelloWorldJobViaWeb(t, app, mockServer.URL)
 	jr := cltest.WaitForJobRunToPendConfirmations(t, app.Store, cltest.CreateJobRunViaWeb(t, app, j))
@@ -207,6 +205,39 @@ func TestIntegration_RunLog(t *testing.T) {
 	cltest.WaitForJobRunToComplete(t, app.Store, jr)
 }
 
+func TestIntegration_RunLog_TaskConfirmations(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	eth := app.MockEthClient()
+	logs := make(chan store.Log, 1)
+	newHeads := eth.RegisterNewHeads()
+	eth.Context("app.Start()", func(eth *cltest.EthMock) {
+		eth.RegisterSubscription("logs", logs)
+	})
+	app.Start()
+
+	j := cltest.FixtureCreateJobViaWeb(t, app, "../internal/fixtures/web/runlog_confirmations_job.json")
+
+	logBlockNumber := 10
+	log := cltest.NewRunLog(j.ID, cltest.NewAddress(), cltest.NewAddress(), logBlockNumber, `{}`)
+	log.BlockHash = cltest.NewHash()
+	logs <- log
+	jr := cltest.WaitForRuns(t, j, app.Store, 1)[0]
+	jr = cltest.WaitForJobRunToPendConfirmations(t, app.Store, jr)
+	assert.Equal(t, log.BlockHash, *jr.CreationHash)
+	assert.Equal(t, models.RunStatusCompleted, jr.TaskRuns[0].Status, "task without confirmations runs at once")
+	assert.Equal(t, uint64(3), jr.TaskRuns[1].MinimumConfirmations)
+
+	newHeads <- models.BlockHeader{Number: cltest.BigHexInt(logBlockNumber + 1)}
+	<-time.After(time.Second)
+	cltest.JobRunStaysPendingConfirmations(t, app.Store, jr)
+
+	newHeads <- models.BlockHeader{Number: cltest.BigHexInt(logBlockNumber + 2)}
+	cltest.WaitForJobRunToComplete(t, app.Store, jr)
+}
+
 func TestIntegration_EndAt(t *testing.T) {
 	t.Parallel()
 
@@ -479,26 +510,33 @@ func TestIntegration_NonceManagement_firstRunWithExistingTXs(t *testing.T) {
 	j := cltest.FixtureCreateJobViaWeb(t, app, "../internal/fixtures/web/web_initiated_eth_tx_job.json")
 
 	eth := app.MockEthClient()
+	newHeads := make(chan models.BlockHeader)
 	eth.Context("app.Start()", func(eth *cltest.EthMock) {
+		eth.RegisterSubscription("newHeads", newHeads)
 		eth.Register("eth_getTransactionCount", `0x0100`) // activate account nonce
 	})
 	app.Start()
 
 	createCompletedJobRun := func(blockNumber uint64, expectedNonce uint64) {
 		hash := common.HexToHash("0xb7862c896a6ba2711bccc0410184e46d793ea83b3e05470f1d359ea276d16bb5")
-		eth.Context("ethTx.Perform()", func(eth *cltest.EthMock) {
+		eth.Context("ethTx.Perform()#1", func(eth *cltest.EthMock) {
 			eth.Register("eth_blockNumber", utils.Uint64ToHex(blockNumber))
 			eth.Register("eth_sendRawTransaction", hash)
+		})
 
+		jr := cltest.CreateJobRunViaWeb(t, app, j, `{"value":"0x11"}`)
+		jr = cltest.WaitForJobRunToPendConfirmations(t, app.Store, jr)
+		eth.EventuallyAllCalled(t)
+
+		confirmedBlockNumber := blockNumber + app.Store.Config.MinOutgoingConfirmations
+		eth.Context("ethTx.Perform()#2", func(eth *cltest.EthMock) {
 			eth.Register("eth_getTransactionReceipt", store.TxReceipt{
 				Hash:        hash,
 				BlockNumber: cltest.Int(blockNumber),
 			})
-			confirmedBlockNumber := blockNumber + app.Store.Config.MinOutgoingConfirmations
 			eth.Register("eth_blockNumber", utils.Uint64ToHex(confirmedBlockNumber))
 		})
-
-		jr := cltest.CreateJobRunViaWeb(t, app, j, `{"value":"0x11"}`)
+		newHeads <- models.BlockHeader{Number: cltest.BigHexInt(confirmedBlockNumber)}
 		jr = cltest.WaitForJobRunToComplete(t, app.Store, jr)
 
 		txHashString, err := jr.Result.Value()
@@ -27,6 +27,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/smartcontractkit/chainlink/cmd"
 	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/assets"
@@ -36,6 +37,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
@@ -91,6 +95,8 @@ func NewConfigWithWSServer(wsserver *httptest.Server) *TestConfig {
 	count := atomic.AddUint64(&storeCounter, 1)
 	rootdir := path.Join(RootDir, fmt.Sprintf("%d-%d", time.Now().UnixNano(), count))
 	rawConfig := store.NewConfig()
+	rawConfig.APIRateLimit = 0
+	rawConfig.AllowUnrestrictedNetworkAccess = true
 	rawConfig.BridgeResponseURL = WebURL("http://localhost:6688")
 	rawConfig.ChainID = 3
 	rawConfig.Dev = true
@@ -102,6 +108,7 @@ func NewConfigWithWSServer(wsserver *httptest.Server) *TestConfig {
 	rawConfig.RootDir = rootdir
 	rawConfig.SecretGenerator = mockSecretGenerator{}
 	rawConfig.SessionTimeout = store.Duration{MustParseDuration("2m")}
+	rawConfig.MaxSessions = 0
 	config := TestConfig{Config: rawConfig}
 	config.SetEthereumServer(wsserver)
 	return &config
@@ -203,6 +210,7 @@ func NewApplicationWithConfigAndKeyStore(tc *TestConfig) (*TestApplication, func
 	_, err := app.Store.KeyStore.NewAccount(Password)
 	mustNotErr(err)
 	mustNotErr(app.Store.KeyStore.Unlock(Password))
+	mustNotErr(app.Store.VRFKeyStore.Unlock(Password))
 	return app, cleanup
 }
 
@@ -211,6 +219,7 @@ func NewApplicationWithConfigAndKeyStore(tc *TestConfig) (*TestApplication, func
 func NewApplicationWithConfigAndUnlockedAccount(tc *TestConfig) (*TestApplication, func()) {
 	app, cleanup := NewApplicationWithConfig(tc)
 	mustNotErr(app.Store.KeyStore.Unlock(Password))
+	mustNotErr(app.Store.VRFKeyStore.Unlock(Password))
 	return app, cleanup
 }
 
@@ -416,6 +425,10 @@ func (r *HTTPClientCleaner) Patch(path string, body io.Reader, headers ...map[st
 	return bodyCleaner(r.HTTPClient.Patch(path, body, headers...))
 }
 
+func (r *HTTPClientCleaner) Put(path string, body io.Reader) (*http.Response, func()) {
+	return bodyCleaner(r.HTTPClient.Put(path, body))
+}
+
 func (r *HTTPClientCleaner) Delete(path string) (*http.Response, func()) {
 	return bodyCleaner(r.HTTPClient.Delete(path))
 }
@@ -477,6 +490,18 @@ func ObserveLogs() *observer.ObservedLogs {
 	return observed
 }
 
+// RecordSpans traces with a provider which records every span, until the
+// returned function restores tracing to how it was before.
+func RecordSpans() (*tracetest.SpanRecorder, func()) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	recorder := tracetest.NewSpanRecorder()
+	observability.UseTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder, func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	}
+}
+
 // ReadLogs returns the contents of the applications log file as a string
 func ReadLogs(app *TestApplication) (string, error) {
 	logFile := fmt.Sprintf("%s/log.jsonl", app.Store.Config.RootDir)
@@ -916,6 +941,7 @@ func MustParseDuration(durationStr string) time.Duration {
 
 func NewSession(optionalSessionID ...string) models.Session {
 	session := models.NewSession()
+	session.Email = APIEmail
 	if len(optionalSessionID) > 0 {
 		session.ID = optionalSessionID[0]
 	}
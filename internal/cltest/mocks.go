@@ -42,6 +42,18 @@ func MockEthOnStore(s *store.Store) *EthMock {
 	return mock
 }
 
+// MockEthOnChain returns a new EthMock Client for the store's TxManager for
+// the chain, which must be in the config's Chains
+func MockEthOnChain(s *store.Store, chainID uint64) *EthMock {
+	mock := &EthMock{}
+	if txm, ok := s.TxManager.ForChain(chainID).(*store.EthTxManager); ok {
+		txm.EthClient = &store.EthClient{CallerSubscriber: mock}
+	} else {
+		log.Panicf("MockEthOnChain only works on chains configured with an EthTxManager, not %d", chainID)
+	}
+	return mock
+}
+
 // EthMock is a mock ethereum client
 type EthMock struct {
 	Responses      []MockResponse
@@ -386,14 +398,15 @@ func (f EmptyAppFactory) NewApplication(config store.Config) services.Applicatio
 // EmptyApplication an empty application
 type EmptyApplication struct{}
 
-func (*EmptyApplication) Start() error                              { return nil }
-func (*EmptyApplication) Stop() error                               { return nil }
-func (*EmptyApplication) GetStore() *store.Store                    { return nil }
-func (*EmptyApplication) GetReaper() services.Reaper                { return nil }
-func (*EmptyApplication) AddJob(job models.JobSpec) error           { return nil }
-func (*EmptyApplication) AddAdapter(bt *models.BridgeType) error    { return nil }
-func (*EmptyApplication) RemoveAdapter(bt *models.BridgeType) error { return nil }
-func (*EmptyApplication) NewBox() packr.Box                         { return packr.Box{} }
+func (*EmptyApplication) Start() error                               { return nil }
+func (*EmptyApplication) Stop() error                                { return nil }
+func (*EmptyApplication) GetStore() *store.Store                     { return nil }
+func (*EmptyApplication) GetReaper() services.Reaper                 { return nil }
+func (*EmptyApplication) GetConfigReloader() services.ConfigReloader { return nil }
+func (*EmptyApplication) AddJob(job models.JobSpec) error            { return nil }
+func (*EmptyApplication) AddAdapter(bt *models.BridgeType) error     { return nil }
+func (*EmptyApplication) RemoveAdapter(bt *models.BridgeType) error  { return nil }
+func (*EmptyApplication) NewBox() packr.Box                          { return packr.Box{} }
 
 // CallbackAuthenticator contains a call back authenticator method
 type CallbackAuthenticator struct {
@@ -0,0 +1,234 @@
+package cltest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// SimulatedChain is an in memory blockchain, funded with a single account,
+// that contracts can be deployed to and called.
+type SimulatedChain struct {
+	*backends.SimulatedBackend
+	key *ecdsa.PrivateKey
+
+	mutex  sync.Mutex
+	height uint64
+	// relayed holds the transactions sent by a store using the chain, keyed
+	// by the hash the store knows them by.
+	relayed map[common.Hash]relayedTx
+}
+
+// relayedTx is the transaction mined in place of one sent by a store, and
+// the block it was mined in.
+type relayedTx struct {
+	hash     common.Hash
+	block    uint64
+	creation bool
+}
+
+// NewSimulatedChain creates a SimulatedChain with a funded deployer account.
+func NewSimulatedChain(t *testing.T) *SimulatedChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	balance, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	alloc := core.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: balance}}
+	return &SimulatedChain{
+		SimulatedBackend: backends.NewSimulatedBackend(alloc, 8000000),
+		key:              key,
+		relayed:          map[common.Hash]relayedTx{},
+	}
+}
+
+// Commit mines the pending transactions into a new block.
+func (sc *SimulatedChain) Commit() {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.SimulatedBackend.Commit()
+	sc.height++
+}
+
+// BlockNumber returns the number of the latest block.
+func (sc *SimulatedChain) BlockNumber() uint64 {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.height
+}
+
+// relay mines a transaction signed by a store, sending its contents from the
+// chain's funded account instead, since the simulated backend only accepts
+// transactions signed without a chain ID.
+func (sc *SimulatedChain) relay(tx *types.Transaction) error {
+	ctx := context.Background()
+	nonce, err := sc.PendingNonceAt(ctx, crypto.PubkeyToAddress(sc.key.PublicKey))
+	if err != nil {
+		return err
+	}
+	var unsigned *types.Transaction
+	if tx.To() == nil {
+		unsigned = types.NewContractCreation(nonce, tx.Value(), tx.Gas(), tx.GasPrice(), tx.Data())
+	} else {
+		unsigned = types.NewTransaction(nonce, *tx.To(), tx.Value(), tx.Gas(), tx.GasPrice(), tx.Data())
+	}
+	signed, err := types.SignTx(unsigned, types.HomesteadSigner{}, sc.key)
+	if err != nil {
+		return err
+	}
+	if err = sc.SendTransaction(ctx, signed); err != nil {
+		return err
+	}
+	sc.Commit()
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.relayed[tx.Hash()] = relayedTx{hash: signed.Hash(), block: sc.height, creation: tx.To() == nil}
+	return nil
+}
+
+// receipt returns the receipt of a transaction sent by a store, as
+// eth_getTransactionReceipt answers, or nil until it is mined.
+func (sc *SimulatedChain) receipt(hash common.Hash) (*store.TxReceipt, error) {
+	sc.mutex.Lock()
+	relayed, ok := sc.relayed[hash]
+	sc.mutex.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	receipt, err := sc.TransactionReceipt(context.Background(), relayed.hash)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+
+	status := hexutil.Uint64(receipt.Status)
+	output := &store.TxReceipt{
+		BlockNumber: Int(relayed.block),
+		Hash:        hash,
+		Status:      &status,
+	}
+	if relayed.creation {
+		output.ContractAddress = &receipt.ContractAddress
+	}
+	return output, nil
+}
+
+// Deploy deploys the contract creation code, mines it, and returns the
+// contract's address.
+func (sc *SimulatedChain) Deploy(t *testing.T, abiJSON string, code []byte) common.Address {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+	address, _, _, err := bind.DeployContract(bind.NewKeyedTransactor(sc.key), parsed, code, sc)
+	require.NoError(t, err)
+	sc.Commit()
+	return address
+}
+
+// UseSimulatedChain answers the store's eth_call requests from the chain,
+// and mines each transaction the store sends in a block of its own.
+func UseSimulatedChain(s *store.Store, sc *SimulatedChain) {
+	txm, ok := s.TxManager.(*store.EthTxManager)
+	if !ok {
+		panic("UseSimulatedChain only works on EthTxManager")
+	}
+	txm.EthClient = &store.EthClient{CallerSubscriber: &simulatedCaller{sc}}
+}
+
+// simulatedCaller implements the JSON-RPC methods the TxManager uses to call
+// contracts and send transactions on top of a SimulatedChain.
+type simulatedCaller struct {
+	chain *SimulatedChain
+}
+
+func (c *simulatedCaller) Call(result interface{}, method string, args ...interface{}) error {
+	switch method {
+	case "eth_call":
+		return c.call(result, args...)
+	case "eth_blockNumber":
+		return setJSONResult(result, hexutil.Uint64(c.chain.BlockNumber()))
+	case "eth_getTransactionCount":
+		address, _ := args[0].(string)
+		nonce, err := c.chain.PendingNonceAt(context.Background(), common.HexToAddress(address))
+		if err != nil {
+			return err
+		}
+		return setJSONResult(result, hexutil.Uint64(nonce))
+	case "eth_sendRawTransaction":
+		hex, _ := args[0].(string)
+		tx, err := utils.DecodeEthereumTx(hex)
+		if err != nil {
+			return err
+		}
+		if err = c.chain.relay(&tx); err != nil {
+			return err
+		}
+		return setJSONResult(result, tx.Hash())
+	case "eth_getTransactionReceipt":
+		hash, _ := args[0].(string)
+		receipt, err := c.chain.receipt(common.HexToHash(hash))
+		if err != nil {
+			return err
+		}
+		return setJSONResult(result, receipt)
+	}
+	return fmt.Errorf("simulatedCaller: method %v not supported", method)
+}
+
+func (c *simulatedCaller) call(result interface{}, args ...interface{}) error {
+	var msg struct {
+		To   common.Address `json:"to"`
+		Data hexutil.Bytes  `json:"data"`
+	}
+	b, err := json.Marshal(args[0])
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(b, &msg); err != nil {
+		return err
+	}
+
+	var blockNumber *big.Int
+	if block, _ := args[1].(string); block != "latest" {
+		if blockNumber, err = hexutil.DecodeBig(block); err != nil {
+			return err
+		}
+	}
+
+	out, err := c.chain.CallContract(context.Background(), ethereum.CallMsg{To: &msg.To, Data: msg.Data}, blockNumber)
+	if err != nil {
+		return err
+	}
+	return setJSONResult(result, hexutil.Bytes(out))
+}
+
+// setJSONResult sets result to value, by way of its JSON as an Ethereum node
+// would answer with it.
+func setJSONResult(result interface{}, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+func (c *simulatedCaller) EthSubscribe(context.Context, interface{}, ...interface{}) (models.EthSubscription, error) {
+	return nil, errors.New("simulatedCaller: subscriptions not supported")
+}
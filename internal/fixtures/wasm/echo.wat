@@ -0,0 +1,13 @@
+(module
+  (import "env" "input_size" (func $input_size (result i32)))
+  (import "env" "input_read" (func $input_read (param i32)))
+  (import "env" "output_write" (func $output_write (param i32 i32)))
+  (memory 1)
+
+  ;; Outputs the run's data unchanged.
+  (func $perform
+    (call $input_read (i32.const 0))
+    (call $output_write (i32.const 0) (call $input_size))
+  )
+  (export "perform" (func $perform))
+)
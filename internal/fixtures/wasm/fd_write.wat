@@ -0,0 +1,6 @@
+(module
+  ;; Imports a WASI function, which the host API does not provide.
+  (import "wasi_unstable" "fd_write" (func $fd_write (param i32 i32 i32 i32) (result i32)))
+  (func $perform)
+  (export "perform" (func $perform))
+)
@@ -0,0 +1,7 @@
+(module
+  ;; Loops forever, to check that runs are stopped at the step limit.
+  (func $perform
+    (loop $forever (br $forever))
+  )
+  (export "perform" (func $perform))
+)
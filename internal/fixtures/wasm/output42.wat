@@ -0,0 +1,11 @@
+(module
+  (import "env" "output_write" (func $output_write (param i32 i32)))
+  (memory 1)
+  (data (i32.const 0) "42")
+
+  ;; Outputs the JSON number 42.
+  (func $perform
+    (call $output_write (i32.const 0) (i32.const 2))
+  )
+  (export "perform" (func $perform))
+)
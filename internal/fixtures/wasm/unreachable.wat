@@ -0,0 +1,7 @@
+(module
+  ;; Traps as soon as it is performed.
+  (func $perform
+    unreachable
+  )
+  (export "perform" (func $perform))
+)
@@ -4,6 +4,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path"
@@ -15,6 +16,10 @@ import (
 
 var logger *Logger
 
+// level is shared by the loggers built in this package, so that the level of
+// the node's logger can be changed while it is running.
+var level = zap.NewAtomicLevel()
+
 func init() {
 	err := zap.RegisterSink("pretty", prettyConsoleSink(os.Stderr))
 	if err != nil {
@@ -46,12 +51,40 @@ func (l *Logger) Write(b []byte) (int, error) {
 	return len(b), nil
 }
 
-// SetLogger sets the internal logger to the given input.
+// NewLogger returns a Logger which writes each entry to output as a line of
+// JSON. Entries below level, such as "debug" or "warn", are dropped; an
+// unrecognized level is treated as "info". The level is shared with the
+// node's logger and can be changed afterwards with SetLogLevel.
+func NewLogger(lvl string, output io.Writer) *Logger {
+	var zl zapcore.Level
+	if err := zl.UnmarshalText([]byte(lvl)); err != nil {
+		zl = zapcore.InfoLevel
+	}
+	level.SetLevel(zl)
+
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	core := zapcore.NewCore(encoder, zapcore.AddSync(output), level)
+	return &Logger{zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)).Sugar()}
+}
+
+// GetLogLevel returns the level below which log entries are dropped.
+func GetLogLevel() zapcore.Level {
+	return level.Level()
+}
+
+// SetLogLevel changes the level below which log entries are dropped, taking
+// effect immediately.
+func SetLogLevel(lvl zapcore.Level) {
+	level.SetLevel(lvl)
+}
+
+// SetLogger sets the internal logger to the given input. Its entries are
+// also kept for clients streaming the node's logs, see Subscribe.
 func SetLogger(zl *zap.Logger) {
 	if logger != nil {
 		defer logger.Sync()
 	}
-	logger = &Logger{zl.Sugar()}
+	logger = &Logger{withStream(zl).Sugar()}
 }
 
 // ProductionLoggerFilepath returns the full path to the file the
@@ -73,7 +106,8 @@ func CreateProductionLogger(
 		config.OutputPaths = append(config.OutputPaths, destination)
 		config.ErrorOutputPaths = append(config.ErrorOutputPaths, destination)
 	}
-	config.Level.SetLevel(lvl)
+	config.Level = level
+	level.SetLevel(lvl)
 
 	zl, err := config.Build(zap.AddCallerSkip(1))
 	if err != nil {
@@ -87,7 +121,8 @@ func CreateProductionLogger(
 func CreateTestLogger() *zap.Logger {
 	color.NoColor = false
 	config := zap.NewProductionConfig()
-	config.Level.SetLevel(zapcore.DebugLevel)
+	config.Level = level
+	level.SetLevel(zapcore.DebugLevel)
 	config.OutputPaths = []string{"pretty"}
 	zl, err := config.Build(zap.AddCallerSkip(1))
 	if err != nil {
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), "invalid JSON: %s", scanner.Text())
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewLogger_WritesJSON(t *testing.T) {
+	defer SetLogLevel(GetLogLevel())
+
+	var buf bytes.Buffer
+	l := NewLogger("info", &buf)
+	l.Infow("Processing task", "job_id", "j1", "run_id", "r1", "task_id", "t1")
+	l.Debug("dropped")
+	l.Error("failed")
+
+	entries := decodeLines(t, &buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "info", entries[0]["level"])
+	assert.Equal(t, "Processing task", entries[0]["msg"])
+	assert.Equal(t, "j1", entries[0]["job_id"])
+	assert.Equal(t, "r1", entries[0]["run_id"])
+	assert.Equal(t, "t1", entries[0]["task_id"])
+	assert.Contains(t, entries[0], "ts")
+	assert.Equal(t, "error", entries[1]["level"])
+	assert.Equal(t, "failed", entries[1]["msg"])
+}
+
+func TestNewLogger_LevelChangesAtRuntime(t *testing.T) {
+	defer SetLogLevel(GetLogLevel())
+
+	var buf bytes.Buffer
+	l := NewLogger("warn", &buf)
+	assert.Equal(t, zapcore.WarnLevel, GetLogLevel())
+	l.Info("dropped")
+	assert.Len(t, decodeLines(t, &buf), 0)
+
+	SetLogLevel(zapcore.DebugLevel)
+	l.Debug("kept")
+	entries := decodeLines(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "debug", entries[0]["level"])
+}
+
+func TestNewLogger_UnknownLevel(t *testing.T) {
+	defer SetLogLevel(GetLogLevel())
+
+	NewLogger("verbose", &bytes.Buffer{})
+	assert.Equal(t, zapcore.InfoLevel, GetLogLevel())
+}
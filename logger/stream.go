@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"encoding/json"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// StreamBufferSize is how many of the most recent log lines are kept for
+// clients which start streaming the node's logs.
+const StreamBufferSize = 1000
+
+// streamSubscriberBuffer is how many lines a subscriber may fall behind by
+// before further lines to it are dropped.
+const streamSubscriberBuffer = 100
+
+// Line is a log entry encoded as a line of JSON, numbered in the order it
+// was logged so that a client can resume after the last line it saw.
+type Line struct {
+	ID    uint64
+	Level zapcore.Level
+	JobID string
+	JSON  []byte
+}
+
+// LineStream keeps the most recent log lines, and sends each new one to its
+// subscribers.
+type LineStream struct {
+	mutex       sync.Mutex
+	lines       []Line
+	next        int
+	lastID      uint64
+	subscribers map[chan Line]struct{}
+}
+
+// NewLineStream returns a LineStream keeping the last size lines.
+func NewLineStream(size int) *LineStream {
+	return &LineStream{
+		lines:       make([]Line, size),
+		subscribers: map[chan Line]struct{}{},
+	}
+}
+
+var stream = NewLineStream(StreamBufferSize)
+
+// Subscribe returns the node's buffered log lines numbered after afterID,
+// a channel receiving each line logged from then on, and a function which
+// unsubscribes and closes the channel.
+func Subscribe(afterID uint64) ([]Line, <-chan Line, func()) {
+	return stream.Subscribe(afterID)
+}
+
+// Subscribe returns the buffered lines numbered after afterID, a channel
+// receiving each line written from then on, and a function which
+// unsubscribes and closes the channel.
+func (s *LineStream) Subscribe(afterID uint64) ([]Line, <-chan Line, func()) {
+	ch := make(chan Line, streamSubscriberBuffer)
+
+	s.mutex.Lock()
+	var backlog []Line
+	for i := range s.lines {
+		line := s.lines[(s.next+i)%len(s.lines)]
+		if line.ID > afterID {
+			backlog = append(backlog, line)
+		}
+	}
+	s.subscribers[ch] = struct{}{}
+	s.mutex.Unlock()
+
+	var once sync.Once
+	return backlog, ch, func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			delete(s.subscribers, ch)
+			close(ch)
+		})
+	}
+}
+
+// Write records a single encoded log entry, as written by a zapcore.Core,
+// and sends it to every subscriber. A subscriber too far behind misses the
+// line rather than holding up the logger.
+func (s *LineStream) Write(b []byte) (int, error) {
+	var fields struct {
+		Level zapcore.Level `json:"level"`
+		JobID string        `json:"job_id"`
+	}
+	json.Unmarshal(b, &fields)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastID++
+	line := Line{
+		ID:    s.lastID,
+		Level: fields.Level,
+		JobID: fields.JobID,
+		// The encoder reuses its buffer once Write returns.
+		JSON: append([]byte(nil), trimNewline(b)...),
+	}
+	if len(s.lines) > 0 {
+		s.lines[s.next] = line
+		s.next = (s.next + 1) % len(s.lines)
+	}
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+	return len(b), nil
+}
+
+func trimNewline(b []byte) []byte {
+	for len(b) > 0 && (b[len(b)-1] == '\n' || b[len(b)-1] == '\r') {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+// withStream tees the entries logged by zl into the node's LineStream as
+// JSON, whichever format zl itself writes.
+func withStream(zl *zap.Logger) *zap.Logger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	streamCore := zapcore.NewCore(encoder, zapcore.AddSync(stream), level)
+	return zl.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, streamCore)
+	}))
+}
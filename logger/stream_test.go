@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLineStream_KeepsMostRecent(t *testing.T) {
+	t.Parallel()
+
+	s := NewLineStream(3)
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(s, `{"level":"info","msg":"line %d"}`+"\n", i)
+	}
+
+	backlog, _, unsubscribe := s.Subscribe(0)
+	defer unsubscribe()
+	require.Len(t, backlog, 3)
+	assert.Equal(t, uint64(3), backlog[0].ID)
+	assert.Equal(t, `{"level":"info","msg":"line 3"}`, string(backlog[0].JSON))
+	assert.Equal(t, uint64(5), backlog[2].ID)
+
+	backlog, _, unsubscribe = s.Subscribe(4)
+	defer unsubscribe()
+	require.Len(t, backlog, 1)
+	assert.Equal(t, uint64(5), backlog[0].ID)
+}
+
+func TestLineStream_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	s := NewLineStream(10)
+	_, lines, unsubscribe := s.Subscribe(0)
+	fmt.Fprint(s, `{"level":"warn","job_id":"j1","msg":"hi"}`)
+
+	line := <-lines
+	assert.Equal(t, zapcore.WarnLevel, line.Level)
+	assert.Equal(t, "j1", line.JobID)
+
+	unsubscribe()
+	_, ok := <-lines
+	assert.False(t, ok)
+	fmt.Fprint(s, `{"level":"info"}`)
+}
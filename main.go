@@ -29,11 +29,22 @@ func Run(client *cmd.Client, args ...string) {
 			Name:  "json, j",
 			Usage: "json output as opposed to table",
 		},
+		cli.StringFlag{
+			Name:  "config",
+			Usage: "TOML file holding settings, which environment variables override",
+		},
 	}
 	app.Before = func(c *cli.Context) error {
 		if c.Bool("json") {
 			client.Renderer = cmd.RendererJSON{Writer: os.Stdout}
 		}
+		if path := c.String("config"); path != "" {
+			cfg, err := store.LoadConfigFromFile(path)
+			if err != nil {
+				return err
+			}
+			useConfig(client, cfg)
+		}
 		return nil
 	}
 	app.Commands = []cli.Command{
@@ -152,6 +163,16 @@ func Run(client *cmd.Client, args ...string) {
 			Usage:  "Removes a specific bridge",
 			Action: client.RemoveBridge,
 		},
+		{
+			Name:   "credential",
+			Usage:  "Add a named credential for HTTP adapters to authenticate with",
+			Action: client.CreateHTTPCredential,
+		},
+		{
+			Name:   "removecredential",
+			Usage:  "Removes a named credential",
+			Action: client.RemoveHTTPCredential,
+		},
 		{
 			Name:    "agree",
 			Aliases: []string{"createsa"},
@@ -169,6 +190,12 @@ func Run(client *cmd.Client, args ...string) {
 			Usage:  "Change your password",
 			Action: client.ChangePassword,
 		},
+		{
+			Name:      "validate-config",
+			Usage:     "Report issues with the config, from the file given or the environment",
+			ArgsUsage: "[file]",
+			Action:    client.ValidateConfig,
+		},
 	}
 	logger.WarnIf(app.Run(args))
 }
@@ -176,20 +203,26 @@ func Run(client *cmd.Client, args ...string) {
 // NewProductionClient configures an instance of the CLI to be used
 // in production.
 func NewProductionClient() *cmd.Client {
-	cfg := store.NewConfig()
 	prompter := cmd.NewTerminalPrompter()
-	cookieAuth := cmd.NewSessionCookieAuthenticator(cfg, cmd.DiskCookieStore{Config: cfg})
-	return &cmd.Client{
+	client := &cmd.Client{
 		Renderer:                       cmd.RendererTable{Writer: os.Stdout},
-		Config:                         cfg,
 		AppFactory:                     cmd.ChainlinkAppFactory{},
 		KeyStoreAuthenticator:          cmd.TerminalKeyStoreAuthenticator{Prompter: prompter},
 		FallbackAPIInitializer:         cmd.NewPromptingAPIInitializer(prompter),
 		Runner:                         cmd.ChainlinkRunner{},
-		HTTP:                           cmd.NewAuthenticatedHTTPClient(cfg, cookieAuth),
-		CookieAuthenticator:            cookieAuth,
 		FileSessionRequestBuilder:      cmd.NewFileSessionRequestBuilder(),
 		PromptingSessionRequestBuilder: cmd.NewPromptingSessionRequestBuilder(prompter),
 		ChangePasswordPrompter:         cmd.NewChangePasswordPrompter(),
 	}
+	useConfig(client, store.NewConfig())
+	return client
+}
+
+// useConfig sets the client's config, along with the authentication of its
+// requests to the node the config points at.
+func useConfig(client *cmd.Client, cfg store.Config) {
+	cookieAuth := cmd.NewSessionCookieAuthenticator(cfg, cmd.DiskCookieStore{Config: cfg})
+	client.Config = cfg
+	client.CookieAuthenticator = cookieAuth
+	client.HTTP = cmd.NewAuthenticatedHTTPClient(cfg, cookieAuth)
 }
@@ -53,12 +53,14 @@ func ExampleRun() {
 	//      agree, createsa           Creates a service agreement
 	//      withdraw, w               Withdraw LINK to an authorized address
 	//      chpass                    Change your password
+	//      validate-config           Report issues with the config, from the file given or the environment
 	//      help, h                   Shows a list of commands or help for one command
 	//
 	// GLOBAL OPTIONS:
-	//    --json, -j     json output as opposed to table
-	//    --help, -h     show help
-	//    --version, -v  print the version
+	//    --json, -j      json output as opposed to table
+	//    --config value  TOML file holding settings, which environment variables override
+	//    --help, -h      show help
+	//    --version, -v   print the version
 }
 
 func ExampleVersion() {
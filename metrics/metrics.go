@@ -0,0 +1,68 @@
+// Package metrics registers the node's Prometheus metrics with the default
+// registry, which is served at GET /metrics.
+package metrics
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// JobRuns counts job runs as they finish, by job and final status.
+	JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chainlink_job_runs_total",
+		Help: "The number of job runs that have finished, by job and status.",
+	}, []string{"job_id", "status"})
+
+	// JobRunDuration observes the time from a job run's creation until it
+	// finished.
+	JobRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chainlink_job_run_duration_seconds",
+		Help:    "The time taken by job runs from creation until they finished.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 16),
+	}, []string{"job_id"})
+
+	// TaskRuns counts each time a task's adapter is performed, by task type
+	// and the status it returned.
+	TaskRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chainlink_task_runs_total",
+		Help: "The number of times a task has been performed, by task type and status.",
+	}, []string{"task_type", "status"})
+
+	// TaskRunDuration observes the time taken to perform each task, by task
+	// type.
+	TaskRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chainlink_task_run_duration_seconds",
+		Help:    "The time taken to perform a task, by task type.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"task_type"})
+
+	// EthBalance is the most recently fetched ETH balance of each account.
+	EthBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "chainlink_eth_balance_wei",
+		Help: "The last fetched ETH balance of an account, in wei.",
+	}, []string{"account"})
+)
+
+// RecordJobRun counts a finished job run and observes how long it took.
+func RecordJobRun(jobID string, status string, duration time.Duration) {
+	JobRuns.WithLabelValues(jobID, status).Inc()
+	JobRunDuration.WithLabelValues(jobID).Observe(duration.Seconds())
+}
+
+// RecordTaskRun counts a single performance of a task and observes how long
+// it took.
+func RecordTaskRun(taskType string, status string, duration time.Duration) {
+	TaskRuns.WithLabelValues(taskType, status).Inc()
+	TaskRunDuration.WithLabelValues(taskType).Observe(duration.Seconds())
+}
+
+// SetEthBalance records the balance of the account in wei. Balances beyond
+// the precision of a float64 are rounded.
+func SetEthBalance(account string, wei *big.Int) {
+	value, _ := new(big.Float).SetInt(wei).Float64()
+	EthBalance.WithLabelValues(account).Set(value)
+}
@@ -0,0 +1,91 @@
+// Package observability traces the node's work with OpenTelemetry, exporting
+// spans over OTLP when OTEL_EXPORTER_OTLP_ENDPOINT is set. Until tracing is
+// initialized every span is a no-op.
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/smartcontractkit/chainlink"
+
+// InitTracing exports spans to the OTLP/HTTP collector at endpoint, for
+// example "http://otel-collector:4318", and returns a function which flushes
+// and stops the exporter. When endpoint is empty tracing stays disabled and
+// the returned function does nothing.
+func InitTracing(endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTEL_EXPORTER_OTLP_ENDPOINT %q", endpoint)
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create OTLP exporter: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String("chainlink"),
+		)),
+	)
+	UseTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// UseTracerProvider makes provider the source of all the node's spans, and
+// propagates trace context in W3C traceparent headers.
+func UseTracerProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// StartSpan starts a span as a child of any span in ctx.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// SpanFromContext returns the span in ctx, or a no-op span when there is
+// none, so that callers can always annotate it.
+func SpanFromContext(ctx context.Context) trace.Span {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return trace.SpanFromContext(ctx)
+}
+
+// InjectHeaders adds a traceparent header for the span in ctx to an outbound
+// request's headers.
+func InjectHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHeaders returns ctx with the remote span described by an inbound
+// request's traceparent header, if it has one.
+func ExtractHeaders(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,61 @@
+package observability_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitTracing_Disabled(t *testing.T) {
+	stop, err := observability.InitTracing("")
+	require.NoError(t, err)
+	assert.NoError(t, stop(context.Background()))
+
+	ctx, span := observability.StartSpan(context.Background(), "disabled")
+	defer span.End()
+	assert.False(t, span.IsRecording())
+	assert.False(t, observability.SpanFromContext(ctx).SpanContext().IsValid())
+
+	header := http.Header{}
+	observability.InjectHeaders(ctx, header)
+	assert.Empty(t, header.Get("traceparent"))
+}
+
+func TestInitTracing_InvalidEndpoint(t *testing.T) {
+	_, err := observability.InitTracing("otel-collector")
+	assert.Error(t, err)
+}
+
+func TestSpanFromContext_NoSpan(t *testing.T) {
+	span := observability.SpanFromContext(nil)
+	assert.False(t, span.IsRecording())
+	span.End()
+}
+
+func TestStartSpan_PropagatesTraceparent(t *testing.T) {
+	recorder, restore := cltest.RecordSpans()
+	defer restore()
+
+	ctx, parent := observability.StartSpan(context.Background(), "parent")
+	assert.Equal(t, parent, observability.SpanFromContext(ctx))
+
+	header := http.Header{}
+	observability.InjectHeaders(ctx, header)
+	require.NotEmpty(t, header.Get("traceparent"))
+
+	remote := observability.ExtractHeaders(context.Background(), header)
+	_, child := observability.StartSpan(remote, "child")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
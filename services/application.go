@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"sync"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/gobuffalo/packr"
 	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
@@ -20,6 +22,7 @@ type Application interface {
 	Stop() error
 	GetStore() *store.Store
 	GetReaper() Reaper
+	GetConfigReloader() ConfigReloader
 	AddJob(job models.JobSpec) error
 	AddAdapter(bt *models.BridgeType) error
 	RemoveAdapter(bt *models.BridgeType) error
@@ -30,15 +33,25 @@ type Application interface {
 // and Store. The JobSubscriber and Scheduler are also available
 // in the services package, but the Store has its own package.
 type ChainlinkApplication struct {
-	Exiter          func(int)
-	HeadTracker     *HeadTracker
-	JobRunner       JobRunner
-	JobSubscriber   JobSubscriber
-	Scheduler       *Scheduler
-	Store           *store.Store
-	Reaper          Reaper
-	bridgeTypeMutex sync.Mutex
-	jobSubscriberID string
+	Exiter           func(int)
+	FluxMonitor      FluxMonitor
+	HeadTracker      *HeadTracker
+	JobRunner        JobRunner
+	JobSubscriber    JobSubscriber
+	KeeperRegistry   *KeeperRegistry
+	ReorgDetector    *ReorgDetector
+	Scheduler        *Scheduler
+	Store            *store.Store
+	Reaper           Reaper
+	ConfigReloader   ConfigReloader
+	VRFCoordinator   *VRFCoordinator
+	bridgeTypeMutex  sync.Mutex
+	fluxMonitorID    string
+	jobSubscriberID  string
+	keeperRegistryID string
+	reorgDetectorID  string
+	vrfCoordinatorID string
+	stopTracing      func(context.Context) error
 }
 
 // NewApplication initializes a new store if one is not already
@@ -49,13 +62,18 @@ func NewApplication(config store.Config) Application {
 	store := store.NewStore(config)
 	ht := NewHeadTracker(store)
 	return &ChainlinkApplication{
-		HeadTracker:   ht,
-		JobSubscriber: NewJobSubscriber(store),
-		JobRunner:     NewJobRunner(store),
-		Scheduler:     NewScheduler(store),
-		Store:         store,
-		Reaper:        NewStoreReaper(store),
-		Exiter:        os.Exit,
+		FluxMonitor:    NewFluxMonitor(store),
+		HeadTracker:    ht,
+		JobSubscriber:  NewJobSubscriber(store),
+		KeeperRegistry: NewKeeperRegistry(store),
+		ReorgDetector:  NewReorgDetector(store, ReorgDetectionDepth),
+		JobRunner:      NewJobRunner(store),
+		Scheduler:      NewScheduler(store),
+		Store:          store,
+		Reaper:         NewStoreReaper(store),
+		ConfigReloader: NewConfigReloader(store, configFileInterval),
+		VRFCoordinator: NewVRFCoordinator(store),
+		Exiter:         os.Exit,
 	}
 }
 
@@ -73,7 +91,17 @@ func (app *ChainlinkApplication) Start() error {
 		app.Exiter(0)
 	}()
 
+	stopTracing, err := observability.InitTracing(app.Store.Config.OTELExporterOTLPEndpoint)
+	if err != nil {
+		return err
+	}
+	app.stopTracing = stopTracing
+
 	app.jobSubscriberID = app.HeadTracker.Attach(app.JobSubscriber)
+	app.reorgDetectorID = app.HeadTracker.Attach(app.ReorgDetector)
+	app.fluxMonitorID = app.HeadTracker.Attach(app.FluxMonitor)
+	app.vrfCoordinatorID = app.HeadTracker.Attach(app.VRFCoordinator)
+	app.keeperRegistryID = app.HeadTracker.Attach(app.KeeperRegistry)
 
 	return multierr.Combine(
 		app.Store.Start(),
@@ -81,6 +109,7 @@ func (app *ChainlinkApplication) Start() error {
 		app.Scheduler.Start(),
 		app.JobRunner.Start(),
 		app.Reaper.Start(),
+		app.ConfigReloader.Start(),
 	)
 }
 
@@ -95,7 +124,15 @@ func (app *ChainlinkApplication) Stop() error {
 	merr = multierr.Append(merr, app.HeadTracker.Stop())
 	app.JobRunner.Stop()
 	merr = multierr.Append(merr, app.Reaper.Stop())
+	merr = multierr.Append(merr, app.ConfigReloader.Stop())
 	app.HeadTracker.Detach(app.jobSubscriberID)
+	app.HeadTracker.Detach(app.reorgDetectorID)
+	app.HeadTracker.Detach(app.fluxMonitorID)
+	app.HeadTracker.Detach(app.vrfCoordinatorID)
+	app.HeadTracker.Detach(app.keeperRegistryID)
+	if app.stopTracing != nil {
+		merr = multierr.Append(merr, app.stopTracing(context.Background()))
+	}
 	return multierr.Append(merr, app.Store.Close())
 }
 
@@ -109,6 +146,12 @@ func (app *ChainlinkApplication) GetReaper() Reaper {
 	return app.Reaper
 }
 
+// GetConfigReloader returns the service changing the config while the node
+// runs.
+func (app *ChainlinkApplication) GetConfigReloader() ConfigReloader {
+	return app.ConfigReloader
+}
+
 // AddJob adds a job to the store and the scheduler. If there was
 // an error from adding the job to the store, the job will not be
 // added to the scheduler.
@@ -119,7 +162,10 @@ func (app *ChainlinkApplication) AddJob(job models.JobSpec) error {
 	}
 
 	app.Scheduler.AddJob(job)
-	return app.JobSubscriber.AddJob(job, app.HeadTracker.Head())
+	return multierr.Append(
+		app.JobSubscriber.AddJob(job, app.HeadTracker.Head()),
+		app.FluxMonitor.AddJob(job),
+	)
 }
 
 // AddAdapter adds an adapter to the store. If another
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+)
+
+// configFileInterval is how often the config file is checked for changes.
+const configFileInterval = 5 * time.Second
+
+// ConfigReloader changes the node's store.ReloadableSettings while it runs,
+// as they are changed in the config file it was started with, or given to
+// Apply. Each setting changed is logged.
+type ConfigReloader interface {
+	Start() error
+	Stop() error
+	Apply(settings map[string]interface{}) ([]store.ConfigChange, error)
+}
+
+type configReloader struct {
+	store    *store.Store
+	interval time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+	modTime  time.Time
+}
+
+// NewConfigReloader returns a ConfigReloader for the store's config, which
+// checks the config file for changes every interval.
+func NewConfigReloader(store *store.Store, interval time.Duration) ConfigReloader {
+	return &configReloader{store: store, interval: interval}
+}
+
+// Start watches the config file for changes, when the node was started with
+// one.
+func (cr *configReloader) Start() error {
+	path := cr.store.Config.FilePath
+	if path == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	cr.modTime = info.ModTime()
+	cr.done = make(chan struct{})
+	cr.wg.Add(1)
+	go cr.watch(path)
+	return nil
+}
+
+// Stop stops watching the config file.
+func (cr *configReloader) Stop() error {
+	if cr.done != nil {
+		close(cr.done)
+		cr.wg.Wait()
+		cr.done = nil
+	}
+	return nil
+}
+
+// Apply changes the settings given, named as their environment variables
+// are. Nothing is changed when any of them are not reloadable, or are
+// invalid.
+func (cr *configReloader) Apply(settings map[string]interface{}) ([]store.ConfigChange, error) {
+	changes, err := cr.store.ChangeSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	cr.applied(changes)
+	return changes, nil
+}
+
+func (cr *configReloader) watch(path string) {
+	defer cr.wg.Done()
+	ticker := time.NewTicker(cr.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-cr.done:
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			logger.Warnw("Unable to check config file for changes", "path", path, "error", err)
+			continue
+		}
+		if info.ModTime().Equal(cr.modTime) {
+			continue
+		}
+		cr.modTime = info.ModTime()
+
+		changes, err := cr.store.ReloadConfigFile()
+		if err != nil {
+			logger.Errorw("Config file changes not applied", "path", path, "error", err)
+			continue
+		}
+		cr.applied(changes)
+	}
+}
+
+// applied logs each setting changed, and sets the log level when it is one
+// of them.
+func (cr *configReloader) applied(changes []store.ConfigChange) {
+	for _, change := range changes {
+		if change.Setting == "LOG_LEVEL" {
+			logger.SetLogLevel(cr.store.CurrentConfig().LogLevel.Level)
+		}
+		logger.Infow("Config setting changed", "setting", change.Setting, "from", change.From, "to", change.To)
+	}
+}
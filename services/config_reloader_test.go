@@ -0,0 +1,82 @@
+package services_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestConfigReloader_Apply(t *testing.T) {
+	logs := cltest.ObserveLogs()
+	defer logger.SetLogLevel(logger.GetLogLevel())
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	cr := services.NewConfigReloader(store, time.Hour)
+	require.NoError(t, cr.Start())
+	defer cr.Stop()
+
+	changes, err := cr.Apply(map[string]interface{}{"LOG_LEVEL": "error", "MIN_OUTGOING_CONFIRMATIONS": "9"})
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, zapcore.ErrorLevel, logger.GetLogLevel())
+	assert.Equal(t, uint64(9), store.CurrentConfig().MinOutgoingConfirmations)
+
+	changed := map[string]string{}
+	for _, log := range logs.FilterMessage("Config setting changed").All() {
+		fields := log.ContextMap()
+		changed[fields["setting"].(string)] = fields["to"].(string)
+	}
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "error", "MIN_OUTGOING_CONFIRMATIONS": "9"}, changed)
+
+	_, err = cr.Apply(map[string]interface{}{"ROOT": "/elsewhere", "MIN_OUTGOING_CONFIRMATIONS": "10"})
+	assert.EqualError(t, err, "ROOT cannot be changed without restarting the node")
+	assert.Equal(t, uint64(9), store.CurrentConfig().MinOutgoingConfirmations)
+}
+
+func TestConfigReloader_WatchesConfigFile(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "chainlink_reloader")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tc, cleanup := cltest.NewConfig()
+	defer cleanup()
+	path := filepath.Join(dir, "chainlink.toml")
+	write := func(contents string, modTime time.Time) {
+		settings := fmt.Sprintf("ROOT = %q\nETH_URL = %q\n%s", dir, tc.EthereumURL, contents)
+		require.NoError(t, ioutil.WriteFile(path, []byte(settings), 0600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	start := time.Now().Add(-time.Minute)
+	write(`JOB_RUN_TIMEOUT = "1m"`, start)
+	config, err := strpkg.LoadConfigFromFile(path)
+	require.NoError(t, err)
+	store, storeCleanup := cltest.NewStoreWithConfig(&cltest.TestConfig{Config: config})
+	defer storeCleanup()
+
+	cr := services.NewConfigReloader(store, 10*time.Millisecond)
+	require.NoError(t, cr.Start())
+	defer cr.Stop()
+
+	write(`JOB_RUN_TIMEOUT = "2m"`, start.Add(time.Second))
+	gomega.NewGomegaWithT(t).Eventually(func() time.Duration {
+		return store.CurrentConfig().JobRunTimeout.Duration
+	}).Should(gomega.Equal(2 * time.Minute))
+
+	write("JOB_RUN_TIMEOUT = \"3m\"\nCHAINLINK_PORT = 7000", start.Add(2*time.Second))
+	gomega.NewGomegaWithT(t).Consistently(func() time.Duration {
+		return store.CurrentConfig().JobRunTimeout.Duration
+	}, 100*time.Millisecond).Should(gomega.Equal(2 * time.Minute))
+}
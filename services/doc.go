@@ -7,12 +7,37 @@
 // The Application is the main component used for starting and
 // stopping the Chainlink node.
 //
+// FluxMonitor
+//
+// The FluxMonitor keeps the answers of FluxAggregator contracts up to date
+// for jobs with a "fluxmonitor" initiator. Every pollInterval it performs the
+// job's tasks, which must complete straight away and end with an integer
+// value, and submits the value as a new round when it is at least threshold
+// percent from the contract's latest answer. Rounds started by other oracles
+// are answered straight away.
+//  { "type": "fluxmonitor", "params": { "contractAddress": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "threshold": 0.5, "pollInterval": "1m" } }
+//
 // JobRunner
 //
 // The JobRunner keeps track of Runs within a Job and ensures
 // that they're executed in order. Within each Run, the tasks
 // are also executed from the JobRunner.
 //
+// A task which fails is attempted again when its spec sets "maxRetries",
+// after waiting "retryDelay" each time. The run errors with the error of
+// every attempt once none remain, or straight away when the error is a
+// models.PermanentError, which adapters return for failures such as a 4xx
+// response or arguments which cannot be encoded. Transactions are never
+// sent again once they have been sent.
+//  { "type": "HTTPGet", "maxRetries": 3, "retryDelay": "10s", "params": { "get": "https://example.com/price" } }
+//
+// KeeperRegistry
+//
+// The KeeperRegistry checks the upkeeps added at /v2/keepers on every new
+// head, calling checkUpkeep on their KeeperRegistry contracts, and sends a
+// performUpkeep transaction for those which are needed, unless the LINK the
+// registry pays is worth less than the transaction's gas.
+//
 // JobSubscriber
 //
 // The JobSubscriber coordinates running job events with
@@ -24,4 +49,11 @@
 // The Scheduler ensures that recurring events are executed
 // according to their schedule, and one-time events occur only
 // when the specified time has passed.
+//
+// VRFCoordinator
+//
+// The VRFCoordinator listens for RandomnessRequest logs from the contract at
+// VRF_COORDINATOR_ADDRESS, and fulfills those made to the hash of one of the
+// node's VRF keys with the proof of the key's output for the request's seed,
+// once the request has MIN_INCOMING_CONFIRMATIONS.
 package services
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 )
@@ -10,7 +12,7 @@ func ExportedExecuteRunAtBlock(
 	store *store.Store,
 	input models.RunResult,
 ) (*models.JobRun, error) {
-	return executeRun(run, store)
+	return executeRun(context.Background(), run, store)
 }
 
 func ExportedChannelForRun(jr JobRunner, runID string) chan<- struct{} {
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/smartcontractkit/chainlink/adapters"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"go.uber.org/multierr"
+)
+
+// MinimumFluxMonitorPollInterval is the shortest pollInterval a "fluxmonitor"
+// initiator may have, so that the data sources behind its tasks are not
+// flooded with requests.
+const MinimumFluxMonitorPollInterval = time.Second
+
+// The FluxAggregator events which the FluxMonitor subscribes to.
+// See https://github.com/smartcontractkit/chainlink/blob/master/evm-contracts/src/v0.6/FluxAggregator.sol
+var (
+	// AnswerUpdatedTopic is the signature of the AnswerUpdated(...) event,
+	// emitted once a round has enough submissions to be answered.
+	AnswerUpdatedTopic = mustHash("AnswerUpdated(int256,uint256,uint256)")
+	// NewRoundTopic is the signature of the NewRound(...) event, emitted
+	// when an oracle starts a round which the others should answer.
+	NewRoundTopic = mustHash("NewRound(uint256,address,uint256)")
+)
+
+// Function selectors of the FluxAggregator methods the FluxMonitor calls.
+var (
+	fluxAggregatorLatestAnswer = models.HexToFunctionSelector("0x50d25bcd") // latestAnswer()
+	fluxAggregatorLatestRound  = models.HexToFunctionSelector("0x668a0f02") // latestRound()
+	fluxAggregatorSubmit       = models.HexToFunctionSelector("0x202ee0ed") // submit(uint256,int256)
+)
+
+// FluxMonitor keeps the answers of FluxAggregator contracts up to date for
+// jobs with a "fluxmonitor" initiator. Every pollInterval it performs the
+// job's tasks, and submits the answer they fetch as a new round when it
+// deviates from the contract's latest answer by at least the initiator's
+// threshold. When another oracle starts a round, the answer is submitted to
+// it whatever its deviation.
+type FluxMonitor interface {
+	HeadTrackable
+	AddJob(job models.JobSpec) error
+}
+
+type fluxMonitor struct {
+	store     *store.Store
+	checkers  []*fluxChecker
+	head      *models.IndexableBlockNumber
+	connected bool
+	mutex     sync.Mutex
+}
+
+// NewFluxMonitor returns a new FluxMonitor, which checks its jobs' contracts
+// once connected.
+func NewFluxMonitor(store *store.Store) FluxMonitor {
+	return &fluxMonitor{store: store}
+}
+
+// AddJob starts checking the contracts of the job's "fluxmonitor"
+// initiators, if connected. Otherwise they are checked once connected.
+func (fm *fluxMonitor) AddJob(job models.JobSpec) error {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	if !fm.connected {
+		return nil
+	}
+	return fm.addJob(job)
+}
+
+func (fm *fluxMonitor) addJob(job models.JobSpec) error {
+	for _, initr := range job.InitiatorsFor(models.InitiatorFluxMonitor) {
+		checker, err := startFluxChecker(fm.store, job, initr, fm.head)
+		if err != nil {
+			return err
+		}
+		fm.checkers = append(fm.checkers, checker)
+	}
+	return nil
+}
+
+// Connect starts checking the contracts of every job with a "fluxmonitor"
+// initiator, listening for their events from the block after head.
+func (fm *fluxMonitor) Connect(head *models.IndexableBlockNumber) error {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	fm.head = head
+	fm.connected = true
+	var merr error
+	err := fm.store.Jobs(func(j models.JobSpec) bool {
+		merr = multierr.Append(merr, fm.addJob(j))
+		return true
+	})
+	return multierr.Append(merr, err)
+}
+
+// Disconnect stops checking every contract.
+func (fm *fluxMonitor) Disconnect() {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	for _, checker := range fm.checkers {
+		checker.stop()
+	}
+	fm.checkers = nil
+	fm.connected = false
+}
+
+// OnNewHead remembers the head, from which the contracts of jobs added later
+// are listened to.
+func (fm *fluxMonitor) OnNewHead(head *models.BlockHeader) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	fm.head = head.ToIndexableBlockNumber()
+}
+
+// fluxChecker checks the contract of one "fluxmonitor" initiator.
+type fluxChecker struct {
+	store        *store.Store
+	job          models.JobSpec
+	initr        models.Initiator
+	subscription *ManagedSubscription
+	done         chan struct{}
+	wg           sync.WaitGroup
+
+	// mutex serializes the checks made by polling and by events, so that a
+	// round is only ever submitted to once.
+	mutex          sync.Mutex
+	submittedRound *big.Int
+}
+
+func startFluxChecker(
+	store *store.Store,
+	job models.JobSpec,
+	initr models.Initiator,
+	head *models.IndexableBlockNumber,
+) (*fluxChecker, error) {
+	fc := &fluxChecker{
+		store: store,
+		job:   job,
+		initr: initr,
+		done:  make(chan struct{}),
+	}
+
+	filter := utils.ToFilterQueryFor(head.NextInt(), []common.Address{initr.ContractAddress})
+	filter.Topics = [][]common.Hash{{AnswerUpdatedTopic, NewRoundTopic}}
+	subscription, err := NewManagedSubscription(store, filter, fc.receiveLog)
+	if err != nil {
+		return nil, fmt.Errorf("unable to subscribe to FluxAggregator %s for job %s: %v", initr.ContractAddress.Hex(), job.ID, err)
+	}
+	fc.subscription = subscription
+	logger.Infow(fmt.Sprintf("Checking FluxAggregator %s every %v for job %s", initr.ContractAddress.Hex(), initr.PollInterval.Duration(), job.ID), fc.forLogger()...)
+
+	fc.wg.Add(1)
+	go fc.pollLoop()
+	return fc, nil
+}
+
+func (fc *fluxChecker) stop() {
+	close(fc.done)
+	fc.wg.Wait()
+	fc.subscription.Unsubscribe()
+}
+
+func (fc *fluxChecker) pollLoop() {
+	defer fc.wg.Done()
+	for {
+		select {
+		case <-fc.done:
+			return
+		case <-fc.store.Clock.After(fc.initr.PollInterval.Duration()):
+			fc.poll()
+		}
+	}
+}
+
+// poll submits the answer fetched by the job's tasks as the next round, if
+// it is far enough from the contract's latest answer and the node has not
+// already submitted to that round.
+func (fc *fluxChecker) poll() {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if !fc.running() {
+		return
+	}
+	round, err := fc.callInt(fluxAggregatorLatestRound)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to get the latest round: %v", err), fc.forLogger()...)
+		return
+	}
+	next := new(big.Int).Add(round, big.NewInt(1))
+	if fc.submittedRound != nil && next.Cmp(fc.submittedRound) <= 0 {
+		logger.Debugw(fmt.Sprintf("Already submitted an answer for round %v", next), fc.forLogger()...)
+		return
+	}
+	current, err := fc.callInt(fluxAggregatorLatestAnswer)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to get the latest answer: %v", err), fc.forLogger()...)
+		return
+	}
+	answer, err := fc.fetchAnswer()
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to fetch an answer: %v", err), fc.forLogger()...)
+		return
+	}
+
+	if !deviates(current, answer, fc.initr.DeviationThreshold) {
+		logger.Debugw(fmt.Sprintf("Answer %v is within %v%% of %v, so not submitting it", answer, fc.initr.DeviationThreshold, current), fc.forLogger()...)
+		return
+	}
+	fc.submit(next, answer)
+}
+
+// receiveLog answers rounds started by other oracles.
+func (fc *fluxChecker) receiveLog(log store.Log) {
+	if log.Removed || len(log.Topics) < 2 {
+		return
+	}
+	switch log.Topics[0] {
+	case NewRoundTopic:
+		fc.answerRound(log.Topics[1].Big())
+	case AnswerUpdatedTopic:
+		answer := math.S256(log.Topics[1].Big())
+		logger.Infow(fmt.Sprintf("FluxAggregator %s answered %v", fc.initr.ContractAddress.Hex(), answer), fc.forLogger("block", log.BlockNumber)...)
+	}
+}
+
+func (fc *fluxChecker) answerRound(round *big.Int) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if !fc.running() || (fc.submittedRound != nil && round.Cmp(fc.submittedRound) <= 0) {
+		return
+	}
+	answer, err := fc.fetchAnswer()
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to fetch an answer for round %v: %v", round, err), fc.forLogger()...)
+		return
+	}
+	fc.submit(round, answer)
+}
+
+func (fc *fluxChecker) submit(round, answer *big.Int) {
+	roundWord, err := utils.EVMWordBigInt(round)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to encode round %v: %v", round, err), fc.forLogger()...)
+		return
+	}
+	answerWord, err := utils.EVMWordSignedBigInt(answer)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to encode answer %v: %v", answer, err), fc.forLogger()...)
+		return
+	}
+	data, err := utils.ConcatBytes(fluxAggregatorSubmit.Bytes(), roundWord, answerWord)
+	if err != nil {
+		logger.Errorw(err.Error(), fc.forLogger()...)
+		return
+	}
+
+	tx, err := fc.store.TxManager.CreateTx(fc.initr.ContractAddress, data)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to submit answer %v for round %v: %v", answer, round, err), fc.forLogger()...)
+		return
+	}
+	fc.submittedRound = round
+	logger.Infow(fmt.Sprintf("Submitted answer %v for round %v", answer, round), fc.forLogger("tx", tx.Hash.Hex())...)
+}
+
+// running returns whether the job is between its start and end.
+func (fc *fluxChecker) running() bool {
+	now := fc.store.Clock.Now()
+	return fc.job.Started(now) && !fc.job.Ended(now)
+}
+
+// callInt returns the int256 returned by the contract's method.
+func (fc *fluxChecker) callInt(selector models.FunctionSelector) (*big.Int, error) {
+	output, err := fc.store.TxManager.CallContract(fc.initr.ContractAddress, selector.Bytes(), "latest")
+	if err != nil {
+		return nil, err
+	}
+	if len(output) != utils.EVMWordByteLen {
+		return nil, fmt.Errorf("%s returned %d bytes, not a word", selector.String(), len(output))
+	}
+	return math.S256(new(big.Int).SetBytes(output)), nil
+}
+
+// fetchAnswer performs the job's tasks in turn, each given the result of the
+// one before, and returns the value of the last truncated to an integer.
+// Tasks such as multiply should scale the value to the contract's decimals.
+// Every task must complete straight away, so bridges, sleeps and EthTx
+// tasks cannot be used.
+func (fc *fluxChecker) fetchAnswer() (*big.Int, error) {
+	result := models.RunResult{}
+	for i, task := range fc.job.Tasks {
+		adapter, err := adapters.For(task, fc.store)
+		if err != nil {
+			return nil, err
+		}
+		result = adapter.Perform(result, fc.store)
+		if result.HasError() {
+			return nil, fmt.Errorf("task %d (%s) errored: %s", i, task.Type, result.Error())
+		} else if !result.Status.Completed() {
+			return nil, fmt.Errorf("task %d (%s) is %s, but must complete straight away", i, task.Type, result.Status)
+		}
+	}
+
+	value := result.Get("value")
+	rat, ok := new(big.Rat).SetString(value.String())
+	if !ok {
+		return nil, fmt.Errorf("cannot parse %q into a number", value.String())
+	}
+	return new(big.Int).Quo(rat.Num(), rat.Denom()), nil
+}
+
+func (fc *fluxChecker) forLogger(kvs ...interface{}) []interface{} {
+	output := []interface{}{
+		"job_id", fc.job.ID,
+		"contract", fc.initr.ContractAddress.Hex(),
+	}
+	return append(output, kvs...)
+}
+
+// deviates returns whether answer is at least threshold percent away from
+// current. Any change from 0 deviates.
+func deviates(current, answer *big.Int, threshold float64) bool {
+	diff := new(big.Int).Sub(answer, current)
+	if diff.Sign() == 0 {
+		return false
+	} else if current.Sign() == 0 {
+		return true
+	}
+	change := new(big.Rat).SetFrac(diff.Abs(diff), new(big.Int).Abs(current))
+	change.Mul(change, big.NewRat(100, 1))
+	limit := new(big.Rat)
+	limit.SetFloat64(threshold)
+	return change.Cmp(limit) >= 0
+}
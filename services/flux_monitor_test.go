@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tickClock only fires After when ticked, so that tests decide when the
+// FluxMonitor polls.
+type tickClock struct {
+	ticks chan time.Time
+}
+
+func (c tickClock) Now() time.Time                       { return time.Now() }
+func (c tickClock) After(time.Duration) <-chan time.Time { return c.ticks }
+
+// mockFluxAggregator answers eth_calls as a FluxAggregator contract with
+// the given latest round and answer would.
+type mockFluxAggregator struct {
+	round  int64
+	answer int64
+}
+
+func (m mockFluxAggregator) register(t *testing.T, eth *cltest.EthMock) {
+	for i := 0; i < 2; i++ {
+		eth.Register("eth_call", hexutil.Bytes{}, func(result interface{}, args ...interface{}) error {
+			b, err := json.Marshal(args[0].([]interface{})[0])
+			require.NoError(t, err)
+			var call struct{ Data hexutil.Bytes }
+			require.NoError(t, json.Unmarshal(b, &call))
+
+			var output []byte
+			switch hexutil.Encode(call.Data) {
+			case "0x668a0f02": // latestRound()
+				output = utils.EVMWordUint64(uint64(m.round))
+			case "0x50d25bcd": // latestAnswer()
+				output, err = utils.EVMWordSignedBigInt(big.NewInt(m.answer))
+				require.NoError(t, err)
+			default:
+				return fmt.Errorf("FluxAggregator has no method %s", call.Data)
+			}
+			*result.(*hexutil.Bytes) = output
+			return nil
+		})
+	}
+}
+
+// expectSubmission registers the transaction submitting answer for round.
+func expectSubmission(t *testing.T, eth *cltest.EthMock, aggregator common.Address, round, answer int64) {
+	eth.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	eth.Register("eth_sendRawTransaction", cltest.NewHash(), func(_ interface{}, data ...interface{}) error {
+		tx, err := utils.DecodeEthereumTx(data[0].([]interface{})[0].(string))
+		require.NoError(t, err)
+		assert.Equal(t, aggregator, *tx.To())
+		answerWord, err := utils.EVMWordSignedBigInt(big.NewInt(answer))
+		require.NoError(t, err)
+		want := append(append(hexutil.MustDecode("0x202ee0ed"), utils.EVMWordUint64(uint64(round))...), answerWord...)
+		assert.Equal(t, hexutil.Encode(want), hexutil.Encode(tx.Data()))
+		return nil
+	})
+}
+
+func newFluxMonitorJob(aggregator common.Address, sourceURL string) models.JobSpec {
+	j := models.NewJob()
+	j.Initiators = []models.Initiator{{
+		JobID: j.ID,
+		Type:  models.InitiatorFluxMonitor,
+		InitiatorParams: models.InitiatorParams{FluxMonitorJob: models.FluxMonitorJob{
+			ContractAddress:    aggregator,
+			DeviationThreshold: 0.5,
+			PollInterval:       models.Duration(time.Minute),
+		}},
+	}}
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("httpget", fmt.Sprintf(`{"get":"%s"}`, sourceURL)),
+		cltest.NewTask("jsonparse", `{"path":["price"]}`),
+		cltest.NewTask("multiply", `{"times":100}`),
+	}
+	return j
+}
+
+// priceSource serves a price which tests can change.
+func priceSource() (*httptest.Server, func(string)) {
+	var mutex sync.Mutex
+	price := "100"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		fmt.Fprintf(w, `{"price":%s}`, price)
+	}))
+	return server, func(p string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		price = p
+	}
+}
+
+func TestFluxMonitor_SubmitsOnDeviationAndNewRounds(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	clock := tickClock{ticks: make(chan time.Time)}
+	store.Clock = clock
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	logs := make(chan strpkg.Log, 1)
+	eth.RegisterSubscription("logs", logs)
+	require.NoError(t, app.Start())
+
+	source, setPrice := priceSource()
+	defer source.Close()
+	aggregator := cltest.NewAddress()
+	require.NoError(t, app.AddJob(newFluxMonitorJob(aggregator, source.URL)))
+	eth.EventuallyAllCalled(t)
+
+	// 100.2 is within 0.5% of the contract's 100.00, so is not submitted.
+	setPrice("100.2")
+	mockFluxAggregator{round: 3, answer: 10000}.register(t, eth)
+	clock.ticks <- time.Now()
+	eth.EventuallyAllCalled(t)
+
+	// 101 deviates by 1%, so is submitted as round 4.
+	setPrice("101")
+	mockFluxAggregator{round: 3, answer: 10000}.register(t, eth)
+	expectSubmission(t, eth, aggregator, 4, 10100)
+	clock.ticks <- time.Now()
+	eth.EventuallyAllCalled(t)
+
+	// The round the node started itself is not answered again.
+	logs <- strpkg.Log{Address: aggregator, Topics: []common.Hash{services.NewRoundTopic, common.BigToHash(big.NewInt(4)), cltest.GetAccountAddress(store).Hash()}}
+
+	// A round started by another oracle is answered whatever the deviation.
+	expectSubmission(t, eth, aggregator, 5, 10100)
+	logs <- strpkg.Log{Address: aggregator, Topics: []common.Hash{services.NewRoundTopic, common.BigToHash(big.NewInt(5)), cltest.NewAddress().Hash()}}
+	eth.EventuallyAllCalled(t)
+
+	txs := []models.Tx{}
+	require.NoError(t, store.Where("From", cltest.GetAccountAddress(store), &txs))
+	assert.Len(t, txs, 2)
+}
+
+func TestFluxMonitor_SubmitsOncePerRound(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	clock := tickClock{ticks: make(chan time.Time)}
+	store.Clock = clock
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	eth.RegisterSubscription("logs")
+	require.NoError(t, app.Start())
+
+	source, setPrice := priceSource()
+	defer source.Close()
+	aggregator := cltest.NewAddress()
+	require.NoError(t, app.AddJob(newFluxMonitorJob(aggregator, source.URL)))
+	eth.EventuallyAllCalled(t)
+
+	setPrice("101")
+	mockFluxAggregator{round: 3, answer: 10000}.register(t, eth)
+	expectSubmission(t, eth, aggregator, 4, 10100)
+	clock.ticks <- time.Now()
+	eth.EventuallyAllCalled(t)
+
+	// Until the submission is mined, the contract's latest round is still 3,
+	// and round 4 is not submitted to again.
+	mockFluxAggregator{round: 3, answer: 10000}.register(t, eth)
+	eth.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	eth.Register("eth_sendRawTransaction", cltest.NewHash(), func(interface{}, ...interface{}) error {
+		t.Error("submitted to round 4 twice")
+		return nil
+	})
+	clock.ticks <- time.Now()
+	// The next tick is only received once the poll has finished.
+	clock.ticks <- time.Now()
+
+	txs := []models.Tx{}
+	require.NoError(t, store.Where("From", cltest.GetAccountAddress(store), &txs))
+	assert.Len(t, txs, 1)
+}
+
+func TestFluxMonitor_AnswerFromZero(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	clock := tickClock{ticks: make(chan time.Time)}
+	store.Clock = clock
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	eth.RegisterSubscription("logs")
+	require.NoError(t, app.Start())
+
+	source, _ := priceSource()
+	defer source.Close()
+	aggregator := cltest.NewAddress()
+	require.NoError(t, app.AddJob(newFluxMonitorJob(aggregator, source.URL)))
+	eth.EventuallyAllCalled(t)
+
+	mockFluxAggregator{round: 0, answer: 0}.register(t, eth)
+	expectSubmission(t, eth, aggregator, 1, 10000)
+	clock.ticks <- time.Now()
+	eth.EventuallyAllCalled(t)
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/store"
+)
+
+// HealthChecker checks one of the dependencies the node needs in order to do
+// its work, returning an error describing the problem if it is unusable.
+type HealthChecker interface {
+	Name() string
+	Check() error
+}
+
+// DBHealthChecker checks that the node's database can be read.
+type DBHealthChecker struct {
+	Store *store.Store
+}
+
+// Name returns "database".
+func (DBHealthChecker) Name() string { return "database" }
+
+// Check opens a read transaction on the database.
+func (c DBHealthChecker) Check() error {
+	return c.Store.ORM.Ping()
+}
+
+// EthHealthChecker checks that the Ethereum node is answering requests.
+type EthHealthChecker struct {
+	Store *store.Store
+}
+
+// Name returns "ethereum".
+func (EthHealthChecker) Name() string { return "ethereum" }
+
+// Check asks the Ethereum node for its latest block.
+func (c EthHealthChecker) Check() error {
+	if _, err := c.Store.TxManager.GetBlockByNumber("latest"); err != nil {
+		return fmt.Errorf("unable to reach Ethereum node: %v", err)
+	}
+	return nil
+}
+
+// AccountHealthChecker checks that the node has an account with ETH to pay
+// for its transactions.
+type AccountHealthChecker struct {
+	Store *store.Store
+}
+
+// Name returns "account".
+func (AccountHealthChecker) Name() string { return "account" }
+
+// Check looks up the balance of the node's account.
+func (c AccountHealthChecker) Check() error {
+	account, err := c.Store.TxSigner.GetAccount()
+	if err != nil {
+		return errors.New("no Ethereum account configured")
+	}
+	balance, err := c.Store.TxManager.GetEthBalance(account.Address)
+	if err != nil {
+		return fmt.Errorf("unable to get balance of %s: %v", account.Address.Hex(), err)
+	}
+	if balance.IsZero() {
+		return fmt.Errorf("account %s has no ETH", account.Address.Hex())
+	}
+	return nil
+}
+
+// ReadinessCheckers returns the checks the node must pass before it is ready
+// to serve requests.
+func ReadinessCheckers(store *store.Store) []HealthChecker {
+	return []HealthChecker{
+		DBHealthChecker{Store: store},
+		EthHealthChecker{Store: store},
+		AccountHealthChecker{Store: store},
+	}
+}
+
+// HealthReport holds the outcome of each check, which is "ok" or the reason
+// it failed.
+type HealthReport struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Healthy returns true if every check passed.
+func (hr HealthReport) Healthy() bool {
+	return hr.Status == "ok"
+}
+
+// CheckHealth runs every checker, continuing past failures so that the report
+// lists all of the problems at once.
+func CheckHealth(checkers []HealthChecker) HealthReport {
+	report := HealthReport{Status: "ok", Checks: map[string]string{}}
+	for _, checker := range checkers {
+		if err := checker.Check(); err != nil {
+			report.Status = "unavailable"
+			report.Checks[checker.Name()] = err.Error()
+		} else {
+			report.Checks[checker.Name()] = "ok"
+		}
+	}
+	return report
+}
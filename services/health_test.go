@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/assets"
+	"github.com/smartcontractkit/chainlink/store/mock_store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChecker struct {
+	name string
+	err  error
+}
+
+func (f fakeChecker) Name() string { return f.name }
+func (f fakeChecker) Check() error { return f.err }
+
+func TestCheckHealth(t *testing.T) {
+	t.Parallel()
+
+	report := services.CheckHealth([]services.HealthChecker{
+		fakeChecker{name: "database"},
+		fakeChecker{name: "ethereum"},
+	})
+	assert.True(t, report.Healthy())
+	assert.Equal(t, map[string]string{"database": "ok", "ethereum": "ok"}, report.Checks)
+
+	report = services.CheckHealth([]services.HealthChecker{
+		fakeChecker{name: "database", err: errors.New("closed")},
+		fakeChecker{name: "ethereum"},
+		fakeChecker{name: "account", err: errors.New("empty")},
+	})
+	assert.False(t, report.Healthy())
+	assert.Equal(t, "unavailable", report.Status)
+	assert.Equal(t, map[string]string{"database": "closed", "ethereum": "ok", "account": "empty"}, report.Checks)
+}
+
+func TestDBHealthChecker(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "health")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := orm.NewORM(path.Join(dir, "db.bolt"), time.Second)
+	require.NoError(t, err)
+
+	checker := services.DBHealthChecker{Store: &strpkg.Store{ORM: db}}
+	assert.NoError(t, checker.Check())
+
+	require.NoError(t, db.Close())
+	assert.Error(t, checker.Check())
+}
+
+func TestEthHealthChecker(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	checker := services.EthHealthChecker{Store: store}
+
+	txmMock.EXPECT().GetBlockByNumber("latest").Return(models.BlockHeader{}, nil)
+	assert.NoError(t, checker.Check())
+
+	txmMock.EXPECT().GetBlockByNumber("latest").Return(models.BlockHeader{}, errors.New("connection refused"))
+	err := checker.Check()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestAccountHealthChecker(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	store.TxManager = txmMock
+
+	account, err := store.KeyStore.GetAccount()
+	require.NoError(t, err)
+	checker := services.AccountHealthChecker{Store: store}
+
+	txmMock.EXPECT().GetEthBalance(account.Address).Return(assets.NewEth(1), nil)
+	assert.NoError(t, checker.Check())
+
+	txmMock.EXPECT().GetEthBalance(account.Address).Return(assets.NewEth(0), nil)
+	err = checker.Check()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no ETH")
+}
+
+func TestAccountHealthChecker_NoAccount(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	err := services.AccountHealthChecker{Store: store}.Check()
+	require.Error(t, err)
+	assert.Equal(t, "no Ethereum account configured", err.Error())
+}
@@ -1,9 +1,11 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/logger"
@@ -23,6 +25,8 @@ type JobRunner interface {
 type jobRunner struct {
 	started              bool
 	done                 chan struct{}
+	ctx                  context.Context
+	cancel               context.CancelFunc
 	bootMutex            sync.Mutex
 	store                *store.Store
 	workerMutex          sync.RWMutex
@@ -48,6 +52,7 @@ func (rm *jobRunner) Start() error {
 		return errors.New("JobRunner already started")
 	}
 	rm.done = make(chan struct{})
+	rm.ctx, rm.cancel = context.WithCancel(context.Background())
 	rm.started = true
 
 	var starterWg sync.WaitGroup
@@ -59,7 +64,7 @@ func (rm *jobRunner) Start() error {
 	return rm.resumeRuns()
 }
 
-// Stop closes all open worker channels.
+// Stop cancels the tasks being performed and closes all open worker channels.
 func (rm *jobRunner) Stop() {
 	rm.bootMutex.Lock()
 	defer rm.bootMutex.Unlock()
@@ -67,6 +72,7 @@ func (rm *jobRunner) Stop() {
 	if !rm.started {
 		return
 	}
+	rm.cancel()
 	close(rm.done)
 	rm.started = false
 	rm.demultiplexStopperWg.Wait()
@@ -78,11 +84,21 @@ func (rm *jobRunner) resumeRuns() error {
 		return err
 	}
 	for _, run := range sleepingRuns {
-		if _, err := QueueSleepingTask(&run, rm.store); err != nil {
+		if _, err := QueueSleepingTask(rm.ctx, &run, rm.store); err != nil {
 			logger.Errorw("Error resuming sleeping job", "error", err)
 		}
 	}
 
+	retryingRuns, err := rm.store.JobRunsWithStatus(models.RunStatusPendingRetry)
+	if err != nil {
+		return err
+	}
+	for _, run := range retryingRuns {
+		if err := QueueRetryingTask(rm.ctx, &run, rm.store); err != nil {
+			logger.Errorw("Error resuming retrying job", "error", err)
+		}
+	}
+
 	inProgressRuns, err := rm.store.JobRunsWithStatus(models.RunStatusInProgress)
 	if err != nil {
 		return err
@@ -145,13 +161,13 @@ func (rm *jobRunner) workerLoop(runID string, workerChannel chan struct{}) {
 				logger.Errorw(fmt.Sprint("Error finding run ", runID), run.ForLogger("error", err)...)
 			}
 
-			if run, err := executeRun(&run, rm.store); err != nil {
+			if run, err := executeRun(rm.ctx, &run, rm.store); err != nil {
 				logger.Errorw(fmt.Sprint("Error executing run ", runID), run.ForLogger("error", err)...)
 				return
 			}
 
 			if run.Status.Finished() {
-				logger.Debugw("All tasks complete for run", []interface{}{"run", run.ID}...)
+				logger.Debugw("All tasks complete for run", []interface{}{"run_id", run.ID}...)
 				return
 			}
 
@@ -186,36 +202,77 @@ func prepareTaskInput(run *models.JobRun, currentTaskRun *models.TaskRun) (model
 	return input, nil
 }
 
-func executeTask(run *models.JobRun, currentTaskRun *models.TaskRun, store *store.Store) models.RunResult {
+// runContext returns a context which is done when ctx is, or once the run
+// has been going for longer than the configured JobRunTimeout. The timeout
+// only stops a task from being started: a task which is resumed, such as an
+// EthTx waiting for confirmations or a bridge which has called back, has
+// already had its effect and is left to finish.
+func runContext(ctx context.Context, run *models.JobRun, starting bool, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 || !starting {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, run.CreatedAt.Add(timeout))
+}
+
+func executeTask(ctx context.Context, run *models.JobRun, currentTaskRun *models.TaskRun, store *store.Store) models.RunResult {
+	if err := ctx.Err(); err != nil {
+		return currentTaskRun.Result.WithError(models.NewPermanentError(fmt.Errorf("run cancelled before performing task: %v", err)))
+	}
+
 	var err error
 	if currentTaskRun.Task.Params, err = currentTaskRun.Task.Params.Merge(run.Overrides.Data); err != nil {
-		return currentTaskRun.Result.WithError(err)
+		return currentTaskRun.Result.WithError(models.NewPermanentError(err))
 	}
 
 	adapter, err := adapters.For(currentTaskRun.Task, store)
 	if err != nil {
-		return currentTaskRun.Result.WithError(err)
+		return currentTaskRun.Result.WithError(models.NewPermanentError(err))
 	}
 
-	logger.Infow(fmt.Sprintf("Processing task %s", currentTaskRun.Task.Type), []interface{}{"task", currentTaskRun.ID}...)
+	logger.Infow(fmt.Sprintf("Processing task %s", currentTaskRun.Task.Type), []interface{}{
+		"job_id", run.JobID,
+		"run_id", run.ID,
+		"task_id", currentTaskRun.ID,
+	}...)
 
 	input, err := prepareTaskInput(run, currentTaskRun)
 	if err != nil {
-		return currentTaskRun.Result.WithError(err)
+		return currentTaskRun.Result.WithError(models.NewPermanentError(err))
 	}
 
-	result := adapter.Perform(input, store)
-
-	logger.Infow(fmt.Sprintf("Finished processing task %s", currentTaskRun.Task.Type), []interface{}{
-		"task", currentTaskRun.ID,
+	event := taskEvent(run, currentTaskRun)
+	store.NotifyTaskStart(event)
+	start := time.Now()
+	result := adapter.PerformCtx(ctx, input, store)
+	event.Duration, event.Status = time.Since(start), result.Status
+	store.NotifyTaskComplete(event)
+
+	fields := []interface{}{
+		"job_id", run.JobID,
+		"run_id", run.ID,
+		"task_id", currentTaskRun.ID,
 		"result", result.Status,
-		"result_data", result.Data,
-	}...)
+	}
+	// The value of an OAuth2 task is a bearer token.
+	if currentTaskRun.Task.Type != adapters.TaskTypeOAuth2 {
+		fields = append(fields, "result_data", result.Data)
+	}
+	logger.Infow(fmt.Sprintf("Finished processing task %s", currentTaskRun.Task.Type), fields...)
 
 	return result
 }
 
-func executeRun(run *models.JobRun, store *store.Store) (*models.JobRun, error) {
+// taskEvent describes the task run for the store's TaskObservers.
+func taskEvent(run *models.JobRun, tr *models.TaskRun) store.TaskEvent {
+	return store.TaskEvent{
+		TaskType:  tr.Task.Type,
+		JobID:     run.JobID,
+		JobRunID:  run.ID,
+		TaskRunID: tr.ID,
+	}
+}
+
+func executeRun(ctx context.Context, run *models.JobRun, store *store.Store) (*models.JobRun, error) {
 	logger.Infow("Processing run", run.ForLogger()...)
 
 	if !run.Status.Runnable() {
@@ -228,28 +285,60 @@ func executeRun(run *models.JobRun, store *store.Store) (*models.JobRun, error)
 
 	currentTaskRunIndex, _ := run.NextTaskRunIndex()
 	currentTaskRun := run.TaskRuns[currentTaskRunIndex]
+	starting := currentTaskRun.Status.Unstarted() || currentTaskRun.Status.PendingRetry()
+	if starting {
+		currentTaskRun = currentTaskRun.StartAttempt()
+	}
 
-	result := executeTask(run, &currentTaskRun, store)
+	taskCtx, cancel := runContext(ctx, run, starting, store.CurrentConfig().JobRunTimeout.Duration)
+	result := executeTask(taskCtx, run, &currentTaskRun, store)
+	cancel()
+
+	// A task cut short by the runner stopping is left as it was saved, so
+	// that it is performed again once the node restarts.
+	if result.HasError() && ctx.Err() != nil {
+		logger.Infow("Run interrupted by shutdown", run.ForLogger("task_id", currentTaskRun.ID)...)
+		return run, nil
+	}
 
 	currentTaskRun = currentTaskRun.ApplyResult(result)
+	if currentTaskRun.RetriesRemain() {
+		retryAt := store.Clock.Now().Add(currentTaskRun.Task.RetryDelay.Duration())
+		currentTaskRun = currentTaskRun.MarkPendingRetry(retryAt)
+		result = currentTaskRun.Result
+	} else if currentTaskRun.Status.Errored() && len(currentTaskRun.ErrorHistory) > 0 {
+		result = result.WithError(currentTaskRun.RetriedError())
+		currentTaskRun = currentTaskRun.ApplyResult(result)
+	}
 	run.TaskRuns[currentTaskRunIndex] = currentTaskRun
 	*run = run.ApplyResult(result)
 
 	if currentTaskRun.Status.PendingSleep() {
-		logger.Debugw("Task is sleeping", []interface{}{"run", run.ID}...)
-		if run, err := QueueSleepingTask(run, store); err != nil {
+		logger.Debugw("Task is sleeping", []interface{}{"run_id", run.ID}...)
+		if run, err := QueueSleepingTask(ctx, run, store); err != nil {
 			return run, err
 		}
+	} else if currentTaskRun.Status.PendingRetry() {
+		logger.Debugw("Task failed, retrying", []interface{}{"run_id", run.ID, "task_id", currentTaskRun.ID, "attempts", currentTaskRun.Attempts, "error", result.Error()}...)
+	} else if currentTaskRun.Status.Aborted() {
+		logger.Debugw("Task aborted run, skipping remaining tasks", []interface{}{"run_id", run.ID, "task_id", currentTaskRun.ID}...)
 	} else if !currentTaskRun.Status.Runnable() {
-		logger.Debugw("Task execution blocked", []interface{}{"run", run.ID, "task", currentTaskRun.ID, "state", currentTaskRun.Result.Status}...)
+		logger.Debugw("Task execution blocked", []interface{}{"run_id", run.ID, "task_id", currentTaskRun.ID, "state", currentTaskRun.Result.Status}...)
 	} else if run.TasksRemain() {
-		logger.Debugw("All tasks completed, marking run complete", []interface{}{"run", run.ID, "task", currentTaskRun.ID}...)
+		logger.Debugw("All tasks completed, marking run complete", []interface{}{"run_id", run.ID, "task_id", currentTaskRun.ID}...)
 		run = queueNextTask(run, store)
 	}
 
 	if err := saveAndTrigger(run, store); err != nil {
 		return run, err
 	}
+	// Queued once saved, so that a retry which is soon due is not
+	// overwritten.
+	if run.Status.PendingRetry() {
+		if err := QueueRetryingTask(ctx, run, store); err != nil {
+			return run, err
+		}
+	}
 	logger.Infow("Run finished processing", run.ForLogger()...)
 
 	return run, nil
@@ -260,10 +349,10 @@ func queueNextTask(run *models.JobRun, store *store.Store) *models.JobRun {
 	futureTaskRun := run.TaskRuns[futureTaskRunIndex]
 
 	if meetsMinimumConfirmations(run, &futureTaskRun, run.ObservedHeight) {
-		logger.Debugw("Adding next task to job run queue", []interface{}{"run", run.ID}...)
+		logger.Debugw("Adding next task to job run queue", []interface{}{"run_id", run.ID}...)
 		run.Status = models.RunStatusInProgress
 	} else {
-		logger.Debugw("Blocking run pending incoming confirmations", []interface{}{"run", run.ID, "required_height", futureTaskRun.MinimumConfirmations}...)
+		logger.Debugw("Blocking run pending incoming confirmations", []interface{}{"run_id", run.ID, "required_height", futureTaskRun.MinimumConfirmations}...)
 		run.Status = models.RunStatusPendingConfirmations
 	}
 
@@ -1,14 +1,25 @@
 package services_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/metrics"
 	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/orm"
 	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -122,6 +133,268 @@ func TestJobRunner_ChannelForRun_equalityWithoutClosing(t *testing.T) {
 	assert.Equal(t, chan1, chan2)
 }
 
+func TestJobRunner_CompareHoldsRunForReview(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("compare", `{"operator":"lt","threshold":100}`),
+		cltest.NewTask("noop"),
+	}
+	assert.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.Overrides = models.RunResult{Data: cltest.JSONFromString(`{"value":"212.54"}`)}
+	assert.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunStatus(t, s, jr, models.RunStatusPendingReview)
+
+	assert.Equal(t, models.RunStatusPendingReview, jr.TaskRuns[0].Status)
+	assert.Equal(t, models.RunStatusUnstarted, jr.TaskRuns[1].Status)
+}
+
+func TestJobRunner_CompareAbortsRun(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("compare", `{"operator":"lt","threshold":100,"onFail":"abort"}`),
+		cltest.NewTask("noop"),
+	}
+	assert.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.Overrides = models.RunResult{Data: cltest.JSONFromString(`{"value":"212.54"}`)}
+	assert.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunStatus(t, s, jr, models.RunStatusAborted)
+
+	assert.True(t, jr.CompletedAt.Valid)
+	assert.False(t, jr.Result.HasError())
+	assert.Equal(t, models.RunStatusAborted, jr.TaskRuns[0].Status)
+	assert.Equal(t, models.RunStatusUnstarted, jr.TaskRuns[1].Status)
+}
+
+func TestJobRunner_DeviationComparesWithLastCompletedRun(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("deviation", `{"threshold":"0.5"}`),
+		cltest.NewTask("noop"),
+	}
+	assert.NoError(t, s.SaveJob(&j))
+
+	tests := []struct {
+		value string
+		want  models.RunStatus
+	}{
+		{"100", models.RunStatusCompleted},
+		{"100.4", models.RunStatusAborted},
+		{"99.6", models.RunStatusAborted},
+		{"100.5", models.RunStatusCompleted},
+	}
+	for _, test := range tests {
+		jr := j.NewRun(initr)
+		jr.Overrides = models.RunResult{Data: cltest.JSONFromString(`{"value":"` + test.value + `"}`)}
+		assert.NoError(t, s.Save(&jr))
+
+		services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+		jr = cltest.WaitForJobRunStatus(t, s, jr, test.want)
+
+		if test.want == models.RunStatusAborted {
+			assert.Equal(t, models.RunStatusUnstarted, jr.TaskRuns[1].Status)
+			assert.Contains(t, jr.Result.Get("reason").String(), "from 100,")
+		} else {
+			assert.Equal(t, models.RunStatusCompleted, jr.TaskRuns[1].Status)
+		}
+	}
+}
+
+// Not parallel, so that no other runs perform noop tasks while it counts them.
+func TestJobRunner_RecordsMetrics(t *testing.T) {
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	noopRuns := metrics.TaskRuns.WithLabelValues("noop", string(models.RunStatusCompleted))
+	before := testutil.ToFloat64(noopRuns)
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("noop"), cltest.NewTask("noop")}
+	assert.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	assert.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	cltest.WaitForJobRunToComplete(t, s, jr)
+
+	completedRuns := metrics.JobRuns.WithLabelValues(j.ID, string(models.RunStatusCompleted))
+	gomega.NewGomegaWithT(t).Eventually(func() float64 {
+		return testutil.ToFloat64(completedRuns)
+	}).Should(gomega.Equal(1.0))
+	assert.Equal(t, before+2, testutil.ToFloat64(noopRuns))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.JobRuns.WithLabelValues(j.ID, string(models.RunStatusErrored))))
+}
+
+// recordingTaskObserver keeps every event it is told of.
+type recordingTaskObserver struct {
+	sync.Mutex
+	started, completed []strpkg.TaskEvent
+}
+
+func (o *recordingTaskObserver) OnTaskStart(event strpkg.TaskEvent) {
+	o.Lock()
+	defer o.Unlock()
+	o.started = append(o.started, event)
+}
+
+func (o *recordingTaskObserver) OnTaskComplete(event strpkg.TaskEvent) {
+	o.Lock()
+	defer o.Unlock()
+	o.completed = append(o.completed, event)
+}
+
+type panickingTaskObserver struct{}
+
+func (panickingTaskObserver) OnTaskStart(strpkg.TaskEvent)    { panic("OnTaskStart") }
+func (panickingTaskObserver) OnTaskComplete(strpkg.TaskEvent) { panic("OnTaskComplete") }
+
+func TestJobRunner_NotifiesTaskObservers(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	observer := &recordingTaskObserver{}
+	s.RegisterTaskObserver(panickingTaskObserver{})
+	s.RegisterTaskObserver(observer)
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("noop"),
+		cltest.NewTask("multiply", `{"times":2}`),
+		cltest.NewTask("compare", `{"operator":"lt","threshold":100,"onFail":"abort"}`),
+	}
+	assert.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.Overrides = models.RunResult{Data: cltest.JSONFromString(`{"value":"60"}`)}
+	assert.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunStatus(t, s, jr, models.RunStatusAborted)
+
+	observer.Lock()
+	defer observer.Unlock()
+	require.Len(t, observer.started, 3)
+	require.Len(t, observer.completed, 3)
+	wantStatuses := []models.RunStatus{models.RunStatusCompleted, models.RunStatusCompleted, models.RunStatusAborted}
+	for i, tr := range jr.TaskRuns {
+		started, completed := observer.started[i], observer.completed[i]
+		assert.Equal(t, tr.Task.Type, started.TaskType)
+		assert.Equal(t, tr.ID, started.TaskRunID)
+		assert.Equal(t, j.ID, started.JobID)
+		assert.Equal(t, jr.ID, started.JobRunID)
+		assert.Equal(t, models.RunStatus(""), started.Status)
+
+		assert.Equal(t, tr.ID, completed.TaskRunID)
+		assert.Equal(t, wantStatuses[i], completed.Status)
+		assert.True(t, completed.Duration > 0)
+	}
+}
+
+func TestJobRunner_executeRun_TimedOut(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.JobRunTimeout = strpkg.Duration{Duration: time.Minute}
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("noop")}
+	require.NoError(t, store.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.CreatedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, store.Save(&jr))
+
+	run, err := services.ExportedExecuteRunAtBlock(&jr, store, models.RunResult{})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RunStatusErrored, run.Status)
+	assert.Equal(t, models.RunStatusErrored, run.TaskRuns[0].Status)
+	assert.Contains(t, run.Result.Error(), context.DeadlineExceeded.Error())
+}
+
+func TestJobRunner_executeRun_TimedOutResumesPendingTask(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	store.Config.JobRunTimeout = strpkg.Duration{Duration: time.Minute}
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("noop")}
+	require.NoError(t, store.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.CreatedAt = time.Now().Add(-time.Hour)
+	jr.Status = models.RunStatusInProgress
+	jr.TaskRuns[0] = jr.TaskRuns[0].MarkPendingConfirmations()
+	require.NoError(t, store.Save(&jr))
+
+	run, err := services.ExportedExecuteRunAtBlock(&jr, store, models.RunResult{})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RunStatusCompleted, run.Status)
+	assert.Equal(t, models.RunStatusCompleted, run.TaskRuns[0].Status)
+}
+
+func TestJobRunner_executeRun_RunFinishedMeanwhile(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("noop")}
+	require.NoError(t, store.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.Status = models.RunStatusInProgress
+	require.NoError(t, store.Save(&jr))
+
+	// The run is errored, as by a chain reorganization, while a copy of it
+	// read before is being performed.
+	stale := jr
+	stale.TaskRuns = append([]models.TaskRun{}, jr.TaskRuns...)
+	jr = jr.ApplyResult(jr.Result.WithError(errors.New("reorged")))
+	require.NoError(t, store.Save(&jr))
+
+	_, err := services.ExportedExecuteRunAtBlock(&stale, store, models.RunResult{})
+	assert.Equal(t, orm.ErrorRunFinished, err)
+
+	found, err := store.FindJobRun(jr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusErrored, found.Status)
+}
+
 func TestJobRunner_Stop(t *testing.T) {
 	t.Parallel()
 
@@ -143,3 +416,321 @@ func TestJobRunner_Stop(t *testing.T) {
 		return services.ExportedWorkerCount(rm)
 	}).Should(gomega.Equal(0))
 }
+
+func TestJobRunner_StopLeavesTaskToResume(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	require.NoError(t, rm.Start())
+
+	requested, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, server.URL))}
+	require.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.Status = models.RunStatusInProgress
+	require.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	<-requested
+	rm.Stop()
+
+	run, err := s.FindJobRun(jr.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusInProgress, run.Status)
+	assert.Equal(t, models.RunStatusUnstarted, run.TaskRuns[0].Status)
+	assert.False(t, run.Result.HasError())
+	assert.False(t, run.TaskRuns[0].Result.HasError())
+}
+
+// Each task adds to the data of the one before, so that a key written early
+// in a pipeline is still there once it finishes.
+func TestJobRunner_ResultCarriesEarlierKeys(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	cltest.UseSettableClock(s).SetTime(time.Date(2018, 6, 1, 12, 0, 0, 250000000, time.UTC))
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	mock, assertCalled := cltest.NewHTTPMockServer(t, 200, "GET", `{"last":"212.54"}`)
+	defer assertCalled()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("timestamp", `{"key":"observedAt","format":"unixMillis"}`),
+		cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, mock.URL)),
+		cltest.NewTask("jsonparse", `{"path":["last"]}`),
+		cltest.NewTask("multiply", `{"times":100}`),
+		cltest.NewTask("ethuint256"),
+	}
+	assert.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	jr.Overrides = models.RunResult{Data: cltest.JSONFromString(`{"requestId":3405678900000000000001}`)}
+	assert.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunToComplete(t, s, jr)
+
+	assert.Equal(t, "1527854400250", jr.Result.Get("observedAt").Raw)
+	assert.Equal(t, "3405678900000000000001", jr.Result.Get("requestId").Raw)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000005306", jr.Result.Get("value").String())
+}
+
+func TestJobRunner_DoesNotLogOAuth2Token(t *testing.T) {
+	logs := cltest.ObserveLogs()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"do-not-log-this-token","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+	require.NoError(t, s.Save(&models.HTTPCredential{Name: "auth-server", URLPrefix: server.URL + "/", Username: "id", Password: "secret"}))
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("oauth2", fmt.Sprintf(`{"tokenURL":"%s","auth":"auth-server"}`, server.URL)),
+	}
+	assert.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	assert.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunToComplete(t, s, jr)
+	assert.Equal(t, "do-not-log-this-token", jr.Result.Get("value").String())
+
+	require.NotEmpty(t, logs.All())
+	for _, entry := range logs.All() {
+		line := fmt.Sprint(entry.Message, entry.ContextMap())
+		assert.False(t, strings.Contains(line, "do-not-log-this-token"), "token logged: %s", line)
+	}
+}
+
+func TestJobRunner_JSONParseNonObjectBodies(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	assert.NoError(t, rm.Start())
+
+	tests := []struct {
+		name  string
+		body  string
+		tasks []models.TaskSpec
+		want  string
+	}{
+		{"array root", `[[1546300800,"3.51"],[1546387200,"3.62"]]`, []models.TaskSpec{
+			cltest.NewTask("jsonparse", `{"path":["-1","1"]}`),
+			cltest.NewTask("multiply", `{"times":100}`),
+		}, `"362"`},
+		{"array root with jsonPath", `[{"price":3.51},{"price":3.62}]`, []models.TaskSpec{
+			cltest.NewTask("jsonparse", `{"jsonPath":"1.price"}`),
+		}, `"3.62"`},
+		{"number root", `3.62`, []models.TaskSpec{
+			cltest.NewTask("jsonparse", `{"path":[]}`),
+			cltest.NewTask("multiply", `{"times":100}`),
+		}, `"362"`},
+		{"string root", `"3.62"`, []models.TaskSpec{
+			cltest.NewTask("jsonparse", `{"path":[]}`),
+		}, `"3.62"`},
+		{"plain text", `3.62 USD`, []models.TaskSpec{
+			cltest.NewTask("jsonparse", `{"path":[]}`),
+		}, `"3.62 USD"`},
+	}
+
+	for _, test := range tests {
+		mock, assertCalled := cltest.NewHTTPMockServer(t, 200, "GET", test.body)
+		j, initr := cltest.NewJobWithWebInitiator()
+		j.Tasks = append([]models.TaskSpec{cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, mock.URL))}, test.tasks...)
+		assert.NoError(t, s.SaveJob(&j))
+		jr := j.NewRun(initr)
+		assert.NoError(t, s.Save(&jr))
+
+		services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+		jr = cltest.WaitForJobRunToComplete(t, s, jr)
+		assert.Equal(t, test.want, jr.Result.Get("value").Raw, test.name)
+		assertCalled()
+		mock.Close()
+	}
+}
+
+// statusSequenceServer responds with each status in turn, repeating the last,
+// and counts the requests made to it.
+func statusSequenceServer(statuses ...int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&requests, 1))
+		if n > len(statuses) {
+			n = len(statuses)
+		}
+		w.WriteHeader(statuses[n-1])
+		w.Write([]byte(`{"last":"212.54"}`))
+	}))
+	return server, &requests
+}
+
+func TestJobRunner_RetriesFailingTask(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	s.Config.HTTPRetryAttempts = 1
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	require.NoError(t, rm.Start())
+
+	server, requests := statusSequenceServer(500, 503, 200)
+	defer server.Close()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	task := cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, server.URL))
+	task.MaxRetries = 2
+	j.Tasks = []models.TaskSpec{task, cltest.NewTask("jsonparse", `{"path":["last"]}`)}
+	require.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	require.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunToComplete(t, s, jr)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+	assert.Equal(t, "212.54", jr.Result.Get("value").String())
+	assert.Equal(t, uint64(3), jr.TaskRuns[0].Attempts)
+	require.Len(t, jr.TaskRuns[0].ErrorHistory, 2)
+	assert.Contains(t, jr.TaskRuns[0].ErrorHistory[0], "status code 500")
+	assert.Contains(t, jr.TaskRuns[0].ErrorHistory[1], "status code 503")
+	assert.False(t, jr.TaskRuns[0].Result.HasError())
+	assert.Equal(t, uint64(1), jr.TaskRuns[1].Attempts)
+}
+
+func TestJobRunner_RetriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	s.Config.HTTPRetryAttempts = 1
+	rm, cleanup := cltest.NewJobRunner(s)
+	defer cleanup()
+	require.NoError(t, rm.Start())
+
+	server, requests := statusSequenceServer(500, 502)
+	defer server.Close()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	task := cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, server.URL))
+	task.MaxRetries = 1
+	j.Tasks = []models.TaskSpec{task}
+	require.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	require.NoError(t, s.Save(&jr))
+
+	services.ExportedChannelForRun(rm, jr.ID) <- struct{}{}
+	jr = cltest.WaitForJobRunStatus(t, s, jr, models.RunStatusErrored)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+	assert.Equal(t, uint64(2), jr.TaskRuns[0].Attempts)
+	assert.Contains(t, jr.Result.Error(), "failed after 2 attempts")
+	assert.Contains(t, jr.Result.Error(), "attempt 1: ")
+	assert.Contains(t, jr.Result.Error(), "status code 500")
+	assert.Contains(t, jr.Result.Error(), "attempt 2: ")
+	assert.Contains(t, jr.Result.Error(), "status code 502")
+	assert.Equal(t, jr.Result.Error(), jr.TaskRuns[0].Result.Error())
+}
+
+func TestJobRunner_PermanentErrorNotRetried(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	s.Config.HTTPRetryAttempts = 1
+
+	server, requests := statusSequenceServer(404, 200)
+	defer server.Close()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	task := cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, server.URL))
+	task.MaxRetries = 3
+	j.Tasks = []models.TaskSpec{task}
+	require.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	require.NoError(t, s.Save(&jr))
+
+	run, err := services.ExportedExecuteRunAtBlock(&jr, s, models.RunResult{})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RunStatusErrored, run.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	assert.Equal(t, uint64(1), run.TaskRuns[0].Attempts)
+	assert.Empty(t, run.TaskRuns[0].ErrorHistory)
+	assert.Contains(t, run.Result.Error(), "status code 404")
+	assert.NotContains(t, run.Result.Error(), "attempt")
+}
+
+func TestJobRunner_RetryDelay(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	s.Config.HTTPRetryAttempts = 1
+	clock := cltest.UseSettableClock(s)
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetTime(now)
+
+	server, _ := statusSequenceServer(500, 200)
+	defer server.Close()
+
+	j, initr := cltest.NewJobWithWebInitiator()
+	task := cltest.NewTask("httpget", fmt.Sprintf(`{"url":"%s"}`, server.URL))
+	task.MaxRetries = 1
+	task.RetryDelay = models.Duration(time.Minute)
+	j.Tasks = []models.TaskSpec{task}
+	require.NoError(t, s.SaveJob(&j))
+	jr := j.NewRun(initr)
+	require.NoError(t, s.Save(&jr))
+
+	run, err := services.ExportedExecuteRunAtBlock(&jr, s, models.RunResult{})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.RunStatusPendingRetry, run.Status)
+	assert.Equal(t, models.RunStatusPendingRetry, run.TaskRuns[0].Status)
+	assert.Equal(t, now.Add(time.Minute), run.TaskRuns[0].RetryAt.Time)
+	assert.Equal(t, uint64(1), run.TaskRuns[0].Attempts)
+	require.Len(t, run.TaskRuns[0].ErrorHistory, 1)
+
+	// The settable clock's After does not wait, so the retry is due at once.
+	runRequest, open := <-s.RunChannel.Receive()
+	assert.True(t, open)
+	assert.Equal(t, run.ID, runRequest.ID)
+
+	saved, err := s.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusInProgress, saved.Status)
+
+	run, err = services.ExportedExecuteRunAtBlock(&saved, s, models.RunResult{})
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusCompleted, run.Status)
+	assert.Equal(t, uint64(2), run.TaskRuns[0].Attempts)
+}
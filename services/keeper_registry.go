@@ -0,0 +1,257 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// Function selectors of the KeeperRegistry methods the KeeperRegistry
+// service calls.
+// See https://github.com/smartcontractkit/chainlink/blob/master/evm-contracts/src/v0.7/KeeperRegistry.sol
+var (
+	keeperRegistryCheckUpkeep   = models.HexToFunctionSelector("0xc41b813a") // checkUpkeep(uint256,address)
+	keeperRegistryPerformUpkeep = models.HexToFunctionSelector("0x7bbaf1ea") // performUpkeep(uint256,bytes)
+)
+
+// errUpkeepNotNeeded is what check returns when the registry's checkUpkeep
+// reverts.
+var errUpkeepNotNeeded = errors.New("checkUpkeep reverted")
+
+// executionRevertedRegex matches the errors geth and parity return from
+// eth_call for a call which reverted.
+var executionRevertedRegex = regexp.MustCompile(`(?i)(execution reverted|vm execution error|^reverted)`)
+
+// KeeperRegistryGasOverhead is the gas a KeeperRegistry uses to perform an
+// upkeep, on top of the gas limit of the upkeep itself.
+const KeeperRegistryGasOverhead = 80000
+
+// KeeperRegistry checks the node's upkeeps on every new head, calling
+// checkUpkeep on their KeeperRegistry contracts, and performs those which
+// need it. The registry's checkUpkeep reverts when an upkeep is not needed,
+// and otherwise returns the performData to perform it with and the most LINK
+// the registry will pay for it. Upkeeps whose payment is worth less ETH than
+// their gas costs at ETH_GAS_PRICE_DEFAULT are skipped. An upkeep is not
+// checked again until MIN_OUTGOING_CONFIRMATIONS blocks after it was
+// performed, so that the registry has seen the performance.
+type KeeperRegistry struct {
+	store *store.Store
+	heads chan uint64
+	done  chan struct{}
+	wg    sync.WaitGroup
+	// performedAt holds the head at which each upkeep was last performed.
+	performedAt map[string]uint64
+}
+
+// upkeepCheck is what checkUpkeep returns for an upkeep which is needed.
+type upkeepCheck struct {
+	PerformData    []byte
+	MaxLinkPayment *big.Int
+	GasLimit       uint64
+	AdjustedGasWei *big.Int
+	LinkEth        *big.Int
+}
+
+// NewKeeperRegistry returns a KeeperRegistry, which checks upkeeps once
+// connected.
+func NewKeeperRegistry(store *store.Store) *KeeperRegistry {
+	return &KeeperRegistry{
+		store:       store,
+		heads:       make(chan uint64, 1),
+		performedAt: map[string]uint64{},
+	}
+}
+
+// Connect starts checking upkeeps for each new head.
+func (kr *KeeperRegistry) Connect(*models.IndexableBlockNumber) error {
+	kr.done = make(chan struct{})
+	kr.wg.Add(1)
+	go kr.checkLoop(kr.done)
+	return nil
+}
+
+// Disconnect stops checking upkeeps, once those being checked are done.
+func (kr *KeeperRegistry) Disconnect() {
+	if kr.done == nil {
+		return
+	}
+	close(kr.done)
+	kr.done = nil
+	kr.wg.Wait()
+}
+
+// OnNewHead checks every upkeep for the head, unless the upkeeps are still
+// being checked for an earlier one and another is waiting to be.
+func (kr *KeeperRegistry) OnNewHead(head *models.BlockHeader) {
+	number := head.Number.ToInt().Uint64()
+	select {
+	case kr.heads <- number:
+	default:
+		logger.Debugw(fmt.Sprintf("Still checking upkeeps, so not checking them for head %d", number), "head", number)
+	}
+}
+
+func (kr *KeeperRegistry) checkLoop(done chan struct{}) {
+	defer kr.wg.Done()
+	for {
+		select {
+		case <-done:
+			return
+		case head := <-kr.heads:
+			kr.checkUpkeeps(head)
+		}
+	}
+}
+
+func (kr *KeeperRegistry) checkUpkeeps(head uint64) {
+	upkeeps, err := kr.store.Upkeeps()
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to load upkeeps: %v", err), "err", err)
+		return
+	}
+
+	confirmations := kr.store.CurrentConfig().MinOutgoingConfirmations
+	performedAt := map[string]uint64{}
+	for _, upkeep := range upkeeps {
+		if at, ok := kr.performedAt[upkeep.ID]; ok && head < at+confirmations {
+			performedAt[upkeep.ID] = at
+			continue
+		}
+		if kr.checkUpkeep(upkeep) {
+			performedAt[upkeep.ID] = head
+		}
+	}
+	// Upkeeps which were removed are forgotten.
+	kr.performedAt = performedAt
+}
+
+// checkUpkeep performs the upkeep if it is needed and profitable, returning
+// whether it was performed.
+func (kr *KeeperRegistry) checkUpkeep(upkeep models.Upkeep) bool {
+	check, err := kr.check(upkeep)
+	if err == errUpkeepNotNeeded {
+		logger.Debugw(fmt.Sprintf("Upkeep %d is not needed", upkeep.UpkeepID), upkeepForLogger(upkeep)...)
+		return false
+	} else if err != nil {
+		logger.Warnw(fmt.Sprintf("Unable to check upkeep %d: %v", upkeep.UpkeepID, err), upkeepForLogger(upkeep, "err", err)...)
+		return false
+	}
+
+	gasLimit := check.GasLimit + KeeperRegistryGasOverhead
+	gasPrice := kr.store.CurrentConfig().EthGasPriceDefault
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), &gasPrice)
+	payment := new(big.Int).Mul(check.MaxLinkPayment, check.LinkEth)
+	payment.Quo(payment, big.NewInt(1e18))
+	if payment.Cmp(cost) < 0 {
+		logger.Infow(
+			fmt.Sprintf("Skipping upkeep %d, whose payment of %v wei in LINK is less than its gas cost of %v wei", upkeep.UpkeepID, payment, cost),
+			upkeepForLogger(upkeep, "payment", payment, "cost", cost)...)
+		return false
+	}
+
+	data, err := utils.ConcatBytes(
+		keeperRegistryPerformUpkeep.Bytes(),
+		utils.EVMEncodeTuple([]utils.EVMTupleElement{
+			{Encoded: utils.EVMWordUint64(upkeep.UpkeepID)},
+			{Encoded: evmBytes(check.PerformData), Dynamic: true},
+		}),
+	)
+	if err != nil {
+		logger.Errorw(err.Error(), upkeepForLogger(upkeep)...)
+		return false
+	}
+	tx, err := kr.store.TxManager.CreateTxWithGas(upkeep.RegistryAddress, data, nil, gasLimit)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to perform upkeep %d: %v", upkeep.UpkeepID, err), upkeepForLogger(upkeep)...)
+		return false
+	}
+	logger.Infow(fmt.Sprintf("Performed upkeep %d", upkeep.UpkeepID), upkeepForLogger(upkeep, "tx", tx.Hash.Hex())...)
+	return true
+}
+
+// check calls checkUpkeep on the upkeep's registry, returning
+// errUpkeepNotNeeded when it reverts.
+func (kr *KeeperRegistry) check(upkeep models.Upkeep) (upkeepCheck, error) {
+	account := kr.store.TxManager.GetActiveAccount()
+	if account == nil {
+		return upkeepCheck{}, errors.New("no account to perform upkeeps from")
+	}
+	data, err := utils.ConcatBytes(
+		keeperRegistryCheckUpkeep.Bytes(),
+		utils.EVMWordUint64(upkeep.UpkeepID),
+		common.LeftPadBytes(account.Address.Bytes(), utils.EVMWordByteLen),
+	)
+	if err != nil {
+		return upkeepCheck{}, err
+	}
+	output, err := kr.store.TxManager.CallContract(upkeep.RegistryAddress, data, "latest")
+	if err != nil && executionRevertedRegex.MatchString(err.Error()) {
+		return upkeepCheck{}, errUpkeepNotNeeded
+	} else if err != nil {
+		return upkeepCheck{}, err
+	}
+	// Before 1.9.15, geth returns no output rather than an error for a call
+	// which reverted.
+	if len(output) == 0 {
+		return upkeepCheck{}, errUpkeepNotNeeded
+	}
+	return parseUpkeepCheck(output)
+}
+
+// parseUpkeepCheck decodes the (bytes performData, uint256 maxLinkPayment,
+// uint256 gasLimit, uint256 adjustedGasWei, uint256 linkEth) returned by
+// checkUpkeep.
+func parseUpkeepCheck(output []byte) (upkeepCheck, error) {
+	const headLen = 5 * utils.EVMWordByteLen
+	if len(output) < headLen+utils.EVMWordByteLen {
+		return upkeepCheck{}, fmt.Errorf("checkUpkeep returned %d bytes, too few for its results", len(output))
+	}
+	word := func(i int) *big.Int {
+		return new(big.Int).SetBytes(output[i*utils.EVMWordByteLen : (i+1)*utils.EVMWordByteLen])
+	}
+
+	offset := word(0)
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(output)-utils.EVMWordByteLen) {
+		return upkeepCheck{}, fmt.Errorf("checkUpkeep returned performData at offset %v, past its end", offset)
+	}
+	start := offset.Uint64() + utils.EVMWordByteLen
+	length := new(big.Int).SetBytes(output[offset.Uint64():start])
+	if !length.IsUint64() || length.Uint64() > uint64(len(output))-start {
+		return upkeepCheck{}, fmt.Errorf("checkUpkeep returned %v bytes of performData, past its end", length)
+	}
+	gasLimit := word(2)
+	if !gasLimit.IsUint64() {
+		return upkeepCheck{}, fmt.Errorf("checkUpkeep returned a gas limit of %v", gasLimit)
+	}
+
+	return upkeepCheck{
+		PerformData:    output[start : start+length.Uint64()],
+		MaxLinkPayment: word(1),
+		GasLimit:       gasLimit.Uint64(),
+		AdjustedGasWei: word(3),
+		LinkEth:        word(4),
+	}, nil
+}
+
+// evmBytes encodes b as the tail of dynamic bytes in an EVM tuple.
+func evmBytes(b []byte) []byte {
+	padded := (len(b) + utils.EVMWordByteLen - 1) / utils.EVMWordByteLen * utils.EVMWordByteLen
+	return append(utils.EVMWordUint64(uint64(len(b))), common.RightPadBytes(b, padded)...)
+}
+
+func upkeepForLogger(upkeep models.Upkeep, kvs ...interface{}) []interface{} {
+	output := []interface{}{
+		"upkeep", upkeep.ID,
+		"upkeep_id", upkeep.UpkeepID,
+		"registry", upkeep.RegistryAddress.Hex(),
+	}
+	return append(output, kvs...)
+}
@@ -0,0 +1,174 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// simulatedUpkeep is how a KeeperRegistry contract answers checkUpkeep for
+// one upkeep. Upkeeps which are not needed revert.
+type simulatedUpkeep struct {
+	needed         bool
+	performData    []byte
+	maxLinkPayment *big.Int
+	gasLimit       uint64
+}
+
+// simulatedRegistry answers checkUpkeep calls as a KeeperRegistry contract
+// with the upkeeps would, paying at 0.01 ETH per LINK.
+type simulatedRegistry map[uint64]simulatedUpkeep
+
+func (sr simulatedRegistry) register(t *testing.T, eth *cltest.EthMock, calls int, from common.Address) {
+	for i := 0; i < calls; i++ {
+		eth.Register("eth_call", hexutil.Bytes{}, func(result interface{}, args ...interface{}) error {
+			b, err := json.Marshal(args[0].([]interface{})[0])
+			require.NoError(t, err)
+			var call struct{ Data hexutil.Bytes }
+			require.NoError(t, json.Unmarshal(b, &call))
+			require.Len(t, call.Data, 4+2*utils.EVMWordByteLen)
+			require.Equal(t, "0xc41b813a", hexutil.Encode(call.Data[:4])) // checkUpkeep(uint256,address)
+			assert.Equal(t, from, common.BytesToAddress(call.Data[36:68]))
+
+			upkeep, ok := sr[new(big.Int).SetBytes(call.Data[4:36]).Uint64()]
+			if !ok || !upkeep.needed {
+				return errors.New("execution reverted: upkeep not needed")
+			}
+			payment, err := utils.EVMWordBigInt(upkeep.maxLinkPayment)
+			require.NoError(t, err)
+			linkEth, err := utils.EVMWordBigInt(big.NewInt(1e16))
+			require.NoError(t, err)
+			*result.(*hexutil.Bytes) = utils.EVMEncodeTuple([]utils.EVMTupleElement{
+				{Encoded: append(utils.EVMWordUint64(uint64(len(upkeep.performData))), common.RightPadBytes(upkeep.performData, utils.EVMWordByteLen)...), Dynamic: true},
+				{Encoded: payment},
+				{Encoded: utils.EVMWordUint64(upkeep.gasLimit)},
+				{Encoded: utils.EVMWordUint64(20000000000)},
+				{Encoded: linkEth},
+			})
+			return nil
+		})
+	}
+}
+
+// expectPerformUpkeep registers the transaction performing the upkeep with
+// performData.
+func expectPerformUpkeep(t *testing.T, eth *cltest.EthMock, registry common.Address, upkeepID uint64, performData []byte, gasLimit uint64) {
+	eth.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	eth.Register("eth_sendRawTransaction", cltest.NewHash(), func(_ interface{}, data ...interface{}) error {
+		tx, err := utils.DecodeEthereumTx(data[0].([]interface{})[0].(string))
+		require.NoError(t, err)
+		assert.Equal(t, registry, *tx.To())
+		assert.Equal(t, gasLimit+services.KeeperRegistryGasOverhead, tx.Gas())
+		want := append(hexutil.MustDecode("0x7bbaf1ea"), utils.EVMWordUint64(upkeepID)...)
+		want = append(want, utils.EVMWordUint64(64)...)
+		want = append(want, utils.EVMWordUint64(uint64(len(performData)))...)
+		want = append(want, common.RightPadBytes(performData, utils.EVMWordByteLen)...)
+		assert.Equal(t, hexutil.Encode(want), hexutil.Encode(tx.Data()))
+		return nil
+	})
+}
+
+func TestKeeperRegistry_PerformsNeededProfitableUpkeeps(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	require.NoError(t, app.Start())
+	eth.EventuallyAllCalled(t)
+
+	registry := cltest.NewAddress()
+	for id := uint64(1); id <= 3; id++ {
+		upkeep, err := models.NewUpkeep(models.UpkeepRequest{RegistryAddress: registry, UpkeepID: id})
+		require.NoError(t, err)
+		require.NoError(t, store.Save(&upkeep))
+	}
+	// At 20 gwei, 100000 gas and the registry's overhead cost 0.0036 ETH.
+	// 1 LINK is worth 0.01 ETH, so is profitable, and 0.1 LINK is not.
+	sim := simulatedRegistry{
+		1: {needed: true, performData: []byte{0xca, 0xfe}, maxLinkPayment: big.NewInt(1e18), gasLimit: 100000},
+		2: {needed: false},
+		3: {needed: true, performData: []byte{0x01}, maxLinkPayment: big.NewInt(1e17), gasLimit: 100000},
+	}
+	from := cltest.GetAccountAddress(store)
+
+	sim.register(t, eth, 3, from)
+	expectPerformUpkeep(t, eth, registry, 1, []byte{0xca, 0xfe}, 100000)
+	app.KeeperRegistry.OnNewHead(cltest.NewBlockHeader(10))
+	eth.EventuallyAllCalled(t)
+
+	// The performed upkeep is not checked again until the transaction has
+	// MIN_OUTGOING_CONFIRMATIONS.
+	sim.register(t, eth, 2, from)
+	app.KeeperRegistry.OnNewHead(cltest.NewBlockHeader(10 + int(store.Config.MinOutgoingConfirmations) - 1))
+	eth.EventuallyAllCalled(t)
+
+	sim[1] = simulatedUpkeep{needed: false}
+	sim.register(t, eth, 3, from)
+	app.KeeperRegistry.OnNewHead(cltest.NewBlockHeader(10 + int(store.Config.MinOutgoingConfirmations)))
+	eth.EventuallyAllCalled(t)
+
+	txs := []models.Tx{}
+	require.NoError(t, store.Where("From", from, &txs))
+	assert.Len(t, txs, 1)
+}
+
+func TestKeeperRegistry_LogsCheckErrors(t *testing.T) {
+	logs := cltest.ObserveLogs()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	require.NoError(t, app.Start())
+	eth.EventuallyAllCalled(t)
+
+	upkeep, err := models.NewUpkeep(models.UpkeepRequest{RegistryAddress: cltest.NewAddress(), UpkeepID: 1})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&upkeep))
+
+	simulatedRegistry{1: {needed: false}}.register(t, eth, 1, cltest.GetAccountAddress(store))
+	app.KeeperRegistry.OnNewHead(cltest.NewBlockHeader(10))
+	eth.EventuallyAllCalled(t)
+
+	eth.Register("eth_call", hexutil.Bytes{}, func(interface{}, ...interface{}) error {
+		return errors.New("connection refused")
+	})
+	app.KeeperRegistry.OnNewHead(cltest.NewBlockHeader(11))
+	eth.EventuallyAllCalled(t)
+
+	unableToCheck := func() []observer.LoggedEntry {
+		var entries []observer.LoggedEntry
+		for _, log := range logs.All() {
+			if strings.HasPrefix(log.Message, "Unable to check upkeep 1") {
+				entries = append(entries, log)
+			}
+		}
+		return entries
+	}
+	gomega.NewGomegaWithT(t).Eventually(unableToCheck).Should(gomega.HaveLen(1))
+	unable := unableToCheck()[0]
+	assert.Equal(t, zapcore.WarnLevel, unable.Level)
+	assert.Contains(t, unable.Message, "connection refused")
+
+	notNeeded := logs.FilterMessage("Upkeep 1 is not needed").All()
+	require.Len(t, notNeeded, 1)
+	assert.Equal(t, zapcore.DebugLevel, notNeeded[0].Level)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// ReorgDetectionDepth is how many of the latest blocks the ReorgDetector
+// remembers, and so the deepest reorganization it can detect.
+const ReorgDetectionDepth = 128
+
+// ReorgDetector remembers the hashes of the latest heads, and when a new head
+// does not follow on from them, errors the unfinished runs triggered by logs
+// in the blocks the chain reorganization removed, going by their hashes. Logs which are included
+// again in the new chain are delivered again by the log subscriptions,
+// triggering new runs.
+type ReorgDetector struct {
+	store  *store.Store
+	depth  int
+	heads  map[uint64]common.Hash
+	mutex  sync.Mutex
+	blocks []uint64
+}
+
+// NewReorgDetector returns a ReorgDetector remembering depth heads.
+func NewReorgDetector(store *store.Store, depth int) *ReorgDetector {
+	return &ReorgDetector{
+		store: store,
+		depth: depth,
+		heads: map[uint64]common.Hash{},
+	}
+}
+
+// Connect does nothing, keeping the heads seen before any disconnection.
+func (rd *ReorgDetector) Connect(*models.IndexableBlockNumber) error {
+	return nil
+}
+
+// Disconnect does nothing.
+func (rd *ReorgDetector) Disconnect() {}
+
+// OnNewHead checks the head against the heads before it, invalidating runs
+// triggered in any blocks it replaces.
+func (rd *ReorgDetector) OnNewHead(head *models.BlockHeader) {
+	rd.mutex.Lock()
+	defer rd.mutex.Unlock()
+
+	number := head.Number.ToInt().Uint64()
+	hash := head.Hash()
+	if seen, ok := rd.heads[number]; ok && seen == hash {
+		return
+	}
+
+	from := rd.reorgedFrom(number, head.ParentHash)
+	if to, ok := rd.tip(); ok && from <= to {
+		logger.Warnw(fmt.Sprintf("Chain reorganization replaced blocks %d to %d", from, to),
+			"from", from, "to", to, "head", number, "hash", hash.Hex())
+		rd.invalidate(from, to, rd.hashesFrom(from))
+	}
+	rd.forgetFrom(from)
+	rd.remember(number, hash)
+}
+
+// reorgedFrom returns the first block which the head at number, with the
+// parent hash, replaces, asking the node for the parents of blocks that are
+// replaced until one matches a remembered head. Blocks which cannot be
+// checked are taken to be replaced.
+func (rd *ReorgDetector) reorgedFrom(number uint64, parent common.Hash) uint64 {
+	from := number
+	for from > 0 {
+		seen, ok := rd.heads[from-1]
+		if !ok || seen == parent {
+			break
+		}
+		from--
+		header, err := rd.store.TxManager.GetBlockByNumber(hexutil.EncodeUint64(from))
+		if err != nil {
+			logger.Warnw(fmt.Sprintf("Unable to get block %d while checking for a chain reorganization: %v", from, err), "err", err)
+			break
+		}
+		parent = header.ParentHash
+	}
+	return from
+}
+
+func (rd *ReorgDetector) invalidate(from, to uint64, removed []common.Hash) {
+	runs, err := rd.store.InvalidateRunsTriggeredInBlocks(removed)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to invalidate runs triggered in blocks %d to %d: %v", from, to, err), "err", err)
+		return
+	}
+	for _, run := range runs {
+		logger.Warnw("Errored run triggered in a block removed by a chain reorganization", run.ForLogger()...)
+		if err := rd.store.BroadcastRunStatus(run.StatusUpdate()); err != nil && err != store.ErrNoSubscribers {
+			logger.Warnw(fmt.Sprintf("Unable to broadcast run status: %v", err), run.ForLogger()...)
+		}
+	}
+}
+
+// hashesFrom returns the hashes of the heads remembered from block from on.
+func (rd *ReorgDetector) hashesFrom(from uint64) []common.Hash {
+	i := sort.Search(len(rd.blocks), func(i int) bool { return rd.blocks[i] >= from })
+	hashes := make([]common.Hash, 0, len(rd.blocks)-i)
+	for _, number := range rd.blocks[i:] {
+		hashes = append(hashes, rd.heads[number])
+	}
+	return hashes
+}
+
+// tip returns the highest head remembered.
+func (rd *ReorgDetector) tip() (uint64, bool) {
+	if len(rd.blocks) == 0 {
+		return 0, false
+	}
+	return rd.blocks[len(rd.blocks)-1], true
+}
+
+func (rd *ReorgDetector) forgetFrom(from uint64) {
+	i := sort.Search(len(rd.blocks), func(i int) bool { return rd.blocks[i] >= from })
+	for _, number := range rd.blocks[i:] {
+		delete(rd.heads, number)
+	}
+	rd.blocks = rd.blocks[:i]
+}
+
+func (rd *ReorgDetector) remember(number uint64, hash common.Hash) {
+	rd.heads[number] = hash
+	rd.blocks = append(rd.blocks, number)
+	if len(rd.blocks) > rd.depth {
+		delete(rd.heads, rd.blocks[0])
+		rd.blocks = rd.blocks[1:]
+	}
+}
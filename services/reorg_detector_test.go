@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chainOfHeads returns headers for the blocks from start to end, each the
+// child of the one before and the first the child of parent.
+func chainOfHeads(parent common.Hash, start, end int) []models.BlockHeader {
+	var heads []models.BlockHeader
+	for n := start; n <= end; n++ {
+		head := *cltest.NewBlockHeader(n)
+		head.ParentHash = parent
+		head.GethHash = cltest.NewHash()
+		heads = append(heads, head)
+		parent = head.GethHash
+	}
+	return heads
+}
+
+func TestReorgDetector_OnNewHead_InvalidatesReorgedRuns(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	eth := cltest.MockEthOnStore(store)
+
+	j, initr := cltest.NewJobWithLogInitiator()
+	require.NoError(t, store.SaveJob(&j))
+	saveRun := func(block models.BlockHeader, status models.RunStatus) models.JobRun {
+		run := j.NewRun(initr)
+		run.Status = status
+		run.CreationHeight = &block.Number
+		hash := block.Hash()
+		run.CreationHash = &hash
+		require.NoError(t, store.Save(&run))
+		return run
+	}
+
+	original := chainOfHeads(cltest.NewHash(), 9, 12)
+	detector := services.NewReorgDetector(store, 10)
+	for i := range original {
+		detector.OnNewHead(&original[i])
+	}
+
+	survivor := saveRun(original[1], models.RunStatusPendingConfirmations)
+	reorged := saveRun(original[2], models.RunStatusPendingConfirmations)
+	reorgedTip := saveRun(original[3], models.RunStatusInProgress)
+	completed := saveRun(original[2], models.RunStatusCompleted)
+	noLogRun := j.NewRun(initr)
+	noLogRun.Status = models.RunStatusPendingConfirmations
+	noLogRun.CreationHeight = &original[2].Number
+	require.NoError(t, store.Save(&noLogRun))
+
+	// Blocks 11 and 12 are replaced by a longer chain branching from 10.
+	replacement := chainOfHeads(original[1].Hash(), 11, 13)
+	included := saveRun(replacement[0], models.RunStatusPendingConfirmations)
+	eth.Register("eth_getBlockByNumber", replacement[1])
+	eth.Register("eth_getBlockByNumber", replacement[0])
+	detector.OnNewHead(&replacement[2])
+	eth.EventuallyAllCalled(t)
+
+	status := func(run models.JobRun) models.RunStatus {
+		found, err := store.FindJobRun(run.ID)
+		require.NoError(t, err)
+		return found.Status
+	}
+	assert.Equal(t, models.RunStatusPendingConfirmations, status(survivor))
+	assert.Equal(t, models.RunStatusErrored, status(reorged))
+	assert.Equal(t, models.RunStatusErrored, status(reorgedTip))
+	assert.Equal(t, models.RunStatusCompleted, status(completed))
+	assert.Equal(t, models.RunStatusPendingConfirmations, status(noLogRun), "runs not triggered by a log are kept")
+	assert.Equal(t, models.RunStatusPendingConfirmations, status(included), "runs triggered in the new chain are kept")
+
+	found, err := store.FindJobRun(reorged.ID)
+	require.NoError(t, err)
+	assert.Contains(t, found.Result.Error(), "which a chain reorganization removed")
+	assert.Equal(t, models.RunStatusErrored, found.TaskRuns[0].Status)
+
+	// The replacement chain is now followed without any further reorg.
+	next := chainOfHeads(replacement[2].Hash(), 14, 14)
+	reorgedAgain := saveRun(replacement[2], models.RunStatusPendingConfirmations)
+	detector.OnNewHead(&next[0])
+	assert.Equal(t, models.RunStatusPendingConfirmations, status(reorgedAgain))
+}
+
+func TestReorgDetector_OnNewHead_SameHeightReplacement(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	cltest.MockEthOnStore(store)
+
+	j, initr := cltest.NewJobWithLogInitiator()
+	require.NoError(t, store.SaveJob(&j))
+
+	original := chainOfHeads(cltest.NewHash(), 1, 3)
+	detector := services.NewReorgDetector(store, 10)
+	for i := range original {
+		detector.OnNewHead(&original[i])
+	}
+
+	run := j.NewRun(initr)
+	run.Status = models.RunStatusPendingConfirmations
+	run.CreationHeight = &original[2].Number
+	hash := original[2].Hash()
+	run.CreationHash = &hash
+	require.NoError(t, store.Save(&run))
+
+	detector.OnNewHead(&original[2])
+	found, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusPendingConfirmations, found.Status, "seeing a head again is not a reorg")
+
+	uncle := chainOfHeads(original[1].Hash(), 3, 3)
+	detector.OnNewHead(&uncle[0])
+	found, err = store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusErrored, found.Status)
+}
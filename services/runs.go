@@ -1,12 +1,17 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/metrics"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
@@ -14,16 +19,21 @@ import (
 )
 
 // ExecuteJob saves and immediately begins executing a run for a specified job
-// if it is ready.
+// if it is ready. Runs initiated by a log are given the block holding it,
+// from which each task's confirmations are counted.
 func ExecuteJob(
 	job models.JobSpec,
 	initiator models.Initiator,
 	input models.RunResult,
-	creationHeight *hexutil.Big,
+	creationBlock *models.IndexableBlockNumber,
 	store *store.Store) (*models.JobRun, error) {
 
+	var creationHeight *hexutil.Big
+	if creationBlock != nil {
+		creationHeight = &creationBlock.Number
+	}
 	logger.Debugw(fmt.Sprintf("New run triggered by %s", initiator.Type), []interface{}{
-		"job", job.ID,
+		"job_id", job.ID,
 		"input_status", input.Status,
 		"creation_height", creationHeight.ToInt(),
 	}...)
@@ -32,6 +42,9 @@ func ExecuteJob(
 	if err != nil {
 		return nil, err
 	}
+	if creationBlock != nil {
+		run.CreationHash = &creationBlock.Hash
+	}
 
 	return run, saveAndTrigger(run, store)
 }
@@ -79,7 +92,7 @@ func NewRun(
 
 		if currentHeight != nil {
 			run.TaskRuns[i].MinimumConfirmations = utils.MaxUint64(
-				store.Config.MinIncomingConfirmations,
+				store.CurrentConfig().MinIncomingConfirmations,
 				taskRun.Task.Confirmations,
 				adapter.MinConfs())
 		}
@@ -89,8 +102,8 @@ func NewRun(
 	if input.Amount != nil {
 		if cost.Cmp(input.Amount) > 0 {
 			logger.Debugw("Rejecting run with insufficient payment", []interface{}{
-				"run", run.ID,
-				"job", run.JobID,
+				"run_id", run.ID,
+				"job_id", run.JobID,
 				"input_amount", input.Amount,
 				"required_amount", cost,
 			}...)
@@ -145,15 +158,15 @@ func ResumeConfirmingTask(
 
 	if meetsMinimumConfirmations(run, currentTaskRun, run.ObservedHeight) {
 		logger.Debugw("Minimum confirmations met, resuming job", []interface{}{
-			"run", run.ID,
-			"job", run.JobID,
+			"run_id", run.ID,
+			"job_id", run.JobID,
 			"observed_height", currentBlockHeight,
 		}...)
 		run.Status = models.RunStatusInProgress
 	} else {
 		logger.Debugw("Insufficient confirmations to wake job", []interface{}{
-			"run", run.ID,
-			"job", run.JobID,
+			"run_id", run.ID,
+			"job_id", run.JobID,
 			"observed_height", currentBlockHeight,
 		}...)
 		run.Status = models.RunStatusPendingConfirmations
@@ -172,8 +185,8 @@ func ResumePendingTask(
 ) (*models.JobRun, error) {
 
 	logger.Debugw("External adapter resuming job", []interface{}{
-		"run", run.ID,
-		"job", run.JobID,
+		"run_id", run.ID,
+		"job_id", run.JobID,
 		"status", run.Status,
 		"input_data", input.Data,
 		"input_result", input.Status,
@@ -193,7 +206,7 @@ func ResumePendingTask(
 	if run.Overrides, err = run.Overrides.Merge(input); err != nil {
 		run.TaskRuns[currentTaskRunIndex] = currentTaskRun.ApplyResult(input.WithError(err))
 		*run = run.ApplyResult(input.WithError(err))
-		return run, store.Save(run)
+		return run, saveRun(run, store)
 	}
 
 	currentTaskRun = currentTaskRun.ApplyResult(input)
@@ -207,9 +220,82 @@ func ResumePendingTask(
 	return run, saveAndTrigger(run, store)
 }
 
+// bridgeResumeMutex serializes callbacks from bridges, so that only the first
+// of several callbacks for the same run resumes it.
+var bridgeResumeMutex sync.Mutex
+
+// ResumePendingBridgeRun reloads the run and resumes it with the input from
+// its bridge, returning orm.ErrorNotPendingBridge if the run is no longer
+// pending, for example because an earlier callback already resumed it.
+func ResumePendingBridgeRun(
+	runID string,
+	store *store.Store,
+	input models.RunResult,
+) (*models.JobRun, error) {
+	bridgeResumeMutex.Lock()
+	defer bridgeResumeMutex.Unlock()
+
+	run, err := store.FindPendingBridgeRun(runID)
+	if err != nil {
+		return nil, err
+	}
+	return ResumePendingTask(&run, store, input)
+}
+
+// reviewMutex serializes reviews, so that a run held for review is only
+// resolved once.
+var reviewMutex sync.Mutex
+
+// ResolvePendingReviewRun resolves a run held for review by a task such as
+// Compare. An approved run continues with its remaining tasks, passing on the
+// held task's result, and a rejected run errors. It returns
+// orm.ErrorNotPendingReview if the run is not held for review, for example
+// because it has already been resolved.
+func ResolvePendingReviewRun(
+	runID string,
+	store *store.Store,
+	approved bool,
+) (*models.JobRun, error) {
+	reviewMutex.Lock()
+	defer reviewMutex.Unlock()
+
+	run, err := store.FindPendingReviewRun(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infow(fmt.Sprintf("Resolving run held for review, approved: %v", approved), run.ForLogger()...)
+
+	currentTaskRunIndex, ok := run.NextTaskRunIndex()
+	if !ok {
+		return &run, fmt.Errorf("Attempting to resolve run pending review with no remaining tasks %s", run.ID)
+	}
+	currentTaskRun := run.TaskRuns[currentTaskRunIndex]
+
+	result := currentTaskRun.Result
+	if approved {
+		result.Status = models.RunStatusCompleted
+	} else {
+		result = result.WithError(errors.New("Rejected on review"))
+	}
+
+	currentTaskRun = currentTaskRun.ApplyResult(result)
+	run.TaskRuns[currentTaskRunIndex] = currentTaskRun
+	if currentTaskRun.Status.Completed() && run.TasksRemain() {
+		run = *queueNextTask(&run, store)
+	} else {
+		run = run.ApplyResult(result)
+	}
+
+	return &run, saveAndTrigger(&run, store)
+}
+
 // QueueSleepingTask creates a go routine which will wake up the job runner
-// once the sleep's time has elapsed
+// once the sleep's time has elapsed, unless ctx is done first. A run whose
+// sleep is interrupted stays pending, and is queued again when the node
+// restarts.
 func QueueSleepingTask(
+	ctx context.Context,
 	run *models.JobRun,
 	store *store.Store,
 ) (*models.JobRun, error) {
@@ -232,24 +318,30 @@ func QueueSleepingTask(
 	if err != nil {
 		run.TaskRuns[currentTaskRunIndex] = currentTaskRun.ApplyResult(run.Result.WithError(err))
 		*run = run.ApplyResult(run.Result.WithError(err))
-		return run, store.Save(run)
+		return run, saveRun(run, store)
 	}
 
 	if sleepAdapter, ok := adapter.BaseAdapter.(*adapters.Sleep); ok {
-		return run, performTaskSleep(run, &currentTaskRun, currentTaskRunIndex, sleepAdapter, store)
+		return run, performTaskSleep(ctx, run, &currentTaskRun, currentTaskRunIndex, sleepAdapter, store)
 	}
 
 	return run, fmt.Errorf("Attempting to resume non sleeping task for run %s (%s)", run.ID, currentTaskRun.Task.Type)
 }
 
 func performTaskSleep(
+	ctx context.Context,
 	run *models.JobRun,
 	task *models.TaskRun,
 	currentTaskRunIndex int,
 	adapter *adapters.Sleep,
 	store *store.Store) error {
 
-	duration := adapter.Duration()
+	if task.SleepUntil.IsZero() {
+		task.SleepUntil = models.NewMillisecondTime(adapter.WakeTime(store.Clock.Now()))
+		run.TaskRuns[currentTaskRunIndex] = *task
+	}
+
+	duration := task.SleepUntil.Sub(store.Clock.Now())
 	if duration <= 0 {
 		logger.Debugw("Sleep duration has already elapsed, completing task", run.ForLogger()...)
 		task.Status = models.RunStatusCompleted
@@ -258,29 +350,81 @@ func performTaskSleep(
 		return saveAndTrigger(run, store)
 	}
 
+	if err := saveRun(run, store); err != nil {
+		return err
+	}
+	completed := *task
+	completed.Status = models.RunStatusCompleted
+	wakeRunAfter(ctx, run, duration, store, "sleep", func(run *models.JobRun) {
+		run.TaskRuns[currentTaskRunIndex] = completed
+	})
+	return nil
+}
+
+// QueueRetryingTask creates a go routine which will wake up the job runner
+// once the failed task is due to be attempted again, unless ctx is done
+// first. A run whose wait is interrupted stays pending, and is queued again
+// when the node restarts.
+func QueueRetryingTask(
+	ctx context.Context,
+	run *models.JobRun,
+	store *store.Store,
+) error {
+	if !run.Status.PendingRetry() {
+		return fmt.Errorf("Attempting to retry non retrying run %s", run.ID)
+	}
+
+	currentTaskRun := run.NextTaskRun()
+	if currentTaskRun == nil || !currentTaskRun.Status.PendingRetry() {
+		return fmt.Errorf("Attempting to retry run with no retrying task %s", run.ID)
+	}
+
+	delay := currentTaskRun.RetryAt.Time.Sub(store.Clock.Now())
+	if delay <= 0 {
+		logger.Debugw("Retry is due, resuming run", run.ForLogger()...)
+		run.Status = models.RunStatusInProgress
+		return saveAndTrigger(run, store)
+	}
+
+	wakeRunAfter(ctx, run, delay, store, "retry", func(*models.JobRun) {})
+	return nil
+}
+
+// wakeRunAfter creates a go routine which, once duration has elapsed, changes
+// a copy of the run with wake and triggers it, unless ctx is done first.
+func wakeRunAfter(
+	ctx context.Context,
+	run *models.JobRun,
+	duration time.Duration,
+	store *store.Store,
+	reason string,
+	wake func(*models.JobRun)) {
+
 	// XXX: This is to eliminate data race that occurs because slices share their
 	// underlying array even in copies
 	runCopy := *run
 	runCopy.TaskRuns = make([]models.TaskRun, len(run.TaskRuns))
 	copy(runCopy.TaskRuns, run.TaskRuns)
 
-	go func(run models.JobRun, task models.TaskRun) {
-		logger.Debugw("Task sleeping...", run.ForLogger()...)
+	go func(run models.JobRun) {
+		logger.Debugw(fmt.Sprintf("Run waiting for %s...", reason), run.ForLogger()...)
 
-		<-store.Clock.After(duration)
+		select {
+		case <-store.Clock.After(duration):
+		case <-ctx.Done():
+			logger.Debugw(fmt.Sprintf("Wait for %s interrupted, leaving run pending", reason), run.ForLogger()...)
+			return
+		}
 
-		task.Status = models.RunStatusCompleted
-		run.TaskRuns[currentTaskRunIndex] = task
+		wake(&run)
 		run.Status = models.RunStatusInProgress
 
-		logger.Debugw("Waking job up after sleep", run.ForLogger()...)
+		logger.Debugw(fmt.Sprintf("Waking run up after %s", reason), run.ForLogger()...)
 
 		if err := saveAndTrigger(&run, store); err != nil {
-			logger.Errorw("Error resuming sleeping job:", "error", err)
+			logger.Errorw(fmt.Sprintf("Error resuming run after %s:", reason), "error", err)
 		}
-	}(runCopy, *task)
-
-	return nil
+	}(runCopy)
 }
 
 func meetsMinimumConfirmations(
@@ -298,7 +442,7 @@ func meetsMinimumConfirmations(
 }
 
 func saveAndTrigger(run *models.JobRun, store *store.Store) error {
-	if err := store.Save(run); err != nil {
+	if err := saveRun(run, store); err != nil {
 		return err
 	}
 
@@ -311,6 +455,23 @@ func saveAndTrigger(run *models.JobRun, store *store.Store) error {
 	return nil
 }
 
+// saveRun saves the run, broadcasts its status to those watching runs, and
+// records it in the job run metrics once it has finished. A run which has
+// finished since it was read, such as one errored by a chain reorganization,
+// is not saved over.
+func saveRun(run *models.JobRun, str *store.Store) error {
+	if err := str.SaveJobRun(run); err != nil {
+		return err
+	}
+	if err := str.BroadcastRunStatus(run.StatusUpdate()); err != nil && err != store.ErrNoSubscribers {
+		logger.Warnw(fmt.Sprintf("Unable to broadcast run status: %v", err), run.ForLogger()...)
+	}
+	if run.Status.Finished() {
+		metrics.RecordJobRun(run.JobID, string(run.Status), time.Since(run.CreatedAt))
+	}
+	return nil
+}
+
 // RecurringScheduleJobError contains the field for the error message.
 type RecurringScheduleJobError struct {
 	msg string
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math"
 	"testing"
@@ -12,8 +13,10 @@ import (
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/orm"
 	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 	null "gopkg.in/guregu/null.v3"
 )
@@ -267,12 +270,12 @@ func TestQueueSleepingTask(t *testing.T) {
 
 	// reject a run with an invalid state
 	run := &models.JobRun{}
-	run, err := services.QueueSleepingTask(run, store)
+	run, err := services.QueueSleepingTask(context.Background(), run, store)
 	assert.Error(t, err)
 
 	// reject a run with no tasks
 	run = &models.JobRun{Status: models.RunStatusPendingSleep}
-	run, err = services.QueueSleepingTask(run, store)
+	run, err = services.QueueSleepingTask(context.Background(), run, store)
 	assert.Error(t, err)
 
 	// reject a run that is sleeping but its task is not
@@ -281,7 +284,7 @@ func TestQueueSleepingTask(t *testing.T) {
 		Status:   models.RunStatusPendingSleep,
 		TaskRuns: []models.TaskRun{models.TaskRun{Task: models.TaskSpec{Type: adapters.TaskTypeSleep}}},
 	}
-	run, err = services.QueueSleepingTask(run, store)
+	run, err = services.QueueSleepingTask(context.Background(), run, store)
 	assert.Error(t, err)
 
 	// error decoding params into adapter
@@ -299,7 +302,7 @@ func TestQueueSleepingTask(t *testing.T) {
 			},
 		},
 	}
-	run, err = services.QueueSleepingTask(run, store)
+	run, err = services.QueueSleepingTask(context.Background(), run, store)
 	assert.NoError(t, err)
 	assert.Equal(t, string(models.RunStatusErrored), string(run.TaskRuns[0].Status))
 	assert.Equal(t, string(models.RunStatusErrored), string(run.Status))
@@ -310,7 +313,7 @@ func TestQueueSleepingTask(t *testing.T) {
 		Status:   models.RunStatusPendingSleep,
 		TaskRuns: []models.TaskRun{models.TaskRun{Status: models.RunStatusPendingSleep, Task: models.TaskSpec{Type: adapters.TaskTypeSleep}}},
 	}
-	run, err = services.QueueSleepingTask(run, store)
+	run, err = services.QueueSleepingTask(context.Background(), run, store)
 	assert.NoError(t, err)
 	assert.Equal(t, string(models.RunStatusCompleted), string(run.TaskRuns[0].Status))
 	assert.Equal(t, string(models.RunStatusInProgress), string(run.Status))
@@ -338,7 +341,7 @@ func TestQueueSleepingTask(t *testing.T) {
 			},
 		},
 	}
-	run, err = services.QueueSleepingTask(run, store)
+	run, err = services.QueueSleepingTask(context.Background(), run, store)
 	assert.NoError(t, err)
 	assert.Equal(t, string(models.RunStatusPendingSleep), string(run.TaskRuns[0].Status))
 	assert.Equal(t, string(models.RunStatusPendingSleep), string(run.Status))
@@ -354,3 +357,77 @@ func TestQueueSleepingTask(t *testing.T) {
 	assert.Equal(t, string(models.RunStatusCompleted), string(run.TaskRuns[0].Status))
 	assert.Equal(t, string(models.RunStatusInProgress), string(run.Status))
 }
+
+func TestQueueSleepingTask_MillisecondsInOrder(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	sleepingRun := func(duration string) *models.JobRun {
+		run := &models.JobRun{
+			ID:     utils.NewBytes32ID(),
+			Status: models.RunStatusPendingSleep,
+			TaskRuns: []models.TaskRun{{
+				Status: models.RunStatusPendingSleep,
+				Task: models.TaskSpec{
+					Type:   adapters.TaskTypeSleep,
+					Params: cltest.JSONFromString(`{"duration": "` + duration + `"}`),
+				},
+			}},
+		}
+		run, err := services.QueueSleepingTask(context.Background(), run, store)
+		require.NoError(t, err)
+		return run
+	}
+	later := sleepingRun("300ms")
+	sooner := sleepingRun("200ms")
+
+	saved, err := store.FindJobRun(later.ID)
+	require.NoError(t, err)
+	wakeAt := saved.TaskRuns[0].SleepUntil
+	assert.Equal(t, wakeAt.Round(time.Millisecond), wakeAt.Time, "wake time is kept to the millisecond")
+	assert.Equal(t, models.RunStatusPendingSleep, saved.Status)
+
+	first := <-store.RunChannel.Receive()
+	second := <-store.RunChannel.Receive()
+	assert.Equal(t, sooner.ID, first.ID)
+	assert.Equal(t, later.ID, second.ID)
+
+	woken, err := store.FindJobRun(later.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusCompleted, woken.TaskRuns[0].Status)
+	assert.True(t, wakeAt.Equal(woken.TaskRuns[0].SleepUntil.Time), "wake time is not recalculated on waking")
+}
+
+func TestResumePendingBridgeRun_Duplicate(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	bt := cltest.NewBridgeType()
+	require.NoError(t, store.Save(&bt))
+	job, initr := cltest.NewJobWithWebInitiator()
+	job.Tasks = []models.TaskSpec{{Type: bt.Name}, cltest.NewTask("noop")}
+	require.NoError(t, store.SaveJob(&job))
+	run := cltest.MarkJobRunPendingBridge(job.NewRun(initr), 0)
+	require.NoError(t, store.Save(&run))
+
+	input := models.RunResult{Data: cltest.JSONFromString(`{"value":"100"}`), Status: models.RunStatusCompleted}
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := services.ResumePendingBridgeRun(run.ID, store, input)
+			results <- err
+		}()
+	}
+
+	errs := []error{<-results, <-results}
+	assert.Contains(t, errs, nil)
+	assert.Contains(t, errs, orm.ErrorNotPendingBridge)
+
+	run, err := store.FindJobRun(run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusInProgress, run.Status)
+	assert.Equal(t, models.RunStatusCompleted, run.TaskRuns[0].Status)
+	assert.Equal(t, models.RunStatusUnstarted, run.TaskRuns[1].Status)
+}
@@ -248,6 +248,11 @@ func receiveEthLog(le InitiatorSubscriptionLogEvent) {
 }
 
 func runJob(le InitiatorSubscriptionLogEvent, data models.JSON, initr models.Initiator) {
+	if le.Log.Removed {
+		logger.Debugw("Skipping log removed by a chain reorganization", le.ForLogger()...)
+		return
+	}
+
 	payment, err := le.ContractPayment()
 	if err != nil {
 		logger.Errorw(err.Error(), le.ForLogger()...)
@@ -264,8 +269,7 @@ func runJob(le InitiatorSubscriptionLogEvent, data models.JSON, initr models.Ini
 		logger.Errorw(err.Error(), le.ForLogger()...)
 	}
 
-	currentHead := le.ToIndexableBlockNumber().Number
-	_, err = ExecuteJob(le.Job, initr, input, &currentHead, le.store)
+	_, err = ExecuteJob(le.Job, initr, input, le.ToIndexableBlockNumber(), le.store)
 	if err != nil {
 		logger.Errorw(err.Error(), le.ForLogger()...)
 	}
@@ -361,7 +365,7 @@ type InitiatorSubscriptionLogEvent struct {
 // ForLogger formats the InitiatorSubscriptionLogEvent for easy common formatting in logs (trace statements, not ethereum events).
 func (le InitiatorSubscriptionLogEvent) ForLogger(kvs ...interface{}) []interface{} {
 	output := []interface{}{
-		"job", le.Job.ID,
+		"job_id", le.Job.ID,
 		"log", le.Log.BlockNumber,
 		"initiator", le.Initiator,
 	}
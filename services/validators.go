@@ -1,13 +1,17 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/tidwall/gjson"
 )
 
 // ValidateJob checks the job and its associated Initiators and Tasks for any
@@ -25,11 +29,65 @@ func ValidateJob(j models.JobSpec, store *store.Store) error {
 			fe.Merge(err)
 		}
 	}
-	for _, task := range j.Tasks {
-		if err := validateTask(task, store); err != nil {
+	for i, task := range j.Tasks {
+		if err := validateTask(i, task, store); err != nil {
 			fe.Merge(err)
 		}
 	}
+	if err := validateTaskTypes(j.Tasks, store); err != nil {
+		fe.Merge(err)
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+// validateTaskTypes checks that each task can accept the kind of value the
+// task before it produces, as far as their adapters declare. Tasks which
+// leave the value as it was are passed over, so that the value produced
+// earlier is checked against the task after them.
+func validateTaskTypes(tasks []models.TaskSpec, store *store.Store) error {
+	fe := models.NewJSONAPIErrors()
+	producer, produced := -1, []string{adapters.ValueAny}
+	for i, task := range tasks {
+		adapter, err := adapters.For(task, store)
+		if err != nil {
+			producer, produced = -1, []string{adapters.ValueAny}
+			continue
+		}
+		accepts, produces := adapters.TaskTypes(adapter.BaseAdapter)
+		if producer >= 0 && !adapters.CanAccept(produced, accepts) {
+			fe.Add(fmt.Sprintf(
+				"Task %d (%s) produces %s, which task %d (%s) cannot accept, expecting %s",
+				producer, tasks[producer].Type, strings.Join(produced, " or "),
+				i, task.Type, strings.Join(accepts, " or ")))
+		}
+		if produces != nil {
+			producer, produced = i, produces
+		}
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+// ResolveENSNames replaces each task's "address" param which is an ENS name,
+// such as "oracle.eth", with the address it resolves to, so that a job is
+// saved with the address it was created with even if the name later changes.
+func ResolveENSNames(ctx context.Context, j *models.JobSpec, store *store.Store) error {
+	fe := models.NewJSONAPIErrors()
+	for i, task := range j.Tasks {
+		name := task.Params.Get("address")
+		if name.Type != gjson.String || !utils.IsENSName(name.Str) {
+			continue
+		}
+		address, err := utils.ResolveENSName(ctx, store.TxManager, name.Str)
+		if err != nil {
+			fe.Add(fmt.Sprintf("Task %d: %v", i, err))
+			continue
+		}
+		params, err := task.Params.Add("address", address)
+		if err != nil {
+			return err
+		}
+		j.Tasks[i].Params = params
+	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -58,6 +116,8 @@ func ValidateInitiator(i models.Initiator, j models.JobSpec) error {
 		return validateCronInitiator(i)
 	case models.InitiatorServiceAgreementExecutionLog:
 		return validateServiceAgreementInitiator(i, j)
+	case models.InitiatorFluxMonitor:
+		return validateFluxMonitorInitiator(i)
 	case models.InitiatorWeb:
 		fallthrough
 	case models.InitiatorRunLog:
@@ -96,9 +156,40 @@ func validateServiceAgreementInitiator(i models.Initiator, j models.JobSpec) err
 	return fe.CoerceEmptyToNil()
 }
 
-func validateTask(task models.TaskSpec, store *store.Store) error {
-	_, err := adapters.For(task, store)
-	return err
+func validateFluxMonitorInitiator(i models.Initiator) error {
+	fe := models.NewJSONAPIErrors()
+	if i.ContractAddress == (common.Address{}) {
+		fe.Add("FluxMonitor must have a contractAddress")
+	}
+	if i.DeviationThreshold < 0 {
+		fe.Add("FluxMonitor threshold must be a percentage of at least 0")
+	}
+	if i.PollInterval.Duration() < MinimumFluxMonitorPollInterval {
+		fe.Add(fmt.Sprintf("FluxMonitor pollInterval must be at least %v", MinimumFluxMonitorPollInterval))
+	}
+	return fe.CoerceEmptyToNil()
+}
+
+func validateTask(index int, task models.TaskSpec, store *store.Store) error {
+	adapter, err := adapters.For(task, store)
+	if err != nil {
+		return err
+	}
+	if !store.Config.AllowUnknownTaskParams {
+		if err := adapters.CheckParams(task); err != nil {
+			return fmt.Errorf("Task %d: %v", index, err)
+		}
+	}
+	if auth := task.Params.Get("auth"); auth.Exists() {
+		credential, err := store.FindHTTPCredential(auth.String())
+		if err != nil {
+			return fmt.Errorf("Task %v references unknown credential %v", task.Type, auth.String())
+		}
+		if u, ok := adapter.BaseAdapter.(interface{ GetURL() string }); ok && !credential.Allows(u.GetURL()) {
+			return fmt.Errorf("Task %v may only send credential %v to %v", task.Type, auth.String(), credential.URLPrefix)
+		}
+	}
+	return nil
 }
 
 // ValidateServiceAgreement checks the ServiceAgreement for any application logic errors.
@@ -1,11 +1,16 @@
 package services_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store/models"
@@ -14,6 +19,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func unsupportedAdapterType(taskType string) string {
+	return fmt.Sprintf("%s is not a supported adapter type, expected a bridge or one of: %s",
+		taskType, strings.Join(adapters.RegisteredTypes(), ", "))
+}
+
 func TestValidateJob(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -35,7 +45,7 @@ func TestValidateJob(t *testing.T) {
 		{
 			"error in task",
 			cltest.LoadJSON("../internal/fixtures/web/nonexistent_task_job.json"),
-			models.NewJSONAPIErrorsWith("idonotexist is not a supported adapter type"),
+			models.NewJSONAPIErrorsWith(unsupportedAdapterType("idonotexist")),
 		},
 		{
 			"zero initiators",
@@ -67,6 +77,238 @@ func TestValidateJob(t *testing.T) {
 	}
 }
 
+func TestValidateJob_HTTPCredential(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	require.NoError(t, store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://example.com/", Token: "s3cr3t"}))
+	require.NoError(t, store.Save(&models.HTTPCredential{Name: "coinbase", URLPrefix: "https://api.coinbase.com/", Token: "s3cr3t"}))
+
+	tests := []struct {
+		name string
+		auth string
+		want error
+	}{
+		{"stored credential", "kaiko", nil},
+		{"missing credential", "cryptocompare", models.NewJSONAPIErrorsWith("Task httpget references unknown credential cryptocompare")},
+		{"credential for another URL", "coinbase", models.NewJSONAPIErrorsWith("Task httpget may only send credential coinbase to https://api.coinbase.com/")},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			j, _ := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{{
+				Type:   adapters.TaskTypeHTTPGet,
+				Params: cltest.JSONFromString(`{"get":"https://example.com","auth":"%s"}`, test.auth),
+			}}
+			assert.Equal(t, test.want, services.ValidateJob(j, store))
+		})
+	}
+}
+
+func TestValidateJob_JSONParsePaths(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		params string
+		want   error
+	}{
+		{"path", `{"path":["last"]}`, nil},
+		{"jsonPath", `{"jsonPath":"data.#(symbol==\"ETH\").last"}`, nil},
+		{"both", `{"path":["last"],"jsonPath":"last"}`, models.NewJSONAPIErrorsWith(`JSONParse accepts either "path" or "jsonPath", not both`)},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			j, _ := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{{
+				Type:   adapters.TaskTypeJSONParse,
+				Params: cltest.JSONFromString(test.params),
+			}}
+			assert.Equal(t, test.want, services.ValidateJob(j, store))
+		})
+	}
+}
+
+func TestResolveENSNames(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	ethMock := app.MockEthClient()
+
+	resolver := cltest.NewAddress()
+	oracle := cltest.NewAddress()
+	ethMock.Register("eth_call", hexutil.Bytes(common.LeftPadBytes(resolver.Bytes(), 32)))
+	ethMock.Register("eth_call", hexutil.Bytes(common.LeftPadBytes(oracle.Bytes(), 32)))
+
+	j, _ := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("httpget", `{"get":"https://example.com"}`),
+		cltest.NewTask("ethtx", `{"address":"Oracle.eth","functionSelector":"0x609ff1bd"}`),
+		cltest.NewTask("ethtx", `{"address":"0x356a04bCe728ba4c62A30294A55E6A8600a320B3","functionSelector":"0x609ff1bd"}`),
+	}
+	httpGet := j.Tasks[0].Params.String()
+	require.NoError(t, services.ResolveENSNames(context.Background(), &j, app.Store))
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, httpGet, j.Tasks[0].Params.String())
+	assert.Equal(t, strings.ToLower(oracle.Hex()), j.Tasks[1].Params.Get("address").String())
+	assert.Equal(t, "0x356a04bCe728ba4c62A30294A55E6A8600a320B3", j.Tasks[2].Params.Get("address").String())
+	assert.NoError(t, services.ValidateJob(j, app.Store))
+}
+
+func TestResolveENSNames_Unresolved(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_call", hexutil.Bytes(make([]byte, 32)))
+
+	j, _ := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("ethtx", `{"address":"missing.eth"}`)}
+	err := services.ResolveENSNames(context.Background(), &j, app.Store)
+	assert.Equal(t, models.NewJSONAPIErrorsWith("Task 0: ENS name missing.eth has no resolver"), err)
+	assert.Equal(t, "missing.eth", j.Tasks[0].Params.Get("address").String())
+}
+
+func TestValidateJob_WrappedTasks(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	bt := cltest.NewBridgeType("rideShare", "https://dUber.eth")
+	require.NoError(t, store.Save(&bt))
+
+	tests := []struct {
+		name   string
+		params string
+		want   error
+	}{
+		{"built in", `{"ttl":"1m","innerTask":{"type":"httpget","params":{"get":"https://example.com"}}}`, nil},
+		{"bridge", `{"ttl":"1m","innerTask":{"type":"rideShare"}}`, nil},
+		{"unknown", `{"ttl":"1m","innerTask":{"type":"idonotexist"}}`,
+			models.NewJSONAPIErrorsWith(unsupportedAdapterType("idonotexist"))},
+		{"unknown nested twice", `{"ttl":"1m","innerTask":{"type":"circuitbreaker","params":{"task":{"type":"idonotexist"}}}}`,
+			models.NewJSONAPIErrorsWith(unsupportedAdapterType("idonotexist"))},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			j, _ := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{{
+				Type:   adapters.TaskTypeCache,
+				Params: cltest.JSONFromString(test.params),
+			}}
+			assert.Equal(t, test.want, services.ValidateJob(j, store))
+		})
+	}
+}
+
+func TestValidateJob_EthTxParams(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name   string
+		params string
+		want   error
+	}{
+		{"valid", `{"functionSelector":"0x609ff1bd"}`, nil},
+		{"short selector", `{"functionSelector":"0x609ff1b"}`,
+			models.NewJSONAPIErrorsWith("EthTx functionSelector must be 4 bytes of hex: Function ID must be hex encoded: encoding/hex: odd length hex string")},
+		{"short prefix", `{"functionSelector":"0x609ff1bd","dataPrefix":"0x17"}`,
+			models.NewJSONAPIErrorsWith("EthTx dataPrefix must be a multiple of 32 bytes, got 1")},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			j, _ := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{{
+				Type:   adapters.TaskTypeEthTx,
+				Params: cltest.JSONFromString(test.params),
+			}}
+			assert.Equal(t, test.want, services.ValidateJob(j, store))
+		})
+	}
+}
+
+func TestValidateJob_UnknownParams(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j, _ := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{
+		cltest.NewTask("noop"),
+		cltest.NewTask("multiply", `{"tims":100}`),
+	}
+	assert.Equal(t,
+		models.NewJSONAPIErrorsWith(`Task 1: multiply has no param named "tims"`),
+		services.ValidateJob(j, store))
+
+	store.Config.AllowUnknownTaskParams = true
+	assert.NoError(t, services.ValidateJob(j, store))
+}
+
+func TestValidateJob_TaskTypes(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	tests := []struct {
+		name  string
+		tasks []models.TaskSpec
+		want  error
+	}{
+		{"compatible", []models.TaskSpec{
+			cltest.NewTask("httpget", `{"url":"https://example.com"}`),
+			cltest.NewTask("jsonparse", `{"path":["last"]}`),
+			cltest.NewTask("multiply", `{"times":100}`),
+			cltest.NewTask("ethuint256"),
+			cltest.NewTask("ethtx", `{"functionSelector":"0x609ff1bd"}`),
+		}, nil},
+		{"text may hold a number", []models.TaskSpec{
+			cltest.NewTask("httpget", `{"url":"https://example.com"}`),
+			cltest.NewTask("multiply", `{"times":100}`),
+		}, nil},
+		{"number into an encoded word", []models.TaskSpec{
+			cltest.NewTask("jsonparse", `{"path":["last"]}`),
+			cltest.NewTask("multiply", `{"times":100}`),
+			cltest.NewTask("ethtx", `{"functionSelector":"0x609ff1bd"}`),
+		}, models.NewJSONAPIErrorsWith("Task 1 (multiply) produces number, which task 2 (ethtx) cannot accept, expecting hex")},
+		{"text into an aggregate", []models.TaskSpec{
+			cltest.NewTask("httpget", `{"url":"https://example.com"}`),
+			cltest.NewTask("median"),
+		}, models.NewJSONAPIErrorsWith("Task 0 (httpget) produces text, which task 1 (median) cannot accept, expecting array")},
+		{"result key passes the value on", []models.TaskSpec{
+			cltest.NewTask("multiply", `{"times":100}`),
+			cltest.NewTask("ethuint256", `{"resultKey":"price"}`),
+			cltest.NewTask("hexdecode"),
+		}, models.NewJSONAPIErrorsWith("Task 0 (multiply) produces number, which task 2 (hexdecode) cannot accept, expecting hex")},
+		{"any is skipped", []models.TaskSpec{
+			cltest.NewTask("multiply", `{"times":100}`),
+			cltest.NewTask("noop"),
+			cltest.NewTask("ethtx", `{"functionSelector":"0x609ff1bd"}`),
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			j, _ := cltest.NewJobWithWebInitiator()
+			j.Tasks = test.tasks
+			assert.Equal(t, test.want, services.ValidateJob(j, store))
+		})
+	}
+}
+
 func TestValidateAdapter(t *testing.T) {
 	t.Parallel()
 
@@ -136,6 +378,11 @@ func TestValidateInitiator(t *testing.T) {
 		{"runat w time after end at", fmt.Sprintf(`{"type":"runat","params": {"time":"%v"}}`, endAt.Add(time.Second).Unix()), true},
 		{"cron", `{"type":"cron","params": {"schedule":"* * * * * *"}}`, false},
 		{"cron w/o schedule", `{"type":"cron"}`, true},
+		{"fluxmonitor", `{"type":"fluxmonitor","params":{"contractAddress":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42","threshold":0.5,"pollInterval":"1m"}}`, false},
+		{"fluxmonitor w/o threshold", `{"type":"fluxmonitor","params":{"contractAddress":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42","pollInterval":"1m"}}`, false},
+		{"fluxmonitor w/o contractAddress", `{"type":"fluxmonitor","params":{"threshold":0.5,"pollInterval":"1m"}}`, true},
+		{"fluxmonitor w negative threshold", `{"type":"fluxmonitor","params":{"contractAddress":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42","threshold":-1,"pollInterval":"1m"}}`, true},
+		{"fluxmonitor w/o pollInterval", `{"type":"fluxmonitor","params":{"contractAddress":"0x3cCad4715152693fE3BC4460591e3D3Fbd071b42","threshold":0.5}}`, true},
 		{"non-existent initiator", `{"type":"doesntExist"}`, true},
 	}
 
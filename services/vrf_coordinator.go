@@ -0,0 +1,247 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/smartcontractkit/chainlink/vrf"
+)
+
+// RandomnessRequestTopic is the signature of the VRFCoordinator's
+// RandomnessRequest(...) event, emitted when a consumer requests randomness
+// from the key with keyHash.
+// See https://github.com/smartcontractkit/chainlink/blob/master/evm-contracts/src/v0.6/VRFCoordinator.sol
+var RandomnessRequestTopic = mustHash("RandomnessRequest(bytes32,uint256,bytes32,address,uint256,bytes32)")
+
+// vrfCoordinatorFulfill is the selector of fulfillRandomnessRequest(bytes),
+// which is given the proof of the requested randomness.
+var vrfCoordinatorFulfill = models.HexToFunctionSelector("0x5e1c1059")
+
+// randomnessRequestDataLength is the length of the unindexed fields of a
+// RandomnessRequest log: keyHash, seed, sender, fee and requestID.
+const randomnessRequestDataLength = 5 * utils.EVMWordByteLen
+
+// vrfFulfillAttempts is how many times fulfilling a request is attempted
+// before it is given up on. The blocks waited after a failed attempt double
+// with each one.
+const vrfFulfillAttempts = 5
+
+// VRFCoordinator fulfills the randomness requested from the VRFCoordinator
+// contract at VRF_COORDINATOR_ADDRESS for any of the node's VRF keys. Once a
+// request has MIN_INCOMING_CONFIRMATIONS, the proof of the VRF output for its
+// seed is submitted to the contract, which verifies it before passing the
+// output on to the consumer. Nothing is done when VRF_COORDINATOR_ADDRESS is
+// not set.
+type VRFCoordinator struct {
+	store        *store.Store
+	subscription *ManagedSubscription
+	mutex        sync.Mutex
+	head         uint64
+	pending      []randomnessRequest
+	fulfilled    map[common.Hash]bool
+}
+
+// randomnessRequest is the request in a RandomnessRequest log.
+type randomnessRequest struct {
+	KeyHash     common.Hash
+	Seed        *big.Int
+	JobID       common.Hash
+	Sender      common.Address
+	Fee         *big.Int
+	RequestID   common.Hash
+	BlockNumber uint64
+	// Attempts counts the failed attempts to fulfill the request, which is
+	// not attempted again before the head reaches RetryAt.
+	Attempts int
+	RetryAt  uint64
+}
+
+// NewVRFCoordinator returns a VRFCoordinator, which listens for requests
+// once connected.
+func NewVRFCoordinator(store *store.Store) *VRFCoordinator {
+	return &VRFCoordinator{
+		store:     store,
+		fulfilled: map[common.Hash]bool{},
+	}
+}
+
+// Connect listens for the requests made from the block after head.
+func (vc *VRFCoordinator) Connect(head *models.IndexableBlockNumber) error {
+	address := vc.store.CurrentConfig().VRFCoordinatorAddress
+	if address == nil {
+		return nil
+	}
+
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+	vc.head = head.ToInt().Uint64()
+	filter := utils.ToFilterQueryFor(head.NextInt(), []common.Address{*address})
+	filter.Topics = [][]common.Hash{{RandomnessRequestTopic}}
+	subscription, err := NewManagedSubscription(vc.store, filter, vc.receiveLog)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to VRFCoordinator %s: %v", address.Hex(), err)
+	}
+	vc.subscription = subscription
+	logger.Infow(fmt.Sprintf("Listening for randomness requests to VRFCoordinator %s", address.Hex()), "contract", address.Hex())
+	return nil
+}
+
+// Disconnect stops listening for requests. Requests which are still waiting
+// for confirmations are fulfilled once connected again.
+func (vc *VRFCoordinator) Disconnect() {
+	vc.mutex.Lock()
+	subscription := vc.subscription
+	vc.subscription = nil
+	vc.mutex.Unlock()
+
+	if subscription != nil {
+		subscription.Unsubscribe()
+	}
+}
+
+// OnNewHead fulfills the requests which the head confirms.
+func (vc *VRFCoordinator) OnNewHead(head *models.BlockHeader) {
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+	vc.head = head.Number.ToInt().Uint64()
+	vc.fulfillConfirmed()
+}
+
+// receiveLog queues the request in the log, dropping it again if the log is
+// removed by a chain reorganization.
+func (vc *VRFCoordinator) receiveLog(log store.Log) {
+	request, err := parseRandomnessRequest(log)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to parse RandomnessRequest log: %v", err), "tx", log.TxHash.Hex())
+		return
+	}
+
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+	if log.Removed {
+		vc.drop(request.RequestID)
+		return
+	} else if vc.fulfilled[request.RequestID] || vc.isPending(request.RequestID) {
+		return
+	} else if !vc.store.VRFKeyStore.HasKey(request.KeyHash) {
+		logger.Debugw(fmt.Sprintf("Ignoring randomness request %s for another node's key", request.RequestID.Hex()), request.forLogger()...)
+		return
+	}
+	logger.Infow(fmt.Sprintf("Received randomness request %s", request.RequestID.Hex()), request.forLogger()...)
+	vc.pending = append(vc.pending, request)
+	vc.fulfillConfirmed()
+}
+
+// fulfillConfirmed fulfills every pending request with enough confirmations.
+// A request which fails to be fulfilled is kept pending until it is due to
+// be attempted again.
+func (vc *VRFCoordinator) fulfillConfirmed() {
+	confirmations := vc.store.CurrentConfig().MinIncomingConfirmations
+	var waiting []randomnessRequest
+	for _, request := range vc.pending {
+		if confirmations > 0 && (vc.head < request.BlockNumber || vc.head-request.BlockNumber+1 < confirmations) {
+			waiting = append(waiting, request)
+			continue
+		} else if vc.head < request.RetryAt {
+			waiting = append(waiting, request)
+			continue
+		}
+		if err := vc.fulfill(request); err != nil {
+			request.Attempts++
+			if request.Attempts >= vrfFulfillAttempts {
+				logger.Errorw(fmt.Sprintf("Giving up on randomness request %s after %d attempts: %v", request.RequestID.Hex(), request.Attempts, err), request.forLogger()...)
+				continue
+			}
+			request.RetryAt = vc.head + 1<<uint(request.Attempts-1)
+			logger.Warnw(fmt.Sprintf("Unable to fulfill randomness request %s, retrying at block %d: %v", request.RequestID.Hex(), request.RetryAt, err), request.forLogger()...)
+			waiting = append(waiting, request)
+			continue
+		}
+		vc.fulfilled[request.RequestID] = true
+	}
+	vc.pending = waiting
+}
+
+// fulfill submits the proof of the randomness for request.
+func (vc *VRFCoordinator) fulfill(request randomnessRequest) error {
+	proof, err := vc.store.VRFKeyStore.GenerateProof(request.KeyHash, request.Seed)
+	if err != nil {
+		return err
+	}
+	proofBytes, err := proof.MarshalForSolidityVerifier()
+	if err != nil {
+		return err
+	}
+	data, err := utils.ConcatBytes(
+		vrfCoordinatorFulfill.Bytes(),
+		utils.EVMWordUint64(utils.EVMWordByteLen),
+		utils.EVMWordUint64(vrf.ProofLength),
+		proofBytes,
+	)
+	if err != nil {
+		return err
+	}
+
+	tx, err := vc.store.TxManager.CreateTx(*vc.store.CurrentConfig().VRFCoordinatorAddress, data)
+	if err != nil {
+		return err
+	}
+	logger.Infow(fmt.Sprintf("Fulfilled randomness request %s", request.RequestID.Hex()), request.forLogger("tx", tx.Hash.Hex(), "output", proof.Output.String())...)
+	return nil
+}
+
+func (vc *VRFCoordinator) isPending(requestID common.Hash) bool {
+	for _, request := range vc.pending {
+		if request.RequestID == requestID {
+			return true
+		}
+	}
+	return false
+}
+
+func (vc *VRFCoordinator) drop(requestID common.Hash) {
+	for i, request := range vc.pending {
+		if request.RequestID == requestID {
+			logger.Warnw(fmt.Sprintf("Dropped randomness request %s removed by a chain reorganization", requestID.Hex()), request.forLogger()...)
+			vc.pending = append(vc.pending[:i], vc.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+func parseRandomnessRequest(log store.Log) (randomnessRequest, error) {
+	if len(log.Topics) < 2 {
+		return randomnessRequest{}, fmt.Errorf("expected the job ID topic, got %d topics", len(log.Topics))
+	} else if len(log.Data) != randomnessRequestDataLength {
+		return randomnessRequest{}, fmt.Errorf("expected %d bytes of data, got %d", randomnessRequestDataLength, len(log.Data))
+	}
+	word := func(i int) []byte {
+		return log.Data[i*utils.EVMWordByteLen : (i+1)*utils.EVMWordByteLen]
+	}
+	return randomnessRequest{
+		KeyHash:     common.BytesToHash(word(0)),
+		Seed:        new(big.Int).SetBytes(word(1)),
+		JobID:       log.Topics[1],
+		Sender:      common.BytesToAddress(word(2)),
+		Fee:         new(big.Int).SetBytes(word(3)),
+		RequestID:   common.BytesToHash(word(4)),
+		BlockNumber: log.BlockNumber,
+	}, nil
+}
+
+func (r randomnessRequest) forLogger(kvs ...interface{}) []interface{} {
+	output := []interface{}{
+		"request_id", r.RequestID.Hex(),
+		"key_hash", r.KeyHash.Hex(),
+		"job_id", r.JobID.Hex(),
+		"sender", r.Sender.Hex(),
+		"block", r.BlockNumber,
+	}
+	return append(output, kvs...)
+}
@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/smartcontractkit/chainlink/vrf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomnessRequestLog returns the log a VRFCoordinator contract emits for a
+// request of the randomness for seed from the key with keyHash.
+func randomnessRequestLog(coordinator common.Address, keyHash common.Hash, seed int64, requestID common.Hash, block uint64) strpkg.Log {
+	data := append(keyHash.Bytes(), utils.EVMWordUint64(uint64(seed))...)
+	data = append(data, cltest.NewAddress().Hash().Bytes()...)
+	data = append(data, utils.EVMWordUint64(1e18)...)
+	data = append(data, requestID.Bytes()...)
+	return strpkg.Log{
+		Address:     coordinator,
+		Topics:      []common.Hash{services.RandomnessRequestTopic, cltest.NewHash()},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      cltest.NewHash(),
+	}
+}
+
+// expectFulfillment registers the transaction fulfilling the request for
+// seed with the proof of the key with keyHash.
+func expectFulfillment(t *testing.T, eth *cltest.EthMock, store *strpkg.Store, coordinator common.Address, keyHash common.Hash, seed int64) {
+	eth.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	eth.Register("eth_sendRawTransaction", cltest.NewHash(), func(_ interface{}, data ...interface{}) error {
+		tx, err := utils.DecodeEthereumTx(data[0].([]interface{})[0].(string))
+		require.NoError(t, err)
+		assert.Equal(t, coordinator, *tx.To())
+
+		input := tx.Data()
+		require.Len(t, input, 4+2*utils.EVMWordByteLen+vrf.ProofLength)
+		assert.Equal(t, "0x5e1c1059", hexutil.Encode(input[:4]))
+		assert.Equal(t, utils.EVMWordUint64(32), input[4:36])
+		assert.Equal(t, utils.EVMWordUint64(vrf.ProofLength), input[36:68])
+
+		// The public key and gamma are the same for every proof of seed.
+		want, err := store.VRFKeyStore.GenerateProof(keyHash, big.NewInt(seed))
+		require.NoError(t, err)
+		wantBytes, err := want.MarshalForSolidityVerifier()
+		require.NoError(t, err)
+		proof := input[68:]
+		assert.Equal(t, wantBytes[:128], proof[:128])
+		assert.Equal(t, utils.EVMWordUint64(uint64(seed)), proof[192:224])
+		return nil
+	})
+}
+
+func TestVRFCoordinator_FulfillsConfirmedRequests(t *testing.T) {
+	t.Parallel()
+
+	config, cleanupConfig := cltest.NewConfig()
+	defer cleanupConfig()
+	coordinator := cltest.NewAddress()
+	config.VRFCoordinatorAddress = &coordinator
+	config.MinIncomingConfirmations = 2
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+
+	key, err := store.VRFKeyStore.CreateKey()
+	require.NoError(t, err)
+	keyHash := common.HexToHash(key.Hash)
+
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	logs := make(chan strpkg.Log, 1)
+	eth.RegisterSubscription("logs", logs)
+	require.NoError(t, app.Start())
+	eth.EventuallyAllCalled(t)
+
+	// Requests for other nodes' keys are ignored.
+	logs <- randomnessRequestLog(coordinator, cltest.NewHash(), 1, cltest.NewHash(), 10)
+
+	// A request removed by a chain reorganization is not fulfilled.
+	removed := randomnessRequestLog(coordinator, keyHash, 2, cltest.NewHash(), 10)
+	logs <- removed
+	removed.Removed = true
+	logs <- removed
+
+	// A request is fulfilled once it has enough confirmations.
+	request := randomnessRequestLog(coordinator, keyHash, 3, cltest.NewHash(), 10)
+	logs <- request
+	logs <- request
+	app.VRFCoordinator.OnNewHead(cltest.NewBlockHeader(10))
+
+	expectFulfillment(t, eth, store, coordinator, keyHash, 3)
+	app.VRFCoordinator.OnNewHead(cltest.NewBlockHeader(11))
+	eth.EventuallyAllCalled(t)
+
+	// It is only fulfilled once.
+	logs <- request
+	app.VRFCoordinator.OnNewHead(cltest.NewBlockHeader(12))
+	assert.True(t, eth.AllCalled())
+
+	txs := []models.Tx{}
+	require.NoError(t, store.Where("From", cltest.GetAccountAddress(store), &txs))
+	assert.Len(t, txs, 1)
+}
+
+func TestVRFCoordinator_RetriesFailedFulfillment(t *testing.T) {
+	t.Parallel()
+
+	config, cleanupConfig := cltest.NewConfig()
+	defer cleanupConfig()
+	coordinator := cltest.NewAddress()
+	config.VRFCoordinatorAddress = &coordinator
+	config.MinIncomingConfirmations = 2
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+
+	key, err := store.VRFKeyStore.CreateKey()
+	require.NoError(t, err)
+	keyHash := common.HexToHash(key.Hash)
+
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	logs := make(chan strpkg.Log, 1)
+	eth.RegisterSubscription("logs", logs)
+	require.NoError(t, app.Start())
+	eth.EventuallyAllCalled(t)
+
+	request := randomnessRequestLog(coordinator, keyHash, 3, cltest.NewHash(), 10)
+	// The third send waits for the first to have been handled.
+	logs <- request
+	logs <- request
+	logs <- request
+	eth.RegisterError("eth_blockNumber", "connection refused")
+	app.VRFCoordinator.OnNewHead(cltest.NewBlockHeader(11))
+	assert.True(t, eth.AllCalled())
+
+	// The failed request is attempted again in the next block.
+	expectFulfillment(t, eth, store, coordinator, keyHash, 3)
+	app.VRFCoordinator.OnNewHead(cltest.NewBlockHeader(12))
+	eth.EventuallyAllCalled(t)
+
+	txs := []models.Tx{}
+	require.NoError(t, store.Where("From", cltest.GetAccountAddress(store), &txs))
+	assert.Len(t, txs, 1)
+}
+
+func TestVRFCoordinator_WithoutAnAddress(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	eth := app.MockEthClient()
+	eth.Register("eth_getTransactionCount", `0x0100`)
+	require.NoError(t, app.Start())
+	eth.EventuallyAllCalled(t)
+
+	app.VRFCoordinator.OnNewHead(cltest.NewBlockHeader(1))
+	assert.True(t, eth.AllCalled())
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/multierr"
+)
+
+// ChainConfig holds the settings for sending transactions on a chain other
+// than the one at ETH_URL. Chains are only set in the config file, each in a
+// table keyed by its chain ID, with the node's settings taken for any left
+// out:
+//
+//   [CHAINS.137]
+//   RPC_URL = "wss://polygon.example.com"
+//   MIN_OUTGOING_CONFIRMATIONS = 20
+//   ETH_GAS_PRICE_DEFAULT = 30000000000
+//   ORACLE_CONTRACT_ADDRESS = "0x9Fe2B0Da1D1c5bD6B0c0b5A2cC1f1ee1e6e8C8a3"
+//
+// Contract addresses differ between chains, so ORACLE_CONTRACT_ADDRESS is
+// never taken from the node's settings. Chains are read as the node starts,
+// and reloading the config leaves them unchanged.
+type ChainConfig struct {
+	RPCURL                string          `toml:"RPC_URL"`
+	MinConfirmations      uint64          `toml:"MIN_OUTGOING_CONFIRMATIONS"`
+	GasPrice              big.Int         `toml:"ETH_GAS_PRICE_DEFAULT"`
+	GasBumpWei            big.Int         `toml:"ETH_GAS_BUMP_WEI"`
+	MaxGasPriceWei        big.Int         `toml:"ETH_MAX_GAS_PRICE_WEI"`
+	OracleContractAddress *common.Address `toml:"ORACLE_CONTRACT_ADDRESS"`
+}
+
+// apply returns config as it is for transactions on the chain.
+func (cc ChainConfig) apply(config Config, chainID uint64) Config {
+	config.ChainID = chainID
+	config.EthereumURL = cc.RPCURL
+	config.MinOutgoingConfirmations = cc.MinConfirmations
+	config.EthGasPriceDefault = cc.GasPrice
+	config.EthGasBumpWei = cc.GasBumpWei
+	config.EthMaxGasPriceWei = cc.MaxGasPriceWei
+	config.OracleContractAddress = cc.OracleContractAddress
+	config.Chains = nil
+	return config
+}
+
+// ChainIDs returns the IDs of the other chains the node is configured for,
+// in ascending order.
+func (c Config) ChainIDs() []uint64 {
+	ids := make([]uint64, 0, len(c.Chains))
+	for id := range c.Chains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// setChains sets Chains from the config file's CHAINS tables, once the
+// node's own settings are set.
+func (c *Config) setChains(setting interface{}) error {
+	tables, ok := setting.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("CHAINS must be tables keyed by chain ID, got %v", setting)
+	}
+	keys := make([]string, 0, len(tables))
+	for key := range tables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	c.Chains = map[uint64]ChainConfig{}
+	var merr error
+	for _, key := range keys {
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || id == 0 {
+			merr = multierr.Append(merr, fmt.Errorf("CHAINS.%s must be keyed by a chain ID", key))
+			continue
+		}
+		settings, ok := tables[key].(map[string]interface{})
+		if !ok {
+			merr = multierr.Append(merr, fmt.Errorf("CHAINS.%d must be a table", id))
+			continue
+		}
+
+		chain := ChainConfig{
+			MinConfirmations: c.MinOutgoingConfirmations,
+			GasPrice:         c.EthGasPriceDefault,
+			GasBumpWei:       c.EthGasBumpWei,
+			MaxGasPriceWei:   c.EthMaxGasPriceWei,
+		}
+		if err := setSettingFields(reflect.ValueOf(&chain).Elem(), "toml", settings, nil); err != nil {
+			merr = multierr.Append(merr, fmt.Errorf("CHAINS.%d: %v", id, err))
+			continue
+		}
+		c.Chains[id] = chain
+	}
+	return merr
+}
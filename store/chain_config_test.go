@@ -0,0 +1,90 @@
+package store
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestReadConfigFile_Chains(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, `
+ETH_GAS_PRICE_DEFAULT = 25000000000
+
+[CHAINS.137]
+RPC_URL = "wss://polygon.example.com"
+MIN_OUTGOING_CONFIRMATIONS = 20
+ETH_GAS_PRICE_DEFAULT = 30000000000
+ORACLE_CONTRACT_ADDRESS = "0x9Fe2B0Da1D1c5bD6B0c0b5A2cC1f1ee1e6e8C8a3"
+
+[CHAINS.42161]
+RPC_URL = "https://arbitrum.example.com"
+`)
+	defer cleanup()
+
+	config, err := LoadConfigFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{137, 42161}, config.ChainIDs())
+
+	polygon := config.Chains[137]
+	assert.Equal(t, "wss://polygon.example.com", polygon.RPCURL)
+	assert.Equal(t, uint64(20), polygon.MinConfirmations)
+	assert.Equal(t, big.NewInt(30000000000), &polygon.GasPrice)
+	assert.Equal(t, big.NewInt(5000000000), &polygon.GasBumpWei)
+	assert.Equal(t, big.NewInt(1500000000000), &polygon.MaxGasPriceWei)
+	oracle := common.HexToAddress("0x9Fe2B0Da1D1c5bD6B0c0b5A2cC1f1ee1e6e8C8a3")
+	assert.Equal(t, &oracle, polygon.OracleContractAddress)
+
+	arbitrum := config.Chains[42161]
+	assert.Equal(t, uint64(12), arbitrum.MinConfirmations, "the node's setting is taken")
+	assert.Equal(t, big.NewInt(25000000000), &arbitrum.GasPrice, "the node's setting is taken")
+	assert.Nil(t, arbitrum.OracleContractAddress, "contract addresses are never taken")
+
+	applied := arbitrum.apply(config, 42161)
+	assert.Equal(t, uint64(42161), applied.ChainID)
+	assert.Equal(t, "https://arbitrum.example.com", applied.EthereumURL)
+	assert.Nil(t, applied.Chains)
+	assert.Equal(t, config.RootDir, applied.RootDir)
+}
+
+func TestReadConfigFile_InvalidChains(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, `
+[CHAINS.polygon]
+RPC_URL = "wss://polygon.example.com"
+
+[CHAINS.42161]
+RPC_URL = "https://arbitrum.example.com"
+MIN_OUTGOING_CONFIRMATIONS = "many"
+GAS = 1
+`)
+	defer cleanup()
+
+	_, err := ReadConfigFile(path)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "CHAINS.42161: GAS is not a setting; invalid MIN_OUTGOING_CONFIRMATIONS: strconv.ParseUint: parsing \"many\": invalid syntax", errs[0].Error())
+	assert.Equal(t, "CHAINS.polygon must be keyed by a chain ID", errs[1].Error())
+}
+
+func TestSettingsValidator_Chains(t *testing.T) {
+	t.Parallel()
+	config, cleanup := validatedConfig(t)
+	defer cleanup()
+	config.ChainID = 1
+	config.Chains = map[uint64]ChainConfig{
+		1:   {RPCURL: "wss://mainnet.example.com", GasPrice: *big.NewInt(1)},
+		137: {RPCURL: "polygon.example.com", GasPrice: *big.NewInt(1)},
+		10:  {RPCURL: "https://optimism.example.com"},
+	}
+
+	issues := SettingsValidator{}.ValidateConfig(config)
+	require.Len(t, issues, 3)
+	assert.Equal(t, "CHAINS.1 is the chain at ETH_URL, set by ETH_CHAIN_ID", issues[0].Message)
+	assert.Equal(t, "CHAINS.10 ETH_GAS_PRICE_DEFAULT must be positive, got 0", issues[1].Message)
+	assert.Equal(t, `CHAINS.137 RPC_URL must be a ws, wss, http or https URL, got "polygon.example.com"`, issues[2].Message)
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -23,22 +24,44 @@ import (
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 // Config holds parameters used by the application which can be overridden by
-// setting environment variables.
+// setting environment variables, or in a config file given to
+// LoadConfigFromFile.
 //
 // If you add an entry here which does not contain sensitive information, you
 // should also update presenters.ConfigWhitelist and cmd_test.TestClient_RunNodeShowsEnv.
 type Config struct {
-	AllowOrigins      string        `env:"ALLOW_ORIGINS" envDefault:"http://localhost:3000,http://localhost:6688"`
-	BridgeResponseURL models.WebURL `env:"BRIDGE_RESPONSE_URL" envDefault:""`
-	ChainID           uint64        `env:"ETH_CHAIN_ID" envDefault:"0"`
-	ClientNodeURL     string        `env:"CLIENT_NODE_URL" envDefault:"http://localhost:6688"`
-	DatabaseTimeout   Duration      `env:"DATABASE_TIMEOUT" envDefault:"500ms"`
-	Dev               bool          `env:"CHAINLINK_DEV" envDefault:"false"`
+	// Clients allowed to use the API and GUI, as IPs or CIDR ranges. Any
+	// client may when empty, unless it is in APIDeniedIPs.
+	APIAllowedIPs []string `env:"API_ALLOWED_IPS" envDefault:""`
+	APIDeniedIPs  []string `env:"API_DENIED_IPS" envDefault:""`
+	// Number of proxies in front of the node, each adding the address it
+	// received a request from to X-Forwarded-For. With none, the header is
+	// ignored and clients are identified by the connection's address.
+	APIForwardedDepth int `env:"API_FORWARDED_DEPTH" envDefault:"0"`
+	// Requests per second allowed to each client of the API, with bursts of
+	// up to APIBurstLimit. A limit of 0 turns rate limiting off.
+	APIRateLimit                   int           `env:"API_RATE_LIMIT" envDefault:"100"`
+	APIBurstLimit                  int           `env:"API_BURST_LIMIT" envDefault:"200"`
+	AllowOrigins                   string        `env:"ALLOW_ORIGINS" envDefault:"http://localhost:3000,http://localhost:6688"`
+	AllowUnknownTaskParams         bool          `env:"ALLOW_UNKNOWN_TASK_PARAMS" envDefault:"false"`
+	AllowUnrestrictedNetworkAccess bool          `env:"ALLOW_UNRESTRICTED_NETWORK_ACCESS" envDefault:"false"`
+	BridgeResponseURL              models.WebURL `env:"BRIDGE_RESPONSE_URL" envDefault:""`
+	ChainID                        uint64        `env:"ETH_CHAIN_ID" envDefault:"0"`
+	ClientNodeURL                  string        `env:"CLIENT_NODE_URL" envDefault:"http://localhost:6688"`
+	DatabaseTimeout                Duration      `env:"DATABASE_TIMEOUT" envDefault:"500ms"`
+	// Redis server used by Cache adapters with the "redis" backend. It can hold
+	// a password, so is left out of presenters.ConfigWhitelist.
+	CacheRedisURL string `env:"CACHE_REDIS_URL" envDefault:""`
+	// Largest response body, in bytes, the HTTP adapters will read.
+	DefaultHTTPLimit   uint64   `env:"DEFAULT_HTTP_LIMIT" envDefault:"4194304"`
+	DefaultHTTPTimeout Duration `env:"DEFAULT_HTTP_TIMEOUT" envDefault:"15s"`
+	Dev                bool     `env:"CHAINLINK_DEV" envDefault:"false"`
 	// How long from now that a service agreement is allowed to run. Default 1 year = 365 * 24h = 8760h
 	MaximumServiceDuration Duration `env:"MAXIMUM_SERVICE_DURATION" envDefault:"8760h"`
 	// Shortest duration from now that a service is allowed to run.
@@ -46,16 +69,33 @@ type Config struct {
 	EthGasBumpThreshold      uint64          `env:"ETH_GAS_BUMP_THRESHOLD" envDefault:"12"`
 	EthGasBumpWei            big.Int         `env:"ETH_GAS_BUMP_WEI" envDefault:"5000000000"`
 	EthGasPriceDefault       big.Int         `env:"ETH_GAS_PRICE_DEFAULT" envDefault:"20000000000"`
+	EthMaxGasLimit           uint64          `env:"ETH_MAX_GAS_LIMIT" envDefault:"8000000"`
+	EthMaxGasPriceWei        big.Int         `env:"ETH_MAX_GAS_PRICE_WEI" envDefault:"1500000000000"`
+	EthNonceBlock            string          `env:"ETH_NONCE_BLOCK" envDefault:"latest"`
+	ETHLedgerPath            string          `env:"ETH_LEDGER_PATH" envDefault:""`
+	EthTxMissingThreshold    uint64          `env:"ETH_TX_MISSING_THRESHOLD" envDefault:"240"`
 	EthereumURL              string          `env:"ETH_URL" envDefault:"ws://localhost:8546"`
+	HTTPRetryAttempts        uint64          `env:"HTTP_RETRY_ATTEMPTS" envDefault:"3"`
+	HTTPRetryMaxBackoff      Duration        `env:"HTTP_RETRY_MAX_BACKOFF" envDefault:"10s"`
+	HTTPRetryMinBackoff      Duration        `env:"HTTP_RETRY_MIN_BACKOFF" envDefault:"1s"`
+	IPFSTimeout              Duration        `env:"IPFS_TIMEOUT" envDefault:"30s"`
 	JSONConsole              bool            `env:"JSON_CONSOLE" envDefault:"false"`
+	JobRunTimeout            Duration        `env:"JOB_RUN_TIMEOUT" envDefault:"0s"`
 	LinkContractAddress      string          `env:"LINK_CONTRACT_ADDRESS" envDefault:"0x514910771AF9Ca656af840dff83E8264EcF986CA"`
 	LogLevel                 LogLevel        `env:"LOG_LEVEL" envDefault:"info"`
 	LogToDisk                bool            `env:"LOG_TO_DISK" envDefault:"true"`
+	MaxSessions              int             `env:"MAX_SESSIONS" envDefault:"1"`
 	MinIncomingConfirmations uint64          `env:"MIN_INCOMING_CONFIRMATIONS" envDefault:"0"`
 	MinOutgoingConfirmations uint64          `env:"MIN_OUTGOING_CONFIRMATIONS" envDefault:"12"`
 	MinimumContractPayment   assets.Link     `env:"MINIMUM_CONTRACT_PAYMENT" envDefault:"1000000000000000000"`
 	MinimumRequestExpiration uint64          `env:"MINIMUM_REQUEST_EXPIRATION" envDefault:"300"`
+	OTELExporterOTLPEndpoint string          `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:""`
 	OracleContractAddress    *common.Address `env:"ORACLE_CONTRACT_ADDRESS"`
+	PasswordMinLength        int             `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordRequireUppercase bool            `env:"PASSWORD_REQUIRE_UPPERCASE" envDefault:"false"`
+	PasswordRequireLowercase bool            `env:"PASSWORD_REQUIRE_LOWERCASE" envDefault:"false"`
+	PasswordRequireDigit     bool            `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"false"`
+	PasswordRequireSymbol    bool            `env:"PASSWORD_REQUIRE_SYMBOL" envDefault:"false"`
 	Port                     uint16          `env:"CHAINLINK_PORT" envDefault:"6688"`
 	ReaperExpiration         Duration        `env:"REAPER_EXPIRATION" envDefault:"240h"`
 	RootDir                  string          `env:"ROOT" envDefault:"~/.chainlink"`
@@ -64,7 +104,17 @@ type Config struct {
 	TLSHost                  string          `env:"CHAINLINK_TLS_HOST" envDefault:""`
 	TLSKeyPath               string          `env:"TLS_KEY_PATH" envDefault:""`
 	TLSPort                  uint16          `env:"CHAINLINK_TLS_PORT" envDefault:"6689"`
-	SecretGenerator          SecretGenerator
+	VaultAddress             string          `env:"VAULT_ADDR" envDefault:""`
+	VaultPath                string          `env:"VAULT_PATH" envDefault:"secret/chainlink"`
+	VaultToken               string          `env:"VAULT_TOKEN" envDefault:""`
+	VRFCoordinatorAddress    *common.Address `env:"VRF_COORDINATOR_ADDRESS"`
+	// Other chains transactions can be sent on, keyed by chain ID, which are
+	// only set in the config file. See ChainConfig.
+	Chains map[uint64]ChainConfig
+	// TOML file the config was read from, if any, which the node watches
+	// for changes to ReloadableSettings.
+	FilePath        string
+	SecretGenerator SecretGenerator
 }
 
 // NewConfig returns the config with the environment variables set to their
@@ -74,16 +124,36 @@ func NewConfig() Config {
 	if err := parseEnv(&config); err != nil {
 		log.Fatal(fmt.Errorf("error parsing environment: %+v", err))
 	}
-	dir, err := homedir.Expand(config.RootDir)
+	if err := config.setUp(); err != nil {
+		log.Fatal(err)
+	}
+	return config
+}
+
+// Validate returns an error listing every setting which cannot work, such
+// as URLs the node cannot connect to and bounds which are the wrong way
+// round. See SettingsValidator.
+func (c Config) Validate() error {
+	var merr error
+	for _, issue := range (SettingsValidator{}).ValidateConfig(c) {
+		merr = multierr.Append(merr, errors.New(issue.Message))
+	}
+	return merr
+}
+
+// setUp creates the root directory, with $HOME expanded, and the secret
+// generator, once the config's values are read.
+func (c *Config) setUp() error {
+	dir, err := homedir.Expand(c.RootDir)
 	if err != nil {
-		log.Fatal(fmt.Errorf("error expanding $HOME: %+v", err))
+		return fmt.Errorf("error expanding $HOME: %+v", err)
 	}
 	if err = os.MkdirAll(dir, os.FileMode(0700)); err != nil {
-		log.Fatal(fmt.Errorf("error creating %s: %+v", dir, err))
+		return fmt.Errorf("error creating %s: %+v", dir, err)
 	}
-	config.RootDir = dir
-	config.SecretGenerator = filePersistedSecretGenerator{}
-	return config
+	c.RootDir = dir
+	c.SecretGenerator = filePersistedSecretGenerator{}
+	return nil
 }
 
 // KeysDir returns the path of the keys directory (used for keystore files).
@@ -125,6 +195,18 @@ func (c Config) SessionSecret() ([]byte, error) {
 	return c.SecretGenerator.Generate(c)
 }
 
+// PasswordPolicy returns the policy which passwords given to new users, and
+// to users changing their password, must follow.
+func (c Config) PasswordPolicy() utils.PasswordPolicy {
+	return utils.PasswordPolicy{
+		MinLength:        c.PasswordMinLength,
+		RequireUppercase: c.PasswordRequireUppercase,
+		RequireLowercase: c.PasswordRequireLowercase,
+		RequireDigit:     c.PasswordRequireDigit,
+		RequireSymbol:    c.PasswordRequireSymbol,
+	}
+}
+
 // SessionOptions returns the sesssions.Options struct used to configure
 // the session store.
 func (c Config) SessionOptions() sessions.Options {
@@ -157,16 +239,20 @@ func (f filePersistedSecretGenerator) Generate(c Config) ([]byte, error) {
 	return key, ioutil.WriteFile(sessionPath, []byte(str), 0644)
 }
 
+// configParsers parse the values of the Config fields whose types the env
+// package does not handle itself.
+var configParsers = env.CustomParsers{
+	reflect.TypeOf(&common.Address{}): addressParser,
+	reflect.TypeOf(big.Int{}):         bigIntParser,
+	reflect.TypeOf(assets.Link{}):     linkParser,
+	reflect.TypeOf(LogLevel{}):        levelParser,
+	reflect.TypeOf(Duration{}):        durationParser,
+	reflect.TypeOf(models.WebURL{}):   urlParser,
+	reflect.TypeOf(uint16(0)):         portParser,
+}
+
 func parseEnv(cfg interface{}) error {
-	return env.ParseWithFuncs(cfg, env.CustomParsers{
-		reflect.TypeOf(&common.Address{}): addressParser,
-		reflect.TypeOf(big.Int{}):         bigIntParser,
-		reflect.TypeOf(assets.Link{}):     linkParser,
-		reflect.TypeOf(LogLevel{}):        levelParser,
-		reflect.TypeOf(Duration{}):        durationParser,
-		reflect.TypeOf(models.WebURL{}):   urlParser,
-		reflect.TypeOf(uint16(0)):         portParser,
-	})
+	return env.ParseWithFuncs(cfg, configParsers)
 }
 
 func addressParser(str string) (interface{}, error) {
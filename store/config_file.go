@@ -0,0 +1,188 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"go.uber.org/multierr"
+)
+
+// LoadConfigFromFile returns the config with the settings in the TOML file
+// at path, which are named as their environment variables are:
+//
+//   ETH_URL = "ws://localhost:8546"
+//   MIN_OUTGOING_CONFIRMATIONS = 12
+//   API_ALLOWED_IPS = ["10.0.0.0/8"]
+//
+// Environment variables override the file's settings, and settings in
+// neither are left at their defaults. Every invalid setting is reported,
+// rather than only the first.
+func LoadConfigFromFile(path string) (Config, error) {
+	config, err := ReadConfigFile(path)
+	if err == nil {
+		err = config.Validate()
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	return config, config.setUp()
+}
+
+// ReadConfigFile returns the config with the settings in the TOML file at
+// path, as LoadConfigFromFile does, but neither validates it nor creates the
+// root directory. Only settings which cannot be parsed are errors.
+func ReadConfigFile(path string) (Config, error) {
+	var settings map[string]interface{}
+	if _, err := toml.DecodeFile(path, &settings); err != nil {
+		return Config{}, fmt.Errorf("error reading config file %s: %v", path, err)
+	}
+
+	chains, hasChains := settings["CHAINS"]
+	delete(settings, "CHAINS")
+
+	config := Config{}
+	var merr error
+	if err := parseEnv(&config); err != nil {
+		merr = multierr.Append(merr, fmt.Errorf("error parsing environment: %v", err))
+	}
+	merr = multierr.Append(merr, config.setSettings(settings, true))
+	if hasChains {
+		merr = multierr.Append(merr, config.setChains(chains))
+	}
+	if merr != nil {
+		return Config{}, merr
+	}
+	config.FilePath = path
+	return config, nil
+}
+
+// setSettings sets each field to its value in settings, which are named as
+// their environment variables are, unless envOverrides is true and its
+// environment variable is set. As with environment variables, empty values
+// leave the field as it is.
+func (c *Config) setSettings(settings map[string]interface{}, envOverrides bool) error {
+	var skip func(string) bool
+	if envOverrides {
+		skip = func(name string) bool {
+			_, set := os.LookupEnv(name)
+			return set
+		}
+	}
+	return setSettingFields(reflect.ValueOf(c).Elem(), "env", settings, skip)
+}
+
+// setSettingFields sets each field of the struct value to its value in
+// settings, which are named by the field's tag, unless skip returns true for
+// the name.
+func setSettingFields(value reflect.Value, tag string, settings map[string]interface{}, skip func(string) bool) error {
+	fields := map[string]reflect.Value{}
+	for i := 0; i < value.NumField(); i++ {
+		if name := strings.Split(value.Type().Field(i).Tag.Get(tag), ",")[0]; name != "" {
+			fields[name] = value.Field(i)
+		}
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var merr error
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			merr = multierr.Append(merr, fmt.Errorf("%s is not a setting", name))
+			continue
+		}
+		if skip != nil && skip(name) {
+			continue
+		}
+		str, err := settingString(settings[name])
+		if err == nil && str != "" {
+			err = setField(field, str)
+		}
+		if err != nil {
+			merr = multierr.Append(merr, fmt.Errorf("invalid %s: %v", name, err))
+		}
+	}
+	return merr
+}
+
+// settingString returns a TOML value, or a JSON value decoded with
+// UseNumber, as it would be written in an environment variable, with arrays
+// comma separated.
+func settingString(setting interface{}) (string, error) {
+	switch v := setting.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool, int64, float64:
+		return fmt.Sprint(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i, elem := range v {
+			str, err := settingString(elem)
+			if err != nil {
+				return "", err
+			}
+			strs[i] = str
+		}
+		return strings.Join(strs, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", setting)
+	}
+}
+
+// setField parses str into field as parseEnv would.
+func setField(field reflect.Value, str string) error {
+	if parser, ok := configParsers[field.Type()]; ok {
+		val, err := parser(str)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(val))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(strings.Split(str, ",")))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func writeConfigFile(t *testing.T, contents string) (string, func()) {
+	dir, err := ioutil.TempDir("", "chainlink_config")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "chainlink.toml")
+	contents = fmt.Sprintf("ROOT = %q\n%s\n", dir, contents)
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+// configFieldString returns the field set by the environment variable name,
+// formatted as a string.
+func configFieldString(config *Config, name string) (string, bool) {
+	value := reflect.ValueOf(config).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).Tag.Get("env") != name {
+			continue
+		}
+		field := value.Field(i)
+		if stringer, ok := field.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+		return fmt.Sprint(field.Interface()), true
+	}
+	return "", false
+}
+
+func TestLoadConfigFromFile_EachSetting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setting string
+		want    string
+	}{
+		{"API_ALLOWED_IPS", `["10.0.0.0/8", "::1"]`, "[10.0.0.0/8 ::1]"},
+		{"API_DENIED_IPS", `["10.1.2.3"]`, "[10.1.2.3]"},
+		{"API_FORWARDED_DEPTH", `2`, "2"},
+		{"API_RATE_LIMIT", `10`, "10"},
+		{"API_BURST_LIMIT", `20`, "20"},
+		{"ALLOW_ORIGINS", `"http://example.com"`, "http://example.com"},
+		{"ALLOW_UNKNOWN_TASK_PARAMS", `true`, "true"},
+		{"ALLOW_UNRESTRICTED_NETWORK_ACCESS", `true`, "true"},
+		{"BRIDGE_RESPONSE_URL", `"http://localhost:6688"`, "http://localhost:6688"},
+		{"ETH_CHAIN_ID", `42`, "42"},
+		{"CLIENT_NODE_URL", `"https://node.example.com"`, "https://node.example.com"},
+		{"DATABASE_TIMEOUT", `"2s"`, "2s"},
+		{"CACHE_REDIS_URL", `"redis://localhost:6379"`, "redis://localhost:6379"},
+		{"DEFAULT_HTTP_LIMIT", `1024`, "1024"},
+		{"DEFAULT_HTTP_TIMEOUT", `"30s"`, "30s"},
+		{"CHAINLINK_DEV", `true`, "true"},
+		{"MAXIMUM_SERVICE_DURATION", `"720h"`, "720h0m0s"},
+		{"MINIMUM_SERVICE_DURATION", `"1h"`, "1h0m0s"},
+		{"ETH_GAS_BUMP_THRESHOLD", `6`, "6"},
+		{"ETH_GAS_BUMP_WEI", `"1000000000"`, "1000000000"},
+		{"ETH_GAS_PRICE_DEFAULT", `"30000000000"`, "30000000000"},
+		{"ETH_LEDGER_PATH", `"/dev/ledger"`, "/dev/ledger"},
+		{"ETH_TX_MISSING_THRESHOLD", `100`, "100"},
+		{"ETH_URL", `"wss://mainnet.example.com"`, "wss://mainnet.example.com"},
+		{"HTTP_RETRY_ATTEMPTS", `5`, "5"},
+		{"HTTP_RETRY_MAX_BACKOFF", `"20s"`, "20s"},
+		{"HTTP_RETRY_MIN_BACKOFF", `"2s"`, "2s"},
+		{"IPFS_TIMEOUT", `"1m"`, "1m0s"},
+		{"JSON_CONSOLE", `true`, "true"},
+		{"JOB_RUN_TIMEOUT", `"10m"`, "10m0s"},
+		{"LINK_CONTRACT_ADDRESS", `"0x20fE562d797A42Dcb3399062AE9546cd06f63280"`, "0x20fE562d797A42Dcb3399062AE9546cd06f63280"},
+		{"LOG_LEVEL", `"debug"`, "debug"},
+		{"LOG_TO_DISK", `false`, "false"},
+		{"MAX_SESSIONS", `3`, "3"},
+		{"MIN_INCOMING_CONFIRMATIONS", `1`, "1"},
+		{"MIN_OUTGOING_CONFIRMATIONS", `6`, "6"},
+		{"MINIMUM_CONTRACT_PAYMENT", `"2000000000000000000"`, "2.000000000000000000"},
+		{"MINIMUM_REQUEST_EXPIRATION", `600`, "600"},
+		{"OTEL_EXPORTER_OTLP_ENDPOINT", `"localhost:4317"`, "localhost:4317"},
+		{"ORACLE_CONTRACT_ADDRESS", `"0x9fbda871d559710256a2502a2517b794b482db40"`, common.HexToAddress("0x9fbda871d559710256a2502a2517b794b482db40").Hex()},
+		{"PASSWORD_MIN_LENGTH", `12`, "12"},
+		{"PASSWORD_REQUIRE_UPPERCASE", `true`, "true"},
+		{"PASSWORD_REQUIRE_LOWERCASE", `true`, "true"},
+		{"PASSWORD_REQUIRE_DIGIT", `true`, "true"},
+		{"PASSWORD_REQUIRE_SYMBOL", `true`, "true"},
+		{"CHAINLINK_PORT", `7788`, "7788"},
+		{"REAPER_EXPIRATION", `"48h"`, "48h0m0s"},
+		{"SESSION_TIMEOUT", `"30m"`, "30m0s"},
+		{"TLS_CERT_PATH", `"/certs/server.crt"` + "\nTLS_KEY_PATH = \"/certs/server.key\"", "/certs/server.crt"},
+		{"CHAINLINK_TLS_HOST", `"node.example.com"`, "node.example.com"},
+		{"TLS_KEY_PATH", `"/certs/server.key"` + "\nTLS_CERT_PATH = \"/certs/server.crt\"", "/certs/server.key"},
+		{"CHAINLINK_TLS_PORT", `7789`, "7789"},
+		{"VAULT_ADDR", `"https://vault.example.com"`, "https://vault.example.com"},
+		{"VAULT_PATH", `"secret/node"`, "secret/node"},
+		{"VAULT_TOKEN", `"s.token"`, "s.token"},
+	}
+
+	tested := map[string]bool{"ROOT": true} // set in every file
+	for _, test := range tests {
+		tested[test.name] = true
+		t.Run(test.name, func(t *testing.T) {
+			path, cleanup := writeConfigFile(t, test.name+" = "+test.setting)
+			defer cleanup()
+
+			config, err := LoadConfigFromFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Dir(path), config.RootDir)
+			got, ok := configFieldString(&config, test.name)
+			require.True(t, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	value := reflect.TypeOf(Config{})
+	for i := 0; i < value.NumField(); i++ {
+		if name := value.Field(i).Tag.Get("env"); name != "" {
+			assert.True(t, tested[name], "%s is not tested", name)
+		}
+	}
+}
+
+func TestLoadConfigFromFile_Defaults(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, "")
+	defer cleanup()
+
+	config, err := LoadConfigFromFile(path)
+	require.NoError(t, err)
+	defaults := NewConfig()
+	assert.Equal(t, defaults.EthereumURL, config.EthereumURL)
+	assert.Equal(t, defaults.MinOutgoingConfirmations, config.MinOutgoingConfirmations)
+	assert.Equal(t, defaults.SessionTimeout, config.SessionTimeout)
+	assert.Equal(t, defaults.MinimumContractPayment, config.MinimumContractPayment)
+	assert.Nil(t, config.APIAllowedIPs)
+	assert.NotNil(t, config.SecretGenerator)
+}
+
+func TestLoadConfigFromFile_MissingFile(t *testing.T) {
+	t.Parallel()
+	_, err := LoadConfigFromFile("/nonexistent/chainlink.toml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/nonexistent/chainlink.toml")
+}
+
+func TestLoadConfigFromFile_MalformedFile(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, "ETH_URL = ")
+	defer cleanup()
+
+	_, err := LoadConfigFromFile(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading config file")
+}
+
+func TestLoadConfigFromFile_AllErrors(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, strings.Join([]string{
+		`ETH_URL = "ftp://localhost"`,
+		`MIN_OUTGOING_CONFIRMATIONS = "twelve"`,
+		`LOG_LEVEL = "chatty"`,
+		`UNKNOWN_SETTING = 1`,
+	}, "\n"))
+	defer cleanup()
+
+	_, err := LoadConfigFromFile(path)
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 3, "ETH_URL is only validated once every setting is read")
+	assert.Contains(t, errs[0].Error(), "invalid LOG_LEVEL")
+	assert.Contains(t, errs[1].Error(), "invalid MIN_OUTGOING_CONFIRMATIONS")
+	assert.Equal(t, "UNKNOWN_SETTING is not a setting", errs[2].Error())
+}
+
+func TestLoadConfigFromFile_Validates(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, strings.Join([]string{
+		`ETH_URL = "ftp://localhost"`,
+		`HTTP_RETRY_MIN_BACKOFF = "1m"`,
+		`TLS_CERT_PATH = "/certs/server.crt"`,
+		`MAX_SESSIONS = -1`,
+	}, "\n"))
+	defer cleanup()
+
+	_, err := LoadConfigFromFile(path)
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 4)
+	assert.Contains(t, errs[0].Error(), "ETH_URL")
+	assert.Contains(t, errs[1].Error(), "HTTP_RETRY_MIN_BACKOFF")
+	assert.Contains(t, errs[2].Error(), "TLS_CERT_PATH")
+	assert.Contains(t, errs[3].Error(), "MAX_SESSIONS")
+}
+
+// Not parallel, since it sets an environment variable.
+func TestLoadConfigFromFile_EnvironmentOverrides(t *testing.T) {
+	path, cleanup := writeConfigFile(t, strings.Join([]string{
+		`ETH_GAS_BUMP_THRESHOLD = 6`,
+		`MIN_OUTGOING_CONFIRMATIONS = 3`,
+	}, "\n"))
+	defer cleanup()
+	require.NoError(t, os.Setenv("ETH_GAS_BUMP_THRESHOLD", "9"))
+	defer os.Unsetenv("ETH_GAS_BUMP_THRESHOLD")
+
+	config, err := LoadConfigFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), config.EthGasBumpThreshold)
+	assert.Equal(t, uint64(3), config.MinOutgoingConfirmations)
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"go.uber.org/multierr"
+)
+
+// ReloadableSettings are the settings, named as their environment variables
+// are, which ReloadConfig changes while the node runs. Every other setting,
+// such as ROOT (where the database and keys are kept), ETH_URL or the ports
+// listened on, is only read as the node starts, so changing it requires a
+// restart.
+var ReloadableSettings = map[string]bool{
+	"DEFAULT_HTTP_TIMEOUT":       true,
+	"ETH_GAS_BUMP_THRESHOLD":     true,
+	"ETH_GAS_BUMP_WEI":           true,
+	"ETH_GAS_PRICE_DEFAULT":      true,
+	"ETH_MAX_GAS_LIMIT":          true,
+	"ETH_MAX_GAS_PRICE_WEI":      true,
+	"IPFS_TIMEOUT":               true,
+	"JOB_RUN_TIMEOUT":            true,
+	"LOG_LEVEL":                  true,
+	"MIN_INCOMING_CONFIRMATIONS": true,
+	"MIN_OUTGOING_CONFIRMATIONS": true,
+}
+
+// ConfigChange is a setting changed by ReloadConfig.
+type ConfigChange struct {
+	Setting string `json:"setting"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// CurrentConfig returns the config as it is now: Config, along with any
+// changes made by ReloadConfig since the node started. ReloadableSettings
+// must be read from it, rather than Config, to take effect without a
+// restart.
+func (s *Store) CurrentConfig() Config {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+	return s.currentConfig()
+}
+
+func (s *Store) currentConfig() Config {
+	if s.reloadedConfig == nil {
+		return s.Config
+	}
+	return *s.reloadedConfig
+}
+
+// ReloadConfig changes the ReloadableSettings to their values in updated,
+// and returns those changed. Nothing is changed when any other setting
+// differs, or a changed setting is invalid.
+func (s *Store) ReloadConfig(updated Config) ([]ConfigChange, error) {
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
+	return s.reloadConfig(updated)
+}
+
+// ReloadConfigFile reloads the config from the file it was read from, with
+// environment variables overriding it as they did when the node started.
+func (s *Store) ReloadConfigFile() ([]ConfigChange, error) {
+	if s.Config.FilePath == "" {
+		return nil, errors.New("config was not read from a file")
+	}
+	updated, err := ReadConfigFile(s.Config.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	if updated.RootDir, err = homedir.Expand(updated.RootDir); err != nil {
+		return nil, fmt.Errorf("error expanding $HOME: %+v", err)
+	}
+	return s.ReloadConfig(updated)
+}
+
+// ChangeSettings changes the settings given, named as their environment
+// variables are, as ReloadConfig does. Values are given as they are in the
+// config file, or as JSON decoded with UseNumber, and take precedence over
+// environment variables.
+func (s *Store) ChangeSettings(settings map[string]interface{}) ([]ConfigChange, error) {
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
+	updated := s.currentConfig()
+	if err := updated.setSettings(settings, false); err != nil {
+		return nil, err
+	}
+	return s.reloadConfig(updated)
+}
+
+func (s *Store) reloadConfig(updated Config) ([]ConfigChange, error) {
+	next := s.currentConfig()
+	nextValue := reflect.ValueOf(&next).Elem()
+	updatedValue := reflect.ValueOf(&updated).Elem()
+
+	changes := []ConfigChange{}
+	var merr error
+	for i := 0; i < nextValue.NumField(); i++ {
+		name := strings.Split(nextValue.Type().Field(i).Tag.Get("env"), ",")[0]
+		if name == "" {
+			continue
+		}
+		from, to := settingValue(nextValue.Field(i)), settingValue(updatedValue.Field(i))
+		if from == to {
+			continue
+		} else if !ReloadableSettings[name] {
+			merr = multierr.Append(merr, fmt.Errorf("%s cannot be changed without restarting the node", name))
+			continue
+		}
+		nextValue.Field(i).Set(updatedValue.Field(i))
+		changes = append(changes, ConfigChange{Setting: name, From: from, To: to})
+	}
+	for _, issue := range ValidateConfig(next, SettingsValidator{}, GasPriceValidator{}) {
+		if issue.Severity == ConfigError {
+			merr = multierr.Append(merr, errors.New(issue.Message))
+		}
+	}
+	if merr != nil {
+		return nil, merr
+	}
+
+	if len(changes) > 0 {
+		s.reloadedConfig = &next
+	}
+	return changes, nil
+}
+
+// settingValue returns the field's value as a string, to compare settings
+// and to describe how they changed.
+func settingValue(field reflect.Value) string {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	if field.CanAddr() {
+		if stringer, ok := field.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	}
+	return fmt.Sprint(field.Interface())
+}
@@ -0,0 +1,160 @@
+package store_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestStore_ReloadConfig(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	updated := store.CurrentConfig()
+	updated.MinOutgoingConfirmations = 20
+	updated.JobRunTimeout = strpkg.Duration{Duration: time.Minute}
+	changes, err := store.ReloadConfig(updated)
+	require.NoError(t, err)
+	assert.Equal(t, []strpkg.ConfigChange{
+		{Setting: "JOB_RUN_TIMEOUT", From: "0s", To: "1m0s"},
+		{Setting: "MIN_OUTGOING_CONFIRMATIONS", From: "6", To: "20"},
+	}, changes)
+
+	assert.Equal(t, uint64(20), store.CurrentConfig().MinOutgoingConfirmations)
+	assert.Equal(t, time.Minute, store.CurrentConfig().JobRunTimeout.Duration)
+	assert.Equal(t, uint64(6), store.Config.MinOutgoingConfirmations, "the config started with is kept")
+
+	changes, err = store.ReloadConfig(updated)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestStore_ReloadConfig_RejectsUnreloadable(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	updated := store.CurrentConfig()
+	updated.MinOutgoingConfirmations = 20
+	updated.RootDir = "/elsewhere"
+	updated.EthereumURL = "ws://elsewhere:8546"
+	_, err := store.ReloadConfig(updated)
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "ETH_URL cannot be changed without restarting the node", errs[0].Error())
+	assert.Equal(t, "ROOT cannot be changed without restarting the node", errs[1].Error())
+	assert.Equal(t, uint64(6), store.CurrentConfig().MinOutgoingConfirmations, "nothing is changed")
+}
+
+func TestStore_ReloadConfig_RejectsInvalid(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	updated := store.CurrentConfig()
+	updated.EthGasPriceDefault = *big.NewInt(0)
+	_, err := store.ReloadConfig(updated)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ETH_GAS_PRICE_DEFAULT must be positive")
+	current := store.CurrentConfig()
+	assert.Equal(t, big.NewInt(20000000000), &current.EthGasPriceDefault)
+}
+
+func TestStore_ChangeSettings(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	var settings map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(`{"ETH_GAS_PRICE_DEFAULT": 30000000000, "LOG_LEVEL": "warn"}`))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&settings))
+
+	changes, err := store.ChangeSettings(settings)
+	require.NoError(t, err)
+	assert.Equal(t, []strpkg.ConfigChange{
+		{Setting: "ETH_GAS_PRICE_DEFAULT", From: "20000000000", To: "30000000000"},
+		{Setting: "LOG_LEVEL", From: "debug", To: "warn"},
+	}, changes)
+	current := store.CurrentConfig()
+	assert.Equal(t, big.NewInt(30000000000), &current.EthGasPriceDefault)
+
+	_, err = store.ChangeSettings(map[string]interface{}{"UNKNOWN_SETTING": "1"})
+	assert.EqualError(t, err, "UNKNOWN_SETTING is not a setting")
+	_, err = store.ChangeSettings(map[string]interface{}{"CHAINLINK_PORT": json.Number("7000")})
+	assert.EqualError(t, err, "CHAINLINK_PORT cannot be changed without restarting the node")
+}
+
+func TestStore_ReloadConfigFile(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "chainlink_reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tc, cleanup := cltest.NewConfig()
+	defer cleanup()
+	path := filepath.Join(dir, "chainlink.toml")
+	write := func(contents string) {
+		settings := fmt.Sprintf("ROOT = %q\nETH_URL = %q\n%s", dir, tc.EthereumURL, contents)
+		require.NoError(t, ioutil.WriteFile(path, []byte(settings), 0600))
+	}
+	write(`MIN_INCOMING_CONFIRMATIONS = 1`)
+	config, err := strpkg.LoadConfigFromFile(path)
+	require.NoError(t, err)
+	store, storeCleanup := cltest.NewStoreWithConfig(&cltest.TestConfig{Config: config})
+	defer storeCleanup()
+
+	write(`MIN_INCOMING_CONFIRMATIONS = 3`)
+	changes, err := store.ReloadConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, []strpkg.ConfigChange{{Setting: "MIN_INCOMING_CONFIRMATIONS", From: "1", To: "3"}}, changes)
+	assert.Equal(t, uint64(3), store.CurrentConfig().MinIncomingConfirmations)
+
+	write("MIN_INCOMING_CONFIRMATIONS = 3\nCHAINLINK_PORT = 7000")
+	_, err = store.ReloadConfigFile()
+	assert.EqualError(t, err, "CHAINLINK_PORT cannot be changed without restarting the node")
+}
+
+func TestStore_ReloadConfig_TxManagerGasPrice(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+
+	ethMock := app.MockEthClient()
+	ethMock.Context("app.Start()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(256))
+	})
+	require.NoError(t, app.Start())
+
+	updated := store.CurrentConfig()
+	updated.EthGasPriceDefault = *big.NewInt(30000000000)
+	_, err := store.ReloadConfig(updated)
+	require.NoError(t, err)
+
+	ethMock.Context("manager.CreateTx", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+		ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+	})
+	data, err := hex.DecodeString("0000abcdef")
+	require.NoError(t, err)
+	a, err := store.TxManager.CreateTx(cltest.NewAddress(), data)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(30000000000), a.GasPrice)
+	ethMock.EventuallyAllCalled(t)
+}
@@ -0,0 +1,270 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// ConfigIssueSeverity is how badly a ConfigIssue affects the node.
+type ConfigIssueSeverity string
+
+const (
+	// ConfigWarning is for settings which work, but likely not as intended.
+	ConfigWarning = ConfigIssueSeverity("warning")
+	// ConfigError is for settings which stop the node from working.
+	ConfigError = ConfigIssueSeverity("error")
+)
+
+// ConfigIssue is a problem with a setting, found by a ConfigValidator.
+type ConfigIssue struct {
+	Severity ConfigIssueSeverity `json:"severity"`
+	Setting  string              `json:"setting"`
+	Message  string              `json:"message"`
+}
+
+// ConfigValidator checks a config for issues, beyond the parsing of each
+// setting.
+type ConfigValidator interface {
+	ValidateConfig(Config) []ConfigIssue
+}
+
+// DefaultConfigValidators returns the validators run by the validate-config
+// command.
+func DefaultConfigValidators() []ConfigValidator {
+	return []ConfigValidator{
+		SettingsValidator{},
+		EthURLValidator{Dialer: EthDialer{}, Timeout: 10 * time.Second},
+		KeyStoreValidator{},
+		GasPriceValidator{},
+		TLSValidator{},
+	}
+}
+
+// ValidateConfig returns the issues found by each validator, in turn.
+func ValidateConfig(config Config, validators ...ConfigValidator) []ConfigIssue {
+	issues := []ConfigIssue{}
+	for _, validator := range validators {
+		issues = append(issues, validator.ValidateConfig(config)...)
+	}
+	return issues
+}
+
+// SettingsValidator checks that settings can work at all, such as URLs
+// having a scheme the node can connect with and bounds being the right way
+// round. Each of its issues is an error, and stops the node from starting.
+type SettingsValidator struct{}
+
+// ValidateConfig checks each setting without connecting to anything.
+func (SettingsValidator) ValidateConfig(c Config) []ConfigIssue {
+	issues := []ConfigIssue{}
+	invalid := func(setting, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{ConfigError, setting, fmt.Sprintf(format, args...)})
+	}
+
+	if !validEthereumURL(c.EthereumURL) {
+		invalid("ETH_URL", "ETH_URL must be a ws, wss, http or https URL, got %q", c.EthereumURL)
+	}
+	if u, err := url.Parse(c.ClientNodeURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		invalid("CLIENT_NODE_URL", "CLIENT_NODE_URL must be an http or https URL, got %q", c.ClientNodeURL)
+	}
+	if c.HTTPRetryMinBackoff.Duration > c.HTTPRetryMaxBackoff.Duration {
+		invalid("HTTP_RETRY_MIN_BACKOFF", "HTTP_RETRY_MIN_BACKOFF of %v is longer than HTTP_RETRY_MAX_BACKOFF of %v", c.HTTPRetryMinBackoff, c.HTTPRetryMaxBackoff)
+	}
+	if c.MinimumServiceDuration.Duration > c.MaximumServiceDuration.Duration {
+		invalid("MINIMUM_SERVICE_DURATION", "MINIMUM_SERVICE_DURATION of %v is longer than MAXIMUM_SERVICE_DURATION of %v", c.MinimumServiceDuration, c.MaximumServiceDuration)
+	}
+	if c.EthNonceBlock != "latest" && c.EthNonceBlock != "pending" {
+		invalid("ETH_NONCE_BLOCK", "ETH_NONCE_BLOCK must be latest or pending, got %q", c.EthNonceBlock)
+	}
+	if (c.TLSCertPath == "") != (c.TLSKeyPath == "") {
+		invalid("TLS_CERT_PATH", "TLS_CERT_PATH and TLS_KEY_PATH must be set together")
+	}
+	for _, setting := range []struct {
+		name  string
+		value int
+	}{
+		{"API_RATE_LIMIT", c.APIRateLimit},
+		{"API_BURST_LIMIT", c.APIBurstLimit},
+		{"API_FORWARDED_DEPTH", c.APIForwardedDepth},
+		{"MAX_SESSIONS", c.MaxSessions},
+		{"PASSWORD_MIN_LENGTH", c.PasswordMinLength},
+	} {
+		if setting.value < 0 {
+			invalid(setting.name, "%s must not be negative, got %d", setting.name, setting.value)
+		}
+	}
+	for _, id := range c.ChainIDs() {
+		chain := c.Chains[id]
+		if id == c.ChainID {
+			invalid("CHAINS", "CHAINS.%d is the chain at ETH_URL, set by ETH_CHAIN_ID", id)
+		}
+		if !validEthereumURL(chain.RPCURL) {
+			invalid("CHAINS", "CHAINS.%d RPC_URL must be a ws, wss, http or https URL, got %q", id, chain.RPCURL)
+		}
+		if chain.GasPrice.Sign() <= 0 {
+			invalid("CHAINS", "CHAINS.%d ETH_GAS_PRICE_DEFAULT must be positive, got %v", id, &chain.GasPrice)
+		}
+	}
+	return issues
+}
+
+func validEthereumURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "ws" || u.Scheme == "wss" || u.Scheme == "http" || u.Scheme == "https")
+}
+
+// EthURLValidator checks that the Ethereum node at ETH_URL answers, and is
+// on the chain ETH_CHAIN_ID expects.
+type EthURLValidator struct {
+	Dialer Dialer
+	// Longest wait for the Ethereum node to answer.
+	Timeout time.Duration
+}
+
+// ValidateConfig connects to ETH_URL, and asks for its network ID. URLs
+// which SettingsValidator rejects are not tried.
+func (v EthURLValidator) ValidateConfig(c Config) []ConfigIssue {
+	if !validEthereumURL(c.EthereumURL) {
+		return nil
+	}
+
+	type answer struct {
+		version string
+		err     error
+	}
+	answered := make(chan answer, 1)
+	go func() {
+		client, err := v.Dialer.Dial(c.EthereumURL)
+		if err != nil {
+			answered <- answer{err: err}
+			return
+		}
+		var version string
+		err = client.Call(&version, "net_version")
+		answered <- answer{version, err}
+	}()
+
+	var got answer
+	select {
+	case got = <-answered:
+	case <-time.After(v.Timeout):
+		got.err = fmt.Errorf("no answer after %v", v.Timeout)
+	}
+	if got.err != nil {
+		return []ConfigIssue{{ConfigError, "ETH_URL", fmt.Sprintf("unable to reach Ethereum node at %s: %v", c.EthereumURL, got.err)}}
+	}
+	if c.ChainID == 0 {
+		return nil
+	}
+	if network, err := strconv.ParseUint(got.version, 10, 64); err != nil || network != c.ChainID {
+		return []ConfigIssue{{ConfigWarning, "ETH_CHAIN_ID", fmt.Sprintf("ETH_CHAIN_ID is %d, but the Ethereum node is on network %s", c.ChainID, got.version)}}
+	}
+	return nil
+}
+
+// KeyStoreValidator checks that there is a key for the node to sign with,
+// either in the keys directory or on a Ledger.
+type KeyStoreValidator struct{}
+
+// ValidateConfig looks for the keys directory under ROOT, and the Ledger at
+// ETH_LEDGER_PATH when it is set.
+func (KeyStoreValidator) ValidateConfig(c Config) []ConfigIssue {
+	if c.ETHLedgerPath != "" {
+		if _, err := os.Stat(c.ETHLedgerPath); err != nil {
+			return []ConfigIssue{{ConfigError, "ETH_LEDGER_PATH", fmt.Sprintf("no Ledger at ETH_LEDGER_PATH: %v", err)}}
+		}
+		return nil
+	}
+
+	dir, err := homedir.Expand(c.KeysDir())
+	if err != nil {
+		return []ConfigIssue{{ConfigError, "ROOT", fmt.Sprintf("error expanding $HOME: %v", err)}}
+	}
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return []ConfigIssue{{ConfigWarning, "ROOT", fmt.Sprintf("keys directory %s does not exist yet, so the node will create a key", dir)}}
+	} else if err != nil {
+		return []ConfigIssue{{ConfigError, "ROOT", fmt.Sprintf("unable to read keys directory: %v", err)}}
+	} else if !info.IsDir() {
+		return []ConfigIssue{{ConfigError, "ROOT", fmt.Sprintf("keys directory %s is not a directory", dir)}}
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return []ConfigIssue{{ConfigError, "ROOT", fmt.Sprintf("unable to read keys directory: %v", err)}}
+	}
+	if len(files) == 0 {
+		return []ConfigIssue{{ConfigWarning, "ROOT", fmt.Sprintf("keys directory %s holds no keys, so the node will create one", dir)}}
+	}
+	return nil
+}
+
+var (
+	minSaneGasPriceWei = big.NewInt(1000000000)
+	maxSaneGasPriceWei = new(big.Int).Mul(big.NewInt(1000), minSaneGasPriceWei)
+)
+
+// GasPriceValidator checks that transactions will be sent at a gas price
+// which gets them mined, without overpaying.
+type GasPriceValidator struct{}
+
+// ValidateConfig checks ETH_GAS_PRICE_DEFAULT, ETH_GAS_BUMP_WEI and
+// ETH_MAX_GAS_PRICE_WEI.
+func (GasPriceValidator) ValidateConfig(c Config) []ConfigIssue {
+	issues := []ConfigIssue{}
+	price := &c.EthGasPriceDefault
+	if price.Sign() <= 0 {
+		issues = append(issues, ConfigIssue{ConfigError, "ETH_GAS_PRICE_DEFAULT", fmt.Sprintf("ETH_GAS_PRICE_DEFAULT must be positive, got %v", price)})
+	} else if price.Cmp(maxSaneGasPriceWei) > 0 {
+		issues = append(issues, ConfigIssue{ConfigWarning, "ETH_GAS_PRICE_DEFAULT", fmt.Sprintf("ETH_GAS_PRICE_DEFAULT of %v wei is over 1000 gwei", price)})
+	} else if price.Cmp(minSaneGasPriceWei) < 0 {
+		issues = append(issues, ConfigIssue{ConfigWarning, "ETH_GAS_PRICE_DEFAULT", fmt.Sprintf("ETH_GAS_PRICE_DEFAULT of %v wei is under 1 gwei, so transactions may never be mined", price)})
+	}
+	if c.EthGasBumpWei.Sign() <= 0 {
+		issues = append(issues, ConfigIssue{ConfigWarning, "ETH_GAS_BUMP_WEI", "ETH_GAS_BUMP_WEI is 0, so stuck transactions are resent at the same gas price"})
+	}
+	if price.Sign() > 0 && c.EthMaxGasPriceWei.Cmp(price) < 0 {
+		issues = append(issues, ConfigIssue{ConfigWarning, "ETH_MAX_GAS_PRICE_WEI", fmt.Sprintf("ETH_MAX_GAS_PRICE_WEI of %v wei is under ETH_GAS_PRICE_DEFAULT, so stuck transactions are never bumped", &c.EthMaxGasPriceWei)})
+	}
+	return issues
+}
+
+// TLSValidator checks that the certificate and key are there to serve
+// HTTPS with, when CHAINLINK_TLS_PORT is set.
+type TLSValidator struct{}
+
+// ValidateConfig looks for the certificate and key files. Those set with
+// TLS_CERT_PATH and TLS_KEY_PATH missing is an error, whereas those at the
+// default paths missing only means HTTPS is not served.
+func (TLSValidator) ValidateConfig(c Config) []ConfigIssue {
+	if c.TLSPort == 0 {
+		return nil
+	}
+	issues := []ConfigIssue{}
+	for _, file := range []struct {
+		setting, set, path string
+	}{
+		{"TLS_CERT_PATH", c.TLSCertPath, c.CertFile()},
+		{"TLS_KEY_PATH", c.TLSKeyPath, c.KeyFile()},
+	} {
+		path, err := homedir.Expand(file.path)
+		if err == nil {
+			_, err = os.Stat(path)
+		}
+		if err == nil {
+			continue
+		}
+		if file.set != "" {
+			issues = append(issues, ConfigIssue{ConfigError, file.setting, fmt.Sprintf("unable to read %s: %v", file.setting, err)})
+		} else {
+			issues = append(issues, ConfigIssue{ConfigWarning, file.setting, fmt.Sprintf("no file at %s, so HTTPS is not served on CHAINLINK_TLS_PORT", file.path)})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,289 @@
+package store
+
+import (
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validatedConfig returns the default config, rooted in a new directory.
+func validatedConfig(t *testing.T) (Config, func()) {
+	dir, err := ioutil.TempDir("", "chainlink_validate")
+	require.NoError(t, err)
+	config := Config{}
+	require.NoError(t, parseEnv(&config))
+	config.RootDir = dir
+	return config, func() { os.RemoveAll(dir) }
+}
+
+func issueSettings(issues []ConfigIssue) map[string]ConfigIssueSeverity {
+	settings := map[string]ConfigIssueSeverity{}
+	for _, issue := range issues {
+		settings[issue.Setting] = issue.Severity
+	}
+	return settings
+}
+
+func TestSettingsValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		change  func(*Config)
+		setting string
+	}{
+		{"ETH_URL", func(c *Config) { c.EthereumURL = "ftp://localhost" }, "ETH_URL"},
+		{"CLIENT_NODE_URL", func(c *Config) { c.ClientNodeURL = "localhost:6688" }, "CLIENT_NODE_URL"},
+		{"HTTP retry backoff", func(c *Config) { c.HTTPRetryMinBackoff.Duration = time.Minute }, "HTTP_RETRY_MIN_BACKOFF"},
+		{"service duration", func(c *Config) { c.MinimumServiceDuration.Duration = 10000 * time.Hour }, "MINIMUM_SERVICE_DURATION"},
+		{"ETH_NONCE_BLOCK", func(c *Config) { c.EthNonceBlock = "earliest" }, "ETH_NONCE_BLOCK"},
+		{"TLS cert without key", func(c *Config) { c.TLSCertPath = "/certs/server.crt" }, "TLS_CERT_PATH"},
+		{"TLS key without cert", func(c *Config) { c.TLSKeyPath = "/certs/server.key" }, "TLS_CERT_PATH"},
+		{"API_RATE_LIMIT", func(c *Config) { c.APIRateLimit = -1 }, "API_RATE_LIMIT"},
+		{"API_BURST_LIMIT", func(c *Config) { c.APIBurstLimit = -1 }, "API_BURST_LIMIT"},
+		{"API_FORWARDED_DEPTH", func(c *Config) { c.APIForwardedDepth = -1 }, "API_FORWARDED_DEPTH"},
+		{"MAX_SESSIONS", func(c *Config) { c.MaxSessions = -1 }, "MAX_SESSIONS"},
+		{"PASSWORD_MIN_LENGTH", func(c *Config) { c.PasswordMinLength = -1 }, "PASSWORD_MIN_LENGTH"},
+	}
+
+	config, cleanup := validatedConfig(t)
+	defer cleanup()
+	assert.Empty(t, SettingsValidator{}.ValidateConfig(config))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changed := config
+			test.change(&changed)
+			issues := SettingsValidator{}.ValidateConfig(changed)
+			require.Len(t, issues, 1)
+			assert.Equal(t, ConfigError, issues[0].Severity)
+			assert.Equal(t, test.setting, issues[0].Setting)
+		})
+	}
+}
+
+func TestConfig_Validate_UsesSettingsValidator(t *testing.T) {
+	t.Parallel()
+	config, cleanup := validatedConfig(t)
+	defer cleanup()
+	assert.NoError(t, config.Validate())
+
+	config.MaxSessions = -1
+	err := config.Validate()
+	require.Error(t, err)
+	assert.Equal(t, "MAX_SESSIONS must not be negative, got -1", err.Error())
+}
+
+func newNetVersionServer(t *testing.T, version string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + version + `"}`))
+	}))
+}
+
+// blockingDialer never finishes dialing.
+type blockingDialer struct{}
+
+func (blockingDialer) Dial(string) (CallerSubscriber, error) {
+	select {}
+}
+
+func TestEthURLValidator(t *testing.T) {
+	t.Parallel()
+	server := newNetVersionServer(t, "42")
+	defer server.Close()
+	closed := newNetVersionServer(t, "42")
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		url      string
+		chainID  uint64
+		dialer   Dialer
+		want     ConfigIssueSeverity
+		wantNone bool
+	}{
+		{"reachable", server.URL, 0, EthDialer{}, "", true},
+		{"matching chain", server.URL, 42, EthDialer{}, "", true},
+		{"other chain", server.URL, 1, EthDialer{}, ConfigWarning, false},
+		{"unreachable", closed.URL, 0, EthDialer{}, ConfigError, false},
+		{"no answer", server.URL, 0, blockingDialer{}, ConfigError, false},
+		{"invalid URL left to SettingsValidator", "ftp://localhost", 0, EthDialer{}, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, cleanup := validatedConfig(t)
+			defer cleanup()
+			config.EthereumURL = test.url
+			config.ChainID = test.chainID
+
+			issues := EthURLValidator{Dialer: test.dialer, Timeout: 100 * time.Millisecond}.ValidateConfig(config)
+			if test.wantNone {
+				assert.Empty(t, issues)
+				return
+			}
+			require.Len(t, issues, 1)
+			assert.Equal(t, test.want, issues[0].Severity)
+		})
+	}
+}
+
+func TestKeyStoreValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setUp   func(*testing.T, *Config)
+		want    ConfigIssueSeverity
+		setting string
+	}{
+		{"no keys directory", func(*testing.T, *Config) {}, ConfigWarning, "ROOT"},
+		{"keys directory is a file", func(t *testing.T, c *Config) {
+			require.NoError(t, ioutil.WriteFile(c.KeysDir(), nil, 0600))
+		}, ConfigError, "ROOT"},
+		{"empty keys directory", func(t *testing.T, c *Config) {
+			require.NoError(t, os.Mkdir(c.KeysDir(), 0700))
+		}, ConfigWarning, "ROOT"},
+		{"key", func(t *testing.T, c *Config) {
+			require.NoError(t, os.Mkdir(c.KeysDir(), 0700))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(c.KeysDir(), "key.json"), []byte("{}"), 0600))
+		}, "", ""},
+		{"missing Ledger", func(t *testing.T, c *Config) {
+			c.ETHLedgerPath = filepath.Join(c.RootDir, "ledger")
+		}, ConfigError, "ETH_LEDGER_PATH"},
+		{"Ledger", func(t *testing.T, c *Config) {
+			c.ETHLedgerPath = filepath.Join(c.RootDir, "ledger")
+			require.NoError(t, ioutil.WriteFile(c.ETHLedgerPath, nil, 0600))
+		}, "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, cleanup := validatedConfig(t)
+			defer cleanup()
+			test.setUp(t, &config)
+
+			issues := KeyStoreValidator{}.ValidateConfig(config)
+			if test.want == "" {
+				assert.Empty(t, issues)
+				return
+			}
+			require.Len(t, issues, 1)
+			assert.Equal(t, test.want, issues[0].Severity)
+			assert.Equal(t, test.setting, issues[0].Setting)
+		})
+	}
+}
+
+func TestGasPriceValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		priceWei     int64
+		bumpWei      int64
+		wantSettings map[string]ConfigIssueSeverity
+	}{
+		{"default", 20000000000, 5000000000, map[string]ConfigIssueSeverity{}},
+		{"zero price", 0, 5000000000, map[string]ConfigIssueSeverity{"ETH_GAS_PRICE_DEFAULT": ConfigError}},
+		{"negative price", -1, 5000000000, map[string]ConfigIssueSeverity{"ETH_GAS_PRICE_DEFAULT": ConfigError}},
+		{"under 1 gwei", 999999999, 5000000000, map[string]ConfigIssueSeverity{"ETH_GAS_PRICE_DEFAULT": ConfigWarning}},
+		{"1 gwei", 1000000000, 5000000000, map[string]ConfigIssueSeverity{}},
+		{"1000 gwei", 1000000000000, 5000000000, map[string]ConfigIssueSeverity{}},
+		{"over 1000 gwei", 1000000000001, 5000000000, map[string]ConfigIssueSeverity{"ETH_GAS_PRICE_DEFAULT": ConfigWarning}},
+		{"no bump", 20000000000, 0, map[string]ConfigIssueSeverity{"ETH_GAS_BUMP_WEI": ConfigWarning}},
+		{"over max price", 2000000000000, 5000000000, map[string]ConfigIssueSeverity{"ETH_GAS_PRICE_DEFAULT": ConfigWarning, "ETH_MAX_GAS_PRICE_WEI": ConfigWarning}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, cleanup := validatedConfig(t)
+			defer cleanup()
+			config.EthGasPriceDefault = *big.NewInt(test.priceWei)
+			config.EthGasBumpWei = *big.NewInt(test.bumpWei)
+
+			issues := GasPriceValidator{}.ValidateConfig(config)
+			assert.Equal(t, test.wantSettings, issueSettings(issues))
+		})
+	}
+}
+
+func TestTLSValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		setUp        func(*testing.T, *Config)
+		wantSettings map[string]ConfigIssueSeverity
+	}{
+		{"TLS off", func(t *testing.T, c *Config) {
+			c.TLSPort = 0
+		}, map[string]ConfigIssueSeverity{}},
+		{"no files at default paths", func(*testing.T, *Config) {}, map[string]ConfigIssueSeverity{
+			"TLS_CERT_PATH": ConfigWarning,
+			"TLS_KEY_PATH":  ConfigWarning,
+		}},
+		{"files at default paths", func(t *testing.T, c *Config) {
+			require.NoError(t, os.Mkdir(filepath.Dir(c.CertFile()), 0700))
+			require.NoError(t, ioutil.WriteFile(c.CertFile(), nil, 0600))
+			require.NoError(t, ioutil.WriteFile(c.KeyFile(), nil, 0600))
+		}, map[string]ConfigIssueSeverity{}},
+		{"no files at set paths", func(t *testing.T, c *Config) {
+			c.TLSCertPath = filepath.Join(c.RootDir, "server.crt")
+			c.TLSKeyPath = filepath.Join(c.RootDir, "server.key")
+		}, map[string]ConfigIssueSeverity{
+			"TLS_CERT_PATH": ConfigError,
+			"TLS_KEY_PATH":  ConfigError,
+		}},
+		{"no key at set path", func(t *testing.T, c *Config) {
+			c.TLSCertPath = filepath.Join(c.RootDir, "server.crt")
+			c.TLSKeyPath = filepath.Join(c.RootDir, "server.key")
+			require.NoError(t, ioutil.WriteFile(c.TLSCertPath, nil, 0600))
+		}, map[string]ConfigIssueSeverity{"TLS_KEY_PATH": ConfigError}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, cleanup := validatedConfig(t)
+			defer cleanup()
+			test.setUp(t, &config)
+
+			issues := TLSValidator{}.ValidateConfig(config)
+			assert.Equal(t, test.wantSettings, issueSettings(issues))
+		})
+	}
+}
+
+func TestValidateConfig_InTurn(t *testing.T) {
+	t.Parallel()
+	config, cleanup := validatedConfig(t)
+	defer cleanup()
+	config.MaxSessions = -1
+	config.EthGasBumpWei = *big.NewInt(0)
+
+	issues := ValidateConfig(config, GasPriceValidator{}, SettingsValidator{})
+	require.Len(t, issues, 2)
+	assert.Equal(t, "ETH_GAS_BUMP_WEI", issues[0].Setting)
+	assert.Equal(t, "MAX_SESSIONS", issues[1].Setting)
+	assert.Empty(t, ValidateConfig(config))
+}
+
+func TestReadConfigFile_DoesNotValidate(t *testing.T) {
+	t.Parallel()
+	path, cleanup := writeConfigFile(t, `MAX_SESSIONS = -1`)
+	defer cleanup()
+
+	config, err := ReadConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, -1, config.MaxSessions)
+	_, err = LoadConfigFromFile(path)
+	assert.Error(t, err)
+}
@@ -19,6 +19,32 @@
 // The underlying functions can be viewed here:
 //  go-ethereum/accounts/keystore/keystore.go
 //
+// LedgerSigner
+//
+// With ETH_LEDGER_PATH set to a BIP-44 derivation path, transactions are
+// signed by a Ledger instead of the KeyStore, so the funded key never
+// touches the node's disk.
+//  ETH_LEDGER_PATH="m/44'/60'/0'/0/0"
+// The Ledger must be plugged in and unlocked, with its Ethereum app open and
+// contract data enabled in the app's settings, before the node starts, and
+// the node fails to start otherwise. Every transaction, including gas bumps,
+// waits for confirmation on the device, so a Ledger suits nodes sending few
+// transactions. The KeyStore is still needed to sign service agreements.
+// Docker containers need the device passed through, as with
+// --device /dev/bus/usb, and building requires cgo for the USB HID library.
+//
+// VaultKeyStore
+//
+// With VAULT_ADDR set, the node's keys are kept in a HashiCorp Vault instead
+// of the KeyStore's directory. VAULT_PATH gives the mount of a KV version 2
+// secrets engine followed by the path within it, and VAULT_TOKEN must be
+// allowed to list, read and write the secrets there.
+//  VAULT_ADDR="https://vault.example.com:8200" VAULT_PATH="secret/chainlink"
+// Each key is stored under its address as an encrypted JSON keystore, so it
+// is only decrypted in the node's memory, with the node's password. The key
+// is fetched again when its account is activated, picking up the newest
+// version written by RotateVaultKey.
+//
 // Store
 //
 // The Store is the persistence layer for the application. It saves the
@@ -27,8 +27,15 @@ type CallerSubscriber interface {
 
 // GetNonce returns the nonce (transaction count) for a given address.
 func (eth *EthClient) GetNonce(address common.Address) (uint64, error) {
+	return eth.GetNonceAt(address, "latest")
+}
+
+// GetNonceAt returns the nonce for a given address at the block, which is
+// "latest" to count mined transactions, or "pending" to count those waiting
+// in the mempool too.
+func (eth *EthClient) GetNonceAt(address common.Address, block string) (uint64, error) {
 	result := ""
-	err := eth.Call(&result, "eth_getTransactionCount", address.Hex(), "latest")
+	err := eth.Call(&result, "eth_getTransactionCount", address.Hex(), block)
 	if err != nil {
 		return 0, err
 	}
@@ -56,21 +63,24 @@ func (eth *EthClient) GetEthBalance(address common.Address) (*assets.Eth, error)
 	return (*assets.Eth)(balance), nil
 }
 
-// GetERC20Balance returns the balance of the given address for the token contract address.
-func (eth *EthClient) GetERC20Balance(address common.Address, contractAddress common.Address) (*big.Int, error) {
-	type callArgs struct {
-		To   common.Address `json:"to"`
-		Data hexutil.Bytes  `json:"data"`
-	}
+// callArgs are the transaction fields sent with an eth_call.
+type callArgs struct {
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// GetERC20Balance returns the balance of the holder address at the ERC-20
+// token contract, in the token's smallest units.
+func (eth *EthClient) GetERC20Balance(tokenAddr, holderAddr common.Address) (*big.Int, error) {
 	result := ""
 	numLinkBigInt := new(big.Int)
 	functionSelector := models.HexToFunctionSelector("0x70a08231") // balanceOf(address)
-	data, err := utils.ConcatBytes(functionSelector.Bytes(), common.LeftPadBytes(address.Bytes(), utils.EVMWordByteLen))
+	data, err := utils.ConcatBytes(functionSelector.Bytes(), common.LeftPadBytes(holderAddr.Bytes(), utils.EVMWordByteLen))
 	if err != nil {
 		return nil, err
 	}
 	args := callArgs{
-		To:   contractAddress,
+		To:   tokenAddr,
 		Data: data,
 	}
 	err = eth.Call(&result, "eth_call", args, "latest")
@@ -81,6 +91,15 @@ func (eth *EthClient) GetERC20Balance(address common.Address, contractAddress co
 	return numLinkBigInt, nil
 }
 
+// CallContract executes a message call against the contract at the given
+// address without creating a transaction, and returns its output. The block
+// is a hex block number, or "latest", "earliest" or "pending".
+func (eth *EthClient) CallContract(to common.Address, data []byte, block string) ([]byte, error) {
+	var result hexutil.Bytes
+	err := eth.Call(&result, "eth_call", callArgs{To: to, Data: data}, block)
+	return result, err
+}
+
 // SendRawTx sends a signed transaction to the transaction pool.
 func (eth *EthClient) SendRawTx(hex string) (common.Hash, error) {
 	result := common.Hash{}
@@ -139,11 +158,15 @@ func (eth *EthClient) SubscribeToNewHeads(
 	return sub, err
 }
 
-// TxReceipt holds the block number and the transaction hash of a signed
-// transaction that has been written to the blockchain.
+// TxReceipt holds the block number, the transaction hash and, since
+// Byzantium, the status of a signed transaction that has been written to the
+// blockchain.
 type TxReceipt struct {
-	BlockNumber *models.Int `json:"blockNumber"`
-	Hash        common.Hash `json:"transactionHash"`
+	BlockNumber *models.Int     `json:"blockNumber"`
+	Hash        common.Hash     `json:"transactionHash"`
+	Status      *hexutil.Uint64 `json:"status,omitempty"`
+	// ContractAddress is the contract a transaction deploying one created.
+	ContractAddress *common.Address `json:"contractAddress,omitempty"`
 }
 
 var emptyHash = common.Hash{}
@@ -2,11 +2,13 @@ package store_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/stretchr/testify/assert"
@@ -124,3 +126,27 @@ func TestEthClient_GetERC20Balance(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
 }
+
+func TestEthClient_CallContract(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+
+	ethMock := app.MockEthClient()
+	ethClientObject := app.Store.TxManager.(*strpkg.EthTxManager).EthClient
+
+	contract := cltest.NewAddress()
+	ethMock.Register("eth_call", hexutil.Bytes{0x01, 0x02}, func(_ interface{}, data ...interface{}) error {
+		args := data[0].([]interface{})
+		b, err := json.Marshal(args[0])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"to":"`+strings.ToLower(contract.Hex())+`","data":"0xabcd"}`, string(b))
+		assert.Equal(t, "0x10", args[1])
+		return nil
+	})
+
+	result, err := ethClientObject.CallContract(contract, []byte{0xab, 0xcd}, "0x10")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, result)
+	ethMock.EventuallyAllCalled(t)
+}
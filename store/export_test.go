@@ -0,0 +1,21 @@
+package store
+
+import "github.com/ethereum/go-ethereum/accounts/keystore"
+
+// UseTxSigner makes s sign its transactions with signer, as it does with a
+// Ledger when ETH_LEDGER_PATH is set.
+func UseTxSigner(s *Store, signer TxSigner) {
+	s.TxSigner = signer
+	s.TxManager.(*EthTxManager).signer = signer
+}
+
+// UseLightScrypt makes vks encrypt keys quickly, at the cost of security.
+func UseLightScrypt(vks *VaultKeyStore) {
+	vks.scryptN, vks.scryptP = keystore.LightScryptN, keystore.LightScryptP
+}
+
+// UseLightScryptForVRFKeys makes ks encrypt keys quickly, at the cost of
+// security.
+func UseLightScryptForVRFKeys(ks *VRFKeyStore) {
+	ks.scryptN, ks.scryptP = keystore.LightScryptN, keystore.LightScryptP
+}
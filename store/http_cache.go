@@ -0,0 +1,139 @@
+package store
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// httpCacheEntries is how many responses the HTTPCache holds.
+	httpCacheEntries = 1000
+	// httpCacheBytes is the total size of the responses the HTTPCache
+	// holds.
+	httpCacheBytes = 32 * 1024 * 1024
+)
+
+// HTTPCache keeps the responses of HTTP adapters opting in to caching,
+// so that identical requests made within the TTL share a single response.
+// The least recently used responses are evicted once it holds maxEntries,
+// or maxBytes of responses. Identical requests made while one is in flight
+// wait for its response, rather than each going to the network.
+type HTTPCache struct {
+	maxEntries int
+	maxBytes   int
+
+	mutex    sync.Mutex
+	lru      *list.List
+	entries  map[string]*list.Element
+	size     int
+	inflight map[string]*httpCacheCall
+}
+
+// CachedResponse is a response kept by the HTTPCache. URL is where the body
+// was fetched from when the request was redirected, and empty otherwise.
+type CachedResponse struct {
+	Body string
+	URL  string
+}
+
+func (cr CachedResponse) size() int {
+	return len(cr.Body) + len(cr.URL)
+}
+
+type httpCacheEntry struct {
+	key       string
+	response  CachedResponse
+	expiresAt time.Time
+}
+
+type httpCacheCall struct {
+	done     chan struct{}
+	response CachedResponse
+	err      error
+}
+
+// NewHTTPCache returns an empty HTTPCache bounded by maxEntries and maxBytes.
+func NewHTTPCache(maxEntries, maxBytes int) *HTTPCache {
+	return &HTTPCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+		inflight:   map[string]*httpCacheCall{},
+	}
+}
+
+// Fetch returns the response cached under key if it has not expired by now,
+// in which case cached is true. Otherwise it calls fetch, caching a
+// successful response for ttl. A failed fetch is not cached, and evicts any expired entry
+// for key, so the next request goes to the network.
+func (hc *HTTPCache) Fetch(key string, now time.Time, ttl time.Duration, fetch func() (CachedResponse, error)) (response CachedResponse, cached bool, err error) {
+	hc.mutex.Lock()
+	if response, ok := hc.get(key, now); ok {
+		hc.mutex.Unlock()
+		return response, true, nil
+	}
+	if call, ok := hc.inflight[key]; ok {
+		hc.mutex.Unlock()
+		<-call.done
+		return call.response, call.err == nil, call.err
+	}
+	call := &httpCacheCall{done: make(chan struct{})}
+	hc.inflight[key] = call
+	hc.mutex.Unlock()
+
+	call.response, call.err = fetch()
+
+	hc.mutex.Lock()
+	delete(hc.inflight, key)
+	if call.err != nil {
+		hc.remove(key)
+	} else {
+		hc.set(key, call.response, now.Add(ttl))
+	}
+	hc.mutex.Unlock()
+	close(call.done)
+	return call.response, false, call.err
+}
+
+// Len returns the number of responses held.
+func (hc *HTTPCache) Len() int {
+	hc.mutex.Lock()
+	defer hc.mutex.Unlock()
+	return hc.lru.Len()
+}
+
+func (hc *HTTPCache) get(key string, now time.Time) (CachedResponse, bool) {
+	element, ok := hc.entries[key]
+	if !ok {
+		return CachedResponse{}, false
+	}
+	entry := element.Value.(*httpCacheEntry)
+	if !now.Before(entry.expiresAt) {
+		hc.remove(key)
+		return CachedResponse{}, false
+	}
+	hc.lru.MoveToFront(element)
+	return entry.response, true
+}
+
+func (hc *HTTPCache) set(key string, response CachedResponse, expiresAt time.Time) {
+	hc.remove(key)
+	if response.size() > hc.maxBytes {
+		return
+	}
+	hc.entries[key] = hc.lru.PushFront(&httpCacheEntry{key: key, response: response, expiresAt: expiresAt})
+	hc.size += response.size()
+	for hc.lru.Len() > hc.maxEntries || hc.size > hc.maxBytes {
+		hc.remove(hc.lru.Back().Value.(*httpCacheEntry).key)
+	}
+}
+
+func (hc *HTTPCache) remove(key string) {
+	if element, ok := hc.entries[key]; ok {
+		hc.lru.Remove(element)
+		delete(hc.entries, key)
+		hc.size -= element.Value.(*httpCacheEntry).response.size()
+	}
+}
@@ -0,0 +1,116 @@
+package store_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchBody(body string) func() (store.CachedResponse, error) {
+	return func() (store.CachedResponse, error) { return store.CachedResponse{Body: body}, nil }
+}
+
+func TestHTTPCache_Fetch(t *testing.T) {
+	t.Parallel()
+	hc := store.NewHTTPCache(10, 1024)
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	response, cached, err := hc.Fetch("key", now, time.Minute, fetchBody("first"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", response.Body)
+	assert.False(t, cached)
+
+	response, cached, err = hc.Fetch("key", now.Add(59*time.Second), time.Minute, fetchBody("second"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", response.Body)
+	assert.True(t, cached)
+
+	response, cached, err = hc.Fetch("key", now.Add(time.Minute), time.Minute, fetchBody("second"))
+	require.NoError(t, err)
+	assert.Equal(t, "second", response.Body, "expired")
+	assert.False(t, cached)
+}
+
+func TestHTTPCache_Fetch_ErrorsNotCached(t *testing.T) {
+	t.Parallel()
+	hc := store.NewHTTPCache(10, 1024)
+	now := time.Now()
+
+	_, _, err := hc.Fetch("key", now, time.Minute, func() (store.CachedResponse, error) {
+		return store.CachedResponse{}, errors.New("unavailable")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, hc.Len())
+
+	response, cached, err := hc.Fetch("key", now, time.Minute, fetchBody("recovered"))
+	require.NoError(t, err)
+	assert.Equal(t, "recovered", response.Body)
+	assert.False(t, cached)
+}
+
+func TestHTTPCache_Bounds(t *testing.T) {
+	t.Parallel()
+	hc := store.NewHTTPCache(2, 10)
+	now := time.Now()
+
+	for _, key := range []string{"a", "b"} {
+		_, _, err := hc.Fetch(key, now, time.Minute, fetchBody(key))
+		require.NoError(t, err)
+	}
+	_, cached, _ := hc.Fetch("a", now, time.Minute, fetchBody("a"))
+	assert.True(t, cached)
+	_, _, err := hc.Fetch("c", now, time.Minute, fetchBody("c"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, hc.Len())
+	_, cached, _ = hc.Fetch("b", now, time.Minute, fetchBody("b"))
+	assert.False(t, cached, "least recently used is evicted")
+
+	_, _, err = hc.Fetch("big", now, time.Minute, fetchBody(strings.Repeat("x", 11)))
+	require.NoError(t, err)
+	_, cached, _ = hc.Fetch("big", now, time.Minute, fetchBody("x"))
+	assert.False(t, cached, "larger than the cache")
+
+	_, _, err = hc.Fetch("d", now, time.Minute, fetchBody(strings.Repeat("d", 10)))
+	require.NoError(t, err)
+	assert.Equal(t, 1, hc.Len(), "evicted to fit within the size")
+}
+
+func TestHTTPCache_Fetch_Concurrent(t *testing.T) {
+	t.Parallel()
+	hc := store.NewHTTPCache(10, 1024)
+	now := time.Now()
+	var fetches int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 20)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, _, err := hc.Fetch("key", now, time.Minute, func() (store.CachedResponse, error) {
+				<-release
+				body := fmt.Sprintf("fetch %d", atomic.AddInt32(&fetches, 1))
+				return store.CachedResponse{Body: body}, nil
+			})
+			assert.NoError(t, err)
+			bodies[i] = response.Body
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	for _, body := range bodies {
+		assert.Equal(t, "fetch 1", body)
+	}
+}
@@ -12,6 +12,15 @@ import (
 	"github.com/smartcontractkit/chainlink/utils"
 )
 
+// AccountStore holds the node's accounts, which are unlocked with the node's
+// password. It is the KeyStore, or the VaultKeyStore when VAULT_ADDR is set.
+type AccountStore interface {
+	TxSigner
+	HasAccounts() bool
+	Unlock(password string) error
+	NewAccount(password string) (accounts.Account, error)
+}
+
 // KeyStore manages a key storage directory on disk.
 type KeyStore struct {
 	*keystore.KeyStore
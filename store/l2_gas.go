@@ -0,0 +1,142 @@
+package store
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// L2Fee is what a transaction on a layer 2 chain costs: its gas, and the fee
+// for posting its data to layer 1.
+type L2Fee struct {
+	GasPrice *big.Int
+	GasLimit uint64
+	L1Fee    *big.Int
+	// L1FeeInGas is set for chains which charge for layer 1 with gas, so
+	// that L1Fee is already covered by GasLimit rather than charged on top.
+	L1FeeInGas bool
+}
+
+// Total returns the most the transaction can cost.
+func (f L2Fee) Total() *big.Int {
+	total := new(big.Int).Mul(f.GasPrice, new(big.Int).SetUint64(f.GasLimit))
+	if f.L1FeeInGas {
+		return total
+	}
+	return total.Add(total, f.L1Fee)
+}
+
+// L2GasPriceEstimator estimates the fee for a transaction on a layer 2
+// chain, whose gas prices are set by the chain rather than by
+// ETH_GAS_PRICE_DEFAULT.
+type L2GasPriceEstimator interface {
+	// EstimateFee returns the fee for the unsigned transaction, asking the
+	// chain through eth.
+	EstimateFee(eth *EthClient, tx *types.Transaction) (L2Fee, error)
+}
+
+var l2GasPriceEstimators = map[uint64]L2GasPriceEstimator{
+	10:     OptimismGasPriceEstimator{}, // Optimism
+	69:     OptimismGasPriceEstimator{}, // Optimism Kovan
+	420:    OptimismGasPriceEstimator{}, // Optimism Goerli
+	42161:  ArbitrumGasPriceEstimator{}, // Arbitrum One
+	421611: ArbitrumGasPriceEstimator{}, // Arbitrum Rinkeby
+	421613: ArbitrumGasPriceEstimator{}, // Arbitrum Goerli
+}
+
+// L2GasPriceEstimatorFor returns the estimator for the layer 2 chain, or nil
+// for chains whose fee is just the gas used at ETH_GAS_PRICE_DEFAULT.
+func L2GasPriceEstimatorFor(chainID uint64) L2GasPriceEstimator {
+	return l2GasPriceEstimators[chainID]
+}
+
+var (
+	// OptimismGasPriceOracle is the address of the OVM_GasPriceOracle
+	// predeploy on each Optimism chain.
+	OptimismGasPriceOracle = common.HexToAddress("0x420000000000000000000000000000000000000F")
+	// ArbitrumGasInfo is the address of the ArbGasInfo precompile on each
+	// Arbitrum chain.
+	ArbitrumGasInfo = common.HexToAddress("0x000000000000000000000000000000000000006C")
+)
+
+// OptimismGasPriceEstimator asks the OVM_GasPriceOracle for the gas price,
+// and for the layer 1 fee of the transaction's data, which Optimism charges
+// separately from the gas.
+type OptimismGasPriceEstimator struct{}
+
+// EstimateFee calls gasPrice() and getL1Fee(bytes) on the oracle.
+func (OptimismGasPriceEstimator) EstimateFee(eth *EthClient, tx *types.Transaction) (L2Fee, error) {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return L2Fee{}, err
+	}
+
+	gasPrice, err := callForWords(eth, OptimismGasPriceOracle, models.HexToFunctionSelector("0xfe173b97"), nil, 1) // gasPrice()
+	if err != nil {
+		return L2Fee{}, fmt.Errorf("unable to get Optimism gas price: %v", err)
+	}
+	arg := append(utils.EVMWordUint64(utils.EVMWordByteLen), utils.EVMWordUint64(uint64(len(raw)))...)
+	arg = append(arg, common.RightPadBytes(raw, (len(raw)+utils.EVMWordByteLen-1)/utils.EVMWordByteLen*utils.EVMWordByteLen)...)
+	l1Fee, err := callForWords(eth, OptimismGasPriceOracle, models.HexToFunctionSelector("0x49948e0e"), arg, 1) // getL1Fee(bytes)
+	if err != nil {
+		return L2Fee{}, fmt.Errorf("unable to get Optimism L1 fee: %v", err)
+	}
+	return L2Fee{GasPrice: gasPrice[0], GasLimit: tx.Gas(), L1Fee: l1Fee[0]}, nil
+}
+
+// ArbitrumGasPriceEstimator asks ArbGasInfo for the prices in wei. Arbitrum
+// charges for the transaction's layer 1 data with gas, so the gas limit is
+// raised to cover it.
+type ArbitrumGasPriceEstimator struct{}
+
+// EstimateFee calls getPricesInWei() on ArbGasInfo, which returns the price
+// per L2 transaction, per L1 calldata byte, per storage allocation, and per
+// ArbGas as base, congestion and total.
+func (ArbitrumGasPriceEstimator) EstimateFee(eth *EthClient, tx *types.Transaction) (L2Fee, error) {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return L2Fee{}, err
+	}
+
+	prices, err := callForWords(eth, ArbitrumGasInfo, models.HexToFunctionSelector("0x41b247a8"), nil, 6) // getPricesInWei()
+	if err != nil {
+		return L2Fee{}, fmt.Errorf("unable to get Arbitrum gas prices: %v", err)
+	}
+	perL2Tx, perL1Byte, gasPrice := prices[0], prices[1], prices[5]
+	if gasPrice.Sign() <= 0 {
+		return L2Fee{}, fmt.Errorf("Arbitrum gas price must be positive, got %v", gasPrice)
+	}
+
+	l1Fee := new(big.Int).Mul(perL1Byte, big.NewInt(int64(len(raw))))
+	l1Fee.Add(l1Fee, perL2Tx)
+	l1Gas := new(big.Int).Add(l1Fee, new(big.Int).Sub(gasPrice, big.NewInt(1)))
+	l1Gas.Div(l1Gas, gasPrice)
+	return L2Fee{
+		GasPrice:   gasPrice,
+		GasLimit:   tx.Gas() + l1Gas.Uint64(),
+		L1Fee:      l1Fee,
+		L1FeeInGas: true,
+	}, nil
+}
+
+// callForWords calls the contract's function, and returns the first count
+// words of its output as uint256s.
+func callForWords(eth *EthClient, to common.Address, fs models.FunctionSelector, args []byte, count int) ([]*big.Int, error) {
+	output, err := eth.CallContract(to, append(fs.Bytes(), args...), "latest")
+	if err != nil {
+		return nil, err
+	}
+	if len(output) < count*utils.EVMWordByteLen {
+		return nil, fmt.Errorf("expected %d bytes of output, got %d", count*utils.EVMWordByteLen, len(output))
+	}
+	words := make([]*big.Int, count)
+	for i := range words {
+		words[i] = new(big.Int).SetBytes(output[i*utils.EVMWordByteLen : (i+1)*utils.EVMWordByteLen])
+	}
+	return words, nil
+}
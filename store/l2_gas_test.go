@@ -0,0 +1,226 @@
+package store_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/assets"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func evmWords(values ...uint64) hexutil.Bytes {
+	var words []byte
+	for _, v := range values {
+		words = append(words, utils.EVMWordUint64(v)...)
+	}
+	return words
+}
+
+// expectCall checks that eth_call was made to the contract, with data
+// starting with the function selector.
+func expectCall(t *testing.T, contract common.Address, selector string) func(interface{}, ...interface{}) error {
+	return func(_ interface{}, data ...interface{}) error {
+		args := data[0].([]interface{})
+		b, err := json.Marshal(args[0])
+		require.NoError(t, err)
+		call := struct {
+			To   common.Address
+			Data hexutil.Bytes
+		}{}
+		require.NoError(t, json.Unmarshal(b, &call))
+		assert.Equal(t, contract, call.To)
+		assert.True(t, strings.HasPrefix(call.Data.String(), selector), "called %s, not %s", call.Data, selector)
+		return nil
+	}
+}
+
+func TestL2GasPriceEstimatorFor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, strpkg.OptimismGasPriceEstimator{}, strpkg.L2GasPriceEstimatorFor(10))
+	assert.Equal(t, strpkg.OptimismGasPriceEstimator{}, strpkg.L2GasPriceEstimatorFor(420))
+	assert.Equal(t, strpkg.ArbitrumGasPriceEstimator{}, strpkg.L2GasPriceEstimatorFor(42161))
+	assert.Equal(t, strpkg.ArbitrumGasPriceEstimator{}, strpkg.L2GasPriceEstimatorFor(421613))
+	assert.Nil(t, strpkg.L2GasPriceEstimatorFor(1))
+	assert.Nil(t, strpkg.L2GasPriceEstimatorFor(137))
+}
+
+func TestOptimismGasPriceEstimator_EstimateFee(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	eth := &strpkg.EthClient{CallerSubscriber: ethMock}
+	tx := types.NewTransaction(7, cltest.NewAddress(), big.NewInt(0), 500000, big.NewInt(1), []byte{0xab, 0xcd})
+
+	ethMock.Register("eth_call", evmWords(1000000), expectCall(t, strpkg.OptimismGasPriceOracle, "0xfe173b97"))
+	ethMock.Register("eth_call", evmWords(40000000000000), expectCall(t, strpkg.OptimismGasPriceOracle, "0x49948e0e"))
+	fee, err := strpkg.OptimismGasPriceEstimator{}.EstimateFee(eth, tx)
+	require.NoError(t, err)
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, big.NewInt(1000000), fee.GasPrice)
+	assert.Equal(t, uint64(500000), fee.GasLimit)
+	assert.Equal(t, big.NewInt(40000000000000), fee.L1Fee)
+	assert.Equal(t, big.NewInt(500000*1000000+40000000000000), fee.Total())
+}
+
+func TestOptimismGasPriceEstimator_EstimateFee_Errors(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	eth := &strpkg.EthClient{CallerSubscriber: ethMock}
+	tx := types.NewTransaction(7, cltest.NewAddress(), big.NewInt(0), 500000, big.NewInt(1), nil)
+
+	ethMock.RegisterError("eth_call", "execution reverted")
+	_, err := strpkg.OptimismGasPriceEstimator{}.EstimateFee(eth, tx)
+	assert.EqualError(t, err, "unable to get Optimism gas price: execution reverted")
+
+	ethMock.Register("eth_call", evmWords(1000000))
+	ethMock.Register("eth_call", hexutil.Bytes{0x01})
+	_, err = strpkg.OptimismGasPriceEstimator{}.EstimateFee(eth, tx)
+	assert.EqualError(t, err, "unable to get Optimism L1 fee: expected 32 bytes of output, got 1")
+}
+
+func TestArbitrumGasPriceEstimator_EstimateFee(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	eth := &strpkg.EthClient{CallerSubscriber: ethMock}
+	tx := types.NewTransaction(7, cltest.NewAddress(), big.NewInt(0), 500000, big.NewInt(1), []byte{0xab, 0xcd})
+	raw, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+
+	ethMock.Register("eth_call", evmWords(1000000000000, 200000000000, 0, 100000000, 0, 100000000), expectCall(t, strpkg.ArbitrumGasInfo, "0x41b247a8"))
+	fee, err := strpkg.ArbitrumGasPriceEstimator{}.EstimateFee(eth, tx)
+	require.NoError(t, err)
+	ethMock.EventuallyAllCalled(t)
+
+	l1Fee := uint64(1000000000000 + 200000000000*len(raw))
+	assert.Equal(t, big.NewInt(100000000), fee.GasPrice)
+	assert.Equal(t, new(big.Int).SetUint64(l1Fee), fee.L1Fee)
+	assert.Equal(t, 500000+l1Fee/100000000, fee.GasLimit, "the L1 fee is paid with gas")
+	assert.Equal(t, new(big.Int).SetUint64(fee.GasLimit*100000000), fee.Total())
+}
+
+func TestArbitrumGasPriceEstimator_EstimateFee_ZeroGasPrice(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	eth := &strpkg.EthClient{CallerSubscriber: ethMock}
+	tx := types.NewTransaction(7, cltest.NewAddress(), big.NewInt(0), 500000, big.NewInt(1), nil)
+
+	ethMock.Register("eth_call", evmWords(1, 1, 0, 0, 0, 0))
+	_, err := strpkg.ArbitrumGasPriceEstimator{}.EstimateFee(eth, tx)
+	assert.EqualError(t, err, "Arbitrum gas price must be positive, got 0")
+}
+
+func TestTxManager_CreateTx_OnL2Chains(t *testing.T) {
+	t.Parallel()
+
+	config, configCleanup := cltest.NewConfig()
+	defer configCleanup()
+	config.ChainID = 10
+	config.Chains = map[uint64]strpkg.ChainConfig{
+		42161: {RPCURL: config.EthereumURL, GasPrice: *big.NewInt(1000000000)},
+	}
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+
+	optimismMock := app.MockEthClient()
+	arbitrumMock := cltest.MockEthOnChain(store, 42161)
+	optimismMock.Register("eth_getTransactionCount", utils.Uint64ToHex(3))
+	arbitrumMock.Register("eth_getTransactionCount", utils.Uint64ToHex(5))
+	require.NoError(t, app.Start())
+
+	optimismMock.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	optimismMock.Register("eth_call", evmWords(1000000))
+	optimismMock.Register("eth_call", evmWords(40000000000000))
+	optimismMock.Register("eth_sendRawTransaction", cltest.NewHash())
+	tx, err := store.TxManager.CreateTx(cltest.NewAddress(), []byte{0xab})
+	require.NoError(t, err)
+	optimismMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, uint64(500000), tx.GasLimit)
+	attempts, err := store.AttemptsFor(tx.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, big.NewInt(1000000), attempts[0].GasPrice)
+
+	arbitrumMock.Register("eth_blockNumber", utils.Uint64ToHex(200))
+	arbitrumMock.Register("eth_call", evmWords(0, 100000000000, 0, 0, 0, 100000000))
+	arbitrumMock.Register("eth_sendRawTransaction", cltest.NewHash())
+	tx, err = store.TxManager.ForChain(42161).CreateTx(cltest.NewAddress(), []byte{0xab})
+	require.NoError(t, err)
+	arbitrumMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, uint64(5), tx.Nonce)
+	assert.True(t, tx.GasLimit > 500000, "gas limit of %d does not cover the L1 fee", tx.GasLimit)
+	attempts, err = store.AttemptsFor(tx.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, big.NewInt(100000000), attempts[0].GasPrice)
+}
+
+func TestTxManager_CreateTx_LimitsEstimatedGasLimit(t *testing.T) {
+	t.Parallel()
+
+	config, configCleanup := cltest.NewConfig()
+	defer configCleanup()
+	config.ChainID = 42161
+	config.EthMaxGasLimit = 600000
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(3))
+	require.NoError(t, app.Start())
+
+	// An L1 fee costing far more than ETH_MAX_GAS_LIMIT in gas.
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	ethMock.Register("eth_call", evmWords(0, 1000000000000000, 0, 0, 0, 100000000))
+	ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+	tx, err := store.TxManager.CreateTx(cltest.NewAddress(), []byte{0xab})
+	require.NoError(t, err)
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, uint64(600000), tx.GasLimit)
+}
+
+func TestTxManager_CreateTxWithValue_IncludesL1Fee(t *testing.T) {
+	t.Parallel()
+
+	config, configCleanup := cltest.NewConfig()
+	defer configCleanup()
+	config.ChainID = 10
+	config.EthMaxGasPriceWei = *big.NewInt(100)
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(3))
+	require.NoError(t, app.Start())
+
+	// 1000 wei, 500000 gas at the maximum price of 100 wei, and the L1 fee.
+	required := int64(1000 + 500000*100 + 5000)
+
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(100))
+	ethMock.Register("eth_call", evmWords(1))
+	ethMock.Register("eth_call", evmWords(5000))
+	ethMock.Register("eth_getBalance", utils.Uint64ToHex(uint64(required-1)))
+	_, err := store.TxManager.CreateTxWithValue(cltest.NewAddress(), assets.NewEth(1000), nil)
+	fundsErr, ok := err.(*strpkg.InsufficientFundsError)
+	require.True(t, ok, "got %v", err)
+	assert.Equal(t, assets.NewEth(required), fundsErr.Required)
+	ethMock.EventuallyAllCalled(t)
+}
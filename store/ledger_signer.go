@@ -0,0 +1,95 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxSigner signs the transactions sent by the TxManager, from the account it
+// returns. The KeyStore is one, used unless ETH_LEDGER_PATH or VAULT_ADDR is
+// set.
+type TxSigner interface {
+	GetAccount() (accounts.Account, error)
+	SignTx(tx *types.Transaction, chainID uint64) (*types.Transaction, error)
+}
+
+// LedgerSigner signs transactions with a key held on a Ledger, so that it
+// never touches the node's disk. The Ledger must stay plugged in, unlocked,
+// and with its Ethereum app open, and each transaction must be confirmed on
+// the device.
+type LedgerSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// NewLedgerSigner opens the first Ledger found over USB and derives the
+// account at path, a BIP-44 derivation path such as m/44'/60'/0'/0/0.
+func NewLedgerSigner(path string) (*LedgerSigner, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, fmt.Errorf("unable to look for a Ledger: %v", err)
+	}
+	return NewLedgerSignerWithBackend(hub, path)
+}
+
+// NewLedgerSignerWithBackend is NewLedgerSigner, taking the first wallet of
+// backend as the Ledger.
+func NewLedgerSignerWithBackend(backend accounts.Backend, path string) (*LedgerSigner, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ETH_LEDGER_PATH %q: %v", path, err)
+	}
+	wallets := backend.Wallets()
+	if len(wallets) == 0 {
+		return nil, errors.New("no Ledger found, check that it is plugged in and unlocked")
+	}
+	wallet := wallets[0]
+	if err := wallet.Open(""); err != nil && err != accounts.ErrWalletAlreadyOpen {
+		return nil, fmt.Errorf("unable to open Ledger, check that its Ethereum app is open: %v", err)
+	}
+	account, err := wallet.Derive(derivationPath, true)
+	if err != nil {
+		wallet.Close()
+		return nil, fmt.Errorf("unable to derive Ledger account at %s: %v", path, err)
+	}
+	return &LedgerSigner{wallet: wallet, account: account}, nil
+}
+
+// GetAccount returns the account derived from the Ledger.
+func (ls *LedgerSigner) GetAccount() (accounts.Account, error) {
+	return ls.account, nil
+}
+
+// Accounts returns every account the Ledger has derived, which includes the
+// signing account.
+func (ls *LedgerSigner) Accounts() []accounts.Account {
+	return ls.wallet.Accounts()
+}
+
+// SignTx asks the Ledger to sign tx, for the chain with chainID.
+func (ls *LedgerSigner) SignTx(tx *types.Transaction, chainID uint64) (*types.Transaction, error) {
+	return ls.wallet.SignTx(ls.account, tx, big.NewInt(int64(chainID)))
+}
+
+// SignerFn returns the signer for contract bindings sending transactions
+// from the Ledger's account on the chain with chainID.
+func (ls *LedgerSigner) SignerFn(chainID uint64) bind.SignerFn {
+	return func(_ types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != ls.account.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		return ls.SignTx(tx, chainID)
+	}
+}
+
+// Close releases the Ledger.
+func (ls *LedgerSigner) Close() error {
+	return ls.wallet.Close()
+}
@@ -0,0 +1,163 @@
+package store_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ledgerPath = "m/44'/60'/0'/0/0"
+
+// mockLedger stands in for a Ledger, holding a single key for every path.
+type mockLedger struct {
+	accounts.Wallet
+	key      *ecdsa.PrivateKey
+	derived  []accounts.Account
+	openErr  error
+	isClosed bool
+}
+
+func newMockLedger(t *testing.T) *mockLedger {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &mockLedger{key: key}
+}
+
+func (l *mockLedger) address() common.Address {
+	return crypto.PubkeyToAddress(l.key.PublicKey)
+}
+
+func (l *mockLedger) Open(string) error { return l.openErr }
+
+func (l *mockLedger) Close() error {
+	l.isClosed = true
+	return nil
+}
+
+func (l *mockLedger) Accounts() []accounts.Account { return l.derived }
+
+func (l *mockLedger) Derive(accounts.DerivationPath, bool) (accounts.Account, error) {
+	account := accounts.Account{Address: l.address()}
+	l.derived = append(l.derived, account)
+	return account, nil
+}
+
+func (l *mockLedger) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if account.Address != l.address() {
+		return nil, accounts.ErrUnknownAccount
+	}
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), l.key)
+}
+
+type mockLedgerHub []accounts.Wallet
+
+func (h mockLedgerHub) Wallets() []accounts.Wallet { return h }
+
+func (h mockLedgerHub) Subscribe(chan<- accounts.WalletEvent) event.Subscription { return nil }
+
+func TestNewLedgerSignerWithBackend(t *testing.T) {
+	t.Parallel()
+	ledger := newMockLedger(t)
+
+	signer, err := store.NewLedgerSignerWithBackend(mockLedgerHub{ledger}, ledgerPath)
+	require.NoError(t, err)
+	account, err := signer.GetAccount()
+	require.NoError(t, err)
+	assert.Equal(t, ledger.address(), account.Address)
+	assert.Equal(t, []accounts.Account{account}, signer.Accounts())
+
+	require.NoError(t, signer.Close())
+	assert.True(t, ledger.isClosed)
+}
+
+func TestNewLedgerSignerWithBackend_Errors(t *testing.T) {
+	t.Parallel()
+	locked := newMockLedger(t)
+	locked.openErr = errors.New("ledger locked")
+	opened := newMockLedger(t)
+	opened.openErr = accounts.ErrWalletAlreadyOpen
+
+	tests := []struct {
+		name    string
+		hub     mockLedgerHub
+		path    string
+		wantErr bool
+	}{
+		{"first ledger", mockLedgerHub{newMockLedger(t), locked}, ledgerPath, false},
+		{"already open", mockLedgerHub{opened}, ledgerPath, false},
+		{"no ledger", mockLedgerHub{}, ledgerPath, true},
+		{"invalid path", mockLedgerHub{newMockLedger(t)}, "m/44'/sixty", true},
+		{"locked", mockLedgerHub{locked}, ledgerPath, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			_, err := store.NewLedgerSignerWithBackend(test.hub, test.path)
+			cltest.AssertError(t, test.wantErr, err)
+		})
+	}
+}
+
+func TestLedgerSigner_SignerFn(t *testing.T) {
+	t.Parallel()
+	ledger := newMockLedger(t)
+	signer, err := store.NewLedgerSignerWithBackend(mockLedgerHub{ledger}, ledgerPath)
+	require.NoError(t, err)
+
+	chainID := uint64(3)
+	ethSigner := types.NewEIP155Signer(big.NewInt(int64(chainID)))
+	tx := types.NewTransaction(1, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(20000000000), nil)
+	signerFn := signer.SignerFn(chainID)
+
+	signed, err := signerFn(ethSigner, ledger.address(), tx)
+	require.NoError(t, err)
+	from, err := types.Sender(ethSigner, signed)
+	require.NoError(t, err)
+	assert.Equal(t, ledger.address(), from)
+
+	_, err = signerFn(ethSigner, cltest.NewAddress(), tx)
+	assert.Error(t, err)
+}
+
+func TestTxManager_CreateTx_SignsWithLedger(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	s := app.Store
+	ledger := newMockLedger(t)
+	signer, err := store.NewLedgerSignerWithBackend(mockLedgerHub{ledger}, ledgerPath)
+	require.NoError(t, err)
+	store.UseTxSigner(s, signer)
+
+	ethMock := app.MockEthClient()
+	ethMock.Context("app.Start()", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(0))
+	})
+	require.NoError(t, app.Start())
+
+	ethMock.Context("manager.CreateTx", func(ethMock *cltest.EthMock) {
+		ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+		ethMock.Register("eth_blockNumber", utils.Uint64ToHex(1))
+	})
+	a, err := s.TxManager.CreateTx(cltest.NewAddress(), []byte{})
+	require.NoError(t, err)
+
+	tx := models.Tx{}
+	require.NoError(t, s.One("ID", a.TxID, &tx))
+	assert.Equal(t, ledger.address(), tx.From)
+	ethMock.EventuallyAllCalled(t)
+}
@@ -9,6 +9,11 @@ import (
 	"github.com/smartcontractkit/chainlink/store/migrations/migration1536696950"
 	"github.com/smartcontractkit/chainlink/store/migrations/migration1536764911"
 	"github.com/smartcontractkit/chainlink/store/migrations/migration1537223654"
+	"github.com/smartcontractkit/chainlink/store/migrations/migration1539722015"
+	"github.com/smartcontractkit/chainlink/store/migrations/migration1541059200"
+	"github.com/smartcontractkit/chainlink/store/migrations/migration1541664000"
+	"github.com/smartcontractkit/chainlink/store/migrations/migration1542240000"
+	"github.com/smartcontractkit/chainlink/store/migrations/migration1542844800"
 	"github.com/smartcontractkit/chainlink/store/orm"
 )
 
@@ -17,6 +22,11 @@ func init() {
 	registerMigration(migration1536696950.Migration{})
 	registerMigration(migration1536764911.Migration{})
 	registerMigration(migration1537223654.Migration{})
+	registerMigration(migration1539722015.Migration{})
+	registerMigration(migration1541059200.Migration{})
+	registerMigration(migration1541664000.Migration{})
+	registerMigration(migration1542240000.Migration{})
+	registerMigration(migration1542844800.Migration{})
 }
 
 type migration interface {
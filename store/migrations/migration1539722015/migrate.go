@@ -0,0 +1,22 @@
+package migration1539722015
+
+import (
+	"github.com/smartcontractkit/chainlink/store/orm"
+)
+
+type Migration struct{}
+
+func (m Migration) Timestamp() string {
+	return "1539722015"
+}
+
+func (m Migration) Migrate(orm *orm.ORM) error {
+	return orm.InitializeModel(&HTTPCredential{})
+}
+
+type HTTPCredential struct {
+	Name     string `json:"name" storm:"id,unique"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
@@ -0,0 +1,28 @@
+package migration1541059200
+
+import (
+	"github.com/smartcontractkit/chainlink/store/migrations/migration0"
+	"github.com/smartcontractkit/chainlink/store/orm"
+)
+
+type Migration struct{}
+
+func (m Migration) Timestamp() string {
+	return "1541059200"
+}
+
+func (m Migration) Migrate(orm *orm.ORM) error {
+	return orm.InitializeModel(&AuditLog{})
+}
+
+type AuditLog struct {
+	ID           uint64               `json:"id" storm:"id,increment"`
+	Actor        string               `json:"actor"`
+	Action       string               `json:"action"`
+	ResourceType string               `json:"resourceType" storm:"index"`
+	ResourceID   string               `json:"resourceId" storm:"index"`
+	BeforeJSON   migration0.Unchanged `json:"beforeJson"`
+	AfterJSON    migration0.Unchanged `json:"afterJson"`
+	IPAddress    string               `json:"ipAddress"`
+	Timestamp    migration0.Time      `json:"timestamp" storm:"index"`
+}
@@ -0,0 +1,24 @@
+package migration1541664000
+
+import (
+	"github.com/smartcontractkit/chainlink/store/migrations/migration0"
+	"github.com/smartcontractkit/chainlink/store/orm"
+)
+
+type Migration struct{}
+
+func (m Migration) Timestamp() string {
+	return "1541664000"
+}
+
+func (m Migration) Migrate(orm *orm.ORM) error {
+	return orm.InitializeModel(&APIKey{})
+}
+
+type APIKey struct {
+	ID           string          `json:"id" storm:"id,unique"`
+	Name         string          `json:"name"`
+	HashedSecret string          `json:"hashedSecret"`
+	Scopes       []string        `json:"scopes"`
+	CreatedAt    migration0.Time `json:"createdAt" storm:"index"`
+}
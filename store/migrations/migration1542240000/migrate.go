@@ -0,0 +1,23 @@
+package migration1542240000
+
+import (
+	"github.com/smartcontractkit/chainlink/store/migrations/migration0"
+	"github.com/smartcontractkit/chainlink/store/orm"
+)
+
+type Migration struct{}
+
+func (m Migration) Timestamp() string {
+	return "1542240000"
+}
+
+func (m Migration) Migrate(orm *orm.ORM) error {
+	return orm.InitializeModel(&EncryptedVRFKey{})
+}
+
+type EncryptedVRFKey struct {
+	PublicKey string          `json:"publicKey" storm:"id,unique"`
+	Hash      string          `json:"hash" storm:"index"`
+	KeyJSON   string          `json:"keyJSON"`
+	CreatedAt migration0.Time `json:"createdAt" storm:"index"`
+}
@@ -0,0 +1,24 @@
+package migration1542844800
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/store/migrations/migration0"
+	"github.com/smartcontractkit/chainlink/store/orm"
+)
+
+type Migration struct{}
+
+func (m Migration) Timestamp() string {
+	return "1542844800"
+}
+
+func (m Migration) Migrate(orm *orm.ORM) error {
+	return orm.InitializeModel(&Upkeep{})
+}
+
+type Upkeep struct {
+	ID              string          `json:"id" storm:"id,unique"`
+	RegistryAddress common.Address  `json:"registryAddress" storm:"index"`
+	UpkeepID        uint64          `json:"upkeepID"`
+	CreatedAt       migration0.Time `json:"createdAt" storm:"index"`
+}
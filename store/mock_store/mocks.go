@@ -12,6 +12,7 @@ import (
 	store "github.com/smartcontractkit/chainlink/store"
 	assets "github.com/smartcontractkit/chainlink/store/assets"
 	models "github.com/smartcontractkit/chainlink/store/models"
+	big "math/big"
 	reflect "reflect"
 )
 
@@ -51,6 +52,45 @@ func (mr *MockTxManagerMockRecorder) CreateTx(to, data interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockTxManager)(nil).CreateTx), to, data)
 }
 
+// CreateTxWithGas mocks base method
+func (m *MockTxManager) CreateTxWithGas(to common.Address, data []byte, gasPrice *big.Int, gasLimit uint64) (*models.Tx, error) {
+	ret := m.ctrl.Call(m, "CreateTxWithGas", to, data, gasPrice, gasLimit)
+	ret0, _ := ret[0].(*models.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTxWithGas indicates an expected call of CreateTxWithGas
+func (mr *MockTxManagerMockRecorder) CreateTxWithGas(to, data, gasPrice, gasLimit interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTxWithGas", reflect.TypeOf((*MockTxManager)(nil).CreateTxWithGas), to, data, gasPrice, gasLimit)
+}
+
+// CreateTxWithValue mocks base method
+func (m *MockTxManager) CreateTxWithValue(to common.Address, value *assets.Eth, data []byte) (*models.Tx, error) {
+	ret := m.ctrl.Call(m, "CreateTxWithValue", to, value, data)
+	ret0, _ := ret[0].(*models.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTxWithValue indicates an expected call of CreateTxWithValue
+func (mr *MockTxManagerMockRecorder) CreateTxWithValue(to, value, data interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTxWithValue", reflect.TypeOf((*MockTxManager)(nil).CreateTxWithValue), to, value, data)
+}
+
+// DeployContract mocks base method
+func (m *MockTxManager) DeployContract(data []byte, gasLimit uint64) (*models.Tx, error) {
+	ret := m.ctrl.Call(m, "DeployContract", data, gasLimit)
+	ret0, _ := ret[0].(*models.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeployContract indicates an expected call of DeployContract
+func (mr *MockTxManagerMockRecorder) DeployContract(data, gasLimit interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployContract", reflect.TypeOf((*MockTxManager)(nil).DeployContract), data, gasLimit)
+}
+
 // ActivateAccount mocks base method
 func (m *MockTxManager) ActivateAccount(account accounts.Account) error {
 	ret := m.ctrl.Call(m, "ActivateAccount", account)
@@ -76,6 +116,19 @@ func (mr *MockTxManagerMockRecorder) MeetsMinConfirmations(hash interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MeetsMinConfirmations", reflect.TypeOf((*MockTxManager)(nil).MeetsMinConfirmations), hash)
 }
 
+// ConfirmedTxReceipt mocks base method
+func (m *MockTxManager) ConfirmedTxReceipt(hash common.Hash) (*store.TxReceipt, error) {
+	ret := m.ctrl.Call(m, "ConfirmedTxReceipt", hash)
+	ret0, _ := ret[0].(*store.TxReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmedTxReceipt indicates an expected call of ConfirmedTxReceipt
+func (mr *MockTxManagerMockRecorder) ConfirmedTxReceipt(hash interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmedTxReceipt", reflect.TypeOf((*MockTxManager)(nil).ConfirmedTxReceipt), hash)
+}
+
 // WithdrawLink mocks base method
 func (m *MockTxManager) WithdrawLink(wr models.WithdrawalRequest) (common.Hash, error) {
 	ret := m.ctrl.Call(m, "WithdrawLink", wr)
@@ -102,6 +155,32 @@ func (mr *MockTxManagerMockRecorder) GetLinkBalance(address interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkBalance", reflect.TypeOf((*MockTxManager)(nil).GetLinkBalance), address)
 }
 
+// GetERC20Balance mocks base method
+func (m *MockTxManager) GetERC20Balance(tokenAddr, holderAddr common.Address) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "GetERC20Balance", tokenAddr, holderAddr)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetERC20Balance indicates an expected call of GetERC20Balance
+func (mr *MockTxManagerMockRecorder) GetERC20Balance(tokenAddr, holderAddr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetERC20Balance", reflect.TypeOf((*MockTxManager)(nil).GetERC20Balance), tokenAddr, holderAddr)
+}
+
+// GetERC20Decimals mocks base method
+func (m *MockTxManager) GetERC20Decimals(tokenAddr common.Address) (uint8, error) {
+	ret := m.ctrl.Call(m, "GetERC20Decimals", tokenAddr)
+	ret0, _ := ret[0].(uint8)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetERC20Decimals indicates an expected call of GetERC20Decimals
+func (mr *MockTxManagerMockRecorder) GetERC20Decimals(tokenAddr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetERC20Decimals", reflect.TypeOf((*MockTxManager)(nil).GetERC20Decimals), tokenAddr)
+}
+
 // GetActiveAccount mocks base method
 func (m *MockTxManager) GetActiveAccount() *store.ActiveAccount {
 	ret := m.ctrl.Call(m, "GetActiveAccount")
@@ -178,3 +257,28 @@ func (m *MockTxManager) GetLogs(q go_ethereum.FilterQuery) ([]store.Log, error)
 func (mr *MockTxManagerMockRecorder) GetLogs(q interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockTxManager)(nil).GetLogs), q)
 }
+
+// CallContract mocks base method
+func (m *MockTxManager) CallContract(to common.Address, data []byte, block string) ([]byte, error) {
+	ret := m.ctrl.Call(m, "CallContract", to, data, block)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallContract indicates an expected call of CallContract
+func (mr *MockTxManagerMockRecorder) CallContract(to, data, block interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContract", reflect.TypeOf((*MockTxManager)(nil).CallContract), to, data, block)
+}
+
+// ForChain mocks base method
+func (m *MockTxManager) ForChain(chainID uint64) store.TxManager {
+	ret := m.ctrl.Call(m, "ForChain", chainID)
+	ret0, _ := ret[0].(store.TxManager)
+	return ret0
+}
+
+// ForChain indicates an expected call of ForChain
+func (mr *MockTxManagerMockRecorder) ForChain(chainID interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForChain", reflect.TypeOf((*MockTxManager)(nil).ForChain), chainID)
+}
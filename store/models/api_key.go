@@ -0,0 +1,156 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// APIKey lets scripts call the API without logging in, by sending
+// "<ID>.<secret>" in the X-API-Key header. Only a bcrypt hash of the secret
+// is kept, so the key cannot be shown again after it is created. The key acts
+// for the user with Email; keys created before keys were bound to a user have
+// none, and act for the user ORM.FindUser returns.
+type APIKey struct {
+	ID           string  `json:"id" storm:"id,unique"`
+	Name         string  `json:"name"`
+	Email        string  `json:"email"`
+	HashedSecret string  `json:"hashedSecret" swaggerignore:"true"`
+	Scopes       []Scope `json:"scopes"`
+	CreatedAt    Time    `json:"createdAt" storm:"index"`
+}
+
+// Scope names the routes an API key may call, as "<resource>:<read|write>".
+type Scope string
+
+const (
+	// ScopeJobsRead allows listing and showing job specs, service agreements
+	// and upkeeps.
+	ScopeJobsRead = Scope("jobs:read")
+	// ScopeJobsWrite allows creating and importing job specs, and adding and
+	// removing upkeeps.
+	ScopeJobsWrite = Scope("jobs:write")
+	// ScopeRunsRead allows listing, showing and streaming job runs.
+	ScopeRunsRead = Scope("runs:read")
+	// ScopeRunsWrite allows starting job runs.
+	ScopeRunsWrite = Scope("runs:write")
+	// ScopeBridgesRead allows listing and showing bridge types.
+	ScopeBridgesRead = Scope("bridges:read")
+	// ScopeBridgesWrite allows creating, updating and deleting bridge types.
+	ScopeBridgesWrite = Scope("bridges:write")
+	// ScopeCredentialsRead allows listing HTTP credentials.
+	ScopeCredentialsRead = Scope("credentials:read")
+	// ScopeCredentialsWrite allows creating and deleting HTTP credentials.
+	ScopeCredentialsWrite = Scope("credentials:write")
+	// ScopeNodeRead allows reading the node's configuration, logs, metrics,
+	// backup, audit log and VRF keys.
+	ScopeNodeRead = Scope("node:read")
+	// ScopeNodeWrite allows changing the log level, withdrawing funds and
+	// creating VRF keys.
+	ScopeNodeWrite = Scope("node:write")
+	// ScopeUserRead allows reading the account's balances and the roles.
+	ScopeUserRead = Scope("user:read")
+	// ScopeUserWrite allows changing passwords, TOTP, roles and sessions.
+	ScopeUserWrite = Scope("user:write")
+	// ScopeAPIKeysRead allows listing API keys.
+	ScopeAPIKeysRead = Scope("api_keys:read")
+	// ScopeAPIKeysWrite allows creating and revoking API keys.
+	ScopeAPIKeysWrite = Scope("api_keys:write")
+)
+
+// Scopes lists every scope.
+var Scopes = []Scope{
+	ScopeJobsRead, ScopeJobsWrite,
+	ScopeRunsRead, ScopeRunsWrite,
+	ScopeBridgesRead, ScopeBridgesWrite,
+	ScopeCredentialsRead, ScopeCredentialsWrite,
+	ScopeNodeRead, ScopeNodeWrite,
+	ScopeUserRead, ScopeUserWrite,
+	ScopeAPIKeysRead, ScopeAPIKeysWrite,
+}
+
+// apiKeySecretLength is the number of random bytes in an API key's secret.
+const apiKeySecretLength = 32
+
+// NewAPIKey creates a key for the user with email, with the given name and
+// limited to scopes unless none are given. It returns the key, and the value
+// to send in the X-API-Key header, which is not stored.
+func NewAPIKey(email, name string, scopes []Scope) (APIKey, string, error) {
+	if name == "" {
+		return APIKey{}, "", errors.New("API key must have a name")
+	}
+	for _, s := range scopes {
+		if !s.valid() {
+			return APIKey{}, "", fmt.Errorf("unknown scope %q, must be one of %v", s, Scopes)
+		}
+	}
+
+	b := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return APIKey{}, "", err
+	}
+	secret := hex.EncodeToString(b)
+	hashed, err := utils.HashPassword(secret)
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	key := APIKey{
+		ID:           utils.NewBytes32ID(),
+		Name:         name,
+		Email:        email,
+		HashedSecret: hashed,
+		Scopes:       scopes,
+		CreatedAt:    Time{Time: time.Now()},
+	}
+	return key, key.ID + "." + secret, nil
+}
+
+// ParseAPIKey splits the value of an X-API-Key header into the key's ID and
+// its secret.
+func ParseAPIKey(value string) (string, string, error) {
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("malformed API key")
+	}
+	return parts[0], parts[1], nil
+}
+
+// CheckSecret returns whether secret is the key's.
+func (k APIKey) CheckSecret(secret string) bool {
+	return utils.CheckPasswordHash(secret, k.HashedSecret)
+}
+
+// HasScope returns whether the key may call routes requiring scope. Keys
+// created without any scopes may call every route.
+func (k APIKey) HasScope(scope Scope) bool {
+	if len(k.Scopes) == 0 {
+		return true
+	}
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Scope) valid() bool {
+	for _, scope := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyRequest creates an API key with the given name and scopes.
+type APIKeyRequest struct {
+	Name   string  `json:"name"`
+	Scopes []Scope `json:"scopes"`
+}
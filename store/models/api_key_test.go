@@ -0,0 +1,51 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Parallel()
+
+	key, value, err := models.NewAPIKey("deployer@chain.link", "deployer", []models.Scope{models.ScopeJobsWrite})
+	require.NoError(t, err)
+	assert.Equal(t, "deployer", key.Name)
+	assert.Equal(t, "deployer@chain.link", key.Email)
+	assert.True(t, strings.HasPrefix(value, key.ID+"."))
+
+	id, secret, err := models.ParseAPIKey(value)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, id)
+	assert.True(t, key.CheckSecret(secret))
+	assert.False(t, key.CheckSecret(secret+"0"))
+
+	_, _, err = models.NewAPIKey("deployer@chain.link", "", nil)
+	assert.Error(t, err)
+	_, _, err = models.NewAPIKey("deployer@chain.link", "deployer", []models.Scope{"jobs:delete"})
+	assert.Error(t, err)
+}
+
+func TestParseAPIKey_Malformed(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "nodot", ".secret", "id."} {
+		_, _, err := models.ParseAPIKey(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	t.Parallel()
+
+	scoped := models.APIKey{Scopes: []models.Scope{models.ScopeJobsRead}}
+	assert.True(t, scoped.HasScope(models.ScopeJobsRead))
+	assert.False(t, scoped.HasScope(models.ScopeJobsWrite))
+
+	unscoped := models.APIKey{}
+	assert.True(t, unscoped.HasScope(models.ScopeNodeWrite))
+}
@@ -0,0 +1,17 @@
+package models
+
+// AuditLog records a change made through the API: who made it, from where,
+// and the state of the resource before and after.
+type AuditLog struct {
+	ID uint64 `json:"id" storm:"id,increment"`
+	// Actor is the email of the user who made the change, or empty when the
+	// route needs no session.
+	Actor        string `json:"actor"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType" storm:"index"`
+	ResourceID   string `json:"resourceId" storm:"index"`
+	BeforeJSON   JSON   `json:"beforeJson"`
+	AfterJSON    JSON   `json:"afterJson"`
+	IPAddress    string `json:"ipAddress"`
+	Timestamp    Time   `json:"timestamp" storm:"index"`
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/store/assets"
@@ -33,10 +35,19 @@ const (
 	RunStatusPendingBridge = RunStatus("pending_bridge")
 	// RunStatusPendingSleep is used for when a run is waiting on a sleep function to finish.
 	RunStatusPendingSleep = RunStatus("pending_sleep")
+	// RunStatusPendingReview is used for when a run has been held back for an
+	// operator to review its result.
+	RunStatusPendingReview = RunStatus("pending_review")
+	// RunStatusPendingRetry is used for when a run is waiting to attempt a
+	// failed task again.
+	RunStatusPendingRetry = RunStatus("pending_retry")
 	// RunStatusErrored is used for when a run has errored and will not complete.
 	RunStatusErrored = RunStatus("errored")
 	// RunStatusCompleted is used for when a run has successfully completed execution.
 	RunStatusCompleted = RunStatus("completed")
+	// RunStatusAborted is used for when a task has ended its run early, without
+	// error, skipping any remaining tasks.
+	RunStatusAborted = RunStatus("aborted")
 )
 
 // Unstarted returns true if the status is the initial state.
@@ -59,11 +70,26 @@ func (s RunStatus) PendingSleep() bool {
 	return s == RunStatusPendingSleep
 }
 
+// PendingReview returns true if the status is pending_review.
+func (s RunStatus) PendingReview() bool {
+	return s == RunStatusPendingReview
+}
+
+// PendingRetry returns true if the status is pending_retry.
+func (s RunStatus) PendingRetry() bool {
+	return s == RunStatusPendingRetry
+}
+
 // Completed returns true if the status is RunStatusCompleted.
 func (s RunStatus) Completed() bool {
 	return s == RunStatusCompleted
 }
 
+// Aborted returns true if the status is RunStatusAborted.
+func (s RunStatus) Aborted() bool {
+	return s == RunStatusAborted
+}
+
 // Errored returns true if the status is RunStatusErrored.
 func (s RunStatus) Errored() bool {
 	return s == RunStatusErrored
@@ -71,17 +97,17 @@ func (s RunStatus) Errored() bool {
 
 // Pending returns true if the status is pending external or confirmations.
 func (s RunStatus) Pending() bool {
-	return s.PendingBridge() || s.PendingConfirmations() || s.PendingSleep()
+	return s.PendingBridge() || s.PendingConfirmations() || s.PendingSleep() || s.PendingReview() || s.PendingRetry()
 }
 
 // Finished returns true if the status is final and can't be changed.
 func (s RunStatus) Finished() bool {
-	return s.Completed() || s.Errored()
+	return s.Completed() || s.Errored() || s.Aborted()
 }
 
 // Runnable returns true if the status is ready to be run.
 func (s RunStatus) Runnable() bool {
-	return !s.Errored() && !s.Pending()
+	return !s.Errored() && !s.Pending() && !s.Aborted()
 }
 
 // CanStart returns true if the run is ready to begin processed.
@@ -149,19 +175,19 @@ func (j JSON) MarshalJSON() ([]byte, error) {
 	return []byte("{}"), nil
 }
 
-// Merge combines the given JSON with the existing JSON.
+// Merge combines the given JSON with the existing JSON, preferring the keys
+// of the given JSON. Values are copied as written, so numbers keep their
+// full precision.
 func (j JSON) Merge(j2 JSON) (JSON, error) {
-	body := j.Map()
-	for key, value := range j2.Map() {
-		body[key] = value
+	body := map[string]json.RawMessage{}
+	for key, value := range j.Map() {
+		body[key] = json.RawMessage(value.Raw)
 	}
-
-	cleaned := map[string]interface{}{}
-	for k, v := range body {
-		cleaned[k] = v.Value()
+	for key, value := range j2.Map() {
+		body[key] = json.RawMessage(value.Raw)
 	}
 
-	b, err := json.Marshal(cleaned)
+	b, err := json.Marshal(body)
 	if err != nil {
 		return JSON{}, err
 	}
@@ -258,11 +284,37 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &n); err != nil {
 		return err
 	}
+	if unix, ok := parseFractionalUnix(n.String()); ok {
+		t.Time = unix
+		return nil
+	}
 	newTime, err := dateparse.ParseAny(n.String())
 	t.Time = newTime.UTC()
 	return err
 }
 
+// parseFractionalUnix parses Unix times in seconds with a fraction, such as
+// 872835240.25, to the millisecond, which dateparse does not.
+func parseFractionalUnix(s string) (time.Time, bool) {
+	parts := strings.SplitN(s, ".", 2)
+	if len(parts) != 2 {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	frac, err := strconv.ParseFloat("0."+parts[1], 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	nanos := time.Duration(frac * float64(time.Second))
+	if strings.HasPrefix(parts[0], "-") {
+		nanos = -nanos
+	}
+	return time.Unix(secs, 0).Add(nanos).Round(time.Millisecond).UTC(), true
+}
+
 // ISO8601 formats and returns the time in ISO 8601 standard.
 func (t Time) ISO8601() string {
 	return t.UTC().Format("2006-01-02T15:04:05Z07:00")
@@ -279,6 +331,77 @@ func (t Time) HumanString() string {
 	return utils.ISO8601UTC(t.Time)
 }
 
+// MillisecondTime is a time kept to the millisecond, written in JSON as
+// ISO 8601 with fractional seconds, such as "2018-06-01T12:00:00.250Z". The
+// zero MillisecondTime is written as null.
+type MillisecondTime struct {
+	time.Time
+}
+
+// millisecondFormat is ISO 8601, always with milliseconds.
+const millisecondFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// NewMillisecondTime returns t, rounded to the millisecond.
+func NewMillisecondTime(t time.Time) MillisecondTime {
+	if t.IsZero() {
+		return MillisecondTime{}
+	}
+	return MillisecondTime{t.Round(time.Millisecond).UTC()}
+}
+
+// MarshalJSON returns the time as a JSON string, or null when it is zero.
+func (t MillisecondTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(millisecondFormat))
+}
+
+// UnmarshalJSON parses an ISO 8601 JSON string, or null, into the time.
+func (t *MillisecondTime) UnmarshalJSON(input []byte) error {
+	var str *string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	if str == nil {
+		*t = MillisecondTime{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, *str)
+	if err != nil {
+		return err
+	}
+	*t = NewMillisecondTime(parsed)
+	return nil
+}
+
+// Duration is a time.Duration, written in JSON as a string such as "1m30s".
+type Duration time.Duration
+
+// Duration returns the Duration as a time.Duration.
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// MarshalJSON returns the duration as a JSON string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration().String())
+}
+
+// UnmarshalJSON parses a JSON string such as "30s" into the Duration.
+func (d *Duration) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	td, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = Duration(td)
+	return nil
+}
+
 // Cron holds the string that will represent the spec of the cron-job.
 // It uses 6 fields to represent the seconds (1), minutes (2), hours (3),
 // day of the month (4), month (5), and day of the week (6).
@@ -315,6 +438,12 @@ type WithdrawalRequest struct {
 	Amount  *assets.Link   `json:"amount"`
 }
 
+// LogLevelRequest is the body of a request to change the node's log level,
+// such as {"level":"debug"}.
+type LogLevelRequest struct {
+	Level string `json:"level"`
+}
+
 // Int stores large integers and can deserialize a variety of inputs.
 type Int big.Int
 
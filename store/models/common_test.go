@@ -83,6 +83,8 @@ func TestJSON_Merge(t *testing.T) {
 			`{"value":"OLD","other":1}`, false},
 		{"null values", `{"value":null}`,
 			`{"value":null,"other":1}`, false},
+		{"large numbers", `{"extra":3405678900000000000001}`,
+			`{"value":"OLD","other":1,"extra":3405678900000000000001}`, false},
 	}
 
 	for _, test := range tests {
@@ -285,6 +287,9 @@ func TestTime_UnmarshalJSON(t *testing.T) {
 		{"iso8601 time", `"2018-06-19T22:17:19Z"`, time.Unix(1529446639, 0).UTC(), false},
 		{"iso8601 date", `"2018-06-19"`, time.Unix(1529366400, 0).UTC(), false},
 		{"iso8601 year", `"2018"`, time.Unix(1514764800, 0).UTC(), false},
+		{"unix with fraction", `872835240.25`, time.Unix(872835240, 250000000).UTC(), false},
+		{"unix string with fraction", `"872835240.2504"`, time.Unix(872835240, 250000000).UTC(), false},
+		{"iso8601 time with fraction", `"2018-06-19T22:17:19.250Z"`, time.Unix(1529446639, 250000000).UTC(), false},
 		{"invalid string", `"1000h"`, time.Now(), true},
 	}
 
@@ -309,6 +314,46 @@ func TestTime_DurationFromNow(t *testing.T) {
 	assert.True(t, 0 < duration)
 }
 
+func TestMillisecondTime_JSON(t *testing.T) {
+	t.Parallel()
+
+	mt := models.NewMillisecondTime(time.Date(2018, 6, 1, 12, 0, 0, 250400000, time.UTC))
+	b, err := json.Marshal(mt)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2018-06-01T12:00:00.250Z"`, string(b))
+
+	b, err = json.Marshal(models.NewMillisecondTime(time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)))
+	assert.NoError(t, err)
+	assert.Equal(t, `"2018-06-01T12:00:00.000Z"`, string(b), "fractional seconds are always written")
+
+	var parsed models.MillisecondTime
+	assert.NoError(t, json.Unmarshal([]byte(`"2018-06-01T12:00:00.250Z"`), &parsed))
+	assert.True(t, mt.Equal(parsed.Time))
+
+	b, err = json.Marshal(models.MillisecondTime{})
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &parsed))
+	assert.True(t, parsed.IsZero())
+
+	assert.Error(t, json.Unmarshal([]byte(`"soon"`), &parsed))
+}
+
+func TestDuration_JSON(t *testing.T) {
+	t.Parallel()
+
+	var d models.Duration
+	assert.NoError(t, json.Unmarshal([]byte(`"1m30s"`), &d))
+	assert.Equal(t, 90*time.Second, d.Duration())
+
+	b, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `"1m30s"`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`"soon"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`90`), &d))
+}
+
 func TestInt_UnmarshalText(t *testing.T) {
 	t.Parallel()
 
@@ -29,6 +29,20 @@ func NewValidationError(msg string, values ...interface{}) error {
 	return &ValidationError{msg: fmt.Sprintf(msg, values...)}
 }
 
+// PermanentError is an error which attempting the task again will not fix,
+// such as a request rejected by the server or arguments which cannot be
+// encoded. A task failing with one errors its run without being retried.
+type PermanentError struct {
+	err error
+}
+
+func (e *PermanentError) Error() string { return e.err.Error() }
+
+// NewPermanentError marks err as permanent.
+func NewPermanentError(err error) error {
+	return &PermanentError{err}
+}
+
 // JSONAPIErrors holds errors conforming to the JSONAPI spec.
 type JSONAPIErrors struct {
 	Errors []JSONAPIError `json:"errors"`
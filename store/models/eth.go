@@ -1,13 +1,16 @@
 package models
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/chainlink/utils"
 )
 
 // Tx contains fields necessary for an Ethereum transaction with
@@ -20,12 +23,22 @@ type Tx struct {
 	Nonce    uint64 `storm:"index"`
 	Value    *big.Int
 	GasLimit uint64
+	// ContractCreation is set for transactions deploying a contract, which
+	// have no To address.
+	ContractCreation bool
+	// BumpRejected is set once the node rejects a gas bump of the
+	// transaction for a reason no gas price will fix, so that it is not
+	// bumped again.
+	BumpRejected bool
 	TxAttempt
 }
 
 // EthTx creates a new Ethereum transaction with a given gasPrice
 // that is ready to be signed.
 func (tx *Tx) EthTx(gasPrice *big.Int) *types.Transaction {
+	if tx.ContractCreation {
+		return types.NewContractCreation(tx.Nonce, tx.Value, tx.GasLimit, gasPrice, tx.Data)
+	}
 	return types.NewTransaction(
 		tx.Nonce,
 		tx.To,
@@ -89,7 +102,10 @@ func (f *FunctionSelector) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
-	bytes := common.FromHex(s)
+	bytes, err := hex.DecodeString(utils.RemoveHexPrefix(s))
+	if err != nil {
+		return fmt.Errorf("Function ID must be hex encoded: %v", err)
+	}
 	if len(bytes) != FunctionSelectorLength {
 		return errors.New("Function ID must be 4 bytes in length")
 	}
@@ -42,6 +42,27 @@ func TestModels_FunctionSelectorUnmarshalJSONError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestModels_FunctionSelectorUnmarshalJSON_RejectsMalformedHex(t *testing.T) {
+	t.Parallel()
+	tests := []string{`"0xb3f98ad"`, `"0xb3f98adcc"`, `"0xb3f98adz"`, `"b3f98ad"`, `""`}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test, func(t *testing.T) {
+			t.Parallel()
+			var fid models.FunctionSelector
+			assert.Error(t, json.Unmarshal([]byte(test), &fid))
+		})
+	}
+}
+
+func TestModels_FunctionSelectorUnmarshalJSON_WithoutPrefix(t *testing.T) {
+	t.Parallel()
+	var fid models.FunctionSelector
+	assert.NoError(t, json.Unmarshal([]byte(`"b3f98adc"`), &fid))
+	assert.Equal(t, "0xb3f98adc", fid.String())
+}
+
 func TestModels_Header_UnmarshalJSON(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
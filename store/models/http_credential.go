@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// HTTPCredential is a named secret used by the HTTP adapters to authenticate
+// their requests, so that job specs only need to reference it by name.
+// It holds either a username and password for basic auth or a bearer token,
+// which are only sent to URLs under URLPrefix, so that a job cannot send
+// them to a server of its own choosing.
+type HTTPCredential struct {
+	Name      string `json:"name" storm:"id,unique"`
+	URLPrefix string `json:"urlPrefix"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	Token     string `json:"token,omitempty"`
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (hc HTTPCredential) GetID() string {
+	return hc.Name
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (hc HTTPCredential) GetName() string {
+	return "http_credentials"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (hc *HTTPCredential) SetID(value string) error {
+	hc.Name = value
+	return nil
+}
+
+// Validate ensures the credential has a name, an absolute URL prefix, and
+// exactly one kind of secret.
+func (hc HTTPCredential) Validate() error {
+	if hc.Name == "" {
+		return errors.New("credential must have a name")
+	}
+	if prefix, err := url.Parse(hc.URLPrefix); err != nil || prefix.Scheme == "" || prefix.Host == "" {
+		return errors.New("credential must have a urlPrefix with a scheme and host, such as https://api.example.com/")
+	}
+	basic := hc.Username != "" || hc.Password != ""
+	bearer := hc.Token != ""
+	if basic == bearer {
+		return errors.New("credential must have either a username and password, or a token")
+	}
+	return nil
+}
+
+// Scheme returns "Basic" or "Bearer" depending on the kind of secret held.
+func (hc HTTPCredential) Scheme() string {
+	if hc.Token != "" {
+		return "Bearer"
+	}
+	return "Basic"
+}
+
+// AuthorizationHeader returns the value of the Authorization header for
+// requests authenticated with this credential.
+func (hc HTTPCredential) AuthorizationHeader() string {
+	if hc.Token != "" {
+		return "Bearer " + hc.Token
+	}
+	userpass := hc.Username + ":" + hc.Password
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(userpass))
+}
+
+// Allows returns whether the credential may be sent with a request to
+// rawURL, which must have the scheme and host of URLPrefix, and a path
+// beginning with its path. Credentials saved without a URLPrefix are not
+// sent anywhere.
+func (hc HTTPCredential) Allows(rawURL string) bool {
+	prefix, err := url.Parse(hc.URLPrefix)
+	if err != nil || prefix.Host == "" {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, prefix.Scheme) &&
+		strings.EqualFold(u.Host, prefix.Host) &&
+		strings.HasPrefix(absolutePath(u), absolutePath(prefix))
+}
+
+func absolutePath(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
@@ -0,0 +1,79 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPCredential_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		credential models.HTTPCredential
+		wantError  bool
+	}{
+		{"basic", models.HTTPCredential{Name: "a", URLPrefix: "https://example.com/", Username: "user", Password: "pass"}, false},
+		{"bearer", models.HTTPCredential{Name: "a", URLPrefix: "https://example.com/", Token: "token"}, false},
+		{"no name", models.HTTPCredential{URLPrefix: "https://example.com/", Token: "token"}, true},
+		{"no secret", models.HTTPCredential{Name: "a", URLPrefix: "https://example.com/"}, true},
+		{"both", models.HTTPCredential{Name: "a", URLPrefix: "https://example.com/", Username: "user", Token: "token"}, true},
+		{"no url prefix", models.HTTPCredential{Name: "a", Token: "token"}, true},
+		{"relative url prefix", models.HTTPCredential{Name: "a", URLPrefix: "/api", Token: "token"}, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.credential.Validate()
+			if test.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPCredential_Allows(t *testing.T) {
+	t.Parallel()
+
+	hc := models.HTTPCredential{Name: "a", URLPrefix: "https://api.example.com/v1/", Token: "token"}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://api.example.com/v1/prices?symbol=ETH", true},
+		{"https://API.example.com/v1/", true},
+		{"https://api.example.com/v2/prices", false},
+		{"http://api.example.com/v1/prices", false},
+		{"https://api.example.com.evil.com/v1/prices", false},
+		{"https://api.example.com:8443/v1/prices", false},
+		{"https://evil.com/v1/?https://api.example.com/v1/", false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.url, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, hc.Allows(test.url))
+		})
+	}
+
+	assert.False(t, models.HTTPCredential{Name: "a", Token: "token"}.Allows("https://api.example.com/"), "saved without a prefix")
+}
+
+func TestHTTPCredential_AuthorizationHeader(t *testing.T) {
+	t.Parallel()
+
+	basic := models.HTTPCredential{Name: "a", Username: "Aladdin", Password: "open sesame"}
+	assert.Equal(t, "Basic", basic.Scheme())
+	assert.Equal(t, "Basic QWxhZGRpbjpvcGVuIHNlc2FtZQ==", basic.AuthorizationHeader())
+
+	bearer := models.HTTPCredential{Name: "a", Token: "abc123"}
+	assert.Equal(t, "Bearer", bearer.Scheme())
+	assert.Equal(t, "Bearer abc123", bearer.AuthorizationHeader())
+}
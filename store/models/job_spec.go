@@ -67,6 +67,47 @@ func NewJobFromRequest(jsr JobSpecRequest) JobSpec {
 	return jobSpec
 }
 
+// JobSpecExport is the canonical document describing a job spec, used to
+// move it between nodes. It leaves out the IDs the node gives to the job's
+// initiators, which are only meaningful in its own database.
+type JobSpecExport struct {
+	ID        string `json:"id"`
+	CreatedAt Time   `json:"createdAt"`
+	JobSpecRequest
+}
+
+// Export returns the job spec as a JobSpecExport.
+func (j JobSpec) Export() JobSpecExport {
+	initiators := make([]Initiator, len(j.Initiators))
+	for i, initr := range j.Initiators {
+		initr.ID = 0
+		initr.JobID = ""
+		initiators[i] = initr
+	}
+
+	export := JobSpecExport{ID: j.ID, CreatedAt: j.CreatedAt, JobSpecRequest: j.JobSpecRequest}
+	export.Initiators = initiators
+	return export
+}
+
+// ToJobSpec returns a new job spec with the exported initiators and tasks. It
+// keeps the exported ID and creation time when preserveID is true, and is
+// otherwise given a new ID like any other job.
+func (e JobSpecExport) ToJobSpec(preserveID bool) JobSpec {
+	js := NewJobFromRequest(e.JobSpecRequest)
+	if preserveID {
+		js.ID = e.ID
+		js.CreatedAt = e.CreatedAt
+	}
+	js.Initiators = make([]Initiator, len(e.Initiators))
+	for i, initr := range e.Initiators {
+		initr.ID = 0
+		initr.JobID = js.ID
+		js.Initiators[i] = initr
+	}
+	return js
+}
+
 // NewRun initializes the job by creating the IDs for the job
 // and all associated tasks, and setting the CreatedAt field.
 func (j JobSpec) NewRun(i Initiator) JobRun {
@@ -157,6 +198,10 @@ const (
 	// InitiatorServiceAgreementExecutionLog for tasks in a job to watch a
 	// Solidity Coordinator contract and expect a payload from a log event.
 	InitiatorServiceAgreementExecutionLog = "execagreement"
+	// InitiatorFluxMonitor for tasks in a job fetching the answer to a
+	// FluxAggregator contract, which the node submits when it deviates from
+	// the contract's.
+	InitiatorFluxMonitor = "fluxmonitor"
 )
 
 // Initiator could be thought of as a trigger, defines how a Job can be
@@ -179,6 +224,17 @@ type InitiatorParams struct {
 	Ran        bool             `json:"ran,omitempty"`
 	Address    common.Address   `json:"address,omitempty" storm:"index"`
 	Requesters []common.Address `json:"requesters,omitempty"`
+	FluxMonitorJob
+}
+
+// FluxMonitorJob holds the parameters of a "fluxmonitor" initiator: the
+// FluxAggregator contract to answer, how far, as a percentage, the answer
+// fetched by the job's tasks must be from the contract's before it is
+// submitted, and how often it is fetched.
+type FluxMonitorJob struct {
+	ContractAddress    common.Address `json:"contractAddress,omitempty"`
+	DeviationThreshold float64        `json:"threshold,omitempty"`
+	PollInterval       Duration       `json:"pollInterval,omitempty"`
 }
 
 // UnmarshalJSON parses the raw initiator data and updates the
@@ -204,9 +260,17 @@ func (i Initiator) IsLogInitiated() bool {
 // TaskSpec is the definition of work to be carried out. The
 // Type will be an adapter, and the Params will contain any
 // additional information that adapter would need to operate.
+//
+// Confirmations holds a run initiated by a log at this task, until the block
+// holding the log is that many blocks deep.
+//
+// A task which fails is attempted up to MaxRetries more times, RetryDelay
+// after each failure, before its run errors.
 type TaskSpec struct {
 	Type          TaskType `json:"type" storm:"index"`
 	Confirmations uint64   `json:"confirmations"`
+	MaxRetries    uint64   `json:"maxRetries,omitempty"`
+	RetryDelay    Duration `json:"retryDelay,omitempty"`
 	Params        JSON     `json:"params"`
 }
 
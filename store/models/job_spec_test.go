@@ -155,3 +155,32 @@ func TestBridgeType_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestJobSpec_ExportRoundTrip(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j, _ := cltest.NewJobWithSchedule("* * * * 7")
+	j.Tasks = []models.TaskSpec{cltest.NewTask("httpget", `{"get":"https://example.com"}`), cltest.NewTask("noop")}
+	assert.NoError(t, store.SaveJob(&j))
+	j, err := store.FindJob(j.ID)
+	assert.NoError(t, err)
+	assert.NotZero(t, j.Initiators[0].ID)
+
+	export := j.Export()
+	assert.Equal(t, j.ID, export.ID)
+	assert.Zero(t, export.Initiators[0].ID)
+	assert.Empty(t, export.Initiators[0].JobID)
+	assert.Equal(t, j.Tasks, export.Tasks)
+
+	preserved := export.ToJobSpec(true)
+	assert.Equal(t, j.ID, preserved.ID)
+	assert.Equal(t, j.ID, preserved.Initiators[0].JobID)
+	assert.Equal(t, j.Initiators[0].Schedule, preserved.Initiators[0].Schedule)
+
+	renamed := export.ToJobSpec(false)
+	assert.NotEqual(t, j.ID, renamed.ID)
+	assert.Equal(t, renamed.ID, renamed.Initiators[0].JobID)
+	assert.Empty(t, export.Initiators[0].JobID)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// OAuth2Token is an access token obtained by the OAuth2 adapter, kept until
+// it expires so that it can be reused by later runs.
+type OAuth2Token struct {
+	AccessToken string    `json:"accessToken"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// Valid returns true if the token has not yet expired at the given time.
+func (t OAuth2Token) Valid(now time.Time) bool {
+	return t.AccessToken != "" && now.Before(t.ExpiresAt)
+}
@@ -2,9 +2,12 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/tidwall/gjson"
@@ -23,6 +26,7 @@ type JobRun struct {
 	CompletedAt    null.Time    `json:"completedAt"`
 	Initiator      Initiator    `json:"initiator"`
 	CreationHeight *hexutil.Big `json:"creationHeight"`
+	CreationHash   *common.Hash `json:"creationHash,omitempty"`
 	ObservedHeight *hexutil.Big `json:"observedHeight"`
 	Overrides      RunResult    `json:"overrides"`
 }
@@ -46,14 +50,17 @@ func (jr *JobRun) SetID(value string) error {
 // ForLogger formats the JobRun for a common formatting in the log.
 func (jr JobRun) ForLogger(kvs ...interface{}) []interface{} {
 	output := []interface{}{
-		"job", jr.JobID,
-		"run", jr.ID,
+		"job_id", jr.JobID,
+		"run_id", jr.ID,
 		"status", jr.Status,
 	}
 
 	if jr.CreationHeight != nil {
 		output = append(output, "creation_height", jr.CreationHeight.ToInt())
 	}
+	if jr.CreationHash != nil {
+		output = append(output, "creation_hash", jr.CreationHash.Hex())
+	}
 
 	if jr.Result.HasError() {
 		output = append(output, "job_error", jr.Result.Error())
@@ -101,12 +108,32 @@ func (jr JobRun) TasksRemain() bool {
 func (jr JobRun) ApplyResult(result RunResult) JobRun {
 	jr.Result = result
 	jr.Status = result.Status
-	if jr.Status.Completed() {
+	if jr.Status.Completed() || jr.Status.Aborted() {
 		jr.CompletedAt = null.Time{Time: time.Now(), Valid: true}
 	}
 	return jr
 }
 
+// StatusUpdate describes the JobRun's current status, for those watching it
+// change.
+func (jr JobRun) StatusUpdate() RunStatusUpdate {
+	return RunStatusUpdate{
+		RunID:     jr.ID,
+		JobID:     jr.JobID,
+		Status:    jr.Status,
+		Timestamp: time.Now(),
+	}
+}
+
+// RunStatusUpdate is sent to clients watching runs whenever a run's status
+// changes.
+type RunStatusUpdate struct {
+	RunID     string    `json:"runId"`
+	JobID     string    `json:"jobId"`
+	Status    RunStatus `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // MarkCompleted sets the JobRun's status to completed and records the
 // completed at time.
 func (jr JobRun) MarkCompleted() JobRun {
@@ -118,12 +145,22 @@ func (jr JobRun) MarkCompleted() JobRun {
 
 // TaskRun stores the Task and represents the status of the
 // Task to be ran.
+//
+// Attempts counts the times the task has been started, and ErrorHistory holds
+// the error of each failed attempt before the last, which is retried at
+// RetryAt.
 type TaskRun struct {
 	ID                   string    `json:"id" storm:"id,unique"`
 	Result               RunResult `json:"result"`
 	Status               RunStatus `json:"status"`
 	Task                 TaskSpec  `json:"task"`
 	MinimumConfirmations uint64    `json:"minimumConfirmations"`
+	Attempts             uint64    `json:"attempts"`
+	ErrorHistory         []string  `json:"errorHistory,omitempty"`
+	RetryAt              null.Time `json:"retryAt"`
+	// When a sleeping task wakes, fixed as it falls asleep so that a
+	// sleep for a duration is not restarted by the node restarting.
+	SleepUntil MillisecondTime `json:"sleepUntil"`
 }
 
 // String returns info on the TaskRun as "ID,Type,Status,Result".
@@ -136,7 +173,7 @@ func (tr TaskRun) ForLogger(kvs ...interface{}) []interface{} {
 	output := []interface{}{
 		"type", tr.Task.Type,
 		"params", tr.Task.Params,
-		"taskrun", tr.ID,
+		"task_id", tr.ID,
 		"status", tr.Status,
 	}
 
@@ -154,6 +191,48 @@ func (tr TaskRun) ApplyResult(result RunResult) TaskRun {
 	return tr
 }
 
+// StartAttempt counts another attempt at the task, clearing the error of the
+// attempt before it, if it is being retried.
+func (tr TaskRun) StartAttempt() TaskRun {
+	tr.Attempts++
+	if tr.Status.PendingRetry() {
+		tr.Result = RunResult{JobRunID: tr.Result.JobRunID}
+		tr.Status = RunStatusUnstarted
+		tr.RetryAt = null.Time{}
+	}
+	return tr
+}
+
+// RetriesRemain returns true if the task has failed, but may be attempted
+// again: its error is not permanent, and it has been retried fewer than
+// MaxRetries times.
+func (tr TaskRun) RetriesRemain() bool {
+	return tr.Status.Errored() && !tr.Result.Permanent() && tr.Attempts <= tr.Task.MaxRetries
+}
+
+// MarkPendingRetry adds the failed attempt's error to the history, and marks
+// the task as waiting to be attempted again at retryAt.
+func (tr TaskRun) MarkPendingRetry(retryAt time.Time) TaskRun {
+	tr.ErrorHistory = append(append([]string{}, tr.ErrorHistory...), tr.Result.Error())
+	tr.RetryAt = null.TimeFrom(retryAt)
+	tr.Status = RunStatusPendingRetry
+	tr.Result.Status = RunStatusPendingRetry
+	return tr
+}
+
+// RetriedError returns the error of the task's last attempt, along with those
+// of the attempts before it when it was retried.
+func (tr TaskRun) RetriedError() error {
+	if len(tr.ErrorHistory) == 0 {
+		return errors.New(tr.Result.Error())
+	}
+	attempts := append(append([]string{}, tr.ErrorHistory...), tr.Result.Error())
+	for i, err := range attempts {
+		attempts[i] = fmt.Sprintf("attempt %d: %s", i+1, err)
+	}
+	return fmt.Errorf("failed after %d attempts: %s", len(attempts), strings.Join(attempts, "; "))
+}
+
 // MarkCompleted marks the task's status as completed.
 func (tr TaskRun) MarkCompleted() TaskRun {
 	tr.Status = RunStatusCompleted
@@ -176,12 +255,20 @@ type RunResult struct {
 	Status       RunStatus    `json:"status"`
 	ErrorMessage null.String  `json:"error"`
 	Amount       *assets.Link `json:"amount,omitempty"`
+	permanent    bool
 }
 
 // WithValue returns a copy of the RunResult, overriding the "value" field of
 // Data and setting the status to completed.
 func (rr RunResult) WithValue(val string) RunResult {
-	data, err := rr.Data.Add("value", val)
+	return rr.Add("value", val)
+}
+
+// Add returns a copy of the RunResult with key set to val in Data, keeping
+// every other key of Data, and with the status set to completed. Adapters
+// use it so that the keys written by earlier tasks are carried forward.
+func (rr RunResult) Add(key string, val interface{}) RunResult {
+	data, err := rr.Data.Add(key, val)
 	if err != nil {
 		return rr.WithError(err)
 	}
@@ -206,6 +293,7 @@ func (rr RunResult) WithNull() RunResult {
 func (rr RunResult) WithError(err error) RunResult {
 	rr.ErrorMessage = null.StringFrom(err.Error())
 	rr.Status = RunStatusErrored
+	_, rr.permanent = err.(*PermanentError)
 	return rr
 }
 
@@ -221,6 +309,18 @@ func (rr RunResult) MarkPendingConfirmations() RunResult {
 	return rr
 }
 
+// MarkPendingReview returns a copy of RunResult but with status set to pending_review.
+func (rr RunResult) MarkPendingReview() RunResult {
+	rr.Status = RunStatusPendingReview
+	return rr
+}
+
+// MarkAborted returns a copy of RunResult but with status set to aborted.
+func (rr RunResult) MarkAborted() RunResult {
+	rr.Status = RunStatusAborted
+	return rr
+}
+
 // Get searches for and returns the JSON at the given path.
 func (rr RunResult) Get(path string) gjson.Result {
 	return rr.Data.Get(path)
@@ -249,6 +349,12 @@ func (rr RunResult) Error() string {
 	return rr.ErrorMessage.String
 }
 
+// Permanent returns true if the error was a PermanentError, which attempting
+// the task again will not fix.
+func (rr RunResult) Permanent() bool {
+	return rr.HasError() && rr.permanent
+}
+
 // GetError returns the error of a RunResult if it is present.
 func (rr RunResult) GetError() error {
 	if rr.HasError() {
@@ -285,6 +391,12 @@ func (rr RunResult) Merge(in RunResult) (RunResult, error) {
 	return in, nil
 }
 
+// RunReviewRequest is the body of a request to resolve a run held for
+// review, such as {"approved":true}.
+type RunReviewRequest struct {
+	Approved bool `json:"approved"`
+}
+
 // BridgeRunResult handles the parsing of RunResults from external adapters.
 type BridgeRunResult struct {
 	RunResult
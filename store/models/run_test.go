@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
@@ -103,6 +104,73 @@ func TestRunResult_WithError(t *testing.T) {
 	assert.Equal(t, cltest.NullString("this blew up"), rr.ErrorMessage)
 }
 
+func TestRunResult_WithError_Permanent(t *testing.T) {
+	t.Parallel()
+
+	rr := models.RunResult{}.WithError(models.NewPermanentError(errors.New("rejected")))
+	assert.Equal(t, cltest.NullString("rejected"), rr.ErrorMessage)
+	assert.True(t, rr.Permanent())
+
+	rr = rr.WithError(errors.New("flaky"))
+	assert.False(t, rr.Permanent())
+	assert.False(t, models.RunResult{}.Permanent())
+}
+
+func TestTaskRun_Retries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	tr := models.TaskRun{
+		Result: models.RunResult{JobRunID: "run"},
+		Task:   models.TaskSpec{MaxRetries: 1},
+	}
+
+	tr = tr.StartAttempt()
+	tr = tr.ApplyResult(tr.Result.WithError(errors.New("first")))
+	assert.Equal(t, uint64(1), tr.Attempts)
+	assert.True(t, tr.RetriesRemain())
+
+	tr = tr.MarkPendingRetry(now)
+	assert.Equal(t, models.RunStatusPendingRetry, tr.Status)
+	assert.Equal(t, now, tr.RetryAt.Time)
+	assert.Equal(t, []string{"first"}, tr.ErrorHistory)
+
+	tr = tr.StartAttempt()
+	assert.Equal(t, models.RunResult{JobRunID: "run"}, tr.Result)
+	assert.False(t, tr.RetryAt.Valid)
+	tr = tr.ApplyResult(tr.Result.WithError(errors.New("second")))
+	assert.Equal(t, uint64(2), tr.Attempts)
+	assert.False(t, tr.RetriesRemain())
+	assert.EqualError(t, tr.RetriedError(), "failed after 2 attempts: attempt 1: first; attempt 2: second")
+}
+
+func TestTaskRun_RetriesRemain_Permanent(t *testing.T) {
+	t.Parallel()
+
+	tr := models.TaskRun{Task: models.TaskSpec{MaxRetries: 3}}.StartAttempt()
+	tr = tr.ApplyResult(tr.Result.WithError(models.NewPermanentError(errors.New("rejected"))))
+	assert.False(t, tr.RetriesRemain())
+	assert.EqualError(t, tr.RetriedError(), "rejected")
+}
+
+func TestRunResult_Add(t *testing.T) {
+	t.Parallel()
+
+	rr := models.RunResult{
+		Data:   cltest.JSONFromString(`{"value":"100","raw":"{\"last\":100}","wei":3405678900000000000001}`),
+		Status: models.RunStatusInProgress,
+	}
+
+	added := rr.Add("parsed", []int{1, 2})
+	assert.Equal(t, models.RunStatusCompleted, added.Status)
+	assert.JSONEq(t, `{"value":"100","raw":"{\"last\":100}","wei":3405678900000000000001,"parsed":[1,2]}`, added.Data.String())
+	assert.Equal(t, "3405678900000000000001", added.Get("wei").Raw)
+
+	overwritten := added.Add("value", 7)
+	assert.JSONEq(t, `{"value":7,"raw":"{\"last\":100}","wei":3405678900000000000001,"parsed":[1,2]}`, overwritten.Data.String())
+	assert.Equal(t, models.RunStatusInProgress, rr.Status, "the original is not changed")
+}
+
 func TestRunResult_Merge(t *testing.T) {
 	t.Parallel()
 
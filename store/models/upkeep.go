@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/utils"
+)
+
+// Upkeep is an upkeep registered with a KeeperRegistry contract, which the
+// node checks and performs as one of the registry's keepers. UpkeepID is the
+// ID the registry gave the upkeep, while ID identifies it to the node.
+type Upkeep struct {
+	ID              string         `json:"id" storm:"id,unique"`
+	RegistryAddress common.Address `json:"registryAddress" storm:"index"`
+	UpkeepID        uint64         `json:"upkeepID"`
+	CreatedAt       Time           `json:"createdAt" storm:"index"`
+}
+
+// UpkeepRequest is the body of a request to add an upkeep.
+type UpkeepRequest struct {
+	RegistryAddress common.Address `json:"registryAddress"`
+	UpkeepID        uint64         `json:"upkeepID"`
+}
+
+// NewUpkeep returns the upkeep with a new ID, or an error if the request
+// has no registry.
+func NewUpkeep(request UpkeepRequest) (Upkeep, error) {
+	if request.RegistryAddress == (common.Address{}) {
+		return Upkeep{}, errors.New("upkeep must have a registryAddress")
+	}
+	return Upkeep{
+		ID:              utils.NewBytes32ID(),
+		RegistryAddress: request.RegistryAddress,
+		UpkeepID:        request.UpkeepID,
+		CreatedAt:       Time{Time: time.Now()},
+	}, nil
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (u Upkeep) GetID() string {
+	return u.ID
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (u Upkeep) GetName() string {
+	return "upkeeps"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (u *Upkeep) SetID(value string) error {
+	u.ID = value
+	return nil
+}
@@ -0,0 +1,25 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpkeep(t *testing.T) {
+	t.Parallel()
+
+	registry := common.HexToAddress("0x02777053d6764996e594c3E88AF1D58D5363a2e6")
+	upkeep, err := models.NewUpkeep(models.UpkeepRequest{RegistryAddress: registry, UpkeepID: 0})
+	require.NoError(t, err)
+	assert.NotEmpty(t, upkeep.ID)
+	assert.Equal(t, registry, upkeep.RegistryAddress)
+	assert.Equal(t, uint64(0), upkeep.UpkeepID)
+	assert.False(t, upkeep.CreatedAt.IsZero())
+
+	_, err = models.NewUpkeep(models.UpkeepRequest{UpkeepID: 1})
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"time"
 
@@ -11,8 +12,67 @@ import (
 // User holds the credentials for API user.
 type User struct {
 	Email          string `json:"email" storm:"id,unique"`
-	HashedPassword string `json:"hashedPassword"`
+	HashedPassword string `json:"hashedPassword" swaggerignore:"true"`
 	CreatedAt      Time   `json:"createdAt" storm:"index"`
+	// TOTPSecret is the encrypted secret shared with the user's authenticator
+	// app, which is only required at login once TOTPEnabled.
+	TOTPSecret  string `json:"totpSecret" swaggerignore:"true"`
+	TOTPEnabled bool   `json:"totpEnabled"`
+	// TOTPLastStep is the time step of the last code used, so that no code
+	// can be used twice.
+	TOTPLastStep int64 `json:"totpLastStep" swaggerignore:"true"`
+	// Role is empty for users saved before roles were added, who are admins.
+	Role Role `json:"role"`
+}
+
+// Role decides which API requests a user may make.
+type Role string
+
+const (
+	// RoleAdmin may make any request.
+	RoleAdmin = Role("admin")
+	// RoleOperator may manage jobs, runs and bridges, but not users, keys,
+	// credentials or the node's funds.
+	RoleOperator = Role("operator")
+	// RoleViewer may only read.
+	RoleViewer = Role("viewer")
+)
+
+// Roles lists every role, from most to least privileged.
+var Roles = []Role{RoleAdmin, RoleOperator, RoleViewer}
+
+// NewRole returns the role with the given name, or an error if there is none.
+func NewRole(name string) (Role, error) {
+	for _, r := range Roles {
+		if string(r) == name {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("unknown role %q, must be one of %v", name, Roles)
+}
+
+// rank orders roles by privilege, 0 being the most privileged.
+func (r Role) rank() int {
+	for i, role := range Roles {
+		if role == r {
+			return i
+		}
+	}
+	return len(Roles)
+}
+
+// EffectiveRole returns the user's role, treating users saved before roles
+// were added as admins.
+func (u User) EffectiveRole() Role {
+	if u.Role == "" {
+		return RoleAdmin
+	}
+	return u.Role
+}
+
+// HasRole returns whether the user's role grants at least required.
+func (u User) HasRole(required Role) bool {
+	return u.EffectiveRole().rank() <= required.rank()
 }
 
 // https://davidcel.is/posts/stop-validating-email-addresses-with-regex/
@@ -41,6 +101,7 @@ func NewUser(email, plainPwd string) (User, error) {
 		Email:          email,
 		HashedPassword: pwd,
 		CreatedAt:      Time{Time: time.Now()},
+		Role:           RoleAdmin,
 	}, nil
 }
 
@@ -49,11 +110,14 @@ func NewUser(email, plainPwd string) (User, error) {
 type SessionRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	TOTPCode string `json:"totpCode"`
 }
 
-// Session holds the unique id for the authenticated session.
+// Session holds the unique id for the authenticated session, and the email
+// of the user it was created for.
 type Session struct {
 	ID       string `json:"id" storm:"id,unique"`
+	Email    string `json:"email"`
 	LastUsed Time   `json:"lastUsed" storm:"index"`
 }
 
@@ -66,6 +130,25 @@ func NewSession() Session {
 	}
 }
 
+// TOTPRequest carries a code from the user's authenticator app.
+type TOTPRequest struct {
+	Code string `json:"code"`
+}
+
+// CreateUserRequest creates a user who logs in with the given email and
+// password, and has the given role.
+type CreateUserRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+// ChangeRoleRequest sets the role of the user with the given email.
+type ChangeRoleRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
 // ChangePasswordRequest sets a new password for the current Session's User.
 type ChangePasswordRequest struct {
 	OldPassword string `json:"oldPassword"`
@@ -40,3 +40,40 @@ func TestNewUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_HasRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		role     models.Role
+		required models.Role
+		want     bool
+	}{
+		{models.RoleAdmin, models.RoleAdmin, true},
+		{models.RoleAdmin, models.RoleViewer, true},
+		{models.RoleOperator, models.RoleOperator, true},
+		{models.RoleOperator, models.RoleAdmin, false},
+		{models.RoleViewer, models.RoleViewer, true},
+		{models.RoleViewer, models.RoleOperator, false},
+		{"", models.RoleAdmin, true},
+		{"superuser", models.RoleViewer, false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.role)+"/"+string(test.required), func(t *testing.T) {
+			user := models.User{Role: test.role}
+			assert.Equal(t, test.want, user.HasRole(test.required))
+		})
+	}
+}
+
+func TestNewRole(t *testing.T) {
+	t.Parallel()
+
+	role, err := models.NewRole("operator")
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleOperator, role)
+
+	_, err = models.NewRole("root")
+	assert.Error(t, err)
+}
@@ -0,0 +1,11 @@
+package models
+
+// EncryptedVRFKey is a VRF key, kept as an encrypted JSON keystore under its
+// compressed public key. Hash is the keyHash which VRFCoordinator contracts
+// identify the key by.
+type EncryptedVRFKey struct {
+	PublicKey string `json:"publicKey" storm:"id,unique"`
+	Hash      string `json:"hash" storm:"index"`
+	KeyJSON   string `json:"keyJSON"`
+	CreatedAt Time   `json:"createdAt" storm:"index"`
+}
@@ -23,6 +23,18 @@ var (
 	ErrorInvalidCallbackSignature = errors.New("AllInBatches callback has incorrect function signature, must return bool")
 	// ErrorInvalidCallbackModel is returned in AllInBatches if the model and bucket do not match types.
 	ErrorInvalidCallbackModel = errors.New("AllInBatches callback has incorrect model, must match bucket")
+	// ErrorNotPendingBridge is returned by FindPendingBridgeRun if the run is not waiting on a bridge.
+	ErrorNotPendingBridge = errors.New("Cannot resume a job run that isn't pending")
+	// ErrorNotPendingReview is returned by FindPendingReviewRun if the run is not held for review.
+	ErrorNotPendingReview = errors.New("Cannot review a job run that isn't pending review")
+	// ErrorLastAdmin is returned by SetUserRole rather than leave no user able to manage roles.
+	ErrorLastAdmin = errors.New("Cannot change the role of the last admin")
+	// ErrorInvalidAPIKey is returned by AuthorizedUserWithAPIKey for unknown or revoked keys.
+	ErrorInvalidAPIKey = errors.New("Invalid API key")
+	// ErrorUserExists is returned by CreateUser if a user already has the email.
+	ErrorUserExists = errors.New("A user with that email already exists")
+	// ErrorRunFinished is returned by SaveJobRun rather than overwrite a run which has since finished.
+	ErrorRunFinished = errors.New("Cannot update a job run which has since finished")
 )
 
 // ORM contains the database object used by Chainlink.
@@ -53,6 +65,11 @@ func (orm *ORM) GetBolt() *bolt.DB {
 	return orm.DB.Bolt
 }
 
+// Ping returns an error if the database is closed or cannot be read.
+func (orm *ORM) Ping() error {
+	return orm.DB.Bolt.View(func(*bolt.Tx) error { return nil })
+}
+
 // Where fetches multiple objects with "Find" in Storm.
 func (orm *ORM) Where(field string, value interface{}, instance interface{}) error {
 	err := orm.Find(field, value, instance)
@@ -82,6 +99,30 @@ func (orm *ORM) FindBridge(name string) (models.BridgeType, error) {
 	return bt, err
 }
 
+// FindHTTPCredential looks up an HTTPCredential by its Name.
+func (orm *ORM) FindHTTPCredential(name string) (models.HTTPCredential, error) {
+	var hc models.HTTPCredential
+	err := orm.One("Name", name, &hc)
+	return hc, err
+}
+
+// oauth2TokensBucket holds cached OAuth2 tokens, keyed by a digest of the
+// token request they were obtained with.
+const oauth2TokensBucket = "oauth2_tokens"
+
+// FindOAuth2Token looks up a cached OAuth2Token by its key.
+func (orm *ORM) FindOAuth2Token(key string) (models.OAuth2Token, error) {
+	var token models.OAuth2Token
+	err := orm.Get(oauth2TokensBucket, key, &token)
+	return token, err
+}
+
+// SaveOAuth2Token caches an OAuth2Token under the given key, replacing any
+// token already held for it.
+func (orm *ORM) SaveOAuth2Token(key string, token models.OAuth2Token) error {
+	return orm.Set(oauth2TokensBucket, key, &token)
+}
+
 // PendingBridgeType returns the bridge type of the current pending task,
 // or error if not pending bridge.
 func (orm *ORM) PendingBridgeType(jr models.JobRun) (models.BridgeType, error) {
@@ -106,6 +147,32 @@ func (orm *ORM) FindJobRun(id string) (models.JobRun, error) {
 	return jr, err
 }
 
+// FindPendingBridgeRun looks up a JobRun by its ID, returning
+// ErrorNotPendingBridge if it is not waiting on a bridge.
+func (orm *ORM) FindPendingBridgeRun(id string) (models.JobRun, error) {
+	jr, err := orm.FindJobRun(id)
+	if err != nil {
+		return jr, err
+	}
+	if !jr.Status.PendingBridge() {
+		return jr, ErrorNotPendingBridge
+	}
+	return jr, nil
+}
+
+// FindPendingReviewRun looks up a JobRun by its ID, returning
+// ErrorNotPendingReview if it is not held for review.
+func (orm *ORM) FindPendingReviewRun(id string) (models.JobRun, error) {
+	jr, err := orm.FindJobRun(id)
+	if err != nil {
+		return jr, err
+	}
+	if !jr.Status.PendingReview() {
+		return jr, ErrorNotPendingReview
+	}
+	return jr, nil
+}
+
 // FindServiceAgreement looks up a ServiceAgreement by its ID.
 func (orm *ORM) FindServiceAgreement(id string) (models.ServiceAgreement, error) {
 	var sa models.ServiceAgreement
@@ -210,6 +277,72 @@ func (orm *ORM) JobRunsWithStatus(statuses ...models.RunStatus) ([]models.JobRun
 	return runs, err
 }
 
+// SaveJobRun saves the run, unless the saved run has since finished with
+// another status, as when a chain reorganization errors a run while it is
+// being performed, in which case ErrorRunFinished is returned.
+func (orm *ORM) SaveJobRun(run *models.JobRun) error {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %+v", err)
+	}
+	defer tx.Rollback()
+
+	var saved models.JobRun
+	if err := tx.One("ID", run.ID, &saved); err != nil && err != storm.ErrNotFound {
+		return err
+	} else if err == nil && saved.Status.Finished() && saved.Status != run.Status {
+		return ErrorRunFinished
+	}
+	if err := tx.Save(run); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// InvalidateRunsTriggeredInBlocks errors the unfinished runs triggered by a
+// log in any of the blocks with hashes, which a chain reorganization has
+// removed, and returns them.
+func (orm *ORM) InvalidateRunsTriggeredInBlocks(hashes []common.Hash) ([]models.JobRun, error) {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %+v", err)
+	}
+	defer tx.Rollback()
+
+	runs := []models.JobRun{}
+	err = tx.Select(q.Not(q.In("Status", []models.RunStatus{
+		models.RunStatusCompleted,
+		models.RunStatusErrored,
+		models.RunStatusAborted,
+	}))).Find(&runs)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	removed := map[common.Hash]bool{}
+	for _, hash := range hashes {
+		removed[hash] = true
+	}
+
+	invalidated := []models.JobRun{}
+	for _, run := range runs {
+		if run.CreationHash == nil || !removed[*run.CreationHash] {
+			continue
+		}
+
+		reason := fmt.Errorf("triggered by a log in block %v with hash %s, which a chain reorganization removed", run.CreationHeight.ToInt(), run.CreationHash.Hex())
+		if i, ok := run.NextTaskRunIndex(); ok {
+			run.TaskRuns[i] = run.TaskRuns[i].ApplyResult(run.Result.WithError(reason))
+		}
+		run = run.ApplyResult(run.Result.WithError(reason))
+		if err := tx.Save(&run); err != nil {
+			return nil, err
+		}
+		invalidated = append(invalidated, run)
+	}
+	return invalidated, tx.Commit()
+}
+
 // AnyJobWithType returns true if there is at least one job associated with
 // the type name specified and false otherwise
 func (orm *ORM) AnyJobWithType(taskTypeName string) (bool, error) {
@@ -233,21 +366,25 @@ func (orm *ORM) AnyJobWithType(taskTypeName string) (bool, error) {
 }
 
 // CreateTx saves the properties of an Ethereum transaction to the database.
+// A nil to is for a transaction deploying a contract.
 func (orm *ORM) CreateTx(
 	from common.Address,
 	nonce uint64,
-	to common.Address,
+	to *common.Address,
 	data []byte,
 	value *big.Int,
 	gasLimit uint64,
 ) (*models.Tx, error) {
 	tx := models.Tx{
-		From:     from,
-		To:       to,
-		Nonce:    nonce,
-		Data:     data,
-		Value:    value,
-		GasLimit: gasLimit,
+		From:             from,
+		Nonce:            nonce,
+		Data:             data,
+		Value:            value,
+		GasLimit:         gasLimit,
+		ContractCreation: to == nil,
+	}
+	if to != nil {
+		tx.To = *to
 	}
 	return &tx, orm.Save(&tx)
 }
@@ -318,6 +455,30 @@ func (orm *ORM) AddAttempt(
 	return attempt, dbtx.Commit()
 }
 
+// RemoveAttempt deletes an attempt which could not be sent, making previous
+// the transaction's current attempt again.
+func (orm *ORM) RemoveAttempt(
+	tx *models.Tx,
+	attempt *models.TxAttempt,
+	previous models.TxAttempt,
+) error {
+	if tx.Hash == attempt.Hash {
+		tx.TxAttempt = previous
+	}
+	dbtx, err := orm.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer dbtx.Rollback()
+	if err = dbtx.DeleteStruct(attempt); err != nil {
+		return err
+	}
+	if err = dbtx.Save(tx); err != nil {
+		return err
+	}
+	return dbtx.Commit()
+}
+
 // GetLastNonce retrieves the last known nonce in the database for an account
 func (orm *ORM) GetLastNonce(address common.Address) (uint64, error) {
 	var transactions []models.Tx
@@ -331,6 +492,31 @@ func (orm *ORM) GetLastNonce(address common.Address) (uint64, error) {
 	return transactions[0].Nonce, nil
 }
 
+// NextNonce returns the nonce after the highest of the transactions from an
+// account in the database, or 0 when it has sent none.
+func (orm *ORM) NextNonce(address common.Address) (uint64, error) {
+	var transactions []models.Tx
+	query := orm.Select(q.Eq("From", address))
+	if err := query.Limit(1).OrderBy("Nonce").Reverse().Find(&transactions); err == storm.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return transactions[0].Nonce + 1, nil
+}
+
+// TxsFromNonce returns the transactions from an account with a nonce of at
+// least nonce, lowest nonce first.
+func (orm *ORM) TxsFromNonce(address common.Address, nonce uint64) ([]models.Tx, error) {
+	transactions := []models.Tx{}
+	query := orm.Select(q.Eq("From", address), q.Gte("Nonce", nonce))
+	if err := query.OrderBy("Nonce").Find(&transactions); err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return transactions, nil
+}
+
 // MarkRan will set Ran to true for a given initiator
 func (orm *ORM) MarkRan(i *models.Initiator) error {
 	dbtx, err := orm.Begin(true)
@@ -370,8 +556,35 @@ func (orm *ORM) FindUser() (models.User, error) {
 	return users[0], nil
 }
 
-// AuthorizedUserWithSession will return the one API user if the Session ID exists
-// and hasn't expired, and update session's LastUsed field.
+// FindUserByEmail looks up the user who logs in with email.
+func (orm *ORM) FindUserByEmail(email string) (models.User, error) {
+	var user models.User
+	err := orm.One("Email", email, &user)
+	return user, err
+}
+
+// CreateUser saves a new user, unless one already has their email.
+func (orm *ORM) CreateUser(user *models.User) error {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %+v", err)
+	}
+	defer tx.Rollback()
+
+	var existing models.User
+	if err := tx.One("Email", user.Email, &existing); err == nil {
+		return ErrorUserExists
+	} else if err != storm.ErrNotFound {
+		return err
+	}
+	if err := tx.Save(user); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AuthorizedUserWithSession will return the session's user if the Session ID
+// exists and hasn't expired, and update session's LastUsed field.
 func (orm *ORM) AuthorizedUserWithSession(sessionID string, sessionDuration time.Duration) (models.User, error) {
 	if len(sessionID) == 0 {
 		return models.User{}, errors.New("Session ID cannot be empty")
@@ -386,11 +599,124 @@ func (orm *ORM) AuthorizedUserWithSession(sessionID string, sessionDuration time
 	if session.LastUsed.Time.Add(sessionDuration).Before(now) {
 		return models.User{}, errors.New("Session has expired")
 	}
+	if session.Email == "" {
+		return models.User{}, errors.New("Session has no user, log in again")
+	}
 	session.LastUsed = models.Time{Time: now}
 	if err := orm.Save(&session); err != nil {
 		return models.User{}, err
 	}
-	return orm.FindUser()
+	return orm.FindUserByEmail(session.Email)
+}
+
+// FindAPIKey looks up an APIKey by its ID.
+func (orm *ORM) FindAPIKey(id string) (models.APIKey, error) {
+	var key models.APIKey
+	err := orm.One("ID", id, &key)
+	return key, err
+}
+
+// APIKeys returns every API key, oldest first.
+func (orm *ORM) APIKeys() ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := orm.AllByIndex("CreatedAt", &keys)
+	return keys, err
+}
+
+// FindEncryptedVRFKey looks up a VRF key by its public key.
+func (orm *ORM) FindEncryptedVRFKey(publicKey string) (models.EncryptedVRFKey, error) {
+	var key models.EncryptedVRFKey
+	err := orm.One("PublicKey", publicKey, &key)
+	return key, err
+}
+
+// EncryptedVRFKeys returns every VRF key, oldest first.
+func (orm *ORM) EncryptedVRFKeys() ([]models.EncryptedVRFKey, error) {
+	var keys []models.EncryptedVRFKey
+	err := orm.AllByIndex("CreatedAt", &keys)
+	return keys, err
+}
+
+// FindUpkeep looks up an upkeep by the ID the node gave it.
+func (orm *ORM) FindUpkeep(id string) (models.Upkeep, error) {
+	var upkeep models.Upkeep
+	err := orm.One("ID", id, &upkeep)
+	return upkeep, err
+}
+
+// FindUpkeepFor looks up the upkeep with upkeepID at the registry.
+func (orm *ORM) FindUpkeepFor(registry common.Address, upkeepID uint64) (models.Upkeep, error) {
+	var upkeep models.Upkeep
+	err := orm.Select(q.Eq("RegistryAddress", registry), q.Eq("UpkeepID", upkeepID)).First(&upkeep)
+	return upkeep, err
+}
+
+// Upkeeps returns every upkeep, oldest first.
+func (orm *ORM) Upkeeps() ([]models.Upkeep, error) {
+	var upkeeps []models.Upkeep
+	err := orm.AllByIndex("CreatedAt", &upkeeps)
+	return upkeeps, err
+}
+
+// AuthorizedUserWithAPIKey returns the key's user, and the key, if value is
+// the X-API-Key header of a key which has not been revoked.
+func (orm *ORM) AuthorizedUserWithAPIKey(value string) (models.User, models.APIKey, error) {
+	id, secret, err := models.ParseAPIKey(value)
+	if err != nil {
+		return models.User{}, models.APIKey{}, ErrorInvalidAPIKey
+	}
+	key, err := orm.FindAPIKey(id)
+	if err == storm.ErrNotFound || (err == nil && !key.CheckSecret(secret)) {
+		return models.User{}, models.APIKey{}, ErrorInvalidAPIKey
+	} else if err != nil {
+		return models.User{}, models.APIKey{}, err
+	}
+	if key.Email == "" {
+		user, err := orm.FindUser()
+		return user, key, err
+	}
+	user, err := orm.FindUserByEmail(key.Email)
+	if err == storm.ErrNotFound {
+		return models.User{}, models.APIKey{}, ErrorInvalidAPIKey
+	}
+	return user, key, err
+}
+
+// SetUserRole gives the user with the given email a new role, unless that
+// would leave no admins.
+func (orm *ORM) SetUserRole(email string, role models.Role) (models.User, error) {
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return models.User{}, fmt.Errorf("error starting transaction: %+v", err)
+	}
+	defer tx.Rollback()
+
+	var users []models.User
+	if err := tx.All(&users); err != nil {
+		return models.User{}, err
+	}
+	var user *models.User
+	admins := 0
+	for i, u := range users {
+		if u.Email == email {
+			user = &users[i]
+		}
+		if u.HasRole(models.RoleAdmin) {
+			admins++
+		}
+	}
+	if user == nil {
+		return models.User{}, storm.ErrNotFound
+	}
+	if user.HasRole(models.RoleAdmin) && role != models.RoleAdmin && admins == 1 {
+		return *user, ErrorLastAdmin
+	}
+
+	user.Role = role
+	if err := tx.Save(user); err != nil {
+		return *user, err
+	}
+	return *user, tx.Commit()
 }
 
 // DeleteUser will delete the API User in the db.
@@ -427,23 +753,81 @@ func (orm *ORM) DeleteUserSession(sessionID string) error {
 	return orm.DeleteStruct(&session)
 }
 
+// LimitSessions deletes the least recently used sessions of the user with
+// email, so that they have no more than max left. A max of 0 leaves every
+// session.
+func (orm *ORM) LimitSessions(email string, max int) error {
+	if max <= 0 {
+		return nil
+	}
+	tx, err := orm.Begin(true)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %+v", err)
+	}
+	defer tx.Rollback()
+
+	var all, sessions []models.Session
+	if err := tx.AllByIndex("LastUsed", &all); err != nil {
+		return err
+	}
+	for _, session := range all {
+		if session.Email == email {
+			sessions = append(sessions, session)
+		}
+	}
+	for i := 0; i < len(sessions)-max; i++ {
+		if err := tx.DeleteStruct(&sessions[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteAllSessions logs the user with email out everywhere, by erasing
+// every one of their sessions.
+func (orm *ORM) DeleteAllSessions(email string) error {
+	err := orm.Select(q.Eq("Email", email)).Delete(&models.Session{})
+	if err == storm.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
 // CreateSession will check the password in the SessionRequest against
-// the hashed API User password in the db.
+// the hashed password of the user with that email in the db.
 func (orm *ORM) CreateSession(sr models.SessionRequest) (string, error) {
-	user, err := orm.FindUser()
+	user, err := orm.CheckCredentials(sr)
 	if err != nil {
 		return "", err
 	}
+	session := models.NewSession()
+	session.Email = user.Email
+	return session.ID, orm.Save(&session)
+}
 
-	if !constantTimeEmailCompare(sr.Email, user.Email) {
-		return "", errors.New("Invalid email")
+// CheckCredentials returns the user with the email in the SessionRequest if
+// the password is theirs. Every user's email is compared, so that the time
+// taken does not tell which emails have users.
+func (orm *ORM) CheckCredentials(sr models.SessionRequest) (models.User, error) {
+	var users []models.User
+	if err := orm.All(&users); err != nil {
+		return models.User{}, err
 	}
 
-	if utils.CheckPasswordHash(sr.Password, user.HashedPassword) {
-		session := models.NewSession()
-		return session.ID, orm.Save(&session)
+	var user *models.User
+	for i := range users {
+		if constantTimeEmailCompare(sr.Email, users[i].Email) {
+			user = &users[i]
+		}
+	}
+	if user == nil {
+		return models.User{}, errors.New("Invalid email")
 	}
-	return "", errors.New("Invalid password")
+
+	if !utils.CheckPasswordHash(sr.Password, user.HashedPassword) {
+		return models.User{}, errors.New("Invalid password")
+	}
+	return *user, nil
 }
 
 const constantTimeEmailLength = 256
@@ -457,6 +841,43 @@ func constantTimeEmailCompare(left, right string) bool {
 	return subtle.ConstantTimeCompare(leftBytes, rightBytes) == 1
 }
 
+// AuditLogs returns a page of the audit log, newest first, along with the
+// number of entries in the whole log which match. Only entries for
+// resourceType, and from or after from and before to, are included when
+// those are given.
+func (orm *ORM) AuditLogs(resourceType string, from, to time.Time, offset, limit int) ([]models.AuditLog, int, error) {
+	matchers := []q.Matcher{q.NewFieldMatcher("Timestamp", timeRange{from, to})}
+	if resourceType != "" {
+		matchers = append(matchers, q.Eq("ResourceType", resourceType))
+	}
+	query := orm.Select(matchers...)
+
+	count, err := query.Count(&models.AuditLog{})
+	if err != nil {
+		return nil, 0, err
+	}
+	var logs []models.AuditLog
+	err = query.OrderBy("ID").Reverse().Skip(offset).Limit(limit).Find(&logs)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, 0, err
+	}
+	return logs, count, nil
+}
+
+// timeRange matches a models.Time field within [from, to), either of which
+// may be zero to leave that end open.
+type timeRange struct {
+	from, to time.Time
+}
+
+func (r timeRange) MatchField(v interface{}) (bool, error) {
+	t, ok := v.(models.Time)
+	if !ok {
+		return false, fmt.Errorf("cannot match %T against a time range", v)
+	}
+	return (r.from.IsZero() || !t.Before(r.from)) && (r.to.IsZero() || t.Before(r.to)), nil
+}
+
 // InitializeModel uses reflection on the passed klass to generate a bucket
 // of the same type name.
 func (orm *ORM) InitializeModel(klass interface{}) error {
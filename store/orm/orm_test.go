@@ -2,11 +2,13 @@ package orm_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/asdine/storm"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/store/models"
@@ -165,6 +167,51 @@ func TestJobRunsWithStatus(t *testing.T) {
 	}
 }
 
+func TestInvalidateRunsTriggeredInBlocks(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	j, i := cltest.NewJobWithLogInitiator()
+	require.NoError(t, store.SaveJob(&j))
+	saveRun := func(height int, status models.RunStatus) models.JobRun {
+		run := j.NewRun(i)
+		run.Status = status
+		block := cltest.IndexableBlockNumber(height)
+		run.CreationHeight = &block.Number
+		run.CreationHash = &block.Hash
+		require.NoError(t, store.Save(&run))
+		return run
+	}
+	below := saveRun(9, models.RunStatusPendingConfirmations)
+	first := saveRun(10, models.RunStatusPendingConfirmations)
+	last := saveRun(12, models.RunStatusPendingBridge)
+	finished := saveRun(11, models.RunStatusCompleted)
+	above := saveRun(13, models.RunStatusPendingConfirmations)
+	sameHeight := saveRun(10, models.RunStatusPendingConfirmations)
+
+	invalidated, err := store.InvalidateRunsTriggeredInBlocks([]common.Hash{
+		*first.CreationHash, *finished.CreationHash, *last.CreationHash,
+	})
+	require.NoError(t, err)
+	ids := []string{}
+	for _, run := range invalidated {
+		ids = append(ids, run.ID)
+		assert.Equal(t, models.RunStatusErrored, run.Status)
+	}
+	assert.ElementsMatch(t, []string{first.ID, last.ID}, ids)
+
+	for _, run := range []models.JobRun{below, finished, above, sameHeight} {
+		found, err := store.FindJobRun(run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, run.Status, found.Status)
+	}
+	found, err := store.FindJobRun(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RunStatusErrored, found.Status)
+	assert.Contains(t, found.Result.Error(), "triggered by a log in block 10")
+}
+
 func TestAnyJobWithType(t *testing.T) {
 	t.Parallel()
 
@@ -225,7 +272,7 @@ func TestCreatingTx(t *testing.T) {
 	data, err := hex.DecodeString("0987612345abcdef")
 	assert.NoError(t, err)
 
-	_, err = store.CreateTx(from, nonce, to, data, value, gasLimit)
+	_, err = store.CreateTx(from, nonce, &to, data, value, gasLimit)
 	assert.NoError(t, err)
 
 	txs := []models.Tx{}
@@ -240,6 +287,7 @@ func TestCreatingTx(t *testing.T) {
 	assert.Equal(t, nonce, tx.Nonce)
 	assert.Equal(t, value, tx.Value)
 	assert.Equal(t, gasLimit, tx.GasLimit)
+	assert.False(t, tx.ContractCreation)
 }
 
 func TestFindBridge(t *testing.T) {
@@ -317,6 +365,30 @@ func TestORM_PendingBridgeType_success(t *testing.T) {
 	assert.Equal(t, bt, retrievedBt)
 }
 
+func TestORM_FindPendingBridgeRun(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	job, initr := cltest.NewJobWithWebInitiator()
+	assert.NoError(t, store.SaveJob(&job))
+	pending := cltest.MarkJobRunPendingBridge(job.NewRun(initr), 0)
+	assert.NoError(t, store.Save(&pending))
+	unstarted := job.NewRun(initr)
+	assert.NoError(t, store.Save(&unstarted))
+
+	found, err := store.FindPendingBridgeRun(pending.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, pending.ID, found.ID)
+
+	_, err = store.FindPendingBridgeRun(unstarted.ID)
+	assert.Equal(t, orm.ErrorNotPendingBridge, err)
+
+	_, err = store.FindPendingBridgeRun("garbage")
+	assert.Equal(t, storm.ErrNotFound, err)
+}
+
 func TestORM_GetLastNonce_StormNotFound(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplicationWithKeyStore()
@@ -356,6 +428,38 @@ func TestORM_GetLastNonce_Valid(t *testing.T) {
 	assert.Equal(t, one, nonce)
 }
 
+func TestORM_NextNonce_TxsFromNonce(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	from := cltest.NewAddress()
+	next, err := store.NextNonce(from)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), next)
+
+	for _, nonce := range []uint64{2, 0, 1} {
+		tx := cltest.NewTx(from, 0)
+		tx.Nonce = nonce
+		require.NoError(t, store.Save(tx))
+	}
+	require.NoError(t, store.Save(cltest.NewTx(cltest.NewAddress(), 0)))
+
+	next, err = store.NextNonce(from)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), next)
+
+	txs, err := store.TxsFromNonce(from, 1)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, uint64(1), txs[0].Nonce)
+	assert.Equal(t, uint64(2), txs[1].Nonce)
+
+	txs, err = store.TxsFromNonce(from, 3)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+}
+
 func TestORM_MarkRan(t *testing.T) {
 	t.Parallel()
 
@@ -414,6 +518,7 @@ func TestORM_AuthorizedUserWithSession(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			prevSession := cltest.NewSession("correctID")
+			prevSession.Email = user.Email
 			prevSession.LastUsed = models.Time{time.Now().Add(-cltest.MustParseDuration("2m"))}
 			require.NoError(t, store.Save(&prevSession))
 
@@ -433,6 +538,120 @@ func TestORM_AuthorizedUserWithSession(t *testing.T) {
 	}
 }
 
+func TestORM_AuthorizedUserWithSession_BoundToUser(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	user1 := cltest.MustUser("test1@email1.net", "password1")
+	user2 := cltest.MustUser("test2@email2.net", "password2")
+	require.NoError(t, store.Save(&user1))
+	require.NoError(t, store.Save(&user2))
+
+	tests := []struct {
+		email, password string
+	}{
+		{"test1@email1.net", "password1"},
+		{"test2@email2.net", "password2"},
+	}
+	for _, test := range tests {
+		sessionID, err := store.ORM.CreateSession(models.SessionRequest{Email: test.email, Password: test.password})
+		require.NoError(t, err)
+		actual, err := store.ORM.AuthorizedUserWithSession(sessionID, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, test.email, actual.Email)
+	}
+
+	_, err := store.ORM.CreateSession(models.SessionRequest{Email: "test1@email1.net", Password: "password2"})
+	assert.Error(t, err, "another user's password")
+
+	legacy := models.NewSession()
+	require.NoError(t, store.Save(&legacy))
+	_, err = store.ORM.AuthorizedUserWithSession(legacy.ID, time.Minute)
+	assert.Error(t, err, "sessions from before they were bound to a user must log in again")
+}
+
+func TestORM_CreateUser(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	user := cltest.MustUser("test1@email1.net", "password1")
+	user.Role = models.RoleViewer
+	require.NoError(t, store.CreateUser(&user))
+
+	again := cltest.MustUser("test1@email1.net", "password2")
+	assert.Equal(t, orm.ErrorUserExists, store.CreateUser(&again))
+
+	actual, err := store.FindUserByEmail("test1@email1.net")
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleViewer, actual.Role)
+	assert.Equal(t, user.HashedPassword, actual.HashedPassword)
+}
+
+func TestORM_AuthorizedUserWithAPIKey(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	user1 := cltest.MustUser("test1@email1.net", "password1")
+	user2 := cltest.MustUser("test2@email2.net", "password2")
+	user2.CreatedAt = models.Time{Time: time.Now().Add(-time.Hour)}
+	require.NoError(t, store.Save(&user1))
+	require.NoError(t, store.Save(&user2))
+
+	tests := []struct {
+		name      string
+		email     string
+		wantEmail string
+		wantError error
+	}{
+		{"bound", "test2@email2.net", "test2@email2.net", nil},
+		{"created before keys were bound", "", "test1@email1.net", nil},
+		{"user deleted", "gone@email.net", "", orm.ErrorInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			key, value, err := models.NewAPIKey(test.email, "key", nil)
+			require.NoError(t, err)
+			require.NoError(t, store.Save(&key))
+
+			user, _, err := store.AuthorizedUserWithAPIKey(value)
+			assert.Equal(t, test.wantError, err)
+			assert.Equal(t, test.wantEmail, user.Email)
+		})
+	}
+}
+
+func TestORM_SetUserRole(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+	user1 := cltest.MustUser("test1@email1.net", "password1")
+	user1.Role = ""
+	require.NoError(t, store.Save(&user1))
+
+	_, err := store.SetUserRole("test1@email1.net", models.RoleViewer)
+	assert.Equal(t, orm.ErrorLastAdmin, err, "users saved without a role are admins")
+	_, err = store.SetUserRole("nobody@email.net", models.RoleViewer)
+	assert.Equal(t, storm.ErrNotFound, err)
+
+	user2 := cltest.MustUser("test2@email2.net", "password2")
+	require.NoError(t, store.Save(&user2))
+	actual, err := store.SetUserRole("test1@email1.net", models.RoleViewer)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleViewer, actual.Role)
+
+	_, err = store.SetUserRole("test2@email2.net", models.RoleOperator)
+	assert.Equal(t, orm.ErrorLastAdmin, err)
+	actual, err = store.SetUserRole("test2@email2.net", models.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, actual.Role)
+}
+
 func TestORM_DeleteUser(t *testing.T) {
 	t.Parallel()
 
@@ -502,7 +721,9 @@ func TestORM_CreateSession(t *testing.T) {
 			sessionID, err := store.CreateSession(sessionRequest)
 			if test.wantSession {
 				require.NoError(t, err)
-				assert.NotEmpty(t, sessionID)
+				var session models.Session
+				require.NoError(t, store.One("ID", sessionID, &session))
+				assert.Equal(t, test.email, session.Email)
 			} else {
 				require.Error(t, err)
 				assert.Empty(t, sessionID)
@@ -511,6 +732,69 @@ func TestORM_CreateSession(t *testing.T) {
 	}
 }
 
+func TestORM_LimitSessions(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	now := time.Now()
+	for i, age := range []time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour} {
+		session := cltest.NewSession(fmt.Sprintf("session%d", i))
+		session.LastUsed = models.Time{Time: now.Add(-age)}
+		require.NoError(t, store.Save(&session))
+	}
+	other := cltest.NewSession("other")
+	other.Email = "other@email.net"
+	other.LastUsed = models.Time{Time: now.Add(-4 * time.Hour)}
+	require.NoError(t, store.Save(&other))
+
+	require.NoError(t, store.LimitSessions(cltest.APIEmail, 0))
+	var sessions []models.Session
+	require.NoError(t, store.All(&sessions))
+	assert.Len(t, sessions, 4, "0 leaves every session")
+
+	require.NoError(t, store.LimitSessions(cltest.APIEmail, 2))
+	require.NoError(t, store.AllByIndex("LastUsed", &sessions))
+	require.Len(t, sessions, 3)
+	assert.Equal(t, "other", sessions[0].ID, "other users' sessions are not limited")
+	assert.Equal(t, "session2", sessions[1].ID)
+	assert.Equal(t, "session1", sessions[2].ID)
+
+	require.NoError(t, store.DeleteAllSessions(cltest.APIEmail))
+	require.NoError(t, store.All(&sessions))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "other", sessions[0].ID)
+}
+
+func TestORM_FindUpkeepFor(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	registry := cltest.NewAddress()
+	for _, request := range []models.UpkeepRequest{
+		{RegistryAddress: registry, UpkeepID: 0},
+		{RegistryAddress: registry, UpkeepID: 1},
+		{RegistryAddress: cltest.NewAddress(), UpkeepID: 1},
+	} {
+		upkeep, err := models.NewUpkeep(request)
+		require.NoError(t, err)
+		require.NoError(t, store.Save(&upkeep))
+	}
+
+	upkeep, err := store.FindUpkeepFor(registry, 1)
+	require.NoError(t, err)
+	assert.Equal(t, registry, upkeep.RegistryAddress)
+	assert.Equal(t, uint64(1), upkeep.UpkeepID)
+
+	_, err = store.FindUpkeepFor(registry, 2)
+	assert.Equal(t, storm.ErrNotFound, err)
+
+	upkeeps, err := store.Upkeeps()
+	require.NoError(t, err)
+	assert.Len(t, upkeeps, 3)
+}
+
 func TestORM_AllInBatches_DifferentBatchSizes(t *testing.T) {
 	t.Parallel()
 
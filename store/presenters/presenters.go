@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -30,10 +31,10 @@ func LogListeningAddress(address common.Address) string {
 // ShowEthBalance returns the current Eth Balance for current Account
 func ShowEthBalance(store *store.Store) (map[string]interface{}, error) {
 	keysAndValues := make(map[string]interface{})
-	if !store.KeyStore.HasAccounts() {
+	if !store.AccountStore().HasAccounts() {
 		logger.Panic("KeyStore must have an account in order to show balance")
 	}
-	account, err := store.KeyStore.GetAccount()
+	account, err := store.TxSigner.GetAccount()
 	if err != nil {
 		return keysAndValues, err
 	}
@@ -54,10 +55,10 @@ func ShowEthBalance(store *store.Store) (map[string]interface{}, error) {
 // ShowLinkBalance returns the current Link Balance for current Account
 func ShowLinkBalance(store *store.Store) (map[string]interface{}, error) {
 	keysAndValues := make(map[string]interface{})
-	if !store.KeyStore.HasAccounts() {
+	if !store.AccountStore().HasAccounts() {
 		logger.Panic("KeyStore must have an account in order to show balance")
 	}
-	account, err := store.KeyStore.GetAccount()
+	account, err := store.TxSigner.GetAccount()
 	if err != nil {
 		return keysAndValues, err
 	}
@@ -88,6 +89,111 @@ func (bt BridgeType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// HTTPCredential presents a stored credential without revealing its secrets.
+type HTTPCredential struct {
+	Name      string `json:"name"`
+	URLPrefix string `json:"urlPrefix"`
+	Scheme    string `json:"scheme"`
+	Username  string `json:"username,omitempty"`
+}
+
+// NewHTTPCredential strips the password and token from the credential.
+func NewHTTPCredential(hc models.HTTPCredential) HTTPCredential {
+	return HTTPCredential{
+		Name:      hc.Name,
+		URLPrefix: hc.URLPrefix,
+		Scheme:    hc.Scheme(),
+		Username:  hc.Username,
+	}
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (hc HTTPCredential) GetID() string {
+	return hc.Name
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (hc HTTPCredential) GetName() string {
+	return "http_credentials"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (hc *HTTPCredential) SetID(value string) error {
+	hc.Name = value
+	return nil
+}
+
+// APIKey presents an API key without its hashed secret. Key holds the value
+// to send in the X-API-Key header, and is only set when the key is created.
+type APIKey struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Email     string         `json:"email"`
+	Scopes    []models.Scope `json:"scopes"`
+	CreatedAt models.Time    `json:"createdAt"`
+	Key       string         `json:"key,omitempty"`
+}
+
+// NewAPIKey strips the hashed secret from the key.
+func NewAPIKey(k models.APIKey) APIKey {
+	return APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Email:     k.Email,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt,
+	}
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (k APIKey) GetID() string {
+	return k.ID
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (k APIKey) GetName() string {
+	return "api_keys"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (k *APIKey) SetID(value string) error {
+	k.ID = value
+	return nil
+}
+
+// VRFKey presents a VRF key without its encrypted secret. Hash is the
+// keyHash which consumers request randomness from.
+type VRFKey struct {
+	PublicKey string      `json:"publicKey"`
+	Hash      string      `json:"hash"`
+	CreatedAt models.Time `json:"createdAt"`
+}
+
+// NewVRFKey strips the encrypted secret from the key.
+func NewVRFKey(k models.EncryptedVRFKey) VRFKey {
+	return VRFKey{
+		PublicKey: k.PublicKey,
+		Hash:      k.Hash,
+		CreatedAt: k.CreatedAt,
+	}
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (k VRFKey) GetID() string {
+	return k.PublicKey
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (k VRFKey) GetName() string {
+	return "vrf_keys"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (k *VRFKey) SetID(value string) error {
+	k.PublicKey = value
+	return nil
+}
+
 // AccountBalance holds the hex representation of the address plus it's ETH & LINK balances
 type AccountBalance struct {
 	Address     string       `json:"address"`
@@ -111,61 +217,119 @@ func (a *AccountBalance) SetID(value string) error {
 // If you add an entry here, you should update NewConfigWhitelist and
 // ConfigWhitelist#String accordingly.
 type ConfigWhitelist struct {
-	AllowOrigins             string          `json:"allowOrigins"`
-	BridgeResponseURL        string          `json:"bridgeResponseURL,omitempty"`
-	ChainID                  uint64          `json:"ethChainId"`
-	ChainlinkDev             bool            `json:"chainlinkDev"`
-	ClientNodeURL            string          `json:"clientNodeUrl"`
-	DatabaseTimeout          store.Duration  `json:"databaseTimeout"`
-	EthereumURL              string          `json:"ethUrl"`
-	EthGasBumpThreshold      uint64          `json:"ethGasBumpThreshold"`
-	EthGasBumpWei            *big.Int        `json:"ethGasBumpWei"`
-	EthGasPriceDefault       *big.Int        `json:"ethGasPriceDefault"`
-	JSONConsle               bool            `json:"jsonConsole"`
-	LinkContractAddress      string          `json:"linkContractAddress"`
-	LogLevel                 store.LogLevel  `json:"logLevel"`
-	LogToDisk                bool            `json:"logToDisk"`
-	MinimumContractPayment   *assets.Link    `json:"minimumContractPayment"`
-	MinimumRequestExpiration uint64          `json:"minimumRequestExpiration"`
-	MinIncomingConfirmations uint64          `json:"minIncomingConfirmations"`
-	MinOutgoingConfirmations uint64          `json:"minOutgoingConfirmations"`
-	OracleContractAddress    *common.Address `json:"oracleContractAddress"`
-	Port                     uint16          `json:"chainlinkPort"`
-	ReaperExpiration         store.Duration  `json:"reaperExpiration"`
-	RootDir                  string          `json:"root"`
-	SessionTimeout           store.Duration  `json:"sessionTimeout"`
-	TLSHost                  string          `json:"chainlinkTLSHost"`
-	TLSPort                  uint16          `json:"chainlinkTLSPort"`
+	APIAllowedIPs                  []string        `json:"apiAllowedIPs"`
+	APIBurstLimit                  int             `json:"apiBurstLimit"`
+	APIDeniedIPs                   []string        `json:"apiDeniedIPs"`
+	APIForwardedDepth              int             `json:"apiForwardedDepth"`
+	APIRateLimit                   int             `json:"apiRateLimit"`
+	AllowOrigins                   string          `json:"allowOrigins"`
+	AllowUnknownTaskParams         bool            `json:"allowUnknownTaskParams"`
+	AllowUnrestrictedNetworkAccess bool            `json:"allowUnrestrictedNetworkAccess"`
+	BridgeResponseURL              string          `json:"bridgeResponseURL,omitempty"`
+	ChainID                        uint64          `json:"ethChainId"`
+	ChainlinkDev                   bool            `json:"chainlinkDev"`
+	ClientNodeURL                  string          `json:"clientNodeUrl"`
+	DatabaseTimeout                store.Duration  `json:"databaseTimeout"`
+	DefaultHTTPLimit               uint64          `json:"defaultHttpLimit"`
+	DefaultHTTPTimeout             store.Duration  `json:"defaultHttpTimeout"`
+	EthereumURL                    string          `json:"ethUrl"`
+	EthGasBumpThreshold            uint64          `json:"ethGasBumpThreshold"`
+	EthGasBumpWei                  *big.Int        `json:"ethGasBumpWei"`
+	EthGasPriceDefault             *big.Int        `json:"ethGasPriceDefault"`
+	ETHLedgerPath                  string          `json:"ethLedgerPath,omitempty"`
+	EthMaxGasLimit                 uint64          `json:"ethMaxGasLimit"`
+	EthMaxGasPriceWei              *big.Int        `json:"ethMaxGasPriceWei"`
+	EthNonceBlock                  string          `json:"ethNonceBlock"`
+	EthTxMissingThreshold          uint64          `json:"ethTxMissingThreshold"`
+	HTTPRetryAttempts              uint64          `json:"httpRetryAttempts"`
+	HTTPRetryMaxBackoff            store.Duration  `json:"httpRetryMaxBackoff"`
+	HTTPRetryMinBackoff            store.Duration  `json:"httpRetryMinBackoff"`
+	IPFSTimeout                    store.Duration  `json:"ipfsTimeout"`
+	JSONConsle                     bool            `json:"jsonConsole"`
+	JobRunTimeout                  store.Duration  `json:"jobRunTimeout"`
+	LinkContractAddress            string          `json:"linkContractAddress"`
+	LogLevel                       store.LogLevel  `json:"logLevel"`
+	LogToDisk                      bool            `json:"logToDisk"`
+	MaxSessions                    int             `json:"maxSessions"`
+	MinimumContractPayment         *assets.Link    `json:"minimumContractPayment"`
+	MinimumRequestExpiration       uint64          `json:"minimumRequestExpiration"`
+	MinIncomingConfirmations       uint64          `json:"minIncomingConfirmations"`
+	MinOutgoingConfirmations       uint64          `json:"minOutgoingConfirmations"`
+	OTELExporterOTLPEndpoint       string          `json:"otelExporterOtlpEndpoint,omitempty"`
+	OracleContractAddress          *common.Address `json:"oracleContractAddress"`
+	VRFCoordinatorAddress          *common.Address `json:"vrfCoordinatorAddress"`
+	PasswordMinLength              int             `json:"passwordMinLength"`
+	PasswordRequireUppercase       bool            `json:"passwordRequireUppercase"`
+	PasswordRequireLowercase       bool            `json:"passwordRequireLowercase"`
+	PasswordRequireDigit           bool            `json:"passwordRequireDigit"`
+	PasswordRequireSymbol          bool            `json:"passwordRequireSymbol"`
+	Port                           uint16          `json:"chainlinkPort"`
+	ReaperExpiration               store.Duration  `json:"reaperExpiration"`
+	RootDir                        string          `json:"root"`
+	SessionTimeout                 store.Duration  `json:"sessionTimeout"`
+	TLSHost                        string          `json:"chainlinkTLSHost"`
+	TLSPort                        uint16          `json:"chainlinkTLSPort"`
+	VaultAddress                   string          `json:"vaultAddress,omitempty"`
+	VaultPath                      string          `json:"vaultPath"`
 }
 
 // NewConfigWhitelist creates an instance of ConfigWhitelist
 func NewConfigWhitelist(config store.Config) ConfigWhitelist {
 	return ConfigWhitelist{
-		AllowOrigins:             config.AllowOrigins,
-		BridgeResponseURL:        config.BridgeResponseURL.String(),
-		ChainID:                  config.ChainID,
-		ChainlinkDev:             config.Dev,
-		ClientNodeURL:            config.ClientNodeURL,
-		DatabaseTimeout:          config.DatabaseTimeout,
-		EthereumURL:              config.EthereumURL,
-		EthGasBumpThreshold:      config.EthGasBumpThreshold,
-		EthGasBumpWei:            &config.EthGasBumpWei,
-		EthGasPriceDefault:       &config.EthGasPriceDefault,
-		JSONConsle:               config.JSONConsole,
-		LinkContractAddress:      config.LinkContractAddress,
-		LogLevel:                 config.LogLevel,
-		LogToDisk:                config.LogToDisk,
-		MinimumContractPayment:   &config.MinimumContractPayment,
-		MinimumRequestExpiration: config.MinimumRequestExpiration,
-		MinIncomingConfirmations: config.MinIncomingConfirmations,
-		MinOutgoingConfirmations: config.MinOutgoingConfirmations,
-		OracleContractAddress:    config.OracleContractAddress,
-		Port:             config.Port,
-		ReaperExpiration: config.ReaperExpiration,
-		RootDir:          config.RootDir,
-		SessionTimeout:   config.SessionTimeout,
-		TLSHost:          config.TLSHost,
-		TLSPort:          config.TLSPort,
+		APIAllowedIPs:                  config.APIAllowedIPs,
+		APIBurstLimit:                  config.APIBurstLimit,
+		APIDeniedIPs:                   config.APIDeniedIPs,
+		APIForwardedDepth:              config.APIForwardedDepth,
+		APIRateLimit:                   config.APIRateLimit,
+		AllowOrigins:                   config.AllowOrigins,
+		AllowUnknownTaskParams:         config.AllowUnknownTaskParams,
+		AllowUnrestrictedNetworkAccess: config.AllowUnrestrictedNetworkAccess,
+		BridgeResponseURL:              config.BridgeResponseURL.String(),
+		ChainID:                        config.ChainID,
+		ChainlinkDev:                   config.Dev,
+		ClientNodeURL:                  config.ClientNodeURL,
+		DatabaseTimeout:                config.DatabaseTimeout,
+		DefaultHTTPLimit:               config.DefaultHTTPLimit,
+		DefaultHTTPTimeout:             config.DefaultHTTPTimeout,
+		EthereumURL:                    config.EthereumURL,
+		EthGasBumpThreshold:            config.EthGasBumpThreshold,
+		EthGasBumpWei:                  &config.EthGasBumpWei,
+		EthGasPriceDefault:             &config.EthGasPriceDefault,
+		ETHLedgerPath:                  config.ETHLedgerPath,
+		EthMaxGasLimit:                 config.EthMaxGasLimit,
+		EthMaxGasPriceWei:              &config.EthMaxGasPriceWei,
+		EthNonceBlock:                  config.EthNonceBlock,
+		EthTxMissingThreshold:          config.EthTxMissingThreshold,
+		HTTPRetryAttempts:              config.HTTPRetryAttempts,
+		HTTPRetryMaxBackoff:            config.HTTPRetryMaxBackoff,
+		HTTPRetryMinBackoff:            config.HTTPRetryMinBackoff,
+		IPFSTimeout:                    config.IPFSTimeout,
+		JSONConsle:                     config.JSONConsole,
+		JobRunTimeout:                  config.JobRunTimeout,
+		LinkContractAddress:            config.LinkContractAddress,
+		LogLevel:                       config.LogLevel,
+		LogToDisk:                      config.LogToDisk,
+		MaxSessions:                    config.MaxSessions,
+		MinimumContractPayment:         &config.MinimumContractPayment,
+		MinimumRequestExpiration:       config.MinimumRequestExpiration,
+		MinIncomingConfirmations:       config.MinIncomingConfirmations,
+		MinOutgoingConfirmations:       config.MinOutgoingConfirmations,
+		OTELExporterOTLPEndpoint:       config.OTELExporterOTLPEndpoint,
+		OracleContractAddress:          config.OracleContractAddress,
+		VRFCoordinatorAddress:          config.VRFCoordinatorAddress,
+		PasswordMinLength:              config.PasswordMinLength,
+		PasswordRequireUppercase:       config.PasswordRequireUppercase,
+		PasswordRequireLowercase:       config.PasswordRequireLowercase,
+		PasswordRequireDigit:           config.PasswordRequireDigit,
+		PasswordRequireSymbol:          config.PasswordRequireSymbol,
+		Port:                           config.Port,
+		ReaperExpiration:               config.ReaperExpiration,
+		RootDir:                        config.RootDir,
+		SessionTimeout:                 config.SessionTimeout,
+		TLSHost:                        config.TLSHost,
+		TLSPort:                        config.TLSPort,
+		VaultAddress:                   config.VaultAddress,
+		VaultPath:                      config.VaultPath,
 	}
 }
 
@@ -186,20 +350,53 @@ func (c ConfigWhitelist) String() string {
 		"ETH_GAS_BUMP_THRESHOLD: %d\n" +
 		"ETH_GAS_BUMP_WEI: %s\n" +
 		"ETH_GAS_PRICE_DEFAULT: %s\n" +
+		"ETH_MAX_GAS_LIMIT: %d\n" +
+		"ETH_MAX_GAS_PRICE_WEI: %s\n" +
+		"ETH_NONCE_BLOCK: %s\n" +
+		"ETH_TX_MISSING_THRESHOLD: %d\n" +
 		"LINK_CONTRACT_ADDRESS: %s\n" +
 		"MINIMUM_CONTRACT_PAYMENT: %s\n" +
 		"ORACLE_CONTRACT_ADDRESS: %s\n" +
+		"VRF_COORDINATOR_ADDRESS: %s\n" +
 		"DATABASE_POLL_INTERVAL: %s\n" +
 		"ALLOW_ORIGINS: %s\n" +
 		"CHAINLINK_DEV: %v\n" +
 		"SESSION_TIMEOUT: %v\n" +
 		"REAPER_EXPIRATION: %v\n" +
-		"BRIDGE_RESPONSE_URL: %s\n"
+		"BRIDGE_RESPONSE_URL: %s\n" +
+		"DEFAULT_HTTP_LIMIT: %d\n" +
+		"DEFAULT_HTTP_TIMEOUT: %v\n" +
+		"HTTP_RETRY_ATTEMPTS: %d\n" +
+		"HTTP_RETRY_MIN_BACKOFF: %v\n" +
+		"HTTP_RETRY_MAX_BACKOFF: %v\n" +
+		"IPFS_TIMEOUT: %v\n" +
+		"ALLOW_UNRESTRICTED_NETWORK_ACCESS: %v\n" +
+		"OTEL_EXPORTER_OTLP_ENDPOINT: %s\n" +
+		"JOB_RUN_TIMEOUT: %v\n" +
+		"ALLOW_UNKNOWN_TASK_PARAMS: %v\n" +
+		"API_RATE_LIMIT: %d\n" +
+		"API_BURST_LIMIT: %d\n" +
+		"ETH_LEDGER_PATH: %s\n" +
+		"VAULT_ADDR: %s\n" +
+		"VAULT_PATH: %s\n" +
+		"MAX_SESSIONS: %d\n" +
+		"API_ALLOWED_IPS: %s\n" +
+		"API_DENIED_IPS: %s\n" +
+		"API_FORWARDED_DEPTH: %d\n" +
+		"PASSWORD_MIN_LENGTH: %d\n" +
+		"PASSWORD_REQUIRE_UPPERCASE: %v\n" +
+		"PASSWORD_REQUIRE_LOWERCASE: %v\n" +
+		"PASSWORD_REQUIRE_DIGIT: %v\n" +
+		"PASSWORD_REQUIRE_SYMBOL: %v\n"
 
 	oracleContractAddress := ""
 	if c.OracleContractAddress != nil {
 		oracleContractAddress = c.OracleContractAddress.String()
 	}
+	vrfCoordinatorAddress := ""
+	if c.VRFCoordinatorAddress != nil {
+		vrfCoordinatorAddress = c.VRFCoordinatorAddress.String()
+	}
 
 	return fmt.Sprintf(
 		fmtConfig,
@@ -218,15 +415,44 @@ func (c ConfigWhitelist) String() string {
 		c.EthGasBumpThreshold,
 		c.EthGasBumpWei.String(),
 		c.EthGasPriceDefault.String(),
+		c.EthMaxGasLimit,
+		c.EthMaxGasPriceWei.String(),
+		c.EthNonceBlock,
+		c.EthTxMissingThreshold,
 		c.LinkContractAddress,
 		c.MinimumContractPayment.String(),
 		oracleContractAddress,
+		vrfCoordinatorAddress,
 		c.DatabaseTimeout,
 		c.AllowOrigins,
 		c.ChainlinkDev,
 		c.SessionTimeout,
 		c.ReaperExpiration,
 		c.BridgeResponseURL,
+		c.DefaultHTTPLimit,
+		c.DefaultHTTPTimeout,
+		c.HTTPRetryAttempts,
+		c.HTTPRetryMinBackoff,
+		c.HTTPRetryMaxBackoff,
+		c.IPFSTimeout,
+		c.AllowUnrestrictedNetworkAccess,
+		c.OTELExporterOTLPEndpoint,
+		c.JobRunTimeout,
+		c.AllowUnknownTaskParams,
+		c.APIRateLimit,
+		c.APIBurstLimit,
+		c.ETHLedgerPath,
+		c.VaultAddress,
+		c.VaultPath,
+		c.MaxSessions,
+		strings.Join(c.APIAllowedIPs, ","),
+		strings.Join(c.APIDeniedIPs, ","),
+		c.APIForwardedDepth,
+		c.PasswordMinLength,
+		c.PasswordRequireUppercase,
+		c.PasswordRequireLowercase,
+		c.PasswordRequireDigit,
+		c.PasswordRequireSymbol,
 	)
 }
 
@@ -346,6 +572,8 @@ func initiatorParams(i Initiator) (interface{}, error) {
 		return struct {
 			Address common.Address `json:"address"`
 		}{i.Address}, nil
+	case models.InitiatorFluxMonitor:
+		return i.FluxMonitorJob, nil
 	default:
 		return nil, fmt.Errorf("Cannot marshal unsupported initiator type %v", i.Type)
 	}
@@ -366,6 +594,8 @@ var emptyAddress = common.Address{}.String()
 func (i Initiator) FriendlyAddress() string {
 	if i.IsLogInitiated() {
 		return LogListeningAddress(i.Address)
+	} else if i.Type == models.InitiatorFluxMonitor {
+		return i.ContractAddress.Hex()
 	}
 	return ""
 }
@@ -459,10 +689,85 @@ func (u UserPresenter) GetName() string {
 // MarshalJSON returns the User as json.
 func (u UserPresenter) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Email     string `json:"email"`
-		CreatedAt string `json:"createdAt"`
+		Email     string      `json:"email"`
+		CreatedAt string      `json:"createdAt"`
+		Role      models.Role `json:"role"`
 	}{
 		Email:     u.User.Email,
 		CreatedAt: u.User.CreatedAt.ISO8601(),
+		Role:      u.User.EffectiveRole(),
 	})
 }
+
+// Role describes what a user with that role may do.
+type Role struct {
+	Name        models.Role `json:"name"`
+	Description string      `json:"description"`
+}
+
+var roleDescriptions = map[models.Role]string{
+	models.RoleAdmin:    "Full access, including users, keys, credentials and withdrawals",
+	models.RoleOperator: "Manages jobs, runs and bridges",
+	models.RoleViewer:   "Read-only access",
+}
+
+// NewRoles describes every role, from most to least privileged.
+func NewRoles() []Role {
+	roles := make([]Role, len(models.Roles))
+	for i, r := range models.Roles {
+		roles[i] = Role{Name: r, Description: roleDescriptions[r]}
+	}
+	return roles
+}
+
+// Authentication reports whether the caller now holds an authenticated
+// session.
+type Authentication struct {
+	Authenticated bool `json:"authenticated"`
+}
+
+// ResourceID identifies the record which a request created or changed, such
+// as the job run started for a snapshot.
+type ResourceID struct {
+	ID string `json:"id"`
+}
+
+// Liveness is the answer to a liveness probe.
+type Liveness struct {
+	Status string `json:"status"`
+}
+
+// TOTPKey is a newly generated TOTP secret, for adding to an authenticator
+// app either by hand or by scanning QRCode, a PNG data URI.
+type TOTPKey struct {
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+	QRCode string `json:"qrCode"`
+}
+
+// TOTPStatus reports whether a TOTP code is required at login.
+type TOTPStatus struct {
+	Enabled bool `json:"enabled"`
+}
+
+// AuditLog presents an entry of the audit log.
+type AuditLog struct {
+	models.AuditLog
+}
+
+// GetID returns the ID of this structure for jsonapi serialization.
+func (al AuditLog) GetID() string {
+	return strconv.FormatUint(al.ID, 10)
+}
+
+// GetName returns the pluralized "type" of this structure for jsonapi serialization.
+func (al AuditLog) GetName() string {
+	return "audit_logs"
+}
+
+// SetID is used to set the ID of this structure when deserializing from jsonapi documents.
+func (al *AuditLog) SetID(value string) error {
+	id, err := strconv.ParseUint(value, 10, 64)
+	al.ID = id
+	return err
+}
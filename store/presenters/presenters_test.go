@@ -35,6 +35,7 @@ func TestPresenterInitiatorHasCorrectKeys(t *testing.T) {
 		{MI{Type: models.InitiatorCron, InitiatorParams: MIP{Schedule: models.Cron("* * * * *")}}, []string{"schedule"}},
 		{MI{Type: models.InitiatorRunAt, InitiatorParams: MIP{Time: models.Time{Time: now}}}, []string{"time", "ran"}},
 		{MI{Type: models.InitiatorEthLog, InitiatorParams: MIP{Address: address}}, []string{"address"}},
+		{MI{Type: models.InitiatorFluxMonitor, InitiatorParams: MIP{FluxMonitorJob: models.FluxMonitorJob{ContractAddress: address, DeviationThreshold: 0.5, PollInterval: models.Duration(time.Minute)}}}, []string{"contractAddress", "pollInterval", "threshold"}},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"regexp"
+)
+
+// Patterns of the errors geth and parity return from eth_sendRawTransaction,
+// by what the TxManager does about them. Geth's come from its transaction
+// pool, such as "nonce too low", and parity's are sentences, such as
+// "Transaction nonce is too low. Try incrementing the nonce."
+var (
+	nonceTooLowRegex  = regexp.MustCompile(`(?i)nonce (is )?too low`)
+	nonceTooHighRegex = regexp.MustCompile(`(?i)nonce (is )?too high`)
+
+	replacementUnderpricedRegex = regexp.MustCompile(`(?i)^replacement transaction underpriced` +
+		`|there is another transaction with same nonce in the queue`)
+
+	alreadyInMempoolRegex = regexp.MustCompile(`(?i)^(known transaction|already known)` +
+		`|transaction with the same hash was already imported`)
+
+	insufficientEthRegex = regexp.MustCompile(`(?i)^insufficient funds`)
+
+	// fatalRegex matches the transactions which no node will accept however
+	// many times they are sent, whatever their gas price or nonce.
+	fatalRegex = regexp.MustCompile(`(?i)^(exceeds block gas limit|invalid sender|negative value|oversized data|intrinsic gas too low)` +
+		`|^(supplied gas is beyond limit|transaction cost exceeds current gas limit|invalid signature|invalid chain id|invalid rlp data)` +
+		`|^(sender|recipient|code) is banned in local queue` +
+		`|^transaction is not permitted` +
+		`|^transaction is too big` +
+		`|^transaction gas is too low\. there is not enough gas to cover minimal cost`)
+)
+
+// SendError is the error of an Ethereum node rejecting a transaction sent to
+// it, classified so that the TxManager can tell a transaction it needs to
+// send differently from one which was sent already, or one which can never
+// be sent.
+type SendError struct {
+	err error
+}
+
+// NewSendError wraps the error of sending a transaction.
+func NewSendError(err error) *SendError {
+	return &SendError{err: err}
+}
+
+func (s *SendError) Error() string {
+	return s.err.Error()
+}
+
+// IsNonceTooLow is whether the account has already sent a transaction with
+// the nonce, so the node's nonce is behind the chain's.
+func (s *SendError) IsNonceTooLow() bool {
+	return nonceTooLowRegex.MatchString(s.err.Error())
+}
+
+// IsNonceTooHigh is whether the nonce leaves a gap after the account's last
+// transaction.
+func (s *SendError) IsNonceTooHigh() bool {
+	return nonceTooHighRegex.MatchString(s.err.Error())
+}
+
+// IsReplacementUnderpriced is whether a transaction with the same nonce is
+// in the mempool at a gas price the transaction does not beat by enough to
+// replace it.
+func (s *SendError) IsReplacementUnderpriced() bool {
+	return replacementUnderpricedRegex.MatchString(s.err.Error())
+}
+
+// IsTransactionAlreadyInMempool is whether the node already has the very
+// same transaction, which is as good as having sent it.
+func (s *SendError) IsTransactionAlreadyInMempool() bool {
+	return alreadyInMempoolRegex.MatchString(s.err.Error())
+}
+
+// IsInsufficientEth is whether the account cannot pay for the transaction's
+// value and gas.
+func (s *SendError) IsInsufficientEth() bool {
+	return insufficientEthRegex.MatchString(s.err.Error())
+}
+
+// Fatal is whether the transaction is invalid, so that sending it again,
+// with any gas price or nonce, will not help.
+func (s *SendError) Fatal() bool {
+	return fatalRegex.MatchString(s.err.Error())
+}
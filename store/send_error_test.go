@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendError(t *testing.T) {
+	t.Parallel()
+
+	const (
+		nonceTooLow = iota
+		nonceTooHigh
+		replacementUnderpriced
+		alreadyInMempool
+		insufficientEth
+		fatal
+		unclassified
+	)
+	tests := []struct {
+		client  string
+		message string
+		want    int
+	}{
+		{"geth", "nonce too low", nonceTooLow},
+		{"parity", "Transaction nonce is too low. Try incrementing the nonce.", nonceTooLow},
+		{"geth", "nonce too high", nonceTooHigh},
+		{"geth", "replacement transaction underpriced", replacementUnderpriced},
+		{"parity", "Transaction gas price is too low. There is another transaction with same nonce in the queue. Try increasing the gas price or incrementing the nonce.", replacementUnderpriced},
+		{"parity", "Transaction gas price 20000000000wei is too low. There is another transaction with same nonce in the queue with gas price 21000000000wei. Try increasing the gas price or incrementing the nonce.", replacementUnderpriced},
+		{"geth", "known transaction: 2b0c6c8b3a1f6ba8c5c4c8d4e2d5a0e6b1c3f5a7d9e1b3c5a7f9e1d3b5c7a9e1", alreadyInMempool},
+		{"geth", "already known", alreadyInMempool},
+		{"parity", "Transaction with the same hash was already imported.", alreadyInMempool},
+		{"geth", "insufficient funds for gas * price + value", insufficientEth},
+		{"parity", "Insufficient funds. The account you tried to send transaction from does not have enough funds. Required 21000000000000 and got: 0.", insufficientEth},
+		{"geth", "exceeds block gas limit", fatal},
+		{"geth", "invalid sender", fatal},
+		{"geth", "negative value", fatal},
+		{"geth", "oversized data", fatal},
+		{"geth", "intrinsic gas too low", fatal},
+		{"parity", "Supplied gas is beyond limit.", fatal},
+		{"parity", "Transaction cost exceeds current gas limit. Limit: 8000000, got: 9000000. Try decreasing supplied gas.", fatal},
+		{"parity", "Transaction gas is too low. There is not enough gas to cover minimal cost of the transaction (minimal: 21000, got: 20000). Try increasing supplied gas.", fatal},
+		{"parity", "Invalid signature: Crypto error (Invalid EC signature)", fatal},
+		{"parity", "Invalid chain id.", fatal},
+		{"parity", "Invalid RLP data: RlpIncorrectListLen", fatal},
+		{"parity", "Sender is banned in local queue.", fatal},
+		{"parity", "Code is banned in local queue.", fatal},
+		{"parity", "Transaction is not permitted.", fatal},
+		{"parity", "Transaction is too big, see chain specification for the limit.", fatal},
+		{"geth", "transaction underpriced", unclassified},
+		{"parity", "Transaction gas price is too low. It does not satisfy your node's minimal gas price (minimal: 20000000000, got: 1). Try increasing the gas price.", unclassified},
+		{"parity", "There are too many transactions in the queue. Your transaction was dropped due to limit. Try increasing the fee.", unclassified},
+		{"", "Cannot connect to nodes", unclassified},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.client+" "+test.message, func(t *testing.T) {
+			t.Parallel()
+			sendErr := strpkg.NewSendError(errors.New(test.message))
+
+			assert.Equal(t, test.message, sendErr.Error())
+			assert.Equal(t, test.want == nonceTooLow, sendErr.IsNonceTooLow(), "IsNonceTooLow")
+			assert.Equal(t, test.want == nonceTooHigh, sendErr.IsNonceTooHigh(), "IsNonceTooHigh")
+			assert.Equal(t, test.want == replacementUnderpriced, sendErr.IsReplacementUnderpriced(), "IsReplacementUnderpriced")
+			assert.Equal(t, test.want == alreadyInMempool, sendErr.IsTransactionAlreadyInMempool(), "IsTransactionAlreadyInMempool")
+			assert.Equal(t, test.want == insufficientEth, sendErr.IsInsufficientEth(), "IsInsufficientEth")
+			assert.Equal(t, test.want == fatal, sendErr.Fatal(), "Fatal")
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
@@ -20,12 +21,31 @@ import (
 // for keeping the application state in sync with the database.
 type Store struct {
 	*orm.ORM
+	// Config is the config the node started with, see CurrentConfig.
 	Config     Config
 	Clock      AfterNower
 	KeyStore   *KeyStore
 	RunChannel RunChannel
 	TxManager  TxManager
-	closed     bool
+	// TxSigner signs the TxManager's transactions. It is the KeyStore unless
+	// ETH_LEDGER_PATH or VAULT_ADDR is set.
+	TxSigner TxSigner
+	// HTTPCache holds the responses of HTTP adapters with a cacheTTL.
+	HTTPCache *HTTPCache
+	// VRFKeyStore holds the keys which prove the node's VRF outputs.
+	VRFKeyStore *VRFKeyStore
+	closed      bool
+
+	runStatusMutex       sync.RWMutex
+	runStatusBroadcaster RunStatusBroadcaster
+
+	totpMutex sync.Mutex
+
+	configMutex    sync.RWMutex
+	reloadedConfig *Config
+
+	taskObserversMutex sync.RWMutex
+	taskObservers      []TaskObserver
 }
 
 type rpcSubscriptionWrapper struct {
@@ -75,26 +95,64 @@ func NewStoreWithDialer(config Config, dialer Dialer) *Store {
 		logger.Fatal(fmt.Sprintf("Unable to dial ETH RPC port: %+v", err))
 	}
 	keyStore := NewKeyStore(config.KeysDir())
+	var signer TxSigner = keyStore
+	if config.ETHLedgerPath != "" {
+		if signer, err = NewLedgerSigner(config.ETHLedgerPath); err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to use Ledger: %+v", err))
+		}
+	} else if config.VaultAddress != "" {
+		if signer, err = NewVaultKeyStore(config); err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to use Vault: %+v", err))
+		}
+	}
 
 	store := &Store{
-		Clock:      Clock{},
-		Config:     config,
-		KeyStore:   keyStore,
-		ORM:        orm,
-		RunChannel: NewQueuedRunChannel(),
-		TxManager: &EthTxManager{
-			EthClient: &EthClient{ethrpc},
-			config:    config,
-			keyStore:  keyStore,
+		Clock:       Clock{},
+		Config:      config,
+		KeyStore:    keyStore,
+		ORM:         orm,
+		RunChannel:  NewQueuedRunChannel(),
+		TxSigner:    signer,
+		HTTPCache:   NewHTTPCache(httpCacheEntries, httpCacheBytes),
+		VRFKeyStore: NewVRFKeyStore(orm),
+	}
+	store.RegisterTaskObserver(MetricsTaskObserver{})
+	txm := &EthTxManager{
+		EthClient: &EthClient{ethrpc},
+		config:    store.CurrentConfig,
+		signer:    signer,
+		orm:       orm,
+		chains:    map[uint64]*EthTxManager{},
+	}
+	for _, id := range config.ChainIDs() {
+		chainID, chain := id, config.Chains[id]
+		chainrpc, err := dialer.Dial(chain.RPCURL)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to dial RPC port of chain %d: %+v", chainID, err))
+		}
+		txm.chains[chainID] = &EthTxManager{
+			EthClient: &EthClient{chainrpc},
+			config:    func() Config { return chain.apply(store.CurrentConfig(), chainID) },
+			signer:    signer,
 			orm:       orm,
-		},
+		}
 	}
+	store.TxManager = txm
 	return store
 }
 
+// AccountStore returns where the node's accounts are kept, which is the
+// KeyStore unless they are in Vault.
+func (s *Store) AccountStore() AccountStore {
+	if as, ok := s.TxSigner.(AccountStore); ok {
+		return as
+	}
+	return s.KeyStore
+}
+
 // Start initiates all of Store's dependencies including the TxManager.
 func (s *Store) Start() error {
-	acc, err := s.KeyStore.GetAccount()
+	acc, err := s.TxSigner.GetAccount()
 	if err != nil {
 		return err
 	}
@@ -105,15 +163,48 @@ func (s *Store) Start() error {
 // Close shuts down all of the working parts of the store.
 func (s *Store) Close() error {
 	s.RunChannel.Close()
+	if closer, ok := s.TxSigner.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logger.Warnw("Unable to close transaction signer", "error", err)
+		}
+	}
 	return s.ORM.Close()
 }
 
-// AuthorizedUserWithSession will return the one API user if the Session ID exists
-// and hasn't expired, and update session's LastUsed field.
+// AuthorizedUserWithSession will return the session's user if the Session ID
+// exists and hasn't expired, and update session's LastUsed field.
 func (s *Store) AuthorizedUserWithSession(sessionID string) (models.User, error) {
 	return s.ORM.AuthorizedUserWithSession(sessionID, s.Config.SessionTimeout.Duration)
 }
 
+// ErrNoSubscribers is returned when a run status update is broadcast with
+// nobody listening, which is expected and can be ignored.
+var ErrNoSubscribers = errors.New("no subscribers for run status updates")
+
+// RunStatusBroadcaster passes each change in a run's status on to its
+// subscribers.
+type RunStatusBroadcaster interface {
+	Broadcast(models.RunStatusUpdate) error
+}
+
+// SetRunStatusBroadcaster sends every later run status change to broadcaster.
+func (s *Store) SetRunStatusBroadcaster(broadcaster RunStatusBroadcaster) {
+	s.runStatusMutex.Lock()
+	defer s.runStatusMutex.Unlock()
+	s.runStatusBroadcaster = broadcaster
+}
+
+// BroadcastRunStatus sends update to the RunStatusBroadcaster, returning
+// ErrNoSubscribers if none has been set.
+func (s *Store) BroadcastRunStatus(update models.RunStatusUpdate) error {
+	s.runStatusMutex.RLock()
+	defer s.runStatusMutex.RUnlock()
+	if s.runStatusBroadcaster == nil {
+		return ErrNoSubscribers
+	}
+	return s.runStatusBroadcaster.Broadcast(update)
+}
+
 // AfterNower is an interface that fulfills the `After()` and `Now()`
 // methods.
 type AfterNower interface {
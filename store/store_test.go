@@ -5,6 +5,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,3 +63,13 @@ func TestQueuedRunChannel_Send_afterClose(t *testing.T) {
 
 	assert.Error(t, rq.Send("first"))
 }
+
+func TestStore_BroadcastRunStatus_NoBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	err := s.BroadcastRunStatus(models.RunStatusUpdate{RunID: "run", Status: models.RunStatusCompleted})
+	assert.Equal(t, store.ErrNoSubscribers, err)
+}
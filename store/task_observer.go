@@ -0,0 +1,87 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/metrics"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// TaskEvent describes a task being performed by the job runner.
+type TaskEvent struct {
+	TaskType  models.TaskType
+	JobID     string
+	JobRunID  string
+	TaskRunID string
+	// Duration is how long the adapter took, and is 0 as the task starts.
+	Duration time.Duration
+	// Status is the status the adapter returned, and is empty as the task
+	// starts.
+	Status models.RunStatus
+}
+
+// TaskObserver is told as each task's adapter starts and finishes being
+// performed. It is called from the job runner, so should return quickly.
+type TaskObserver interface {
+	OnTaskStart(TaskEvent)
+	OnTaskComplete(TaskEvent)
+}
+
+// MetricsTaskObserver records the number of tasks performed, and how long
+// they took, in the node's Prometheus metrics. Every store has one.
+type MetricsTaskObserver struct{}
+
+// OnTaskStart records nothing, as tasks are counted once they complete.
+func (MetricsTaskObserver) OnTaskStart(TaskEvent) {}
+
+// OnTaskComplete counts the task by its type and status, and observes its
+// duration.
+func (MetricsTaskObserver) OnTaskComplete(event TaskEvent) {
+	metrics.RecordTaskRun(event.TaskType.String(), string(event.Status), event.Duration)
+}
+
+// RegisterTaskObserver adds an observer to be told of every task performed
+// from now on, after those already registered.
+func (s *Store) RegisterTaskObserver(observer TaskObserver) {
+	s.taskObserversMutex.Lock()
+	defer s.taskObserversMutex.Unlock()
+	s.taskObservers = append(s.taskObservers, observer)
+}
+
+// NotifyTaskStart calls OnTaskStart on each registered observer.
+func (s *Store) NotifyTaskStart(event TaskEvent) {
+	s.notifyTaskObservers("OnTaskStart", event, TaskObserver.OnTaskStart)
+}
+
+// NotifyTaskComplete calls OnTaskComplete on each registered observer.
+func (s *Store) NotifyTaskComplete(event TaskEvent) {
+	s.notifyTaskObservers("OnTaskComplete", event, TaskObserver.OnTaskComplete)
+}
+
+func (s *Store) notifyTaskObservers(name string, event TaskEvent, notify func(TaskObserver, TaskEvent)) {
+	s.taskObserversMutex.RLock()
+	observers := s.taskObservers
+	s.taskObserversMutex.RUnlock()
+
+	for _, observer := range observers {
+		notifyTaskObserver(name, observer, event, notify)
+	}
+}
+
+// notifyTaskObserver recovers from the observer panicking, so that it can
+// neither stop the run nor the other observers being told.
+func notifyTaskObserver(name string, observer TaskObserver, event TaskEvent, notify func(TaskObserver, TaskEvent)) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorw(fmt.Sprintf("Task observer panicked in %s", name),
+				"observer", fmt.Sprintf("%T", observer),
+				"task_type", event.TaskType.String(),
+				"run_id", event.JobRunID,
+				"panic", r,
+			)
+		}
+	}()
+	notify(observer, event)
+}
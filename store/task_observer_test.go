@@ -0,0 +1,63 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/metrics"
+	strpkg "github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTaskObserver struct {
+	started, completed int
+}
+
+func (o *countingTaskObserver) OnTaskStart(strpkg.TaskEvent)    { o.started++ }
+func (o *countingTaskObserver) OnTaskComplete(strpkg.TaskEvent) { o.completed++ }
+
+type panickingTaskObserver struct{}
+
+func (panickingTaskObserver) OnTaskStart(strpkg.TaskEvent)    { panic("OnTaskStart") }
+func (panickingTaskObserver) OnTaskComplete(strpkg.TaskEvent) { panic("OnTaskComplete") }
+
+func TestStore_NotifyTaskObservers_RecoversFromPanics(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	counter := &countingTaskObserver{}
+	store.RegisterTaskObserver(panickingTaskObserver{})
+	store.RegisterTaskObserver(counter)
+
+	event := strpkg.TaskEvent{TaskType: models.MustNewTaskType("observed")}
+	assert.NotPanics(t, func() { store.NotifyTaskStart(event) })
+	event.Status, event.Duration = models.RunStatusCompleted, time.Millisecond
+	assert.NotPanics(t, func() { store.NotifyTaskComplete(event) })
+
+	assert.Equal(t, 1, counter.started)
+	assert.Equal(t, 1, counter.completed)
+}
+
+func TestMetricsTaskObserver(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	event := strpkg.TaskEvent{TaskType: models.MustNewTaskType("metricsobserved")}
+	store.NotifyTaskStart(event)
+	event.Status, event.Duration = models.RunStatusErrored, 20*time.Millisecond
+	store.NotifyTaskComplete(event)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.TaskRuns.WithLabelValues("metricsobserved", "errored")))
+	histogram := dto.Metric{}
+	require.NoError(t, metrics.TaskRunDuration.WithLabelValues("metricsobserved").(prometheus.Metric).Write(&histogram))
+	assert.Equal(t, uint64(1), histogram.GetHistogram().GetSampleCount())
+	assert.Equal(t, 0.02, histogram.GetHistogram().GetSampleSum())
+}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// totpPeriod is how long each TOTP code is valid for, as in RFC 6238.
+const totpPeriod = 30
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+var (
+	// ErrTOTPRequired is returned when logging in without a TOTP code once
+	// two-factor authentication is enabled.
+	ErrTOTPRequired = errors.New("TOTP code required")
+	// ErrInvalidTOTPCode is returned for a code which is wrong, expired, or
+	// has already been used.
+	ErrInvalidTOTPCode = errors.New("Invalid TOTP code")
+	// ErrTOTPAlreadyEnabled is returned when enabling TOTP a second time.
+	ErrTOTPAlreadyEnabled = errors.New("TOTP is already enabled")
+	// ErrTOTPNotPending is returned when verifying TOTP before it has been
+	// enabled, or after it has already been verified.
+	ErrTOTPNotPending = errors.New("TOTP is not waiting to be verified")
+	// ErrTOTPNotEnabled is returned when disabling TOTP which is not enabled.
+	ErrTOTPNotEnabled = errors.New("TOTP is not enabled")
+)
+
+// CreateSession checks the credentials in the SessionRequest, including its
+// TOTP code once the user has enabled two-factor authentication, and
+// returns the ID of a new session. Beyond MAX_SESSIONS, the user's least
+// recently used sessions are logged out.
+func (s *Store) CreateSession(sr models.SessionRequest) (string, error) {
+	user, err := s.ORM.CheckCredentials(sr)
+	if err != nil {
+		return "", err
+	}
+	if user.TOTPEnabled {
+		if sr.TOTPCode == "" {
+			return "", ErrTOTPRequired
+		}
+		if err := s.useTOTPCode(user.Email, sr.TOTPCode, func(*models.User) error { return nil }); err != nil {
+			return "", err
+		}
+	}
+	session := models.NewSession()
+	session.Email = user.Email
+	if err := s.Save(&session); err != nil {
+		return "", err
+	}
+	return session.ID, s.LimitSessions(user.Email, s.Config.MaxSessions)
+}
+
+// EnableTOTP generates a new TOTP secret for the user with email, which is
+// required at login once a code from it has been checked by VerifyTOTP.
+// Until then enabling again replaces the secret.
+func (s *Store) EnableTOTP(email string) (*otp.Key, error) {
+	s.totpMutex.Lock()
+	defer s.totpMutex.Unlock()
+
+	user, err := s.FindUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      "Chainlink",
+		AccountName: user.Email,
+		Period:      totpOpts.Period,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPSecret, err = s.encryptTOTPSecret(key.Secret()); err != nil {
+		return nil, err
+	}
+	return key, s.Save(&user)
+}
+
+// VerifyTOTP turns on the TOTP secret generated by EnableTOTP, once the user
+// shows that their authenticator app has it by sending one of its codes.
+func (s *Store) VerifyTOTP(email, code string) error {
+	return s.useTOTPCode(email, code, func(user *models.User) error {
+		if user.TOTPSecret == "" || user.TOTPEnabled {
+			return ErrTOTPNotPending
+		}
+		user.TOTPEnabled = true
+		return nil
+	})
+}
+
+// DisableTOTP stops requiring a TOTP code at login, given a current code.
+func (s *Store) DisableTOTP(email, code string) error {
+	return s.useTOTPCode(email, code, func(user *models.User) error {
+		if !user.TOTPEnabled {
+			return ErrTOTPNotEnabled
+		}
+		user.TOTPSecret = ""
+		user.TOTPEnabled = false
+		return nil
+	})
+}
+
+// useTOTPCode saves the user with email as changed by update, which may
+// refuse the change with an error, if code is valid now or in the periods
+// either side to allow for clock drift. A code is not accepted for the
+// period of the last code used, or any before it, so that an intercepted
+// code cannot be replayed.
+func (s *Store) useTOTPCode(email, code string, update func(*models.User) error) error {
+	s.totpMutex.Lock()
+	defer s.totpMutex.Unlock()
+
+	user, err := s.FindUserByEmail(email)
+	if err != nil {
+		return err
+	}
+	updated := user
+	if err := update(&updated); err != nil {
+		return err
+	}
+	if user.TOTPSecret == "" {
+		return ErrTOTPNotEnabled
+	}
+	secret, err := s.decryptTOTPSecret(user.TOTPSecret)
+	if err != nil {
+		return err
+	}
+
+	step := s.Clock.Now().Unix() / totpPeriod
+	for _, candidate := range []int64{step - 1, step, step + 1} {
+		if candidate <= user.TOTPLastStep {
+			continue
+		}
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(candidate*totpPeriod, 0), totpOpts)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			updated.TOTPLastStep = candidate
+			return s.Save(&updated)
+		}
+	}
+	return ErrInvalidTOTPCode
+}
+
+// totpCipher encrypts TOTP secrets with a key derived from the session
+// secret, so that a copy of the database alone does not reveal them.
+func (s *Store) totpCipher() (cipher.AEAD, error) {
+	secret, err := s.Config.SessionSecret()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("totp"))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *Store) encryptTOTPSecret(secret string) (string, error) {
+	aead, err := s.totpCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *Store) decryptTOTPSecret(encrypted string) (string, error) {
+	aead, err := s.totpCipher()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("encrypted TOTP secret is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	secret, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
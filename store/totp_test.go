@@ -0,0 +1,102 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_TOTPLifecycle(t *testing.T) {
+	t.Parallel()
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(s)
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetTime(now)
+
+	user := cltest.MustUser(cltest.APIEmail, cltest.Password)
+	require.NoError(t, s.Save(&user))
+	login := models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password}
+
+	key, err := s.EnableTOTP(cltest.APIEmail)
+	require.NoError(t, err)
+	user, err = s.FindUserByEmail(cltest.APIEmail)
+	require.NoError(t, err)
+	assert.NotContains(t, user.TOTPSecret, key.Secret())
+	assert.False(t, user.TOTPEnabled)
+
+	_, err = s.CreateSession(login)
+	assert.NoError(t, err, "TOTP is not required until verified")
+
+	assert.Equal(t, store.ErrInvalidTOTPCode, s.VerifyTOTP(cltest.APIEmail, "000000"))
+	code, err := totp.GenerateCode(key.Secret(), now)
+	require.NoError(t, err)
+	require.NoError(t, s.VerifyTOTP(cltest.APIEmail, code))
+	assert.Equal(t, store.ErrTOTPNotPending, s.VerifyTOTP(cltest.APIEmail, code))
+	_, err = s.EnableTOTP(cltest.APIEmail)
+	assert.Equal(t, store.ErrTOTPAlreadyEnabled, err)
+
+	clock.SetTime(now.Add(30 * time.Second))
+	_, err = s.CreateSession(login)
+	assert.Equal(t, store.ErrTOTPRequired, err)
+	login.TOTPCode, err = totp.GenerateCode(key.Secret(), clock.Now())
+	require.NoError(t, err)
+	_, err = s.CreateSession(login)
+	assert.NoError(t, err)
+
+	clock.SetTime(now.Add(60 * time.Second))
+	code, err = totp.GenerateCode(key.Secret(), clock.Now())
+	require.NoError(t, err)
+	require.NoError(t, s.DisableTOTP(cltest.APIEmail, code))
+	assert.Equal(t, store.ErrTOTPNotEnabled, s.DisableTOTP(cltest.APIEmail, code))
+
+	user, err = s.FindUserByEmail(cltest.APIEmail)
+	require.NoError(t, err)
+	assert.Empty(t, user.TOTPSecret)
+	assert.False(t, user.TOTPEnabled)
+	login.TOTPCode = ""
+	_, err = s.CreateSession(login)
+	assert.NoError(t, err)
+}
+
+func TestStore_TOTPReplay(t *testing.T) {
+	t.Parallel()
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+	clock := cltest.UseSettableClock(s)
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetTime(now)
+
+	user := cltest.MustUser(cltest.APIEmail, cltest.Password)
+	require.NoError(t, s.Save(&user))
+	key, err := s.EnableTOTP(cltest.APIEmail)
+	require.NoError(t, err)
+	code, err := totp.GenerateCode(key.Secret(), now)
+	require.NoError(t, err)
+	require.NoError(t, s.VerifyTOTP(cltest.APIEmail, code))
+
+	login := models.SessionRequest{Email: cltest.APIEmail, Password: cltest.Password, TOTPCode: code}
+	_, err = s.CreateSession(login)
+	assert.Equal(t, store.ErrInvalidTOTPCode, err, "code used to verify is replayed")
+
+	// The next period's code is accepted early for clock drift, after which
+	// the current period's code is too old.
+	clock.SetTime(now.Add(30 * time.Second))
+	login.TOTPCode, err = totp.GenerateCode(key.Secret(), now.Add(60*time.Second))
+	require.NoError(t, err)
+	_, err = s.CreateSession(login)
+	require.NoError(t, err)
+	_, err = s.CreateSession(login)
+	assert.Equal(t, store.ErrInvalidTOTPCode, err, "code used to log in is replayed")
+
+	login.TOTPCode, err = totp.GenerateCode(key.Secret(), clock.Now())
+	require.NoError(t, err)
+	_, err = s.CreateSession(login)
+	assert.Equal(t, store.ErrInvalidTOTPCode, err, "code older than the last used")
+}
@@ -1,21 +1,28 @@
 package store
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
-	"regexp"
+	"sort"
 	"sync"
+	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/metrics"
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/store/orm"
 	"github.com/smartcontractkit/chainlink/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/multierr"
 )
 
@@ -25,10 +32,16 @@ const nonceReloadLimit uint = 1
 // TxManager represents an interface for interacting with the blockchain
 type TxManager interface {
 	CreateTx(to common.Address, data []byte) (*models.Tx, error)
+	CreateTxWithGas(to common.Address, data []byte, gasPrice *big.Int, gasLimit uint64) (*models.Tx, error)
+	CreateTxWithValue(to common.Address, value *assets.Eth, data []byte) (*models.Tx, error)
+	DeployContract(data []byte, gasLimit uint64) (*models.Tx, error)
 	ActivateAccount(account accounts.Account) error
 	MeetsMinConfirmations(hash common.Hash) (bool, error)
+	ConfirmedTxReceipt(hash common.Hash) (*TxReceipt, error)
 	WithdrawLink(wr models.WithdrawalRequest) (common.Hash, error)
 	GetLinkBalance(address common.Address) (*assets.Link, error)
+	GetERC20Balance(tokenAddr, holderAddr common.Address) (*big.Int, error)
+	GetERC20Decimals(tokenAddr common.Address) (uint8, error)
 	GetActiveAccount() *ActiveAccount
 
 	GetEthBalance(address common.Address) (*assets.Eth, error)
@@ -36,24 +49,85 @@ type TxManager interface {
 	GetBlockByNumber(hex string) (models.BlockHeader, error)
 	SubscribeToLogs(channel chan<- Log, q ethereum.FilterQuery) (models.EthSubscription, error)
 	GetLogs(q ethereum.FilterQuery) ([]Log, error)
+	CallContract(to common.Address, data []byte, block string) ([]byte, error)
+
+	// ForChain returns the TxManager for sending transactions on the chain,
+	// which is this one for 0 or ETH_CHAIN_ID, or nil when the node is not
+	// configured for the chain. See ChainConfig.
+	ForChain(chainID uint64) TxManager
 }
 
-// EthTxManager contains fields for the Ethereum client, the KeyStore,
+// EthTxManager contains fields for the Ethereum client, the TxSigner,
 // the local Config for the application, and the database.
 type EthTxManager struct {
 	*EthClient
-	keyStore      *KeyStore
-	config        Config
+	signer        TxSigner
+	config        func() Config
 	orm           *orm.ORM
 	activeAccount *ActiveAccount
+	// Managers for each of the other chains in Config.Chains.
+	chains map[uint64]*EthTxManager
+
+	erc20DecimalsMutex sync.Mutex
+	erc20Decimals      map[common.Address]erc20Decimals
 }
 
 // CreateTx signs and sends a transaction to the Ethereum blockchain.
 func (txm *EthTxManager) CreateTx(to common.Address, data []byte) (*models.Tx, error) {
-	return txm.createTxWithNonceReload(to, data, 0)
+	return txm.CreateTxWithGas(to, data, nil, 0)
+}
+
+// CreateTxWithGas signs and sends a transaction with the given gas price and
+// limit, using the defaults CreateTx would for a nil or zero price and a zero
+// limit. Values over ETH_MAX_GAS_PRICE_WEI or ETH_MAX_GAS_LIMIT are lowered
+// to them.
+func (txm *EthTxManager) CreateTxWithGas(to common.Address, data []byte, gasPrice *big.Int, gasLimit uint64) (*models.Tx, error) {
+	return txm.createTx(&to, big.NewInt(0), data, gasPrice, gasLimit)
+}
+
+// CreateTxWithValue signs and sends a transaction transferring value wei of
+// ETH along with data, which is empty for a plain transfer. It fails with an
+// InsufficientFundsError, without sending anything, unless the account holds
+// the value and the gas the transaction could cost once bumped to
+// ETH_MAX_GAS_PRICE_WEI.
+func (txm *EthTxManager) CreateTxWithValue(to common.Address, value *assets.Eth, data []byte) (*models.Tx, error) {
+	if value == nil {
+		value = assets.NewEth(0)
+	} else if (*big.Int)(value).Sign() < 0 {
+		return nil, fmt.Errorf("cannot send a negative value of %v ETH", value)
+	}
+	return txm.createTx(&to, new(big.Int).Set((*big.Int)(value)), data, nil, 0)
 }
 
-func (txm *EthTxManager) createTxWithNonceReload(to common.Address, data []byte, nrc uint) (*models.Tx, error) {
+// DeployContract signs and sends a transaction creating a contract from data,
+// the contract's bytecode followed by its ABI encoded constructor arguments,
+// with the default gas limit for 0. The contract's address is in the
+// transaction's receipt once it is mined.
+func (txm *EthTxManager) DeployContract(data []byte, gasLimit uint64) (*models.Tx, error) {
+	return txm.createTx(nil, big.NewInt(0), data, nil, gasLimit)
+}
+
+// createTx sends a transaction to to, or deploying a contract when to is nil.
+func (txm *EthTxManager) createTx(to *common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64) (*models.Tx, error) {
+	_, span := observability.StartSpan(context.Background(), "TxManager.CreateTx",
+		attribute.String("eth.to", toHex(to)),
+		attribute.String("eth.value", value.String()))
+	defer span.End()
+
+	tx, err := txm.createTxWithNonceReload(to, value, data, gasPrice, gasLimit, 0)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return tx, err
+	}
+	span.SetAttributes(
+		attribute.String("eth.from", tx.From.Hex()),
+		attribute.Int64("eth.nonce", int64(tx.Nonce)),
+		attribute.String("eth.tx_hash", tx.Hash.Hex()),
+	)
+	return tx, nil
+}
+
+func (txm *EthTxManager) createTxWithNonceReload(to *common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64, nrc uint) (*models.Tx, error) {
 	if txm.activeAccount == nil {
 		return nil, errors.New("Must activate an account before creating a transaction")
 	}
@@ -65,95 +139,308 @@ func (txm *EthTxManager) createTxWithNonceReload(to common.Address, data []byte,
 
 	var tx *models.Tx
 	err = txm.activeAccount.GetAndIncrementNonce(func(nonce uint64) error {
+		fee, err := txm.gasFor(nonce, to, data, gasPrice, gasLimit)
+		if err != nil {
+			return err
+		}
+		price, limit := fee.GasPrice, fee.GasLimit
+		if value.Sign() > 0 {
+			if err = txm.checkFunds(value, fee); err != nil {
+				return err
+			}
+		}
 		tx, err = txm.orm.CreateTx(
 			txm.activeAccount.Address,
 			nonce,
 			to,
 			data,
-			big.NewInt(0),
-			defaultGasLimit,
+			value,
+			limit,
 		)
 		if err != nil {
 			return err
 		}
 
-		logger.Infow(fmt.Sprintf("Created ETH transaction, attempt #: %v", nrc), []interface{}{"from", txm.activeAccount.Address.String(), "to", to.String()}...)
-		gasPrice := txm.config.EthGasPriceDefault
-		var txa *models.TxAttempt
-		txa, err = txm.createAttempt(tx, &gasPrice, blkNum)
+		logger.Infow(fmt.Sprintf("Created ETH transaction, attempt #: %v", nrc), []interface{}{"from", txm.activeAccount.Address.String(), "to", toHex(to)}...)
+		_, err = txm.createAttempt(tx, price, blkNum)
 		if err != nil {
 			txm.orm.DeleteStruct(tx)
-			txm.orm.DeleteStruct(txa)
 
+			if sendErr, ok := err.(*SendError); ok {
+				return sendErr
+			}
 			return fmt.Errorf("TxManager CreateTX %v", err)
 		}
 
 		return nil
 	})
 
-	if err != nil {
-		nonceErr, _ := regexp.MatchString("nonce .*too low", err.Error())
-		if nonceErr {
+	if sendErr, ok := err.(*SendError); ok {
+		if direction := nonceDirection(sendErr); direction != "" {
 			if nrc >= nonceReloadLimit {
 				err = fmt.Errorf(
-					"Transaction reattempt limit reached for 'nonce is too low' error. Limit: %v, Reattempt: %v",
+					"Transaction reattempt limit reached for 'nonce is too %s' error. Limit: %v, Reattempt: %v",
+					direction,
 					nonceReloadLimit,
 					nrc,
 				)
 				return tx, err
 			}
 
-			logger.Warnw("Transaction nonce is too low. Reloading the nonce from the network and reattempting the transaction.")
+			logger.Warnw(fmt.Sprintf("Transaction nonce is too %s. Reconciling the nonce with the network and reattempting the transaction.", direction))
 			err = txm.ReloadNonce()
 			if err != nil {
 				return tx, fmt.Errorf("TxManager CreateTX ReloadNonce %v", err)
 			}
 
-			return txm.createTxWithNonceReload(to, data, nrc+1)
+			return txm.createTxWithNonceReload(to, value, data, gasPrice, gasLimit, nrc+1)
 		}
 	}
 
 	return tx, err
 }
 
+// nonceDirection returns "low" or "high" when the node rejected the
+// transaction for its nonce, which reconciling the nonce fixes, and
+// otherwise "".
+func nonceDirection(sendErr *SendError) string {
+	switch {
+	case sendErr.IsNonceTooLow():
+		return "low"
+	case sendErr.IsNonceTooHigh():
+		return "high"
+	}
+	return ""
+}
+
+// toHex returns the hex of a transaction's to address, or "" for one
+// deploying a contract.
+func toHex(to *common.Address) string {
+	if to == nil {
+		return ""
+	}
+	return to.Hex()
+}
+
+// checkFunds returns an InsufficientFundsError unless the active account
+// holds value, and what a transaction with fee costs with its gas at
+// ETH_MAX_GAS_PRICE_WEI, the highest price it can be bumped to, including
+// any layer 1 fee charged on top of the gas.
+func (txm *EthTxManager) checkFunds(value *big.Int, fee L2Fee) error {
+	balance, err := txm.GetEthBalance(txm.activeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("TxManager CreateTX getting the balance of %s: %v", txm.activeAccount.Address.Hex(), err)
+	}
+	fee.GasPrice = &txm.config().EthMaxGasPriceWei
+	required := fee.Total()
+	required.Add(required, value)
+	if (*big.Int)(balance).Cmp(required) < 0 {
+		return &InsufficientFundsError{
+			Address:  txm.activeAccount.Address,
+			Required: (*assets.Eth)(required),
+			Balance:  balance,
+		}
+	}
+	return nil
+}
+
+// gasFor returns the fee for a transaction, whose gas price and limit on a
+// layer 2 chain are estimated by its L2GasPriceEstimator, along with any
+// layer 1 fee, and otherwise are ETH_GAS_PRICE_DEFAULT and the default limit.
+// A price or limit given for the transaction is used instead. Prices and
+// limits are lowered to the configured maximum.
+func (txm *EthTxManager) gasFor(nonce uint64, to *common.Address, data []byte, gasPrice *big.Int, gasLimit uint64) (L2Fee, error) {
+	config := txm.config()
+	fee, err := txm.defaultGasFor(config, nonce, to, data)
+	if err != nil {
+		return L2Fee{}, err
+	}
+
+	if gasPrice == nil || gasPrice.Sign() <= 0 {
+		gasPrice = fee.GasPrice
+	} else if max := &config.EthMaxGasPriceWei; gasPrice.Cmp(max) > 0 {
+		logger.Warnw(
+			fmt.Sprintf("Gas price of %v wei is over ETH_MAX_GAS_PRICE_WEI, using %v wei", gasPrice, max),
+			"gasPrice", gasPrice, "max", max, "to", toHex(to))
+		gasPrice = new(big.Int).Set(max)
+	}
+	if gasLimit == 0 {
+		gasLimit = fee.GasLimit
+	}
+	if max := config.EthMaxGasLimit; gasLimit > max {
+		logger.Warnw(
+			fmt.Sprintf("Gas limit of %d is over ETH_MAX_GAS_LIMIT, using %d", gasLimit, max),
+			"gasLimit", gasLimit, "max", max, "to", toHex(to))
+		gasLimit = max
+	}
+	fee.GasPrice, fee.GasLimit = gasPrice, gasLimit
+	return fee, nil
+}
+
+// defaultGasFor returns the fee for a transaction when no gas price or limit
+// is given for it, which on a layer 1 chain has no layer 1 fee.
+func (txm *EthTxManager) defaultGasFor(config Config, nonce uint64, to *common.Address, data []byte) (L2Fee, error) {
+	gasPrice := config.EthGasPriceDefault
+	estimator := L2GasPriceEstimatorFor(config.ChainID)
+	if estimator == nil {
+		return L2Fee{GasPrice: &gasPrice, GasLimit: defaultGasLimit, L1Fee: big.NewInt(0)}, nil
+	}
+
+	etx := types.NewContractCreation(nonce, big.NewInt(0), defaultGasLimit, &gasPrice, data)
+	if to != nil {
+		etx = types.NewTransaction(nonce, *to, big.NewInt(0), defaultGasLimit, &gasPrice, data)
+	}
+	fee, err := estimator.EstimateFee(txm.EthClient, etx)
+	if err != nil {
+		return L2Fee{}, fmt.Errorf("TxManager CreateTX estimating fee on chain %d: %v", config.ChainID, err)
+	}
+	logger.Infow(
+		"Estimated L2 transaction fee",
+		"chainID", config.ChainID,
+		"gasPrice", fee.GasPrice,
+		"gasLimit", fee.GasLimit,
+		"l1Fee", fee.L1Fee,
+		"total", fee.Total(),
+	)
+	return fee, nil
+}
+
+// GetEthBalance returns the balance of ETH at the given address, and records
+// it in the chainlink_eth_balance_wei metric.
+func (txm *EthTxManager) GetEthBalance(address common.Address) (*assets.Eth, error) {
+	balance, err := txm.EthClient.GetEthBalance(address)
+	if err != nil {
+		return balance, err
+	}
+	metrics.SetEthBalance(address.Hex(), (*big.Int)(balance))
+	return balance, nil
+}
+
 // GetLinkBalance returns the balance of LINK at the given address
 func (txm *EthTxManager) GetLinkBalance(address common.Address) (*assets.Link, error) {
-	contractAddress := common.HexToAddress(txm.config.LinkContractAddress)
-	balance, err := txm.GetERC20Balance(address, contractAddress)
+	contractAddress := common.HexToAddress(txm.config().LinkContractAddress)
+	balance, err := txm.GetERC20Balance(contractAddress, address)
 	if err != nil {
 		return assets.NewLink(0), err
 	}
 	return (*assets.Link)(balance), nil
 }
 
+// erc20DecimalsTTL is how long a token's decimals are cached for.
+const erc20DecimalsTTL = 5 * time.Minute
+
+type erc20Decimals struct {
+	decimals  uint8
+	expiresAt time.Time
+}
+
+// GetERC20Decimals returns the number of decimals of the ERC-20 token
+// contract, which its balances are divided by for display. Each token's
+// decimals are cached for a few minutes.
+func (txm *EthTxManager) GetERC20Decimals(tokenAddr common.Address) (uint8, error) {
+	txm.erc20DecimalsMutex.Lock()
+	cached, ok := txm.erc20Decimals[tokenAddr]
+	txm.erc20DecimalsMutex.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.decimals, nil
+	}
+
+	output, err := txm.CallContract(tokenAddr, models.HexToFunctionSelector("0x313ce567").Bytes(), "latest") // decimals()
+	if err != nil {
+		return 0, err
+	}
+	if len(output) != utils.EVMWordByteLen {
+		return 0, fmt.Errorf("decimals() of %s returned %d bytes, not a uint8", tokenAddr.Hex(), len(output))
+	}
+	decimals := new(big.Int).SetBytes(output)
+	if !decimals.IsUint64() || decimals.Uint64() > math.MaxUint8 {
+		return 0, fmt.Errorf("decimals() of %s returned %v, not a uint8", tokenAddr.Hex(), decimals)
+	}
+
+	txm.erc20DecimalsMutex.Lock()
+	defer txm.erc20DecimalsMutex.Unlock()
+	if txm.erc20Decimals == nil {
+		txm.erc20Decimals = map[common.Address]erc20Decimals{}
+	}
+	txm.erc20Decimals[tokenAddr] = erc20Decimals{uint8(decimals.Uint64()), time.Now().Add(erc20DecimalsTTL)}
+	return uint8(decimals.Uint64()), nil
+}
+
 // MeetsMinConfirmations returns true if the given transaction hash has been
 // confirmed on the blockchain.
 func (txm *EthTxManager) MeetsMinConfirmations(hash common.Hash) (bool, error) {
+	rcpt, err := txm.ConfirmedTxReceipt(hash)
+	return rcpt != nil, err
+}
+
+// ConfirmedTxReceipt returns the receipt of whichever attempt of the given
+// transaction has met the minimum confirmations, or nil while none has.
+//
+// A TxMissingError is returned once EthTxMissingThreshold blocks have passed
+// since the transaction was first sent without any attempt being mined.
+func (txm *EthTxManager) ConfirmedTxReceipt(hash common.Hash) (*TxReceipt, error) {
 	blkNum, err := txm.GetBlockNumber()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	attempts, err := txm.getAttempts(hash)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	if len(attempts) == 0 {
-		return false, fmt.Errorf("Can only ensure transactions with attempts")
+		return nil, fmt.Errorf("Can only ensure transactions with attempts")
 	}
 	tx := models.Tx{}
 	if err := txm.orm.One("ID", attempts[0].TxID, &tx); err != nil {
-		return false, err
+		return nil, err
 	}
 
 	var merr error
+	mined := false
+	sentAt := attempts[0].SentAt
 	for _, txat := range attempts {
-		success, err := txm.checkAttempt(&tx, &txat, blkNum)
+		rcpt, confirmed, err := txm.checkAttempt(&tx, &txat, blkNum)
 		merr = multierr.Combine(merr, err)
-		if success {
-			return success, merr
+		if confirmed {
+			return rcpt, merr
+		}
+		mined = mined || rcpt != nil
+		if txat.SentAt < sentAt {
+			sentAt = txat.SentAt
 		}
 	}
-	return false, merr
+
+	threshold := txm.config().EthTxMissingThreshold
+	if !mined && merr == nil && threshold > 0 && blkNum >= sentAt+threshold {
+		return nil, &TxMissingError{Hash: hash, SentAt: sentAt, BlockNumber: blkNum}
+	}
+	return nil, merr
+}
+
+// TxMissingError is returned when none of a transaction's attempts have been
+// mined long after it was first sent, most likely because it was dropped.
+type TxMissingError struct {
+	Hash        common.Hash
+	SentAt      uint64
+	BlockNumber uint64
+}
+
+func (e *TxMissingError) Error() string {
+	return fmt.Sprintf("transaction %s sent at block %d is still not mined at block %d",
+		e.Hash.String(), e.SentAt, e.BlockNumber)
+}
+
+// InsufficientFundsError is returned when the account sending a transaction
+// does not hold the value it transfers and the most gas it could cost.
+type InsufficientFundsError struct {
+	Address  common.Address
+	Required *assets.Eth
+	Balance  *assets.Eth
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("insufficient funds: sending the transaction needs %v ETH including the most it could cost in gas, but %s holds %v ETH",
+		e.Required, e.Address.Hex(), e.Balance)
 }
 
 // WithdrawLink withdraws the given amount of LINK from the contract to the configured withdrawal address
@@ -167,10 +454,10 @@ func (txm *EthTxManager) WithdrawLink(wr models.WithdrawalRequest) (common.Hash,
 		common.LeftPadBytes(amount.Bytes(), utils.EVMWordByteLen),
 	)
 
-	if txm.config.OracleContractAddress == nil {
+	if txm.config().OracleContractAddress == nil {
 		return common.Hash{}, errors.New("OracleContractAddress not set can not withdraw")
 	}
-	tx, err := txm.CreateTx(*txm.config.OracleContractAddress, data)
+	tx, err := txm.CreateTx(*txm.config().OracleContractAddress, data)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -178,31 +465,50 @@ func (txm *EthTxManager) WithdrawLink(wr models.WithdrawalRequest) (common.Hash,
 	return tx.Hash, nil
 }
 
+// createAttempt signs and sends the transaction with gasPrice, making it the
+// transaction's current attempt. The attempt is saved before it is sent, so
+// that it is tracked should the node stop while sending it, and is deleted
+// again if the send fails. The unsaved attempt is still returned with the
+// error.
 func (txm *EthTxManager) createAttempt(
 	tx *models.Tx,
 	gasPrice *big.Int,
 	blkNum uint64,
 ) (*models.TxAttempt, error) {
 	etx := tx.EthTx(gasPrice)
-	etx, err := txm.keyStore.SignTx(etx, txm.config.ChainID)
+	etx, err := txm.signer.SignTx(etx, txm.config().ChainID)
 	if err != nil {
 		return nil, err
 	}
 
+	previous := tx.TxAttempt
 	a, err := txm.orm.AddAttempt(tx, etx, blkNum)
 	if err != nil {
 		return nil, err
 	}
-	return a, txm.sendTransaction(etx)
+	if err := txm.sendTransaction(etx); err != nil {
+		if rmErr := txm.orm.RemoveAttempt(tx, a, previous); rmErr != nil {
+			logger.Errorw(fmt.Sprintf("Unable to remove unsent attempt %v: %v", a.Hash.String(), rmErr), "txat", a)
+		}
+		return a, err
+	}
+	return a, nil
 }
 
+// sendTransaction sends the signed transaction, returning a SendError when
+// the node rejects it. A node which already has the transaction rejects it
+// as known, which is as good as accepting it.
 func (txm *EthTxManager) sendTransaction(tx *types.Transaction) error {
 	hex, err := utils.EncodeTxToHex(tx)
 	if err != nil {
 		return err
 	}
 	if _, err = txm.SendRawTx(hex); err != nil {
-		return fmt.Errorf("TxManager sendTransaction: %v", err)
+		sendErr := NewSendError(err)
+		if !sendErr.IsTransactionAlreadyInMempool() {
+			return sendErr
+		}
+		logger.Debugw(fmt.Sprintf("Transaction %s is already in the mempool", tx.Hash().Hex()), "tx", tx.Hash().Hex())
 	}
 	return nil
 }
@@ -219,20 +525,23 @@ func (txm *EthTxManager) getAttempts(hash common.Hash) ([]models.TxAttempt, erro
 	return attempts, nil
 }
 
+// checkAttempt returns the attempt's receipt if it has been mined, and
+// whether it has met the minimum confirmations.
 func (txm *EthTxManager) checkAttempt(
 	tx *models.Tx,
 	txat *models.TxAttempt,
 	blkNum uint64,
-) (bool, error) {
+) (*TxReceipt, bool, error) {
 	receipt, err := txm.GetTxReceipt(txat.Hash)
 	if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
 	if receipt.Unconfirmed() {
-		return txm.handleUnconfirmed(tx, txat, blkNum)
+		return nil, false, txm.handleUnconfirmed(tx, txat, blkNum)
 	}
-	return txm.handleConfirmed(tx, txat, receipt, blkNum)
+	confirmed, err := txm.handleConfirmed(tx, txat, receipt, blkNum)
+	return receipt, confirmed, err
 }
 
 func (txm *EthTxManager) handleConfirmed(
@@ -242,7 +551,7 @@ func (txm *EthTxManager) handleConfirmed(
 	blkNum uint64,
 ) (bool, error) {
 
-	minConfs := big.NewInt(int64(txm.config.MinOutgoingConfirmations))
+	minConfs := big.NewInt(int64(txm.config().MinOutgoingConfirmations))
 	rcptBlkNum := rcpt.BlockNumber.ToBig()
 	safeAt := minConfs.Add(rcptBlkNum, minConfs)
 	safeAt.Sub(safeAt, big.NewInt(1)) // 0 based indexing since rcpt is 1 conf
@@ -261,13 +570,13 @@ func (txm *EthTxManager) handleUnconfirmed(
 	tx *models.Tx,
 	txat *models.TxAttempt,
 	blkNum uint64,
-) (bool, error) {
-	bumpable := tx.Hash == txat.Hash
-	pastThreshold := blkNum >= txat.SentAt+txm.config.EthGasBumpThreshold
+) error {
+	bumpable := tx.Hash == txat.Hash && !tx.BumpRejected
+	pastThreshold := blkNum >= txat.SentAt+txm.config().EthGasBumpThreshold
 	if bumpable && pastThreshold {
-		return false, txm.bumpGas(txat, blkNum)
+		return txm.bumpGas(txat, blkNum)
 	}
-	return false, nil
+	return nil
 }
 
 func (txm *EthTxManager) bumpGas(txat *models.TxAttempt, blkNum uint64) error {
@@ -275,10 +584,44 @@ func (txm *EthTxManager) bumpGas(txat *models.TxAttempt, blkNum uint64) error {
 	if err := txm.orm.One("ID", txat.TxID, tx); err != nil {
 		return err
 	}
-	gasPrice := new(big.Int).Add(txat.GasPrice, &txm.config.EthGasBumpWei)
-	txat, err := txm.createAttempt(tx, gasPrice, blkNum)
-	logger.Infow(fmt.Sprintf("Bumping gas to %v for transaction %v", gasPrice, txat.Hash.String()), "txat", txat)
-	return err
+	config := txm.config()
+	maxPrice := &config.EthMaxGasPriceWei
+	if txat.GasPrice.Cmp(maxPrice) >= 0 {
+		logger.Debugw(fmt.Sprintf("Not bumping gas for transaction %v, already at ETH_MAX_GAS_PRICE_WEI", txat.Hash.String()), "txat", txat)
+		return nil
+	}
+
+	gasPrice := new(big.Int).Add(txat.GasPrice, &config.EthGasBumpWei)
+	if gasPrice.Cmp(maxPrice) >= 0 {
+		gasPrice.Set(maxPrice)
+		logger.Errorw(
+			fmt.Sprintf("Gas for transaction %v has reached ETH_MAX_GAS_PRICE_WEI of %v, and will not be bumped again", tx.Hash.String(), maxPrice),
+			"txat", txat, "nonce", tx.Nonce,
+		)
+	}
+	attempt, err := txm.createAttempt(tx, gasPrice, blkNum)
+	if sendErr, ok := err.(*SendError); ok {
+		if sendErr.IsReplacementUnderpriced() && gasPrice.Cmp(txat.GasPrice) > 0 {
+			logger.Infow(
+				fmt.Sprintf("Gas price of %v is too low to replace transaction %v, bumping it again", gasPrice, txat.Hash.String()),
+				"txat", attempt, "err", sendErr)
+			return txm.bumpGas(attempt, blkNum)
+		}
+		if sendErr.Fatal() {
+			logger.Errorw(
+				fmt.Sprintf("Node rejected the gas bump of transaction %v, which no gas price will fix, and it will not be bumped again: %v", tx.Hash.String(), sendErr),
+				"txat", attempt, "nonce", tx.Nonce, "err", sendErr)
+			tx.BumpRejected = true
+			if saveErr := txm.orm.Save(tx); saveErr != nil {
+				logger.Errorw(fmt.Sprintf("Unable to save transaction %v: %v", tx.Hash.String(), saveErr), "nonce", tx.Nonce)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	logger.Infow(fmt.Sprintf("Bumping gas to %v for transaction %v", gasPrice, attempt.Hash.String()), "txat", attempt)
+	return nil
 }
 
 // GetActiveAccount returns a copy of the TxManager's active nonce managed
@@ -293,10 +636,44 @@ func (txm *EthTxManager) GetActiveAccount() *ActiveAccount {
 	}
 }
 
+// keyLoader is implemented by signers which fetch an account's key when the
+// TxManager activates it, rather than holding every key from the start.
+type keyLoader interface {
+	LoadKey(accounts.Account) error
+}
+
 // ActivateAccount retrieves an account's nonce from the blockchain for client
-// side management in ActiveAccount.
+// side management in ActiveAccount, reconciled with the transactions in the
+// database, first loading its key if the signer fetches keys on activation.
+// The account is activated on each of the other chains too, with its nonce
+// there.
 func (txm *EthTxManager) ActivateAccount(account accounts.Account) error {
-	nonce, err := txm.GetNonce(account.Address)
+	if loader, ok := txm.signer.(keyLoader); ok {
+		if err := loader.LoadKey(account); err != nil {
+			return fmt.Errorf("unable to load key for %s: %v", account.Address.Hex(), err)
+		}
+	}
+	if err := txm.activate(account); err != nil {
+		return err
+	}
+	for _, id := range txm.chainIDs() {
+		if err := txm.chains[id].activate(account); err != nil {
+			return fmt.Errorf("unable to activate account on chain %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (txm *EthTxManager) activate(account accounts.Account) error {
+	var local uint64
+	if txm.ownsTxs() {
+		next, err := txm.orm.NextNonce(account.Address)
+		if err != nil {
+			return fmt.Errorf("unable to load the transactions of %s: %v", account.Address.Hex(), err)
+		}
+		local = next
+	}
+	nonce, err := txm.reconcileNonce(account.Address, local)
 	if err != nil {
 		return err
 	}
@@ -305,13 +682,122 @@ func (txm *EthTxManager) ActivateAccount(account accounts.Account) error {
 	return nil
 }
 
-// ReloadNonce fetch and update the current nonce via eth_getTransactionCount
+// ownsTxs is whether every transaction from the account in the database was
+// sent by this manager. Transactions are stored without their chain, so this
+// is only so for the manager of the chain at ETH_URL, and only when the node
+// is configured for no other chains. The other chains' managers have no
+// chains of their own.
+func (txm *EthTxManager) ownsTxs() bool {
+	return txm.chains != nil && len(txm.chains) == 0
+}
+
+// reconcileNonce returns the nonce to send the account's next transaction
+// with, given the local nonce the node expects it to be. The chain's count of
+// the account's transactions at ETH_NONCE_BLOCK is adopted when it is ahead,
+// as when a transaction was sent without the node or the database was
+// restored from a backup. When it is behind, the local nonce is kept, and the
+// node's unconfirmed transactions with the nonces in between are broadcast
+// again, so that the chain is not left waiting on a gap.
+func (txm *EthTxManager) reconcileNonce(address common.Address, local uint64) (uint64, error) {
+	block := txm.config().EthNonceBlock
+	chain, err := txm.GetNonceAt(address, block)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the nonce of %s: %v", address.Hex(), err)
+	}
+
+	switch {
+	case chain > local:
+		logger.Warnw(
+			fmt.Sprintf("Nonce of %d on chain is ahead of the local nonce of %d, adopting it", chain, local),
+			"address", address.Hex(), "block", block, "before", local, "after", chain)
+		return chain, nil
+	case chain < local:
+		missing := local - chain
+		if txm.ownsTxs() {
+			missing = txm.rebroadcast(address, chain, local)
+		}
+		if missing > 0 {
+			logger.Warnw(
+				fmt.Sprintf("Nonce of %d on chain is behind the local nonce of %d, with %d nonces in between the node has no transaction to send again for. Keeping the local nonce, but transactions after the gap will not be mined until it is filled", chain, local, missing),
+				"address", address.Hex(), "block", block, "before", local, "after", local, "missing", missing)
+		} else {
+			logger.Infow(
+				fmt.Sprintf("Nonce of %d on chain is behind the local nonce of %d, keeping it and waiting for the transactions in between to be mined", chain, local),
+				"address", address.Hex(), "block", block, "before", local, "after", local)
+		}
+	default:
+		logger.Debugw(
+			fmt.Sprintf("Local nonce of %d matches the chain", local),
+			"address", address.Hex(), "block", block, "before", local, "after", local)
+	}
+	return local, nil
+}
+
+// rebroadcast sends the latest attempt of each unconfirmed transaction from
+// the account with a nonce from start up to end again, returning how many of
+// those nonces have no transaction in the database.
+func (txm *EthTxManager) rebroadcast(address common.Address, start, end uint64) uint64 {
+	txs, err := txm.orm.TxsFromNonce(address, start)
+	if err != nil {
+		logger.Errorw(fmt.Sprintf("Unable to load transactions to broadcast again: %v", err), "address", address.Hex())
+		return end - start
+	}
+
+	found := map[uint64]bool{}
+	for _, tx := range txs {
+		if tx.Nonce >= end {
+			break
+		}
+		found[tx.Nonce] = true
+		if tx.Confirmed || tx.Hex == "" {
+			continue
+		}
+		if _, err := txm.SendRawTx(tx.Hex); err != nil && !NewSendError(err).IsTransactionAlreadyInMempool() {
+			logger.Debugw(
+				fmt.Sprintf("Transaction %s with nonce %d was not broadcast again: %v", tx.Hash.Hex(), tx.Nonce, err),
+				"address", address.Hex(), "nonce", tx.Nonce, "tx", tx.Hash.Hex())
+			continue
+		}
+		logger.Infow(
+			fmt.Sprintf("Broadcast transaction %s with nonce %d again", tx.Hash.Hex(), tx.Nonce),
+			"address", address.Hex(), "nonce", tx.Nonce, "tx", tx.Hash.Hex())
+	}
+	return end - start - uint64(len(found))
+}
+
+// ForChain returns the TxManager for the chain, each of which has its own
+// connection, settings and nonce.
+func (txm *EthTxManager) ForChain(chainID uint64) TxManager {
+	if chainID == 0 || chainID == txm.config().ChainID {
+		return txm
+	}
+	if chain, ok := txm.chains[chainID]; ok {
+		return chain
+	}
+	return nil
+}
+
+func (txm *EthTxManager) chainIDs() []uint64 {
+	ids := make([]uint64, 0, len(txm.chains))
+	for id := range txm.chains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// ReloadNonce reconciles the active account's nonce with the chain's, from
+// eth_getTransactionCount. See reconcileNonce.
 func (txm *EthTxManager) ReloadNonce() error {
-	nonce, err := txm.GetNonce(txm.activeAccount.Address)
+	account := txm.activeAccount
+	account.mutex.Lock()
+	defer account.mutex.Unlock()
+
+	nonce, err := txm.reconcileNonce(account.Address, account.nonce)
 	if err != nil {
 		return fmt.Errorf("TxManager ReloadNonce: %v", err)
 	}
-	txm.activeAccount.nonce = nonce
+	account.nonce = nonce
 	return nil
 }
 
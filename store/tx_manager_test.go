@@ -2,18 +2,26 @@ package store_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"math/big"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/metrics"
 	strpkg "github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/assets"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTxManager_CreateTx_Success(t *testing.T) {
@@ -227,6 +235,178 @@ func TestTxManager_CreateTx_NonceTooLowReloadLimit(t *testing.T) {
 	ethMock.EventuallyAllCalled(t)
 }
 
+func TestTxManager_CreateTx_AlreadyInMempool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		ethClientErrorMsg string
+	}{
+		{"geth", "known transaction: 2b0c6c8b3a1f6ba8c5c4c8d4e2d5a0e6b1c3f5a7d9e1b3c5a7f9e1d3b5c7a9e1"},
+		{"parity", "Transaction with the same hash was already imported."},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			app, cleanup := cltest.NewApplicationWithKeyStore()
+			defer cleanup()
+			store := app.Store
+			ethMock := app.MockEthClient()
+
+			nonce := uint64(256)
+			ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(nonce))
+			require.NoError(t, app.Start())
+
+			ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+			ethMock.RegisterError("eth_sendRawTransaction", test.ethClientErrorMsg)
+			tx, err := store.TxManager.CreateTx(cltest.NewAddress(), []byte{0xab})
+			require.NoError(t, err, "the node already having the transaction is as good as sending it")
+			assert.Equal(t, nonce, tx.Nonce)
+			assert.Equal(t, nonce+1, store.TxManager.GetActiveAccount().GetNonce())
+			attempts, err := store.AttemptsFor(tx.ID)
+			require.NoError(t, err)
+			assert.Len(t, attempts, 1)
+
+			ethMock.EventuallyAllCalled(t)
+		})
+	}
+}
+
+func TestTxManager_CreateTx_FatalSendError(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+
+	nonce := uint64(256)
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(nonce))
+	require.NoError(t, app.Start())
+
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+	ethMock.RegisterError("eth_sendRawTransaction", "exceeds block gas limit")
+	_, err := store.TxManager.CreateTx(cltest.NewAddress(), []byte{0xab})
+
+	sendErr, ok := err.(*strpkg.SendError)
+	require.True(t, ok, "want a SendError, got %v", err)
+	assert.True(t, sendErr.Fatal())
+	assert.Equal(t, nonce, store.TxManager.GetActiveAccount().GetNonce())
+	var txs []models.Tx
+	require.NoError(t, store.All(&txs))
+	assert.Len(t, txs, 0)
+	ethMock.EventuallyAllCalled(t)
+}
+
+func TestTxManager_CreateTxWithGas(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthGasPriceDefault = *big.NewInt(20)
+	config.EthMaxGasPriceWei = *big.NewInt(100)
+	config.EthMaxGasLimit = 1000000
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(256))
+	require.NoError(t, app.Start())
+
+	tests := []struct {
+		name      string
+		gasPrice  *big.Int
+		gasLimit  uint64
+		wantPrice int64
+		wantLimit uint64
+	}{
+		{"defaults", nil, 0, 20, 500000},
+		{"zero price", big.NewInt(0), 0, 20, 500000},
+		{"overrides", big.NewInt(50), 700000, 50, 700000},
+		{"over maximums", big.NewInt(200), 9000000, 100, 1000000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+			ethMock.Register("eth_sendRawTransaction", cltest.NewHash(), func(_ interface{}, data ...interface{}) error {
+				signed, err := utils.DecodeEthereumTx(data[0].([]interface{})[0].(string))
+				require.NoError(t, err)
+				assert.Equal(t, big.NewInt(test.wantPrice), signed.GasPrice())
+				assert.Equal(t, test.wantLimit, signed.Gas())
+				return nil
+			})
+
+			a, err := store.TxManager.CreateTxWithGas(cltest.NewAddress(), []byte{0xab}, test.gasPrice, test.gasLimit)
+			require.NoError(t, err)
+			ethMock.EventuallyAllCalled(t)
+
+			tx := models.Tx{}
+			require.NoError(t, store.One("ID", a.TxID, &tx))
+			assert.Equal(t, test.wantLimit, tx.GasLimit)
+			assert.Equal(t, big.NewInt(test.wantPrice), tx.GasPrice)
+			attempts, err := store.AttemptsFor(tx.ID)
+			require.NoError(t, err)
+			require.Len(t, attempts, 1)
+			assert.Equal(t, big.NewInt(test.wantPrice), attempts[0].GasPrice)
+		})
+	}
+}
+
+func TestTxManager_CreateTxWithValue(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthGasPriceDefault = *big.NewInt(20)
+	config.EthMaxGasPriceWei = *big.NewInt(100)
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(256))
+	require.NoError(t, app.Start())
+	to := cltest.NewAddress()
+
+	// 1000 wei and 500000 gas at the maximum price of 100 wei.
+	required := int64(1000 + 500000*100)
+
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+	ethMock.Register("eth_getBalance", utils.Uint64ToHex(uint64(required-1)))
+	_, err := store.TxManager.CreateTxWithValue(to, assets.NewEth(1000), nil)
+	require.Error(t, err)
+	fundsErr, ok := err.(*strpkg.InsufficientFundsError)
+	require.True(t, ok, "got %v", err)
+	assert.Equal(t, assets.NewEth(required), fundsErr.Required)
+	assert.Equal(t, assets.NewEth(required-1), fundsErr.Balance)
+	assert.Contains(t, err.Error(), fundsErr.Required.String())
+	assert.Contains(t, err.Error(), fundsErr.Balance.String())
+	ethMock.EventuallyAllCalled(t)
+	assert.Equal(t, uint64(256), store.TxManager.GetActiveAccount().GetNonce(), "the nonce is not used")
+
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+	ethMock.Register("eth_getBalance", utils.Uint64ToHex(uint64(required)))
+	ethMock.Register("eth_sendRawTransaction", cltest.NewHash(), func(_ interface{}, data ...interface{}) error {
+		signed, err := utils.DecodeEthereumTx(data[0].([]interface{})[0].(string))
+		require.NoError(t, err)
+		assert.Equal(t, to, *signed.To())
+		assert.Equal(t, big.NewInt(1000), signed.Value())
+		assert.Equal(t, []byte{0xab}, signed.Data())
+		return nil
+	})
+	a, err := store.TxManager.CreateTxWithValue(to, assets.NewEth(1000), []byte{0xab})
+	require.NoError(t, err)
+	ethMock.EventuallyAllCalled(t)
+
+	tx := models.Tx{}
+	require.NoError(t, store.One("ID", a.TxID, &tx))
+	assert.Equal(t, big.NewInt(1000), tx.Value)
+
+	_, err = store.TxManager.CreateTxWithValue(to, assets.NewEth(-1), nil)
+	assert.Error(t, err)
+}
+
 func TestTxManager_MeetsMinConfirmations(t *testing.T) {
 	t.Parallel()
 
@@ -283,6 +463,146 @@ func TestTxManager_MeetsMinConfirmations(t *testing.T) {
 	}
 }
 
+func TestTxManager_MeetsMinConfirmations_BumpsGasUpToMax(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthGasBumpWei = *big.NewInt(5)
+	config.EthMaxGasPriceWei = *big.NewInt(12)
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+
+	sentAt := uint64(23456)
+	tx := cltest.CreateTxAndAttempt(store, cltest.GetAccountAddress(store), sentAt)
+	hash := tx.Hash
+
+	gasPrices := func() []int64 {
+		attempts, err := store.AttemptsFor(tx.ID)
+		require.NoError(t, err)
+		prices := []int64{}
+		for _, a := range attempts {
+			prices = append(prices, a.GasPrice.Int64())
+			assert.Equal(t, tx.ID, a.TxID)
+		}
+		sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+		return prices
+	}
+
+	rounds := []struct {
+		wantPrices []int64
+	}{
+		{[]int64{1, 6}},
+		{[]int64{1, 6, 11}},
+		{[]int64{1, 6, 11, 12}},
+		{[]int64{1, 6, 11, 12}},
+	}
+	blkNum := sentAt
+	for i, round := range rounds {
+		blkNum += config.EthGasBumpThreshold
+		ethMock.Register("eth_blockNumber", utils.Uint64ToHex(blkNum))
+		for range gasPrices() {
+			ethMock.Register("eth_getTransactionReceipt", strpkg.TxReceipt{})
+		}
+		bumps := len(round.wantPrices) > len(gasPrices())
+		if bumps {
+			ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+		}
+
+		confirmed, err := store.TxManager.MeetsMinConfirmations(hash)
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+		assert.Equal(t, round.wantPrices, gasPrices(), "round %d", i)
+		ethMock.EventuallyAllCalled(t)
+	}
+
+	blkNum += config.MinOutgoingConfirmations
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(blkNum))
+	ethMock.Register("eth_getTransactionReceipt", strpkg.TxReceipt{Hash: cltest.NewHash(), BlockNumber: cltest.Int(sentAt + 1)})
+	confirmed, err := store.TxManager.MeetsMinConfirmations(hash)
+	require.NoError(t, err)
+	assert.True(t, confirmed, "any of the attempts landing confirms the transaction")
+	ethMock.EventuallyAllCalled(t)
+}
+
+func TestTxManager_MeetsMinConfirmations_BumpsAgainWhenUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.EthGasBumpWei = *big.NewInt(5)
+	config.EthMaxGasPriceWei = *big.NewInt(100)
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+
+	sentAt := uint64(23456)
+	tx := cltest.CreateTxAndAttempt(store, cltest.GetAccountAddress(store), sentAt)
+
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(sentAt+config.EthGasBumpThreshold))
+	ethMock.Register("eth_getTransactionReceipt", strpkg.TxReceipt{})
+	ethMock.RegisterError("eth_sendRawTransaction", "replacement transaction underpriced")
+	ethMock.RegisterError("eth_sendRawTransaction", "Transaction gas price is too low. There is another transaction with same nonce in the queue. Try increasing the gas price or incrementing the nonce.")
+	ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+
+	confirmed, err := store.TxManager.MeetsMinConfirmations(tx.Hash)
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+	ethMock.EventuallyAllCalled(t)
+
+	attempts, err := store.AttemptsFor(tx.ID)
+	require.NoError(t, err)
+	prices := []int64{}
+	for _, a := range attempts {
+		prices = append(prices, a.GasPrice.Int64())
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+	assert.Equal(t, []int64{1, 16}, prices, "rejected attempts are not kept")
+
+	require.NoError(t, store.One("ID", tx.ID, tx))
+	assert.Equal(t, int64(16), tx.GasPrice.Int64())
+}
+
+func TestTxManager_MeetsMinConfirmations_StopsBumpingWhenFatal(t *testing.T) {
+	t.Parallel()
+
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+
+	sentAt := uint64(23456)
+	tx := cltest.CreateTxAndAttempt(store, cltest.GetAccountAddress(store), sentAt)
+	hash := tx.Hash
+
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(sentAt+config.EthGasBumpThreshold))
+	ethMock.Register("eth_getTransactionReceipt", strpkg.TxReceipt{})
+	ethMock.RegisterError("eth_sendRawTransaction", "exceeds block gas limit")
+	_, err := store.TxManager.MeetsMinConfirmations(hash)
+	require.Error(t, err)
+	ethMock.EventuallyAllCalled(t)
+
+	require.NoError(t, store.One("ID", tx.ID, tx))
+	assert.Equal(t, hash, tx.Hash, "the rejected attempt does not replace the sent one")
+	assert.True(t, tx.BumpRejected)
+	attempts, err := store.AttemptsFor(tx.ID)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 1)
+
+	// No further bump is sent.
+	ethMock.Register("eth_blockNumber", utils.Uint64ToHex(sentAt+2*config.EthGasBumpThreshold))
+	ethMock.Register("eth_getTransactionReceipt", strpkg.TxReceipt{})
+	confirmed, err := store.TxManager.MeetsMinConfirmations(hash)
+	require.NoError(t, err)
+	assert.False(t, confirmed)
+	ethMock.EventuallyAllCalled(t)
+}
+
 func TestTxManager_MeetsMinConfirmations_erroring(t *testing.T) {
 	t.Parallel()
 
@@ -356,6 +676,60 @@ func TestTxManager_MeetsMinConfirmations_erroring(t *testing.T) {
 	}
 }
 
+func TestTxManager_ConfirmedTxReceipt_Missing(t *testing.T) {
+	t.Parallel()
+
+	sentAt := uint64(23456)
+	minedReceipt := strpkg.TxReceipt{Hash: cltest.NewHash(), BlockNumber: cltest.Int(sentAt + 18)}
+
+	tests := []struct {
+		name        string
+		threshold   uint64
+		blockHeight uint64
+		receipt     strpkg.TxReceipt
+		wantMissing bool
+	}{
+		{"unmined < threshold", 20, sentAt + 19, strpkg.TxReceipt{}, false},
+		{"unmined == threshold", 20, sentAt + 20, strpkg.TxReceipt{}, true},
+		{"unmined > threshold", 20, sentAt + 21, strpkg.TxReceipt{}, true},
+		{"mined but unconfirmed", 20, sentAt + 20, minedReceipt, false},
+		{"threshold disabled", 0, sentAt + 1000, strpkg.TxReceipt{}, false},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			config, cfgCleanup := cltest.NewConfig()
+			defer cfgCleanup()
+			config.EthGasBumpThreshold = 10000
+			config.EthTxMissingThreshold = test.threshold
+			app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+			defer cleanup()
+			store := app.Store
+			ethMock := app.MockEthClient()
+
+			tx := cltest.CreateTxAndAttempt(store, cltest.GetAccountAddress(store), sentAt)
+			ethMock.Register("eth_blockNumber", utils.Uint64ToHex(test.blockHeight))
+			ethMock.Register("eth_getTransactionReceipt", test.receipt)
+
+			receipt, err := store.TxManager.ConfirmedTxReceipt(tx.Hash)
+			assert.Nil(t, receipt)
+			if test.wantMissing {
+				missing, ok := err.(*strpkg.TxMissingError)
+				assert.True(t, ok, "expected a TxMissingError, got %v", err)
+				if ok {
+					assert.Equal(t, sentAt, missing.SentAt)
+					assert.Equal(t, test.blockHeight, missing.BlockNumber)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			ethMock.EventuallyAllCalled(t)
+		})
+	}
+}
+
 func TestTxManager_ActivateAccount(t *testing.T) {
 	t.Parallel()
 
@@ -374,20 +748,104 @@ func TestTxManager_ActivateAccount(t *testing.T) {
 	assert.Equal(t, uint64(0x2d0), aa.GetNonce())
 }
 
-func TestTxManager_ReloadNonce(t *testing.T) {
+func TestTxManager_GetEthBalance_RecordsMetric(t *testing.T) {
 	t.Parallel()
 
 	ethMock := &cltest.EthMock{}
 	txm := &strpkg.EthTxManager{
 		EthClient: &strpkg.EthClient{CallerSubscriber: ethMock},
 	}
-	account := accounts.Account{Address: common.HexToAddress("0xbf4ed7b27f1d666546e30d74d50d173d20bca754")}
+	address := cltest.NewAddress()
+
+	ethMock.Register("eth_getBalance", "0x0100")
+	balance, err := txm.GetEthBalance(address)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.000000000000000256", balance.String())
+	assert.Equal(t, 256.0, testutil.ToFloat64(metrics.EthBalance.WithLabelValues(address.Hex())))
+}
+
+func TestTxManager_GetERC20Balance(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	txm := &strpkg.EthTxManager{
+		EthClient: &strpkg.EthClient{CallerSubscriber: ethMock},
+	}
+	token, holder := cltest.NewAddress(), cltest.NewAddress()
+
+	ethMock.Register("eth_call", "0x16345785d8a0000", func(_ interface{}, data ...interface{}) error { // 1e17
+		args := data[0].([]interface{})
+		b, err := json.Marshal(args[0])
+		require.NoError(t, err)
+		want := "0x70a08231" + hex.EncodeToString(common.LeftPadBytes(holder.Bytes(), utils.EVMWordByteLen))
+		assert.JSONEq(t, `{"to":"`+strings.ToLower(token.Hex())+`","data":"`+want+`"}`, string(b))
+		return nil
+	})
+	balance, err := txm.GetERC20Balance(token, holder)
+	require.NoError(t, err)
+	ethMock.EventuallyAllCalled(t)
+	assert.Equal(t, big.NewInt(100000000000000000), balance)
+}
+
+func TestTxManager_GetERC20Decimals_Cached(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	txm := &strpkg.EthTxManager{
+		EthClient: &strpkg.EthClient{CallerSubscriber: ethMock},
+	}
+	usdc, dai := cltest.NewAddress(), cltest.NewAddress()
+
+	ethMock.Register("eth_call", evmWords(6), expectCall(t, usdc, "0x313ce567"))
+	ethMock.Register("eth_call", evmWords(18), expectCall(t, dai, "0x313ce567"))
+	for i := 0; i < 2; i++ {
+		decimals, err := txm.GetERC20Decimals(usdc)
+		require.NoError(t, err)
+		assert.Equal(t, uint8(6), decimals)
+		decimals, err = txm.GetERC20Decimals(dai)
+		require.NoError(t, err)
+		assert.Equal(t, uint8(18), decimals)
+	}
+	ethMock.EventuallyAllCalled(t)
+}
+
+func TestTxManager_GetERC20Decimals_NotAUint8(t *testing.T) {
+	t.Parallel()
+
+	ethMock := &cltest.EthMock{}
+	txm := &strpkg.EthTxManager{
+		EthClient: &strpkg.EthClient{CallerSubscriber: ethMock},
+	}
+	token := cltest.NewAddress()
+
+	ethMock.Register("eth_call", evmWords(256))
+	_, err := txm.GetERC20Decimals(token)
+	assert.EqualError(t, err, "decimals() of "+token.Hex()+" returned 256, not a uint8")
+
+	ethMock.Register("eth_call", hexutil.Bytes{})
+	_, err = txm.GetERC20Decimals(token)
+	assert.EqualError(t, err, "decimals() of "+token.Hex()+" returned 0 bytes, not a uint8")
+
+	ethMock.Register("eth_call", evmWords(8))
+	decimals, err := txm.GetERC20Decimals(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(8), decimals, "errors are not cached")
+}
+
+func TestTxManager_ReloadNonce(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	txm := app.Store.TxManager.(*strpkg.EthTxManager)
+	ethMock := app.MockEthClient()
+	account := cltest.GetAccountAddress(app.Store)
 
 	ethMock.Register("eth_getTransactionCount", `0x2D0`)
-	assert.NoError(t, txm.ActivateAccount(account))
+	require.NoError(t, app.Start())
 
 	aa := txm.GetActiveAccount()
-	assert.Equal(t, account.Address, aa.Address)
+	assert.Equal(t, account, aa.Address)
 	assert.Equal(t, uint64(0x2d0), aa.GetNonce())
 
 	ethMock.Register("eth_getTransactionCount", `0x2D1`)
@@ -395,10 +853,169 @@ func TestTxManager_ReloadNonce(t *testing.T) {
 	ethMock.EventuallyAllCalled(t)
 
 	aa = txm.GetActiveAccount()
-	assert.Equal(t, account.Address, aa.Address)
+	assert.Equal(t, account, aa.Address)
 	assert.Equal(t, uint64(0x2d1), aa.GetNonce())
 }
 
+// saveTxs saves a transaction with an attempt from the account for each of
+// the nonces.
+func saveTxs(t *testing.T, store *strpkg.Store, from common.Address, nonces ...uint64) []*models.Tx {
+	txs := []*models.Tx{}
+	for _, nonce := range nonces {
+		tx := cltest.NewTx(from, 0)
+		tx.Nonce = nonce
+		require.NoError(t, store.Save(tx))
+		_, err := store.AddAttempt(tx, tx.EthTx(big.NewInt(1)), 0)
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func TestTxManager_ActivateAccount_ChainNonceAhead(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+	from := cltest.GetAccountAddress(store)
+	saveTxs(t, store, from, 0, 1, 2)
+
+	// A transaction was sent without the node, so the chain is ahead of the
+	// database.
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(5))
+	require.NoError(t, app.Start())
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, uint64(5), store.TxManager.GetActiveAccount().GetNonce())
+}
+
+func TestTxManager_ActivateAccount_ChainNonceBehind(t *testing.T) {
+	t.Parallel()
+
+	config, configCleanup := cltest.NewConfig()
+	defer configCleanup()
+	config.EthNonceBlock = "pending"
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+	ethMock := app.MockEthClient()
+	from := cltest.GetAccountAddress(store)
+	// The transaction with nonce 2 is missing, leaving a gap.
+	txs := saveTxs(t, store, from, 0, 1, 3)
+
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(1), func(_ interface{}, data ...interface{}) error {
+		assert.Equal(t, "pending", data[0].([]interface{})[1])
+		return nil
+	})
+	for _, tx := range txs[1:] {
+		hex := tx.Hex
+		ethMock.Register("eth_sendRawTransaction", tx.Hash, func(_ interface{}, data ...interface{}) error {
+			assert.Equal(t, hex, data[0].([]interface{})[0])
+			return nil
+		})
+	}
+	require.NoError(t, app.Start())
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, uint64(4), store.TxManager.GetActiveAccount().GetNonce(), "the local nonce is kept")
+}
+
+func TestTxManager_ReloadNonce_ChainNonceBehind(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	store := app.Store
+	txm := store.TxManager.(*strpkg.EthTxManager)
+	ethMock := app.MockEthClient()
+
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(3))
+	require.NoError(t, app.Start())
+	txs := []*models.Tx{}
+	for i := 0; i < 2; i++ {
+		ethMock.Register("eth_blockNumber", utils.Uint64ToHex(10))
+		ethMock.Register("eth_sendRawTransaction", cltest.NewHash())
+		tx, err := txm.CreateTx(cltest.NewAddress(), []byte{})
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+	require.NoError(t, store.ConfirmTx(txs[0], &txs[0].TxAttempt))
+
+	// Neither transaction has been mined, but the first is confirmed in the
+	// database, so only the second is sent again.
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(3))
+	ethMock.Register("eth_sendRawTransaction", txs[1].Hash, func(_ interface{}, data ...interface{}) error {
+		assert.Equal(t, txs[1].Hex, data[0].([]interface{})[0])
+		return nil
+	})
+	require.NoError(t, txm.ReloadNonce())
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, uint64(5), txm.GetActiveAccount().GetNonce(), "the local nonce is kept")
+}
+
+func TestTxManager_ForChain_CreateTxOnTwoChains(t *testing.T) {
+	t.Parallel()
+
+	config, configCleanup := cltest.NewConfig()
+	defer configCleanup()
+	config.Chains = map[uint64]strpkg.ChainConfig{
+		137:   {RPCURL: config.EthereumURL, MinConfirmations: 20, GasPrice: *big.NewInt(30000000000)},
+		42161: {RPCURL: config.EthereumURL, GasPrice: *big.NewInt(1000000000)},
+	}
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	store := app.Store
+
+	ethMock := app.MockEthClient()
+	polygonMock := cltest.MockEthOnChain(store, 137)
+	arbitrumMock := cltest.MockEthOnChain(store, 42161)
+	ethMock.Register("eth_getTransactionCount", utils.Uint64ToHex(256))
+	polygonMock.Register("eth_getTransactionCount", utils.Uint64ToHex(12))
+	arbitrumMock.Register("eth_getTransactionCount", utils.Uint64ToHex(7))
+	assert.NoError(t, app.Start())
+
+	chains := []struct {
+		id       uint64
+		mock     *cltest.EthMock
+		nonce    uint64
+		gasPrice *big.Int
+	}{
+		{137, polygonMock, 12, big.NewInt(30000000000)},
+		{42161, arbitrumMock, 7, big.NewInt(1000000000)},
+	}
+	txs := make([]*models.Tx, len(chains))
+	errs := make([]error, len(chains))
+	var wg sync.WaitGroup
+	for i, chain := range chains {
+		chain.mock.Register("eth_sendRawTransaction", cltest.NewHash())
+		chain.mock.Register("eth_blockNumber", utils.Uint64ToHex(23456))
+		wg.Add(1)
+		go func(i int, chainID uint64) {
+			defer wg.Done()
+			txs[i], errs[i] = store.TxManager.ForChain(chainID).CreateTx(cltest.NewAddress(), []byte{0xab})
+		}(i, chain.id)
+	}
+	wg.Wait()
+
+	for i, chain := range chains {
+		require.NoError(t, errs[i])
+		assert.Equal(t, chain.nonce, txs[i].Nonce)
+		attempts, err := store.AttemptsFor(txs[i].ID)
+		require.NoError(t, err)
+		require.Len(t, attempts, 1)
+		assert.Equal(t, chain.gasPrice, attempts[0].GasPrice)
+		chain.mock.EventuallyAllCalled(t)
+	}
+	ethMock.EventuallyAllCalled(t)
+
+	assert.Equal(t, store.TxManager, store.TxManager.ForChain(0))
+	assert.Equal(t, store.TxManager, store.TxManager.ForChain(3))
+	assert.Nil(t, store.TxManager.ForChain(999))
+}
+
 func TestTxManager_WithdrawLink(t *testing.T) {
 	t.Parallel()
 	config, configCleanup := cltest.NewConfig()
@@ -0,0 +1,274 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+)
+
+// VaultKeyStore keeps the node's keys in the KV version 2 secrets engine of a
+// HashiCorp Vault, instead of on the node's disk. Each key is stored as an
+// encrypted JSON keystore, under the key's address, so Vault never sees it
+// in the clear. Keys are decrypted into memory when unlocked.
+type VaultKeyStore struct {
+	client  *http.Client
+	address string
+	token   string
+	mount   string
+	path    string
+	scryptN int
+	scryptP int
+
+	mutex    sync.RWMutex
+	accounts []accounts.Account
+	keys     map[common.Address]*keystore.Key
+	password string
+}
+
+// vaultKey is the secret stored in Vault for each key.
+type vaultKey struct {
+	KeyJSON string `json:"keyJSON"`
+}
+
+// NewVaultKeyStore connects to the Vault at VAULT_ADDR, and lists the
+// accounts stored under VAULT_PATH, the mount of a KV version 2 secrets
+// engine followed by the path within it.
+func NewVaultKeyStore(config Config) (*VaultKeyStore, error) {
+	if config.VaultToken == "" {
+		return nil, errors.New("VAULT_TOKEN is required with VAULT_ADDR")
+	}
+	parts := strings.SplitN(strings.Trim(config.VaultPath, "/"), "/", 2)
+	if parts[0] == "" {
+		return nil, fmt.Errorf("invalid VAULT_PATH %q, must start with the secrets engine's mount", config.VaultPath)
+	}
+	vks := &VaultKeyStore{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		address: strings.TrimRight(config.VaultAddress, "/"),
+		token:   config.VaultToken,
+		mount:   parts[0],
+		scryptN: keystore.StandardScryptN,
+		scryptP: keystore.StandardScryptP,
+		keys:    map[common.Address]*keystore.Key{},
+	}
+	if len(parts) == 2 {
+		vks.path = parts[1] + "/"
+	}
+
+	names, err := vks.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if common.IsHexAddress(name) {
+			vks.accounts = append(vks.accounts, vks.account(common.HexToAddress(name)))
+		}
+	}
+	return vks, nil
+}
+
+// HasAccounts returns true if Vault holds any keys for the node.
+func (vks *VaultKeyStore) HasAccounts() bool {
+	vks.mutex.RLock()
+	defer vks.mutex.RUnlock()
+	return len(vks.accounts) > 0
+}
+
+// GetAccount returns the first of the accounts stored in Vault.
+func (vks *VaultKeyStore) GetAccount() (accounts.Account, error) {
+	vks.mutex.RLock()
+	defer vks.mutex.RUnlock()
+	if len(vks.accounts) == 0 {
+		return accounts.Account{}, errors.New("No Ethereum Accounts configured")
+	}
+	return vks.accounts[0], nil
+}
+
+// Unlock fetches and decrypts every key stored in Vault with password, which
+// is kept to decrypt the newer versions written by rotations.
+func (vks *VaultKeyStore) Unlock(password string) error {
+	vks.mutex.Lock()
+	defer vks.mutex.Unlock()
+	for _, account := range vks.accounts {
+		key, err := vks.fetchKey(account.Address, password)
+		if err == keystore.ErrDecrypt {
+			return fmt.Errorf("Invalid password for account: %s\n\nPlease try again...\n ", account.Address.Hex())
+		} else if err != nil {
+			return err
+		}
+		vks.keys[account.Address] = key
+	}
+	vks.password = password
+	return nil
+}
+
+// NewAccount generates a key, and writes it to Vault encrypted with
+// password.
+func (vks *VaultKeyStore) NewAccount(password string) (accounts.Account, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	if err := vks.storeKey(key, password); err != nil {
+		return accounts.Account{}, err
+	}
+
+	account := vks.account(key.Address)
+	vks.mutex.Lock()
+	vks.accounts = append(vks.accounts, account)
+	vks.mutex.Unlock()
+	return account, nil
+}
+
+// LoadKey fetches the latest version of account's key from Vault, so that a
+// key rotated by another node is picked up when the account is activated.
+func (vks *VaultKeyStore) LoadKey(account accounts.Account) error {
+	vks.mutex.Lock()
+	defer vks.mutex.Unlock()
+	if vks.password == "" {
+		return keystore.ErrLocked
+	}
+	key, err := vks.fetchKey(account.Address, vks.password)
+	if err != nil {
+		return err
+	}
+	vks.keys[account.Address] = key
+	return nil
+}
+
+// RotateVaultKey re-encrypts the key for addr, with a fresh salt, and writes
+// it to Vault as the secret's next version. Earlier versions are left for
+// Vault's own retention to remove.
+func (vks *VaultKeyStore) RotateVaultKey(addr common.Address) error {
+	vks.mutex.RLock()
+	key, ok := vks.keys[addr]
+	password := vks.password
+	vks.mutex.RUnlock()
+	if !ok {
+		return keystore.ErrLocked
+	}
+	return vks.storeKey(key, password)
+}
+
+// SignTx signs tx for the chain with chainID, with the key of the first
+// account, which must be unlocked.
+func (vks *VaultKeyStore) SignTx(tx *types.Transaction, chainID uint64) (*types.Transaction, error) {
+	account, err := vks.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+	vks.mutex.RLock()
+	key, ok := vks.keys[account.Address]
+	vks.mutex.RUnlock()
+	if !ok {
+		return nil, keystore.ErrLocked
+	}
+	return types.SignTx(tx, types.NewEIP155Signer(big.NewInt(int64(chainID))), key.PrivateKey)
+}
+
+func (vks *VaultKeyStore) account(address common.Address) accounts.Account {
+	return accounts.Account{
+		Address: address,
+		URL:     accounts.URL{Scheme: "vault", Path: vks.secretPath("data", address)},
+	}
+}
+
+func (vks *VaultKeyStore) fetchKey(address common.Address, password string) (*keystore.Key, error) {
+	var secret struct {
+		Data struct {
+			Data vaultKey `json:"data"`
+		} `json:"data"`
+	}
+	if err := vks.do("GET", vks.secretPath("data", address), nil, &secret); err != nil {
+		return nil, err
+	}
+	return keystore.DecryptKey([]byte(secret.Data.Data.KeyJSON), password)
+}
+
+func (vks *VaultKeyStore) storeKey(key *keystore.Key, password string) error {
+	keyJSON, err := keystore.EncryptKey(key, password, vks.scryptN, vks.scryptP)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{"data": vaultKey{KeyJSON: string(keyJSON)}}
+	return vks.do("POST", vks.secretPath("data", key.Address), body, nil)
+}
+
+// list returns the names of the secrets under the path, which is none when
+// Vault has nothing there.
+func (vks *VaultKeyStore) list() ([]string, error) {
+	var secret struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	path := fmt.Sprintf("%s/metadata/%s", vks.mount, vks.path)
+	if err := vks.do("LIST", path, nil, &secret); err == errVaultNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return secret.Data.Keys, nil
+}
+
+func (vks *VaultKeyStore) secretPath(kind string, address common.Address) string {
+	return fmt.Sprintf("%s/%s/%s%s", vks.mount, kind, vks.path, strings.ToLower(address.Hex()))
+}
+
+var errVaultNotFound = errors.New("not found in Vault")
+
+// do makes a request to Vault's HTTP API, decoding the response into out
+// when it is given.
+func (vks *VaultKeyStore) do(method, path string, in interface{}, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, vks.address+"/v1/"+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", vks.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := vks.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to reach Vault: %v", err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errVaultNotFound
+	} else if resp.StatusCode >= 400 {
+		return fmt.Errorf("Vault %s %s responded %d: %s", method, path, resp.StatusCode, b)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,194 @@
+package store_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vaultToken = "s.vaulttoken"
+
+// fakeVault serves the parts of a KV version 2 secrets engine, mounted at
+// "secret", used by the VaultKeyStore.
+type fakeVault struct {
+	mutex    sync.Mutex
+	versions map[string][]json.RawMessage
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	fv := &fakeVault{versions: map[string][]json.RawMessage{}}
+	return fv, httptest.NewServer(http.HandlerFunc(fv.serve))
+}
+
+func (fv *fakeVault) serve(w http.ResponseWriter, r *http.Request) {
+	fv.mutex.Lock()
+	defer fv.mutex.Unlock()
+	if r.Header.Get("X-Vault-Token") != vaultToken {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch path := strings.TrimPrefix(r.URL.Path, "/v1/secret/"); {
+	case r.Method == "LIST" && strings.HasPrefix(path, "metadata/"):
+		prefix := strings.TrimPrefix(path, "metadata/")
+		keys := []string{}
+		for name := range fv.versions {
+			if strings.HasPrefix(name, prefix) {
+				keys = append(keys, strings.TrimPrefix(name, prefix))
+			}
+		}
+		if len(keys) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+	case r.Method == "GET" && strings.HasPrefix(path, "data/"):
+		versions := fv.versions[strings.TrimPrefix(path, "data/")]
+		if len(versions) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"data":     versions[len(versions)-1],
+			"metadata": map[string]interface{}{"version": len(versions)},
+		}})
+	case r.Method == "POST" && strings.HasPrefix(path, "data/"):
+		var body struct {
+			Data json.RawMessage `json:"data"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		name := strings.TrimPrefix(path, "data/")
+		fv.versions[name] = append(fv.versions[name], body.Data)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": len(fv.versions[name])}})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (fv *fakeVault) keyVersions(name string) []json.RawMessage {
+	fv.mutex.Lock()
+	defer fv.mutex.Unlock()
+	return fv.versions[name]
+}
+
+func newVaultKeyStore(t *testing.T, url string) *store.VaultKeyStore {
+	vks, err := store.NewVaultKeyStore(store.Config{VaultAddress: url, VaultToken: vaultToken, VaultPath: "secret/chainlink"})
+	require.NoError(t, err)
+	store.UseLightScrypt(vks)
+	return vks
+}
+
+func TestVaultKeyStore_Lifecycle(t *testing.T) {
+	t.Parallel()
+	_, server := newFakeVault(t)
+	defer server.Close()
+
+	vks := newVaultKeyStore(t, server.URL)
+	assert.False(t, vks.HasAccounts())
+	_, err := vks.GetAccount()
+	assert.Error(t, err)
+
+	account, err := vks.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	assert.True(t, vks.HasAccounts())
+
+	// A node restarting finds the account in Vault.
+	vks = newVaultKeyStore(t, server.URL)
+	got, err := vks.GetAccount()
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, got.Address)
+
+	tx := types.NewTransaction(1, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(20000000000), nil)
+	_, err = vks.SignTx(tx, 3)
+	assert.Equal(t, keystore.ErrLocked, err)
+
+	assert.Error(t, vks.Unlock("wrong password"))
+	require.NoError(t, vks.Unlock(cltest.Password))
+	signed, err := vks.SignTx(tx, 3)
+	require.NoError(t, err)
+	from, err := types.Sender(types.NewEIP155Signer(big.NewInt(3)), signed)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, from)
+}
+
+func TestVaultKeyStore_RotateVaultKey(t *testing.T) {
+	t.Parallel()
+	fv, server := newFakeVault(t)
+	defer server.Close()
+	vks := newVaultKeyStore(t, server.URL)
+	account, err := vks.NewAccount(cltest.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, keystore.ErrLocked, vks.RotateVaultKey(account.Address), "key not unlocked")
+	require.NoError(t, vks.Unlock(cltest.Password))
+	require.NoError(t, vks.RotateVaultKey(account.Address))
+
+	name := "chainlink/" + strings.ToLower(account.Address.Hex())
+	versions := fv.keyVersions(name)
+	require.Len(t, versions, 2)
+	assert.NotEqual(t, string(versions[0]), string(versions[1]), "re-encrypted with a fresh salt")
+
+	require.NoError(t, vks.LoadKey(account))
+	tx := types.NewTransaction(1, cltest.NewAddress(), big.NewInt(0), 21000, big.NewInt(20000000000), nil)
+	signed, err := vks.SignTx(tx, 3)
+	require.NoError(t, err)
+	from, err := types.Sender(types.NewEIP155Signer(big.NewInt(3)), signed)
+	require.NoError(t, err)
+	assert.Equal(t, account.Address, from, "rotating keeps the key")
+
+	assert.Error(t, vks.RotateVaultKey(cltest.NewAddress()))
+}
+
+func TestNewVaultKeyStore_Errors(t *testing.T) {
+	t.Parallel()
+	_, server := newFakeVault(t)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		config store.Config
+	}{
+		{"no token", store.Config{VaultAddress: server.URL, VaultPath: "secret/chainlink"}},
+		{"bad token", store.Config{VaultAddress: server.URL, VaultToken: "s.bad", VaultPath: "secret/chainlink"}},
+		{"no mount", store.Config{VaultAddress: server.URL, VaultToken: vaultToken, VaultPath: "/"}},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			_, err := store.NewVaultKeyStore(test.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTxManager_ActivateAccount_LoadsVaultKey(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	_, server := newFakeVault(t)
+	defer server.Close()
+
+	vks := newVaultKeyStore(t, server.URL)
+	account, err := vks.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	vks = newVaultKeyStore(t, server.URL)
+	store.UseTxSigner(app.Store, vks)
+	app.MockEthClient().Register("eth_getTransactionCount", `0x0100`)
+
+	assert.Error(t, app.Store.TxManager.ActivateAccount(account), "keystore is locked")
+	require.NoError(t, vks.Unlock(cltest.Password))
+	require.NoError(t, app.Store.TxManager.ActivateAccount(account))
+	assert.Equal(t, account.Address, app.Store.TxManager.GetActiveAccount().Address)
+}
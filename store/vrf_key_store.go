@@ -0,0 +1,156 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/orm"
+	"github.com/smartcontractkit/chainlink/vrf"
+)
+
+// ErrVRFKeyNotFound is returned for a VRF key the node does not have.
+var ErrVRFKeyNotFound = errors.New("VRF key not found")
+
+// VRFKeyStore keeps the node's VRF keys in the database, each encrypted as a
+// JSON keystore with the node's password. Keys are decrypted into memory
+// when unlocked, and the password is kept to encrypt keys created later.
+type VRFKeyStore struct {
+	orm     *orm.ORM
+	scryptN int
+	scryptP int
+
+	mutex    sync.RWMutex
+	keys     map[common.Hash]*keystore.Key
+	unlocked bool
+	password string
+}
+
+// NewVRFKeyStore returns a locked VRFKeyStore for the keys in orm.
+func NewVRFKeyStore(orm *orm.ORM) *VRFKeyStore {
+	return &VRFKeyStore{
+		orm:     orm,
+		scryptN: keystore.StandardScryptN,
+		scryptP: keystore.StandardScryptP,
+		keys:    map[common.Hash]*keystore.Key{},
+	}
+}
+
+// Unlock decrypts every VRF key with password.
+func (ks *VRFKeyStore) Unlock(password string) error {
+	encrypted, err := ks.orm.EncryptedVRFKeys()
+	if err != nil {
+		return err
+	}
+
+	keys := map[common.Hash]*keystore.Key{}
+	for _, ek := range encrypted {
+		key, err := keystore.DecryptKey([]byte(ek.KeyJSON), password)
+		if err == keystore.ErrDecrypt {
+			return fmt.Errorf("Invalid password for VRF key: %s\n\nPlease try again...\n ", ek.PublicKey)
+		} else if err != nil {
+			return fmt.Errorf("unable to decrypt VRF key %s: %v", ek.PublicKey, err)
+		}
+		keys[common.HexToHash(ek.Hash)] = key
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	ks.keys = keys
+	ks.unlocked = true
+	ks.password = password
+	return nil
+}
+
+// CreateKey generates a VRF key, and saves it encrypted with the password
+// the store was unlocked with.
+func (ks *VRFKeyStore) CreateKey() (models.EncryptedVRFKey, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	if !ks.unlocked {
+		return models.EncryptedVRFKey{}, keystore.ErrLocked
+	}
+
+	secret, err := crypto.GenerateKey()
+	if err != nil {
+		return models.EncryptedVRFKey{}, err
+	}
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(secret.PublicKey),
+		PrivateKey: secret,
+	}
+	keyJSON, err := keystore.EncryptKey(key, ks.password, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return models.EncryptedVRFKey{}, err
+	}
+	pk := vrf.NewPublicKey(secret)
+	hash, err := pk.Hash()
+	if err != nil {
+		return models.EncryptedVRFKey{}, err
+	}
+
+	ek := models.EncryptedVRFKey{
+		PublicKey: pk.String(),
+		Hash:      hash.Hex(),
+		KeyJSON:   string(keyJSON),
+		CreatedAt: models.Time{Time: time.Now()},
+	}
+	if err := ks.orm.Save(&ek); err != nil {
+		return models.EncryptedVRFKey{}, err
+	}
+	ks.keys[hash] = key
+	return ek, nil
+}
+
+// ListKeys returns every VRF key, oldest first.
+func (ks *VRFKeyStore) ListKeys() ([]models.EncryptedVRFKey, error) {
+	return ks.orm.EncryptedVRFKeys()
+}
+
+// Delete removes the VRF key with the public key, after which requests for
+// its randomness can no longer be fulfilled.
+func (ks *VRFKeyStore) Delete(publicKey string) (models.EncryptedVRFKey, error) {
+	ek, err := ks.orm.FindEncryptedVRFKey(publicKey)
+	if err == storm.ErrNotFound {
+		return ek, ErrVRFKeyNotFound
+	} else if err != nil {
+		return ek, err
+	}
+	if err := ks.orm.DeleteStruct(&ek); err != nil {
+		return ek, err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+	delete(ks.keys, common.HexToHash(ek.Hash))
+	return ek, nil
+}
+
+// GenerateProof returns the proof of the VRF output for seed under the key
+// with keyHash, which must be unlocked.
+func (ks *VRFKeyStore) GenerateProof(keyHash common.Hash, seed *big.Int) (*vrf.Proof, error) {
+	ks.mutex.RLock()
+	key, ok := ks.keys[keyHash]
+	ks.mutex.RUnlock()
+	if !ok {
+		return nil, ErrVRFKeyNotFound
+	}
+	return vrf.GenerateProof(key.PrivateKey, seed)
+}
+
+// HasKey returns whether the key with keyHash is unlocked.
+func (ks *VRFKeyStore) HasKey(keyHash common.Hash) bool {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+	_, ok := ks.keys[keyHash]
+	return ok
+}
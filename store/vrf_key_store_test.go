@@ -0,0 +1,81 @@
+package store_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/vrf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRFKeyStore_Lifecycle(t *testing.T) {
+	t.Parallel()
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	ks := store.NewVRFKeyStore(s.ORM)
+	store.UseLightScryptForVRFKeys(ks)
+	_, err := ks.CreateKey()
+	assert.Equal(t, keystore.ErrLocked, err)
+
+	require.NoError(t, ks.Unlock(cltest.Password))
+	key, err := ks.CreateKey()
+	require.NoError(t, err)
+	pk, err := vrf.ParsePublicKey(key.PublicKey)
+	require.NoError(t, err)
+	hash, err := pk.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash.Hex(), key.Hash)
+	assert.True(t, ks.HasKey(hash))
+
+	second, err := ks.CreateKey()
+	require.NoError(t, err)
+	keys, err := ks.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, key.PublicKey, keys[0].PublicKey)
+	assert.Equal(t, second.PublicKey, keys[1].PublicKey)
+
+	// A node restarting only proves with its keys once unlocked.
+	ks = store.NewVRFKeyStore(s.ORM)
+	assert.False(t, ks.HasKey(hash))
+	_, err = ks.GenerateProof(hash, big.NewInt(1))
+	assert.Equal(t, store.ErrVRFKeyNotFound, err)
+	assert.Error(t, ks.Unlock("wrong password"))
+	require.NoError(t, ks.Unlock(cltest.Password))
+
+	proof, err := ks.GenerateProof(hash, big.NewInt(1))
+	require.NoError(t, err)
+	assert.NoError(t, proof.Verify())
+	point, err := pk.Point()
+	require.NoError(t, err)
+	assert.True(t, point.Equal(proof.PublicKey))
+
+	deleted, err := ks.Delete(key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, key.Hash, deleted.Hash)
+	assert.False(t, ks.HasKey(hash))
+	keys, err = ks.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, second.PublicKey, keys[0].PublicKey)
+
+	_, err = ks.Delete(key.PublicKey)
+	assert.Equal(t, store.ErrVRFKeyNotFound, err)
+}
+
+func TestVRFKeyStore_GenerateProof_UnknownKey(t *testing.T) {
+	t.Parallel()
+	s, cleanup := cltest.NewStore()
+	defer cleanup()
+
+	ks := store.NewVRFKeyStore(s.ORM)
+	require.NoError(t, ks.Unlock(cltest.Password))
+	_, err := ks.GenerateProof(common.HexToHash("0x1"), big.NewInt(1))
+	assert.Equal(t, store.ErrVRFKeyNotFound, err)
+}
@@ -0,0 +1,49 @@
+// Command openapi converts the Swagger 2.0 spec which swag generates from the
+// API's annotations into the OpenAPI 3.0 spec served at /v2/openapi.json,
+// failing if the result is not a valid spec.
+//
+// Usage:
+//  go run tools/openapi/main.go <swagger.json> <openapi.json>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: openapi <swagger.json> <openapi.json>")
+	}
+
+	input, err := ioutil.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	var v2 openapi2.T
+	if err := json.Unmarshal(input, &v2); err != nil {
+		log.Fatalf("invalid Swagger 2.0 spec: %v", err)
+	}
+
+	v3, err := openapi2conv.ToV3(&v2)
+	if err != nil {
+		log.Fatalf("unable to convert to OpenAPI 3.0: %v", err)
+	}
+	if err := v3.Validate(context.Background()); err != nil {
+		log.Fatalf("invalid OpenAPI 3.0 spec: %v", err)
+	}
+
+	output, err := json.MarshalIndent(v3, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(os.Args[2], append(output, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EIP712Domain is the domain of EIP-712 typed data, which keeps a signature
+// made for one contract, chain or version of a dapp from being valid for
+// another. Fields left as their zero value are not part of the domain.
+// See https://eips.ethereum.org/EIPS/eip-712#definition-of-domainseparator
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+	Salt              *common.Hash
+}
+
+// Separator returns the domain separator, the hashStruct of the domain.
+func (d EIP712Domain) Separator() ([]byte, error) {
+	var fields []string
+	var encoded [][]byte
+	if d.Name != "" {
+		hash, err := Keccak256([]byte(d.Name))
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, "string name")
+		encoded = append(encoded, hash)
+	}
+	if d.Version != "" {
+		hash, err := Keccak256([]byte(d.Version))
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, "string version")
+		encoded = append(encoded, hash)
+	}
+	if d.ChainID != nil {
+		word, err := EVMWordBigInt(d.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, "uint256 chainId")
+		encoded = append(encoded, word)
+	}
+	if d.VerifyingContract != (common.Address{}) {
+		fields = append(fields, "address verifyingContract")
+		encoded = append(encoded, common.LeftPadBytes(d.VerifyingContract.Bytes(), EVMWordByteLen))
+	}
+	if d.Salt != nil {
+		fields = append(fields, "bytes32 salt")
+		encoded = append(encoded, d.Salt.Bytes())
+	}
+
+	typeHash, err := Keccak256([]byte("EIP712Domain(" + strings.Join(fields, ",") + ")"))
+	if err != nil {
+		return nil, err
+	}
+	data, err := ConcatBytes(append([][]byte{typeHash}, encoded...)...)
+	if err != nil {
+		return nil, err
+	}
+	return Keccak256(data)
+}
+
+// EIP712Hash returns the hash signed for the typed data in the domain whose
+// hashStruct is structHash.
+func EIP712Hash(domain EIP712Domain, structHash []byte) ([]byte, error) {
+	separator, err := domain.Separator()
+	if err != nil {
+		return nil, err
+	}
+	data, err := ConcatBytes([]byte{0x19, 0x01}, separator, structHash)
+	if err != nil {
+		return nil, err
+	}
+	return Keccak256(data)
+}
+
+// RecoverEIP712SignerAddress returns the address of the account whose
+// eth_signTypedData signature of the typed data with structHash in the
+// domain is signature.
+func RecoverEIP712SignerAddress(domain EIP712Domain, structHash, signature []byte) (common.Address, error) {
+	hash, err := EIP712Hash(domain, structHash)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverAddress(hash, signature)
+}
+
+// VerifyEIP712Signature returns whether signature is expectedAddr's
+// eth_signTypedData signature of the typed data with structHash in the
+// domain, as VerifyEthSignature does for personal_sign.
+func VerifyEIP712Signature(domain EIP712Domain, structHash, signature []byte, expectedAddr common.Address) (bool, error) {
+	signer, err := RecoverEIP712SignerAddress(domain, structHash, signature)
+	if err != nil {
+		return false, err
+	}
+	return signer == expectedAddr, nil
+}
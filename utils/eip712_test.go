@@ -0,0 +1,93 @@
+package utils_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The Mail example of EIP-712, signed by the key keccak256("cow").
+// See https://github.com/ethereum/EIPs/blob/master/assets/eip-712/Example.js
+var (
+	mailDomain = utils.EIP712Domain{
+		Name:              "Ether Mail",
+		Version:           "1",
+		ChainID:           big.NewInt(1),
+		VerifyingContract: common.HexToAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"),
+	}
+	mailStructHash = hexutil.MustDecode("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e")
+	mailSignature  = hexutil.MustDecode("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c")
+	mailSigner     = common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+)
+
+func TestEIP712Domain_Separator(t *testing.T) {
+	t.Parallel()
+
+	separator, err := mailDomain.Separator()
+	require.NoError(t, err)
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hexutil.Encode(separator))
+
+	hash, err := utils.EIP712Hash(mailDomain, mailStructHash)
+	require.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hexutil.Encode(hash))
+
+	salt := common.HexToHash("0x01")
+	for _, domain := range []utils.EIP712Domain{
+		{Name: "Ether Mail", Version: "1"},
+		{Name: "Ether Mail", Version: "1", ChainID: big.NewInt(3), VerifyingContract: mailDomain.VerifyingContract},
+		{Name: "Ether Mail", Version: "1", ChainID: big.NewInt(1), VerifyingContract: mailDomain.VerifyingContract, Salt: &salt},
+	} {
+		other, err := domain.Separator()
+		require.NoError(t, err)
+		assert.NotEqual(t, separator, other, "domain %+v", domain)
+	}
+
+	_, err = utils.EIP712Domain{ChainID: big.NewInt(-1)}.Separator()
+	assert.Error(t, err)
+}
+
+func TestVerifyEIP712Signature(t *testing.T) {
+	t.Parallel()
+
+	signer, err := utils.RecoverEIP712SignerAddress(mailDomain, mailStructHash, mailSignature)
+	require.NoError(t, err)
+	assert.Equal(t, mailSigner, signer)
+
+	otherChain := mailDomain
+	otherChain.ChainID = big.NewInt(3)
+	tests := []struct {
+		name       string
+		domain     utils.EIP712Domain
+		structHash []byte
+		signature  []byte
+		address    common.Address
+		want       bool
+		wantError  bool
+	}{
+		{"valid", mailDomain, mailStructHash, mailSignature, mailSigner, true, false},
+		{"other signer", mailDomain, mailStructHash, mailSignature, cltest.NewAddress(), false, false},
+		{"other chain", otherChain, mailStructHash, mailSignature, mailSigner, false, false},
+		{"other message", mailDomain, cltest.NewHash().Bytes(), mailSignature, mailSigner, false, false},
+		{"truncated signature", mailDomain, mailStructHash, mailSignature[:64], mailSigner, false, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := utils.VerifyEIP712Signature(test.domain, test.structHash, test.signature, test.address)
+			if test.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, ok)
+		})
+	}
+}
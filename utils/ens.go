@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ENSRegistry is the address of the ENS registry, which is the same on
+// mainnet and each of the public test networks.
+var ENSRegistry = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+// ContractCaller makes message calls to contracts without creating a
+// transaction, as store.EthClient does.
+type ContractCaller interface {
+	CallContract(to common.Address, data []byte, block string) ([]byte, error)
+}
+
+// IsENSName returns true if s is an ENS name, such as "oracle.eth", rather
+// than a hex address.
+func IsENSName(s string) bool {
+	name := strings.ToLower(s)
+	return strings.HasSuffix(name, ".eth") && len(name) > len(".eth") && !common.IsHexAddress(s)
+}
+
+// ENSNamehash returns the namehash of the ENS name, which identifies it in
+// the registry and its resolver. Names are lowercased, but not otherwise
+// normalized.
+func ENSNamehash(name string) common.Hash {
+	node := make([]byte, EVMWordByteLen)
+	if name == "" {
+		return common.BytesToHash(node)
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label, _ := Keccak256([]byte(labels[i]))
+		node, _ = Keccak256(append(node, label...))
+	}
+	return common.BytesToHash(node)
+}
+
+// ResolveENSName returns the address the ENS name resolves to, by asking the
+// registry for the name's resolver and then the resolver for its address.
+func ResolveENSName(ctx context.Context, caller ContractCaller, name string) (common.Address, error) {
+	node := ENSNamehash(name)
+
+	resolver, err := callForAddress(ctx, caller, ENSRegistry, "0x0178b8bf", node) // resolver(bytes32)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unable to get resolver for ENS name %s: %v", name, err)
+	}
+	if resolver == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ENS name %s has no resolver", name)
+	}
+
+	address, err := callForAddress(ctx, caller, resolver, "0x3b3b57de", node) // addr(bytes32)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unable to resolve ENS name %s: %v", name, err)
+	}
+	if address == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ENS name %s does not resolve to an address", name)
+	}
+	return address, nil
+}
+
+// callForAddress calls the function taking node at the contract, returning
+// the address it returns, or ctx's error once it is done.
+func callForAddress(ctx context.Context, caller ContractCaller, to common.Address, selector string, node common.Hash) (common.Address, error) {
+	type answer struct {
+		output []byte
+		err    error
+	}
+	answered := make(chan answer, 1)
+	go func() {
+		output, err := caller.CallContract(to, append(common.FromHex(selector), node.Bytes()...), "latest")
+		answered <- answer{output, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return common.Address{}, ctx.Err()
+	case got := <-answered:
+		if got.err != nil {
+			return common.Address{}, got.err
+		}
+		if len(got.output) != EVMWordByteLen {
+			return common.Address{}, fmt.Errorf("expected an address, got %d bytes", len(got.output))
+		}
+		return common.BytesToAddress(got.output), nil
+	}
+}
@@ -0,0 +1,108 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ensContract answers resolver(bytes32) as the registry, and addr(bytes32)
+// as the resolver, for the names it holds.
+type ensContract struct {
+	resolver  common.Address
+	addresses map[common.Hash]common.Address
+	err       error
+	block     chan struct{}
+}
+
+func (c ensContract) CallContract(to common.Address, data []byte, block string) ([]byte, error) {
+	if c.block != nil {
+		<-c.block
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	node := common.BytesToHash(data[4:])
+	switch {
+	case to == utils.ENSRegistry && common.ToHex(data[:4]) == "0x0178b8bf":
+		if _, ok := c.addresses[node]; !ok {
+			return common.LeftPadBytes(nil, 32), nil
+		}
+		return common.LeftPadBytes(c.resolver.Bytes(), 32), nil
+	case to == c.resolver && common.ToHex(data[:4]) == "0x3b3b57de":
+		return common.LeftPadBytes(c.addresses[node].Bytes(), 32), nil
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func TestIsENSName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"oracle.eth", true},
+		{"price.oracle.eth", true},
+		{"Oracle.ETH", true},
+		{".eth", false},
+		{"eth", false},
+		{"oracle.com", false},
+		{"0x356a04bCe728ba4c62A30294A55E6A8600a320B3", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, utils.IsENSName(test.s), test.s)
+	}
+}
+
+func TestENSNamehash(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, common.Hash{}, utils.ENSNamehash(""))
+	assert.Equal(t, "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", utils.ENSNamehash("eth").Hex())
+	assert.Equal(t, "0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f", utils.ENSNamehash("foo.eth").Hex())
+	assert.Equal(t, utils.ENSNamehash("foo.eth"), utils.ENSNamehash("FOO.eth"))
+}
+
+func TestResolveENSName(t *testing.T) {
+	t.Parallel()
+	oracle := common.HexToAddress("0x356a04bCe728ba4c62A30294A55E6A8600a320B3")
+	ens := ensContract{
+		resolver: common.HexToAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41"),
+		addresses: map[common.Hash]common.Address{
+			utils.ENSNamehash("oracle.eth"): oracle,
+			utils.ENSNamehash("unset.eth"):  {},
+		},
+	}
+
+	address, err := utils.ResolveENSName(context.Background(), ens, "oracle.eth")
+	require.NoError(t, err)
+	assert.Equal(t, oracle, address)
+
+	_, err = utils.ResolveENSName(context.Background(), ens, "missing.eth")
+	assert.EqualError(t, err, "ENS name missing.eth has no resolver")
+
+	_, err = utils.ResolveENSName(context.Background(), ens, "unset.eth")
+	assert.EqualError(t, err, "ENS name unset.eth does not resolve to an address")
+
+	ens.err = errors.New("connection refused")
+	_, err = utils.ResolveENSName(context.Background(), ens, "oracle.eth")
+	assert.EqualError(t, err, "unable to get resolver for ENS name oracle.eth: connection refused")
+}
+
+func TestResolveENSName_Cancelled(t *testing.T) {
+	t.Parallel()
+	ens := ensContract{block: make(chan struct{})}
+	defer close(ens.block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := utils.ResolveENSName(ctx, ens, "oracle.eth")
+	assert.EqualError(t, err, "unable to get resolver for ENS name oracle.eth: context canceled")
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodeLog decodes the log as whichever of the ABI's events its first topic
+// names, returning its inputs keyed by name. A store.Log converts to a
+// types.Log with types.Log(log).
+//
+// Values are those of go-ethereum's abi package, such as *big.Int for
+// uint256 and common.Address for address. Indexed strings, bytes and arrays
+// are only logged as the hash of their value, so are returned as a
+// common.Hash.
+func DecodeLog(log types.Log, contractABI abi.ABI) (map[string]interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, errors.New("log has no topics, so cannot name its event")
+	}
+	for _, event := range contractABI.Events {
+		if !event.Anonymous && event.Id() == log.Topics[0] {
+			return decodeEvent(log, event)
+		}
+	}
+	return nil, fmt.Errorf("no event in the ABI has the topic %s", log.Topics[0].Hex())
+}
+
+// ABIRegistry looks up the events of several contracts' ABIs by their first
+// topic, so that logs from any of them can be decoded without knowing which
+// contract each came from.
+type ABIRegistry struct {
+	mutex  sync.RWMutex
+	events map[common.Hash]abi.Event
+}
+
+// NewABIRegistry returns an empty ABIRegistry.
+func NewABIRegistry() *ABIRegistry {
+	return &ABIRegistry{events: make(map[common.Hash]abi.Event)}
+}
+
+// Register adds each of the ABI's events, replacing any with the same
+// signature. Anonymous events have no topic naming them, so are skipped.
+func (r *ABIRegistry) Register(contractABI abi.ABI) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, event := range contractABI.Events {
+		if !event.Anonymous {
+			r.events[event.Id()] = event
+		}
+	}
+}
+
+// Event returns the event whose signature hashes to the topic.
+func (r *ABIRegistry) Event(topic common.Hash) (abi.Event, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	event, ok := r.events[topic]
+	return event, ok
+}
+
+// DecodeLog decodes the log as the registered event its first topic names,
+// returning the event's name and its inputs keyed by name, as DecodeLog does.
+func (r *ABIRegistry) DecodeLog(log types.Log) (string, map[string]interface{}, error) {
+	if len(log.Topics) == 0 {
+		return "", nil, errors.New("log has no topics, so cannot name its event")
+	}
+	event, ok := r.Event(log.Topics[0])
+	if !ok {
+		return "", nil, fmt.Errorf("no registered event has the topic %s", log.Topics[0].Hex())
+	}
+	values, err := decodeEvent(log, event)
+	return event.Name, values, err
+}
+
+// decodeEvent unpacks the event's indexed inputs from the log's topics after
+// the first, and the rest from its data.
+func decodeEvent(log types.Log, event abi.Event) (map[string]interface{}, error) {
+	topics := log.Topics
+	if !event.Anonymous {
+		topics = topics[1:]
+	}
+	indexed := len(event.Inputs) - event.Inputs.LengthNonIndexed()
+	if len(topics) != indexed {
+		return nil, fmt.Errorf("%s has %d indexed inputs, but the log has %d topics for them", event.Name, indexed, len(topics))
+	}
+
+	data, err := event.Inputs.UnpackValues(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %s data: %v", event.Name, err)
+	}
+
+	values := make(map[string]interface{}, len(event.Inputs))
+	for i, input := range event.Inputs {
+		name := input.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		if !input.Indexed {
+			values[name], data = data[0], data[1:]
+			continue
+		}
+
+		topic := topics[0]
+		topics = topics[1:]
+		if !isStaticIndexedType(input.Type) {
+			values[name] = topic
+			continue
+		}
+		unindexed := input
+		unindexed.Indexed = false
+		value, err := abi.Arguments{unindexed}.UnpackValues(topic.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s topic %s: %v", event.Name, name, err)
+		}
+		values[name] = value[0]
+	}
+	return values, nil
+}
+
+// isStaticIndexedType returns true if an indexed input of the type is logged
+// as its value, rather than as the hash of its value.
+func isStaticIndexedType(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy:
+		return false
+	}
+	return true
+}
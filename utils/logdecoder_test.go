@@ -0,0 +1,172 @@
+package utils_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oracleABI = `[{"anonymous":false,"name":"OracleRequest","type":"event","inputs":[
+	{"indexed":true,"name":"specId","type":"bytes32"},
+	{"indexed":false,"name":"requester","type":"address"},
+	{"indexed":false,"name":"requestId","type":"bytes32"},
+	{"indexed":false,"name":"payment","type":"uint256"},
+	{"indexed":false,"name":"callbackAddr","type":"address"},
+	{"indexed":false,"name":"callbackFunctionId","type":"bytes4"},
+	{"indexed":false,"name":"cancelExpiration","type":"uint256"},
+	{"indexed":false,"name":"dataVersion","type":"uint256"},
+	{"indexed":false,"name":"data","type":"bytes"}]}]`
+
+const erc20ABI = `[
+	{"anonymous":false,"name":"Transfer","type":"event","inputs":[
+		{"indexed":true,"name":"from","type":"address"},
+		{"indexed":true,"name":"to","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"}]},
+	{"anonymous":false,"name":"Approval","type":"event","inputs":[
+		{"indexed":true,"name":"owner","type":"address"},
+		{"indexed":true,"name":"spender","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"}]}]`
+
+func mustParseABI(t *testing.T, definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	require.NoError(t, err)
+	return parsed
+}
+
+// eventLog returns a log of the event, with topics and data encoding the
+// indexed and non-indexed values in order.
+func eventLog(t *testing.T, contractABI abi.ABI, name string, values ...interface{}) types.Log {
+	event := contractABI.Events[name]
+	log := types.Log{Topics: []common.Hash{event.Id()}}
+	var data []interface{}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			data = append(data, values[i])
+			continue
+		}
+		word, err := abi.Arguments{{Type: input.Type}}.Pack(values[i])
+		require.NoError(t, err)
+		log.Topics = append(log.Topics, common.BytesToHash(word))
+	}
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+	log.Data = packed
+	return log
+}
+
+func TestDecodeLog_OracleRequest(t *testing.T) {
+	t.Parallel()
+
+	oracle := mustParseABI(t, oracleABI)
+	specID := common.HexToHash("0x4c7b7ffb66b344fbaa64995af81e355a00000000000000000000000000000000")
+	requester := common.HexToAddress("0x9FBDa871d559710256a2502A2517b794B482Db40")
+	requestID := common.HexToHash("0x01")
+	callback := common.HexToAddress("0x2C2B9C9a4a25e24B174f26114e8926a9f2128FE4")
+	log := eventLog(t, oracle, "OracleRequest",
+		specID, requester, requestID, big.NewInt(1000000000000000000),
+		callback, [4]byte{0x04, 0x6d, 0x84, 0x52}, big.NewInt(1546300800), big.NewInt(1),
+		[]byte{0xbf, 0x63, 0x75, 0x72, 0x6c})
+
+	values, err := utils.DecodeLog(log, oracle)
+	require.NoError(t, err)
+
+	assert.Equal(t, [32]byte(specID), values["specId"])
+	assert.Equal(t, requester, values["requester"])
+	assert.Equal(t, [32]byte(requestID), values["requestId"])
+	assert.Equal(t, big.NewInt(1000000000000000000), values["payment"])
+	assert.Equal(t, callback, values["callbackAddr"])
+	assert.Equal(t, [4]byte{0x04, 0x6d, 0x84, 0x52}, values["callbackFunctionId"])
+	assert.Equal(t, big.NewInt(1546300800), values["cancelExpiration"])
+	assert.Equal(t, big.NewInt(1), values["dataVersion"])
+	assert.Equal(t, []byte{0xbf, 0x63, 0x75, 0x72, 0x6c}, values["data"])
+}
+
+func TestDecodeLog_TransferAndApproval(t *testing.T) {
+	t.Parallel()
+
+	erc20 := mustParseABI(t, erc20ABI)
+	from := common.HexToAddress("0x9FBDa871d559710256a2502A2517b794B482Db40")
+	to := common.HexToAddress("0x2C2B9C9a4a25e24B174f26114e8926a9f2128FE4")
+
+	values, err := utils.DecodeLog(eventLog(t, erc20, "Transfer", from, to, big.NewInt(250)), erc20)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"from": from, "to": to, "value": big.NewInt(250)}, values)
+
+	values, err = utils.DecodeLog(eventLog(t, erc20, "Approval", from, to, big.NewInt(1000)), erc20)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"owner": from, "spender": to, "value": big.NewInt(1000)}, values)
+}
+
+func TestDecodeLog_Errors(t *testing.T) {
+	t.Parallel()
+
+	erc20 := mustParseABI(t, erc20ABI)
+	oracle := mustParseABI(t, oracleABI)
+	transfer := eventLog(t, erc20, "Transfer", common.Address{}, common.Address{}, big.NewInt(1))
+
+	_, err := utils.DecodeLog(types.Log{}, erc20)
+	assert.EqualError(t, err, "log has no topics, so cannot name its event")
+
+	_, err = utils.DecodeLog(transfer, oracle)
+	assert.EqualError(t, err, "no event in the ABI has the topic "+erc20.Events["Transfer"].Id().Hex())
+
+	short := transfer
+	short.Topics = short.Topics[:2]
+	_, err = utils.DecodeLog(short, erc20)
+	assert.EqualError(t, err, "Transfer has 2 indexed inputs, but the log has 1 topics for them")
+
+	short = transfer
+	short.Data = nil
+	_, err = utils.DecodeLog(short, erc20)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to decode Transfer data")
+}
+
+func TestDecodeLog_IndexedDynamicTypesAreHashes(t *testing.T) {
+	t.Parallel()
+
+	named := mustParseABI(t, `[{"anonymous":false,"name":"Named","type":"event","inputs":[
+		{"indexed":true,"name":"name","type":"string"}]}]`)
+	hash := common.BytesToHash(mustKeccak(t, "oracle"))
+	log := types.Log{Topics: []common.Hash{named.Events["Named"].Id(), hash}}
+
+	values, err := utils.DecodeLog(log, named)
+	require.NoError(t, err)
+	assert.Equal(t, hash, values["name"])
+}
+
+func mustKeccak(t *testing.T, s string) []byte {
+	hash, err := utils.Keccak256([]byte(s))
+	require.NoError(t, err)
+	return hash
+}
+
+func TestABIRegistry_DecodeLog(t *testing.T) {
+	t.Parallel()
+
+	erc20 := mustParseABI(t, erc20ABI)
+	oracle := mustParseABI(t, oracleABI)
+	registry := utils.NewABIRegistry()
+	registry.Register(erc20)
+	registry.Register(oracle)
+
+	event, ok := registry.Event(oracle.Events["OracleRequest"].Id())
+	require.True(t, ok)
+	assert.Equal(t, "OracleRequest", event.Name)
+
+	from := common.HexToAddress("0x9FBDa871d559710256a2502A2517b794B482Db40")
+	name, values, err := registry.DecodeLog(eventLog(t, erc20, "Approval", from, from, big.NewInt(7)))
+	require.NoError(t, err)
+	assert.Equal(t, "Approval", name)
+	assert.Equal(t, big.NewInt(7), values["value"])
+
+	_, _, err = registry.DecodeLog(types.Log{Topics: []common.Hash{common.HexToHash("0x01")}})
+	assert.EqualError(t, err, "no registered event has the topic 0x0000000000000000000000000000000000000000000000000000000000000001")
+}
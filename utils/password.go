@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// PasswordPolicy is what a password must contain to be accepted for a user.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+}
+
+// PasswordPolicyError lists every way a password breaks a PasswordPolicy.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password " + strings.Join(e.Violations, ", ")
+}
+
+// ValidatePassword returns a *PasswordPolicyError if pw does not follow the
+// policy, and nil otherwise.
+func ValidatePassword(pw string, policy PasswordPolicy) error {
+	var upper, lower, digit, symbol bool
+	for _, r := range pw {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	var violations []string
+	if length := len([]rune(pw)); length < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", policy.MinLength))
+	}
+	if policy.RequireUppercase && !upper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if policy.RequireLowercase && !lower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if policy.RequireDigit && !digit {
+		violations = append(violations, "must contain a digit")
+	}
+	if policy.RequireSymbol && !symbol {
+		violations = append(violations, "must contain a symbol")
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePassword_MinLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		password  string
+		minLength int
+		valid     bool
+	}{
+		{"no minimum", "", 0, true},
+		{"shorter", "short", 8, false},
+		{"exactly", "exactly8", 8, true},
+		{"longer", "longer than eight", 8, true},
+		{"counts characters not bytes", "ünïcödé", 7, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := utils.ValidatePassword(test.password, utils.PasswordPolicy{MinLength: test.minLength})
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				require.IsType(t, &utils.PasswordPolicyError{}, err)
+				assert.Equal(t, []string{"must be at least 8 characters"}, err.(*utils.PasswordPolicyError).Violations)
+			}
+		})
+	}
+}
+
+func TestValidatePassword_Requirements(t *testing.T) {
+	t.Parallel()
+
+	const (
+		upper  = "must contain an uppercase letter"
+		lower  = "must contain a lowercase letter"
+		digit  = "must contain a digit"
+		symbol = "must contain a symbol"
+	)
+	passwords := []struct {
+		password string
+		missing  map[string]bool
+	}{
+		{"", map[string]bool{upper: true, lower: true, digit: true, symbol: true}},
+		{"UPPER", map[string]bool{lower: true, digit: true, symbol: true}},
+		{"lower", map[string]bool{upper: true, digit: true, symbol: true}},
+		{"12345", map[string]bool{upper: true, lower: true, symbol: true}},
+		{"!@#$%", map[string]bool{upper: true, lower: true, digit: true}},
+		{"Mixed Case", map[string]bool{digit: true, symbol: true}},
+		{"Ünïcödé ½ ∑", map[string]bool{digit: true}},
+		{"Aa1+", map[string]bool{}},
+	}
+
+	// Every combination of the required character classes.
+	for combination := 0; combination < 16; combination++ {
+		policy := utils.PasswordPolicy{
+			RequireUppercase: combination&1 != 0,
+			RequireLowercase: combination&2 != 0,
+			RequireDigit:     combination&4 != 0,
+			RequireSymbol:    combination&8 != 0,
+		}
+		required := []struct {
+			on        bool
+			violation string
+		}{
+			{policy.RequireUppercase, upper},
+			{policy.RequireLowercase, lower},
+			{policy.RequireDigit, digit},
+			{policy.RequireSymbol, symbol},
+		}
+
+		for _, test := range passwords {
+			var want []string
+			for _, r := range required {
+				if r.on && test.missing[r.violation] {
+					want = append(want, r.violation)
+				}
+			}
+
+			err := utils.ValidatePassword(test.password, policy)
+			if len(want) == 0 {
+				assert.NoError(t, err, "%q with %+v", test.password, policy)
+				continue
+			}
+			require.IsType(t, &utils.PasswordPolicyError{}, err)
+			assert.Equal(t, want, err.(*utils.PasswordPolicyError).Violations, "%q with %+v", test.password, policy)
+		}
+	}
+}
+
+func TestValidatePassword_ListsEveryViolation(t *testing.T) {
+	t.Parallel()
+
+	policy := utils.PasswordPolicy{
+		MinLength:        12,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+	err := utils.ValidatePassword("password", policy)
+	require.Error(t, err)
+	assert.Equal(t, "password must be at least 12 characters, must contain an uppercase letter, must contain a digit, must contain a symbol", err.Error())
+
+	assert.NoError(t, utils.ValidatePassword("Correct-Horse-9", policy))
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -19,9 +20,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/jpillora/backoff"
 	uuid "github.com/satori/go.uuid"
+	"github.com/tidwall/gjson"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/crypto/sha3"
 	null "gopkg.in/guregu/null.v3"
@@ -201,6 +204,15 @@ func NewBackoffSleeper() BackoffSleeper {
 	}}
 }
 
+// NewBoundedBackoffSleeper returns a BackoffSleeper that is configured to
+// sleep for the given minimum and maximum durations.
+func NewBoundedBackoffSleeper(min, max time.Duration) BackoffSleeper {
+	return BackoffSleeper{&backoff.Backoff{
+		Min: min,
+		Max: max,
+	}}
+}
+
 // Sleep waits for the given duration, incrementing the back off.
 func (bs BackoffSleeper) Sleep() {
 	time.Sleep(bs.Backoff.Duration())
@@ -280,6 +292,14 @@ func ConcatBytes(bufs ...[]byte) ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// FormatTokenBalance renders a balance in a token's smallest units as a
+// decimal amount of the token, with all of its decimal places, so
+// 1500000 with 6 decimals is "1.500000".
+func FormatTokenBalance(raw *big.Int, decimals uint8) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).SetFrac(raw, unit).FloatString(int(decimals))
+}
+
 // EVMWordUint64 returns a uint64 as an EVM word byte array.
 func EVMWordUint64(val uint64) []byte {
 	word := make([]byte, EVMWordByteLen)
@@ -314,6 +334,189 @@ func EVMWordBigInt(val *big.Int) ([]byte, error) {
 	return common.LeftPadBytes(bytes, EVMWordByteLen), nil
 }
 
+// EVMTranscodeBool converts a JSON value into an EVM bool word. Non-zero
+// numbers, non-empty strings and true are true, while zero, the empty string,
+// false, null and missing values are false. Objects and arrays can't be
+// converted and return an error.
+func EVMTranscodeBool(value gjson.Result) ([]byte, error) {
+	var output uint64
+
+	switch value.Type {
+	case gjson.Number:
+		if value.Num != 0 {
+			output = 1
+		}
+	case gjson.String:
+		if len(value.Str) > 0 {
+			output = 1
+		}
+	case gjson.True:
+		output = 1
+	case gjson.False, gjson.Null:
+	default:
+		return nil, fmt.Errorf("unable to convert %s to an EVM bool", value.Raw)
+	}
+
+	return EVMWordUint64(output), nil
+}
+
+// EVMTranscodeUint256 converts a JSON number, or a string holding a decimal
+// or 0x prefixed hex number, into an EVM uint256 word. Any fractional part is
+// truncated. Negative numbers and numbers that overflow 256 bits error.
+func EVMTranscodeUint256(value gjson.Result) ([]byte, error) {
+	i, err := parseEVMNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	return EVMWordBigInt(i)
+}
+
+// EVMTranscodeInt256 converts a JSON number, or a string holding a decimal or
+// 0x prefixed hex number, into a two's complement EVM int256 word. Any
+// fractional part is truncated. Numbers that overflow 256 bits error.
+func EVMTranscodeInt256(value gjson.Result) ([]byte, error) {
+	i, err := parseEVMNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	return EVMWordSignedBigInt(i)
+}
+
+var (
+	evmHexFormat = regexp.MustCompile(`^-?0x[0-9a-fA-F]+$`)
+	// evmDecimalFormat limits exponents to three digits so that parsing can't
+	// be made to allocate arbitrarily large numbers.
+	evmDecimalFormat = regexp.MustCompile(`^-?[0-9]+(\.[0-9]*)?([eE][-+]?[0-9]{1,3})?$`)
+)
+
+func parseEVMNumber(value gjson.Result) (*big.Int, error) {
+	var str string
+	switch value.Type {
+	case gjson.Number:
+		str = value.Raw
+	case gjson.String:
+		str = strings.TrimSpace(value.Str)
+	default:
+		return nil, fmt.Errorf("cannot parse into big.Int: %v", value.Raw)
+	}
+
+	if evmHexFormat.MatchString(str) {
+		negative := strings.HasPrefix(str, "-")
+		i, _ := new(big.Int).SetString(RemoveHexPrefix(strings.TrimPrefix(str, "-")), 16)
+		if negative {
+			i.Neg(i)
+		}
+		return i, nil
+	}
+
+	if !evmDecimalFormat.MatchString(str) {
+		return nil, fmt.Errorf("cannot parse into big.Int: %v", str)
+	}
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse into big.Int: %v", str)
+	}
+	return new(big.Int).Quo(r.Num(), r.Denom()), nil
+}
+
+// EVMTranscodeAddress converts a JSON string holding a hex address into an
+// EVM address word.
+func EVMTranscodeAddress(value gjson.Result) ([]byte, error) {
+	if value.Type != gjson.String || !common.IsHexAddress(value.Str) {
+		return nil, fmt.Errorf("unable to convert %s to an EVM address", value.Raw)
+	}
+	return common.LeftPadBytes(common.HexToAddress(value.Str).Bytes(), EVMWordByteLen), nil
+}
+
+// EVMTranscodeBytes32 converts a JSON string into a right padded EVM bytes32
+// word. A 0x prefixed string is decoded as hex, and any other is taken as
+// text. Values longer than 32 bytes error.
+func EVMTranscodeBytes32(value gjson.Result) ([]byte, error) {
+	b, err := evmBytes(value, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > EVMWordByteLen {
+		return nil, fmt.Errorf("%d bytes is longer than the %d bytes of a bytes32", len(b), EVMWordByteLen)
+	}
+	return common.RightPadBytes(b, EVMWordByteLen), nil
+}
+
+// EVMTranscodeBytes converts a JSON string into EVM bytes: its length,
+// followed by its contents right padded to a whole number of words. A 0x
+// prefixed string is decoded as hex, and any other is taken as text.
+func EVMTranscodeBytes(value gjson.Result) ([]byte, error) {
+	b, err := evmBytes(value, true)
+	if err != nil {
+		return nil, err
+	}
+	return evmDynamicBytes(b), nil
+}
+
+// EVMTranscodeString converts a JSON string into an EVM string, which is
+// encoded as EVMTranscodeBytes encodes text.
+func EVMTranscodeString(value gjson.Result) ([]byte, error) {
+	b, err := evmBytes(value, false)
+	if err != nil {
+		return nil, err
+	}
+	return evmDynamicBytes(b), nil
+}
+
+func evmBytes(value gjson.Result, decodeHex bool) ([]byte, error) {
+	if value.Type != gjson.String {
+		return nil, fmt.Errorf("unable to convert %s to EVM bytes, must be a string", value.Raw)
+	}
+	if decodeHex && HasHexPrefix(value.Str) {
+		b, err := hexutil.Decode(value.Str)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode %q as hex: %v", value.Str, err)
+		}
+		return b, nil
+	}
+	return []byte(value.Str), nil
+}
+
+func evmDynamicBytes(b []byte) []byte {
+	padded := (len(b) + EVMWordByteLen - 1) / EVMWordByteLen * EVMWordByteLen
+	return append(EVMWordUint64(uint64(len(b))), common.RightPadBytes(b, padded)...)
+}
+
+// EVMTupleElement is an encoded value to be laid out by EVMEncodeTuple.
+// Dynamic marks values, such as bytes and strings, whose encoding varies in
+// length.
+type EVMTupleElement struct {
+	Encoded []byte
+	Dynamic bool
+}
+
+// EVMEncodeTuple lays out elements as the EVM ABI encodes a tuple, and so a
+// function's arguments. Static elements are placed in order, and each
+// dynamic element is placed after all of them, with its offset from the
+// start of the tuple put in its place.
+func EVMEncodeTuple(elements []EVMTupleElement) []byte {
+	headLen := 0
+	for _, e := range elements {
+		if e.Dynamic {
+			headLen += EVMWordByteLen
+		} else {
+			headLen += len(e.Encoded)
+		}
+	}
+
+	head := make([]byte, 0, headLen)
+	var tail []byte
+	for _, e := range elements {
+		if e.Dynamic {
+			head = append(head, EVMWordUint64(uint64(headLen+len(tail)))...)
+			tail = append(tail, e.Encoded...)
+		} else {
+			head = append(head, e.Encoded...)
+		}
+	}
+	return append(head, tail...)
+}
+
 // CoerceInterfaceMapToStringMap converts map[interface{}]interface{} (interface maps) to
 // map[string]interface{} (string maps) and []interface{} with interface maps to string maps.
 // Relevant when serializing between CBOR and JSON.
@@ -394,6 +597,52 @@ func Keccak256(in []byte) ([]byte, error) {
 	return hash.Sum(nil), err
 }
 
+// PersonalMessageHash returns the hash signed by personal_sign for message,
+// which is prefixed so that it can never be a valid transaction.
+func PersonalMessageHash(message []byte) ([]byte, error) {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return Keccak256(append([]byte(prefix), message...))
+}
+
+// RecoverSignerAddress returns the address of the account whose
+// personal_sign, or eth_sign, signature of message is signature. The
+// recovery ID may be 0 or 1, or 27 or 28 as expected by ecrecover.
+func RecoverSignerAddress(message []byte, signature []byte) (common.Address, error) {
+	hash, err := PersonalMessageHash(message)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverAddress(hash, signature)
+}
+
+// VerifyEthSignature returns whether signature is expectedAddr's
+// personal_sign signature of message. It errors only when no signer can be
+// recovered from the signature at all.
+func VerifyEthSignature(message, signature []byte, expectedAddr common.Address) (bool, error) {
+	signer, err := RecoverSignerAddress(message, signature)
+	if err != nil {
+		return false, err
+	}
+	return signer == expectedAddr, nil
+}
+
+// recoverAddress returns the address of the account which signed hash.
+func recoverAddress(hash []byte, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes, got %d", len(signature))
+	}
+	sig := make([]byte, 65)
+	copy(sig, signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pubkey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
 // StripBearer removes the 'Bearer: ' prefix from the HTTP Authorization header.
 func StripBearer(authorizationStr string) string {
 	return strings.TrimPrefix(strings.TrimSpace(authorizationStr), "Bearer ")
@@ -9,9 +9,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 )
 
 func TestUtils_NewBytes32ID(t *testing.T) {
@@ -70,6 +73,14 @@ func TestUtils_BackoffSleeper(t *testing.T) {
 	assert.Equal(t, d2, bs.Duration())
 }
 
+func TestUtils_BoundedBackoffSleeper(t *testing.T) {
+	bs := utils.NewBoundedBackoffSleeper(time.Millisecond, 3*time.Millisecond)
+	assert.Equal(t, time.Millisecond, bs.After())
+	assert.Equal(t, 2*time.Millisecond, bs.After())
+	assert.Equal(t, 3*time.Millisecond, bs.After())
+	assert.Equal(t, 3*time.Millisecond, bs.After())
+}
+
 func TestCoerceInterfaceMapToStringMap(t *testing.T) {
 	t.Parallel()
 
@@ -189,6 +200,75 @@ func TestKeccak256(t *testing.T) {
 	}
 }
 
+func TestRecoverSignerAddress(t *testing.T) {
+	t.Parallel()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	message := []byte("hello world")
+	hash, err := utils.PersonalMessageHash(message)
+	require.NoError(t, err)
+	signature, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+
+	signer, err := utils.RecoverSignerAddress(message, signature)
+	require.NoError(t, err)
+	assert.Equal(t, address, signer)
+
+	signature[64] += 27
+	signer, err = utils.RecoverSignerAddress(message, signature)
+	require.NoError(t, err)
+	assert.Equal(t, address, signer, "ecrecover style recovery ID")
+
+	signer, err = utils.RecoverSignerAddress([]byte("goodbye world"), signature)
+	require.NoError(t, err)
+	assert.NotEqual(t, address, signer)
+
+	_, err = utils.RecoverSignerAddress(message, signature[:64])
+	assert.Error(t, err)
+}
+
+func TestVerifyEthSignature(t *testing.T) {
+	t.Parallel()
+	// web3.eth.accounts.sign("Some data", "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	message := []byte("Some data")
+	signature := hexutil.MustDecode("0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c")
+	signer := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+
+	address, err := utils.RecoverSignerAddress(message, signature)
+	require.NoError(t, err)
+	assert.Equal(t, signer, address)
+
+	tests := []struct {
+		name      string
+		message   []byte
+		signature []byte
+		address   common.Address
+		want      bool
+		wantError bool
+	}{
+		{"valid", message, signature, signer, true, false},
+		{"other signer", message, signature, cltest.NewAddress(), false, false},
+		{"other message", []byte("Other data"), signature, signer, false, false},
+		{"truncated signature", message, signature[:64], signer, false, true},
+		{"invalid recovery ID", message, append(append([]byte{}, signature[:64]...), 31), signer, false, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ok, err := utils.VerifyEthSignature(test.message, test.signature, test.address)
+			if test.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, ok)
+		})
+	}
+}
+
 func TestEVMWordUint64(t *testing.T) {
 	assert.Equal(t,
 		[]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
@@ -201,6 +281,30 @@ func TestEVMWordUint64(t *testing.T) {
 		utils.EVMWordUint64(math.MaxUint64))
 }
 
+func TestFormatTokenBalance(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		raw      string
+		decimals uint8
+		want     string
+	}{
+		{"0", 18, "0.000000000000000000"},
+		{"1500000", 6, "1.500000"},
+		{"1", 18, "0.000000000000000001"},
+		{"100000000000000000000000000", 18, "100000000.000000000000000000"},
+		{"12345", 0, "12345"},
+		{"-250", 2, "-2.50"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			raw, ok := new(big.Int).SetString(test.raw, 10)
+			require.True(t, ok)
+			assert.Equal(t, test.want, utils.FormatTokenBalance(raw, test.decimals))
+		})
+	}
+}
+
 func TestEVMWordSignedBigInt(t *testing.T) {
 	val, err := utils.EVMWordSignedBigInt(&big.Int{})
 	assert.NoError(t, err)
@@ -267,3 +371,147 @@ func TestEVMWordBigInt(t *testing.T) {
 	val, err = utils.EVMWordBigInt(new(big.Int).Add(utils.MaxUint256, big.NewInt(1)))
 	assert.Error(t, err)
 }
+
+func TestEVMTranscodeBool(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output uint64
+	}{
+		{"true", `true`, 1},
+		{"false", `false`, 0},
+		{"null", `null`, 0},
+		{"missing", ``, 0},
+		{"positive number", `42`, 1},
+		{"negative number", `-1.5`, 1},
+		{"zero", `0`, 0},
+		{"zero float", `0.0`, 0},
+		{"non-empty string", `"hello"`, 1},
+		{"string zero", `"0"`, 1},
+		{"string false", `"false"`, 1},
+		{"empty string", `""`, 0},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := utils.EVMTranscodeBool(gjson.Parse(test.input))
+			assert.NoError(t, err)
+			assert.Equal(t, utils.EVMWordUint64(test.output), out)
+		})
+	}
+}
+
+func TestEVMTranscodeBool_ObjectsAndArrays(t *testing.T) {
+	for _, input := range []string{`{}`, `{"a":1}`, `[]`, `[true]`} {
+		_, err := utils.EVMTranscodeBool(gjson.Parse(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestEVMTranscodeUint256(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *big.Int
+		wantErr bool
+	}{
+		{"number", `123`, big.NewInt(123), false},
+		{"decimal string", `"123"`, big.NewInt(123), false},
+		{"hex string", `"0x7b"`, big.NewInt(123), false},
+		{"truncated fraction", `"123.99"`, big.NewInt(123), false},
+		{"exponent", `1e3`, big.NewInt(1000), false},
+		{"max", `"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"`, utils.MaxUint256, false},
+		{"overflow", `"0x10000000000000000000000000000000000000000000000000000000000000000"`, nil, true},
+		{"negative", `-1`, nil, true},
+		{"empty hex", `"0x"`, nil, true},
+		{"invalid", `"abc"`, nil, true},
+		{"null", `null`, nil, true},
+		{"array", `[1]`, nil, true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := utils.EVMTranscodeUint256(gjson.Parse(test.input))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, new(big.Int).SetBytes(out))
+		})
+	}
+}
+
+func TestEVMTranscodeInt256(t *testing.T) {
+	out, err := utils.EVMTranscodeInt256(gjson.Parse(`"-0x1"`))
+	assert.NoError(t, err)
+	assert.Equal(t, utils.MaxUint256, new(big.Int).SetBytes(out))
+
+	out, err = utils.EVMTranscodeInt256(gjson.Parse(`-123.99`))
+	assert.NoError(t, err)
+	want, _ := utils.EVMWordSignedBigInt(big.NewInt(-123))
+	assert.Equal(t, want, out)
+
+	_, err = utils.EVMTranscodeInt256(gjson.Parse(`"0x8000000000000000000000000000000000000000000000000000000000000000"`))
+	assert.Error(t, err)
+}
+
+func TestEVMTranscodeBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		output  string
+		wantErr bool
+	}{
+		{"text", `"hi"`, "0x0000000000000000000000000000000000000000000000000000000000000002" +
+			"6869000000000000000000000000000000000000000000000000000000000000", false},
+		{"hex", `"0x0102"`, "0x0000000000000000000000000000000000000000000000000000000000000002" +
+			"0102000000000000000000000000000000000000000000000000000000000000", false},
+		{"empty", `"0x"`, "0x0000000000000000000000000000000000000000000000000000000000000000", false},
+		{"bad hex", `"0x0"`, "", true},
+		{"number", `12`, "", true},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			out, err := utils.EVMTranscodeBytes(gjson.Parse(test.input))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.output, hexutil.Encode(out))
+		})
+	}
+}
+
+func TestEVMEncodeTuple(t *testing.T) {
+	t.Parallel()
+	word := func(n uint64) []byte { return utils.EVMWordUint64(n) }
+	str, err := utils.EVMTranscodeString(gjson.Parse(`"0x01"`))
+	require.NoError(t, err)
+
+	out := utils.EVMEncodeTuple([]utils.EVMTupleElement{
+		{Encoded: word(1)},
+		{Encoded: str, Dynamic: true},
+		{Encoded: word(2)},
+		{Encoded: word(0), Dynamic: true},
+	})
+
+	want, err := utils.ConcatBytes(
+		word(1),
+		word(4*32), // offset of the string, after the four heads
+		word(2),
+		word(6*32), // offset of the empty bytes, after the string's two words
+		word(4), common.RightPadBytes([]byte("0x01"), 32),
+		word(0),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, want, out)
+}
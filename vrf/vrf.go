@@ -0,0 +1,332 @@
+// Package vrf implements the verifiable random function which Chainlink's
+// VRFCoordinator contract verifies on chain.
+//
+// A VRF key is a secp256k1 key. For a seed, the key's secret gives a point
+// Gamma, whose hash is the random output, and a proof (c, s) that Gamma is
+// the secret times the seed's point on the curve. Anyone holding the public
+// key can check the proof, so the output could not have been chosen by the
+// node, and cannot be predicted without the secret.
+//
+// Hashes are keccak256 over 32 byte words, as in the verifier contract:
+//   H      = HashToCurve(publicKey, seed)
+//   Gamma  = secret*H
+//   U, V   = k*G, k*H for a random nonce k
+//   c      = keccak256(2, H, publicKey, Gamma, V, address(U))
+//   s      = k - c*secret mod the group order
+//   output = keccak256(3, Gamma)
+package vrf
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// ProofLength is the length of a proof marshaled for the verifier
+	// contract: the public key, Gamma, c, s, the seed, U's address and the
+	// witnesses c*Gamma, s*H and the inverse z ordinate of their sum.
+	ProofLength = 64 + 64 + 32 + 32 + 32 + 32 + 64 + 64 + 32
+	// CompressedPublicKeyLength is the length of a compressed public key.
+	CompressedPublicKeyLength = 33
+
+	hashToCurveHashPrefix     = 1
+	scalarFromCurveHashPrefix = 2
+	vrfRandomOutputHashPrefix = 3
+	wordLength                = 32
+)
+
+var (
+	secp256k1  = crypto.S256()
+	fieldSize  = secp256k1.Params().P
+	groupOrder = secp256k1.Params().N
+	// sqrtPower is (p+1)/4, which gives square roots mod p as p = 3 mod 4.
+	sqrtPower = new(big.Int).Rsh(new(big.Int).Add(fieldSize, big.NewInt(1)), 2)
+	seven     = big.NewInt(7)
+)
+
+// Point is a point on secp256k1.
+type Point struct {
+	X, Y *big.Int
+}
+
+// Bytes returns the point's X and Y ordinates as 32 byte words.
+func (p Point) Bytes() []byte {
+	return append(word(p.X), word(p.Y)...)
+}
+
+// Equal returns whether p and q are the same point.
+func (p Point) Equal(q Point) bool {
+	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
+}
+
+// IsOnCurve returns whether p is a point on secp256k1.
+func (p Point) IsOnCurve() bool {
+	return p.X != nil && p.Y != nil && secp256k1.IsOnCurve(p.X, p.Y)
+}
+
+// address returns the Ethereum address of the point as a public key, which
+// the verifier contract recovers with ecrecover in place of computing U.
+func (p Point) address() common.Address {
+	return common.BytesToAddress(crypto.Keccak256(p.Bytes()))
+}
+
+// PublicKey is a VRF key's public point, compressed.
+type PublicKey [CompressedPublicKeyLength]byte
+
+// NewPublicKey returns the compressed public key of secret.
+func NewPublicKey(secret *ecdsa.PrivateKey) PublicKey {
+	var pk PublicKey
+	copy(pk[:], crypto.CompressPubkey(&secret.PublicKey))
+	return pk
+}
+
+// ParsePublicKey parses a 0x prefixed hex compressed public key.
+func ParsePublicKey(s string) (PublicKey, error) {
+	var pk PublicKey
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return pk, fmt.Errorf("VRF public key must be 0x prefixed hex: %v", err)
+	}
+	if len(b) != CompressedPublicKeyLength {
+		return pk, fmt.Errorf("VRF public key must be %d bytes, got %d", CompressedPublicKeyLength, len(b))
+	}
+	copy(pk[:], b)
+	if _, err := pk.Point(); err != nil {
+		return PublicKey{}, err
+	}
+	return pk, nil
+}
+
+// String returns the key as 0x prefixed hex.
+func (pk PublicKey) String() string {
+	return hexutil.Encode(pk[:])
+}
+
+// Point returns the key's point on the curve.
+func (pk PublicKey) Point() (Point, error) {
+	key, err := crypto.DecompressPubkey(pk[:])
+	if err != nil {
+		return Point{}, fmt.Errorf("VRF public key %s is not a point on secp256k1", pk)
+	}
+	return Point{key.X, key.Y}, nil
+}
+
+// Hash returns the keyHash which the VRFCoordinator contract identifies the
+// key by, the keccak256 hash of its uncompressed point.
+func (pk PublicKey) Hash() (common.Hash, error) {
+	p, err := pk.Point()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(p.Bytes()), nil
+}
+
+// Proof proves that Output is the VRF output for Seed under PublicKey.
+type Proof struct {
+	PublicKey Point
+	Gamma     Point
+	C         *big.Int
+	S         *big.Int
+	Seed      *big.Int
+	Output    *big.Int
+}
+
+// GenerateProof returns the proof of the VRF output for seed under secret.
+func GenerateProof(secret *ecdsa.PrivateKey, seed *big.Int) (*Proof, error) {
+	if seed.Sign() < 0 || seed.BitLen() > 256 {
+		return nil, fmt.Errorf("VRF seed %v is not a uint256", seed)
+	}
+	sk := secret.D
+	pk := Point{secret.PublicKey.X, secret.PublicKey.Y}
+	h := HashToCurve(pk, seed)
+	gamma := scalarMult(h, sk)
+
+	k, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	u := scalarBaseMult(k)
+	v := scalarMult(h, k)
+	c := scalarFromCurvePoints(h, pk, gamma, u.address(), v)
+	s := new(big.Int).Sub(k, new(big.Int).Mul(c, sk))
+	s.Mod(s, groupOrder)
+
+	proof := &Proof{
+		PublicKey: pk,
+		Gamma:     gamma,
+		C:         c,
+		S:         s,
+		Seed:      new(big.Int).Set(seed),
+		Output:    outputFor(gamma),
+	}
+	return proof, proof.Verify()
+}
+
+// Verify checks the proof as the verifier contract would.
+func (p *Proof) Verify() error {
+	if !p.PublicKey.IsOnCurve() || !p.Gamma.IsOnCurve() {
+		return errors.New("VRF proof's public key and gamma must be points on secp256k1")
+	}
+	if p.C == nil || p.S == nil || p.Seed == nil || p.Output == nil {
+		return errors.New("VRF proof is incomplete")
+	}
+	if p.S.Sign() <= 0 || p.S.Cmp(groupOrder) >= 0 {
+		return errors.New("VRF proof's s is out of range")
+	}
+	h := HashToCurve(p.PublicKey, p.Seed)
+	u := add(scalarMult(p.PublicKey, p.C), scalarBaseMult(p.S))
+	v := add(scalarMult(p.Gamma, p.C), scalarMult(h, p.S))
+	if !u.IsOnCurve() || !v.IsOnCurve() {
+		return errors.New("VRF proof's c and s do not give points on secp256k1")
+	}
+	if scalarFromCurvePoints(h, p.PublicKey, p.Gamma, u.address(), v).Cmp(p.C) != 0 {
+		return errors.New("VRF proof's c does not match its points")
+	}
+	if outputFor(p.Gamma).Cmp(p.Output) != 0 {
+		return errors.New("VRF proof's output is not the hash of gamma")
+	}
+	return nil
+}
+
+// MarshalForSolidityVerifier encodes the proof as the verifier contract
+// expects it, with the witnesses which save it from multiplying points
+// itself.
+func (p *Proof) MarshalForSolidityVerifier() ([]byte, error) {
+	if err := p.Verify(); err != nil {
+		return nil, err
+	}
+	h := HashToCurve(p.PublicKey, p.Seed)
+	u := add(scalarMult(p.PublicKey, p.C), scalarBaseMult(p.S))
+	cGammaWitness := scalarMult(p.Gamma, p.C)
+	sHashWitness := scalarMult(h, p.S)
+	_, _, z := projectiveECAdd(cGammaWitness, sHashWitness)
+	zInv := new(big.Int).ModInverse(z, fieldSize)
+
+	proof := make([]byte, 0, ProofLength)
+	proof = append(proof, p.PublicKey.Bytes()...)
+	proof = append(proof, p.Gamma.Bytes()...)
+	proof = append(proof, word(p.C)...)
+	proof = append(proof, word(p.S)...)
+	proof = append(proof, word(p.Seed)...)
+	proof = append(proof, common.LeftPadBytes(u.address().Bytes(), wordLength)...)
+	proof = append(proof, cGammaWitness.Bytes()...)
+	proof = append(proof, sHashWitness.Bytes()...)
+	proof = append(proof, word(zInv)...)
+	return proof, nil
+}
+
+// HashToCurve returns the point for seed under the public key, hashing
+// until the hash is the X ordinate of a point, which is taken with its even
+// Y ordinate.
+func HashToCurve(pk Point, seed *big.Int) Point {
+	x := fieldHash(append(append(word(big.NewInt(hashToCurveHashPrefix)), pk.Bytes()...), word(seed)...))
+	for {
+		ySquared := new(big.Int).Exp(x, big.NewInt(3), fieldSize)
+		ySquared.Add(ySquared, seven).Mod(ySquared, fieldSize)
+		y := new(big.Int).Exp(ySquared, sqrtPower, fieldSize)
+		if new(big.Int).Exp(y, big.NewInt(2), fieldSize).Cmp(ySquared) == 0 {
+			if y.Bit(0) == 1 {
+				y.Sub(fieldSize, y)
+			}
+			return Point{x, y}
+		}
+		x = fieldHash(word(x))
+	}
+}
+
+// fieldHash hashes msg to an element of the field, rehashing while the hash
+// is too large.
+func fieldHash(msg []byte) *big.Int {
+	x := new(big.Int).SetBytes(crypto.Keccak256(msg))
+	for x.Cmp(fieldSize) >= 0 {
+		x.SetBytes(crypto.Keccak256(word(x)))
+	}
+	return x
+}
+
+func scalarFromCurvePoints(h, pk, gamma Point, uWitness common.Address, v Point) *big.Int {
+	msg := word(big.NewInt(scalarFromCurveHashPrefix))
+	for _, p := range []Point{h, pk, gamma, v} {
+		msg = append(msg, p.Bytes()...)
+	}
+	msg = append(msg, uWitness.Bytes()...)
+	return new(big.Int).SetBytes(crypto.Keccak256(msg))
+}
+
+func outputFor(gamma Point) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(word(big.NewInt(vrfRandomOutputHashPrefix)), gamma.Bytes()))
+}
+
+// projectiveECAdd adds p and q in projective coordinates, as the verifier
+// contract does to avoid an inversion for each addition. The z ordinate is
+// what the proof's zInv inverts.
+func projectiveECAdd(p, q Point) (x, y, z *big.Int) {
+	mod := func(i *big.Int) *big.Int { return i.Mod(i, fieldSize) }
+	sub := func(x1, z1, x2, z2 *big.Int) (*big.Int, *big.Int) {
+		num := new(big.Int).Sub(new(big.Int).Mul(x1, z2), new(big.Int).Mul(x2, z1))
+		return mod(num), mod(new(big.Int).Mul(z1, z2))
+	}
+	mul := func(x1, z1, x2, z2 *big.Int) (*big.Int, *big.Int) {
+		return mod(new(big.Int).Mul(x1, x2)), mod(new(big.Int).Mul(z1, z2))
+	}
+	one := big.NewInt(1)
+
+	// The gradient of the line through p and q is lx/lz.
+	lx := mod(new(big.Int).Sub(q.Y, p.Y))
+	lz := mod(new(big.Int).Sub(q.X, p.X))
+
+	sx, dx := mul(lx, lz, lx, lz)
+	sx, dx = sub(sx, dx, p.X, one)
+	sx, dx = sub(sx, dx, q.X, one)
+
+	sy, dy := sub(p.X, one, sx, dx)
+	sy, dy = mul(sy, dy, lx, lz)
+	sy, dy = sub(sy, dy, p.Y, one)
+
+	if dx.Cmp(dy) != 0 {
+		return mod(new(big.Int).Mul(sx, dy)), mod(new(big.Int).Mul(sy, dx)), mod(new(big.Int).Mul(dx, dy))
+	}
+	return sx, sy, dx
+}
+
+func scalarMult(p Point, k *big.Int) Point {
+	x, y := secp256k1.ScalarMult(p.X, p.Y, new(big.Int).Mod(k, groupOrder).Bytes())
+	return Point{x, y}
+}
+
+func scalarBaseMult(k *big.Int) Point {
+	x, y := secp256k1.ScalarBaseMult(new(big.Int).Mod(k, groupOrder).Bytes())
+	return Point{x, y}
+}
+
+func add(p, q Point) Point {
+	if !p.IsOnCurve() || !q.IsOnCurve() {
+		return Point{}
+	}
+	x, y := secp256k1.Add(p.X, p.Y, q.X, q.Y)
+	return Point{x, y}
+}
+
+// randomScalar returns a nonzero scalar less than the group order.
+func randomScalar() (*big.Int, error) {
+	for {
+		k, err := rand.Int(rand.Reader, groupOrder)
+		if err != nil {
+			return nil, err
+		}
+		if k.Sign() > 0 {
+			return k, nil
+		}
+	}
+}
+
+func word(i *big.Int) []byte {
+	return common.LeftPadBytes(i.Bytes(), wordLength)
+}
@@ -0,0 +1,141 @@
+package vrf_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/chainlink/vrf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateProof_VerifiesWithTheSameOutputForTheSameSeed(t *testing.T) {
+	t.Parallel()
+
+	secret, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	seed := big.NewInt(0xdeadbeef)
+
+	proof, err := vrf.GenerateProof(secret, seed)
+	require.NoError(t, err)
+	assert.NoError(t, proof.Verify())
+
+	again, err := vrf.GenerateProof(secret, seed)
+	require.NoError(t, err)
+	assert.Equal(t, proof.Output, again.Output)
+	assert.NotEqual(t, proof.C, again.C, "each proof uses a fresh nonce")
+
+	other, err := vrf.GenerateProof(secret, big.NewInt(0xdeadbeee))
+	require.NoError(t, err)
+	assert.NotEqual(t, proof.Output, other.Output)
+}
+
+func TestProof_Verify_RejectsTamperedProofs(t *testing.T) {
+	t.Parallel()
+
+	secret, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherSecret, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		tamper func(*vrf.Proof)
+	}{
+		{"seed", func(p *vrf.Proof) { p.Seed = new(big.Int).Add(p.Seed, big.NewInt(1)) }},
+		{"output", func(p *vrf.Proof) { p.Output = new(big.Int).Add(p.Output, big.NewInt(1)) }},
+		{"c", func(p *vrf.Proof) { p.C = new(big.Int).Add(p.C, big.NewInt(1)) }},
+		{"s", func(p *vrf.Proof) { p.S = new(big.Int).Add(p.S, big.NewInt(1)) }},
+		{"public key", func(p *vrf.Proof) {
+			p.PublicKey = vrf.Point{X: otherSecret.PublicKey.X, Y: otherSecret.PublicKey.Y}
+		}},
+		{"gamma off the curve", func(p *vrf.Proof) { p.Gamma = vrf.Point{X: big.NewInt(1), Y: big.NewInt(1)} }},
+	}
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			proof, err := vrf.GenerateProof(secret, big.NewInt(42))
+			require.NoError(t, err)
+			test.tamper(proof)
+			assert.Error(t, proof.Verify())
+			_, err = proof.MarshalForSolidityVerifier()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProof_MarshalForSolidityVerifier(t *testing.T) {
+	t.Parallel()
+
+	secret, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	seed := big.NewInt(7)
+	proof, err := vrf.GenerateProof(secret, seed)
+	require.NoError(t, err)
+
+	b, err := proof.MarshalForSolidityVerifier()
+	require.NoError(t, err)
+	require.Len(t, b, vrf.ProofLength)
+
+	word := func(i int) []byte { return b[i*32 : (i+1)*32] }
+	assert.Equal(t, proof.PublicKey.Bytes(), b[:64])
+	assert.Equal(t, proof.Gamma.Bytes(), b[64:128])
+	assert.Equal(t, common.LeftPadBytes(proof.C.Bytes(), 32), word(4))
+	assert.Equal(t, common.LeftPadBytes(proof.S.Bytes(), 32), word(5))
+	assert.Equal(t, common.LeftPadBytes(seed.Bytes(), 32), word(6))
+
+	// U = c*publicKey + s*G is given by its address.
+	curve := crypto.S256()
+	cx, cy := curve.ScalarMult(proof.PublicKey.X, proof.PublicKey.Y, new(big.Int).Mod(proof.C, curve.Params().N).Bytes())
+	sx, sy := curve.ScalarBaseMult(proof.S.Bytes())
+	ux, uy := curve.Add(cx, cy, sx, sy)
+	u := vrf.Point{X: ux, Y: uy}
+	assert.Equal(t, common.BytesToAddress(crypto.Keccak256(u.Bytes())), common.BytesToAddress(word(7)))
+
+	// c*Gamma is the first witness.
+	gx, gy := curve.ScalarMult(proof.Gamma.X, proof.Gamma.Y, new(big.Int).Mod(proof.C, curve.Params().N).Bytes())
+	assert.Equal(t, vrf.Point{X: gx, Y: gy}.Bytes(), b[256:320])
+}
+
+func TestHashToCurve(t *testing.T) {
+	t.Parallel()
+
+	secret, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pk := vrf.Point{X: secret.PublicKey.X, Y: secret.PublicKey.Y}
+
+	for seed := int64(0); seed < 10; seed++ {
+		h := vrf.HashToCurve(pk, big.NewInt(seed))
+		assert.True(t, h.IsOnCurve())
+		assert.Equal(t, uint(0), h.Y.Bit(0), "the even Y ordinate is taken")
+		assert.True(t, h.Equal(vrf.HashToCurve(pk, big.NewInt(seed))))
+	}
+}
+
+func TestPublicKey(t *testing.T) {
+	t.Parallel()
+
+	secret, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pk := vrf.NewPublicKey(secret)
+
+	parsed, err := vrf.ParsePublicKey(pk.String())
+	require.NoError(t, err)
+	assert.Equal(t, pk, parsed)
+
+	point, err := pk.Point()
+	require.NoError(t, err)
+	assert.Equal(t, 0, secret.PublicKey.X.Cmp(point.X))
+	assert.Equal(t, 0, secret.PublicKey.Y.Cmp(point.Y))
+
+	hash, err := pk.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(point.Bytes()), hash)
+
+	_, err = vrf.ParsePublicKey("0x1234")
+	assert.Error(t, err)
+	_, err = vrf.ParsePublicKey("not hex")
+	assert.Error(t, err)
+}
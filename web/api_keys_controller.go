@@ -0,0 +1,134 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/asdine/storm"
+	"github.com/gin-gonic/gin"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// APIKeysController manages the keys which scripts can send in the
+// X-API-Key header instead of logging in.
+type APIKeysController struct {
+	App services.Application
+}
+
+// Create makes a new key, limited to the given scopes if there are any. The
+// key is only shown in this response. A key made with another key must have
+// scopes, all of which that key has, so that keys cannot grant themselves
+// more access.
+// Example:
+//  "<application>/v2/keys"
+//
+// @Summary Create an API key
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param key body models.APIKeyRequest true "Name and scopes"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.APIKey}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 403 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/keys [post]
+func (akc *APIKeysController) Create(c *gin.Context) {
+	var request models.APIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		publicError(c, 422, err)
+	} else if err := grantableScopes(c, request.Scopes); err != nil {
+		publicError(c, 403, err)
+	} else if key, value, err := models.NewAPIKey(currentUser(c).Email, request.Name, request.Scopes); err != nil {
+		publicError(c, 400, err)
+	} else if err = akc.App.GetStore().Save(&key); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		pk := presenters.NewAPIKey(key)
+		pk.Key = value
+		if doc, err := jsonapi.Marshal(pk); err != nil {
+			c.AbortWithError(500, err)
+		} else {
+			c.Data(200, MediaType, doc)
+		}
+	}
+}
+
+// grantableScopes returns an error unless the key making the request, if
+// any, may create a key with scopes.
+func grantableScopes(c *gin.Context, scopes []models.Scope) error {
+	k, ok := c.Get(currentAPIKeyKey)
+	if !ok {
+		return nil
+	}
+	key := k.(models.APIKey)
+	if len(scopes) == 0 {
+		return errors.New("A key made with a scoped API key must have scopes")
+	}
+	for _, scope := range scopes {
+		if !key.HasScope(scope) {
+			return fmt.Errorf("API key lacks the %s scope", scope)
+		}
+	}
+	return nil
+}
+
+// Index lists the API keys without their secrets.
+// Example:
+//  "<application>/v2/keys"
+//
+// @Summary List API keys
+// @Tags keys
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.APIKey}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Router /v2/keys [get]
+func (akc *APIKeysController) Index(c *gin.Context) {
+	keys, err := akc.App.GetStore().APIKeys()
+	if err != nil {
+		c.AbortWithError(500, fmt.Errorf("error fetching API keys: %+v", err))
+		return
+	}
+
+	pks := make([]presenters.APIKey, len(keys))
+	for i, k := range keys {
+		pks[i] = presenters.NewAPIKey(k)
+	}
+	if doc, err := jsonapi.Marshal(pks); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
+// Destroy revokes an API key.
+// Example:
+//  "<application>/v2/keys/:ID"
+//
+// @Summary Revoke an API key
+// @Tags keys
+// @Produce json
+// @Security SessionCookie
+// @Param ID path string true "API key ID"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.APIKey}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/keys/{ID} [delete]
+func (akc *APIKeysController) Destroy(c *gin.Context) {
+	store := akc.App.GetStore()
+	if key, err := store.FindAPIKey(c.Param("ID")); err == storm.ErrNotFound {
+		publicError(c, 404, errors.New("API key not found"))
+	} else if err != nil {
+		c.AbortWithError(500, err)
+	} else if err = store.DeleteStruct(&key); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(presenters.NewAPIKey(key)); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
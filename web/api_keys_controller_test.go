@@ -0,0 +1,173 @@
+package web_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func apiKeyRequest(t *testing.T, app *cltest.TestApplication, method, path, key string, body io.Reader) *http.Response {
+	req, err := http.NewRequest(method, app.Config.ClientNodeURL+path, body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(web.APIKeyHeader, key)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func createAPIKey(t *testing.T, client cltest.HTTPClientCleaner, body string) presenters.APIKey {
+	resp, done := client.Post("/v2/keys", bytes.NewBufferString(body))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var key presenters.APIKey
+	require.NoError(t, jsonapi.Unmarshal(b, &key))
+	return key
+}
+
+func TestAPIKeysController_Create(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	key := createAPIKey(t, client, `{"name":"deployer","scopes":["jobs:read","jobs:write"]}`)
+	assert.Equal(t, "deployer", key.Name)
+	assert.Equal(t, []models.Scope{models.ScopeJobsRead, models.ScopeJobsWrite}, key.Scopes)
+	require.NotEmpty(t, key.Key)
+
+	stored, err := app.Store.FindAPIKey(key.ID)
+	require.NoError(t, err)
+	assert.NotContains(t, stored.HashedSecret, key.Key[len(key.ID)+1:], "only a hash is stored")
+	assert.Equal(t, cltest.APIEmail, stored.Email, "the key acts for the user who created it")
+
+	resp, done := client.Get("/v2/keys")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var keys []presenters.APIKey
+	require.NoError(t, jsonapi.Unmarshal(b, &keys))
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID, keys[0].ID)
+	assert.Empty(t, keys[0].Key, "the key is only shown once")
+	assert.NotContains(t, string(b), "hashedSecret")
+
+	resp, done = client.Post("/v2/keys", bytes.NewBufferString(`{"name":"deployer","scopes":["everything"]}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 400)
+	resp, done = client.Post("/v2/keys", bytes.NewBufferString(`{"scopes":["jobs:read"]}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 400)
+}
+
+func TestAPIKeysController_ScopeEnforcement(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	readOnly := createAPIKey(t, client, `{"name":"reader","scopes":["jobs:read","runs:read"]}`).Key
+	unscoped := createAPIKey(t, client, `{"name":"everything"}`).Key
+	jobJSON := cltest.LoadJSON("../internal/fixtures/web/hello_world_job.json")
+
+	assert.Equal(t, 200, apiKeyRequest(t, app, "GET", "/v2/specs", readOnly, nil).StatusCode)
+	assert.Equal(t, 200, apiKeyRequest(t, app, "GET", "/v2/runs", readOnly, nil).StatusCode)
+	assert.Equal(t, 403, apiKeyRequest(t, app, "POST", "/v2/specs", readOnly, bytes.NewBuffer(jobJSON)).StatusCode)
+	assert.Equal(t, 403, apiKeyRequest(t, app, "GET", "/v2/bridge_types", readOnly, nil).StatusCode)
+	assert.Equal(t, 403, apiKeyRequest(t, app, "GET", "/v2/keys", readOnly, nil).StatusCode)
+
+	assert.Equal(t, 200, apiKeyRequest(t, app, "POST", "/v2/specs", unscoped, bytes.NewBuffer(jobJSON)).StatusCode)
+	assert.Equal(t, 200, apiKeyRequest(t, app, "GET", "/v2/bridge_types", unscoped, nil).StatusCode)
+
+	assert.Equal(t, 401, apiKeyRequest(t, app, "GET", "/v2/specs", readOnly+"0", nil).StatusCode)
+	assert.Equal(t, 401, apiKeyRequest(t, app, "GET", "/v2/specs", "garbage", nil).StatusCode)
+}
+
+func TestAPIKeysController_CreateWithKey(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	keyWriter := createAPIKey(t, client, `{"name":"keys","scopes":["api_keys:write","jobs:read"]}`).Key
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"no scopes", `{"name":"everything"}`, 403},
+		{"scope the key lacks", `{"name":"writer","scopes":["jobs:write"]}`, 403},
+		{"some scopes the key lacks", `{"name":"writer","scopes":["jobs:read","node:write"]}`, 403},
+		{"scopes the key has", `{"name":"reader","scopes":["jobs:read"]}`, 200},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			resp := apiKeyRequest(t, app, "POST", "/v2/keys", keyWriter, bytes.NewBufferString(test.body))
+			assert.Equal(t, test.want, resp.StatusCode)
+		})
+	}
+
+	keys, err := app.Store.APIKeys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestAPIKeysController_Destroy(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	key := createAPIKey(t, client, `{"name":"temporary"}`)
+
+	assert.Equal(t, 200, apiKeyRequest(t, app, "GET", "/v2/specs", key.Key, nil).StatusCode)
+
+	resp, done := client.Delete("/v2/keys/" + key.ID)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	resp, done = client.Delete("/v2/keys/" + key.ID)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 404)
+
+	assert.Equal(t, 401, apiKeyRequest(t, app, "GET", "/v2/specs", key.Key, nil).StatusCode)
+	_, err := app.Store.FindAPIKey(key.ID)
+	assert.Error(t, err)
+}
+
+func TestAPIKeysController_KeyActsForItsUser(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	jobJSON := cltest.LoadJSON("../internal/fixtures/web/hello_world_job.json")
+
+	resp, done := client.Post("/v2/users", bytes.NewBufferString(`{"email":"deployer@chainlink.test","password":"`+cltest.Password+`","role":"operator"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 201)
+	key, value, err := models.NewAPIKey("deployer@chainlink.test", "deployer", nil)
+	require.NoError(t, err)
+	require.NoError(t, app.Store.Save(&key))
+
+	assert.Equal(t, 200, apiKeyRequest(t, app, "POST", "/v2/specs", value, bytes.NewBuffer(jobJSON)).StatusCode)
+	assert.Equal(t, 403, apiKeyRequest(t, app, "GET", "/v2/keys", value, nil).StatusCode, "the key's user is not an admin")
+
+	resp, done = client.Patch("/v2/user/role", bytes.NewBufferString(`{"email":"deployer@chainlink.test","role":"viewer"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	assert.Equal(t, 403, apiKeyRequest(t, app, "POST", "/v2/specs", value, bytes.NewBuffer(jobJSON)).StatusCode)
+	assert.Equal(t, 200, apiKeyRequest(t, app, "GET", "/v2/specs", value, nil).StatusCode)
+}
@@ -19,6 +19,16 @@ type AssignmentsController struct {
 // assignment spec format.
 // Example:
 //  "<application>/assignments"
+//
+// @Summary Create a job from a v1 assignment
+// @Tags v1
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param assignment body models.AssignmentSpec true "Assignment"
+// @Success 200 {object} presenters.JobSpec
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v1/assignments [post]
 func (ac *AssignmentsController) Create(c *gin.Context) {
 	var a models.AssignmentSpec
 
@@ -37,6 +47,15 @@ func (ac *AssignmentsController) Create(c *gin.Context) {
 // assignment spec format.
 // Example:
 //  "<application>/assignments/:ID"
+//
+// @Summary Show a job as a v1 assignment
+// @Tags v1
+// @Produce json
+// @Security SessionCookie
+// @Param ID path string true "Job ID"
+// @Success 200 {object} models.AssignmentSpec
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v1/assignments/{ID} [get]
 func (ac *AssignmentsController) Show(c *gin.Context) {
 	id := c.Param("ID")
 
@@ -0,0 +1,182 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/tidwall/gjson"
+)
+
+// auditResource describes a kind of resource changed by audited routes.
+type auditResource struct {
+	// Type is recorded as the entry's resource type.
+	Type string
+	// Param names the route parameter holding the resource's ID. Routes
+	// creating a resource have none, and the ID is read from the response.
+	Param string
+	// ID returns the resource's ID for routes which have it somewhere other
+	// than their path.
+	ID func(*gin.Context) string
+	// Load returns the resource's current state as recorded, with any
+	// secrets left out. It is nil when the state is not recorded.
+	Load func(*store.Store, string) (interface{}, error)
+}
+
+var (
+	auditJobSpec = auditResource{Type: "job_spec", Param: "SpecID", Load: func(s *store.Store, id string) (interface{}, error) {
+		return s.FindJob(id)
+	}}
+	auditJobRun = auditResource{Type: "job_run", Param: "RunID", Load: func(s *store.Store, id string) (interface{}, error) {
+		return s.FindJobRun(id)
+	}}
+	auditServiceAgreement = auditResource{Type: "service_agreement", Param: "SAID", Load: func(s *store.Store, id string) (interface{}, error) {
+		return s.FindServiceAgreement(id)
+	}}
+	auditBridgeType = auditResource{Type: "bridge_type", Param: "BridgeName", Load: func(s *store.Store, name string) (interface{}, error) {
+		bt, err := s.FindBridge(name)
+		bt.IncomingToken, bt.OutgoingToken = "", ""
+		return bt, err
+	}}
+	auditHTTPCredential = auditResource{Type: "http_credential", Param: "Name", Load: func(s *store.Store, name string) (interface{}, error) {
+		hc, err := s.FindHTTPCredential(name)
+		return presenters.NewHTTPCredential(hc), err
+	}}
+	// auditUser is the user making the request, and auditUserByEmail the
+	// user with the email in its body.
+	auditUser = auditResource{Type: "user", Load: loadAuditUser, ID: func(c *gin.Context) string {
+		return currentUser(c).Email
+	}}
+	auditUserByEmail = auditResource{Type: "user", Load: loadAuditUser, ID: func(c *gin.Context) string {
+		body, err := ioutil.ReadAll(c.Request.Body)
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+		return gjson.GetBytes(body, "email").String()
+	}}
+	auditAPIKey = auditResource{Type: "api_key", Param: "ID", Load: func(s *store.Store, id string) (interface{}, error) {
+		key, err := s.FindAPIKey(id)
+		return presenters.NewAPIKey(key), err
+	}}
+	auditUpkeep = auditResource{Type: "upkeep", Param: "ID", Load: func(s *store.Store, id string) (interface{}, error) {
+		return s.FindUpkeep(id)
+	}}
+	auditVRFKey = auditResource{Type: "vrf_key", Load: func(s *store.Store, publicKey string) (interface{}, error) {
+		key, err := s.FindEncryptedVRFKey(publicKey)
+		return presenters.NewVRFKey(key), err
+	}}
+	auditLogLevel = auditResource{Type: "log_level", Load: func(*store.Store, string) (interface{}, error) {
+		return models.LogLevelRequest{Level: logger.GetLogLevel().String()}, nil
+	}}
+	auditWithdrawal = auditResource{Type: "withdrawal"}
+	auditConfig     = auditResource{Type: "config", Load: func(s *store.Store, _ string) (interface{}, error) {
+		return presenters.NewConfigWhitelist(s.CurrentConfig()), nil
+	}}
+)
+
+func loadAuditUser(s *store.Store, email string) (interface{}, error) {
+	user, err := s.FindUserByEmail(email)
+	return struct {
+		Email       string      `json:"email"`
+		TOTPEnabled bool        `json:"totpEnabled"`
+		Role        models.Role `json:"role"`
+	}{user.Email, user.TOTPEnabled, user.EffectiveRole()}, err
+}
+
+// AuditLogger records the changes made by the routes it wraps in the audit
+// log.
+type AuditLogger struct {
+	App services.Application
+}
+
+// Record returns middleware which, once the handler after it succeeds, adds
+// an entry for action on the resource to the audit log. Failed requests are
+// assumed to have changed nothing, and are not recorded.
+func (al *AuditLogger) Record(action string, resource auditResource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store := al.App.GetStore()
+		id := c.Param(resource.Param)
+		if resource.ID != nil {
+			id = resource.ID(c)
+		}
+		before := al.state(resource, id)
+
+		writer := &auditWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		if c.Writer.Status() >= 400 || len(c.Errors) > 0 {
+			return
+		}
+
+		if id == "" {
+			id = createdResourceID(writer.body.Bytes())
+		}
+		entry := models.AuditLog{
+			Action:       action,
+			ResourceType: resource.Type,
+			ResourceID:   id,
+			BeforeJSON:   before,
+			AfterJSON:    al.state(resource, id),
+			IPAddress:    clientIP(c.Request, store.Config.APIForwardedDepth).String(),
+			Timestamp:    models.Time{Time: store.Clock.Now()},
+		}
+		if user, ok := c.Get(currentUserKey); ok {
+			entry.Actor = user.(models.User).Email
+		}
+		if err := store.Save(&entry); err != nil {
+			logger.Errorw("Unable to record audit log entry", "action", action, "resourceType", resource.Type, "resourceID", id, "error", err)
+		}
+	}
+}
+
+// state returns the JSON of the resource's current state, which is empty
+// when it does not exist.
+func (al *AuditLogger) state(resource auditResource, id string) models.JSON {
+	if resource.Load == nil || (resource.Param != "" && id == "") {
+		return models.JSON{}
+	}
+	state, err := resource.Load(al.App.GetStore(), id)
+	if err != nil {
+		return models.JSON{}
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		logger.Warnw("Unable to record audit log state", "resourceType", resource.Type, "error", err)
+		return models.JSON{}
+	}
+	js, _ := models.ParseJSON(b)
+	return js
+}
+
+// createdResourceID reads the ID of a created resource from the response,
+// either a JSON:API document or an object with an "id".
+func createdResourceID(body []byte) string {
+	if id := gjson.GetBytes(body, "data.id"); id.Exists() {
+		return id.String()
+	}
+	return gjson.GetBytes(body, "id").String()
+}
+
+// auditWriter keeps a copy of the response, so that the ID of a created
+// resource can be read from it.
+type auditWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// AuditController shows the changes recorded by the AuditLogger.
+type AuditController struct {
+	App services.Application
+}
+
+// Index lists the audit log, newest first, one page at a time. Only entries
+// for resource_type, and between from and to, are listed when those are
+// given.
+// Example:
+//  "<application>/audit?resource_type=job_spec&from=2018-11-01T00:00:00Z"
+//
+// @Summary List audit log entries
+// @Tags audit
+// @Produce json
+// @Security SessionCookie
+// @Param resource_type query string false "Only list changes to this kind of resource" Enums(job_spec, job_run, service_agreement, bridge_type, http_credential, user, log_level, withdrawal, api_key, config)
+// @Param from query string false "RFC 3339 time of the earliest entry"
+// @Param to query string false "RFC 3339 time after the latest entry"
+// @Param size query int false "Number of records per page"
+// @Param page query int false "Page number, starting at 1"
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.AuditLog}}
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/audit [get]
+func (ac *AuditController) Index(c *gin.Context) {
+	size, page, offset, err := ParsePaginatedRequest(c.Query("size"), c.Query("page"))
+	if err != nil {
+		publicError(c, 422, err)
+		return
+	}
+	from, err := parseAuditTime(c.Query("from"))
+	if err != nil {
+		publicError(c, 422, err)
+		return
+	}
+	to, err := parseAuditTime(c.Query("to"))
+	if err != nil {
+		publicError(c, 422, err)
+		return
+	}
+
+	logs, count, err := ac.App.GetStore().AuditLogs(c.Query("resource_type"), from, to, offset, size)
+	if err != nil {
+		c.AbortWithError(500, fmt.Errorf("error fetching audit logs: %+v", err))
+		return
+	}
+	pals := make([]presenters.AuditLog, len(logs))
+	for i, l := range logs {
+		pals[i] = presenters.AuditLog{AuditLog: l}
+	}
+
+	buffer, err := NewPaginatedResponse(*c.Request.URL, size, page, count, pals)
+	if err != nil {
+		c.AbortWithError(500, fmt.Errorf("failed to marshal document: %+v", err))
+	} else {
+		c.Data(200, MediaType, buffer)
+	}
+}
+
+func parseAuditTime(param string) (time.Time, error) {
+	if param == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, must be RFC 3339", param)
+	}
+	return t, nil
+}
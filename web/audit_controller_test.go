@@ -0,0 +1,178 @@
+package web_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchAuditLogs(t *testing.T, client cltest.HTTPClientCleaner, query string) ([]presenters.AuditLog, jsonapi.Links) {
+	resp, cleanup := client.Get("/v2/audit" + query)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var logs []presenters.AuditLog
+	var links jsonapi.Links
+	require.NoError(t, web.ParsePaginatedResponse(body, &logs, &links))
+	return logs, links
+}
+
+func TestAuditController_JobSpecCreated(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	j := cltest.FixtureCreateJobViaWeb(t, app, "../internal/fixtures/web/hello_world_job.json")
+
+	logs, _ := fetchAuditLogs(t, client, "?resource_type=job_spec")
+	require.Len(t, logs, 1)
+	entry := logs[0]
+	assert.Equal(t, cltest.APIEmail, entry.Actor)
+	assert.Equal(t, "create", entry.Action)
+	assert.Equal(t, "job_spec", entry.ResourceType)
+	assert.Equal(t, j.ID, entry.ResourceID)
+	assert.NotEmpty(t, entry.IPAddress)
+	assert.JSONEq(t, `{}`, entry.BeforeJSON.String())
+	assert.Equal(t, j.ID, entry.AfterJSON.Get("id").String())
+	assert.True(t, entry.AfterJSON.Get("tasks").IsArray())
+}
+
+func TestAuditController_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		depth int
+		want  string
+	}{
+		{"without a proxy", 0, "127.0.0.1"},
+		{"behind a proxy", 1, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			config, cfgCleanup := cltest.NewConfig()
+			defer cfgCleanup()
+			config.APIForwardedDepth = test.depth
+			app, cleanup := cltest.NewApplicationWithConfig(config)
+			defer cleanup()
+			client := app.NewHTTPClient()
+
+			resp, done := client.Post("/v2/bridge_types", bytes.NewBufferString(`{"name":"bridgea","url":"http://mybridge"}`))
+			defer done()
+			cltest.AssertServerResponse(t, resp, 200)
+			resp, done = client.Patch("/v2/bridge_types/bridgea", bytes.NewBufferString(`{"url":"http://yourbridge"}`),
+				map[string]string{"X-Forwarded-For": "10.0.0.1"})
+			defer done()
+			cltest.AssertServerResponse(t, resp, 200)
+
+			logs, _ := fetchAuditLogs(t, client, "?resource_type=bridge_type")
+			require.Len(t, logs, 2)
+			for _, entry := range logs {
+				if entry.Action == "update" {
+					assert.Equal(t, test.want, entry.IPAddress)
+				} else {
+					assert.Equal(t, "127.0.0.1", entry.IPAddress)
+				}
+			}
+		})
+	}
+}
+
+func TestAuditController_BridgeTypeUpdatedAndDeleted(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, done := client.Post("/v2/bridge_types", bytes.NewBufferString(`{"name":"bridgea","url":"http://mybridge"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	resp, done = client.Patch("/v2/bridge_types/bridgea", bytes.NewBufferString(`{"url":"http://yourbridge"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	resp, done = client.Delete("/v2/bridge_types/bridgea")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	// Failed requests change nothing, so are not recorded.
+	resp, done = client.Delete("/v2/bridge_types/bridgea")
+	defer done()
+	assert.Equal(t, 404, resp.StatusCode)
+
+	logs, _ := fetchAuditLogs(t, client, "?resource_type=bridge_type")
+	require.Len(t, logs, 3)
+	deleted, updated, created := logs[0], logs[1], logs[2]
+
+	assert.Equal(t, "create", created.Action)
+	assert.Equal(t, "bridgea", created.ResourceID)
+	assert.JSONEq(t, `{}`, created.BeforeJSON.String())
+	assert.Equal(t, "http://mybridge", created.AfterJSON.Get("url").String())
+	assert.Empty(t, created.AfterJSON.Get("incomingToken").String(), "secrets are not recorded")
+	assert.Empty(t, created.AfterJSON.Get("outgoingToken").String(), "secrets are not recorded")
+
+	assert.Equal(t, "update", updated.Action)
+	assert.Equal(t, "bridgea", updated.ResourceID)
+	assert.Equal(t, "http://mybridge", updated.BeforeJSON.Get("url").String())
+	assert.Equal(t, "http://yourbridge", updated.AfterJSON.Get("url").String())
+
+	assert.Equal(t, "delete", deleted.Action)
+	assert.Equal(t, "bridgea", deleted.ResourceID)
+	assert.Equal(t, "http://yourbridge", deleted.BeforeJSON.Get("url").String())
+	assert.JSONEq(t, `{}`, deleted.AfterJSON.String())
+}
+
+// Not parallel, since it changes the global log level.
+func TestAuditController_Index_FilterAndPaginate(t *testing.T) {
+	defer logger.SetLogLevel(logger.GetLogLevel())
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	clock := cltest.UseSettableClock(app.Store)
+	start := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, level := range []string{"debug", "info", "warn"} {
+		clock.SetTime(start.Add(time.Duration(i) * time.Hour))
+		resp, done := client.Put("/v2/loglevel", bytes.NewBufferString(`{"level":"`+level+`"}`))
+		defer done()
+		cltest.AssertServerResponse(t, resp, 200)
+	}
+	resp, done := client.Put("/v2/loglevel", bytes.NewBufferString(`{"level":"info"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	logs, links := fetchAuditLogs(t, client, "?resource_type=log_level&from=2018-11-01T01:00:00Z&size=1")
+	require.Len(t, logs, 1)
+	assert.Equal(t, "info", logs[0].AfterJSON.Get("level").String(), "newest first")
+	assert.NotEmpty(t, links["next"].Href)
+
+	logs, _ = fetchAuditLogs(t, client, "?resource_type=log_level&from=2018-11-01T01:00:00Z&size=1&page=2")
+	require.Len(t, logs, 1)
+	assert.Equal(t, "warn", logs[0].AfterJSON.Get("level").String())
+
+	logs, _ = fetchAuditLogs(t, client, "?from=2018-11-01T01:00:00Z&to=2018-11-01T02:00:00Z")
+	require.Len(t, logs, 1)
+	assert.Equal(t, "debug", logs[0].BeforeJSON.Get("level").String())
+	assert.Equal(t, "info", logs[0].AfterJSON.Get("level").String())
+
+	logs, _ = fetchAuditLogs(t, client, "?resource_type=job_spec")
+	assert.Empty(t, logs)
+
+	resp, done = client.Get("/v2/audit?from=yesterday")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 422)
+}
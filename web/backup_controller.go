@@ -13,6 +13,13 @@ type BackupController struct {
 }
 
 // Show streams a backup of the current db through a read-only transaction.
+//
+// @Summary Download a backup of the database
+// @Tags backup
+// @Produce octet-stream
+// @Security SessionCookie
+// @Success 200 {file} file "backup.bolt"
+// @Router /v2/backup [get]
 func (bc *BackupController) Show(c *gin.Context) {
 	tx, err := bc.App.GetStore().GetBolt().Begin(false)
 	if err != nil {
@@ -19,6 +19,16 @@ type BridgeTypesController struct {
 }
 
 // Create adds the BridgeType to the given context.
+//
+// @Summary Add a bridge
+// @Tags bridges
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param bridge body models.BridgeType true "Bridge"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.BridgeType}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v2/bridge_types [post]
 func (btc *BridgeTypesController) Create(c *gin.Context) {
 	bt := &models.BridgeType{}
 
@@ -34,6 +44,16 @@ func (btc *BridgeTypesController) Create(c *gin.Context) {
 }
 
 // Index lists Bridges, one page at a time.
+//
+// @Summary List bridges
+// @Tags bridges
+// @Produce json
+// @Security SessionCookie
+// @Param size query int false "Number of records per page"
+// @Param page query int false "Page number, starting at 1"
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.BridgeType}}
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/bridge_types [get]
 func (btc *BridgeTypesController) Index(c *gin.Context) {
 	size, page, offset, err := ParsePaginatedRequest(c.Query("size"), c.Query("page"))
 	if err != nil {
@@ -68,6 +88,15 @@ func (btc *BridgeTypesController) Index(c *gin.Context) {
 }
 
 // Show returns the details of a specific Bridge.
+//
+// @Summary Show a bridge
+// @Tags bridges
+// @Produce json
+// @Security SessionCookie
+// @Param BridgeName path string true "Bridge name"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.BridgeType}}
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/bridge_types/{BridgeName} [get]
 func (btc *BridgeTypesController) Show(c *gin.Context) {
 	name := c.Param("BridgeName")
 	if bt, err := btc.App.GetStore().FindBridge(name); err == storm.ErrNotFound {
@@ -82,6 +111,17 @@ func (btc *BridgeTypesController) Show(c *gin.Context) {
 }
 
 // Update can change the restricted attributes for a bridge
+//
+// @Summary Change a bridge
+// @Tags bridges
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param BridgeName path string true "Bridge name"
+// @Param bridge body forms.UpdateBridgeType true "Attributes to change"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.BridgeType}}
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/bridge_types/{BridgeName} [patch]
 func (btc *BridgeTypesController) Update(c *gin.Context) {
 	bn := c.Param("BridgeName")
 	form, err := forms.NewUpdateBridgeType(btc.App.GetStore(), bn)
@@ -108,6 +148,16 @@ func (btc *BridgeTypesController) Update(c *gin.Context) {
 }
 
 // Destroy removes a specific Bridge.
+//
+// @Summary Remove a bridge
+// @Tags bridges
+// @Produce json
+// @Security SessionCookie
+// @Param BridgeName path string true "Bridge name"
+// @Success 200 {object} presenters.BridgeType
+// @Failure 404 {object} models.JSONAPIErrors
+// @Failure 409 "Jobs still use the bridge"
+// @Router /v2/bridge_types/{BridgeName} [delete]
 func (btc *BridgeTypesController) Destroy(c *gin.Context) {
 	name := c.Param("BridgeName")
 	if bt, err := btc.App.GetStore().FindBridge(name); err == storm.ErrNotFound {
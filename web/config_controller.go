@@ -1,11 +1,13 @@
 package web
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/presenters"
 )
 
@@ -17,11 +19,54 @@ type ConfigController struct {
 // Show returns the whitelist of config variables
 // Example:
 //  "<application>/config"
+//
+// @Summary Show the node's configuration
+// @Tags config
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.ConfigWhitelist}}
+// @Router /v2/config [get]
 func (cc *ConfigController) Show(c *gin.Context) {
-	pc := presenters.NewConfigWhitelist(cc.App.GetStore().Config)
+	pc := presenters.NewConfigWhitelist(cc.App.GetStore().CurrentConfig())
 	if json, err := jsonapi.Marshal(pc); err != nil {
 		c.AbortWithError(500, fmt.Errorf("failed to marshal config using jsonapi: %+v", err))
 	} else {
 		c.Data(200, MediaType, json)
 	}
 }
+
+// Update changes settings without restarting the node, given as an object
+// keyed by their environment variables. Only store.ReloadableSettings can be
+// changed, and nothing is changed when any other setting is given.
+// Example:
+//  "<application>/config"
+//
+// @Summary Change reloadable settings
+// @Tags config
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param settings body object true "Settings keyed by environment variable"
+// @Success 200 {array} store.ConfigChange
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/config [put]
+func (cc *ConfigController) Update(c *gin.Context) {
+	var settings map[string]interface{}
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&settings); err != nil {
+		publicError(c, 400, err)
+		return
+	}
+
+	changes, err := cc.App.GetConfigReloader().Apply(settings)
+	if err != nil {
+		publicError(c, 422, err)
+		return
+	}
+	if changes == nil {
+		changes = []store.ConfigChange{}
+	}
+	c.JSON(200, changes)
+}
@@ -1,6 +1,8 @@
 package web_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"math/big"
 	"testing"
 	"time"
@@ -42,9 +44,78 @@ func TestConfigController_Show(t *testing.T) {
 	assert.Equal(t, uint64(300), cwl.MinimumRequestExpiration)
 	assert.Equal(t, big.NewInt(5000000000), cwl.EthGasBumpWei)
 	assert.Equal(t, big.NewInt(20000000000), cwl.EthGasPriceDefault)
+	assert.Equal(t, uint64(240), cwl.EthTxMissingThreshold)
 	assert.Equal(t, store.NewConfig().LinkContractAddress,
 		cwl.LinkContractAddress)
 	assert.Equal(t, assets.NewLink(100), cwl.MinimumContractPayment)
 	assert.Equal(t, (*common.Address)(nil), cwl.OracleContractAddress)
 	assert.Equal(t, store.Duration{Duration: time.Millisecond * 500}, cwl.DatabaseTimeout)
+	assert.Equal(t, uint64(4194304), cwl.DefaultHTTPLimit)
+	assert.Equal(t, store.Duration{Duration: time.Second * 15}, cwl.DefaultHTTPTimeout)
+	assert.Equal(t, uint64(3), cwl.HTTPRetryAttempts)
+	assert.Equal(t, store.Duration{Duration: time.Second}, cwl.HTTPRetryMinBackoff)
+	assert.Equal(t, store.Duration{Duration: time.Second * 10}, cwl.HTTPRetryMaxBackoff)
+	assert.Equal(t, store.Duration{Duration: time.Second * 30}, cwl.IPFSTimeout)
+	assert.True(t, cwl.AllowUnrestrictedNetworkAccess)
+	assert.Equal(t, "", cwl.OTELExporterOTLPEndpoint)
+	assert.Equal(t, store.Duration{}, cwl.JobRunTimeout)
+	assert.False(t, cwl.AllowUnknownTaskParams)
+	assert.Equal(t, 0, cwl.APIRateLimit)
+	assert.Equal(t, 200, cwl.APIBurstLimit)
+}
+
+func TestConfigController_Update(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	body := `{"ETH_GAS_PRICE_DEFAULT": 30000000000, "MIN_INCOMING_CONFIRMATIONS": 2}`
+	resp, cleanup := client.Put("/v2/config", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	var changes []store.ConfigChange
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(resp), &changes))
+	assert.Equal(t, []store.ConfigChange{
+		{Setting: "ETH_GAS_PRICE_DEFAULT", From: "20000000000", To: "30000000000"},
+		{Setting: "MIN_INCOMING_CONFIRMATIONS", From: "0", To: "2"},
+	}, changes)
+
+	resp, cleanup = client.Get("/v2/config")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	cwl := presenters.ConfigWhitelist{}
+	require.NoError(t, cltest.ParseJSONAPIResponse(resp, &cwl))
+	assert.Equal(t, big.NewInt(30000000000), cwl.EthGasPriceDefault)
+	assert.Equal(t, uint64(2), cwl.MinIncomingConfirmations)
+}
+
+func TestConfigController_Update_Rejected(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"not reloadable", `{"CHAINLINK_PORT": 7000, "MIN_INCOMING_CONFIRMATIONS": 2}`, 422},
+		{"invalid", `{"ETH_GAS_PRICE_DEFAULT": 0}`, 422},
+		{"not a setting", `{"UNKNOWN_SETTING": 1}`, 422},
+		{"not an object", `[]`, 400},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := client.Put("/v2/config", bytes.NewBufferString(test.body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, test.want)
+		})
+	}
+	assert.Equal(t, uint64(0), app.Store.CurrentConfig().MinIncomingConfirmations)
 }
@@ -31,6 +31,56 @@
 // to the node, and shows the current specs which have already
 // been added.
 //
+// RunStatusController
+//
+// RunStatusController streams changes in the status of runs to
+// websocket clients at /v2/runs/ws, as they are broadcast by a
+// RunStatusHub.
+//
+// RateLimitController
+//
+// RateLimitController shows the per client limits which the
+// router applies to API requests, answering 429 once they are
+// exceeded.
+//
+// LogStreamController
+//
+// LogStreamController streams the node's log lines as server-sent
+// events at /v2/logs/stream, catching new clients up on the most
+// recent lines first.
+//
+// OpenAPIController
+//
+// OpenAPIController serves the OpenAPI spec generated from the
+// annotations on each handler at /v2/openapi.json, and a Swagger UI
+// for browsing it at /docs.
+//
+// AuditController
+//
+// AuditController lists the audit log, in which AuditLogger
+// middleware records who changed which resource, from where, and
+// its state before and after.
+//
+// TOTPController
+//
+// TOTPController lets the user turn on two-factor authentication,
+// after which logging in takes a code from their authenticator app
+// as well as their password.
+//
+// APIKeysController
+//
+// APIKeysController creates, lists and revokes the keys which
+// scripts send in the X-API-Key header instead of logging in. A key
+// may be limited to scopes such as jobs:write, which RequireScope
+// middleware checks on each route.
+//
+// RolesController
+//
+// RolesController lists the roles a user may hold: admins may do
+// anything, operators manage jobs, runs and bridges, and viewers
+// may only read. RequireRole middleware answers 403 to users whose
+// role is not enough for a route.
+//
 // Router
 //
 // Router defines the valid paths for the node and responds
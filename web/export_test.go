@@ -0,0 +1,24 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/store"
+)
+
+const ExportedMaxLimiters = maxLimiters
+
+func ExportedRateLimiterBuckets(config store.Config, clients int) int {
+	rl := newRateLimiter(config)
+	now := time.Now()
+	for i := 0; i < clients; i++ {
+		rl.reserve([]string{fmt.Sprintf("key:%d", i)}, now)
+	}
+	return len(rl.byClient)
+}
+
+func ExportedReadSanitizedJSON(body string) (string, error) {
+	return readSanitizedJSON(bytes.NewBufferString(body))
+}
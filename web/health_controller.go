@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// HealthController answers liveness and readiness probes, such as those made
+// by Kubernetes.
+type HealthController struct {
+	App services.Application
+}
+
+// Health responds 200 whenever the node is able to serve HTTP requests
+// Example:
+//  "<application>/health"
+//
+// @Summary Liveness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} presenters.Liveness
+// @Router /health [get]
+func (hc *HealthController) Health(c *gin.Context) {
+	c.JSON(http.StatusOK, presenters.Liveness{Status: "ok"})
+}
+
+// Ready responds 200 once the database, the Ethereum node and a funded
+// account are all available, and 503 with the failing checks otherwise
+// Example:
+//  "<application>/ready"
+//
+// @Summary Readiness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} services.HealthReport
+// @Failure 503 {object} services.HealthReport
+// @Router /ready [get]
+func (hc *HealthController) Ready(c *gin.Context) {
+	report := services.CheckHealth(services.ReadinessCheckers(hc.App.GetStore()))
+	if report.Healthy() {
+		c.JSON(http.StatusOK, report)
+	} else {
+		c.JSON(http.StatusServiceUnavailable, report)
+	}
+}
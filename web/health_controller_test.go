@@ -0,0 +1,76 @@
+package web_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/assets"
+	"github.com/smartcontractkit/chainlink/store/mock_store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getHealthReport(t *testing.T, url string) (int, services.HealthReport) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var report services.HealthReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	return resp.StatusCode, report
+}
+
+func TestHealthController_Health(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	status, report := getHealthReport(t, app.Config.ClientNodeURL+"/health")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", report.Status)
+}
+
+func TestHealthController_Ready(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	app.Store.TxManager = txmMock
+
+	account, err := app.Store.KeyStore.GetAccount()
+	require.NoError(t, err)
+	txmMock.EXPECT().GetBlockByNumber("latest").Return(models.BlockHeader{}, nil)
+	txmMock.EXPECT().GetEthBalance(account.Address).Return(assets.NewEth(1), nil)
+
+	status, report := getHealthReport(t, app.Config.ClientNodeURL+"/ready")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", report.Status)
+	assert.Equal(t, map[string]string{"database": "ok", "ethereum": "ok", "account": "ok"}, report.Checks)
+}
+
+func TestHealthController_Ready_Unavailable(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	txmMock := mock_store.NewMockTxManager(ctrl)
+	app.Store.TxManager = txmMock
+
+	txmMock.EXPECT().GetBlockByNumber("latest").Return(models.BlockHeader{}, errors.New("connection refused"))
+
+	status, report := getHealthReport(t, app.Config.ClientNodeURL+"/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "unavailable", report.Status)
+	assert.Equal(t, "ok", report.Checks["database"])
+	assert.Contains(t, report.Checks["ethereum"], "connection refused")
+	assert.Equal(t, "no Ethereum account configured", report.Checks["account"])
+}
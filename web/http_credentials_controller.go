@@ -0,0 +1,106 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/asdine/storm"
+	"github.com/gin-gonic/gin"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// HTTPCredentialsController manages the credentials HTTP adapters can
+// reference by name. Secrets are accepted but never returned.
+type HTTPCredentialsController struct {
+	App services.Application
+}
+
+// Create stores a new named credential, which is only sent with requests
+// to URLs under its urlPrefix.
+//
+// @Summary Add an HTTP credential
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param credential body models.HTTPCredential true "Credential"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.HTTPCredential}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/http_credentials [post]
+func (hcc *HTTPCredentialsController) Create(c *gin.Context) {
+	hc := models.HTTPCredential{}
+	store := hcc.App.GetStore()
+
+	if err := c.ShouldBindJSON(&hc); err != nil {
+		publicError(c, 422, err)
+	} else if err = hc.Validate(); err != nil {
+		publicError(c, 400, err)
+	} else if _, err = store.FindHTTPCredential(hc.Name); err == nil {
+		publicError(c, 409, fmt.Errorf("credential %s already exists", hc.Name))
+	} else if err != storm.ErrNotFound {
+		c.AbortWithError(500, err)
+	} else if err = store.Save(&hc); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(presenters.NewHTTPCredential(hc)); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
+// Index lists the stored credentials without their secrets.
+//
+// @Summary List HTTP credentials
+// @Tags credentials
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.HTTPCredential}}
+// @Router /v2/http_credentials [get]
+func (hcc *HTTPCredentialsController) Index(c *gin.Context) {
+	var credentials []models.HTTPCredential
+	if err := hcc.App.GetStore().AllByIndex("Name", &credentials); err != nil {
+		c.AbortWithError(500, fmt.Errorf("error fetching credentials: %+v", err))
+		return
+	}
+
+	phc := make([]presenters.HTTPCredential, len(credentials))
+	for i, hc := range credentials {
+		phc[i] = presenters.NewHTTPCredential(hc)
+	}
+	if doc, err := jsonapi.Marshal(phc); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
+// Destroy removes a credential by name.
+//
+// @Summary Remove an HTTP credential
+// @Tags credentials
+// @Produce json
+// @Security SessionCookie
+// @Param Name path string true "Credential name"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.HTTPCredential}}
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/http_credentials/{Name} [delete]
+func (hcc *HTTPCredentialsController) Destroy(c *gin.Context) {
+	name := c.Param("Name")
+	store := hcc.App.GetStore()
+	if hc, err := store.FindHTTPCredential(name); err == storm.ErrNotFound {
+		publicError(c, 404, errors.New("credential not found"))
+	} else if err != nil {
+		c.AbortWithError(500, err)
+	} else if err = store.DeleteStruct(&hc); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(presenters.NewHTTPCredential(hc)); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
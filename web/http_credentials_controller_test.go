@@ -0,0 +1,132 @@
+package web_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPCredentialsController_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"basic", `{"name":"coinbase","urlPrefix":"https://api.coinbase.com/","username":"oracle","password":"hunter22"}`, 200},
+		{"bearer", `{"name":"kaiko","urlPrefix":"https://api.kaiko.com/","token":"s3cr3t"}`, 200},
+		{"no name", `{"urlPrefix":"https://api.kaiko.com/","token":"s3cr3t"}`, 400},
+		{"no secret", `{"name":"empty","urlPrefix":"https://api.kaiko.com/"}`, 400},
+		{"both secrets", `{"name":"both","urlPrefix":"https://api.kaiko.com/","username":"oracle","password":"hunter22","token":"s3cr3t"}`, 400},
+		{"no url prefix", `{"name":"anywhere","token":"s3cr3t"}`, 400},
+		{"invalid json", `{"name":`, 422},
+	}
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := client.Post("/v2/http_credentials", bytes.NewBufferString(test.body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, test.status)
+
+			body := string(cltest.ParseResponseBody(resp))
+			assert.NotContains(t, body, "hunter22")
+			assert.NotContains(t, body, "s3cr3t")
+		})
+	}
+
+	hc, err := app.Store.FindHTTPCredential("coinbase")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter22", hc.Password)
+	assert.Equal(t, "https://api.coinbase.com/", hc.URLPrefix)
+}
+
+func TestHTTPCredentialsController_Create_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.Store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://example.com/", Token: "s3cr3t"}))
+
+	resp, cleanup := client.Post("/v2/http_credentials", bytes.NewBufferString(`{"name":"kaiko","urlPrefix":"https://api.kaiko.com/","token":"other"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 409)
+}
+
+func TestHTTPCredentialsController_Index(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.Store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://example.com/", Token: "s3cr3t"}))
+	require.NoError(t, app.Store.Save(&models.HTTPCredential{Name: "coinbase", URLPrefix: "https://api.coinbase.com/", Username: "oracle", Password: "hunter22"}))
+
+	resp, cleanup := client.Get("/v2/http_credentials")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	body := cltest.ParseResponseBody(resp)
+	assert.NotContains(t, string(body), "hunter22")
+	assert.NotContains(t, string(body), "s3cr3t")
+
+	json := cltest.JSONFromString(string(body))
+	assert.Equal(t, "coinbase", json.Get("data.0.id").String())
+	assert.Equal(t, "Basic", json.Get("data.0.attributes.scheme").String())
+	assert.Equal(t, "oracle", json.Get("data.0.attributes.username").String())
+	assert.Equal(t, "https://api.coinbase.com/", json.Get("data.0.attributes.urlPrefix").String())
+	assert.Equal(t, "kaiko", json.Get("data.1.id").String())
+	assert.Equal(t, "Bearer", json.Get("data.1.attributes.scheme").String())
+}
+
+func TestHTTPCredentialsController_Destroy(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.Store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://example.com/", Token: "s3cr3t"}))
+
+	resp, cleanup := client.Delete("/v2/http_credentials/kaiko")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	assert.NotContains(t, string(cltest.ParseResponseBody(resp)), "s3cr3t")
+
+	_, err := app.Store.FindHTTPCredential("kaiko")
+	assert.Error(t, err)
+
+	resp, cleanup = client.Delete("/v2/http_credentials/kaiko")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 404)
+}
+
+func TestJobSpecsController_Create_CredentialNotEchoed(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.Store.Save(&models.HTTPCredential{Name: "kaiko", URLPrefix: "https://example.com/", Token: "s3cr3t"}))
+
+	body := `{
+		"initiators": [{"type": "web"}],
+		"tasks": [{"type": "HttpGet", "params": {"get": "https://example.com/api", "auth": "kaiko"}}]
+	}`
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	respBody := string(cltest.ParseResponseBody(resp))
+	assert.Contains(t, respBody, "kaiko")
+	assert.NotContains(t, respBody, "s3cr3t")
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/store"
+)
+
+// ipFilter decides which clients may use the API by their IP, as configured
+// with APIAllowedIPs and APIDeniedIPs.
+type ipFilter struct {
+	allowed []*net.IPNet
+	denied  []*net.IPNet
+	depth   int
+}
+
+func newIPFilter(config store.Config) (*ipFilter, error) {
+	allowed, err := parseIPNets(config.APIAllowedIPs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API_ALLOWED_IPS: %v", err)
+	}
+	denied, err := parseIPNets(config.APIDeniedIPs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API_DENIED_IPS: %v", err)
+	}
+	return &ipFilter{allowed: allowed, denied: denied, depth: config.APIForwardedDepth}, nil
+}
+
+// parseIPNets parses each entry as a CIDR range, or as a single IP when it
+// has no prefix length.
+func parseIPNets(entries []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("%q is not an IP or CIDR range", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP or CIDR range", entry)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// enabled is whether any clients are to be turned away.
+func (f *ipFilter) enabled() bool {
+	return len(f.allowed) > 0 || len(f.denied) > 0
+}
+
+// allows is whether ip is in none of the denied ranges, and in one of the
+// allowed ranges when there are any.
+func (f *ipFilter) allows(ip net.IP) bool {
+	if ip == nil || containsIP(f.denied, ip) {
+		return false
+	}
+	return len(f.allowed) == 0 || containsIP(f.allowed, ip)
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the IP of the client which sent r, as seen by the
+// outermost of the depth proxies in front of the node. Each proxy appends
+// the address it received the request from to X-Forwarded-For, so only that
+// many entries from the right are to be trusted, and those to their left,
+// which the client can send itself, are ignored.
+func clientIP(r *http.Request, depth int) net.IP {
+	hops := []string{}
+	if depth > 0 {
+		for _, header := range r.Header["X-Forwarded-For"] {
+			hops = append(hops, strings.Split(header, ",")...)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	hops = append(hops, host)
+
+	i := len(hops) - 1 - depth
+	if i < 0 {
+		i = 0
+	}
+	return net.ParseIP(strings.TrimSpace(hops[i]))
+}
+
+// ipFilterFunc responds 403 Forbidden to clients whose IP is denied, or is
+// not allowed when APIAllowedIPs is set.
+func ipFilterFunc(config store.Config) (gin.HandlerFunc, error) {
+	f, err := newIPFilter(config)
+	if err != nil {
+		return nil, err
+	}
+	if !f.enabled() {
+		return func(c *gin.Context) { c.Next() }, nil
+	}
+	return func(c *gin.Context) {
+		if !f.allows(clientIP(c.Request, f.depth)) {
+			publicError(c, http.StatusForbidden, fmt.Errorf("access from this address is forbidden"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}, nil
+}
@@ -0,0 +1,115 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIPFilteredApplication(allowed, denied []string, depth int) (*cltest.TestApplication, func()) {
+	config, cfgCleanup := cltest.NewConfig()
+	config.APIAllowedIPs = allowed
+	config.APIDeniedIPs = denied
+	config.APIForwardedDepth = depth
+	app, cleanup := cltest.NewApplicationWithConfig(config)
+	return app, func() {
+		cleanup()
+		cfgCleanup()
+	}
+}
+
+// healthStatus requests /health from the test server, which the client
+// reaches from 127.0.0.1, sending forwardedFor as X-Forwarded-For.
+func healthStatus(t *testing.T, app *cltest.TestApplication, forwardedFor ...string) int {
+	req, err := http.NewRequest("GET", app.Server.URL+"/health", nil)
+	require.NoError(t, err)
+	for _, header := range forwardedFor {
+		req.Header.Add("X-Forwarded-For", header)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestIPFilter_RemoteAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		want    int
+	}{
+		{"no lists", nil, nil, http.StatusOK},
+		{"allowed IPv4", []string{"127.0.0.1"}, nil, http.StatusOK},
+		{"allowed IPv4 range", []string{"10.0.0.0/8", "127.0.0.0/8"}, nil, http.StatusOK},
+		{"not allowed", []string{"10.0.0.0/8"}, nil, http.StatusForbidden},
+		{"denied range", nil, []string{"127.0.0.0/24"}, http.StatusForbidden},
+		{"denied elsewhere", nil, []string{"10.0.0.0/8"}, http.StatusOK},
+		{"denied over allowed", []string{"127.0.0.0/8"}, []string{"127.0.0.1/32"}, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, cleanup := newIPFilteredApplication(test.allowed, test.denied, 0)
+			defer cleanup()
+			assert.Equal(t, test.want, healthStatus(t, app))
+		})
+	}
+}
+
+func TestIPFilter_ForwardedFor(t *testing.T) {
+	t.Parallel()
+	app, cleanup := newIPFilteredApplication(
+		[]string{"203.0.113.0/24", "2001:db8::/32"},
+		[]string{"203.0.113.66", "2001:db8:bad::/48"},
+		1,
+	)
+	defer cleanup()
+
+	assert.Equal(t, http.StatusOK, healthStatus(t, app, "203.0.113.5"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "203.0.113.66"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "198.51.100.1"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app), "the proxy itself is not allowed")
+
+	assert.Equal(t, http.StatusOK, healthStatus(t, app, "2001:db8::1"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "2001:db8:bad::1"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "2001:db9::1"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "not an ip"))
+
+	// Entries to the left of those added by trusted proxies come from the
+	// client, so cannot be used to pass as an allowed address.
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "203.0.113.5, 198.51.100.1"))
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "203.0.113.5", "198.51.100.1"))
+	assert.Equal(t, http.StatusOK, healthStatus(t, app, "198.51.100.1, 203.0.113.5"))
+}
+
+func TestIPFilter_ForwardedForIgnoredWithoutProxies(t *testing.T) {
+	t.Parallel()
+	app, cleanup := newIPFilteredApplication([]string{"203.0.113.0/24"}, []string{"198.51.100.1"}, 0)
+	defer cleanup()
+
+	assert.Equal(t, http.StatusForbidden, healthStatus(t, app, "203.0.113.5"))
+
+	app, cleanup = newIPFilteredApplication(nil, []string{"198.51.100.1"}, 0)
+	defer cleanup()
+	assert.Equal(t, http.StatusOK, healthStatus(t, app, "198.51.100.1"))
+}
+
+func TestIPFilter_InvalidRange(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	app.Store.Config.APIAllowedIPs = []string{"10.0.0.0/33"}
+	assert.Panics(t, func() { web.Router(app) })
+
+	app.Store.Config.APIAllowedIPs = nil
+	app.Store.Config.APIDeniedIPs = []string{"localhost"}
+	assert.Panics(t, func() { web.Router(app) })
+}
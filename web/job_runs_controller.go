@@ -13,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/orm"
 	"github.com/smartcontractkit/chainlink/store/presenters"
 	"github.com/smartcontractkit/chainlink/utils"
 )
@@ -25,6 +26,17 @@ type JobRunsController struct {
 // Index returns paginated JobRuns for a given JobSpec
 // Example:
 //  "<application>/runs?jobSpecId=:jobSpecId&size=1&page=2"
+//
+// @Summary List runs
+// @Tags runs
+// @Produce json
+// @Security SessionCookie
+// @Param jobSpecId query string false "Only list the runs of this job"
+// @Param size query int false "Number of records per page"
+// @Param page query int false "Page number, starting at 1"
+// @Param sort query string false "-createdAt for newest first" Enums(-createdAt)
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.JobRun}}
+// @Router /v2/runs [get]
 func (jrc *JobRunsController) Index(c *gin.Context) {
 	id := c.Query("jobSpecId")
 	size, page, offset, err := ParsePaginatedRequest(c.Query("size"), c.Query("page"))
@@ -75,6 +87,18 @@ func runsForJob(jobID string, store *store.Store, size int, offset int) (query s
 // Create starts a new Run for the requested JobSpec.
 // Example:
 //  "<application>/specs/:SpecID/runs"
+//
+// @Summary Run a job
+// @Tags runs
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param SpecID path string true "Job ID"
+// @Param data body object false "Input data for the run"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.JobRun}}
+// @Failure 403 "Job has no web initiator"
+// @Failure 404 "Job not found"
+// @Router /v2/specs/{SpecID}/runs [post]
 func (jrc *JobRunsController) Create(c *gin.Context) {
 	id := c.Param("SpecID")
 
@@ -103,9 +127,54 @@ func getRunData(c *gin.Context) (models.JSON, error) {
 	return models.ParseJSON(b)
 }
 
+// Review resolves a JobRun held for review by a task such as compare. An
+// approved run continues with its remaining tasks, and a rejected run
+// errors. Runs which are not held for review, including those already
+// resolved, are rejected with a 409.
+// Example:
+//  "<application>/runs/:RunID/review"
+//
+// @Summary Approve or reject a run pending review
+// @Tags runs
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param RunID path string true "Run ID"
+// @Param review body models.RunReviewRequest true "Whether the run is approved"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.JobRun}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 404 "Run not found"
+// @Failure 409 {object} models.JSONAPIErrors
+// @Router /v2/runs/{RunID}/review [patch]
+func (jrc *JobRunsController) Review(c *gin.Context) {
+	var request models.RunReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		publicError(c, http.StatusBadRequest, err)
+	} else if jr, err := services.ResolvePendingReviewRun(c.Param("RunID"), jrc.App.GetStore(), request.Approved); err == storm.ErrNotFound {
+		c.AbortWithError(404, errors.New("Job Run not found"))
+	} else if err == orm.ErrorNotPendingReview {
+		publicError(c, http.StatusConflict, err)
+	} else if err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(presenters.JobRun{JobRun: *jr}); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
 // Show returns the details of a JobRun.
 // Example:
 //  "<application>/runs/:RunID"
+//
+// @Summary Show a run
+// @Tags runs
+// @Produce json
+// @Security SessionCookie
+// @Param RunID path string true "Run ID"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.JobRun}}
+// @Failure 404 "Run not found"
+// @Router /v2/runs/{RunID} [get]
 func (jrc *JobRunsController) Show(c *gin.Context) {
 	id := c.Param("RunID")
 	if jr, err := jrc.App.GetStore().FindJobRun(id); err == storm.ErrNotFound {
@@ -120,27 +189,71 @@ func (jrc *JobRunsController) Show(c *gin.Context) {
 }
 
 // Update allows external adapters to resume a JobRun, reporting the result of
-// the task and marking it no longer pending.
+// the task and marking it no longer pending. The request must be made with
+// the incoming token of one of the run's bridges before anything about the
+// run is revealed, so unknown runs are also rejected with a 401. Callbacks
+// for a run which is not pending, including repeats of one which already
+// resumed it, are rejected with a 409.
 // Example:
 //  "<application>/runs/:RunID"
+//
+// @Summary Resume a run pending on a bridge
+// @Tags runs
+// @Accept json
+// @Produce json
+// @Security BridgeToken
+// @Param RunID path string true "Run ID"
+// @Param result body models.BridgeRunResult true "Result of the bridge's task"
+// @Success 200 {object} presenters.ResourceID
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 401 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Router /v2/runs/{RunID} [patch]
 func (jrc *JobRunsController) Update(c *gin.Context) {
 	id := c.Param("RunID")
+	token := utils.StripBearer(c.Request.Header.Get("Authorization"))
+	str := jrc.App.GetStore()
 	var brr models.BridgeRunResult
-	if jr, err := jrc.App.GetStore().FindJobRun(id); err == storm.ErrNotFound {
-		c.AbortWithError(404, errors.New("Job Run not found"))
+	if jr, err := str.FindJobRun(id); err == storm.ErrNotFound {
+		publicError(c, http.StatusUnauthorized, errIncorrectBridgeToken)
 	} else if err != nil {
 		c.AbortWithError(500, err)
-	} else if !jr.Result.Status.PendingBridge() {
-		c.AbortWithError(405, errors.New("Cannot resume a job run that isn't pending"))
-	} else if err := c.ShouldBindJSON(&brr); err != nil {
+	} else if ok, err := bridgeAuthenticated(str, jr, token); err != nil {
 		c.AbortWithError(500, err)
-	} else if bt, err := jrc.App.GetStore().PendingBridgeType(jr); err != nil {
+	} else if !ok {
+		publicError(c, http.StatusUnauthorized, errIncorrectBridgeToken)
+	} else if !jr.Status.PendingBridge() {
+		publicError(c, http.StatusConflict, orm.ErrorNotPendingBridge)
+	} else if bt, err := str.PendingBridgeType(jr); err != nil {
 		c.AbortWithError(500, err)
-	} else if _, err := bt.Authenticate(utils.StripBearer(c.Request.Header.Get("Authorization"))); err != nil {
+	} else if _, err := bt.Authenticate(token); err != nil {
 		publicError(c, http.StatusUnauthorized, err)
-	} else if _, err = services.ResumePendingTask(&jr, jrc.App.GetStore(), brr.RunResult); err != nil {
+	} else if err := c.ShouldBindJSON(&brr); err != nil {
+		publicError(c, http.StatusBadRequest, err)
+	} else if _, err = services.ResumePendingBridgeRun(jr.ID, str, brr.RunResult); err == orm.ErrorNotPendingBridge {
+		publicError(c, http.StatusConflict, err)
+	} else if err != nil {
 		c.AbortWithError(500, err)
 	} else {
-		c.JSON(200, gin.H{"id": jr.ID})
+		c.JSON(200, presenters.ResourceID{ID: jr.ID})
+	}
+}
+
+var errIncorrectBridgeToken = errors.New("Incorrect access token for the run's bridges")
+
+// bridgeAuthenticated returns whether token is the incoming token of a
+// bridge used by one of the run's tasks.
+func bridgeAuthenticated(str *store.Store, jr models.JobRun, token string) (bool, error) {
+	for _, tr := range jr.TaskRuns {
+		bt, err := str.FindBridge(tr.Task.Type.String())
+		if err == storm.ErrNotFound {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if ok, _ := bt.Authenticate(token); ok {
+			return true, nil
+		}
 	}
+	return false, nil
 }
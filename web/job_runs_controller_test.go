@@ -269,10 +269,49 @@ func TestJobRunsController_Update_NotPending(t *testing.T) {
 	assert.Nil(t, app.Store.Save(&jr))
 
 	body := fmt.Sprintf(`{"id":"%v","data":{"value": "100"}}`, jr.ID)
-	headers := map[string]string{"Authorization": "Bearer " + bt.IncomingToken}
+	headers := map[string]string{"Authorization": "Bearer " + "wrongaccesstoken"}
 	resp, cleanup := client.Patch("/v2/runs/"+jr.ID, bytes.NewBufferString(body), headers)
 	defer cleanup()
-	assert.Equal(t, 405, resp.StatusCode, "Response should be unsuccessful")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "The run's state is not revealed without its bridge's token")
+
+	headers = map[string]string{"Authorization": "Bearer " + bt.IncomingToken}
+	resp, cleanup = client.Patch("/v2/runs/"+jr.ID, bytes.NewBufferString(body), headers)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "Response should be unsuccessful")
+}
+
+func TestJobRunsController_Update_DuplicateCallback(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	app.Start()
+	defer cleanup()
+
+	bt := cltest.NewBridgeType()
+	assert.Nil(t, app.Store.Save(&bt))
+	j, initr := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{{Type: bt.Name}, cltest.NewTask("noop")}
+	assert.Nil(t, app.Store.Save(&j))
+	jr := cltest.MarkJobRunPendingBridge(j.NewRun(initr), 0)
+	assert.Nil(t, app.Store.Save(&jr))
+
+	headers := map[string]string{"Authorization": "Bearer " + bt.IncomingToken}
+	url := app.Config.ClientNodeURL + "/v2/runs/" + jr.ID
+	first := fmt.Sprintf(`{"id":"%v","data":{"value": "100"}}`, jr.ID)
+	resp, cleanup := cltest.UnauthenticatedPatch(url, bytes.NewBufferString(first), headers)
+	defer cleanup()
+	assert.Equal(t, 200, resp.StatusCode, "Response should be successful")
+	jr = cltest.WaitForJobRunToComplete(t, app.Store, jr)
+
+	late := fmt.Sprintf(`{"id":"%v","data":{"value": "200"}}`, jr.ID)
+	resp, cleanup = cltest.UnauthenticatedPatch(url, bytes.NewBufferString(late), headers)
+	defer cleanup()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "Response should be a conflict")
+
+	assert.Nil(t, app.Store.One("ID", jr.ID, &jr))
+	assert.Equal(t, models.RunStatusCompleted, jr.Status)
+	val, err := jr.Result.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "100", val)
 }
 
 func TestJobRunsController_Update_WithError(t *testing.T) {
@@ -349,9 +388,10 @@ func TestJobRunsController_Update_BadInput(t *testing.T) {
 	assert.Nil(t, app.Store.Save(&jr))
 
 	body := fmt.Sprint(`{`, jr.ID)
-	resp, cleanup := client.Patch("/v2/runs/"+jr.ID, bytes.NewBufferString(body))
+	headers := map[string]string{"Authorization": "Bearer " + bt.IncomingToken}
+	resp, cleanup := client.Patch("/v2/runs/"+jr.ID, bytes.NewBufferString(body), headers)
 	defer cleanup()
-	assert.Equal(t, 500, resp.StatusCode, "Response should be successful")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Response should be a bad request")
 	assert.Nil(t, app.Store.One("ID", jr.ID, &jr))
 	assert.Equal(t, models.RunStatusPendingBridge, jr.Status)
 }
@@ -371,14 +411,81 @@ func TestJobRunsController_Update_NotFound(t *testing.T) {
 	jr := cltest.MarkJobRunPendingBridge(j.NewRun(initr), 0)
 	assert.Nil(t, app.Store.Save(&jr))
 
+	// Whether a run exists is not revealed without a token for its bridges.
 	body := fmt.Sprintf(`{"id":"%v","data":{"value": "100"}}`, jr.ID)
-	resp, cleanup := client.Patch("/v2/runs/"+jr.ID+"1", bytes.NewBufferString(body))
+	headers := map[string]string{"Authorization": "Bearer " + bt.IncomingToken}
+	resp, cleanup := client.Patch("/v2/runs/"+jr.ID+"1", bytes.NewBufferString(body), headers)
 	defer cleanup()
-	assert.Equal(t, 404, resp.StatusCode, "Response should be successful")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Response should be unauthorized")
 	assert.Nil(t, app.Store.One("ID", jr.ID, &jr))
 	assert.Equal(t, models.RunStatusPendingBridge, jr.Status)
 }
 
+func TestJobRunsController_Review(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		approved   bool
+		wantStatus models.RunStatus
+	}{
+		{"approved", true, models.RunStatusCompleted},
+		{"rejected", false, models.RunStatusErrored},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			app, cleanup := cltest.NewApplication()
+			app.Start()
+			defer cleanup()
+			client := app.NewHTTPClient()
+
+			j, _ := cltest.NewJobWithWebInitiator()
+			j.Tasks = []models.TaskSpec{
+				cltest.NewTask("compare", `{"operator":"lt","threshold":100}`),
+				cltest.NewTask("noop"),
+			}
+			assert.Nil(t, app.Store.SaveJob(&j))
+			jr := cltest.CreateJobRunViaWeb(t, app, j, `{"value":"212.54"}`)
+			jr = cltest.WaitForJobRunStatus(t, app.Store, jr, models.RunStatusPendingReview)
+
+			body := fmt.Sprintf(`{"approved":%v}`, test.approved)
+			resp, cleanup := client.Patch("/v2/runs/"+jr.ID+"/review", bytes.NewBufferString(body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, 200)
+
+			jr = cltest.WaitForJobRunStatus(t, app.Store, jr, test.wantStatus)
+			assert.Equal(t, test.wantStatus, jr.TaskRuns[0].Status)
+			if test.approved {
+				assert.Equal(t, models.RunStatusCompleted, jr.TaskRuns[1].Status)
+				val, err := jr.Result.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, "212.54", val)
+			} else {
+				assert.Equal(t, models.RunStatusUnstarted, jr.TaskRuns[1].Status)
+			}
+
+			resp, cleanup = client.Patch("/v2/runs/"+jr.ID+"/review", bytes.NewBufferString(body))
+			defer cleanup()
+			assert.Equal(t, http.StatusConflict, resp.StatusCode, "A resolved run cannot be reviewed again")
+		})
+	}
+}
+
+func TestJobRunsController_Review_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	app.Start()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Patch("/v2/runs/nope/review", bytes.NewBufferString(`{"approved":true}`))
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Response should be not found")
+}
+
 func TestJobRunsController_Show_Found(t *testing.T) {
 	t.Parallel()
 
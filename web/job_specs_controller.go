@@ -21,6 +21,17 @@ type JobSpecsController struct {
 // Index lists JobSpecs, one page at a time.
 // Example:
 //  "<application>/specs?size=1&page=2"
+//
+// @Summary List jobs
+// @Tags jobs
+// @Produce json
+// @Security SessionCookie
+// @Param size query int false "Number of records per page"
+// @Param page query int false "Page number, starting at 1"
+// @Param sort query string false "-createdAt for newest first" Enums(-createdAt)
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.JobSpec}}
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/specs [get]
 func (jsc *JobSpecsController) Index(c *gin.Context) {
 	size, page, offset, err := ParsePaginatedRequest(c.Query("size"), c.Query("page"))
 	if err != nil {
@@ -61,10 +72,22 @@ func (jsc *JobSpecsController) Index(c *gin.Context) {
 // Create adds validates, saves, and starts a new JobSpec.
 // Example:
 //  "<application>/specs"
+//
+// @Summary Create a job
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param spec body models.JobSpec true "Job spec"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.JobSpec}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v2/specs [post]
 func (jsc *JobSpecsController) Create(c *gin.Context) {
 	js := models.NewJob()
 	if err := c.ShouldBindJSON(&js); err != nil {
 		publicError(c, 400, err)
+	} else if err := services.ResolveENSNames(c.Request.Context(), &js, jsc.App.GetStore()); err != nil {
+		publicError(c, 400, err)
 	} else if err := services.ValidateJob(js, jsc.App.GetStore()); err != nil {
 		publicError(c, 400, err)
 	} else if err = jsc.App.AddJob(js); err != nil {
@@ -79,6 +102,15 @@ func (jsc *JobSpecsController) Create(c *gin.Context) {
 // Show returns the details of a JobSpec.
 // Example:
 //  "<application>/specs/:SpecID"
+//
+// @Summary Show a job and its runs
+// @Tags jobs
+// @Produce json
+// @Security SessionCookie
+// @Param SpecID path string true "Job ID"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.JobSpec}}
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/specs/{SpecID} [get]
 func (jsc *JobSpecsController) Show(c *gin.Context) {
 	id := c.Param("SpecID")
 	if j, err := jsc.App.GetStore().FindJob(id); err == storm.ErrNotFound {
@@ -94,6 +126,79 @@ func (jsc *JobSpecsController) Show(c *gin.Context) {
 	}
 }
 
+// Export returns a JobSpec as a document which Import can recreate on
+// another node.
+// Example:
+//  "<application>/jobs/:SpecID/export"
+//
+// @Summary Export a job
+// @Tags jobs
+// @Produce json
+// @Security SessionCookie
+// @Param SpecID path string true "Job ID"
+// @Success 200 {object} models.JobSpecExport
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/jobs/{SpecID}/export [get]
+func (jsc *JobSpecsController) Export(c *gin.Context) {
+	id := c.Param("SpecID")
+	if j, err := jsc.App.GetStore().FindJob(id); err == storm.ErrNotFound {
+		publicError(c, 404, errors.New("JobSpec not found"))
+	} else if err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.JSON(200, j.Export())
+	}
+}
+
+// Import validates, saves, and starts a JobSpec from a document returned by
+// Export. The job keeps its original ID if the preserveID query param is
+// "true", in which case importing over an existing job is a conflict.
+// Example:
+//  "<application>/jobs/import?preserveID=true"
+//
+// @Summary Import an exported job
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param export body models.JobSpecExport true "Exported job"
+// @Param preserveID query bool false "Keep the exported job's ID"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.JobSpec}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Router /v2/jobs/import [post]
+func (jsc *JobSpecsController) Import(c *gin.Context) {
+	store := jsc.App.GetStore()
+	preserveID := c.Query("preserveID") == "true"
+
+	var export models.JobSpecExport
+	if err := c.ShouldBindJSON(&export); err != nil {
+		publicError(c, 400, err)
+		return
+	}
+	if preserveID && export.ID == "" {
+		publicError(c, 400, errors.New("Cannot preserve the ID of a job without one"))
+		return
+	}
+
+	js := export.ToJobSpec(preserveID)
+	if _, err := store.FindJob(js.ID); err == nil {
+		publicError(c, 409, fmt.Errorf("JobSpec %s already exists", js.ID))
+	} else if err != storm.ErrNotFound {
+		c.AbortWithError(500, err)
+	} else if err := services.ResolveENSNames(c.Request.Context(), &js, store); err != nil {
+		publicError(c, 400, err)
+	} else if err := services.ValidateJob(js, store); err != nil {
+		publicError(c, 400, err)
+	} else if err = jsc.App.AddJob(js); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(presenters.JobSpec{JobSpec: js, Runs: []presenters.JobRun{}}); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
 func marshalSpecFromJSONAPI(j models.JobSpec, runs []models.JobRun) (*jsonapi.Document, error) {
 	pruns := make([]presenters.JobRun, len(runs))
 	for i, r := range runs {
@@ -2,11 +2,15 @@ package web_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/smartcontractkit/chainlink/adapters"
 	"github.com/smartcontractkit/chainlink/internal/cltest"
@@ -188,6 +192,33 @@ func TestJobSpecsController_Create(t *testing.T) {
 	assert.NotEqual(t, models.Time{}, j.CreatedAt)
 }
 
+func TestJobSpecsController_Create_ENSName(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	ethMock := app.MockEthClient()
+
+	oracle := cltest.NewAddress()
+	ethMock.Register("eth_call", hexutil.Bytes(common.LeftPadBytes(cltest.NewAddress().Bytes(), 32)))
+	ethMock.Register("eth_call", hexutil.Bytes(common.LeftPadBytes(oracle.Bytes(), 32)))
+
+	body := `{"initiators":[{"type":"web"}],"tasks":[{"type":"ethtx","params":{"address":"oracle.eth","functionSelector":"0x609ff1bd"}}]}`
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBufferString(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	ethMock.EventuallyAllCalled(t)
+
+	var j models.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(resp, &j))
+	j, err := app.Store.FindJob(j.ID)
+	require.NoError(t, err)
+	adapter, err := adapters.For(j.Tasks[0], app.Store)
+	require.NoError(t, err)
+	assert.Equal(t, oracle, adapter.BaseAdapter.(*adapters.EthTx).Address)
+}
+
 func TestJobSpecsController_Create_CaseInsensitiveTypes(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication()
@@ -226,7 +257,9 @@ func TestJobSpecsController_Create_NonExistentTaskJob(t *testing.T) {
 
 	assert.Equal(t, 400, resp.StatusCode, "Response should be caller error")
 
-	expected := `{"errors":[{"detail":"idonotexist is not a supported adapter type"}]}`
+	expected := fmt.Sprintf(
+		`{"errors":[{"detail":"idonotexist is not a supported adapter type, expected a bridge or one of: %s"}]}`,
+		strings.Join(adapters.RegisteredTypes(), ", "))
 	assert.Equal(t, expected, string(cltest.ParseResponseBody(resp)))
 }
 
@@ -246,6 +279,22 @@ func TestJobSpecsController_Create_InvalidJob(t *testing.T) {
 	assert.Equal(t, expected, string(cltest.ParseResponseBody(resp)))
 }
 
+func TestJobSpecsController_Create_UnknownParam(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	jsonStr := cltest.LoadJSON("../internal/fixtures/web/unknown_param_job.json")
+	resp, cleanup := client.Post("/v2/specs", bytes.NewBuffer(jsonStr))
+	defer cleanup()
+
+	assert.Equal(t, 400, resp.StatusCode, "Response should be caller error")
+
+	expected := `{"errors":[{"detail":"Task 1: multiply has no param named \"tims\""}]}`
+	assert.Equal(t, expected, string(cltest.ParseResponseBody(resp)))
+}
+
 func TestJobSpecsController_Create_InvalidCron(t *testing.T) {
 	t.Parallel()
 	app, cleanup := cltest.NewApplication()
@@ -365,3 +414,107 @@ func TestJobSpecsController_Show_Unauthenticated(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 401, resp.StatusCode, "Response should be forbidden")
 }
+
+func exportJobSpec(t *testing.T, app *cltest.TestApplication, id string) []byte {
+	client := app.NewHTTPClient()
+	resp, cleanup := client.Get("/v2/jobs/" + id + "/export")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	return cltest.ParseResponseBody(resp)
+}
+
+func TestJobSpecsController_ExportImport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	source, cleanup := cltest.NewApplication()
+	defer cleanup()
+	original := cltest.FixtureCreateJobViaWeb(t, source, "../internal/fixtures/web/hello_world_job.json")
+	document := exportJobSpec(t, source, original.ID)
+
+	var export models.JobSpecExport
+	require.NoError(t, json.Unmarshal(document, &export))
+	assert.Equal(t, original.ID, export.ID)
+	assert.Zero(t, export.Initiators[0].ID)
+
+	destination, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := destination.NewHTTPClient()
+	resp, cleanup := client.Post("/v2/jobs/import?preserveID=true", bytes.NewBuffer(document))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	imported, err := destination.Store.FindJob(original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, original.CreatedAt.Unix(), imported.CreatedAt.Unix())
+	require.Len(t, imported.Initiators, len(original.Initiators))
+	assert.Equal(t, original.Initiators[0].Type, imported.Initiators[0].Type)
+	assert.Equal(t, original.ID, imported.Initiators[0].JobID)
+	require.Len(t, imported.Tasks, len(original.Tasks))
+	for i, task := range original.Tasks {
+		assert.Equal(t, task.Type, imported.Tasks[i].Type)
+		assert.JSONEq(t, task.Params.String(), imported.Tasks[i].Params.String())
+	}
+
+	assert.JSONEq(t, string(document), string(exportJobSpec(t, destination, original.ID)))
+}
+
+func TestJobSpecsController_Import_Conflict(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	j := cltest.FixtureCreateJobViaWeb(t, app, "../internal/fixtures/web/hello_world_job.json")
+	document := exportJobSpec(t, app, j.ID)
+
+	resp, cleanup := client.Post("/v2/jobs/import?preserveID=true", bytes.NewBuffer(document))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 409)
+
+	resp, cleanup = client.Post("/v2/jobs/import", bytes.NewBuffer(document))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	var copied presenters.JobSpec
+	require.NoError(t, cltest.ParseJSONAPIResponse(resp, &copied))
+	assert.NotEqual(t, j.ID, copied.ID)
+
+	count, err := app.Store.Count(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestJobSpecsController_Import_Invalid(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"malformed", "/v2/jobs/import", `{"id":`},
+		{"unknown task", "/v2/jobs/import", `{"id":"abc","initiators":[{"type":"web"}],"tasks":[{"type":"idonotexist"}]}`},
+		{"preserve without id", "/v2/jobs/import?preserveID=true", `{"initiators":[{"type":"web"}],"tasks":[{"type":"noop"}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := client.Post(test.path, bytes.NewBufferString(test.body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, 400)
+		})
+	}
+}
+
+func TestJobSpecsController_Export_NotFound(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/jobs/garbage/export")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 404)
+}
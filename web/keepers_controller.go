@@ -0,0 +1,105 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/asdine/storm"
+	"github.com/gin-gonic/gin"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// KeepersController manages the upkeeps which the node checks and performs
+// as a keeper of their KeeperRegistry contracts.
+type KeepersController struct {
+	App services.Application
+}
+
+// Index lists the upkeeps, oldest first.
+// Example:
+//  "<application>/v2/keepers"
+//
+// @Summary List upkeeps
+// @Tags keepers
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=models.Upkeep}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Router /v2/keepers [get]
+func (kc *KeepersController) Index(c *gin.Context) {
+	upkeeps, err := kc.App.GetStore().Upkeeps()
+	if err != nil {
+		c.AbortWithError(500, fmt.Errorf("error fetching upkeeps: %+v", err))
+	} else if doc, err := jsonapi.Marshal(upkeeps); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
+// Create adds an upkeep registered with a KeeperRegistry, which is checked
+// from the next head.
+// Example:
+//  "<application>/v2/keepers"
+//
+// @Summary Add an upkeep
+// @Tags keepers
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param upkeep body models.UpkeepRequest true "Registry and the upkeep's ID in it"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=models.Upkeep}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 403 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/keepers [post]
+func (kc *KeepersController) Create(c *gin.Context) {
+	var request models.UpkeepRequest
+	store := kc.App.GetStore()
+	if err := c.ShouldBindJSON(&request); err != nil {
+		publicError(c, 422, err)
+	} else if upkeep, err := models.NewUpkeep(request); err != nil {
+		publicError(c, 400, err)
+	} else if _, err = store.FindUpkeepFor(upkeep.RegistryAddress, upkeep.UpkeepID); err == nil {
+		publicError(c, 409, fmt.Errorf("upkeep %d of registry %s already exists", upkeep.UpkeepID, upkeep.RegistryAddress.Hex()))
+	} else if err != storm.ErrNotFound {
+		c.AbortWithError(500, err)
+	} else if err = store.Save(&upkeep); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(upkeep); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
+// Destroy removes an upkeep, which is no longer checked.
+// Example:
+//  "<application>/v2/keepers/:ID"
+//
+// @Summary Remove an upkeep
+// @Tags keepers
+// @Produce json
+// @Security SessionCookie
+// @Param ID path string true "Upkeep ID"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=models.Upkeep}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/keepers/{ID} [delete]
+func (kc *KeepersController) Destroy(c *gin.Context) {
+	store := kc.App.GetStore()
+	if upkeep, err := store.FindUpkeep(c.Param("ID")); err == storm.ErrNotFound {
+		publicError(c, 404, errors.New("upkeep not found"))
+	} else if err != nil {
+		c.AbortWithError(500, err)
+	} else if err = store.DeleteStruct(&upkeep); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(upkeep); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
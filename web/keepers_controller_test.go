@@ -0,0 +1,69 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepersController_CreateIndexDestroy(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	registry := cltest.NewAddress()
+	body := fmt.Sprintf(`{"registryAddress":"%s","upkeepID":7}`, registry.Hex())
+
+	resp, done := client.Post("/v2/keepers", bytes.NewBufferString(body))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var upkeep models.Upkeep
+	require.NoError(t, jsonapi.Unmarshal(b, &upkeep))
+	assert.NotEmpty(t, upkeep.ID)
+	assert.Equal(t, registry, upkeep.RegistryAddress)
+	assert.Equal(t, uint64(7), upkeep.UpkeepID)
+
+	resp, done = client.Post("/v2/keepers", bytes.NewBufferString(body))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 409)
+	resp, done = client.Post("/v2/keepers", bytes.NewBufferString(`{"upkeepID":7}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 400)
+
+	resp, done = client.Get("/v2/keepers")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	b, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var upkeeps []models.Upkeep
+	require.NoError(t, jsonapi.Unmarshal(b, &upkeeps))
+	require.Len(t, upkeeps, 1)
+	assert.Equal(t, upkeep.ID, upkeeps[0].ID)
+
+	resp, done = client.Delete("/v2/keepers/" + upkeep.ID)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	_, err = app.Store.FindUpkeep(upkeep.ID)
+	assert.Error(t, err)
+
+	resp, done = client.Delete("/v2/keepers/" + upkeep.ID)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 404)
+
+	logs, _ := fetchAuditLogs(t, client, "?resource_type=upkeep")
+	require.Len(t, logs, 2)
+	actions := []string{logs[0].Action, logs[1].Action}
+	assert.ElementsMatch(t, []string{"create", "delete"}, actions)
+	for _, entry := range logs {
+		assert.Equal(t, upkeep.ID, entry.ResourceID)
+	}
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevelController shows and changes the level of the node's logger
+type LogLevelController struct {
+	App services.Application
+}
+
+// Show returns the current log level
+// Example:
+//  "<application>/loglevel"
+//
+// @Summary Show the log level
+// @Tags logs
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} models.LogLevelRequest
+// @Router /v2/loglevel [get]
+func (llc *LogLevelController) Show(c *gin.Context) {
+	c.JSON(200, models.LogLevelRequest{Level: logger.GetLogLevel().String()})
+}
+
+// Update changes the log level without restarting the node
+// Example:
+//  "<application>/loglevel"
+//
+// @Summary Change the log level
+// @Tags logs
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param level body models.LogLevelRequest true "Level"
+// @Success 200 {object} models.LogLevelRequest
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v2/loglevel [put]
+func (llc *LogLevelController) Update(c *gin.Context) {
+	var request models.LogLevelRequest
+	var lvl zapcore.Level
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		publicError(c, 400, err)
+	} else if request.Level == "" {
+		publicError(c, 400, errors.New("Must specify a level"))
+	} else if err := lvl.UnmarshalText([]byte(request.Level)); err != nil {
+		publicError(c, 400, fmt.Errorf("Invalid log level %q", request.Level))
+	} else {
+		logger.SetLogLevel(lvl)
+		logger.Infow("Log level changed", "level", lvl.String())
+		c.JSON(200, models.LogLevelRequest{Level: lvl.String()})
+	}
+}
@@ -0,0 +1,72 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogLevelController_Show(t *testing.T) {
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/loglevel")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	var body models.LogLevelRequest
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(resp), &body))
+	assert.Equal(t, logger.GetLogLevel().String(), body.Level)
+}
+
+func TestLogLevelController_Update(t *testing.T) {
+	defer logger.SetLogLevel(logger.GetLogLevel())
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Put("/v2/loglevel", bytes.NewBufferString(`{"level":"warn"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	var body models.LogLevelRequest
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(resp), &body))
+	assert.Equal(t, "warn", body.Level)
+	assert.Equal(t, zapcore.WarnLevel, logger.GetLogLevel())
+}
+
+func TestLogLevelController_Update_Invalid(t *testing.T) {
+	defer logger.SetLogLevel(logger.GetLogLevel())
+	logger.SetLogLevel(zapcore.DebugLevel)
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"unknown level", `{"level":"verbose"}`},
+		{"missing level", `{}`},
+		{"malformed", `{"level":`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := client.Put("/v2/loglevel", bytes.NewBufferString(test.body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, 400)
+			assert.Equal(t, zapcore.DebugLevel, logger.GetLogLevel())
+		})
+	}
+}
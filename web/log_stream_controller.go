@@ -0,0 +1,91 @@
+package web
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/services"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogStreamController streams the node's logs as server-sent events.
+type LogStreamController struct {
+	App services.Application
+}
+
+// Stream sends each log line as the data of an event, starting with the
+// most recent lines kept by the logger. Only lines at or above level, and
+// for the job with job_id, are sent when those are given. A client
+// reconnecting with a Last-Event-ID header catches up from that event.
+// Example:
+//  "<application>/logs/stream?level=warn&job_id=:jobID"
+//
+// @Summary Stream log lines as server-sent events
+// @Tags logs
+// @Produce text/event-stream
+// @Security SessionCookie
+// @Param level query string false "Lowest level to send" Enums(debug, info, warn, error)
+// @Param job_id query string false "Only send lines for this job"
+// @Param Last-Event-ID header int false "Resume after this event"
+// @Success 200 {string} string "Events whose data is a JSON log line"
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v2/logs/stream [get]
+func (lsc *LogStreamController) Stream(c *gin.Context) {
+	minLevel := zapcore.DebugLevel
+	if lvl := c.Query("level"); lvl != "" {
+		if err := minLevel.UnmarshalText([]byte(lvl)); err != nil {
+			publicError(c, 400, fmt.Errorf("Invalid log level %q", lvl))
+			return
+		}
+	}
+	jobID := c.Query("job_id")
+
+	var lastID uint64
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			publicError(c, 400, fmt.Errorf("Invalid Last-Event-ID %q", header))
+			return
+		}
+		lastID = id
+	}
+
+	backlog, lines, unsubscribe := logger.Subscribe(lastID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(200)
+
+	send := func(line logger.Line) error {
+		if line.Level < minLevel || (jobID != "" && line.JobID != jobID) {
+			return nil
+		}
+		_, err := fmt.Fprintf(c.Writer, "id: %d\ndata: %s\n\n", line.ID, line.JSON)
+		return err
+	}
+	for _, line := range backlog {
+		if err := send(line); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			if err := send(line); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package web_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type sseEvent struct {
+	ID   string
+	Data map[string]interface{}
+}
+
+// readEvents reads server-sent events from resp on a goroutine, so that
+// tests can wait on them with a timeout.
+func readEvents(t *testing.T, resp *http.Response) <-chan sseEvent {
+	events := make(chan sseEvent, 100)
+	go func() {
+		defer close(events)
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				events <- event
+				event = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				event.Data = map[string]interface{}{}
+				assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.Data))
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return sseEvent{}
+}
+
+func TestLogStreamController_Stream(t *testing.T) {
+	defer logger.SetLogLevel(logger.GetLogLevel())
+	logger.SetLogLevel(zapcore.DebugLevel)
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	jobID := utils.NewBytes32ID()
+	logger.Infow("Before connecting", "job_id", jobID)
+
+	resp, cleanup := client.Get("/v2/logs/stream?job_id=" + jobID)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	events := readEvents(t, resp)
+
+	event := nextEvent(t, events)
+	assert.Equal(t, "Before connecting", event.Data["msg"])
+	assert.Equal(t, jobID, event.Data["job_id"])
+
+	logger.Infow("Another job", "job_id", utils.NewBytes32ID())
+	logger.Infow("After connecting", "job_id", jobID)
+
+	event = nextEvent(t, events)
+	assert.Equal(t, "After connecting", event.Data["msg"])
+	assert.Equal(t, "info", event.Data["level"])
+}
+
+func TestLogStreamController_Stream_Level(t *testing.T) {
+	defer logger.SetLogLevel(logger.GetLogLevel())
+	logger.SetLogLevel(zapcore.DebugLevel)
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	jobID := utils.NewBytes32ID()
+	resp, cleanup := client.Get("/v2/logs/stream?level=warn&job_id=" + jobID)
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	events := readEvents(t, resp)
+
+	logger.Infow("Not sent", "job_id", jobID)
+	logger.Warnw("Sent", "job_id", jobID)
+
+	event := nextEvent(t, events)
+	assert.Equal(t, "Sent", event.Data["msg"])
+	assert.Equal(t, "warn", event.Data["level"])
+}
+
+func TestLogStreamController_Stream_Reconnect(t *testing.T) {
+	defer logger.SetLogLevel(logger.GetLogLevel())
+	logger.SetLogLevel(zapcore.DebugLevel)
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	jobID := utils.NewBytes32ID()
+	resp, cleanupFirst := client.Get("/v2/logs/stream?job_id=" + jobID)
+	cltest.AssertServerResponse(t, resp, 200)
+	events := readEvents(t, resp)
+
+	logger.Infow("First", "job_id", jobID)
+	first := nextEvent(t, events)
+	assert.Equal(t, "First", first.Data["msg"])
+	cleanupFirst()
+
+	logger.Infow("Missed", "job_id", jobID)
+
+	resp, cleanup = client.Get("/v2/logs/stream?job_id="+jobID, map[string]string{"Last-Event-ID": first.ID})
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 200)
+	events = readEvents(t, resp)
+
+	event := nextEvent(t, events)
+	assert.Equal(t, "Missed", event.Data["msg"])
+}
+
+func TestLogStreamController_Stream_InvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/logs/stream?level=loud")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, 400)
+}
@@ -0,0 +1,119 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gobuffalo/packr"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// The annotations below, together with those on each controller's handlers,
+// are read by swag to generate docs/openapi.json. Run `make openapi` after
+// changing them.
+//
+// @title Chainlink Node API
+// @version 2.0
+// @description The API for managing the jobs, runs, bridges and
+// @description configuration of a Chainlink node.
+// @BasePath /
+//
+// @securityDefinitions.apikey SessionCookie
+// @in header
+// @name Cookie
+// @description The clsession cookie set by POST /sessions.
+//
+// @securityDefinitions.apikey BridgeToken
+// @in header
+// @name Authorization
+// @description "Bearer " followed by the bridge's incoming token.
+//
+// @securityDefinitions.apikey APIKey
+// @in header
+// @name X-API-Key
+// @description A key from POST /v2/keys, accepted wherever SessionCookie is.
+
+// openAPIBox holds the generated spec, and is packed into the binary along
+// with the GUI's assets.
+var openAPIBox = packr.NewBox("../docs")
+
+// openAPIFile is the generated spec's name within openAPIBox.
+const openAPIFile = "openapi.json"
+
+// JSONAPIDocument is the shape of the JSON:API documents which many of the
+// handlers respond with, so that the spec can describe them.
+type JSONAPIDocument struct {
+	Data  interface{}            `json:"data"`
+	Links map[string]string      `json:"links,omitempty"`
+	Meta  map[string]interface{} `json:"meta,omitempty"`
+}
+
+// JSONAPIResource is the shape of a single resource in a JSONAPIDocument.
+type JSONAPIResource struct {
+	Type       string      `json:"type"`
+	ID         string      `json:"id"`
+	Attributes interface{} `json:"attributes"`
+}
+
+// ServiceAgreementRequest is the shape of the body of a request to create a
+// service agreement, which is read as both of these at once.
+type ServiceAgreementRequest struct {
+	models.JobSpecRequest
+	models.Encumbrance
+}
+
+// OpenAPIController describes the node's API.
+type OpenAPIController struct {
+	App services.Application
+}
+
+// Show returns the OpenAPI 3.0 spec of the API
+// Example:
+//  "<application>/v2/openapi.json"
+//
+// @Summary Show the OpenAPI spec of this API
+// @Tags docs
+// @Produce json
+// @Success 200 {object} object "OpenAPI 3.0 spec"
+// @Router /v2/openapi.json [get]
+func (oc *OpenAPIController) Show(c *gin.Context) {
+	spec, err := openAPIBox.MustBytes(openAPIFile)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", spec)
+}
+
+// UI returns a Swagger UI page for browsing and trying out the API
+// Example:
+//  "<application>/docs"
+//
+// @Summary Browse the API with Swagger UI
+// @Tags docs
+// @Produce html
+// @Success 200 {string} string "HTML page"
+// @Router /docs [get]
+func (oc *OpenAPIController) UI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Chainlink Node API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@3/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@3/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      SwaggerUIBundle({ url: "/v2/openapi.json", dom_id: "#swagger-ui" })
+    }
+  </script>
+</body>
+</html>
+`
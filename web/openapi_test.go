@@ -0,0 +1,72 @@
+package web_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIController_Show(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	resp, err := http.Get(app.Config.ClientNodeURL + "/v2/openapi.json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	spec, err := openapi3.NewLoader().LoadFromData(body)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+	assert.True(t, strings.HasPrefix(spec.OpenAPI, "3.0"), "unexpected version %s", spec.OpenAPI)
+}
+
+// The spec is generated from annotations on the handlers, which are easy to
+// forget when adding a route.
+func TestOpenAPI_DocumentsEveryRoute(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	spec, err := openapi3.NewLoader().LoadFromFile("../docs/openapi.json")
+	require.NoError(t, err)
+
+	undocumented := map[string]bool{"/debug/vars": true, "/metrics": true}
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, route := range web.Router(app).Routes() {
+		if undocumented[route.Path] {
+			continue
+		}
+		path := param.ReplaceAllString(route.Path, "{$1}")
+		item := spec.Paths.Find(path)
+		if assert.NotNil(t, item, "%s is not documented", path) {
+			assert.NotNil(t, item.GetOperation(route.Method), "%s %s is not documented", route.Method, path)
+		}
+	}
+}
+
+func TestOpenAPIController_UI(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	resp, err := http.Get(app.Config.ClientNodeURL + "/docs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "/v2/openapi.json")
+}
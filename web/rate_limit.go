@@ -0,0 +1,137 @@
+package web
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/store"
+	"golang.org/x/time/rate"
+)
+
+// maxLimiters is how many clients' buckets are kept. Once there are as
+// many, those which have refilled are forgotten, and failing that the one
+// seen least recently.
+const maxLimiters = 10000
+
+// rateLimiter holds a token bucket for each client IP, and for each API key,
+// refilled at the configured APIRateLimit. Clients are identified by their
+// IP as seen by the outermost of the APIForwardedDepth proxies, as for
+// APIAllowedIPs, so that X-Forwarded-For entries sent by the client itself
+// cannot give it a new bucket.
+type rateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mutex    sync.Mutex
+	byClient map[string]*clientLimiter
+}
+
+type clientLimiter struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter(config store.Config) *rateLimiter {
+	burst := config.APIBurstLimit
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		limit:    rate.Limit(config.APIRateLimit),
+		burst:    burst,
+		byClient: map[string]*clientLimiter{},
+	}
+}
+
+// rateLimitFunc responds 429 Too Many Requests, with a Retry-After header,
+// to clients making requests faster than the configured APIRateLimit. A
+// request sending an API key has to fit within the key's limit as well as
+// that of its IP.
+func rateLimitFunc(config store.Config) gin.HandlerFunc {
+	if config.APIRateLimit <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	rl := newRateLimiter(config)
+	return func(c *gin.Context) {
+		keys := []string{"ip:" + clientIP(c.Request, config.APIForwardedDepth).String()}
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+			keys = append(keys, "key:"+apiKey)
+		}
+		if delay := rl.reserve(keys, time.Now()); delay > 0 {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(math.Ceil(delay.Seconds()))))
+			publicError(c, http.StatusTooManyRequests, fmt.Errorf("rate limit of %d requests per second exceeded", config.APIRateLimit))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// reserve takes a token from the bucket of each key, returning how long to
+// wait until the request would be allowed if any bucket is empty, in which
+// case no tokens are taken.
+func (rl *rateLimiter) reserve(keys []string, now time.Time) time.Duration {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	var reservations []*rate.Reservation
+	var delay time.Duration
+	for _, key := range keys {
+		r := rl.limiterFor(key, now).ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+	return delay
+}
+
+func (rl *rateLimiter) limiterFor(key string, now time.Time) *rate.Limiter {
+	if cl, ok := rl.byClient[key]; ok {
+		cl.lastSeen = now
+		return cl.Limiter
+	}
+	if len(rl.byClient) >= maxLimiters {
+		rl.forgetRefilled(now)
+	}
+	if len(rl.byClient) >= maxLimiters {
+		rl.forgetLeastRecent()
+	}
+	cl := &clientLimiter{Limiter: rate.NewLimiter(rl.limit, rl.burst), lastSeen: now}
+	rl.byClient[key] = cl
+	return cl.Limiter
+}
+
+// forgetRefilled drops the buckets of clients idle for long enough that
+// their buckets are full again, since a new bucket would be the same.
+func (rl *rateLimiter) forgetRefilled(now time.Time) {
+	refill := time.Duration(float64(rl.burst) / float64(rl.limit) * float64(time.Second))
+	for key, cl := range rl.byClient {
+		if now.Sub(cl.lastSeen) > refill {
+			delete(rl.byClient, key)
+		}
+	}
+}
+
+// forgetLeastRecent drops the bucket of the client seen least recently, so
+// that clients sending requests from ever more IPs or API keys cannot grow
+// the buckets without bound.
+func (rl *rateLimiter) forgetLeastRecent() {
+	var oldest string
+	var oldestSeen time.Time
+	for key, cl := range rl.byClient {
+		if oldest == "" || cl.lastSeen.Before(oldestSeen) {
+			oldest, oldestSeen = key, cl.lastSeen
+		}
+	}
+	delete(rl.byClient, oldest)
+}
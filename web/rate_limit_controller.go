@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+)
+
+// RateLimitController shows the limits applied to API clients.
+type RateLimitController struct {
+	App services.Application
+}
+
+// RateLimit describes the API's rate limits, with a rate of 0 meaning
+// requests are not limited.
+type RateLimit struct {
+	RequestsPerSecond int      `json:"requestsPerSecond"`
+	Burst             int      `json:"burst"`
+	LimitedBy         []string `json:"limitedBy"`
+}
+
+// Show returns the rate and burst allowed to each client, and whether the
+// caller is limited by its IP alone or by its API key too
+// Example:
+//  "<application>/ratelimit"
+//
+// @Summary Show API rate limits
+// @Tags config
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} RateLimit
+// @Router /v2/ratelimit [get]
+func (rlc *RateLimitController) Show(c *gin.Context) {
+	config := rlc.App.GetStore().Config
+	limit := RateLimit{
+		RequestsPerSecond: config.APIRateLimit,
+		Burst:             config.APIBurstLimit,
+		LimitedBy:         []string{},
+	}
+	if config.APIRateLimit > 0 {
+		limit.LimitedBy = append(limit.LimitedBy, "ip")
+		if c.GetHeader(APIKeyHeader) != "" {
+			limit.LimitedBy = append(limit.LimitedBy, "apiKey")
+		}
+	}
+	c.JSON(http.StatusOK, limit)
+}
@@ -0,0 +1,140 @@
+package web_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedApplication() (*cltest.TestApplication, func()) {
+	config, cfgCleanup := cltest.NewConfig()
+	config.APIRateLimit = 1
+	config.APIBurstLimit = 2
+	// The test client reaches the node from 127.0.0.1, as if through a
+	// proxy which appends the address of each client to X-Forwarded-For.
+	config.APIForwardedDepth = 1
+	app, cleanup := cltest.NewApplicationWithConfig(config)
+	return app, func() {
+		cleanup()
+		cfgCleanup()
+	}
+}
+
+func TestRateLimit_PerIP(t *testing.T) {
+	t.Parallel()
+	app, cleanup := newRateLimitedApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	fromA := map[string]string{"X-Forwarded-For": "10.0.0.1"}
+	for i := 0; i < 2; i++ {
+		resp, cleanup := client.Get("/v2/config", fromA)
+		defer cleanup()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, cleanup := client.Get("/v2/config", fromA)
+	defer cleanup()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+	resp, cleanup = client.Get("/v2/config", map[string]string{"X-Forwarded-For": "10.0.0.2"})
+	defer cleanup()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "other clients have their own limit")
+
+	for i := 0; i < 3; i++ {
+		resp, err := http.Get(app.Server.URL + "/health")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "probes are not limited")
+	}
+}
+
+func TestRateLimit_PerAPIKey(t *testing.T) {
+	t.Parallel()
+	app, cleanup := newRateLimitedApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		resp, cleanup := client.Get("/v2/config", map[string]string{"X-Forwarded-For": ip, web.APIKeyHeader: "key"})
+		defer cleanup()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, cleanup := client.Get("/v2/config", map[string]string{"X-Forwarded-For": "10.0.0.3", web.APIKeyHeader: "key"})
+	defer cleanup()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "the key is limited across IPs")
+
+	resp, cleanup = client.Get("/v2/config", map[string]string{"X-Forwarded-For": "10.0.0.3"})
+	defer cleanup()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "the rejected request should not use up the IP's limit")
+}
+
+func TestRateLimit_SpoofedForwardedFor(t *testing.T) {
+	t.Parallel()
+	app, cleanup := newRateLimitedApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	// The proxy appends 10.0.0.1 after whatever the client sent itself.
+	for i := 1; i <= 2; i++ {
+		resp, cleanup := client.Get("/v2/config", map[string]string{"X-Forwarded-For": fmt.Sprintf("192.168.0.%d, 10.0.0.1", i)})
+		defer cleanup()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, cleanup := client.Get("/v2/config", map[string]string{"X-Forwarded-For": "192.168.0.3, 10.0.0.1"})
+	defer cleanup()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "the client's own X-Forwarded-For entries are ignored")
+}
+
+func TestRateLimit_ForwardedForIgnoredWithoutProxies(t *testing.T) {
+	t.Parallel()
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.APIRateLimit = 1
+	config.APIBurstLimit = 2
+	app, cleanup := cltest.NewApplicationWithConfig(config)
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	for i := 1; i <= 2; i++ {
+		resp, cleanup := client.Get("/v2/config", map[string]string{"X-Forwarded-For": fmt.Sprintf("10.0.0.%d", i)})
+		defer cleanup()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	resp, cleanup := client.Get("/v2/config", map[string]string{"X-Forwarded-For": "10.0.0.3"})
+	defer cleanup()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestRateLimit_BoundedBuckets(t *testing.T) {
+	t.Parallel()
+	config := store.Config{APIRateLimit: 1, APIBurstLimit: 2}
+
+	assert.Equal(t, web.ExportedMaxLimiters, web.ExportedRateLimiterBuckets(config, 2*web.ExportedMaxLimiters))
+}
+
+func TestRateLimitController_Show(t *testing.T) {
+	t.Parallel()
+	app, cleanup := newRateLimitedApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/ratelimit", map[string]string{web.APIKeyHeader: "key"})
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var limit web.RateLimit
+	require.NoError(t, json.Unmarshal(cltest.ParseResponseBody(resp), &limit))
+	assert.Equal(t, web.RateLimit{RequestsPerSecond: 1, Burst: 2, LimitedBy: []string{"ip", "apiKey"}}, limit)
+}
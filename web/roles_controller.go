@@ -0,0 +1,29 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// RolesController lists the roles which can be given to users.
+type RolesController struct {
+	App services.Application
+}
+
+// Index lists every role and what it allows.
+// Example:
+//  "<application>/v2/roles"
+//
+// @Summary List roles
+// @Tags user
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {array} presenters.Role
+// @Failure 403 {object} models.JSONAPIErrors
+// @Router /v2/roles [get]
+func (rc *RolesController) Index(c *gin.Context) {
+	c.JSON(http.StatusOK, presenters.NewRoles())
+}
@@ -0,0 +1,163 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setAPIUserRole(t *testing.T, app *cltest.TestApplication, role models.Role) {
+	user, err := app.Store.FindUserByEmail(cltest.APIEmail)
+	require.NoError(t, err)
+	user.Role = role
+	require.NoError(t, app.Store.Save(&user))
+}
+
+func TestRequireRole_ViewerCannotCreateJob(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	setAPIUserRole(t, app, models.RoleViewer)
+
+	resp, done := client.Post("/v2/specs", bytes.NewBuffer(cltest.LoadJSON("../internal/fixtures/web/hello_world_job.json")))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 403)
+	count, err := app.Store.Count(&models.JobSpec{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	resp, done = client.Get("/v2/specs")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	resp, done = client.Post("/v2/bridge_types", bytes.NewBufferString(`{"name":"bridgea","url":"http://mybridge"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 403)
+}
+
+func TestRequireRole_Operator(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	setAPIUserRole(t, app, models.RoleOperator)
+
+	cltest.FixtureCreateJobViaWeb(t, app, "../internal/fixtures/web/hello_world_job.json")
+
+	resp, done := client.Post("/v2/withdrawals", bytes.NewBufferString(`{"address":"0x9FBDa871d559710256a2502A2517b794B482Db40","amount":"1"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 403)
+
+	resp, done = client.Get("/v2/roles")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 403)
+
+	resp, done = client.Patch("/v2/user/role", bytes.NewBufferString(`{"email":"`+cltest.APIEmail+`","role":"admin"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 403)
+}
+
+func TestRolesController_Index(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, done := client.Get("/v2/roles")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	var roles []presenters.Role
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&roles))
+	require.Len(t, roles, 3)
+	assert.Equal(t, models.RoleAdmin, roles[0].Name)
+	assert.Equal(t, models.RoleOperator, roles[1].Name)
+	assert.Equal(t, models.RoleViewer, roles[2].Name)
+	assert.NotEmpty(t, roles[2].Description)
+}
+
+func TestUserController_UpdateRole(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, done := client.Patch("/v2/user/role", bytes.NewBufferString(`{"email":"`+cltest.APIEmail+`","role":"viewer"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 409)
+
+	resp, done = client.Patch("/v2/user/role", bytes.NewBufferString(`{"email":"nobody@chainlink.test","role":"viewer"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 404)
+
+	resp, done = client.Patch("/v2/user/role", bytes.NewBufferString(`{"email":"`+cltest.APIEmail+`","role":"root"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 422)
+
+	other := cltest.MustUser("other@chainlink.test", cltest.Password)
+	require.NoError(t, app.Store.Save(&other))
+
+	resp, done = client.Patch("/v2/user/role", bytes.NewBufferString(`{"email":"`+cltest.APIEmail+`","role":"operator"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	user, err := app.Store.FindUserByEmail(cltest.APIEmail)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleOperator, user.Role)
+
+	resp, done = client.Get("/v2/roles")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 403)
+}
+
+func sessionRequest(t *testing.T, app *cltest.TestApplication, sessionID, method, path string, body []byte) *http.Response {
+	req, err := http.NewRequest(method, app.Config.ClientNodeURL+path, bytes.NewBuffer(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(cltest.MustGenerateSessionCookie(sessionID))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestUserController_Create(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+	jobJSON := cltest.LoadJSON("../internal/fixtures/web/hello_world_job.json")
+
+	resp, done := client.Post("/v2/users", bytes.NewBufferString(`{"email":"viewer@chainlink.test","password":"`+cltest.Password+`","role":"viewer"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 201)
+	created, err := app.Store.FindUserByEmail("viewer@chainlink.test")
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleViewer, created.Role)
+
+	resp, done = client.Post("/v2/users", bytes.NewBufferString(`{"email":"viewer@chainlink.test","password":"`+cltest.Password+`","role":"admin"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 409)
+	resp, done = client.Post("/v2/users", bytes.NewBufferString(`{"email":"root@chainlink.test","password":"`+cltest.Password+`","role":"root"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 422)
+	resp, done = client.Post("/v2/users", bytes.NewBufferString(`{"email":"short@chainlink.test","password":"short","role":"viewer"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 422)
+
+	sessionID, err := app.Store.CreateSession(models.SessionRequest{Email: "viewer@chainlink.test", Password: cltest.Password})
+	require.NoError(t, err)
+	assert.Equal(t, 200, sessionRequest(t, app, sessionID, "GET", "/v2/specs", nil).StatusCode)
+	assert.Equal(t, 403, sessionRequest(t, app, sessionID, "POST", "/v2/specs", jobJSON).StatusCode)
+	assert.Equal(t, 403, sessionRequest(t, app, sessionID, "POST", "/v2/users", []byte(`{"email":"x@chainlink.test","password":"`+cltest.Password+`","role":"admin"}`)).StatusCode)
+
+	resp, done = client.Post("/v2/specs", bytes.NewBuffer(jobJSON))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+}
@@ -19,10 +19,15 @@ import (
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/gobuffalo/packr"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/observability"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/unrolled/secure"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 const (
@@ -30,8 +35,19 @@ const (
 	SessionName = "clsession"
 	// SessionIDKey is the session ID key in the session map
 	SessionIDKey = "clsession_id"
+	// APIKeyHeader is the request header holding an API key, which can be
+	// sent instead of a session cookie.
+	APIKeyHeader = "X-API-Key"
 )
 
+// currentUserKey is where authRequired keeps the logged in user in the
+// request's context.
+const currentUserKey = "user"
+
+// currentAPIKeyKey is where apiKeyAuth keeps the API key a request was
+// authenticated with in the request's context.
+const currentAPIKeyKey = "apiKey"
+
 // Router listens and responds to requests to the node for valid paths.
 func Router(app services.Application) *gin.Engine {
 	engine := gin.New()
@@ -44,17 +60,28 @@ func Router(app services.Application) *gin.Engine {
 	sessionStore := sessions.NewCookieStore(secret)
 	sessionStore.Options(config.SessionOptions())
 	cors := uiCorsHandler(config)
+	ipFilter, err := ipFilterFunc(config)
+	if err != nil {
+		logger.Panic(err)
+	}
 
 	engine.Use(
+		tracingFunc(),
 		loggerFunc(),
 		gin.Recovery(),
+		ipFilter,
 		cors,
 		sessions.Sessions(SessionName, sessionStore),
 		secureMiddleware(config),
 	)
 
 	metricRoutes(app, engine)
+	healthRoutes(app, engine)
+	// Routes added from here on are rate limited, leaving scrapers and probes
+	// alone.
+	engine.Use(rateLimitFunc(config))
 	sessionRoutes(app, engine)
+	docsRoutes(app, engine)
 	v1Routes(app, engine)
 	v2Routes(app, engine)
 	guiAssetRoutes(app.NewBox(), engine)
@@ -97,86 +124,231 @@ func secureMiddleware(config store.Config) gin.HandlerFunc {
 	return secureFunc
 }
 
+// authRequired authenticates requests by their session cookie, or by their
+// API key when one is sent.
 func authRequired(store *store.Store) gin.HandlerFunc {
+	keyAuth := apiKeyAuth(store)
 	return func(c *gin.Context) {
+		if c.GetHeader(APIKeyHeader) != "" {
+			keyAuth(c)
+			return
+		}
 		session := sessions.Default(c)
 		sessionID, ok := session.Get(SessionIDKey).(string)
 		if !ok {
 			c.AbortWithStatus(http.StatusUnauthorized)
-		} else if _, err := store.AuthorizedUserWithSession(sessionID); err != nil {
+		} else if user, err := store.AuthorizedUserWithSession(sessionID); err != nil {
 			c.AbortWithStatus(http.StatusUnauthorized)
 		} else {
+			c.Set(currentUserKey, user)
 			c.Next()
 		}
 	}
 }
 
+// apiKeyAuth authenticates requests by the API key in their X-API-Key header.
+func apiKeyAuth(store *store.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, key, err := store.AuthorizedUserWithAPIKey(c.GetHeader(APIKeyHeader))
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Set(currentUserKey, user)
+		c.Set(currentAPIKeyKey, key)
+		c.Next()
+	}
+}
+
+// RequireScope returns middleware which answers 403 to requests made with an
+// API key not granted scope. Requests made with a session are not limited
+// by scope.
+func RequireScope(scope models.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key, ok := c.Get(currentAPIKeyKey); ok && !key.(models.APIKey).HasScope(scope) {
+			publicError(c, http.StatusForbidden, fmt.Errorf("API key lacks the %s scope", scope))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRole returns middleware which answers 403 to users whose role does
+// not grant at least role. It must follow authRequired.
+func RequireRole(role models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := c.Get(currentUserKey)
+		if !ok || !user.(models.User).HasRole(role) {
+			publicError(c, http.StatusForbidden, fmt.Errorf("Requires the %s role", role))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// currentUser returns the user authRequired authenticated the request as.
+func currentUser(c *gin.Context) models.User {
+	user, _ := c.Get(currentUserKey)
+	u, _ := user.(models.User)
+	return u
+}
+
 func metricRoutes(app services.Application, engine *gin.Engine) {
-	auth := engine.Group("/", authRequired(app.GetStore()))
-	auth.GET("/debug/vars", expvar.Handler())
+	auth := engine.Group("/", authRequired(app.GetStore()), RequireRole(models.RoleViewer))
+	auth.GET("/debug/vars", RequireScope(models.ScopeNodeRead), expvar.Handler())
+	// Prometheus scrapers cannot hold a session, so /metrics is left open.
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
+
+// healthRoutes are left unauthenticated for the benefit of orchestrators
+// probing the node.
+func healthRoutes(app services.Application, engine *gin.Engine) {
+	hc := HealthController{app}
+	engine.GET("/health", hc.Health)
+	engine.GET("/ready", hc.Ready)
 }
 
 func sessionRoutes(app services.Application, engine *gin.Engine) {
 	sc := SessionsController{app}
 	engine.POST("/sessions", sc.Create)
-	auth := engine.Group("/", authRequired(app.GetStore()))
-	auth.DELETE("/sessions", sc.Destroy)
+	auth := engine.Group("/", authRequired(app.GetStore()), RequireRole(models.RoleViewer))
+	auth.DELETE("/sessions", RequireScope(models.ScopeUserWrite), sc.Destroy)
+}
+
+// docsRoutes are left unauthenticated so that the API can be explored before
+// logging in.
+func docsRoutes(app services.Application, engine *gin.Engine) {
+	oc := OpenAPIController{app}
+	engine.GET("/v2/openapi.json", oc.Show)
+	engine.GET("/docs", oc.UI)
 }
 
 func v1Routes(app services.Application, engine *gin.Engine) {
 	v1 := engine.Group("/v1")
-	v1.Use(authRequired(app.GetStore()))
+	v1.Use(authRequired(app.GetStore()), RequireRole(models.RoleViewer))
+	operator := RequireRole(models.RoleOperator)
+	audit := AuditLogger{app}
 
 	ac := AssignmentsController{app}
-	v1.POST("/assignments", ac.Create)
-	v1.GET("/assignments/:ID", ac.Show)
+	v1.POST("/assignments", operator, RequireScope(models.ScopeJobsWrite), audit.Record("create", auditJobSpec), ac.Create)
+	v1.GET("/assignments/:ID", RequireScope(models.ScopeJobsRead), ac.Show)
 
 	sc := SnapshotsController{app}
-	v1.POST("/assignments/:AID/snapshots", sc.CreateSnapshot)
-	v1.GET("/snapshots/:ID", sc.ShowSnapshot)
+	v1.POST("/assignments/:AID/snapshots", operator, RequireScope(models.ScopeRunsWrite), audit.Record("create", auditJobRun), sc.CreateSnapshot)
+	v1.GET("/snapshots/:ID", RequireScope(models.ScopeRunsRead), sc.ShowSnapshot)
 }
 
 func v2Routes(app services.Application, engine *gin.Engine) {
 	v2 := engine.Group("/v2")
+	audit := AuditLogger{app}
 
 	jr := JobRunsController{app}
 	v2.PATCH("/runs/:RunID", jr.Update)
 
 	sa := ServiceAgreementsController{app}
-	v2.POST("/service_agreements", sa.Create)
-
-	authv2 := engine.Group("/v2", authRequired(app.GetStore()))
+	v2.POST("/service_agreements", audit.Record("create", auditServiceAgreement), sa.Create)
+
+	// Every user may read, manage their own password and TOTP, and log out
+	// everywhere. Other changes are limited to operators, or to admins for
+	// those touching users, keys, credentials or the node's funds. Requests
+	// made with an API key are further limited to the routes its scopes
+	// allow.
+	authv2 := engine.Group("/v2", authRequired(app.GetStore()), RequireRole(models.RoleViewer))
+	operator := RequireRole(models.RoleOperator)
+	admin := RequireRole(models.RoleAdmin)
 	{
 		uc := UserController{app}
-		authv2.PATCH("/user/password", uc.UpdatePassword)
-		authv2.GET("/user/balances", uc.AccountBalances)
+		authv2.PATCH("/user/password", RequireScope(models.ScopeUserWrite), audit.Record("update_password", auditUser), uc.UpdatePassword)
+		authv2.GET("/user/balances", RequireScope(models.ScopeUserRead), uc.AccountBalances)
+		authv2.POST("/users", admin, RequireScope(models.ScopeUserWrite), audit.Record("create", auditUserByEmail), uc.Create)
+		authv2.PATCH("/user/role", admin, RequireScope(models.ScopeUserWrite), audit.Record("update_role", auditUserByEmail), uc.UpdateRole)
 
-		j := JobSpecsController{app}
-		authv2.GET("/specs", j.Index)
-		authv2.POST("/specs", j.Create)
-		authv2.GET("/specs/:SpecID", j.Show)
+		sc := SessionsController{app}
+		authv2.DELETE("/sessions", RequireScope(models.ScopeUserWrite), sc.DestroyAll)
+
+		roles := RolesController{app}
+		authv2.GET("/roles", admin, RequireScope(models.ScopeUserRead), roles.Index)
+
+		tc := TOTPController{app}
+		authv2.POST("/user/totp/enable", RequireScope(models.ScopeUserWrite), audit.Record("enable_totp", auditUser), tc.Enable)
+		authv2.POST("/user/totp/verify", RequireScope(models.ScopeUserWrite), audit.Record("verify_totp", auditUser), tc.Verify)
+		authv2.POST("/user/totp/disable", RequireScope(models.ScopeUserWrite), audit.Record("disable_totp", auditUser), tc.Disable)
+
+		akc := APIKeysController{app}
+		authv2.GET("/keys", admin, RequireScope(models.ScopeAPIKeysRead), akc.Index)
+		authv2.POST("/keys", admin, RequireScope(models.ScopeAPIKeysWrite), audit.Record("create", auditAPIKey), akc.Create)
+		authv2.DELETE("/keys/:ID", admin, RequireScope(models.ScopeAPIKeysWrite), audit.Record("delete", auditAPIKey), akc.Destroy)
 
-		authv2.GET("/runs", jr.Index)
-		authv2.POST("/specs/:SpecID/runs", jr.Create)
-		authv2.GET("/runs/:RunID", jr.Show)
+		kc := KeepersController{app}
+		authv2.GET("/keepers", RequireScope(models.ScopeJobsRead), kc.Index)
+		authv2.POST("/keepers", operator, RequireScope(models.ScopeJobsWrite), audit.Record("create", auditUpkeep), kc.Create)
+		authv2.DELETE("/keepers/:ID", operator, RequireScope(models.ScopeJobsWrite), audit.Record("delete", auditUpkeep), kc.Destroy)
+
+		vk := VRFKeysController{app}
+		authv2.GET("/keys/vrf", admin, RequireScope(models.ScopeNodeRead), vk.Index)
+		authv2.POST("/keys/vrf", admin, RequireScope(models.ScopeNodeWrite), audit.Record("create", auditVRFKey), vk.Create)
+
+		j := JobSpecsController{app}
+		authv2.GET("/specs", RequireScope(models.ScopeJobsRead), j.Index)
+		authv2.POST("/specs", operator, RequireScope(models.ScopeJobsWrite), audit.Record("create", auditJobSpec), j.Create)
+		authv2.GET("/specs/:SpecID", RequireScope(models.ScopeJobsRead), j.Show)
+		authv2.GET("/jobs/:SpecID/export", RequireScope(models.ScopeJobsRead), j.Export)
+		authv2.POST("/jobs/import", operator, RequireScope(models.ScopeJobsWrite), audit.Record("import", auditJobSpec), j.Import)
+
+		rs := RunStatusController{app, NewRunStatusHub()}
+		app.GetStore().SetRunStatusBroadcaster(rs.Hub)
+
+		authv2.GET("/runs", RequireScope(models.ScopeRunsRead), jr.Index)
+		authv2.POST("/specs/:SpecID/runs", operator, RequireScope(models.ScopeRunsWrite), audit.Record("create", auditJobRun), jr.Create)
+		authv2.PATCH("/runs/:RunID/review", operator, RequireScope(models.ScopeRunsWrite), audit.Record("review", auditJobRun), jr.Review)
+		// The router cannot match the static /runs/ws alongside the
+		// /runs/:RunID parameter, so the stream is picked out here.
+		authv2.GET("/runs/:RunID", RequireScope(models.ScopeRunsRead), func(c *gin.Context) {
+			if c.Param("RunID") == "ws" {
+				rs.Stream(c)
+			} else {
+				jr.Show(c)
+			}
+		})
 
-		authv2.GET("/service_agreements/:SAID", sa.Show)
+		authv2.GET("/service_agreements/:SAID", RequireScope(models.ScopeJobsRead), sa.Show)
 
 		bt := BridgeTypesController{app}
-		authv2.GET("/bridge_types", bt.Index)
-		authv2.POST("/bridge_types", bt.Create)
-		authv2.GET("/bridge_types/:BridgeName", bt.Show)
-		authv2.PATCH("/bridge_types/:BridgeName", bt.Update)
-		authv2.DELETE("/bridge_types/:BridgeName", bt.Destroy)
+		authv2.GET("/bridge_types", RequireScope(models.ScopeBridgesRead), bt.Index)
+		authv2.POST("/bridge_types", operator, RequireScope(models.ScopeBridgesWrite), audit.Record("create", auditBridgeType), bt.Create)
+		authv2.GET("/bridge_types/:BridgeName", RequireScope(models.ScopeBridgesRead), bt.Show)
+		authv2.PATCH("/bridge_types/:BridgeName", operator, RequireScope(models.ScopeBridgesWrite), audit.Record("update", auditBridgeType), bt.Update)
+		authv2.DELETE("/bridge_types/:BridgeName", operator, RequireScope(models.ScopeBridgesWrite), audit.Record("delete", auditBridgeType), bt.Destroy)
+
+		hc := HTTPCredentialsController{app}
+		authv2.GET("/http_credentials", RequireScope(models.ScopeCredentialsRead), hc.Index)
+		authv2.POST("/http_credentials", admin, RequireScope(models.ScopeCredentialsWrite), audit.Record("create", auditHTTPCredential), hc.Create)
+		authv2.DELETE("/http_credentials/:Name", admin, RequireScope(models.ScopeCredentialsWrite), audit.Record("delete", auditHTTPCredential), hc.Destroy)
 
 		w := WithdrawalsController{app}
-		authv2.POST("/withdrawals", w.Create)
+		authv2.POST("/withdrawals", admin, RequireScope(models.ScopeNodeWrite), audit.Record("create", auditWithdrawal), w.Create)
 
 		backup := BackupController{app}
-		authv2.GET("/backup", backup.Show)
+		authv2.GET("/backup", admin, RequireScope(models.ScopeNodeRead), backup.Show)
 
 		cc := ConfigController{app}
-		authv2.GET("/config", cc.Show)
+		authv2.GET("/config", RequireScope(models.ScopeNodeRead), cc.Show)
+		authv2.PUT("/config", admin, RequireScope(models.ScopeNodeWrite), audit.Record("update", auditConfig), cc.Update)
+
+		rl := RateLimitController{app}
+		authv2.GET("/ratelimit", RequireScope(models.ScopeNodeRead), rl.Show)
+
+		ll := LogLevelController{app}
+		authv2.GET("/loglevel", RequireScope(models.ScopeNodeRead), ll.Show)
+		authv2.PUT("/loglevel", admin, RequireScope(models.ScopeNodeWrite), audit.Record("update", auditLogLevel), ll.Update)
+
+		ls := LogStreamController{app}
+		authv2.GET("/logs/stream", RequireScope(models.ScopeNodeRead), ls.Stream)
+
+		ac := AuditController{app}
+		authv2.GET("/audit", admin, RequireScope(models.ScopeNodeRead), ac.Index)
 	}
 }
 
@@ -251,6 +423,28 @@ func loggerFunc() gin.HandlerFunc {
 	}
 }
 
+// tracingFunc serves each request inside a span, continuing the trace of any
+// traceparent header sent by the caller.
+func tracingFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := observability.ExtractHeaders(c.Request.Context(), c.Request.Header)
+		ctx, span := observability.StartSpan(ctx, fmt.Sprintf("%s %s", c.Request.Method, c.Request.URL.Path),
+			attribute.String("http.method", c.Request.Method),
+			attribute.String("http.target", c.Request.URL.Path),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
 // Add CORS headers so UI can make api requests
 func uiCorsHandler(config store.Config) gin.HandlerFunc {
 	c := cors.Config{
@@ -287,31 +481,48 @@ var blacklist = map[string]struct{}{
 	"password":    struct{}{},
 	"newpassword": struct{}{},
 	"oldpassword": struct{}{},
+	"token":       struct{}{},
 }
 
 func readSanitizedJSON(buf *bytes.Buffer) (string, error) {
-	var dst map[string]interface{}
+	var dst interface{}
 	err := json.Unmarshal(buf.Bytes(), &dst)
 	if err != nil {
 		return "", err
 	}
 
-	cleaned := map[string]interface{}{}
-	for k, v := range dst {
-		if _, ok := blacklist[strings.ToLower(k)]; ok {
-			cleaned[k] = "*REDACTED*"
-			continue
-		}
-		cleaned[k] = v
-	}
-
-	b, err := json.Marshal(cleaned)
+	b, err := json.Marshal(sanitizeJSON(dst))
 	if err != nil {
 		return "", err
 	}
 	return string(b), err
 }
 
+// sanitizeJSON redacts the blacklisted keys of every object in v, however
+// deeply nested.
+func sanitizeJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		cleaned := map[string]interface{}{}
+		for k, value := range v {
+			if _, ok := blacklist[strings.ToLower(k)]; ok {
+				cleaned[k] = "*REDACTED*"
+				continue
+			}
+			cleaned[k] = sanitizeJSON(value)
+		}
+		return cleaned
+	case []interface{}:
+		cleaned := make([]interface{}, len(v))
+		for i, value := range v {
+			cleaned[i] = sanitizeJSON(value)
+		}
+		return cleaned
+	default:
+		return v
+	}
+}
+
 func redact(values url.Values) string {
 	cleaned := url.Values{}
 	for k, v := range values {
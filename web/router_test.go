@@ -0,0 +1,103 @@
+package web_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRouter_Metrics(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j, _ := cltest.NewJobWithWebInitiator()
+	j.Tasks = []models.TaskSpec{cltest.NewTask("noop")}
+	j = cltest.CreateJobSpecViaWeb(t, app, j)
+	jr := cltest.CreateJobRunViaWeb(t, app, j)
+	cltest.WaitForJobRunToComplete(t, app.Store, jr)
+
+	scrape := func() string {
+		resp, err := http.Get(app.Server.URL + "/metrics")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, 200, resp.StatusCode)
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	completed := fmt.Sprintf(`chainlink_job_runs_total{job_id="%s",status="completed"} 1`, j.ID)
+	gomega.NewGomegaWithT(t).Eventually(scrape).Should(gomega.ContainSubstring(completed))
+	body := scrape()
+	assert.Contains(t, body, fmt.Sprintf(`chainlink_job_run_duration_seconds_count{job_id="%s"} 1`, j.ID))
+	assert.Contains(t, body, `chainlink_task_runs_total{status="completed",task_type="noop"}`)
+}
+
+func TestRouter_ReadSanitizedJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"password", `{"email":"a@b.c","password":"hunter22"}`, `{"email":"a@b.c","password":"*REDACTED*"}`},
+		{"token", `{"name":"kaiko","Token":"s3cr3t"}`, `{"Token":"*REDACTED*","name":"kaiko"}`},
+		{"nested", `{"tasks":[{"params":{"token":"s3cr3t","get":"https://a.b"}}]}`, `{"tasks":[{"params":{"get":"https://a.b","token":"*REDACTED*"}}]}`},
+		{"array", `[{"password":"hunter22"}]`, `[{"password":"*REDACTED*"}]`},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := web.ExportedReadSanitizedJSON(test.body)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, actual)
+		})
+	}
+}
+
+func TestRouter_TracesRequests(t *testing.T) {
+	recorder, restore := cltest.RecordSpans()
+	defer restore()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	request, err := http.NewRequest("GET", app.Server.URL+"/metrics", nil)
+	require.NoError(t, err)
+	request.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	servedSpans := func() []sdktrace.ReadOnlySpan {
+		var served []sdktrace.ReadOnlySpan
+		for _, span := range recorder.Ended() {
+			if span.Name() == "GET /metrics" {
+				served = append(served, span)
+			}
+		}
+		return served
+	}
+	// The span ends once the middleware returns, which can be after the
+	// response has been read.
+	gomega.NewGomegaWithT(t).Eventually(servedSpans).Should(gomega.HaveLen(1))
+	span := servedSpans()[0]
+	assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", 200))
+}
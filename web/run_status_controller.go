@@ -0,0 +1,90 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store"
+)
+
+// runStatusWriteTimeout is how long a client has to accept each update before
+// its websocket is closed.
+const runStatusWriteTimeout = 10 * time.Second
+
+// RunStatusController streams run status changes to websocket clients.
+type RunStatusController struct {
+	App services.Application
+	Hub *RunStatusHub
+}
+
+// Stream upgrades the request to a websocket and sends a RunStatusUpdate as
+// JSON each time a run's status changes, only for the runs of jobID if given
+// Example:
+//  "<application>/runs/ws?jobID=:jobID"
+//
+// @Summary Stream run status changes over a websocket
+// @Tags runs
+// @Security SessionCookie
+// @Param jobID query string false "Only send changes to the runs of this job"
+// @Success 101 {object} models.RunStatusUpdate "Each websocket message"
+// @Router /v2/runs/ws [get]
+func (rsc *RunStatusController) Stream(c *gin.Context) {
+	upgrader := websocket.Upgrader{CheckOrigin: websocketOriginChecker(rsc.App.GetStore().Config)}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already responded with the error.
+		return
+	}
+	defer conn.Close()
+
+	updates, unsubscribe := rsc.Hub.Subscribe(c.Query("jobID"))
+	defer unsubscribe()
+
+	// Nothing is expected from the client, but reading is how a closed
+	// connection is noticed.
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(runStatusWriteTimeout))
+			if err := conn.WriteJSON(update); err != nil {
+				return
+			}
+		case <-disconnected:
+			return
+		}
+	}
+}
+
+// websocketOriginChecker accepts websocket requests from the same origins as
+// the CORS handler allows, and from clients sending no Origin at all.
+func websocketOriginChecker(config store.Config) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || config.AllowOrigins == "*" {
+			return true
+		}
+		for _, allowed := range strings.Split(config.AllowOrigins, ",") {
+			if strings.TrimSpace(allowed) == origin {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,66 @@
+package web_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dialRunStatus(t *testing.T, app *cltest.TestApplication, query string) *websocket.Conn {
+	t.Helper()
+	app.MustSeedUserSession()
+	url := strings.Replace(app.Server.URL, "http", "ws", 1) + "/v2/runs/ws" + query
+	header := http.Header{}
+	header.Add("Cookie", cltest.MustGenerateSessionCookie(cltest.APISessionID).String())
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	require.NoError(t, err)
+	return conn
+}
+
+func TestRunStatusController_Stream(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	j, _ := cltest.NewJobWithWebInitiator()
+	j = cltest.CreateJobSpecViaWeb(t, app, j)
+	other, _ := cltest.NewJobWithWebInitiator()
+	other = cltest.CreateJobSpecViaWeb(t, app, other)
+
+	conn := dialRunStatus(t, app, "?jobID="+j.ID)
+	defer conn.Close()
+
+	cltest.CreateJobRunViaWeb(t, app, other)
+	jr := cltest.CreateJobRunViaWeb(t, app, j)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		var update models.RunStatusUpdate
+		require.NoError(t, conn.ReadJSON(&update))
+		assert.Equal(t, j.ID, update.JobID)
+		assert.Equal(t, jr.ID, update.RunID)
+		assert.False(t, update.Timestamp.IsZero())
+		if update.Status == models.RunStatusCompleted {
+			break
+		}
+	}
+}
+
+func TestRunStatusController_Stream_Unauthenticated(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+
+	url := strings.Replace(app.Server.URL, "http", "ws", 1) + "/v2/runs/ws"
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
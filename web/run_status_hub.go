@@ -0,0 +1,89 @@
+package web
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/logger"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+)
+
+// runStatusBufferSize is how many updates a subscriber may fall behind by
+// before further updates to it are dropped.
+const runStatusBufferSize = 100
+
+// RunStatusHub fans out run status changes to the clients subscribed to them.
+type RunStatusHub struct {
+	mutex       sync.Mutex
+	subscribers map[*runStatusSubscriber]struct{}
+	lastStatus  map[string]models.RunStatus
+}
+
+type runStatusSubscriber struct {
+	jobID   string
+	updates chan models.RunStatusUpdate
+}
+
+// NewRunStatusHub returns a RunStatusHub without any subscribers.
+func NewRunStatusHub() *RunStatusHub {
+	return &RunStatusHub{
+		subscribers: map[*runStatusSubscriber]struct{}{},
+		lastStatus:  map[string]models.RunStatus{},
+	}
+}
+
+// Subscribe returns a channel receiving the status changes of runs for the
+// job with jobID, or of all runs if jobID is empty, along with a function
+// which unsubscribes and closes the channel.
+func (h *RunStatusHub) Subscribe(jobID string) (<-chan models.RunStatusUpdate, func()) {
+	sub := &runStatusSubscriber{
+		jobID:   jobID,
+		updates: make(chan models.RunStatusUpdate, runStatusBufferSize),
+	}
+
+	h.mutex.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mutex.Unlock()
+
+	var once sync.Once
+	return sub.updates, func() {
+		once.Do(func() {
+			h.mutex.Lock()
+			defer h.mutex.Unlock()
+			delete(h.subscribers, sub)
+			close(sub.updates)
+		})
+	}
+}
+
+// Broadcast sends update to each interested subscriber, unless the run's
+// status has not changed since it was last broadcast. A subscriber too far
+// behind misses the update rather than holding up the run.
+func (h *RunStatusHub) Broadcast(update models.RunStatusUpdate) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.lastStatus[update.RunID] == update.Status {
+		return nil
+	}
+	if update.Status.Finished() {
+		delete(h.lastStatus, update.RunID)
+	} else {
+		h.lastStatus[update.RunID] = update.Status
+	}
+
+	if len(h.subscribers) == 0 {
+		return store.ErrNoSubscribers
+	}
+	for sub := range h.subscribers {
+		if sub.jobID != "" && sub.jobID != update.JobID {
+			continue
+		}
+		select {
+		case sub.updates <- update:
+		default:
+			logger.Warnw("Dropping run status update for slow subscriber", "run_id", update.RunID)
+		}
+	}
+	return nil
+}
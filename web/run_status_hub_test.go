@@ -0,0 +1,39 @@
+package web_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunStatusHub_Broadcast(t *testing.T) {
+	t.Parallel()
+	hub := web.NewRunStatusHub()
+
+	assert.Equal(t, store.ErrNoSubscribers, hub.Broadcast(models.RunStatusUpdate{
+		RunID: "run-0", JobID: "job-a", Status: models.RunStatusInProgress,
+	}))
+
+	all, unsubscribeAll := hub.Subscribe("")
+	defer unsubscribeAll()
+	jobA, unsubscribeA := hub.Subscribe("job-a")
+
+	inProgress := models.RunStatusUpdate{RunID: "run-1", JobID: "job-a", Status: models.RunStatusInProgress}
+	assert.NoError(t, hub.Broadcast(inProgress))
+	assert.NoError(t, hub.Broadcast(inProgress))
+	other := models.RunStatusUpdate{RunID: "run-2", JobID: "job-b", Status: models.RunStatusCompleted}
+	assert.NoError(t, hub.Broadcast(other))
+
+	assert.Equal(t, inProgress, <-all)
+	assert.Equal(t, other, <-all)
+	assert.Equal(t, inProgress, <-jobA)
+	assert.Len(t, jobA, 0, "unchanged status and other jobs should not be sent")
+
+	unsubscribeA()
+	_, open := <-jobA
+	assert.False(t, open)
+	assert.NotPanics(t, unsubscribeA)
+}
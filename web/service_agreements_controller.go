@@ -19,6 +19,15 @@ type ServiceAgreementsController struct {
 }
 
 // Create builds and saves a new service agreement record.
+//
+// @Summary Create a service agreement
+// @Tags service agreements
+// @Accept json
+// @Produce json
+// @Param agreement body ServiceAgreementRequest true "Job spec and encumbrance"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.ServiceAgreement}}
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/service_agreements [post]
 func (sac *ServiceAgreementsController) Create(c *gin.Context) {
 	if !sac.App.GetStore().Config.Dev {
 		publicError(c, 500, errors.New("Service Agreements are currently under development and not yet usable outside of development mode"))
@@ -58,6 +67,15 @@ func (sac *ServiceAgreementsController) Create(c *gin.Context) {
 // Show returns the details of a ServiceAgreement.
 // Example:
 //  "<application>/service_agreements/:SAID"
+//
+// @Summary Show a service agreement
+// @Tags service agreements
+// @Produce json
+// @Security SessionCookie
+// @Param SAID path string true "Service agreement ID"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.ServiceAgreement}}
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v2/service_agreements/{SAID} [get]
 func (sac *ServiceAgreementsController) Show(c *gin.Context) {
 	id := common.HexToHash(c.Param("SAID"))
 	if sa, err := sac.App.GetStore().FindServiceAgreement(id.String()); err == storm.ErrNotFound {
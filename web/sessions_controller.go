@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
 	"go.uber.org/multierr"
 )
 
@@ -17,7 +18,17 @@ type SessionsController struct {
 }
 
 // Create creates a session ID for the given user credentials, and returns it
-// in a cookie.
+// in a cookie. Once two-factor authentication is enabled, the credentials
+// must include a current totpCode.
+//
+// @Summary Log in
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param credentials body models.SessionRequest true "Email, password and, once enabled, TOTP code"
+// @Success 200 {object} presenters.Authentication
+// @Failure 401 {object} models.JSONAPIErrors
+// @Router /sessions [post]
 func (sc *SessionsController) Create(c *gin.Context) {
 	defer sc.App.GetReaper().ReapSessions()
 
@@ -30,11 +41,18 @@ func (sc *SessionsController) Create(c *gin.Context) {
 	} else if err := saveSessionID(session, sid); err != nil {
 		c.AbortWithError(500, multierr.Append(errors.New("Unable to save session id"), err))
 	} else {
-		c.JSON(http.StatusOK, gin.H{"authenticated": true})
+		c.JSON(http.StatusOK, presenters.Authentication{Authenticated: true})
 	}
 }
 
 // Destroy erases the session ID for the sole API user.
+//
+// @Summary Log out
+// @Tags sessions
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} presenters.Authentication
+// @Router /sessions [delete]
 func (sc *SessionsController) Destroy(c *gin.Context) {
 	defer sc.App.GetReaper().ReapSessions()
 
@@ -42,11 +60,32 @@ func (sc *SessionsController) Destroy(c *gin.Context) {
 	defer session.Clear()
 	sessionID, ok := session.Get(SessionIDKey).(string)
 	if !ok {
-		c.JSON(http.StatusOK, gin.H{"authenticated": false})
+		c.JSON(http.StatusOK, presenters.Authentication{Authenticated: false})
 	} else if err := sc.App.GetStore().DeleteUserSession(sessionID); err != nil {
 		c.AbortWithError(500, err)
 	} else {
-		c.JSON(http.StatusOK, gin.H{"authenticated": false})
+		c.JSON(http.StatusOK, presenters.Authentication{Authenticated: false})
+	}
+}
+
+// DestroyAll logs out every session of the current user, including the one
+// making the request.
+// Example:
+//  "<application>/v2/sessions"
+//
+// @Summary Log out everywhere
+// @Tags sessions
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} presenters.Authentication
+// @Router /v2/sessions [delete]
+func (sc *SessionsController) DestroyAll(c *gin.Context) {
+	session := sessions.Default(c)
+	defer session.Clear()
+	if err := sc.App.GetStore().DeleteAllSessions(currentUser(c).Email); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.JSON(http.StatusOK, presenters.Authentication{Authenticated: false})
 	}
 }
 
@@ -107,7 +107,7 @@ func TestSessionsController_Destroy(t *testing.T) {
 	err := app.Store.Save(&seedUser)
 	assert.NoError(t, err)
 
-	correctSession := models.NewSession()
+	correctSession := cltest.NewSession()
 	require.NoError(t, app.Store.Save(&correctSession))
 	defer cleanup()
 
@@ -154,7 +154,7 @@ func TestSessionsController_Destroy_ReapSessions(t *testing.T) {
 	err := app.Store.Save(&user)
 	assert.NoError(t, err)
 
-	correctSession := models.NewSession()
+	correctSession := cltest.NewSession()
 	require.NoError(t, app.Store.Save(&correctSession))
 	cookie := cltest.MustGenerateSessionCookie(correctSession.ID)
 
@@ -176,3 +176,98 @@ func TestSessionsController_Destroy_ReapSessions(t *testing.T) {
 		return sessions
 	}).Should(gomega.HaveLen(0))
 }
+
+func getSpecsWithSession(t *testing.T, app *cltest.TestApplication, sessionID string) *http.Response {
+	request, err := http.NewRequest("GET", app.Config.ClientNodeURL+"/v2/specs", nil)
+	require.NoError(t, err)
+	request.AddCookie(cltest.MustGenerateSessionCookie(sessionID))
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestSessionsController_Create_MaxSessions(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	app.Start()
+	app.Store.Config.MaxSessions = 1
+	user := cltest.MustUser(cltest.APIEmail, cltest.Password)
+	require.NoError(t, app.Store.Save(&user))
+
+	login := func() string {
+		body := fmt.Sprintf(`{"email":"%s","password":"%s"}`, cltest.APIEmail, cltest.Password)
+		resp, err := http.Post(app.Config.ClientNodeURL+"/sessions", "application/json", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+		sessionID, err := cltest.DecodeSessionCookie(resp.Cookies()[0].Value)
+		require.NoError(t, err)
+		return sessionID
+	}
+	otherUser := cltest.MustUser("other@chainlink.test", cltest.Password)
+	require.NoError(t, app.Store.Save(&otherUser))
+	otherSession := cltest.NewSession("other")
+	otherSession.Email = otherUser.Email
+	require.NoError(t, app.Store.Save(&otherSession))
+
+	first := login()
+	assert.Equal(t, 200, getSpecsWithSession(t, app, first).StatusCode)
+	second := login()
+
+	assert.Equal(t, 401, getSpecsWithSession(t, app, first).StatusCode, "oldest session is logged out")
+	assert.Equal(t, 200, getSpecsWithSession(t, app, second).StatusCode)
+	assert.Equal(t, 200, getSpecsWithSession(t, app, otherSession.ID).StatusCode, "other users' sessions are not limited")
+}
+
+func TestSessionsController_DestroyAll(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	app.Start()
+	client := app.NewHTTPClient()
+	other := cltest.NewSession()
+	require.NoError(t, app.Store.Save(&other))
+	otherUser := cltest.MustUser("other@chainlink.test", cltest.Password)
+	require.NoError(t, app.Store.Save(&otherUser))
+	otherUserSession := cltest.NewSession("otherUser")
+	otherUserSession.Email = otherUser.Email
+	require.NoError(t, app.Store.Save(&otherUserSession))
+
+	resp, done := client.Delete("/v2/sessions")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	var sessions []models.Session
+	require.NoError(t, app.Store.All(&sessions))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, otherUserSession.ID, sessions[0].ID)
+	assert.Equal(t, 401, getSpecsWithSession(t, app, other.ID).StatusCode)
+	assert.Equal(t, 401, getSpecsWithSession(t, app, cltest.APISessionID).StatusCode)
+	assert.Equal(t, 200, getSpecsWithSession(t, app, otherUserSession.ID).StatusCode)
+}
+
+func TestSessions_TimeoutAndLastUsed(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	app.Start()
+	user := cltest.MustUser(cltest.APIEmail, cltest.Password)
+	require.NoError(t, app.Store.Save(&user))
+	timeout := app.Config.SessionTimeout.Duration
+
+	idle := cltest.NewSession("idle")
+	idle.LastUsed = models.Time{Time: time.Now().Add(-timeout / 2)}
+	require.NoError(t, app.Store.Save(&idle))
+	expired := cltest.NewSession("expired")
+	expired.LastUsed = models.Time{Time: time.Now().Add(-timeout - time.Second)}
+	require.NoError(t, app.Store.Save(&expired))
+
+	assert.Equal(t, 200, getSpecsWithSession(t, app, idle.ID).StatusCode)
+	var refreshed models.Session
+	require.NoError(t, app.Store.One("ID", idle.ID, &refreshed))
+	assert.True(t, refreshed.LastUsed.After(idle.LastUsed.Time), "last used is refreshed")
+
+	assert.Equal(t, 401, getSpecsWithSession(t, app, expired.ID).StatusCode)
+}
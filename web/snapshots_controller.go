@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
 )
 
 // SnapshotsController manages Snapshot requests.
@@ -17,6 +18,15 @@ type SnapshotsController struct {
 // CreateSnapshot begins the job run for the given Assignment ID
 // Example:
 //  "/assignments/:AID/snapshots"
+//
+// @Summary Run a v1 assignment
+// @Tags v1
+// @Produce json
+// @Security SessionCookie
+// @Param AID path string true "Job ID"
+// @Success 200 {object} presenters.ResourceID
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v1/assignments/{AID}/snapshots [post]
 func (sc *SnapshotsController) CreateSnapshot(c *gin.Context) {
 	id := c.Param("AID")
 
@@ -27,13 +37,22 @@ func (sc *SnapshotsController) CreateSnapshot(c *gin.Context) {
 	} else if jr, err := services.ExecuteJob(j, j.InitiatorsFor(models.InitiatorWeb)[0], models.RunResult{}, nil, sc.App.GetStore()); err != nil {
 		c.AbortWithError(500, err)
 	} else {
-		c.JSON(200, gin.H{"id": jr.ID})
+		c.JSON(200, presenters.ResourceID{ID: jr.ID})
 	}
 }
 
 // ShowSnapshot returns snapshot for given ID
 // Example:
 //  "/snapshots/:ID"
+//
+// @Summary Show the result of a v1 assignment run
+// @Tags v1
+// @Produce json
+// @Security SessionCookie
+// @Param ID path string true "Run ID"
+// @Success 200 {object} models.Snapshot
+// @Failure 404 {object} models.JSONAPIErrors
+// @Router /v1/snapshots/{ID} [get]
 func (sc *SnapshotsController) ShowSnapshot(c *gin.Context) {
 	id := c.Param("ID")
 
@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/smartcontractkit/chainlink/store"
 	"github.com/smartcontractkit/chainlink/store/models"
 	"github.com/smartcontractkit/chainlink/utils"
 
@@ -77,8 +76,6 @@ func TestSnapshotsController_ShowSnapshot_V1_Format(t *testing.T) {
 
 	eth.Register("eth_blockNumber", utils.Uint64ToHex(sentAt))
 	eth.Register("eth_sendRawTransaction", hash)
-	eth.Register("eth_blockNumber", utils.Uint64ToHex(sentAt))
-	eth.Register("eth_getTransactionReceipt", store.TxReceipt{})
 
 	assert.Nil(t, app.Start())
 	defer cleanup()
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store"
+	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// totpQRCodeSize is the width and height in pixels of the QR code image.
+const totpQRCodeSize = 200
+
+// TOTPController manages two-factor authentication for the user.
+type TOTPController struct {
+	App services.Application
+}
+
+// Enable generates a new TOTP secret, which is only required at login once
+// a code from it has been sent to Verify.
+// Example:
+//  "<application>/v2/user/totp/enable"
+//
+// @Summary Generate a TOTP secret
+// @Tags user
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} presenters.TOTPKey
+// @Failure 409 {object} models.JSONAPIErrors
+// @Router /v2/user/totp/enable [post]
+func (tc *TOTPController) Enable(c *gin.Context) {
+	key, err := tc.App.GetStore().EnableTOTP(currentUser(c).Email)
+	if err == store.ErrTOTPAlreadyEnabled {
+		publicError(c, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		c.AbortWithError(500, err)
+		return
+	}
+	c.JSON(http.StatusOK, presenters.TOTPKey{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	})
+}
+
+// Verify turns on the secret from Enable, given a current code from it.
+// Example:
+//  "<application>/v2/user/totp/verify"
+//
+// @Summary Confirm a TOTP secret
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param code body models.TOTPRequest true "Current code"
+// @Success 200 {object} presenters.TOTPStatus
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Router /v2/user/totp/verify [post]
+func (tc *TOTPController) Verify(c *gin.Context) {
+	var tr models.TOTPRequest
+	if err := c.ShouldBindJSON(&tr); err != nil {
+		publicError(c, 400, err)
+	} else if err := tc.App.GetStore().VerifyTOTP(currentUser(c).Email, tr.Code); err != nil {
+		tc.error(c, err)
+	} else {
+		c.JSON(http.StatusOK, presenters.TOTPStatus{Enabled: true})
+	}
+}
+
+// Disable stops requiring a TOTP code at login, given a current code.
+// Example:
+//  "<application>/v2/user/totp/disable"
+//
+// @Summary Turn off two-factor authentication
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param code body models.TOTPRequest true "Current code"
+// @Success 200 {object} presenters.TOTPStatus
+// @Failure 400 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Router /v2/user/totp/disable [post]
+func (tc *TOTPController) Disable(c *gin.Context) {
+	var tr models.TOTPRequest
+	if err := c.ShouldBindJSON(&tr); err != nil {
+		publicError(c, 400, err)
+	} else if err := tc.App.GetStore().DisableTOTP(currentUser(c).Email, tr.Code); err != nil {
+		tc.error(c, err)
+	} else {
+		c.JSON(http.StatusOK, presenters.TOTPStatus{Enabled: false})
+	}
+}
+
+func (tc *TOTPController) error(c *gin.Context, err error) {
+	switch err {
+	case store.ErrInvalidTOTPCode:
+		publicError(c, 400, err)
+	case store.ErrTOTPNotPending, store.ErrTOTPNotEnabled:
+		publicError(c, http.StatusConflict, err)
+	default:
+		c.AbortWithError(500, err)
+	}
+}
@@ -0,0 +1,107 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func totpLogin(t *testing.T, url, code string) *http.Response {
+	body := fmt.Sprintf(`{"email":"%s","password":"%s","totpCode":"%s"}`, cltest.APIEmail, cltest.Password, code)
+	resp, err := http.Post(url+"/sessions", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestTOTPController_Lifecycle(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	clock := cltest.UseSettableClock(app.Store)
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetTime(now)
+	client := app.NewHTTPClient()
+	url := app.Config.ClientNodeURL
+
+	resp, done := client.Post("/v2/user/totp/enable", nil)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	var key presenters.TOTPKey
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&key))
+	assert.NotEmpty(t, key.Secret)
+	assert.True(t, strings.HasPrefix(key.URL, "otpauth://totp/"))
+	assert.True(t, strings.HasPrefix(key.QRCode, "data:image/png;base64,"))
+
+	resp, done = client.Post("/v2/user/totp/verify", bytes.NewBufferString(`{"code":"000000"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 400)
+
+	code, err := totp.GenerateCode(key.Secret, now)
+	require.NoError(t, err)
+	resp, done = client.Post("/v2/user/totp/verify", bytes.NewBufferString(`{"code":"`+code+`"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	resp, done = client.Post("/v2/user/totp/enable", nil)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 409)
+
+	clock.SetTime(now.Add(30 * time.Second))
+	assert.Equal(t, 401, totpLogin(t, url, "").StatusCode)
+	code, err = totp.GenerateCode(key.Secret, clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 200, totpLogin(t, url, code).StatusCode)
+
+	clock.SetTime(now.Add(60 * time.Second))
+	code, err = totp.GenerateCode(key.Secret, clock.Now())
+	require.NoError(t, err)
+	resp, done = client.Post("/v2/user/totp/disable", bytes.NewBufferString(`{"code":"`+code+`"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	resp, done = client.Post("/v2/user/totp/disable", bytes.NewBufferString(`{"code":"`+code+`"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 409)
+	assert.Equal(t, 200, totpLogin(t, url, "").StatusCode)
+}
+
+func TestTOTPController_LoginReplay(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	clock := cltest.UseSettableClock(app.Store)
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock.SetTime(now)
+	client := app.NewHTTPClient()
+	url := app.Config.ClientNodeURL
+
+	resp, done := client.Post("/v2/user/totp/enable", nil)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	var key presenters.TOTPKey
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&key))
+	code, err := totp.GenerateCode(key.Secret, now)
+	require.NoError(t, err)
+	resp, done = client.Post("/v2/user/totp/verify", bytes.NewBufferString(`{"code":"`+code+`"}`))
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+
+	assert.Equal(t, 401, totpLogin(t, url, code).StatusCode)
+
+	clock.SetTime(now.Add(30 * time.Second))
+	code, err = totp.GenerateCode(key.Secret, clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 200, totpLogin(t, url, code).StatusCode)
+	assert.Equal(t, 401, totpLogin(t, url, code).StatusCode)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/smartcontractkit/chainlink/services"
 	"github.com/smartcontractkit/chainlink/store/models"
+	"github.com/smartcontractkit/chainlink/store/orm"
 	"github.com/smartcontractkit/chainlink/store/presenters"
 	"github.com/smartcontractkit/chainlink/utils"
 )
@@ -30,9 +31,11 @@ func (c *UserController) getCurrentSessionID(ctx *gin.Context) (string, error) {
 	return sessionID, nil
 }
 
-func (c *UserController) clearNonCurrentSessions(sessionID string) error {
+// clearNonCurrentSessions logs the user with email out of every session but
+// the current one.
+func (c *UserController) clearNonCurrentSessions(email, sessionID string) error {
 	var sessions []models.Session
-	err := c.App.GetStore().Select(q.Not(q.Eq("ID", sessionID))).Find(&sessions)
+	err := c.App.GetStore().Select(q.Eq("Email", email), q.Not(q.Eq("ID", sessionID))).Find(&sessions)
 	if err != nil && err != storm.ErrNotFound {
 		return err
 	}
@@ -47,6 +50,19 @@ func (c *UserController) clearNonCurrentSessions(sessionID string) error {
 	return nil
 }
 
+func (c *UserController) newUser(request models.CreateUserRequest) (models.User, error) {
+	role, err := models.NewRole(request.Role)
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := utils.ValidatePassword(request.Password, c.App.GetStore().Config.PasswordPolicy()); err != nil {
+		return models.User{}, passwordPolicyErrors(err)
+	}
+	user, err := models.NewUser(request.Email, request.Password)
+	user.Role = role
+	return user, err
+}
+
 func (c *UserController) saveNewPassword(user *models.User, newPassword string) error {
 	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
@@ -59,7 +75,7 @@ func (c *UserController) saveNewPassword(user *models.User, newPassword string)
 func (c *UserController) updateUserPassword(ctx *gin.Context, user *models.User, newPassword string) error {
 	if sessionID, err := c.getCurrentSessionID(ctx); err != nil {
 		return err
-	} else if err := c.clearNonCurrentSessions(sessionID); err != nil {
+	} else if err := c.clearNonCurrentSessions(user.Email, sessionID); err != nil {
 		return fmt.Errorf("failed to clear non current user sessions: %+v", err)
 	} else if err := c.saveNewPassword(user, newPassword); err != nil {
 		return fmt.Errorf("failed to update current user password: %+v", err)
@@ -67,15 +83,30 @@ func (c *UserController) updateUserPassword(ctx *gin.Context, user *models.User,
 	return nil
 }
 
-// UpdatePassword changes the password for the current User.
+// UpdatePassword changes the password for the current User. The new password
+// must follow the node's PasswordPolicy, and every way it does not is listed
+// as a separate error.
+//
+// @Summary Change password
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param passwords body models.ChangePasswordRequest true "Old and new passwords"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.UserPresenter}}
+// @Failure 409 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/user/password [patch]
 func (c *UserController) UpdatePassword(ctx *gin.Context) {
 	var request models.ChangePasswordRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
 		publicError(ctx, http.StatusUnprocessableEntity, err)
-	} else if user, err := c.App.GetStore().FindUser(); err != nil {
+	} else if user, err := c.App.GetStore().FindUserByEmail(currentUser(ctx).Email); err != nil {
 		ctx.AbortWithError(http.StatusInternalServerError, fmt.Errorf("failed to obtain current user record: %+v", err))
 	} else if !utils.CheckPasswordHash(request.OldPassword, user.HashedPassword) {
 		publicError(ctx, http.StatusConflict, errors.New("Old password does not match"))
+	} else if err := utils.ValidatePassword(request.NewPassword, c.App.GetStore().Config.PasswordPolicy()); err != nil {
+		publicError(ctx, http.StatusUnprocessableEntity, passwordPolicyErrors(err))
 	} else if err := c.updateUserPassword(ctx, &user, request.NewPassword); err != nil {
 		ctx.AbortWithError(http.StatusInternalServerError, err)
 	} else if json, err := jsonapi.Marshal(presenters.UserPresenter{User: &user}); err != nil {
@@ -85,14 +116,91 @@ func (c *UserController) UpdatePassword(ctx *gin.Context) {
 	}
 }
 
+// Create adds a user who logs in with the given email and password, and has
+// the given role. The password must follow the node's PasswordPolicy.
+// Example:
+//  "<application>/v2/users"
+//
+// @Summary Create a user
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param user body models.CreateUserRequest true "Email, password and role"
+// @Success 201 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.UserPresenter}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/users [post]
+func (c *UserController) Create(ctx *gin.Context) {
+	var request models.CreateUserRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		publicError(ctx, http.StatusUnprocessableEntity, err)
+	} else if user, err := c.newUser(request); err != nil {
+		publicError(ctx, http.StatusUnprocessableEntity, err)
+	} else if err := c.App.GetStore().CreateUser(&user); err == orm.ErrorUserExists {
+		publicError(ctx, http.StatusConflict, err)
+	} else if err != nil {
+		ctx.AbortWithError(http.StatusInternalServerError, fmt.Errorf("failed to create user: %+v", err))
+	} else if json, err := jsonapi.Marshal(presenters.UserPresenter{User: &user}); err != nil {
+		ctx.AbortWithError(http.StatusInternalServerError, fmt.Errorf("failed to marshal user using jsonapi: %+v", err))
+	} else {
+		ctx.Data(http.StatusCreated, MediaType, json)
+	}
+}
+
+// UpdateRole gives the user with the given email a new role. The last admin
+// cannot be given another role, so that roles can always be managed.
+// Example:
+//  "<application>/v2/user/role"
+//
+// @Summary Change a user's role
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param role body models.ChangeRoleRequest true "User's email and new role"
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.UserPresenter}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Failure 404 {object} models.JSONAPIErrors
+// @Failure 409 {object} models.JSONAPIErrors
+// @Failure 422 {object} models.JSONAPIErrors
+// @Router /v2/user/role [patch]
+func (c *UserController) UpdateRole(ctx *gin.Context) {
+	var request models.ChangeRoleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		publicError(ctx, http.StatusUnprocessableEntity, err)
+	} else if role, err := models.NewRole(request.Role); err != nil {
+		publicError(ctx, http.StatusUnprocessableEntity, err)
+	} else if user, err := c.App.GetStore().SetUserRole(request.Email, role); err == storm.ErrNotFound {
+		publicError(ctx, http.StatusNotFound, errors.New("User not found"))
+	} else if err == orm.ErrorLastAdmin {
+		publicError(ctx, http.StatusConflict, err)
+	} else if err != nil {
+		ctx.AbortWithError(http.StatusInternalServerError, fmt.Errorf("failed to update user role: %+v", err))
+	} else if json, err := jsonapi.Marshal(presenters.UserPresenter{User: &user}); err != nil {
+		ctx.AbortWithError(http.StatusInternalServerError, fmt.Errorf("failed to marshal user using jsonapi: %+v", err))
+	} else {
+		ctx.Data(http.StatusOK, MediaType, json)
+	}
+}
+
 // AccountBalances returns the account balances of ETH & LINK.
 // Example:
 //  "<application>/user/balances"
+//
+// @Summary Show ETH and LINK balances
+// @Tags user
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.AccountBalance}}
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v2/user/balances [get]
 func (c *UserController) AccountBalances(ctx *gin.Context) {
 	store := c.App.GetStore()
 	txm := store.TxManager
 
-	if account, err := store.KeyStore.GetAccount(); err != nil {
+	if account, err := store.TxSigner.GetAccount(); err != nil {
 		publicError(ctx, 400, err)
 	} else if ethBalance, err := txm.GetEthBalance(account.Address); err != nil {
 		ctx.AbortWithError(500, err)
@@ -111,3 +219,17 @@ func (c *UserController) AccountBalances(ctx *gin.Context) {
 		}
 	}
 }
+
+// passwordPolicyErrors lists each of the ways a password breaks the policy
+// as its own error.
+func passwordPolicyErrors(err error) error {
+	policyErr, ok := err.(*utils.PasswordPolicyError)
+	if !ok {
+		return err
+	}
+	errs := models.NewJSONAPIErrors()
+	for _, violation := range policyErr.Violations {
+		errs.Add("Password " + violation)
+	}
+	return errs
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/smartcontractkit/chainlink/internal/cltest"
 	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/smartcontractkit/chainlink/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUserController_UpdatePassword(t *testing.T) {
@@ -80,3 +82,36 @@ func TestUserController_AccountBalances_Success(t *testing.T) {
 	assert.Equal(t, "0.000000000000000256", ab.EthBalance.String())
 	assert.Equal(t, "0.000000000000000256", ab.LinkBalance.String())
 }
+
+func TestUserController_UpdatePassword_Policy(t *testing.T) {
+	t.Parallel()
+	config, cfgCleanup := cltest.NewConfig()
+	defer cfgCleanup()
+	config.PasswordMinLength = 12
+	config.PasswordRequireUppercase = true
+	config.PasswordRequireDigit = true
+	app, cleanup := cltest.NewApplicationWithConfigAndKeyStore(config)
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Patch(
+		"/v2/user/password",
+		bytes.NewBufferString(`{"newPassword": "weak", "oldPassword": "password"}`))
+	defer cleanup()
+	assert.Equal(t, 422, resp.StatusCode)
+	errors := cltest.ParseJSONAPIErrors(resp.Body)
+	require.Len(t, errors.Errors, 3)
+	assert.Equal(t, "Password must be at least 12 characters", errors.Errors[0].Detail)
+	assert.Equal(t, "Password must contain an uppercase letter", errors.Errors[1].Detail)
+	assert.Equal(t, "Password must contain a digit", errors.Errors[2].Detail)
+
+	user, err := app.Store.FindUser()
+	require.NoError(t, err)
+	assert.True(t, utils.CheckPasswordHash(cltest.Password, user.HashedPassword), "password is unchanged")
+
+	resp, cleanup = client.Patch(
+		"/v2/user/password",
+		bytes.NewBufferString(`{"newPassword": "Much Stronger 42", "oldPassword": "password"}`))
+	defer cleanup()
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,67 @@
+package web
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/services"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+)
+
+// VRFKeysController manages the keys with which the node proves the
+// randomness it provides to VRFCoordinator consumers.
+type VRFKeysController struct {
+	App services.Application
+}
+
+// Index lists the VRF keys without their secrets.
+// Example:
+//  "<application>/v2/keys/vrf"
+//
+// @Summary List VRF keys
+// @Tags keys
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=[]JSONAPIResource{attributes=presenters.VRFKey}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Router /v2/keys/vrf [get]
+func (vkc *VRFKeysController) Index(c *gin.Context) {
+	keys, err := vkc.App.GetStore().VRFKeyStore.ListKeys()
+	if err != nil {
+		c.AbortWithError(500, fmt.Errorf("error fetching VRF keys: %+v", err))
+		return
+	}
+
+	pks := make([]presenters.VRFKey, len(keys))
+	for i, k := range keys {
+		pks[i] = presenters.NewVRFKey(k)
+	}
+	if doc, err := jsonapi.Marshal(pks); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
+
+// Create generates a VRF key, encrypted with the node's password. Consumers
+// request randomness from its hash.
+// Example:
+//  "<application>/v2/keys/vrf"
+//
+// @Summary Create a VRF key
+// @Tags keys
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} JSONAPIDocument{data=JSONAPIResource{attributes=presenters.VRFKey}}
+// @Failure 403 {object} models.JSONAPIErrors
+// @Router /v2/keys/vrf [post]
+func (vkc *VRFKeysController) Create(c *gin.Context) {
+	if key, err := vkc.App.GetStore().VRFKeyStore.CreateKey(); err != nil {
+		c.AbortWithError(500, err)
+	} else if doc, err := jsonapi.Marshal(presenters.NewVRFKey(key)); err != nil {
+		c.AbortWithError(500, err)
+	} else {
+		c.Data(200, MediaType, doc)
+	}
+}
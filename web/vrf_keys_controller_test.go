@@ -0,0 +1,60 @@
+package web_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/smartcontractkit/chainlink/internal/cltest"
+	"github.com/smartcontractkit/chainlink/store/presenters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRFKeysController_CreateAndIndex(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplicationWithKeyStore()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, done := client.Post("/v2/keys/vrf", nil)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var key presenters.VRFKey
+	require.NoError(t, jsonapi.Unmarshal(b, &key))
+	assert.NotEmpty(t, key.PublicKey)
+	assert.True(t, app.Store.VRFKeyStore.HasKey(common.HexToHash(key.Hash)))
+	assert.NotContains(t, string(b), "keyJSON")
+
+	resp, done = client.Get("/v2/keys/vrf")
+	defer done()
+	cltest.AssertServerResponse(t, resp, 200)
+	b, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var keys []presenters.VRFKey
+	require.NoError(t, jsonapi.Unmarshal(b, &keys))
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.PublicKey, keys[0].PublicKey)
+	assert.Equal(t, key.Hash, keys[0].Hash)
+	assert.NotContains(t, string(b), "keyJSON")
+
+	logs, _ := fetchAuditLogs(t, client, "?resource_type=vrf_key")
+	require.Len(t, logs, 1)
+	assert.Equal(t, "create", logs[0].Action)
+	assert.Equal(t, key.PublicKey, logs[0].ResourceID)
+	assert.Equal(t, key.Hash, logs[0].AfterJSON.Get("hash").String())
+}
+
+func TestVRFKeysController_Create_Locked(t *testing.T) {
+	t.Parallel()
+	app, cleanup := cltest.NewApplication()
+	defer cleanup()
+	client := app.NewHTTPClient()
+
+	resp, done := client.Post("/v2/keys/vrf", nil)
+	defer done()
+	cltest.AssertServerResponse(t, resp, 500)
+}
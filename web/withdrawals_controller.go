@@ -21,6 +21,16 @@ var naz = assets.NewLink(1)
 // Create sends LINK from the configured oracle contract to the given address
 // Example:
 //  "<application>/withdrawals"
+//
+// @Summary Withdraw LINK from the oracle contract
+// @Tags withdrawals
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param withdrawal body models.WithdrawalRequest true "Address and amount"
+// @Success 200 {string} string "Transaction hash"
+// @Failure 400 {object} models.JSONAPIErrors
+// @Router /v2/withdrawals [post]
 func (abc *WithdrawalsController) Create(c *gin.Context) {
 	store := abc.App.GetStore()
 	txm := store.TxManager
@@ -32,7 +42,7 @@ func (abc *WithdrawalsController) Create(c *gin.Context) {
 		publicError(c, 400, fmt.Errorf("Must withdraw at least %v LINK", naz.String()))
 	} else if wr.Address == utils.ZeroAddress { // address is unmarshalled to ZeroAddres if invalid
 		publicError(c, 400, errors.New("Invalid withdrawal address"))
-	} else if account, err := store.KeyStore.GetAccount(); err != nil {
+	} else if account, err := store.TxSigner.GetAccount(); err != nil {
 		c.AbortWithError(500, err)
 	} else if linkBalance, err := txm.GetLinkBalance(account.Address); err != nil {
 		c.AbortWithError(500, err)